@@ -683,6 +683,154 @@ func (x *EvaluateResponse) GetEffect() corev1.Policy_Spec_Rule_Effect {
 	return corev1.Policy_Spec_Rule_Effect(0)
 }
 
+type SimulatePolicyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RequestContext *corev1.RequestContext `protobuf:"bytes,1,opt,name=requestContext,proto3" json:"requestContext,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SimulatePolicyRequest) Reset() {
+	*x = SimulatePolicyRequest{}
+	mi := &file_coctovigilv1_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulatePolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulatePolicyRequest) ProtoMessage() {}
+
+func (x *SimulatePolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coctovigilv1_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulatePolicyRequest.ProtoReflect.Descriptor instead.
+func (*SimulatePolicyRequest) Descriptor() ([]byte, []int) {
+	return file_coctovigilv1_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SimulatePolicyRequest) GetRequestContext() *corev1.RequestContext {
+	if x != nil {
+		return x.RequestContext
+	}
+	return nil
+}
+
+type TestPolicyRequest struct {
+	state           protoimpl.MessageState   `protogen:"open.v1"`
+	Policies        []*corev1.Policy         `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	Namespaces      []*corev1.Namespace      `protobuf:"bytes,2,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	RequestContexts []*corev1.RequestContext `protobuf:"bytes,3,rep,name=requestContexts,proto3" json:"requestContexts,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TestPolicyRequest) Reset() {
+	*x = TestPolicyRequest{}
+	mi := &file_coctovigilv1_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestPolicyRequest) ProtoMessage() {}
+
+func (x *TestPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coctovigilv1_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestPolicyRequest.ProtoReflect.Descriptor instead.
+func (*TestPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_coctovigilv1_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TestPolicyRequest) GetPolicies() []*corev1.Policy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *TestPolicyRequest) GetNamespaces() []*corev1.Namespace {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *TestPolicyRequest) GetRequestContexts() []*corev1.RequestContext {
+	if x != nil {
+		return x.RequestContexts
+	}
+	return nil
+}
+
+type TestPolicyResponse struct {
+	state         protoimpl.MessageState           `protogen:"open.v1"`
+	Results       []*corev1.SimulatePolicyResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestPolicyResponse) Reset() {
+	*x = TestPolicyResponse{}
+	mi := &file_coctovigilv1_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestPolicyResponse) ProtoMessage() {}
+
+func (x *TestPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coctovigilv1_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestPolicyResponse.ProtoReflect.Descriptor instead.
+func (*TestPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_coctovigilv1_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TestPolicyResponse) GetResults() []*corev1.SimulatePolicyResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
 type DownstreamRequest_Source struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
@@ -693,7 +841,7 @@ type DownstreamRequest_Source struct {
 
 func (x *DownstreamRequest_Source) Reset() {
 	*x = DownstreamRequest_Source{}
-	mi := &file_coctovigilv1_proto_msgTypes[11]
+	mi := &file_coctovigilv1_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -705,7 +853,7 @@ func (x *DownstreamRequest_Source) String() string {
 func (*DownstreamRequest_Source) ProtoMessage() {}
 
 func (x *DownstreamRequest_Source) ProtoReflect() protoreflect.Message {
-	mi := &file_coctovigilv1_proto_msgTypes[11]
+	mi := &file_coctovigilv1_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -745,7 +893,7 @@ type EvaluateRequest_InlinePolicy struct {
 
 func (x *EvaluateRequest_InlinePolicy) Reset() {
 	*x = EvaluateRequest_InlinePolicy{}
-	mi := &file_coctovigilv1_proto_msgTypes[12]
+	mi := &file_coctovigilv1_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -757,7 +905,7 @@ func (x *EvaluateRequest_InlinePolicy) String() string {
 func (*EvaluateRequest_InlinePolicy) ProtoMessage() {}
 
 func (x *EvaluateRequest_InlinePolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_coctovigilv1_proto_msgTypes[12]
+	mi := &file_coctovigilv1_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -955,49 +1103,92 @@ var file_coctovigilv1_proto_rawDesc = []byte{
 	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76,
 	0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x2e, 0x52, 0x75,
 	0x6c, 0x65, 0x2e, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x52, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63,
-	0x74, 0x32, 0xdb, 0x04, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xa5, 0x01, 0x0a, 0x18, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x22, 0x6a, 0x0a, 0x15, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x51, 0x0a, 0x0e, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0e, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0xed, 0x01,
+	0x0a, 0x11, 0x54, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69,
+	0x65, 0x73, 0x12, 0x44, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75,
+	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x53, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x73, 0x22, 0x61, 0x0a,
+	0x12, 0x54, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x32, 0xd5, 0x06, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0xa5, 0x01, 0x0a, 0x18, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a,
+	0x65, 0x12, 0x42, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67,
+	0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x41, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x43, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74,
+	0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e,
 	0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
-	0x7a, 0x65, 0x12, 0x42, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69,
-	0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
-	0x61, 0x74, 0x65, 0x41, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x43, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75,
-	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63,
-	0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65,
-	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
-	0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x78, 0x0a,
-	0x09, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x12, 0x33, 0x2e, 0x6f, 0x63, 0x74,
-	0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
-	0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x34, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63,
-	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c,
-	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0xae, 0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x44,
-	0x6f, 0x77, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x55, 0x49, 0x44, 0x12, 0x45, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69,
-	0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f,
-	0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44,
-	0x6f, 0x77, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x55, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x46,
+	0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x78, 0x0a, 0x09,
+	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x12, 0x33, 0x2e, 0x6f, 0x63, 0x74, 0x65,
+	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34,
 	0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c,
 	0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e,
-	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x49, 0x44, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x75, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c,
-	0x75, 0x61, 0x74, 0x65, 0x12, 0x32, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f,
-	0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c,
-	0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e,
-	0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61,
-	0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
-	0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x63,
-	0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2f, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2f,
-	0x61, 0x70, 0x69, 0x73, 0x2f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x63, 0x6f, 0x63,
-	0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0xae, 0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x44, 0x6f,
+	0x77, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x55, 0x49, 0x44, 0x12, 0x45, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75,
+	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63,
+	0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x6f,
+	0x77, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x55, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x46, 0x2e,
+	0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46,
+	0x72, 0x6f, 0x6d, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x49, 0x44, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x75, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x75,
+	0x61, 0x74, 0x65, 0x12, 0x32, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76,
+	0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69,
+	0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f,
+	0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c,
+	0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x7d,
+	0x0a, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x38, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69,
+	0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6f, 0x63, 0x74,
+	0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x79, 0x0a,
+	0x0a, 0x54, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x34, 0x2e, 0x6f, 0x63,
+	0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x35, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67,
+	0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2f,
+	0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x63, 0x6f, 0x63, 0x74, 0x6f, 0x76, 0x69, 0x67, 0x69, 0x6c,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1012,7 +1203,7 @@ func file_coctovigilv1_proto_rawDescGZIP() []byte {
 	return file_coctovigilv1_proto_rawDescData
 }
 
-var file_coctovigilv1_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_coctovigilv1_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_coctovigilv1_proto_goTypes = []any{
 	(*Authorization)(nil),                        // 0: octelium.api.cluster.octovigil.v1.Authorization
 	(*AuthenticateAndAuthorizeRequest)(nil),      // 1: octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeRequest
@@ -1025,59 +1216,74 @@ var file_coctovigilv1_proto_goTypes = []any{
 	(*AuthorizeResponse)(nil),                    // 8: octelium.api.cluster.octovigil.v1.AuthorizeResponse
 	(*EvaluateRequest)(nil),                      // 9: octelium.api.cluster.octovigil.v1.EvaluateRequest
 	(*EvaluateResponse)(nil),                     // 10: octelium.api.cluster.octovigil.v1.EvaluateResponse
-	(*DownstreamRequest_Source)(nil),             // 11: octelium.api.cluster.octovigil.v1.DownstreamRequest.Source
-	(*EvaluateRequest_InlinePolicy)(nil),         // 12: octelium.api.cluster.octovigil.v1.EvaluateRequest.InlinePolicy
-	(*corev1.InlinePolicy)(nil),                  // 13: octelium.api.main.core.v1.InlinePolicy
-	(*corev1.RequestContext)(nil),                // 14: octelium.api.main.core.v1.RequestContext
-	(*corev1.AccessLog_Entry_Common_Reason)(nil), // 15: octelium.api.main.core.v1.AccessLog.Entry.Common.Reason
-	(*corev1.Service_Spec_Config)(nil),           // 16: octelium.api.main.core.v1.Service.Spec.Config
-	(*corev1.RequestContext_Request)(nil),        // 17: octelium.api.main.core.v1.RequestContext.Request
-	(*corev1.Service)(nil),                       // 18: octelium.api.main.core.v1.Service
-	(*corev1.Session)(nil),                       // 19: octelium.api.main.core.v1.Session
-	(*corev1.User)(nil),                          // 20: octelium.api.main.core.v1.User
-	(*corev1.Group)(nil),                         // 21: octelium.api.main.core.v1.Group
-	(*corev1.Device)(nil),                        // 22: octelium.api.main.core.v1.Device
-	(*structpb.Struct)(nil),                      // 23: google.protobuf.Struct
-	(corev1.Policy_Spec_Rule_Effect)(0),          // 24: octelium.api.main.core.v1.Policy.Spec.Rule.Effect
-	(*metav1.ObjectReference)(nil),               // 25: octelium.api.main.meta.v1.ObjectReference
+	(*SimulatePolicyRequest)(nil),                // 11: octelium.api.cluster.octovigil.v1.SimulatePolicyRequest
+	(*TestPolicyRequest)(nil),                    // 12: octelium.api.cluster.octovigil.v1.TestPolicyRequest
+	(*TestPolicyResponse)(nil),                   // 13: octelium.api.cluster.octovigil.v1.TestPolicyResponse
+	(*DownstreamRequest_Source)(nil),             // 14: octelium.api.cluster.octovigil.v1.DownstreamRequest.Source
+	(*EvaluateRequest_InlinePolicy)(nil),         // 15: octelium.api.cluster.octovigil.v1.EvaluateRequest.InlinePolicy
+	(*corev1.InlinePolicy)(nil),                  // 16: octelium.api.main.core.v1.InlinePolicy
+	(*corev1.RequestContext)(nil),                // 17: octelium.api.main.core.v1.RequestContext
+	(*corev1.AccessLog_Entry_Common_Reason)(nil), // 18: octelium.api.main.core.v1.AccessLog.Entry.Common.Reason
+	(*corev1.Service_Spec_Config)(nil),           // 19: octelium.api.main.core.v1.Service.Spec.Config
+	(*corev1.RequestContext_Request)(nil),        // 20: octelium.api.main.core.v1.RequestContext.Request
+	(*corev1.Service)(nil),                       // 21: octelium.api.main.core.v1.Service
+	(*corev1.Session)(nil),                       // 22: octelium.api.main.core.v1.Session
+	(*corev1.User)(nil),                          // 23: octelium.api.main.core.v1.User
+	(*corev1.Group)(nil),                         // 24: octelium.api.main.core.v1.Group
+	(*corev1.Device)(nil),                        // 25: octelium.api.main.core.v1.Device
+	(*structpb.Struct)(nil),                      // 26: google.protobuf.Struct
+	(corev1.Policy_Spec_Rule_Effect)(0),          // 27: octelium.api.main.core.v1.Policy.Spec.Rule.Effect
+	(*corev1.Policy)(nil),                        // 28: octelium.api.main.core.v1.Policy
+	(*corev1.Namespace)(nil),                     // 29: octelium.api.main.core.v1.Namespace
+	(*corev1.SimulatePolicyResponse)(nil),        // 30: octelium.api.main.core.v1.SimulatePolicyResponse
+	(*metav1.ObjectReference)(nil),               // 31: octelium.api.main.meta.v1.ObjectReference
 }
 var file_coctovigilv1_proto_depIdxs = []int32{
-	13, // 0: octelium.api.cluster.octovigil.v1.Authorization.inlinePolicies:type_name -> octelium.api.main.core.v1.InlinePolicy
+	16, // 0: octelium.api.cluster.octovigil.v1.Authorization.inlinePolicies:type_name -> octelium.api.main.core.v1.InlinePolicy
 	3,  // 1: octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeRequest.request:type_name -> octelium.api.cluster.octovigil.v1.DownstreamRequest
 	0,  // 2: octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeRequest.additional:type_name -> octelium.api.cluster.octovigil.v1.Authorization
-	14, // 3: octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeResponse.requestContext:type_name -> octelium.api.main.core.v1.RequestContext
-	15, // 4: octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeResponse.authorizationDecisionReason:type_name -> octelium.api.main.core.v1.AccessLog.Entry.Common.Reason
-	16, // 5: octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeResponse.config:type_name -> octelium.api.main.core.v1.Service.Spec.Config
-	11, // 6: octelium.api.cluster.octovigil.v1.DownstreamRequest.source:type_name -> octelium.api.cluster.octovigil.v1.DownstreamRequest.Source
-	17, // 7: octelium.api.cluster.octovigil.v1.DownstreamRequest.request:type_name -> octelium.api.main.core.v1.RequestContext.Request
-	18, // 8: octelium.api.cluster.octovigil.v1.DoAuthenticateAndAuthorizeRequest.service:type_name -> octelium.api.main.core.v1.Service
+	17, // 3: octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeResponse.requestContext:type_name -> octelium.api.main.core.v1.RequestContext
+	18, // 4: octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeResponse.authorizationDecisionReason:type_name -> octelium.api.main.core.v1.AccessLog.Entry.Common.Reason
+	19, // 5: octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeResponse.config:type_name -> octelium.api.main.core.v1.Service.Spec.Config
+	14, // 6: octelium.api.cluster.octovigil.v1.DownstreamRequest.source:type_name -> octelium.api.cluster.octovigil.v1.DownstreamRequest.Source
+	20, // 7: octelium.api.cluster.octovigil.v1.DownstreamRequest.request:type_name -> octelium.api.main.core.v1.RequestContext.Request
+	21, // 8: octelium.api.cluster.octovigil.v1.DoAuthenticateAndAuthorizeRequest.service:type_name -> octelium.api.main.core.v1.Service
 	3,  // 9: octelium.api.cluster.octovigil.v1.DoAuthenticateAndAuthorizeRequest.request:type_name -> octelium.api.cluster.octovigil.v1.DownstreamRequest
 	0,  // 10: octelium.api.cluster.octovigil.v1.DoAuthenticateAndAuthorizeRequest.additional:type_name -> octelium.api.cluster.octovigil.v1.Authorization
-	19, // 11: octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDResponse.session:type_name -> octelium.api.main.core.v1.Session
-	20, // 12: octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDResponse.user:type_name -> octelium.api.main.core.v1.User
-	21, // 13: octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDResponse.groups:type_name -> octelium.api.main.core.v1.Group
-	22, // 14: octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDResponse.device:type_name -> octelium.api.main.core.v1.Device
-	17, // 15: octelium.api.cluster.octovigil.v1.AuthorizeRequest.request:type_name -> octelium.api.main.core.v1.RequestContext.Request
+	22, // 11: octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDResponse.session:type_name -> octelium.api.main.core.v1.Session
+	23, // 12: octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDResponse.user:type_name -> octelium.api.main.core.v1.User
+	24, // 13: octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDResponse.groups:type_name -> octelium.api.main.core.v1.Group
+	25, // 14: octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDResponse.device:type_name -> octelium.api.main.core.v1.Device
+	20, // 15: octelium.api.cluster.octovigil.v1.AuthorizeRequest.request:type_name -> octelium.api.main.core.v1.RequestContext.Request
 	0,  // 16: octelium.api.cluster.octovigil.v1.AuthorizeRequest.additional:type_name -> octelium.api.cluster.octovigil.v1.Authorization
-	15, // 17: octelium.api.cluster.octovigil.v1.AuthorizeResponse.reason:type_name -> octelium.api.main.core.v1.AccessLog.Entry.Common.Reason
-	12, // 18: octelium.api.cluster.octovigil.v1.EvaluateRequest.inlinePolicies:type_name -> octelium.api.cluster.octovigil.v1.EvaluateRequest.InlinePolicy
-	23, // 19: octelium.api.cluster.octovigil.v1.EvaluateRequest.ctx:type_name -> google.protobuf.Struct
-	24, // 20: octelium.api.cluster.octovigil.v1.EvaluateResponse.effect:type_name -> octelium.api.main.core.v1.Policy.Spec.Rule.Effect
-	13, // 21: octelium.api.cluster.octovigil.v1.EvaluateRequest.InlinePolicy.policy:type_name -> octelium.api.main.core.v1.InlinePolicy
-	25, // 22: octelium.api.cluster.octovigil.v1.EvaluateRequest.InlinePolicy.resourceRef:type_name -> octelium.api.main.meta.v1.ObjectReference
-	1,  // 23: octelium.api.cluster.octovigil.v1.InternalService.AuthenticateAndAuthorize:input_type -> octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeRequest
-	7,  // 24: octelium.api.cluster.octovigil.v1.InternalService.Authorize:input_type -> octelium.api.cluster.octovigil.v1.AuthorizeRequest
-	5,  // 25: octelium.api.cluster.octovigil.v1.InternalService.GetDownstreamFromSessionUID:input_type -> octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDRequest
-	9,  // 26: octelium.api.cluster.octovigil.v1.InternalService.Evaluate:input_type -> octelium.api.cluster.octovigil.v1.EvaluateRequest
-	2,  // 27: octelium.api.cluster.octovigil.v1.InternalService.AuthenticateAndAuthorize:output_type -> octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeResponse
-	8,  // 28: octelium.api.cluster.octovigil.v1.InternalService.Authorize:output_type -> octelium.api.cluster.octovigil.v1.AuthorizeResponse
-	6,  // 29: octelium.api.cluster.octovigil.v1.InternalService.GetDownstreamFromSessionUID:output_type -> octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDResponse
-	10, // 30: octelium.api.cluster.octovigil.v1.InternalService.Evaluate:output_type -> octelium.api.cluster.octovigil.v1.EvaluateResponse
-	27, // [27:31] is the sub-list for method output_type
-	23, // [23:27] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	18, // 17: octelium.api.cluster.octovigil.v1.AuthorizeResponse.reason:type_name -> octelium.api.main.core.v1.AccessLog.Entry.Common.Reason
+	15, // 18: octelium.api.cluster.octovigil.v1.EvaluateRequest.inlinePolicies:type_name -> octelium.api.cluster.octovigil.v1.EvaluateRequest.InlinePolicy
+	26, // 19: octelium.api.cluster.octovigil.v1.EvaluateRequest.ctx:type_name -> google.protobuf.Struct
+	27, // 20: octelium.api.cluster.octovigil.v1.EvaluateResponse.effect:type_name -> octelium.api.main.core.v1.Policy.Spec.Rule.Effect
+	17, // 21: octelium.api.cluster.octovigil.v1.SimulatePolicyRequest.requestContext:type_name -> octelium.api.main.core.v1.RequestContext
+	28, // 22: octelium.api.cluster.octovigil.v1.TestPolicyRequest.policies:type_name -> octelium.api.main.core.v1.Policy
+	29, // 23: octelium.api.cluster.octovigil.v1.TestPolicyRequest.namespaces:type_name -> octelium.api.main.core.v1.Namespace
+	17, // 24: octelium.api.cluster.octovigil.v1.TestPolicyRequest.requestContexts:type_name -> octelium.api.main.core.v1.RequestContext
+	30, // 25: octelium.api.cluster.octovigil.v1.TestPolicyResponse.results:type_name -> octelium.api.main.core.v1.SimulatePolicyResponse
+	16, // 26: octelium.api.cluster.octovigil.v1.EvaluateRequest.InlinePolicy.policy:type_name -> octelium.api.main.core.v1.InlinePolicy
+	31, // 27: octelium.api.cluster.octovigil.v1.EvaluateRequest.InlinePolicy.resourceRef:type_name -> octelium.api.main.meta.v1.ObjectReference
+	1,  // 28: octelium.api.cluster.octovigil.v1.InternalService.AuthenticateAndAuthorize:input_type -> octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeRequest
+	7,  // 29: octelium.api.cluster.octovigil.v1.InternalService.Authorize:input_type -> octelium.api.cluster.octovigil.v1.AuthorizeRequest
+	5,  // 30: octelium.api.cluster.octovigil.v1.InternalService.GetDownstreamFromSessionUID:input_type -> octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDRequest
+	9,  // 31: octelium.api.cluster.octovigil.v1.InternalService.Evaluate:input_type -> octelium.api.cluster.octovigil.v1.EvaluateRequest
+	11, // 32: octelium.api.cluster.octovigil.v1.InternalService.SimulatePolicy:input_type -> octelium.api.cluster.octovigil.v1.SimulatePolicyRequest
+	12, // 33: octelium.api.cluster.octovigil.v1.InternalService.TestPolicy:input_type -> octelium.api.cluster.octovigil.v1.TestPolicyRequest
+	2,  // 34: octelium.api.cluster.octovigil.v1.InternalService.AuthenticateAndAuthorize:output_type -> octelium.api.cluster.octovigil.v1.AuthenticateAndAuthorizeResponse
+	8,  // 35: octelium.api.cluster.octovigil.v1.InternalService.Authorize:output_type -> octelium.api.cluster.octovigil.v1.AuthorizeResponse
+	6,  // 36: octelium.api.cluster.octovigil.v1.InternalService.GetDownstreamFromSessionUID:output_type -> octelium.api.cluster.octovigil.v1.GetDownstreamFromSessionUIDResponse
+	10, // 37: octelium.api.cluster.octovigil.v1.InternalService.Evaluate:output_type -> octelium.api.cluster.octovigil.v1.EvaluateResponse
+	30, // 38: octelium.api.cluster.octovigil.v1.InternalService.SimulatePolicy:output_type -> octelium.api.main.core.v1.SimulatePolicyResponse
+	13, // 39: octelium.api.cluster.octovigil.v1.InternalService.TestPolicy:output_type -> octelium.api.cluster.octovigil.v1.TestPolicyResponse
+	34, // [34:40] is the sub-list for method output_type
+	28, // [28:34] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_coctovigilv1_proto_init() }
@@ -1091,7 +1297,7 @@ func file_coctovigilv1_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_coctovigilv1_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,7 @@ package coctovigilv1
 
 import (
 	context "context"
+	corev1 "github.com/octelium/octelium/apis/main/corev1"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -37,6 +38,8 @@ const (
 	InternalService_Authorize_FullMethodName                   = "/octelium.api.cluster.octovigil.v1.InternalService/Authorize"
 	InternalService_GetDownstreamFromSessionUID_FullMethodName = "/octelium.api.cluster.octovigil.v1.InternalService/GetDownstreamFromSessionUID"
 	InternalService_Evaluate_FullMethodName                    = "/octelium.api.cluster.octovigil.v1.InternalService/Evaluate"
+	InternalService_SimulatePolicy_FullMethodName              = "/octelium.api.cluster.octovigil.v1.InternalService/SimulatePolicy"
+	InternalService_TestPolicy_FullMethodName                  = "/octelium.api.cluster.octovigil.v1.InternalService/TestPolicy"
 )
 
 // InternalServiceClient is the client API for InternalService service.
@@ -47,6 +50,8 @@ type InternalServiceClient interface {
 	Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*AuthorizeResponse, error)
 	GetDownstreamFromSessionUID(ctx context.Context, in *GetDownstreamFromSessionUIDRequest, opts ...grpc.CallOption) (*GetDownstreamFromSessionUIDResponse, error)
 	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	SimulatePolicy(ctx context.Context, in *SimulatePolicyRequest, opts ...grpc.CallOption) (*corev1.SimulatePolicyResponse, error)
+	TestPolicy(ctx context.Context, in *TestPolicyRequest, opts ...grpc.CallOption) (*TestPolicyResponse, error)
 }
 
 type internalServiceClient struct {
@@ -97,6 +102,26 @@ func (c *internalServiceClient) Evaluate(ctx context.Context, in *EvaluateReques
 	return out, nil
 }
 
+func (c *internalServiceClient) SimulatePolicy(ctx context.Context, in *SimulatePolicyRequest, opts ...grpc.CallOption) (*corev1.SimulatePolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(corev1.SimulatePolicyResponse)
+	err := c.cc.Invoke(ctx, InternalService_SimulatePolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalServiceClient) TestPolicy(ctx context.Context, in *TestPolicyRequest, opts ...grpc.CallOption) (*TestPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestPolicyResponse)
+	err := c.cc.Invoke(ctx, InternalService_TestPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InternalServiceServer is the server API for InternalService service.
 // All implementations must embed UnimplementedInternalServiceServer
 // for forward compatibility.
@@ -105,6 +130,8 @@ type InternalServiceServer interface {
 	Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error)
 	GetDownstreamFromSessionUID(context.Context, *GetDownstreamFromSessionUIDRequest) (*GetDownstreamFromSessionUIDResponse, error)
 	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	SimulatePolicy(context.Context, *SimulatePolicyRequest) (*corev1.SimulatePolicyResponse, error)
+	TestPolicy(context.Context, *TestPolicyRequest) (*TestPolicyResponse, error)
 	mustEmbedUnimplementedInternalServiceServer()
 }
 
@@ -127,6 +154,12 @@ func (UnimplementedInternalServiceServer) GetDownstreamFromSessionUID(context.Co
 func (UnimplementedInternalServiceServer) Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
 }
+func (UnimplementedInternalServiceServer) SimulatePolicy(context.Context, *SimulatePolicyRequest) (*corev1.SimulatePolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulatePolicy not implemented")
+}
+func (UnimplementedInternalServiceServer) TestPolicy(context.Context, *TestPolicyRequest) (*TestPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestPolicy not implemented")
+}
 func (UnimplementedInternalServiceServer) mustEmbedUnimplementedInternalServiceServer() {}
 func (UnimplementedInternalServiceServer) testEmbeddedByValue()                         {}
 
@@ -220,6 +253,42 @@ func _InternalService_Evaluate_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InternalService_SimulatePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulatePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServiceServer).SimulatePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InternalService_SimulatePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServiceServer).SimulatePolicy(ctx, req.(*SimulatePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InternalService_TestPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServiceServer).TestPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InternalService_TestPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServiceServer).TestPolicy(ctx, req.(*TestPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InternalService_ServiceDesc is the grpc.ServiceDesc for InternalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,6 +312,14 @@ var InternalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Evaluate",
 			Handler:    _InternalService_Evaluate_Handler,
 		},
+		{
+			MethodName: "SimulatePolicy",
+			Handler:    _InternalService_SimulatePolicy_Handler,
+		},
+		{
+			MethodName: "TestPolicy",
+			Handler:    _InternalService_TestPolicy_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "coctovigilv1.proto",
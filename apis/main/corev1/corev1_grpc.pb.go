@@ -39,6 +39,9 @@ const (
 	MainService_UpdatePolicy_FullMethodName            = "/octelium.api.main.core.v1.MainService/UpdatePolicy"
 	MainService_DeletePolicy_FullMethodName            = "/octelium.api.main.core.v1.MainService/DeletePolicy"
 	MainService_GetPolicy_FullMethodName               = "/octelium.api.main.core.v1.MainService/GetPolicy"
+	MainService_SimulatePolicy_FullMethodName          = "/octelium.api.main.core.v1.MainService/SimulatePolicy"
+	MainService_CheckPolicy_FullMethodName             = "/octelium.api.main.core.v1.MainService/CheckPolicy"
+	MainService_TestPolicy_FullMethodName              = "/octelium.api.main.core.v1.MainService/TestPolicy"
 	MainService_CreateUser_FullMethodName              = "/octelium.api.main.core.v1.MainService/CreateUser"
 	MainService_ListUser_FullMethodName                = "/octelium.api.main.core.v1.MainService/ListUser"
 	MainService_UpdateUser_FullMethodName              = "/octelium.api.main.core.v1.MainService/UpdateUser"
@@ -72,6 +75,7 @@ const (
 	MainService_DeleteDevice_FullMethodName            = "/octelium.api.main.core.v1.MainService/DeleteDevice"
 	MainService_GetDevice_FullMethodName               = "/octelium.api.main.core.v1.MainService/GetDevice"
 	MainService_UpdateDevice_FullMethodName            = "/octelium.api.main.core.v1.MainService/UpdateDevice"
+	MainService_SetDevicePosture_FullMethodName        = "/octelium.api.main.core.v1.MainService/SetDevicePosture"
 	MainService_CreateCredential_FullMethodName        = "/octelium.api.main.core.v1.MainService/CreateCredential"
 	MainService_UpdateCredential_FullMethodName        = "/octelium.api.main.core.v1.MainService/UpdateCredential"
 	MainService_DeleteCredential_FullMethodName        = "/octelium.api.main.core.v1.MainService/DeleteCredential"
@@ -98,6 +102,11 @@ const (
 	MainService_UpdateConfig_FullMethodName            = "/octelium.api.main.core.v1.MainService/UpdateConfig"
 	MainService_DeleteConfig_FullMethodName            = "/octelium.api.main.core.v1.MainService/DeleteConfig"
 	MainService_GetConfig_FullMethodName               = "/octelium.api.main.core.v1.MainService/GetConfig"
+	MainService_ListAccessRequest_FullMethodName       = "/octelium.api.main.core.v1.MainService/ListAccessRequest"
+	MainService_GetAccessRequest_FullMethodName        = "/octelium.api.main.core.v1.MainService/GetAccessRequest"
+	MainService_DeleteAccessRequest_FullMethodName     = "/octelium.api.main.core.v1.MainService/DeleteAccessRequest"
+	MainService_ApproveAccessRequest_FullMethodName    = "/octelium.api.main.core.v1.MainService/ApproveAccessRequest"
+	MainService_DenyAccessRequest_FullMethodName       = "/octelium.api.main.core.v1.MainService/DenyAccessRequest"
 )
 
 // MainServiceClient is the client API for MainService service.
@@ -119,6 +128,15 @@ type MainServiceClient interface {
 	DeletePolicy(ctx context.Context, in *metav1.DeleteOptions, opts ...grpc.CallOption) (*metav1.OperationResult, error)
 	// GetPolicy retrieves a specific Policy
 	GetPolicy(ctx context.Context, in *metav1.GetOptions, opts ...grpc.CallOption) (*Policy, error)
+	// SimulatePolicy computes the authorization decision of a synthetic request
+	// along with all the Policy rules and enforcement rules evaluated for it
+	SimulatePolicy(ctx context.Context, in *SimulatePolicyRequest, opts ...grpc.CallOption) (*SimulatePolicyResponse, error)
+	// CheckPolicy validates a Policy spec, including type-checking its
+	// Conditions against the request context, without storing anything
+	CheckPolicy(ctx context.Context, in *CheckPolicyRequest, opts ...grpc.CallOption) (*CheckPolicyResponse, error)
+	// TestPolicy evaluates a suite of test cases against a set of fixtures
+	// with the same authorization logic used for real requests
+	TestPolicy(ctx context.Context, in *TestPolicyRequest, opts ...grpc.CallOption) (*TestPolicyResponse, error)
 	// CreateUser creates a User
 	CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
 	// ListUser lists Users
@@ -185,6 +203,8 @@ type MainServiceClient interface {
 	GetDevice(ctx context.Context, in *metav1.GetOptions, opts ...grpc.CallOption) (*Device, error)
 	// UpdateDevice updates a Device
 	UpdateDevice(ctx context.Context, in *Device, opts ...grpc.CallOption) (*Device, error)
+	// SetDevicePosture sets the Posture of a Device from an external provider
+	SetDevicePosture(ctx context.Context, in *SetDevicePostureRequest, opts ...grpc.CallOption) (*Device, error)
 	// CreateCredential creates a Credential
 	CreateCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Credential, error)
 	// UpdateCredential updates a Credential
@@ -237,6 +257,16 @@ type MainServiceClient interface {
 	DeleteConfig(ctx context.Context, in *metav1.DeleteOptions, opts ...grpc.CallOption) (*metav1.OperationResult, error)
 	// GetConfig retrieves a specific Config
 	GetConfig(ctx context.Context, in *metav1.GetOptions, opts ...grpc.CallOption) (*Config, error)
+	// ListAccessRequest lists AccessRequests
+	ListAccessRequest(ctx context.Context, in *ListAccessRequestOptions, opts ...grpc.CallOption) (*AccessRequestList, error)
+	// GetAccessRequest retrieves an AccessRequest
+	GetAccessRequest(ctx context.Context, in *metav1.GetOptions, opts ...grpc.CallOption) (*AccessRequest, error)
+	// DeleteAccessRequest deletes an AccessRequest and revokes its access, if any
+	DeleteAccessRequest(ctx context.Context, in *metav1.DeleteOptions, opts ...grpc.CallOption) (*metav1.OperationResult, error)
+	// ApproveAccessRequest approves a PENDING AccessRequest
+	ApproveAccessRequest(ctx context.Context, in *ApproveAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error)
+	// DenyAccessRequest denies a PENDING AccessRequest
+	DenyAccessRequest(ctx context.Context, in *DenyAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error)
 }
 
 type mainServiceClient struct {
@@ -297,6 +327,36 @@ func (c *mainServiceClient) GetPolicy(ctx context.Context, in *metav1.GetOptions
 	return out, nil
 }
 
+func (c *mainServiceClient) SimulatePolicy(ctx context.Context, in *SimulatePolicyRequest, opts ...grpc.CallOption) (*SimulatePolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimulatePolicyResponse)
+	err := c.cc.Invoke(ctx, MainService_SimulatePolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mainServiceClient) CheckPolicy(ctx context.Context, in *CheckPolicyRequest, opts ...grpc.CallOption) (*CheckPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckPolicyResponse)
+	err := c.cc.Invoke(ctx, MainService_CheckPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mainServiceClient) TestPolicy(ctx context.Context, in *TestPolicyRequest, opts ...grpc.CallOption) (*TestPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestPolicyResponse)
+	err := c.cc.Invoke(ctx, MainService_TestPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mainServiceClient) CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(User)
@@ -627,6 +687,16 @@ func (c *mainServiceClient) UpdateDevice(ctx context.Context, in *Device, opts .
 	return out, nil
 }
 
+func (c *mainServiceClient) SetDevicePosture(ctx context.Context, in *SetDevicePostureRequest, opts ...grpc.CallOption) (*Device, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Device)
+	err := c.cc.Invoke(ctx, MainService_SetDevicePosture_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mainServiceClient) CreateCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Credential, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Credential)
@@ -887,6 +957,56 @@ func (c *mainServiceClient) GetConfig(ctx context.Context, in *metav1.GetOptions
 	return out, nil
 }
 
+func (c *mainServiceClient) ListAccessRequest(ctx context.Context, in *ListAccessRequestOptions, opts ...grpc.CallOption) (*AccessRequestList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessRequestList)
+	err := c.cc.Invoke(ctx, MainService_ListAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mainServiceClient) GetAccessRequest(ctx context.Context, in *metav1.GetOptions, opts ...grpc.CallOption) (*AccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, MainService_GetAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mainServiceClient) DeleteAccessRequest(ctx context.Context, in *metav1.DeleteOptions, opts ...grpc.CallOption) (*metav1.OperationResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(metav1.OperationResult)
+	err := c.cc.Invoke(ctx, MainService_DeleteAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mainServiceClient) ApproveAccessRequest(ctx context.Context, in *ApproveAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, MainService_ApproveAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mainServiceClient) DenyAccessRequest(ctx context.Context, in *DenyAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, MainService_DenyAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MainServiceServer is the server API for MainService service.
 // All implementations must embed UnimplementedMainServiceServer
 // for forward compatibility.
@@ -906,6 +1026,15 @@ type MainServiceServer interface {
 	DeletePolicy(context.Context, *metav1.DeleteOptions) (*metav1.OperationResult, error)
 	// GetPolicy retrieves a specific Policy
 	GetPolicy(context.Context, *metav1.GetOptions) (*Policy, error)
+	// SimulatePolicy computes the authorization decision of a synthetic request
+	// along with all the Policy rules and enforcement rules evaluated for it
+	SimulatePolicy(context.Context, *SimulatePolicyRequest) (*SimulatePolicyResponse, error)
+	// CheckPolicy validates a Policy spec, including type-checking its
+	// Conditions against the request context, without storing anything
+	CheckPolicy(context.Context, *CheckPolicyRequest) (*CheckPolicyResponse, error)
+	// TestPolicy evaluates a suite of test cases against a set of fixtures
+	// with the same authorization logic used for real requests
+	TestPolicy(context.Context, *TestPolicyRequest) (*TestPolicyResponse, error)
 	// CreateUser creates a User
 	CreateUser(context.Context, *User) (*User, error)
 	// ListUser lists Users
@@ -972,6 +1101,8 @@ type MainServiceServer interface {
 	GetDevice(context.Context, *metav1.GetOptions) (*Device, error)
 	// UpdateDevice updates a Device
 	UpdateDevice(context.Context, *Device) (*Device, error)
+	// SetDevicePosture sets the Posture of a Device from an external provider
+	SetDevicePosture(context.Context, *SetDevicePostureRequest) (*Device, error)
 	// CreateCredential creates a Credential
 	CreateCredential(context.Context, *Credential) (*Credential, error)
 	// UpdateCredential updates a Credential
@@ -1024,6 +1155,16 @@ type MainServiceServer interface {
 	DeleteConfig(context.Context, *metav1.DeleteOptions) (*metav1.OperationResult, error)
 	// GetConfig retrieves a specific Config
 	GetConfig(context.Context, *metav1.GetOptions) (*Config, error)
+	// ListAccessRequest lists AccessRequests
+	ListAccessRequest(context.Context, *ListAccessRequestOptions) (*AccessRequestList, error)
+	// GetAccessRequest retrieves an AccessRequest
+	GetAccessRequest(context.Context, *metav1.GetOptions) (*AccessRequest, error)
+	// DeleteAccessRequest deletes an AccessRequest and revokes its access, if any
+	DeleteAccessRequest(context.Context, *metav1.DeleteOptions) (*metav1.OperationResult, error)
+	// ApproveAccessRequest approves a PENDING AccessRequest
+	ApproveAccessRequest(context.Context, *ApproveAccessRequestRequest) (*AccessRequest, error)
+	// DenyAccessRequest denies a PENDING AccessRequest
+	DenyAccessRequest(context.Context, *DenyAccessRequestRequest) (*AccessRequest, error)
 	mustEmbedUnimplementedMainServiceServer()
 }
 
@@ -1049,6 +1190,15 @@ func (UnimplementedMainServiceServer) DeletePolicy(context.Context, *metav1.Dele
 func (UnimplementedMainServiceServer) GetPolicy(context.Context, *metav1.GetOptions) (*Policy, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPolicy not implemented")
 }
+func (UnimplementedMainServiceServer) SimulatePolicy(context.Context, *SimulatePolicyRequest) (*SimulatePolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulatePolicy not implemented")
+}
+func (UnimplementedMainServiceServer) CheckPolicy(context.Context, *CheckPolicyRequest) (*CheckPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPolicy not implemented")
+}
+func (UnimplementedMainServiceServer) TestPolicy(context.Context, *TestPolicyRequest) (*TestPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestPolicy not implemented")
+}
 func (UnimplementedMainServiceServer) CreateUser(context.Context, *User) (*User, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
 }
@@ -1148,6 +1298,9 @@ func (UnimplementedMainServiceServer) GetDevice(context.Context, *metav1.GetOpti
 func (UnimplementedMainServiceServer) UpdateDevice(context.Context, *Device) (*Device, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateDevice not implemented")
 }
+func (UnimplementedMainServiceServer) SetDevicePosture(context.Context, *SetDevicePostureRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDevicePosture not implemented")
+}
 func (UnimplementedMainServiceServer) CreateCredential(context.Context, *Credential) (*Credential, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateCredential not implemented")
 }
@@ -1226,6 +1379,21 @@ func (UnimplementedMainServiceServer) DeleteConfig(context.Context, *metav1.Dele
 func (UnimplementedMainServiceServer) GetConfig(context.Context, *metav1.GetOptions) (*Config, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
 }
+func (UnimplementedMainServiceServer) ListAccessRequest(context.Context, *ListAccessRequestOptions) (*AccessRequestList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessRequest not implemented")
+}
+func (UnimplementedMainServiceServer) GetAccessRequest(context.Context, *metav1.GetOptions) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccessRequest not implemented")
+}
+func (UnimplementedMainServiceServer) DeleteAccessRequest(context.Context, *metav1.DeleteOptions) (*metav1.OperationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccessRequest not implemented")
+}
+func (UnimplementedMainServiceServer) ApproveAccessRequest(context.Context, *ApproveAccessRequestRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveAccessRequest not implemented")
+}
+func (UnimplementedMainServiceServer) DenyAccessRequest(context.Context, *DenyAccessRequestRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyAccessRequest not implemented")
+}
 func (UnimplementedMainServiceServer) mustEmbedUnimplementedMainServiceServer() {}
 func (UnimplementedMainServiceServer) testEmbeddedByValue()                     {}
 
@@ -1337,6 +1505,60 @@ func _MainService_GetPolicy_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MainService_SimulatePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulatePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).SimulatePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_SimulatePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).SimulatePolicy(ctx, req.(*SimulatePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MainService_CheckPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).CheckPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_CheckPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).CheckPolicy(ctx, req.(*CheckPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MainService_TestPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).TestPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_TestPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).TestPolicy(ctx, req.(*TestPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MainService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(User)
 	if err := dec(in); err != nil {
@@ -1931,6 +2153,24 @@ func _MainService_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MainService_SetDevicePosture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDevicePostureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).SetDevicePosture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_SetDevicePosture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).SetDevicePosture(ctx, req.(*SetDevicePostureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MainService_CreateCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Credential)
 	if err := dec(in); err != nil {
@@ -2399,6 +2639,96 @@ func _MainService_GetConfig_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MainService_ListAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessRequestOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).ListAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_ListAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).ListAccessRequest(ctx, req.(*ListAccessRequestOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MainService_GetAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(metav1.GetOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).GetAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_GetAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).GetAccessRequest(ctx, req.(*metav1.GetOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MainService_DeleteAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(metav1.DeleteOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).DeleteAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_DeleteAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).DeleteAccessRequest(ctx, req.(*metav1.DeleteOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MainService_ApproveAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).ApproveAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_ApproveAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).ApproveAccessRequest(ctx, req.(*ApproveAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MainService_DenyAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).DenyAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_DenyAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).DenyAccessRequest(ctx, req.(*DenyAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MainService_ServiceDesc is the grpc.ServiceDesc for MainService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2426,6 +2756,18 @@ var MainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPolicy",
 			Handler:    _MainService_GetPolicy_Handler,
 		},
+		{
+			MethodName: "SimulatePolicy",
+			Handler:    _MainService_SimulatePolicy_Handler,
+		},
+		{
+			MethodName: "CheckPolicy",
+			Handler:    _MainService_CheckPolicy_Handler,
+		},
+		{
+			MethodName: "TestPolicy",
+			Handler:    _MainService_TestPolicy_Handler,
+		},
 		{
 			MethodName: "CreateUser",
 			Handler:    _MainService_CreateUser_Handler,
@@ -2558,6 +2900,10 @@ var MainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDevice",
 			Handler:    _MainService_UpdateDevice_Handler,
 		},
+		{
+			MethodName: "SetDevicePosture",
+			Handler:    _MainService_SetDevicePosture_Handler,
+		},
 		{
 			MethodName: "CreateCredential",
 			Handler:    _MainService_CreateCredential_Handler,
@@ -2662,6 +3008,26 @@ var MainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfig",
 			Handler:    _MainService_GetConfig_Handler,
 		},
+		{
+			MethodName: "ListAccessRequest",
+			Handler:    _MainService_ListAccessRequest_Handler,
+		},
+		{
+			MethodName: "GetAccessRequest",
+			Handler:    _MainService_GetAccessRequest_Handler,
+		},
+		{
+			MethodName: "DeleteAccessRequest",
+			Handler:    _MainService_DeleteAccessRequest_Handler,
+		},
+		{
+			MethodName: "ApproveAccessRequest",
+			Handler:    _MainService_ApproveAccessRequest_Handler,
+		},
+		{
+			MethodName: "DenyAccessRequest",
+			Handler:    _MainService_DenyAccessRequest_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "corev1.proto",
@@ -486,6 +486,61 @@ func (Service_Spec_Type) EnumDescriptor() ([]byte, []int) {
 	return file_userv1_proto_rawDescGZIP(), []int{11, 0, 0}
 }
 
+type AccessRequest_Status_State int32
+
+const (
+	AccessRequest_Status_STATE_UNKNOWN AccessRequest_Status_State = 0
+	AccessRequest_Status_PENDING       AccessRequest_Status_State = 1
+	AccessRequest_Status_APPROVED      AccessRequest_Status_State = 2
+	AccessRequest_Status_DENIED        AccessRequest_Status_State = 3
+	AccessRequest_Status_EXPIRED       AccessRequest_Status_State = 4
+)
+
+// Enum value maps for AccessRequest_Status_State.
+var (
+	AccessRequest_Status_State_name = map[int32]string{
+		0: "STATE_UNKNOWN",
+		1: "PENDING",
+		2: "APPROVED",
+		3: "DENIED",
+		4: "EXPIRED",
+	}
+	AccessRequest_Status_State_value = map[string]int32{
+		"STATE_UNKNOWN": 0,
+		"PENDING":       1,
+		"APPROVED":      2,
+		"DENIED":        3,
+		"EXPIRED":       4,
+	}
+)
+
+func (x AccessRequest_Status_State) Enum() *AccessRequest_Status_State {
+	p := new(AccessRequest_Status_State)
+	*p = x
+	return p
+}
+
+func (x AccessRequest_Status_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccessRequest_Status_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_userv1_proto_enumTypes[8].Descriptor()
+}
+
+func (AccessRequest_Status_State) Type() protoreflect.EnumType {
+	return &file_userv1_proto_enumTypes[8]
+}
+
+func (x AccessRequest_Status_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccessRequest_Status_State.Descriptor instead.
+func (AccessRequest_Status_State) EnumDescriptor() ([]byte, []int) {
+	return file_userv1_proto_rawDescGZIP(), []int{17, 1, 0}
+}
+
 type SetServiceConfigsResponse_L3Mode int32
 
 const (
@@ -519,11 +574,11 @@ func (x SetServiceConfigsResponse_L3Mode) String() string {
 }
 
 func (SetServiceConfigsResponse_L3Mode) Descriptor() protoreflect.EnumDescriptor {
-	return file_userv1_proto_enumTypes[8].Descriptor()
+	return file_userv1_proto_enumTypes[9].Descriptor()
 }
 
 func (SetServiceConfigsResponse_L3Mode) Type() protoreflect.EnumType {
-	return &file_userv1_proto_enumTypes[8]
+	return &file_userv1_proto_enumTypes[9]
 }
 
 func (x SetServiceConfigsResponse_L3Mode) Number() protoreflect.EnumNumber {
@@ -532,7 +587,7 @@ func (x SetServiceConfigsResponse_L3Mode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SetServiceConfigsResponse_L3Mode.Descriptor instead.
 func (SetServiceConfigsResponse_L3Mode) EnumDescriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{19, 0}
+	return file_userv1_proto_rawDescGZIP(), []int{24, 0}
 }
 
 type LogoutRequest struct {
@@ -1616,6 +1671,313 @@ func (x *NamespaceList) GetListResponseMeta() *metav1.ListResponseMeta {
 	return nil
 }
 
+type AccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiVersion    string                 `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Metadata      *metav1.Metadata       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Spec          *AccessRequest_Spec    `protobuf:"bytes,4,opt,name=spec,proto3" json:"spec,omitempty"`
+	Status        *AccessRequest_Status  `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessRequest) Reset() {
+	*x = AccessRequest{}
+	mi := &file_userv1_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessRequest) ProtoMessage() {}
+
+func (x *AccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userv1_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessRequest.ProtoReflect.Descriptor instead.
+func (*AccessRequest) Descriptor() ([]byte, []int) {
+	return file_userv1_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AccessRequest) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *AccessRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AccessRequest) GetMetadata() *metav1.Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *AccessRequest) GetSpec() *AccessRequest_Spec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *AccessRequest) GetStatus() *AccessRequest_Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type AccessRequestList struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ApiVersion string                 `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	Kind       string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Items      []*AccessRequest       `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	// ListResponseMeta is common information about the list.
+	ListResponseMeta *metav1.ListResponseMeta `protobuf:"bytes,4,opt,name=listResponseMeta,proto3" json:"listResponseMeta,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AccessRequestList) Reset() {
+	*x = AccessRequestList{}
+	mi := &file_userv1_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessRequestList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessRequestList) ProtoMessage() {}
+
+func (x *AccessRequestList) ProtoReflect() protoreflect.Message {
+	mi := &file_userv1_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessRequestList.ProtoReflect.Descriptor instead.
+func (*AccessRequestList) Descriptor() ([]byte, []int) {
+	return file_userv1_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AccessRequestList) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *AccessRequestList) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AccessRequestList) GetItems() []*AccessRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *AccessRequestList) GetListResponseMeta() *metav1.ListResponseMeta {
+	if x != nil {
+		return x.ListResponseMeta
+	}
+	return nil
+}
+
+type ListAccessRequestOptions struct {
+	state  protoimpl.MessageState    `protogen:"open.v1"`
+	Common *metav1.CommonListOptions `protobuf:"bytes,1,opt,name=common,proto3" json:"common,omitempty"`
+	// ToDecide lists the PENDING AccessRequests of the other Users that the
+	// User can approve or deny instead of the User's own AccessRequests
+	ToDecide      bool `protobuf:"varint,2,opt,name=toDecide,proto3" json:"toDecide,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessRequestOptions) Reset() {
+	*x = ListAccessRequestOptions{}
+	mi := &file_userv1_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessRequestOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessRequestOptions) ProtoMessage() {}
+
+func (x *ListAccessRequestOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_userv1_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessRequestOptions.ProtoReflect.Descriptor instead.
+func (*ListAccessRequestOptions) Descriptor() ([]byte, []int) {
+	return file_userv1_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListAccessRequestOptions) GetCommon() *metav1.CommonListOptions {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+func (x *ListAccessRequestOptions) GetToDecide() bool {
+	if x != nil {
+		return x.ToDecide
+	}
+	return false
+}
+
+type ApproveAccessRequestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name is the name of the AccessRequest
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Reason is an optional comment
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveAccessRequestRequest) Reset() {
+	*x = ApproveAccessRequestRequest{}
+	mi := &file_userv1_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveAccessRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveAccessRequestRequest) ProtoMessage() {}
+
+func (x *ApproveAccessRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userv1_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveAccessRequestRequest.ProtoReflect.Descriptor instead.
+func (*ApproveAccessRequestRequest) Descriptor() ([]byte, []int) {
+	return file_userv1_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ApproveAccessRequestRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApproveAccessRequestRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DenyAccessRequestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name is the name of the AccessRequest
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Reason is an optional comment
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyAccessRequestRequest) Reset() {
+	*x = DenyAccessRequestRequest{}
+	mi := &file_userv1_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyAccessRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyAccessRequestRequest) ProtoMessage() {}
+
+func (x *DenyAccessRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userv1_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyAccessRequestRequest.ProtoReflect.Descriptor instead.
+func (*DenyAccessRequestRequest) Descriptor() ([]byte, []int) {
+	return file_userv1_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DenyAccessRequestRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DenyAccessRequestRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type ConnectResponse struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
@@ -1638,7 +2000,7 @@ type ConnectResponse struct {
 
 func (x *ConnectResponse) Reset() {
 	*x = ConnectResponse{}
-	mi := &file_userv1_proto_msgTypes[17]
+	mi := &file_userv1_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1650,7 +2012,7 @@ func (x *ConnectResponse) String() string {
 func (*ConnectResponse) ProtoMessage() {}
 
 func (x *ConnectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[17]
+	mi := &file_userv1_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1663,7 +2025,7 @@ func (x *ConnectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse.ProtoReflect.Descriptor instead.
 func (*ConnectResponse) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{17}
+	return file_userv1_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ConnectResponse) GetCreatedAt() *timestamppb.Timestamp {
@@ -1854,7 +2216,7 @@ type SetServiceConfigsRequest struct {
 
 func (x *SetServiceConfigsRequest) Reset() {
 	*x = SetServiceConfigsRequest{}
-	mi := &file_userv1_proto_msgTypes[18]
+	mi := &file_userv1_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1866,7 +2228,7 @@ func (x *SetServiceConfigsRequest) String() string {
 func (*SetServiceConfigsRequest) ProtoMessage() {}
 
 func (x *SetServiceConfigsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[18]
+	mi := &file_userv1_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1879,7 +2241,7 @@ func (x *SetServiceConfigsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetServiceConfigsRequest.ProtoReflect.Descriptor instead.
 func (*SetServiceConfigsRequest) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{18}
+	return file_userv1_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *SetServiceConfigsRequest) GetName() string {
@@ -1903,7 +2265,7 @@ type SetServiceConfigsResponse struct {
 
 func (x *SetServiceConfigsResponse) Reset() {
 	*x = SetServiceConfigsResponse{}
-	mi := &file_userv1_proto_msgTypes[19]
+	mi := &file_userv1_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1915,7 +2277,7 @@ func (x *SetServiceConfigsResponse) String() string {
 func (*SetServiceConfigsResponse) ProtoMessage() {}
 
 func (x *SetServiceConfigsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[19]
+	mi := &file_userv1_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1928,7 +2290,7 @@ func (x *SetServiceConfigsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetServiceConfigsResponse.ProtoReflect.Descriptor instead.
 func (*SetServiceConfigsResponse) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{19}
+	return file_userv1_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *SetServiceConfigsResponse) GetConfigs() []*SetServiceConfigsResponse_Config {
@@ -1970,7 +2332,7 @@ type GetStatusResponse_User struct {
 
 func (x *GetStatusResponse_User) Reset() {
 	*x = GetStatusResponse_User{}
-	mi := &file_userv1_proto_msgTypes[20]
+	mi := &file_userv1_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1982,7 +2344,7 @@ func (x *GetStatusResponse_User) String() string {
 func (*GetStatusResponse_User) ProtoMessage() {}
 
 func (x *GetStatusResponse_User) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[20]
+	mi := &file_userv1_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2030,7 +2392,7 @@ type GetStatusResponse_Session struct {
 
 func (x *GetStatusResponse_Session) Reset() {
 	*x = GetStatusResponse_Session{}
-	mi := &file_userv1_proto_msgTypes[21]
+	mi := &file_userv1_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2042,7 +2404,7 @@ func (x *GetStatusResponse_Session) String() string {
 func (*GetStatusResponse_Session) ProtoMessage() {}
 
 func (x *GetStatusResponse_Session) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[21]
+	mi := &file_userv1_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2088,7 +2450,7 @@ type GetStatusResponse_Cluster struct {
 
 func (x *GetStatusResponse_Cluster) Reset() {
 	*x = GetStatusResponse_Cluster{}
-	mi := &file_userv1_proto_msgTypes[22]
+	mi := &file_userv1_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2100,7 +2462,7 @@ func (x *GetStatusResponse_Cluster) String() string {
 func (*GetStatusResponse_Cluster) ProtoMessage() {}
 
 func (x *GetStatusResponse_Cluster) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[22]
+	mi := &file_userv1_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2133,7 +2495,7 @@ type GetStatusResponse_User_Spec struct {
 
 func (x *GetStatusResponse_User_Spec) Reset() {
 	*x = GetStatusResponse_User_Spec{}
-	mi := &file_userv1_proto_msgTypes[23]
+	mi := &file_userv1_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2145,7 +2507,7 @@ func (x *GetStatusResponse_User_Spec) String() string {
 func (*GetStatusResponse_User_Spec) ProtoMessage() {}
 
 func (x *GetStatusResponse_User_Spec) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[23]
+	mi := &file_userv1_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2183,7 +2545,7 @@ type GetStatusResponse_User_Status struct {
 
 func (x *GetStatusResponse_User_Status) Reset() {
 	*x = GetStatusResponse_User_Status{}
-	mi := &file_userv1_proto_msgTypes[24]
+	mi := &file_userv1_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2195,7 +2557,7 @@ func (x *GetStatusResponse_User_Status) String() string {
 func (*GetStatusResponse_User_Status) ProtoMessage() {}
 
 func (x *GetStatusResponse_User_Status) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[24]
+	mi := &file_userv1_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2219,7 +2581,7 @@ type GetStatusResponse_Session_Spec struct {
 
 func (x *GetStatusResponse_Session_Spec) Reset() {
 	*x = GetStatusResponse_Session_Spec{}
-	mi := &file_userv1_proto_msgTypes[25]
+	mi := &file_userv1_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2231,7 +2593,7 @@ func (x *GetStatusResponse_Session_Spec) String() string {
 func (*GetStatusResponse_Session_Spec) ProtoMessage() {}
 
 func (x *GetStatusResponse_Session_Spec) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[25]
+	mi := &file_userv1_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2257,7 +2619,7 @@ type GetStatusResponse_Session_Status struct {
 
 func (x *GetStatusResponse_Session_Status) Reset() {
 	*x = GetStatusResponse_Session_Status{}
-	mi := &file_userv1_proto_msgTypes[26]
+	mi := &file_userv1_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2269,7 +2631,7 @@ func (x *GetStatusResponse_Session_Status) String() string {
 func (*GetStatusResponse_Session_Status) ProtoMessage() {}
 
 func (x *GetStatusResponse_Session_Status) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[26]
+	mi := &file_userv1_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2323,7 +2685,7 @@ type ConnectRequest_Initialize struct {
 
 func (x *ConnectRequest_Initialize) Reset() {
 	*x = ConnectRequest_Initialize{}
-	mi := &file_userv1_proto_msgTypes[27]
+	mi := &file_userv1_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2335,7 +2697,7 @@ func (x *ConnectRequest_Initialize) String() string {
 func (*ConnectRequest_Initialize) ProtoMessage() {}
 
 func (x *ConnectRequest_Initialize) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[27]
+	mi := &file_userv1_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2423,7 +2785,7 @@ type ConnectRequest_KeepAlive struct {
 
 func (x *ConnectRequest_KeepAlive) Reset() {
 	*x = ConnectRequest_KeepAlive{}
-	mi := &file_userv1_proto_msgTypes[28]
+	mi := &file_userv1_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2435,7 +2797,7 @@ func (x *ConnectRequest_KeepAlive) String() string {
 func (*ConnectRequest_KeepAlive) ProtoMessage() {}
 
 func (x *ConnectRequest_KeepAlive) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[28]
+	mi := &file_userv1_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2474,7 +2836,7 @@ type ConnectRequest_Initialize_ServiceOptions struct {
 
 func (x *ConnectRequest_Initialize_ServiceOptions) Reset() {
 	*x = ConnectRequest_Initialize_ServiceOptions{}
-	mi := &file_userv1_proto_msgTypes[29]
+	mi := &file_userv1_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2486,7 +2848,7 @@ func (x *ConnectRequest_Initialize_ServiceOptions) String() string {
 func (*ConnectRequest_Initialize_ServiceOptions) ProtoMessage() {}
 
 func (x *ConnectRequest_Initialize_ServiceOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[29]
+	mi := &file_userv1_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2544,7 +2906,7 @@ type ConnectRequest_Initialize_PublishedService struct {
 
 func (x *ConnectRequest_Initialize_PublishedService) Reset() {
 	*x = ConnectRequest_Initialize_PublishedService{}
-	mi := &file_userv1_proto_msgTypes[30]
+	mi := &file_userv1_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2556,7 +2918,7 @@ func (x *ConnectRequest_Initialize_PublishedService) String() string {
 func (*ConnectRequest_Initialize_PublishedService) ProtoMessage() {}
 
 func (x *ConnectRequest_Initialize_PublishedService) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[30]
+	mi := &file_userv1_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2602,7 +2964,7 @@ type ConnectRequest_Initialize_ServiceOptions_Service struct {
 
 func (x *ConnectRequest_Initialize_ServiceOptions_Service) Reset() {
 	*x = ConnectRequest_Initialize_ServiceOptions_Service{}
-	mi := &file_userv1_proto_msgTypes[31]
+	mi := &file_userv1_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2614,7 +2976,7 @@ func (x *ConnectRequest_Initialize_ServiceOptions_Service) String() string {
 func (*ConnectRequest_Initialize_ServiceOptions_Service) ProtoMessage() {}
 
 func (x *ConnectRequest_Initialize_ServiceOptions_Service) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[31]
+	mi := &file_userv1_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2653,7 +3015,7 @@ type Gateway_WireGuard struct {
 
 func (x *Gateway_WireGuard) Reset() {
 	*x = Gateway_WireGuard{}
-	mi := &file_userv1_proto_msgTypes[32]
+	mi := &file_userv1_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2665,7 +3027,7 @@ func (x *Gateway_WireGuard) String() string {
 func (*Gateway_WireGuard) ProtoMessage() {}
 
 func (x *Gateway_WireGuard) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[32]
+	mi := &file_userv1_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2713,7 +3075,7 @@ type Gateway_QUICV0 struct {
 
 func (x *Gateway_QUICV0) Reset() {
 	*x = Gateway_QUICV0{}
-	mi := &file_userv1_proto_msgTypes[33]
+	mi := &file_userv1_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2725,7 +3087,7 @@ func (x *Gateway_QUICV0) String() string {
 func (*Gateway_QUICV0) ProtoMessage() {}
 
 func (x *Gateway_QUICV0) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[33]
+	mi := &file_userv1_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2767,7 +3129,7 @@ type HostedService_Upstream struct {
 
 func (x *HostedService_Upstream) Reset() {
 	*x = HostedService_Upstream{}
-	mi := &file_userv1_proto_msgTypes[34]
+	mi := &file_userv1_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2779,7 +3141,7 @@ func (x *HostedService_Upstream) String() string {
 func (*HostedService_Upstream) ProtoMessage() {}
 
 func (x *HostedService_Upstream) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[34]
+	mi := &file_userv1_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2819,7 +3181,7 @@ type ConnectionState_ServiceOptions struct {
 
 func (x *ConnectionState_ServiceOptions) Reset() {
 	*x = ConnectionState_ServiceOptions{}
-	mi := &file_userv1_proto_msgTypes[35]
+	mi := &file_userv1_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2831,7 +3193,7 @@ func (x *ConnectionState_ServiceOptions) String() string {
 func (*ConnectionState_ServiceOptions) ProtoMessage() {}
 
 func (x *ConnectionState_ServiceOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[35]
+	mi := &file_userv1_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2866,7 +3228,7 @@ type ConnectionState_ServiceConfig struct {
 
 func (x *ConnectionState_ServiceConfig) Reset() {
 	*x = ConnectionState_ServiceConfig{}
-	mi := &file_userv1_proto_msgTypes[36]
+	mi := &file_userv1_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2878,7 +3240,7 @@ func (x *ConnectionState_ServiceConfig) String() string {
 func (*ConnectionState_ServiceConfig) ProtoMessage() {}
 
 func (x *ConnectionState_ServiceConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[36]
+	mi := &file_userv1_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2933,7 +3295,7 @@ type ConnectionState_ServiceConfig_SSH struct {
 
 func (x *ConnectionState_ServiceConfig_SSH) Reset() {
 	*x = ConnectionState_ServiceConfig_SSH{}
-	mi := &file_userv1_proto_msgTypes[37]
+	mi := &file_userv1_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2945,7 +3307,7 @@ func (x *ConnectionState_ServiceConfig_SSH) String() string {
 func (*ConnectionState_ServiceConfig_SSH) ProtoMessage() {}
 
 func (x *ConnectionState_ServiceConfig_SSH) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[37]
+	mi := &file_userv1_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2992,7 +3354,7 @@ type Service_Spec struct {
 
 func (x *Service_Spec) Reset() {
 	*x = Service_Spec{}
-	mi := &file_userv1_proto_msgTypes[38]
+	mi := &file_userv1_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3004,7 +3366,7 @@ func (x *Service_Spec) String() string {
 func (*Service_Spec) ProtoMessage() {}
 
 func (x *Service_Spec) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[38]
+	mi := &file_userv1_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3062,7 +3424,7 @@ type Service_Status struct {
 
 func (x *Service_Status) Reset() {
 	*x = Service_Status{}
-	mi := &file_userv1_proto_msgTypes[39]
+	mi := &file_userv1_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3074,7 +3436,7 @@ func (x *Service_Status) String() string {
 func (*Service_Status) ProtoMessage() {}
 
 func (x *Service_Status) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[39]
+	mi := &file_userv1_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3119,7 +3481,7 @@ type Namespace_Spec struct {
 
 func (x *Namespace_Spec) Reset() {
 	*x = Namespace_Spec{}
-	mi := &file_userv1_proto_msgTypes[40]
+	mi := &file_userv1_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3131,7 +3493,7 @@ func (x *Namespace_Spec) String() string {
 func (*Namespace_Spec) ProtoMessage() {}
 
 func (x *Namespace_Spec) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[40]
+	mi := &file_userv1_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3155,7 +3517,7 @@ type Namespace_Status struct {
 
 func (x *Namespace_Status) Reset() {
 	*x = Namespace_Status{}
-	mi := &file_userv1_proto_msgTypes[41]
+	mi := &file_userv1_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3167,7 +3529,7 @@ func (x *Namespace_Status) String() string {
 func (*Namespace_Status) ProtoMessage() {}
 
 func (x *Namespace_Status) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[41]
+	mi := &file_userv1_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3183,6 +3545,159 @@ func (*Namespace_Status) Descriptor() ([]byte, []int) {
 	return file_userv1_proto_rawDescGZIP(), []int{15, 1}
 }
 
+type AccessRequest_Spec struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service is the name of the requested Service
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Namespace is the name of the requested Namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Duration is the requested duration of the access
+	Duration *metav1.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Justification is the reason of the request
+	Justification string `protobuf:"bytes,4,opt,name=justification,proto3" json:"justification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessRequest_Spec) Reset() {
+	*x = AccessRequest_Spec{}
+	mi := &file_userv1_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessRequest_Spec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessRequest_Spec) ProtoMessage() {}
+
+func (x *AccessRequest_Spec) ProtoReflect() protoreflect.Message {
+	mi := &file_userv1_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessRequest_Spec.ProtoReflect.Descriptor instead.
+func (*AccessRequest_Spec) Descriptor() ([]byte, []int) {
+	return file_userv1_proto_rawDescGZIP(), []int{17, 0}
+}
+
+func (x *AccessRequest_Spec) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *AccessRequest_Spec) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AccessRequest_Spec) GetDuration() *metav1.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *AccessRequest_Spec) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+type AccessRequest_Status struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State is the current state of the AccessRequest
+	State AccessRequest_Status_State `protobuf:"varint,1,opt,name=state,proto3,enum=octelium.api.main.user.v1.AccessRequest_Status_State" json:"state,omitempty"`
+	// User is the name of the requesting User
+	User string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// Approver is the name of the User that decided the AccessRequest, if any
+	Approver string `protobuf:"bytes,3,opt,name=approver,proto3" json:"approver,omitempty"`
+	// Reason is the comment of the approver, if any
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// ExpiresAt is the time after which the AccessRequest expires
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessRequest_Status) Reset() {
+	*x = AccessRequest_Status{}
+	mi := &file_userv1_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessRequest_Status) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessRequest_Status) ProtoMessage() {}
+
+func (x *AccessRequest_Status) ProtoReflect() protoreflect.Message {
+	mi := &file_userv1_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessRequest_Status.ProtoReflect.Descriptor instead.
+func (*AccessRequest_Status) Descriptor() ([]byte, []int) {
+	return file_userv1_proto_rawDescGZIP(), []int{17, 1}
+}
+
+func (x *AccessRequest_Status) GetState() AccessRequest_Status_State {
+	if x != nil {
+		return x.State
+	}
+	return AccessRequest_Status_STATE_UNKNOWN
+}
+
+func (x *AccessRequest_Status) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *AccessRequest_Status) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *AccessRequest_Status) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AccessRequest_Status) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
 type ConnectResponse_AddGateway struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Gateway is the added Gateway.
@@ -3193,7 +3708,7 @@ type ConnectResponse_AddGateway struct {
 
 func (x *ConnectResponse_AddGateway) Reset() {
 	*x = ConnectResponse_AddGateway{}
-	mi := &file_userv1_proto_msgTypes[42]
+	mi := &file_userv1_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3205,7 +3720,7 @@ func (x *ConnectResponse_AddGateway) String() string {
 func (*ConnectResponse_AddGateway) ProtoMessage() {}
 
 func (x *ConnectResponse_AddGateway) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[42]
+	mi := &file_userv1_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3218,7 +3733,7 @@ func (x *ConnectResponse_AddGateway) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse_AddGateway.ProtoReflect.Descriptor instead.
 func (*ConnectResponse_AddGateway) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{17, 0}
+	return file_userv1_proto_rawDescGZIP(), []int{22, 0}
 }
 
 func (x *ConnectResponse_AddGateway) GetGateway() *Gateway {
@@ -3238,7 +3753,7 @@ type ConnectResponse_UpdateGateway struct {
 
 func (x *ConnectResponse_UpdateGateway) Reset() {
 	*x = ConnectResponse_UpdateGateway{}
-	mi := &file_userv1_proto_msgTypes[43]
+	mi := &file_userv1_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3250,7 +3765,7 @@ func (x *ConnectResponse_UpdateGateway) String() string {
 func (*ConnectResponse_UpdateGateway) ProtoMessage() {}
 
 func (x *ConnectResponse_UpdateGateway) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[43]
+	mi := &file_userv1_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3263,7 +3778,7 @@ func (x *ConnectResponse_UpdateGateway) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse_UpdateGateway.ProtoReflect.Descriptor instead.
 func (*ConnectResponse_UpdateGateway) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{17, 1}
+	return file_userv1_proto_rawDescGZIP(), []int{22, 1}
 }
 
 func (x *ConnectResponse_UpdateGateway) GetGateway() *Gateway {
@@ -3283,7 +3798,7 @@ type ConnectResponse_DeleteGateway struct {
 
 func (x *ConnectResponse_DeleteGateway) Reset() {
 	*x = ConnectResponse_DeleteGateway{}
-	mi := &file_userv1_proto_msgTypes[44]
+	mi := &file_userv1_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3295,7 +3810,7 @@ func (x *ConnectResponse_DeleteGateway) String() string {
 func (*ConnectResponse_DeleteGateway) ProtoMessage() {}
 
 func (x *ConnectResponse_DeleteGateway) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[44]
+	mi := &file_userv1_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3308,7 +3823,7 @@ func (x *ConnectResponse_DeleteGateway) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse_DeleteGateway.ProtoReflect.Descriptor instead.
 func (*ConnectResponse_DeleteGateway) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{17, 2}
+	return file_userv1_proto_rawDescGZIP(), []int{22, 2}
 }
 
 func (x *ConnectResponse_DeleteGateway) GetId() string {
@@ -3329,7 +3844,7 @@ type ConnectResponse_Disconnect struct {
 
 func (x *ConnectResponse_Disconnect) Reset() {
 	*x = ConnectResponse_Disconnect{}
-	mi := &file_userv1_proto_msgTypes[45]
+	mi := &file_userv1_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3341,7 +3856,7 @@ func (x *ConnectResponse_Disconnect) String() string {
 func (*ConnectResponse_Disconnect) ProtoMessage() {}
 
 func (x *ConnectResponse_Disconnect) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[45]
+	mi := &file_userv1_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3354,7 +3869,7 @@ func (x *ConnectResponse_Disconnect) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse_Disconnect.ProtoReflect.Descriptor instead.
 func (*ConnectResponse_Disconnect) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{17, 3}
+	return file_userv1_proto_rawDescGZIP(), []int{22, 3}
 }
 
 func (x *ConnectResponse_Disconnect) GetMessage() string {
@@ -3374,7 +3889,7 @@ type ConnectResponse_Message struct {
 
 func (x *ConnectResponse_Message) Reset() {
 	*x = ConnectResponse_Message{}
-	mi := &file_userv1_proto_msgTypes[46]
+	mi := &file_userv1_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3386,7 +3901,7 @@ func (x *ConnectResponse_Message) String() string {
 func (*ConnectResponse_Message) ProtoMessage() {}
 
 func (x *ConnectResponse_Message) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[46]
+	mi := &file_userv1_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3399,7 +3914,7 @@ func (x *ConnectResponse_Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse_Message.ProtoReflect.Descriptor instead.
 func (*ConnectResponse_Message) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{17, 4}
+	return file_userv1_proto_rawDescGZIP(), []int{22, 4}
 }
 
 func (x *ConnectResponse_Message) GetMessage() string {
@@ -3419,7 +3934,7 @@ type ConnectResponse_UpdateDNS struct {
 
 func (x *ConnectResponse_UpdateDNS) Reset() {
 	*x = ConnectResponse_UpdateDNS{}
-	mi := &file_userv1_proto_msgTypes[47]
+	mi := &file_userv1_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3431,7 +3946,7 @@ func (x *ConnectResponse_UpdateDNS) String() string {
 func (*ConnectResponse_UpdateDNS) ProtoMessage() {}
 
 func (x *ConnectResponse_UpdateDNS) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[47]
+	mi := &file_userv1_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3444,7 +3959,7 @@ func (x *ConnectResponse_UpdateDNS) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse_UpdateDNS.ProtoReflect.Descriptor instead.
 func (*ConnectResponse_UpdateDNS) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{17, 5}
+	return file_userv1_proto_rawDescGZIP(), []int{22, 5}
 }
 
 func (x *ConnectResponse_UpdateDNS) GetDns() *DNS {
@@ -3464,7 +3979,7 @@ type ConnectResponse_AddService struct {
 
 func (x *ConnectResponse_AddService) Reset() {
 	*x = ConnectResponse_AddService{}
-	mi := &file_userv1_proto_msgTypes[48]
+	mi := &file_userv1_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3476,7 +3991,7 @@ func (x *ConnectResponse_AddService) String() string {
 func (*ConnectResponse_AddService) ProtoMessage() {}
 
 func (x *ConnectResponse_AddService) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[48]
+	mi := &file_userv1_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3489,7 +4004,7 @@ func (x *ConnectResponse_AddService) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse_AddService.ProtoReflect.Descriptor instead.
 func (*ConnectResponse_AddService) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{17, 6}
+	return file_userv1_proto_rawDescGZIP(), []int{22, 6}
 }
 
 func (x *ConnectResponse_AddService) GetService() *HostedService {
@@ -3509,7 +4024,7 @@ type ConnectResponse_UpdateService struct {
 
 func (x *ConnectResponse_UpdateService) Reset() {
 	*x = ConnectResponse_UpdateService{}
-	mi := &file_userv1_proto_msgTypes[49]
+	mi := &file_userv1_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3521,7 +4036,7 @@ func (x *ConnectResponse_UpdateService) String() string {
 func (*ConnectResponse_UpdateService) ProtoMessage() {}
 
 func (x *ConnectResponse_UpdateService) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[49]
+	mi := &file_userv1_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3534,7 +4049,7 @@ func (x *ConnectResponse_UpdateService) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse_UpdateService.ProtoReflect.Descriptor instead.
 func (*ConnectResponse_UpdateService) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{17, 7}
+	return file_userv1_proto_rawDescGZIP(), []int{22, 7}
 }
 
 func (x *ConnectResponse_UpdateService) GetService() *HostedService {
@@ -3554,7 +4069,7 @@ type ConnectResponse_DeleteService struct {
 
 func (x *ConnectResponse_DeleteService) Reset() {
 	*x = ConnectResponse_DeleteService{}
-	mi := &file_userv1_proto_msgTypes[50]
+	mi := &file_userv1_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3566,7 +4081,7 @@ func (x *ConnectResponse_DeleteService) String() string {
 func (*ConnectResponse_DeleteService) ProtoMessage() {}
 
 func (x *ConnectResponse_DeleteService) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[50]
+	mi := &file_userv1_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3579,7 +4094,7 @@ func (x *ConnectResponse_DeleteService) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse_DeleteService.ProtoReflect.Descriptor instead.
 func (*ConnectResponse_DeleteService) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{17, 8}
+	return file_userv1_proto_rawDescGZIP(), []int{22, 8}
 }
 
 func (x *ConnectResponse_DeleteService) GetName() string {
@@ -3602,7 +4117,7 @@ type SetServiceConfigsResponse_Config struct {
 
 func (x *SetServiceConfigsResponse_Config) Reset() {
 	*x = SetServiceConfigsResponse_Config{}
-	mi := &file_userv1_proto_msgTypes[51]
+	mi := &file_userv1_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3614,7 +4129,7 @@ func (x *SetServiceConfigsResponse_Config) String() string {
 func (*SetServiceConfigsResponse_Config) ProtoMessage() {}
 
 func (x *SetServiceConfigsResponse_Config) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[51]
+	mi := &file_userv1_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3627,7 +4142,7 @@ func (x *SetServiceConfigsResponse_Config) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetServiceConfigsResponse_Config.ProtoReflect.Descriptor instead.
 func (*SetServiceConfigsResponse_Config) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{19, 0}
+	return file_userv1_proto_rawDescGZIP(), []int{24, 0}
 }
 
 func (x *SetServiceConfigsResponse_Config) GetType() isSetServiceConfigsResponse_Config_Type {
@@ -3682,7 +4197,7 @@ type SetServiceConfigsResponse_Config_Kubeconfig struct {
 
 func (x *SetServiceConfigsResponse_Config_Kubeconfig) Reset() {
 	*x = SetServiceConfigsResponse_Config_Kubeconfig{}
-	mi := &file_userv1_proto_msgTypes[52]
+	mi := &file_userv1_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3694,7 +4209,7 @@ func (x *SetServiceConfigsResponse_Config_Kubeconfig) String() string {
 func (*SetServiceConfigsResponse_Config_Kubeconfig) ProtoMessage() {}
 
 func (x *SetServiceConfigsResponse_Config_Kubeconfig) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[52]
+	mi := &file_userv1_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3707,7 +4222,7 @@ func (x *SetServiceConfigsResponse_Config_Kubeconfig) ProtoReflect() protoreflec
 
 // Deprecated: Use SetServiceConfigsResponse_Config_Kubeconfig.ProtoReflect.Descriptor instead.
 func (*SetServiceConfigsResponse_Config_Kubeconfig) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{19, 0, 0}
+	return file_userv1_proto_rawDescGZIP(), []int{24, 0, 0}
 }
 
 func (x *SetServiceConfigsResponse_Config_Kubeconfig) GetContent() []byte {
@@ -3729,7 +4244,7 @@ type SetServiceConfigsResponse_Config_SSH struct {
 
 func (x *SetServiceConfigsResponse_Config_SSH) Reset() {
 	*x = SetServiceConfigsResponse_Config_SSH{}
-	mi := &file_userv1_proto_msgTypes[53]
+	mi := &file_userv1_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3741,7 +4256,7 @@ func (x *SetServiceConfigsResponse_Config_SSH) String() string {
 func (*SetServiceConfigsResponse_Config_SSH) ProtoMessage() {}
 
 func (x *SetServiceConfigsResponse_Config_SSH) ProtoReflect() protoreflect.Message {
-	mi := &file_userv1_proto_msgTypes[53]
+	mi := &file_userv1_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3754,7 +4269,7 @@ func (x *SetServiceConfigsResponse_Config_SSH) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use SetServiceConfigsResponse_Config_SSH.ProtoReflect.Descriptor instead.
 func (*SetServiceConfigsResponse_Config_SSH) Descriptor() ([]byte, []int) {
-	return file_userv1_proto_rawDescGZIP(), []int{19, 0, 1}
+	return file_userv1_proto_rawDescGZIP(), []int{24, 0, 1}
 }
 
 func (x *SetServiceConfigsResponse_Config_SSH) GetKnownHosts() []string {
@@ -4165,194 +4680,301 @@ var file_userv1_proto_rawDesc = []byte{
 	0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e,
 	0x2e, 0x6d, 0x65, 0x74, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
 	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x10, 0x6c, 0x69, 0x73, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x22, 0xe5, 0x0b, 0x0a, 0x0f,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x0b, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x57, 0x0a, 0x0a, 0x61, 0x64, 0x64,
-	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e,
-	0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69,
-	0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x47, 0x61, 0x74,
-	0x65, 0x77, 0x61, 0x79, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x47, 0x61, 0x74, 0x65, 0x77,
-	0x61, 0x79, 0x12, 0x60, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6f, 0x63, 0x74, 0x65,
-	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74,
-	0x65, 0x77, 0x61, 0x79, 0x12, 0x60, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x61,
-	0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6f, 0x63,
-	0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e,
-	0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x61,
-	0x74, 0x65, 0x77, 0x61, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47,
-	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x54, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x44, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6f, 0x63, 0x74, 0x65,
-	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x4e, 0x53, 0x48,
-	0x00, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x4e, 0x53, 0x12, 0x4e, 0x0a, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e,
-	0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69,
-	0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x57, 0x0a, 0x0a,
-	0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x22, 0xe2, 0x05, 0x0a, 0x0d,
+	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x12, 0x3f, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x41, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2d, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x52,
+	0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x47, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0xa5,
+	0x01, 0x0a, 0x04, 0x53, 0x70, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
+	0x3f, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x24, 0x0a, 0x0d, 0x6a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0xa7, 0x02, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x4b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
 	0x32, 0x35, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x69, 0x73,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x57, 0x0a, 0x0a, 0x61, 0x64, 0x64, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6f, 0x63, 0x74, 0x65,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73,
+	0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
+	0x22, 0x4e, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07,
+	0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x50, 0x50,
+	0x52, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4e, 0x49, 0x45,
+	0x44, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x04,
+	0x22, 0xe0, 0x01, 0x0a, 0x11, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x3e, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x63, 0x74, 0x65,
 	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x48, 0x00, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x60,
-	0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x48,
-	0x00, 0x52, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x60, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69,
-	0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x42, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x2a, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52,
-	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x1a, 0x4a, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x47, 0x61, 0x74,
-	0x65, 0x77, 0x61, 0x79, 0x12, 0x3c, 0x0a, 0x07, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x52, 0x07, 0x67, 0x61, 0x74, 0x65, 0x77,
-	0x61, 0x79, 0x1a, 0x4d, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x12, 0x3c, 0x0a, 0x07, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x57, 0x0a, 0x10, 0x6c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x74,
+	0x61, 0x52, 0x10, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d,
+	0x65, 0x74, 0x61, 0x22, 0x7c, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x44, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2c, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x6f, 0x44, 0x65, 0x63, 0x69, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x6f, 0x44, 0x65, 0x63, 0x69, 0x64,
+	0x65, 0x22, 0x49, 0x0a, 0x1b, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x46, 0x0a, 0x18,
+	0x44, 0x65, 0x6e, 0x79, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x22, 0xe5, 0x0b, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x57, 0x0a, 0x0a, 0x61, 0x64, 0x64, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75,
+	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x48, 0x00, 0x52,
+	0x0a, 0x61, 0x64, 0x64, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x60, 0x0a, 0x0d, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x48, 0x00, 0x52, 0x0d,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x60, 0x0a,
+	0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e,
 	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x52, 0x07, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
-	0x79, 0x1a, 0x1f, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77,
-	0x61, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x1a, 0x26, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x23, 0x0a, 0x07, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a,
-	0x3d, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x4e, 0x53, 0x12, 0x30, 0x0a, 0x03,
-	0x64, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x63, 0x74, 0x65,
+	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x48, 0x00,
+	0x52, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12,
+	0x54, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x4e, 0x53, 0x48, 0x00, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x44, 0x4e, 0x53, 0x12, 0x4e, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75,
+	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x57, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6f, 0x63, 0x74, 0x65,
 	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x4e, 0x53, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x1a, 0x50,
-	0x0a, 0x0a, 0x41, 0x64, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x07,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x48, 0x00, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x57,
+	0x0a, 0x0a, 0x61, 0x64, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41,
+	0x64, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x64, 0x64,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x60, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38,
+	0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61,
+	0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x60, 0x0a, 0x0d, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x38, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6f, 0x63, 0x74,
+	0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x1a,
+	0x4a, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x3c, 0x0a,
+	0x07, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61,
+	0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x52, 0x07, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x1a, 0x4d, 0x0a, 0x0d, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x3c, 0x0a, 0x07,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
 	0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69,
-	0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x64,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x1a, 0x53, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x42, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48,
-	0x6f, 0x73, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x23, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x22, 0x2e, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x52, 0x07, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x1a, 0x1f, 0x0a, 0x0d, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x26, 0x0a, 0x0a, 0x44,
+	0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x1a, 0x23, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x3d, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x44, 0x4e, 0x53, 0x12, 0x30, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x4e, 0x53, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x1a, 0x50, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75,
+	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x53, 0x0a, 0x0d, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x07, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x63,
+	0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x64, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x23,
+	0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
 	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x22, 0xdc, 0x04, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x61, 0x6d, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x2e, 0x0a, 0x18,
+	0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xdc, 0x04, 0x0a,
+	0x19, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6f, 0x63,
+	0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
 	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x55, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
-	0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
-	0x12, 0x53, 0x0a, 0x06, 0x6c, 0x33, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x3b, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x53, 0x0a, 0x06, 0x6c, 0x33, 0x4d,
+	0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3b, 0x2e, 0x6f, 0x63, 0x74, 0x65,
+	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x4c, 0x33, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x6c, 0x33, 0x4d, 0x6f, 0x64, 0x65, 0x1a, 0xc6,
+	0x02, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x68, 0x0a, 0x0a, 0x6b, 0x75, 0x62,
+	0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x46, 0x2e,
+	0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69,
+	0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x0a, 0x6b, 0x75, 0x62, 0x65, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x53, 0x0a, 0x03, 0x73, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x3f, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
 	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74,
 	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4c, 0x33, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x6c,
-	0x33, 0x4d, 0x6f, 0x64, 0x65, 0x1a, 0xc6, 0x02, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x12, 0x68, 0x0a, 0x0a, 0x6b, 0x75, 0x62, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x46, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x0a,
-	0x6b, 0x75, 0x62, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x53, 0x0a, 0x03, 0x73, 0x73,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69,
-	0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53, 0x53, 0x48, 0x48, 0x00, 0x52, 0x03, 0x73, 0x73, 0x68, 0x1a,
-	0x26, 0x0a, 0x0a, 0x4b, 0x75, 0x62, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a,
-	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x4d, 0x0a, 0x03, 0x53, 0x53, 0x48, 0x12, 0x1e,
-	0x0a, 0x0a, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0a, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x26,
-	0x0a, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a,
-	0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x22,
-	0x0a, 0x06, 0x4c, 0x33, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x54, 0x48,
-	0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x56, 0x34, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x56, 0x36,
-	0x10, 0x02, 0x32, 0x80, 0x06, 0x0a, 0x0b, 0x4d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x66, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x29, 0x2e,
-	0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69,
-	0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c,
-	0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x6b, 0x0a, 0x0a, 0x44, 0x69,
-	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x2c, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c,
-	0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53, 0x53,
+	0x48, 0x48, 0x00, 0x52, 0x03, 0x73, 0x73, 0x68, 0x1a, 0x26, 0x0a, 0x0a, 0x4b, 0x75, 0x62, 0x65,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x1a, 0x4d, 0x0a, 0x03, 0x53, 0x53, 0x48, 0x12, 0x1e, 0x0a, 0x0a, 0x6b, 0x6e, 0x6f, 0x77, 0x6e,
+	0x48, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6e, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x7a, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x42,
+	0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x22, 0x0a, 0x06, 0x4c, 0x33, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x54, 0x48, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x56,
+	0x34, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x56, 0x36, 0x10, 0x02, 0x32, 0xd1, 0x09, 0x0a, 0x0b,
+	0x4d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x66, 0x0a, 0x07, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x29, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75,
 	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x66, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2d, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2a, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28,
+	0x01, 0x30, 0x01, 0x12, 0x6b, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x12, 0x2c, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2d, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x66, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x2d, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x26,
+	0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61,
+	0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x6c, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74,
+	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x2f, 0x2e, 0x6f, 0x63, 0x74, 0x65,
+	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x28, 0x2e, 0x6f, 0x63, 0x74,
+	0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x68, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x2b, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2c, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x80, 0x01, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x33, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75,
 	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x26, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x6f, 0x63,
+	0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x25, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x22, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c,
+	0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x69,
+	0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d,
 	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12,
-	0x6c, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x12, 0x2f, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x1a, 0x28, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x68, 0x0a,
-	0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2b, 0x2e, 0x6f, 0x63, 0x74,
-	0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75,
-	0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69,
-	0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x80, 0x01, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x33, 0x2e,
-	0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69,
-	0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x34, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x0a, 0x47, 0x65,
-	0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x25, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c,
-	0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x6d, 0x65, 0x74,
-	0x61, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a,
-	0x22, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d,
-	0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x22, 0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2f, 0x6f, 0x63, 0x74,
-	0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x6d, 0x61, 0x69, 0x6e, 0x2f,
-	0x75, 0x73, 0x65, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x28, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x76, 0x0a, 0x11, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33,
+	0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61,
+	0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x1a, 0x2c, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x78, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x2e, 0x6f, 0x63, 0x74, 0x65,
+	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x28, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x72, 0x0a, 0x11, 0x44,
+	0x65, 0x6e, 0x79, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x33, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6e,
+	0x79, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42,
+	0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x63,
+	0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2f, 0x6f, 0x63, 0x74, 0x65, 0x6c, 0x69, 0x75, 0x6d, 0x2f,
+	0x61, 0x70, 0x69, 0x73, 0x2f, 0x6d, 0x61, 0x69, 0x6e, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -4367,8 +4989,8 @@ func file_userv1_proto_rawDescGZIP() []byte {
 	return file_userv1_proto_rawDescData
 }
 
-var file_userv1_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
-var file_userv1_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
+var file_userv1_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_userv1_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_userv1_proto_goTypes = []any{
 	(GetStatusResponse_User_Spec_Type)(0),                    // 0: octelium.api.main.user.v1.GetStatusResponse.User.Spec.Type
 	(GetStatusResponse_Session_Status_Type)(0),               // 1: octelium.api.main.user.v1.GetStatusResponse.Session.Status.Type
@@ -4378,158 +5000,184 @@ var file_userv1_proto_goTypes = []any{
 	(HostedService_Mode)(0),                                  // 5: octelium.api.main.user.v1.HostedService.Mode
 	(ConnectionState_L3Mode)(0),                              // 6: octelium.api.main.user.v1.ConnectionState.L3Mode
 	(Service_Spec_Type)(0),                                   // 7: octelium.api.main.user.v1.Service.Spec.Type
-	(SetServiceConfigsResponse_L3Mode)(0),                    // 8: octelium.api.main.user.v1.SetServiceConfigsResponse.L3Mode
-	(*LogoutRequest)(nil),                                    // 9: octelium.api.main.user.v1.LogoutRequest
-	(*LogoutResponse)(nil),                                   // 10: octelium.api.main.user.v1.LogoutResponse
-	(*GetStatusRequest)(nil),                                 // 11: octelium.api.main.user.v1.GetStatusRequest
-	(*GetStatusResponse)(nil),                                // 12: octelium.api.main.user.v1.GetStatusResponse
-	(*ConnectRequest)(nil),                                   // 13: octelium.api.main.user.v1.ConnectRequest
-	(*DisconnectRequest)(nil),                                // 14: octelium.api.main.user.v1.DisconnectRequest
-	(*DisconnectResponse)(nil),                               // 15: octelium.api.main.user.v1.DisconnectResponse
-	(*Gateway)(nil),                                          // 16: octelium.api.main.user.v1.Gateway
-	(*DNS)(nil),                                              // 17: octelium.api.main.user.v1.DNS
-	(*HostedService)(nil),                                    // 18: octelium.api.main.user.v1.HostedService
-	(*ConnectionState)(nil),                                  // 19: octelium.api.main.user.v1.ConnectionState
-	(*Service)(nil),                                          // 20: octelium.api.main.user.v1.Service
-	(*ServiceList)(nil),                                      // 21: octelium.api.main.user.v1.ServiceList
-	(*ListServiceOptions)(nil),                               // 22: octelium.api.main.user.v1.ListServiceOptions
-	(*ListNamespaceOptions)(nil),                             // 23: octelium.api.main.user.v1.ListNamespaceOptions
-	(*Namespace)(nil),                                        // 24: octelium.api.main.user.v1.Namespace
-	(*NamespaceList)(nil),                                    // 25: octelium.api.main.user.v1.NamespaceList
-	(*ConnectResponse)(nil),                                  // 26: octelium.api.main.user.v1.ConnectResponse
-	(*SetServiceConfigsRequest)(nil),                         // 27: octelium.api.main.user.v1.SetServiceConfigsRequest
-	(*SetServiceConfigsResponse)(nil),                        // 28: octelium.api.main.user.v1.SetServiceConfigsResponse
-	(*GetStatusResponse_User)(nil),                           // 29: octelium.api.main.user.v1.GetStatusResponse.User
-	(*GetStatusResponse_Session)(nil),                        // 30: octelium.api.main.user.v1.GetStatusResponse.Session
-	(*GetStatusResponse_Cluster)(nil),                        // 31: octelium.api.main.user.v1.GetStatusResponse.Cluster
-	(*GetStatusResponse_User_Spec)(nil),                      // 32: octelium.api.main.user.v1.GetStatusResponse.User.Spec
-	(*GetStatusResponse_User_Status)(nil),                    // 33: octelium.api.main.user.v1.GetStatusResponse.User.Status
-	(*GetStatusResponse_Session_Spec)(nil),                   // 34: octelium.api.main.user.v1.GetStatusResponse.Session.Spec
-	(*GetStatusResponse_Session_Status)(nil),                 // 35: octelium.api.main.user.v1.GetStatusResponse.Session.Status
-	(*ConnectRequest_Initialize)(nil),                        // 36: octelium.api.main.user.v1.ConnectRequest.Initialize
-	(*ConnectRequest_KeepAlive)(nil),                         // 37: octelium.api.main.user.v1.ConnectRequest.KeepAlive
-	(*ConnectRequest_Initialize_ServiceOptions)(nil),         // 38: octelium.api.main.user.v1.ConnectRequest.Initialize.ServiceOptions
-	(*ConnectRequest_Initialize_PublishedService)(nil),       // 39: octelium.api.main.user.v1.ConnectRequest.Initialize.PublishedService
-	(*ConnectRequest_Initialize_ServiceOptions_Service)(nil), // 40: octelium.api.main.user.v1.ConnectRequest.Initialize.ServiceOptions.Service
-	(*Gateway_WireGuard)(nil),                                // 41: octelium.api.main.user.v1.Gateway.WireGuard
-	(*Gateway_QUICV0)(nil),                                   // 42: octelium.api.main.user.v1.Gateway.QUICV0
-	(*HostedService_Upstream)(nil),                           // 43: octelium.api.main.user.v1.HostedService.Upstream
-	(*ConnectionState_ServiceOptions)(nil),                   // 44: octelium.api.main.user.v1.ConnectionState.ServiceOptions
-	(*ConnectionState_ServiceConfig)(nil),                    // 45: octelium.api.main.user.v1.ConnectionState.ServiceConfig
-	(*ConnectionState_ServiceConfig_SSH)(nil),                // 46: octelium.api.main.user.v1.ConnectionState.ServiceConfig.SSH
-	(*Service_Spec)(nil),                                     // 47: octelium.api.main.user.v1.Service.Spec
-	(*Service_Status)(nil),                                   // 48: octelium.api.main.user.v1.Service.Status
-	(*Namespace_Spec)(nil),                                   // 49: octelium.api.main.user.v1.Namespace.Spec
-	(*Namespace_Status)(nil),                                 // 50: octelium.api.main.user.v1.Namespace.Status
-	(*ConnectResponse_AddGateway)(nil),                       // 51: octelium.api.main.user.v1.ConnectResponse.AddGateway
-	(*ConnectResponse_UpdateGateway)(nil),                    // 52: octelium.api.main.user.v1.ConnectResponse.UpdateGateway
-	(*ConnectResponse_DeleteGateway)(nil),                    // 53: octelium.api.main.user.v1.ConnectResponse.DeleteGateway
-	(*ConnectResponse_Disconnect)(nil),                       // 54: octelium.api.main.user.v1.ConnectResponse.Disconnect
-	(*ConnectResponse_Message)(nil),                          // 55: octelium.api.main.user.v1.ConnectResponse.Message
-	(*ConnectResponse_UpdateDNS)(nil),                        // 56: octelium.api.main.user.v1.ConnectResponse.UpdateDNS
-	(*ConnectResponse_AddService)(nil),                       // 57: octelium.api.main.user.v1.ConnectResponse.AddService
-	(*ConnectResponse_UpdateService)(nil),                    // 58: octelium.api.main.user.v1.ConnectResponse.UpdateService
-	(*ConnectResponse_DeleteService)(nil),                    // 59: octelium.api.main.user.v1.ConnectResponse.DeleteService
-	(*SetServiceConfigsResponse_Config)(nil),                 // 60: octelium.api.main.user.v1.SetServiceConfigsResponse.Config
-	(*SetServiceConfigsResponse_Config_Kubeconfig)(nil),      // 61: octelium.api.main.user.v1.SetServiceConfigsResponse.Config.Kubeconfig
-	(*SetServiceConfigsResponse_Config_SSH)(nil),             // 62: octelium.api.main.user.v1.SetServiceConfigsResponse.Config.SSH
-	(*metav1.DualStackIP)(nil),                               // 63: octelium.api.main.meta.v1.DualStackIP
-	(*metav1.DualStackNetwork)(nil),                          // 64: octelium.api.main.meta.v1.DualStackNetwork
-	(*metav1.Metadata)(nil),                                  // 65: octelium.api.main.meta.v1.Metadata
-	(*metav1.ListResponseMeta)(nil),                          // 66: octelium.api.main.meta.v1.ListResponseMeta
-	(*metav1.CommonListOptions)(nil),                         // 67: octelium.api.main.meta.v1.CommonListOptions
-	(*timestamppb.Timestamp)(nil),                            // 68: google.protobuf.Timestamp
-	(*metav1.GetOptions)(nil),                                // 69: octelium.api.main.meta.v1.GetOptions
+	(AccessRequest_Status_State)(0),                          // 8: octelium.api.main.user.v1.AccessRequest.Status.State
+	(SetServiceConfigsResponse_L3Mode)(0),                    // 9: octelium.api.main.user.v1.SetServiceConfigsResponse.L3Mode
+	(*LogoutRequest)(nil),                                    // 10: octelium.api.main.user.v1.LogoutRequest
+	(*LogoutResponse)(nil),                                   // 11: octelium.api.main.user.v1.LogoutResponse
+	(*GetStatusRequest)(nil),                                 // 12: octelium.api.main.user.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),                                // 13: octelium.api.main.user.v1.GetStatusResponse
+	(*ConnectRequest)(nil),                                   // 14: octelium.api.main.user.v1.ConnectRequest
+	(*DisconnectRequest)(nil),                                // 15: octelium.api.main.user.v1.DisconnectRequest
+	(*DisconnectResponse)(nil),                               // 16: octelium.api.main.user.v1.DisconnectResponse
+	(*Gateway)(nil),                                          // 17: octelium.api.main.user.v1.Gateway
+	(*DNS)(nil),                                              // 18: octelium.api.main.user.v1.DNS
+	(*HostedService)(nil),                                    // 19: octelium.api.main.user.v1.HostedService
+	(*ConnectionState)(nil),                                  // 20: octelium.api.main.user.v1.ConnectionState
+	(*Service)(nil),                                          // 21: octelium.api.main.user.v1.Service
+	(*ServiceList)(nil),                                      // 22: octelium.api.main.user.v1.ServiceList
+	(*ListServiceOptions)(nil),                               // 23: octelium.api.main.user.v1.ListServiceOptions
+	(*ListNamespaceOptions)(nil),                             // 24: octelium.api.main.user.v1.ListNamespaceOptions
+	(*Namespace)(nil),                                        // 25: octelium.api.main.user.v1.Namespace
+	(*NamespaceList)(nil),                                    // 26: octelium.api.main.user.v1.NamespaceList
+	(*AccessRequest)(nil),                                    // 27: octelium.api.main.user.v1.AccessRequest
+	(*AccessRequestList)(nil),                                // 28: octelium.api.main.user.v1.AccessRequestList
+	(*ListAccessRequestOptions)(nil),                         // 29: octelium.api.main.user.v1.ListAccessRequestOptions
+	(*ApproveAccessRequestRequest)(nil),                      // 30: octelium.api.main.user.v1.ApproveAccessRequestRequest
+	(*DenyAccessRequestRequest)(nil),                         // 31: octelium.api.main.user.v1.DenyAccessRequestRequest
+	(*ConnectResponse)(nil),                                  // 32: octelium.api.main.user.v1.ConnectResponse
+	(*SetServiceConfigsRequest)(nil),                         // 33: octelium.api.main.user.v1.SetServiceConfigsRequest
+	(*SetServiceConfigsResponse)(nil),                        // 34: octelium.api.main.user.v1.SetServiceConfigsResponse
+	(*GetStatusResponse_User)(nil),                           // 35: octelium.api.main.user.v1.GetStatusResponse.User
+	(*GetStatusResponse_Session)(nil),                        // 36: octelium.api.main.user.v1.GetStatusResponse.Session
+	(*GetStatusResponse_Cluster)(nil),                        // 37: octelium.api.main.user.v1.GetStatusResponse.Cluster
+	(*GetStatusResponse_User_Spec)(nil),                      // 38: octelium.api.main.user.v1.GetStatusResponse.User.Spec
+	(*GetStatusResponse_User_Status)(nil),                    // 39: octelium.api.main.user.v1.GetStatusResponse.User.Status
+	(*GetStatusResponse_Session_Spec)(nil),                   // 40: octelium.api.main.user.v1.GetStatusResponse.Session.Spec
+	(*GetStatusResponse_Session_Status)(nil),                 // 41: octelium.api.main.user.v1.GetStatusResponse.Session.Status
+	(*ConnectRequest_Initialize)(nil),                        // 42: octelium.api.main.user.v1.ConnectRequest.Initialize
+	(*ConnectRequest_KeepAlive)(nil),                         // 43: octelium.api.main.user.v1.ConnectRequest.KeepAlive
+	(*ConnectRequest_Initialize_ServiceOptions)(nil),         // 44: octelium.api.main.user.v1.ConnectRequest.Initialize.ServiceOptions
+	(*ConnectRequest_Initialize_PublishedService)(nil),       // 45: octelium.api.main.user.v1.ConnectRequest.Initialize.PublishedService
+	(*ConnectRequest_Initialize_ServiceOptions_Service)(nil), // 46: octelium.api.main.user.v1.ConnectRequest.Initialize.ServiceOptions.Service
+	(*Gateway_WireGuard)(nil),                                // 47: octelium.api.main.user.v1.Gateway.WireGuard
+	(*Gateway_QUICV0)(nil),                                   // 48: octelium.api.main.user.v1.Gateway.QUICV0
+	(*HostedService_Upstream)(nil),                           // 49: octelium.api.main.user.v1.HostedService.Upstream
+	(*ConnectionState_ServiceOptions)(nil),                   // 50: octelium.api.main.user.v1.ConnectionState.ServiceOptions
+	(*ConnectionState_ServiceConfig)(nil),                    // 51: octelium.api.main.user.v1.ConnectionState.ServiceConfig
+	(*ConnectionState_ServiceConfig_SSH)(nil),                // 52: octelium.api.main.user.v1.ConnectionState.ServiceConfig.SSH
+	(*Service_Spec)(nil),                                     // 53: octelium.api.main.user.v1.Service.Spec
+	(*Service_Status)(nil),                                   // 54: octelium.api.main.user.v1.Service.Status
+	(*Namespace_Spec)(nil),                                   // 55: octelium.api.main.user.v1.Namespace.Spec
+	(*Namespace_Status)(nil),                                 // 56: octelium.api.main.user.v1.Namespace.Status
+	(*AccessRequest_Spec)(nil),                               // 57: octelium.api.main.user.v1.AccessRequest.Spec
+	(*AccessRequest_Status)(nil),                             // 58: octelium.api.main.user.v1.AccessRequest.Status
+	(*ConnectResponse_AddGateway)(nil),                       // 59: octelium.api.main.user.v1.ConnectResponse.AddGateway
+	(*ConnectResponse_UpdateGateway)(nil),                    // 60: octelium.api.main.user.v1.ConnectResponse.UpdateGateway
+	(*ConnectResponse_DeleteGateway)(nil),                    // 61: octelium.api.main.user.v1.ConnectResponse.DeleteGateway
+	(*ConnectResponse_Disconnect)(nil),                       // 62: octelium.api.main.user.v1.ConnectResponse.Disconnect
+	(*ConnectResponse_Message)(nil),                          // 63: octelium.api.main.user.v1.ConnectResponse.Message
+	(*ConnectResponse_UpdateDNS)(nil),                        // 64: octelium.api.main.user.v1.ConnectResponse.UpdateDNS
+	(*ConnectResponse_AddService)(nil),                       // 65: octelium.api.main.user.v1.ConnectResponse.AddService
+	(*ConnectResponse_UpdateService)(nil),                    // 66: octelium.api.main.user.v1.ConnectResponse.UpdateService
+	(*ConnectResponse_DeleteService)(nil),                    // 67: octelium.api.main.user.v1.ConnectResponse.DeleteService
+	(*SetServiceConfigsResponse_Config)(nil),                 // 68: octelium.api.main.user.v1.SetServiceConfigsResponse.Config
+	(*SetServiceConfigsResponse_Config_Kubeconfig)(nil),      // 69: octelium.api.main.user.v1.SetServiceConfigsResponse.Config.Kubeconfig
+	(*SetServiceConfigsResponse_Config_SSH)(nil),             // 70: octelium.api.main.user.v1.SetServiceConfigsResponse.Config.SSH
+	(*metav1.DualStackIP)(nil),                               // 71: octelium.api.main.meta.v1.DualStackIP
+	(*metav1.DualStackNetwork)(nil),                          // 72: octelium.api.main.meta.v1.DualStackNetwork
+	(*metav1.Metadata)(nil),                                  // 73: octelium.api.main.meta.v1.Metadata
+	(*metav1.ListResponseMeta)(nil),                          // 74: octelium.api.main.meta.v1.ListResponseMeta
+	(*metav1.CommonListOptions)(nil),                         // 75: octelium.api.main.meta.v1.CommonListOptions
+	(*timestamppb.Timestamp)(nil),                            // 76: google.protobuf.Timestamp
+	(*metav1.Duration)(nil),                                  // 77: octelium.api.main.meta.v1.Duration
+	(*metav1.GetOptions)(nil),                                // 78: octelium.api.main.meta.v1.GetOptions
 }
 var file_userv1_proto_depIdxs = []int32{
-	31, // 0: octelium.api.main.user.v1.GetStatusResponse.cluster:type_name -> octelium.api.main.user.v1.GetStatusResponse.Cluster
-	29, // 1: octelium.api.main.user.v1.GetStatusResponse.user:type_name -> octelium.api.main.user.v1.GetStatusResponse.User
-	30, // 2: octelium.api.main.user.v1.GetStatusResponse.session:type_name -> octelium.api.main.user.v1.GetStatusResponse.Session
-	36, // 3: octelium.api.main.user.v1.ConnectRequest.initialize:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize
-	37, // 4: octelium.api.main.user.v1.ConnectRequest.keepAlive:type_name -> octelium.api.main.user.v1.ConnectRequest.KeepAlive
-	41, // 5: octelium.api.main.user.v1.Gateway.wireguard:type_name -> octelium.api.main.user.v1.Gateway.WireGuard
-	42, // 6: octelium.api.main.user.v1.Gateway.quicv0:type_name -> octelium.api.main.user.v1.Gateway.QUICV0
+	37, // 0: octelium.api.main.user.v1.GetStatusResponse.cluster:type_name -> octelium.api.main.user.v1.GetStatusResponse.Cluster
+	35, // 1: octelium.api.main.user.v1.GetStatusResponse.user:type_name -> octelium.api.main.user.v1.GetStatusResponse.User
+	36, // 2: octelium.api.main.user.v1.GetStatusResponse.session:type_name -> octelium.api.main.user.v1.GetStatusResponse.Session
+	42, // 3: octelium.api.main.user.v1.ConnectRequest.initialize:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize
+	43, // 4: octelium.api.main.user.v1.ConnectRequest.keepAlive:type_name -> octelium.api.main.user.v1.ConnectRequest.KeepAlive
+	47, // 5: octelium.api.main.user.v1.Gateway.wireguard:type_name -> octelium.api.main.user.v1.Gateway.WireGuard
+	48, // 6: octelium.api.main.user.v1.Gateway.quicv0:type_name -> octelium.api.main.user.v1.Gateway.QUICV0
 	4,  // 7: octelium.api.main.user.v1.HostedService.l4Type:type_name -> octelium.api.main.user.v1.HostedService.L4Type
-	43, // 8: octelium.api.main.user.v1.HostedService.upstream:type_name -> octelium.api.main.user.v1.HostedService.Upstream
-	63, // 9: octelium.api.main.user.v1.HostedService.address:type_name -> octelium.api.main.meta.v1.DualStackIP
+	49, // 8: octelium.api.main.user.v1.HostedService.upstream:type_name -> octelium.api.main.user.v1.HostedService.Upstream
+	71, // 9: octelium.api.main.user.v1.HostedService.address:type_name -> octelium.api.main.meta.v1.DualStackIP
 	5,  // 10: octelium.api.main.user.v1.HostedService.mode:type_name -> octelium.api.main.user.v1.HostedService.Mode
-	64, // 11: octelium.api.main.user.v1.ConnectionState.addresses:type_name -> octelium.api.main.meta.v1.DualStackNetwork
-	16, // 12: octelium.api.main.user.v1.ConnectionState.gateways:type_name -> octelium.api.main.user.v1.Gateway
-	17, // 13: octelium.api.main.user.v1.ConnectionState.dns:type_name -> octelium.api.main.user.v1.DNS
-	45, // 14: octelium.api.main.user.v1.ConnectionState.serviceConfigs:type_name -> octelium.api.main.user.v1.ConnectionState.ServiceConfig
+	72, // 11: octelium.api.main.user.v1.ConnectionState.addresses:type_name -> octelium.api.main.meta.v1.DualStackNetwork
+	17, // 12: octelium.api.main.user.v1.ConnectionState.gateways:type_name -> octelium.api.main.user.v1.Gateway
+	18, // 13: octelium.api.main.user.v1.ConnectionState.dns:type_name -> octelium.api.main.user.v1.DNS
+	51, // 14: octelium.api.main.user.v1.ConnectionState.serviceConfigs:type_name -> octelium.api.main.user.v1.ConnectionState.ServiceConfig
 	6,  // 15: octelium.api.main.user.v1.ConnectionState.l3Mode:type_name -> octelium.api.main.user.v1.ConnectionState.L3Mode
-	44, // 16: octelium.api.main.user.v1.ConnectionState.serviceOptions:type_name -> octelium.api.main.user.v1.ConnectionState.ServiceOptions
-	64, // 17: octelium.api.main.user.v1.ConnectionState.cidr:type_name -> octelium.api.main.meta.v1.DualStackNetwork
-	65, // 18: octelium.api.main.user.v1.Service.metadata:type_name -> octelium.api.main.meta.v1.Metadata
-	47, // 19: octelium.api.main.user.v1.Service.spec:type_name -> octelium.api.main.user.v1.Service.Spec
-	48, // 20: octelium.api.main.user.v1.Service.status:type_name -> octelium.api.main.user.v1.Service.Status
-	20, // 21: octelium.api.main.user.v1.ServiceList.items:type_name -> octelium.api.main.user.v1.Service
-	66, // 22: octelium.api.main.user.v1.ServiceList.listResponseMeta:type_name -> octelium.api.main.meta.v1.ListResponseMeta
-	67, // 23: octelium.api.main.user.v1.ListServiceOptions.common:type_name -> octelium.api.main.meta.v1.CommonListOptions
+	50, // 16: octelium.api.main.user.v1.ConnectionState.serviceOptions:type_name -> octelium.api.main.user.v1.ConnectionState.ServiceOptions
+	72, // 17: octelium.api.main.user.v1.ConnectionState.cidr:type_name -> octelium.api.main.meta.v1.DualStackNetwork
+	73, // 18: octelium.api.main.user.v1.Service.metadata:type_name -> octelium.api.main.meta.v1.Metadata
+	53, // 19: octelium.api.main.user.v1.Service.spec:type_name -> octelium.api.main.user.v1.Service.Spec
+	54, // 20: octelium.api.main.user.v1.Service.status:type_name -> octelium.api.main.user.v1.Service.Status
+	21, // 21: octelium.api.main.user.v1.ServiceList.items:type_name -> octelium.api.main.user.v1.Service
+	74, // 22: octelium.api.main.user.v1.ServiceList.listResponseMeta:type_name -> octelium.api.main.meta.v1.ListResponseMeta
+	75, // 23: octelium.api.main.user.v1.ListServiceOptions.common:type_name -> octelium.api.main.meta.v1.CommonListOptions
 	7,  // 24: octelium.api.main.user.v1.ListServiceOptions.type:type_name -> octelium.api.main.user.v1.Service.Spec.Type
-	67, // 25: octelium.api.main.user.v1.ListNamespaceOptions.common:type_name -> octelium.api.main.meta.v1.CommonListOptions
-	65, // 26: octelium.api.main.user.v1.Namespace.metadata:type_name -> octelium.api.main.meta.v1.Metadata
-	49, // 27: octelium.api.main.user.v1.Namespace.spec:type_name -> octelium.api.main.user.v1.Namespace.Spec
-	50, // 28: octelium.api.main.user.v1.Namespace.status:type_name -> octelium.api.main.user.v1.Namespace.Status
-	24, // 29: octelium.api.main.user.v1.NamespaceList.items:type_name -> octelium.api.main.user.v1.Namespace
-	66, // 30: octelium.api.main.user.v1.NamespaceList.listResponseMeta:type_name -> octelium.api.main.meta.v1.ListResponseMeta
-	68, // 31: octelium.api.main.user.v1.ConnectResponse.createdAt:type_name -> google.protobuf.Timestamp
-	51, // 32: octelium.api.main.user.v1.ConnectResponse.addGateway:type_name -> octelium.api.main.user.v1.ConnectResponse.AddGateway
-	52, // 33: octelium.api.main.user.v1.ConnectResponse.updateGateway:type_name -> octelium.api.main.user.v1.ConnectResponse.UpdateGateway
-	53, // 34: octelium.api.main.user.v1.ConnectResponse.deleteGateway:type_name -> octelium.api.main.user.v1.ConnectResponse.DeleteGateway
-	56, // 35: octelium.api.main.user.v1.ConnectResponse.updateDNS:type_name -> octelium.api.main.user.v1.ConnectResponse.UpdateDNS
-	55, // 36: octelium.api.main.user.v1.ConnectResponse.message:type_name -> octelium.api.main.user.v1.ConnectResponse.Message
-	54, // 37: octelium.api.main.user.v1.ConnectResponse.disconnect:type_name -> octelium.api.main.user.v1.ConnectResponse.Disconnect
-	57, // 38: octelium.api.main.user.v1.ConnectResponse.addService:type_name -> octelium.api.main.user.v1.ConnectResponse.AddService
-	58, // 39: octelium.api.main.user.v1.ConnectResponse.updateService:type_name -> octelium.api.main.user.v1.ConnectResponse.UpdateService
-	59, // 40: octelium.api.main.user.v1.ConnectResponse.deleteService:type_name -> octelium.api.main.user.v1.ConnectResponse.DeleteService
-	19, // 41: octelium.api.main.user.v1.ConnectResponse.state:type_name -> octelium.api.main.user.v1.ConnectionState
-	60, // 42: octelium.api.main.user.v1.SetServiceConfigsResponse.configs:type_name -> octelium.api.main.user.v1.SetServiceConfigsResponse.Config
-	8,  // 43: octelium.api.main.user.v1.SetServiceConfigsResponse.l3Mode:type_name -> octelium.api.main.user.v1.SetServiceConfigsResponse.L3Mode
-	65, // 44: octelium.api.main.user.v1.GetStatusResponse.User.metadata:type_name -> octelium.api.main.meta.v1.Metadata
-	32, // 45: octelium.api.main.user.v1.GetStatusResponse.User.spec:type_name -> octelium.api.main.user.v1.GetStatusResponse.User.Spec
-	33, // 46: octelium.api.main.user.v1.GetStatusResponse.User.status:type_name -> octelium.api.main.user.v1.GetStatusResponse.User.Status
-	65, // 47: octelium.api.main.user.v1.GetStatusResponse.Session.metadata:type_name -> octelium.api.main.meta.v1.Metadata
-	34, // 48: octelium.api.main.user.v1.GetStatusResponse.Session.spec:type_name -> octelium.api.main.user.v1.GetStatusResponse.Session.Spec
-	35, // 49: octelium.api.main.user.v1.GetStatusResponse.Session.status:type_name -> octelium.api.main.user.v1.GetStatusResponse.Session.Status
-	65, // 50: octelium.api.main.user.v1.GetStatusResponse.Cluster.metadata:type_name -> octelium.api.main.meta.v1.Metadata
-	0,  // 51: octelium.api.main.user.v1.GetStatusResponse.User.Spec.type:type_name -> octelium.api.main.user.v1.GetStatusResponse.User.Spec.Type
-	1,  // 52: octelium.api.main.user.v1.GetStatusResponse.Session.Status.type:type_name -> octelium.api.main.user.v1.GetStatusResponse.Session.Status.Type
-	2,  // 53: octelium.api.main.user.v1.ConnectRequest.Initialize.l3Mode:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize.L3Mode
-	38, // 54: octelium.api.main.user.v1.ConnectRequest.Initialize.serviceOptions:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize.ServiceOptions
-	3,  // 55: octelium.api.main.user.v1.ConnectRequest.Initialize.connectionType:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize.ConnectionType
-	39, // 56: octelium.api.main.user.v1.ConnectRequest.Initialize.publishedServices:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize.PublishedService
-	68, // 57: octelium.api.main.user.v1.ConnectRequest.KeepAlive.setAt:type_name -> google.protobuf.Timestamp
-	40, // 58: octelium.api.main.user.v1.ConnectRequest.Initialize.ServiceOptions.services:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize.ServiceOptions.Service
-	18, // 59: octelium.api.main.user.v1.ConnectionState.ServiceOptions.services:type_name -> octelium.api.main.user.v1.HostedService
-	46, // 60: octelium.api.main.user.v1.ConnectionState.ServiceConfig.ssh:type_name -> octelium.api.main.user.v1.ConnectionState.ServiceConfig.SSH
-	7,  // 61: octelium.api.main.user.v1.Service.Spec.type:type_name -> octelium.api.main.user.v1.Service.Spec.Type
-	16, // 62: octelium.api.main.user.v1.ConnectResponse.AddGateway.gateway:type_name -> octelium.api.main.user.v1.Gateway
-	16, // 63: octelium.api.main.user.v1.ConnectResponse.UpdateGateway.gateway:type_name -> octelium.api.main.user.v1.Gateway
-	17, // 64: octelium.api.main.user.v1.ConnectResponse.UpdateDNS.dns:type_name -> octelium.api.main.user.v1.DNS
-	18, // 65: octelium.api.main.user.v1.ConnectResponse.AddService.service:type_name -> octelium.api.main.user.v1.HostedService
-	18, // 66: octelium.api.main.user.v1.ConnectResponse.UpdateService.service:type_name -> octelium.api.main.user.v1.HostedService
-	61, // 67: octelium.api.main.user.v1.SetServiceConfigsResponse.Config.kubeconfig:type_name -> octelium.api.main.user.v1.SetServiceConfigsResponse.Config.Kubeconfig
-	62, // 68: octelium.api.main.user.v1.SetServiceConfigsResponse.Config.ssh:type_name -> octelium.api.main.user.v1.SetServiceConfigsResponse.Config.SSH
-	13, // 69: octelium.api.main.user.v1.MainService.Connect:input_type -> octelium.api.main.user.v1.ConnectRequest
-	14, // 70: octelium.api.main.user.v1.MainService.Disconnect:input_type -> octelium.api.main.user.v1.DisconnectRequest
-	22, // 71: octelium.api.main.user.v1.MainService.ListService:input_type -> octelium.api.main.user.v1.ListServiceOptions
-	23, // 72: octelium.api.main.user.v1.MainService.ListNamespace:input_type -> octelium.api.main.user.v1.ListNamespaceOptions
-	11, // 73: octelium.api.main.user.v1.MainService.GetStatus:input_type -> octelium.api.main.user.v1.GetStatusRequest
-	27, // 74: octelium.api.main.user.v1.MainService.SetServiceConfigs:input_type -> octelium.api.main.user.v1.SetServiceConfigsRequest
-	69, // 75: octelium.api.main.user.v1.MainService.GetService:input_type -> octelium.api.main.meta.v1.GetOptions
-	26, // 76: octelium.api.main.user.v1.MainService.Connect:output_type -> octelium.api.main.user.v1.ConnectResponse
-	15, // 77: octelium.api.main.user.v1.MainService.Disconnect:output_type -> octelium.api.main.user.v1.DisconnectResponse
-	21, // 78: octelium.api.main.user.v1.MainService.ListService:output_type -> octelium.api.main.user.v1.ServiceList
-	25, // 79: octelium.api.main.user.v1.MainService.ListNamespace:output_type -> octelium.api.main.user.v1.NamespaceList
-	12, // 80: octelium.api.main.user.v1.MainService.GetStatus:output_type -> octelium.api.main.user.v1.GetStatusResponse
-	28, // 81: octelium.api.main.user.v1.MainService.SetServiceConfigs:output_type -> octelium.api.main.user.v1.SetServiceConfigsResponse
-	20, // 82: octelium.api.main.user.v1.MainService.GetService:output_type -> octelium.api.main.user.v1.Service
-	76, // [76:83] is the sub-list for method output_type
-	69, // [69:76] is the sub-list for method input_type
-	69, // [69:69] is the sub-list for extension type_name
-	69, // [69:69] is the sub-list for extension extendee
-	0,  // [0:69] is the sub-list for field type_name
+	75, // 25: octelium.api.main.user.v1.ListNamespaceOptions.common:type_name -> octelium.api.main.meta.v1.CommonListOptions
+	73, // 26: octelium.api.main.user.v1.Namespace.metadata:type_name -> octelium.api.main.meta.v1.Metadata
+	55, // 27: octelium.api.main.user.v1.Namespace.spec:type_name -> octelium.api.main.user.v1.Namespace.Spec
+	56, // 28: octelium.api.main.user.v1.Namespace.status:type_name -> octelium.api.main.user.v1.Namespace.Status
+	25, // 29: octelium.api.main.user.v1.NamespaceList.items:type_name -> octelium.api.main.user.v1.Namespace
+	74, // 30: octelium.api.main.user.v1.NamespaceList.listResponseMeta:type_name -> octelium.api.main.meta.v1.ListResponseMeta
+	73, // 31: octelium.api.main.user.v1.AccessRequest.metadata:type_name -> octelium.api.main.meta.v1.Metadata
+	57, // 32: octelium.api.main.user.v1.AccessRequest.spec:type_name -> octelium.api.main.user.v1.AccessRequest.Spec
+	58, // 33: octelium.api.main.user.v1.AccessRequest.status:type_name -> octelium.api.main.user.v1.AccessRequest.Status
+	27, // 34: octelium.api.main.user.v1.AccessRequestList.items:type_name -> octelium.api.main.user.v1.AccessRequest
+	74, // 35: octelium.api.main.user.v1.AccessRequestList.listResponseMeta:type_name -> octelium.api.main.meta.v1.ListResponseMeta
+	75, // 36: octelium.api.main.user.v1.ListAccessRequestOptions.common:type_name -> octelium.api.main.meta.v1.CommonListOptions
+	76, // 37: octelium.api.main.user.v1.ConnectResponse.createdAt:type_name -> google.protobuf.Timestamp
+	59, // 38: octelium.api.main.user.v1.ConnectResponse.addGateway:type_name -> octelium.api.main.user.v1.ConnectResponse.AddGateway
+	60, // 39: octelium.api.main.user.v1.ConnectResponse.updateGateway:type_name -> octelium.api.main.user.v1.ConnectResponse.UpdateGateway
+	61, // 40: octelium.api.main.user.v1.ConnectResponse.deleteGateway:type_name -> octelium.api.main.user.v1.ConnectResponse.DeleteGateway
+	64, // 41: octelium.api.main.user.v1.ConnectResponse.updateDNS:type_name -> octelium.api.main.user.v1.ConnectResponse.UpdateDNS
+	63, // 42: octelium.api.main.user.v1.ConnectResponse.message:type_name -> octelium.api.main.user.v1.ConnectResponse.Message
+	62, // 43: octelium.api.main.user.v1.ConnectResponse.disconnect:type_name -> octelium.api.main.user.v1.ConnectResponse.Disconnect
+	65, // 44: octelium.api.main.user.v1.ConnectResponse.addService:type_name -> octelium.api.main.user.v1.ConnectResponse.AddService
+	66, // 45: octelium.api.main.user.v1.ConnectResponse.updateService:type_name -> octelium.api.main.user.v1.ConnectResponse.UpdateService
+	67, // 46: octelium.api.main.user.v1.ConnectResponse.deleteService:type_name -> octelium.api.main.user.v1.ConnectResponse.DeleteService
+	20, // 47: octelium.api.main.user.v1.ConnectResponse.state:type_name -> octelium.api.main.user.v1.ConnectionState
+	68, // 48: octelium.api.main.user.v1.SetServiceConfigsResponse.configs:type_name -> octelium.api.main.user.v1.SetServiceConfigsResponse.Config
+	9,  // 49: octelium.api.main.user.v1.SetServiceConfigsResponse.l3Mode:type_name -> octelium.api.main.user.v1.SetServiceConfigsResponse.L3Mode
+	73, // 50: octelium.api.main.user.v1.GetStatusResponse.User.metadata:type_name -> octelium.api.main.meta.v1.Metadata
+	38, // 51: octelium.api.main.user.v1.GetStatusResponse.User.spec:type_name -> octelium.api.main.user.v1.GetStatusResponse.User.Spec
+	39, // 52: octelium.api.main.user.v1.GetStatusResponse.User.status:type_name -> octelium.api.main.user.v1.GetStatusResponse.User.Status
+	73, // 53: octelium.api.main.user.v1.GetStatusResponse.Session.metadata:type_name -> octelium.api.main.meta.v1.Metadata
+	40, // 54: octelium.api.main.user.v1.GetStatusResponse.Session.spec:type_name -> octelium.api.main.user.v1.GetStatusResponse.Session.Spec
+	41, // 55: octelium.api.main.user.v1.GetStatusResponse.Session.status:type_name -> octelium.api.main.user.v1.GetStatusResponse.Session.Status
+	73, // 56: octelium.api.main.user.v1.GetStatusResponse.Cluster.metadata:type_name -> octelium.api.main.meta.v1.Metadata
+	0,  // 57: octelium.api.main.user.v1.GetStatusResponse.User.Spec.type:type_name -> octelium.api.main.user.v1.GetStatusResponse.User.Spec.Type
+	1,  // 58: octelium.api.main.user.v1.GetStatusResponse.Session.Status.type:type_name -> octelium.api.main.user.v1.GetStatusResponse.Session.Status.Type
+	2,  // 59: octelium.api.main.user.v1.ConnectRequest.Initialize.l3Mode:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize.L3Mode
+	44, // 60: octelium.api.main.user.v1.ConnectRequest.Initialize.serviceOptions:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize.ServiceOptions
+	3,  // 61: octelium.api.main.user.v1.ConnectRequest.Initialize.connectionType:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize.ConnectionType
+	45, // 62: octelium.api.main.user.v1.ConnectRequest.Initialize.publishedServices:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize.PublishedService
+	76, // 63: octelium.api.main.user.v1.ConnectRequest.KeepAlive.setAt:type_name -> google.protobuf.Timestamp
+	46, // 64: octelium.api.main.user.v1.ConnectRequest.Initialize.ServiceOptions.services:type_name -> octelium.api.main.user.v1.ConnectRequest.Initialize.ServiceOptions.Service
+	19, // 65: octelium.api.main.user.v1.ConnectionState.ServiceOptions.services:type_name -> octelium.api.main.user.v1.HostedService
+	52, // 66: octelium.api.main.user.v1.ConnectionState.ServiceConfig.ssh:type_name -> octelium.api.main.user.v1.ConnectionState.ServiceConfig.SSH
+	7,  // 67: octelium.api.main.user.v1.Service.Spec.type:type_name -> octelium.api.main.user.v1.Service.Spec.Type
+	77, // 68: octelium.api.main.user.v1.AccessRequest.Spec.duration:type_name -> octelium.api.main.meta.v1.Duration
+	8,  // 69: octelium.api.main.user.v1.AccessRequest.Status.state:type_name -> octelium.api.main.user.v1.AccessRequest.Status.State
+	76, // 70: octelium.api.main.user.v1.AccessRequest.Status.expiresAt:type_name -> google.protobuf.Timestamp
+	17, // 71: octelium.api.main.user.v1.ConnectResponse.AddGateway.gateway:type_name -> octelium.api.main.user.v1.Gateway
+	17, // 72: octelium.api.main.user.v1.ConnectResponse.UpdateGateway.gateway:type_name -> octelium.api.main.user.v1.Gateway
+	18, // 73: octelium.api.main.user.v1.ConnectResponse.UpdateDNS.dns:type_name -> octelium.api.main.user.v1.DNS
+	19, // 74: octelium.api.main.user.v1.ConnectResponse.AddService.service:type_name -> octelium.api.main.user.v1.HostedService
+	19, // 75: octelium.api.main.user.v1.ConnectResponse.UpdateService.service:type_name -> octelium.api.main.user.v1.HostedService
+	69, // 76: octelium.api.main.user.v1.SetServiceConfigsResponse.Config.kubeconfig:type_name -> octelium.api.main.user.v1.SetServiceConfigsResponse.Config.Kubeconfig
+	70, // 77: octelium.api.main.user.v1.SetServiceConfigsResponse.Config.ssh:type_name -> octelium.api.main.user.v1.SetServiceConfigsResponse.Config.SSH
+	14, // 78: octelium.api.main.user.v1.MainService.Connect:input_type -> octelium.api.main.user.v1.ConnectRequest
+	15, // 79: octelium.api.main.user.v1.MainService.Disconnect:input_type -> octelium.api.main.user.v1.DisconnectRequest
+	23, // 80: octelium.api.main.user.v1.MainService.ListService:input_type -> octelium.api.main.user.v1.ListServiceOptions
+	24, // 81: octelium.api.main.user.v1.MainService.ListNamespace:input_type -> octelium.api.main.user.v1.ListNamespaceOptions
+	12, // 82: octelium.api.main.user.v1.MainService.GetStatus:input_type -> octelium.api.main.user.v1.GetStatusRequest
+	33, // 83: octelium.api.main.user.v1.MainService.SetServiceConfigs:input_type -> octelium.api.main.user.v1.SetServiceConfigsRequest
+	78, // 84: octelium.api.main.user.v1.MainService.GetService:input_type -> octelium.api.main.meta.v1.GetOptions
+	27, // 85: octelium.api.main.user.v1.MainService.CreateAccessRequest:input_type -> octelium.api.main.user.v1.AccessRequest
+	29, // 86: octelium.api.main.user.v1.MainService.ListAccessRequest:input_type -> octelium.api.main.user.v1.ListAccessRequestOptions
+	30, // 87: octelium.api.main.user.v1.MainService.ApproveAccessRequest:input_type -> octelium.api.main.user.v1.ApproveAccessRequestRequest
+	31, // 88: octelium.api.main.user.v1.MainService.DenyAccessRequest:input_type -> octelium.api.main.user.v1.DenyAccessRequestRequest
+	32, // 89: octelium.api.main.user.v1.MainService.Connect:output_type -> octelium.api.main.user.v1.ConnectResponse
+	16, // 90: octelium.api.main.user.v1.MainService.Disconnect:output_type -> octelium.api.main.user.v1.DisconnectResponse
+	22, // 91: octelium.api.main.user.v1.MainService.ListService:output_type -> octelium.api.main.user.v1.ServiceList
+	26, // 92: octelium.api.main.user.v1.MainService.ListNamespace:output_type -> octelium.api.main.user.v1.NamespaceList
+	13, // 93: octelium.api.main.user.v1.MainService.GetStatus:output_type -> octelium.api.main.user.v1.GetStatusResponse
+	34, // 94: octelium.api.main.user.v1.MainService.SetServiceConfigs:output_type -> octelium.api.main.user.v1.SetServiceConfigsResponse
+	21, // 95: octelium.api.main.user.v1.MainService.GetService:output_type -> octelium.api.main.user.v1.Service
+	27, // 96: octelium.api.main.user.v1.MainService.CreateAccessRequest:output_type -> octelium.api.main.user.v1.AccessRequest
+	28, // 97: octelium.api.main.user.v1.MainService.ListAccessRequest:output_type -> octelium.api.main.user.v1.AccessRequestList
+	27, // 98: octelium.api.main.user.v1.MainService.ApproveAccessRequest:output_type -> octelium.api.main.user.v1.AccessRequest
+	27, // 99: octelium.api.main.user.v1.MainService.DenyAccessRequest:output_type -> octelium.api.main.user.v1.AccessRequest
+	89, // [89:100] is the sub-list for method output_type
+	78, // [78:89] is the sub-list for method input_type
+	78, // [78:78] is the sub-list for extension type_name
+	78, // [78:78] is the sub-list for extension extendee
+	0,  // [0:78] is the sub-list for field type_name
 }
 
 func init() { file_userv1_proto_init() }
@@ -4541,7 +5189,7 @@ func file_userv1_proto_init() {
 		(*ConnectRequest_Initialize_)(nil),
 		(*ConnectRequest_KeepAlive_)(nil),
 	}
-	file_userv1_proto_msgTypes[17].OneofWrappers = []any{
+	file_userv1_proto_msgTypes[22].OneofWrappers = []any{
 		(*ConnectResponse_AddGateway_)(nil),
 		(*ConnectResponse_UpdateGateway_)(nil),
 		(*ConnectResponse_DeleteGateway_)(nil),
@@ -4553,10 +5201,10 @@ func file_userv1_proto_init() {
 		(*ConnectResponse_DeleteService_)(nil),
 		(*ConnectResponse_State)(nil),
 	}
-	file_userv1_proto_msgTypes[36].OneofWrappers = []any{
+	file_userv1_proto_msgTypes[41].OneofWrappers = []any{
 		(*ConnectionState_ServiceConfig_Ssh)(nil),
 	}
-	file_userv1_proto_msgTypes[51].OneofWrappers = []any{
+	file_userv1_proto_msgTypes[58].OneofWrappers = []any{
 		(*SetServiceConfigsResponse_Config_Kubeconfig_)(nil),
 		(*SetServiceConfigsResponse_Config_Ssh)(nil),
 	}
@@ -4565,8 +5213,8 @@ func file_userv1_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_userv1_proto_rawDesc,
-			NumEnums:      9,
-			NumMessages:   54,
+			NumEnums:      10,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -34,13 +34,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MainService_Connect_FullMethodName           = "/octelium.api.main.user.v1.MainService/Connect"
-	MainService_Disconnect_FullMethodName        = "/octelium.api.main.user.v1.MainService/Disconnect"
-	MainService_ListService_FullMethodName       = "/octelium.api.main.user.v1.MainService/ListService"
-	MainService_ListNamespace_FullMethodName     = "/octelium.api.main.user.v1.MainService/ListNamespace"
-	MainService_GetStatus_FullMethodName         = "/octelium.api.main.user.v1.MainService/GetStatus"
-	MainService_SetServiceConfigs_FullMethodName = "/octelium.api.main.user.v1.MainService/SetServiceConfigs"
-	MainService_GetService_FullMethodName        = "/octelium.api.main.user.v1.MainService/GetService"
+	MainService_Connect_FullMethodName              = "/octelium.api.main.user.v1.MainService/Connect"
+	MainService_Disconnect_FullMethodName           = "/octelium.api.main.user.v1.MainService/Disconnect"
+	MainService_ListService_FullMethodName          = "/octelium.api.main.user.v1.MainService/ListService"
+	MainService_ListNamespace_FullMethodName        = "/octelium.api.main.user.v1.MainService/ListNamespace"
+	MainService_GetStatus_FullMethodName            = "/octelium.api.main.user.v1.MainService/GetStatus"
+	MainService_SetServiceConfigs_FullMethodName    = "/octelium.api.main.user.v1.MainService/SetServiceConfigs"
+	MainService_GetService_FullMethodName           = "/octelium.api.main.user.v1.MainService/GetService"
+	MainService_CreateAccessRequest_FullMethodName  = "/octelium.api.main.user.v1.MainService/CreateAccessRequest"
+	MainService_ListAccessRequest_FullMethodName    = "/octelium.api.main.user.v1.MainService/ListAccessRequest"
+	MainService_ApproveAccessRequest_FullMethodName = "/octelium.api.main.user.v1.MainService/ApproveAccessRequest"
+	MainService_DenyAccessRequest_FullMethodName    = "/octelium.api.main.user.v1.MainService/DenyAccessRequest"
 )
 
 // MainServiceClient is the client API for MainService service.
@@ -62,6 +66,15 @@ type MainServiceClient interface {
 	SetServiceConfigs(ctx context.Context, in *SetServiceConfigsRequest, opts ...grpc.CallOption) (*SetServiceConfigsResponse, error)
 	// GetService retrieves a Service
 	GetService(ctx context.Context, in *metav1.GetOptions, opts ...grpc.CallOption) (*Service, error)
+	// CreateAccessRequest requests a just-in-time access to a Service or a
+	// Namespace
+	CreateAccessRequest(ctx context.Context, in *AccessRequest, opts ...grpc.CallOption) (*AccessRequest, error)
+	// ListAccessRequest lists AccessRequests
+	ListAccessRequest(ctx context.Context, in *ListAccessRequestOptions, opts ...grpc.CallOption) (*AccessRequestList, error)
+	// ApproveAccessRequest approves a PENDING AccessRequest of another User
+	ApproveAccessRequest(ctx context.Context, in *ApproveAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error)
+	// DenyAccessRequest denies a PENDING AccessRequest of another User
+	DenyAccessRequest(ctx context.Context, in *DenyAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error)
 }
 
 type mainServiceClient struct {
@@ -145,6 +158,46 @@ func (c *mainServiceClient) GetService(ctx context.Context, in *metav1.GetOption
 	return out, nil
 }
 
+func (c *mainServiceClient) CreateAccessRequest(ctx context.Context, in *AccessRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, MainService_CreateAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mainServiceClient) ListAccessRequest(ctx context.Context, in *ListAccessRequestOptions, opts ...grpc.CallOption) (*AccessRequestList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessRequestList)
+	err := c.cc.Invoke(ctx, MainService_ListAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mainServiceClient) ApproveAccessRequest(ctx context.Context, in *ApproveAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, MainService_ApproveAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mainServiceClient) DenyAccessRequest(ctx context.Context, in *DenyAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, MainService_DenyAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MainServiceServer is the server API for MainService service.
 // All implementations must embed UnimplementedMainServiceServer
 // for forward compatibility.
@@ -164,6 +217,15 @@ type MainServiceServer interface {
 	SetServiceConfigs(context.Context, *SetServiceConfigsRequest) (*SetServiceConfigsResponse, error)
 	// GetService retrieves a Service
 	GetService(context.Context, *metav1.GetOptions) (*Service, error)
+	// CreateAccessRequest requests a just-in-time access to a Service or a
+	// Namespace
+	CreateAccessRequest(context.Context, *AccessRequest) (*AccessRequest, error)
+	// ListAccessRequest lists AccessRequests
+	ListAccessRequest(context.Context, *ListAccessRequestOptions) (*AccessRequestList, error)
+	// ApproveAccessRequest approves a PENDING AccessRequest of another User
+	ApproveAccessRequest(context.Context, *ApproveAccessRequestRequest) (*AccessRequest, error)
+	// DenyAccessRequest denies a PENDING AccessRequest of another User
+	DenyAccessRequest(context.Context, *DenyAccessRequestRequest) (*AccessRequest, error)
 	mustEmbedUnimplementedMainServiceServer()
 }
 
@@ -195,6 +257,18 @@ func (UnimplementedMainServiceServer) SetServiceConfigs(context.Context, *SetSer
 func (UnimplementedMainServiceServer) GetService(context.Context, *metav1.GetOptions) (*Service, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetService not implemented")
 }
+func (UnimplementedMainServiceServer) CreateAccessRequest(context.Context, *AccessRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccessRequest not implemented")
+}
+func (UnimplementedMainServiceServer) ListAccessRequest(context.Context, *ListAccessRequestOptions) (*AccessRequestList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessRequest not implemented")
+}
+func (UnimplementedMainServiceServer) ApproveAccessRequest(context.Context, *ApproveAccessRequestRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveAccessRequest not implemented")
+}
+func (UnimplementedMainServiceServer) DenyAccessRequest(context.Context, *DenyAccessRequestRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyAccessRequest not implemented")
+}
 func (UnimplementedMainServiceServer) mustEmbedUnimplementedMainServiceServer() {}
 func (UnimplementedMainServiceServer) testEmbeddedByValue()                     {}
 
@@ -331,6 +405,78 @@ func _MainService_GetService_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MainService_CreateAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).CreateAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_CreateAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).CreateAccessRequest(ctx, req.(*AccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MainService_ListAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessRequestOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).ListAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_ListAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).ListAccessRequest(ctx, req.(*ListAccessRequestOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MainService_ApproveAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).ApproveAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_ApproveAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).ApproveAccessRequest(ctx, req.(*ApproveAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MainService_DenyAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainServiceServer).DenyAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainService_DenyAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainServiceServer).DenyAccessRequest(ctx, req.(*DenyAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MainService_ServiceDesc is the grpc.ServiceDesc for MainService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -362,6 +508,22 @@ var MainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetService",
 			Handler:    _MainService_GetService_Handler,
 		},
+		{
+			MethodName: "CreateAccessRequest",
+			Handler:    _MainService_CreateAccessRequest_Handler,
+		},
+		{
+			MethodName: "ListAccessRequest",
+			Handler:    _MainService_ListAccessRequest_Handler,
+		},
+		{
+			MethodName: "ApproveAccessRequest",
+			Handler:    _MainService_ApproveAccessRequest_Handler,
+		},
+		{
+			MethodName: "DenyAccessRequest",
+			Handler:    _MainService_DenyAccessRequest_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -143,6 +143,12 @@ const (
 	ResourceService_ListAuthenticator_FullMethodName      = "/octelium.api.rsc.core.v1.ResourceService/ListAuthenticator"
 	ResourceService_DeleteAuthenticator_FullMethodName    = "/octelium.api.rsc.core.v1.ResourceService/DeleteAuthenticator"
 	ResourceService_WatchAuthenticator_FullMethodName     = "/octelium.api.rsc.core.v1.ResourceService/WatchAuthenticator"
+	ResourceService_CreateAccessRequest_FullMethodName    = "/octelium.api.rsc.core.v1.ResourceService/CreateAccessRequest"
+	ResourceService_UpdateAccessRequest_FullMethodName    = "/octelium.api.rsc.core.v1.ResourceService/UpdateAccessRequest"
+	ResourceService_GetAccessRequest_FullMethodName       = "/octelium.api.rsc.core.v1.ResourceService/GetAccessRequest"
+	ResourceService_ListAccessRequest_FullMethodName      = "/octelium.api.rsc.core.v1.ResourceService/ListAccessRequest"
+	ResourceService_DeleteAccessRequest_FullMethodName    = "/octelium.api.rsc.core.v1.ResourceService/DeleteAccessRequest"
+	ResourceService_WatchAccessRequest_FullMethodName     = "/octelium.api.rsc.core.v1.ResourceService/WatchAccessRequest"
 )
 
 // ResourceServiceClient is the client API for ResourceService service.
@@ -244,6 +250,12 @@ type ResourceServiceClient interface {
 	ListAuthenticator(ctx context.Context, in *rmetav1.ListOptions, opts ...grpc.CallOption) (*corev1.AuthenticatorList, error)
 	DeleteAuthenticator(ctx context.Context, in *rmetav1.DeleteOptions, opts ...grpc.CallOption) (*rmetav1.OperationResult, error)
 	WatchAuthenticator(ctx context.Context, in *rmetav1.WatchOptions, opts ...grpc.CallOption) (grpc.ServerStreamingClient[rmetav1.WatchEvent], error)
+	CreateAccessRequest(ctx context.Context, in *corev1.AccessRequest, opts ...grpc.CallOption) (*corev1.AccessRequest, error)
+	UpdateAccessRequest(ctx context.Context, in *corev1.AccessRequest, opts ...grpc.CallOption) (*corev1.AccessRequest, error)
+	GetAccessRequest(ctx context.Context, in *rmetav1.GetOptions, opts ...grpc.CallOption) (*corev1.AccessRequest, error)
+	ListAccessRequest(ctx context.Context, in *rmetav1.ListOptions, opts ...grpc.CallOption) (*corev1.AccessRequestList, error)
+	DeleteAccessRequest(ctx context.Context, in *rmetav1.DeleteOptions, opts ...grpc.CallOption) (*rmetav1.OperationResult, error)
+	WatchAccessRequest(ctx context.Context, in *rmetav1.WatchOptions, opts ...grpc.CallOption) (grpc.ServerStreamingClient[rmetav1.WatchEvent], error)
 }
 
 type resourceServiceClient struct {
@@ -1328,6 +1340,75 @@ func (c *resourceServiceClient) WatchAuthenticator(ctx context.Context, in *rmet
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ResourceService_WatchAuthenticatorClient = grpc.ServerStreamingClient[rmetav1.WatchEvent]
 
+func (c *resourceServiceClient) CreateAccessRequest(ctx context.Context, in *corev1.AccessRequest, opts ...grpc.CallOption) (*corev1.AccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(corev1.AccessRequest)
+	err := c.cc.Invoke(ctx, ResourceService_CreateAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) UpdateAccessRequest(ctx context.Context, in *corev1.AccessRequest, opts ...grpc.CallOption) (*corev1.AccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(corev1.AccessRequest)
+	err := c.cc.Invoke(ctx, ResourceService_UpdateAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) GetAccessRequest(ctx context.Context, in *rmetav1.GetOptions, opts ...grpc.CallOption) (*corev1.AccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(corev1.AccessRequest)
+	err := c.cc.Invoke(ctx, ResourceService_GetAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) ListAccessRequest(ctx context.Context, in *rmetav1.ListOptions, opts ...grpc.CallOption) (*corev1.AccessRequestList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(corev1.AccessRequestList)
+	err := c.cc.Invoke(ctx, ResourceService_ListAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) DeleteAccessRequest(ctx context.Context, in *rmetav1.DeleteOptions, opts ...grpc.CallOption) (*rmetav1.OperationResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(rmetav1.OperationResult)
+	err := c.cc.Invoke(ctx, ResourceService_DeleteAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) WatchAccessRequest(ctx context.Context, in *rmetav1.WatchOptions, opts ...grpc.CallOption) (grpc.ServerStreamingClient[rmetav1.WatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResourceService_ServiceDesc.Streams[16], ResourceService_WatchAccessRequest_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[rmetav1.WatchOptions, rmetav1.WatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResourceService_WatchAccessRequestClient = grpc.ServerStreamingClient[rmetav1.WatchEvent]
+
 // ResourceServiceServer is the server API for ResourceService service.
 // All implementations must embed UnimplementedResourceServiceServer
 // for forward compatibility.
@@ -1427,6 +1508,12 @@ type ResourceServiceServer interface {
 	ListAuthenticator(context.Context, *rmetav1.ListOptions) (*corev1.AuthenticatorList, error)
 	DeleteAuthenticator(context.Context, *rmetav1.DeleteOptions) (*rmetav1.OperationResult, error)
 	WatchAuthenticator(*rmetav1.WatchOptions, grpc.ServerStreamingServer[rmetav1.WatchEvent]) error
+	CreateAccessRequest(context.Context, *corev1.AccessRequest) (*corev1.AccessRequest, error)
+	UpdateAccessRequest(context.Context, *corev1.AccessRequest) (*corev1.AccessRequest, error)
+	GetAccessRequest(context.Context, *rmetav1.GetOptions) (*corev1.AccessRequest, error)
+	ListAccessRequest(context.Context, *rmetav1.ListOptions) (*corev1.AccessRequestList, error)
+	DeleteAccessRequest(context.Context, *rmetav1.DeleteOptions) (*rmetav1.OperationResult, error)
+	WatchAccessRequest(*rmetav1.WatchOptions, grpc.ServerStreamingServer[rmetav1.WatchEvent]) error
 	mustEmbedUnimplementedResourceServiceServer()
 }
 
@@ -1716,6 +1803,24 @@ func (UnimplementedResourceServiceServer) DeleteAuthenticator(context.Context, *
 func (UnimplementedResourceServiceServer) WatchAuthenticator(*rmetav1.WatchOptions, grpc.ServerStreamingServer[rmetav1.WatchEvent]) error {
 	return status.Errorf(codes.Unimplemented, "method WatchAuthenticator not implemented")
 }
+func (UnimplementedResourceServiceServer) CreateAccessRequest(context.Context, *corev1.AccessRequest) (*corev1.AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccessRequest not implemented")
+}
+func (UnimplementedResourceServiceServer) UpdateAccessRequest(context.Context, *corev1.AccessRequest) (*corev1.AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccessRequest not implemented")
+}
+func (UnimplementedResourceServiceServer) GetAccessRequest(context.Context, *rmetav1.GetOptions) (*corev1.AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccessRequest not implemented")
+}
+func (UnimplementedResourceServiceServer) ListAccessRequest(context.Context, *rmetav1.ListOptions) (*corev1.AccessRequestList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessRequest not implemented")
+}
+func (UnimplementedResourceServiceServer) DeleteAccessRequest(context.Context, *rmetav1.DeleteOptions) (*rmetav1.OperationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccessRequest not implemented")
+}
+func (UnimplementedResourceServiceServer) WatchAccessRequest(*rmetav1.WatchOptions, grpc.ServerStreamingServer[rmetav1.WatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAccessRequest not implemented")
+}
 func (UnimplementedResourceServiceServer) mustEmbedUnimplementedResourceServiceServer() {}
 func (UnimplementedResourceServiceServer) testEmbeddedByValue()                         {}
 
@@ -3299,6 +3404,107 @@ func _ResourceService_WatchAuthenticator_Handler(srv interface{}, stream grpc.Se
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ResourceService_WatchAuthenticatorServer = grpc.ServerStreamingServer[rmetav1.WatchEvent]
 
+func _ResourceService_CreateAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(corev1.AccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).CreateAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_CreateAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).CreateAccessRequest(ctx, req.(*corev1.AccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_UpdateAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(corev1.AccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).UpdateAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_UpdateAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).UpdateAccessRequest(ctx, req.(*corev1.AccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_GetAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(rmetav1.GetOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).GetAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_GetAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).GetAccessRequest(ctx, req.(*rmetav1.GetOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_ListAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(rmetav1.ListOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).ListAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_ListAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).ListAccessRequest(ctx, req.(*rmetav1.ListOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_DeleteAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(rmetav1.DeleteOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).DeleteAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_DeleteAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).DeleteAccessRequest(ctx, req.(*rmetav1.DeleteOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_WatchAccessRequest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(rmetav1.WatchOptions)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourceServiceServer).WatchAccessRequest(m, &grpc.GenericServerStream[rmetav1.WatchOptions, rmetav1.WatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResourceService_WatchAccessRequestServer = grpc.ServerStreamingServer[rmetav1.WatchEvent]
+
 // ResourceService_ServiceDesc is the grpc.ServiceDesc for ResourceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -3614,6 +3820,26 @@ var ResourceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAuthenticator",
 			Handler:    _ResourceService_DeleteAuthenticator_Handler,
 		},
+		{
+			MethodName: "CreateAccessRequest",
+			Handler:    _ResourceService_CreateAccessRequest_Handler,
+		},
+		{
+			MethodName: "UpdateAccessRequest",
+			Handler:    _ResourceService_UpdateAccessRequest_Handler,
+		},
+		{
+			MethodName: "GetAccessRequest",
+			Handler:    _ResourceService_GetAccessRequest_Handler,
+		},
+		{
+			MethodName: "ListAccessRequest",
+			Handler:    _ResourceService_ListAccessRequest_Handler,
+		},
+		{
+			MethodName: "DeleteAccessRequest",
+			Handler:    _ResourceService_DeleteAccessRequest_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3696,6 +3922,11 @@ var ResourceService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ResourceService_WatchAuthenticator_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchAccessRequest",
+			Handler:       _ResourceService_WatchAccessRequest_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rcorev1.proto",
 }
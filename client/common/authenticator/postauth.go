@@ -26,6 +26,7 @@ import (
 	"github.com/octelium/octelium/apis/main/userv1"
 	"github.com/octelium/octelium/client/common/authc"
 	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/common/commands/auth/authcommon/deviceprobe"
 	authn "github.com/octelium/octelium/client/common/commands/auth/authenticator"
 	"github.com/octelium/octelium/client/common/commands/auth/device/register"
 	"github.com/octelium/octelium/pkg/apiutils/umetav1"
@@ -143,6 +144,10 @@ func (a *authenticator) doPostAuth(ctx context.Context) error {
 
 		defer authC.Close()
 
+		if err := deviceprobe.Run(ctx, authC.C()); err != nil {
+			zap.L().Debug("Could not run Device probes", zap.Error(err))
+		}
+
 		if resp, err := authC.C().GetAvailableAuthenticator(ctx,
 			&authv1.GetAvailableAuthenticatorRequest{}); err == nil {
 			if resp.MainAuthenticator != nil {
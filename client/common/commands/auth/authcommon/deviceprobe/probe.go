@@ -79,11 +79,13 @@ func execute(ctx context.Context, p *authv1.DeviceProbe) *authv1.DeviceProbeResu
 
 	switch t := p.Type.(type) {
 	case *authv1.DeviceProbe_RunCommand_:
-		out, err := runCommand(ctx, t.RunCommand)
+		out, exitCode, err := runCommand(ctx, t.RunCommand)
 		if err != nil {
 			return probeErr(p.ProbeID, err.Error())
 		}
-		return probeOut(p.ProbeID, out)
+		ret := probeOut(p.ProbeID, out)
+		ret.ExitCode = int32(exitCode)
+		return ret
 	case *authv1.DeviceProbe_ReadFile_:
 		out, err := readFile(t.ReadFile)
 		if err != nil {
@@ -101,9 +103,9 @@ func execute(ctx context.Context, p *authv1.DeviceProbe) *authv1.DeviceProbeResu
 	}
 }
 
-func runCommand(ctx context.Context, rc *authv1.DeviceProbe_RunCommand) ([]byte, error) {
+func runCommand(ctx context.Context, rc *authv1.DeviceProbe_RunCommand) ([]byte, int, error) {
 	if !filepath.IsAbs(rc.Command) {
-		return nil, errors.Errorf("probe command must be an absolute path")
+		return nil, 0, errors.Errorf("probe command must be an absolute path")
 	}
 
 	timeout := time.Duration(rc.TimeoutSeconds) * time.Second
@@ -122,10 +124,10 @@ func runCommand(ctx context.Context, rc *authv1.DeviceProbe_RunCommand) ([]byte,
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if err := cmd.Start(); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	limit := int64(rc.MaxOutputBytes)
@@ -138,10 +140,16 @@ func runCommand(ctx context.Context, rc *authv1.DeviceProbe_RunCommand) ([]byte,
 
 	out, _ := io.ReadAll(io.LimitReader(stdout, limit))
 	waitErr := cmd.Wait()
-	if len(out) == 0 && waitErr != nil {
-		return nil, waitErr
+	if waitErr != nil {
+		var exitErr *exec.ExitError
+		if errors.As(waitErr, &exitErr) && cctx.Err() == nil {
+			return out, exitErr.ExitCode(), nil
+		}
+		if len(out) == 0 {
+			return nil, 0, waitErr
+		}
 	}
-	return out, nil
+	return out, 0, nil
 }
 
 func readFile(rf *authv1.DeviceProbe_ReadFile) ([]byte, error) {
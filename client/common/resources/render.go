@@ -0,0 +1,279 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+type RenderOpts struct {
+	// Values are the variables available to the manifests as .Values. If
+	// nil, the manifests are not rendered as templates.
+	Values map[string]any
+	// Overlays are files or directories of patches merged into the
+	// manifests.
+	Overlays []string
+}
+
+// LoadCoreResourcesRendered renders the manifests of fPath and its overlays
+// and parses the result the same way as LoadCoreResources.
+func LoadCoreResourcesRendered(fPath string, o *RenderOpts) ([]umetav1.ResourceObjectI, error) {
+	docs, err := RenderDocuments(fPath, o)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := MarshalDocuments(docs)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadResources(bytes.NewReader(out), ucorev1.NewObject)
+}
+
+// RenderDocuments returns the yaml documents of fPath after rendering them
+// as templates and merging the overlay patches into them. Rendering fails on
+// any undefined variable.
+//
+// Every overlay document must have the kind and the metadata name of an
+// existing document. It is merged into that document: objects are merged
+// recursively, null values remove the field and any other value, including
+// lists, replaces the existing one.
+func RenderDocuments(fPath string, o *RenderOpts) ([]map[string]any, error) {
+	if o == nil {
+		o = &RenderOpts{}
+	}
+
+	docs, err := readDocuments(fPath, o.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, overlay := range o.Overlays {
+		patches, err := readDocuments(overlay, o.Values)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, patch := range patches {
+			if err := applyPatch(docs, patch); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+// MarshalDocuments encodes the documents as a multi-document yaml stream.
+func MarshalDocuments(docs []map[string]any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	for _, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func readDocuments(fPath string, values map[string]any) ([]map[string]any, error) {
+	var ret []map[string]any
+
+	if err := walkFiles(fPath, func(path string, r io.Reader) error {
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		if values != nil {
+			content, err = renderTemplate(path, content, values)
+			if err != nil {
+				return err
+			}
+		}
+
+		d := yaml.NewDecoder(bytes.NewReader(content))
+		for {
+			doc := make(map[string]any)
+			if err := d.Decode(&doc); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return errors.Errorf("Could not decode yaml in %s: %s", path, err)
+			}
+
+			if len(doc) > 0 {
+				ret = append(ret, doc)
+			}
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func renderTemplate(name string, content []byte, values map[string]any) ([]byte, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"quote": strconv.Quote,
+			"toJSON": func(arg any) (string, error) {
+				ret, err := json.Marshal(arg)
+				return string(ret), err
+			},
+			"lower": strings.ToLower,
+			"upper": strings.ToUpper,
+		}).
+		Parse(string(content))
+	if err != nil {
+		return nil, errors.Errorf("Could not parse the template %s: %s", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]any{
+		"Values": values,
+	}); err != nil {
+		return nil, errors.Errorf("Could not render the template %s: %s", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func getDocKey(doc map[string]any) (string, string, error) {
+	kind, _ := doc["kind"].(string)
+	md, _ := doc["metadata"].(map[string]any)
+	name, _ := md["name"].(string)
+
+	if kind == "" || name == "" {
+		return "", "", errors.Errorf("Overlay document has no kind or metadata name")
+	}
+
+	if kind == ucorev1.KindService && !strings.Contains(name, ".") {
+		name = fmt.Sprintf("%s.default", name)
+	}
+
+	return kind, name, nil
+}
+
+func applyPatch(docs []map[string]any, patch map[string]any) error {
+	kind, name, err := getDocKey(patch)
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		docKind, docName, err := getDocKey(doc)
+		if err != nil {
+			continue
+		}
+
+		if docKind == kind && docName == name {
+			mergePatch(doc, patch)
+			return nil
+		}
+	}
+
+	return errors.Errorf("Overlay targets %s %s which does not exist", kind, name)
+}
+
+// mergePatch merges patch into dst following the JSON merge patch semantics.
+func mergePatch(dst, patch map[string]any) {
+	keys := make([]string, 0, len(patch))
+	for k := range patch {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := patch[k]
+		if v == nil {
+			delete(dst, k)
+			continue
+		}
+
+		patchMap, isPatchMap := v.(map[string]any)
+		dstMap, isDstMap := dst[k].(map[string]any)
+		if isPatchMap && isDstMap {
+			mergePatch(dstMap, patchMap)
+			continue
+		}
+
+		dst[k] = v
+	}
+}
+
+// LoadValues merges the variables of the given yaml files, in order, and
+// then the key=value pairs of sets. Keys of sets may be dot-separated to set
+// nested variables.
+func LoadValues(files []string, sets []string) (map[string]any, error) {
+	ret := make(map[string]any)
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]any)
+		if err := yaml.Unmarshal(content, &values); err != nil {
+			return nil, errors.Errorf("Could not decode the values file %s: %s", file, err)
+		}
+
+		mergePatch(ret, values)
+	}
+
+	for _, set := range sets {
+		key, val, ok := strings.Cut(set, "=")
+		if !ok || key == "" {
+			return nil, errors.Errorf("Invalid variable %q. It must be in the form key=value", set)
+		}
+
+		parts := strings.Split(key, ".")
+		cur := ret
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := cur[part].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				cur[part] = next
+			}
+			cur = next
+		}
+		cur[parts[len(parts)-1]] = val
+	}
+
+	return ret, nil
+}
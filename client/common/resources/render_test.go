@@ -0,0 +1,128 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestRenderDocuments(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(dir, "base", "svc.yaml"), `
+kind: Service
+metadata:
+  name: api
+spec:
+  port: 8080
+  mode: HTTP
+  config:
+    upstream:
+      url: https://api.{{ .Values.upstream.domain }}
+---
+kind: User
+metadata:
+  name: {{ .Values.user | lower }}
+spec:
+  type: HUMAN
+  email: {{ printf "%s@%s" .Values.user .Values.upstream.domain | quote }}
+`)
+
+	writeTestFile(t, filepath.Join(dir, "values.yaml"), `
+user: ALICE
+upstream:
+  domain: staging.example.com
+`)
+
+	writeTestFile(t, filepath.Join(dir, "overlay", "svc.yaml"), `
+kind: Service
+metadata:
+  name: api.default
+  description: {{ .Values.env }}
+spec:
+  port: 9090
+  mode: null
+`)
+
+	values, err := LoadValues([]string{filepath.Join(dir, "values.yaml")},
+		[]string{"upstream.domain=prod.example.com", "env=production"})
+	assert.Nil(t, err)
+
+	docs, err := RenderDocuments(filepath.Join(dir, "base"), &RenderOpts{
+		Values:   values,
+		Overlays: []string{filepath.Join(dir, "overlay")},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, docs, 2)
+
+	out, err := MarshalDocuments(docs)
+	assert.Nil(t, err)
+
+	rscs, err := loadResources(bytes.NewReader(out), ucorev1.NewObject)
+	assert.Nil(t, err)
+	assert.Len(t, rscs, 2)
+
+	svc := rscs[0].(*corev1.Service)
+	assert.Equal(t, "https://api.prod.example.com", svc.Spec.Config.Upstream.GetUrl())
+	assert.Equal(t, uint32(9090), svc.Spec.Port)
+	assert.Equal(t, corev1.Service_Spec_MODE_UNSET, svc.Spec.Mode)
+	assert.Equal(t, "production", svc.Metadata.Description)
+
+	usr := rscs[1].(*corev1.User)
+	assert.Equal(t, "alice", usr.Metadata.Name)
+	assert.Equal(t, "ALICE@prod.example.com", usr.Spec.Email)
+
+	{
+		_, err := RenderDocuments(filepath.Join(dir, "base"), &RenderOpts{
+			Values: map[string]any{
+				"user": "alice",
+			},
+		})
+		assert.NotNil(t, err, "undefined variables must fail")
+	}
+
+	{
+		writeTestFile(t, filepath.Join(dir, "overlay2", "usr.yaml"), `
+kind: User
+metadata:
+  name: bob
+spec:
+  type: WORKLOAD
+`)
+		_, err := RenderDocuments(filepath.Join(dir, "base"), &RenderOpts{
+			Values:   values,
+			Overlays: []string{filepath.Join(dir, "overlay2")},
+		})
+		assert.NotNil(t, err, "overlays must target existing documents")
+	}
+
+	{
+		_, err := LoadValues(nil, []string{"invalid"})
+		assert.NotNil(t, err)
+	}
+}
@@ -36,35 +36,51 @@ func LoadCoreResources(fPath string) ([]umetav1.ResourceObjectI, error) {
 
 func LoadResources(fPath string, newObjFn func(kind string) (umetav1.ResourceObjectI, error)) ([]umetav1.ResourceObjectI, error) {
 	var ret []umetav1.ResourceObjectI
-	var err error
+
+	if err := walkFiles(fPath, func(_ string, r io.Reader) error {
+		fileRet, err := loadResources(r, newObjFn)
+		if err != nil {
+			return err
+		}
+
+		ret = append(ret, fileRet...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// walkFiles calls fn for fPath if it is a regular file, for stdin if it is
+// "-" or for every yaml file found recursively if it is a directory.
+func walkFiles(fPath string, fn func(path string, r io.Reader) error) error {
 	if fPath == "" {
-		return ret, nil
+		return nil
 	}
 
 	if fPath == "-" {
-		return loadResources(os.Stdin, newObjFn)
+		return fn(fPath, os.Stdin)
 	}
 
 	pathInfo, err := os.Stat(fPath)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	switch {
 	case pathInfo.Mode().IsRegular():
 		f, err := os.Open(fPath)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		defer f.Close()
 
-		ret, err = loadResources(f, newObjFn)
-		if err != nil {
-			return nil, err
-		}
+		return fn(fPath, f)
 
 	case pathInfo.IsDir():
 
-		if err := filepath.Walk(fPath,
+		return filepath.Walk(fPath,
 			func(path string, info os.FileInfo, err error) error {
 				if err != nil {
 					return err
@@ -90,21 +106,13 @@ func LoadResources(fPath string, newObjFn func(kind string) (umetav1.ResourceObj
 				if err != nil {
 					return err
 				}
+				defer f.Close()
 
-				fileRet, err := loadResources(f, newObjFn)
-				if err != nil {
-					return err
-				}
-
-				ret = append(ret, fileRet...)
-
-				return nil
-			}); err != nil {
-			return nil, err
-		}
+				return fn(path, f)
+			})
 	}
 
-	return ret, nil
+	return nil
 }
 
 func loadResources(r io.Reader, newObjFn func(kind string) (umetav1.ResourceObjectI, error)) ([]umetav1.ResourceObjectI, error) {
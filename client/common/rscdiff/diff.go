@@ -18,67 +18,53 @@ import (
 	"context"
 	"fmt"
 	"reflect"
-	"strings"
 
 	"github.com/octelium/octelium/apis/main/corev1"
 	"github.com/octelium/octelium/apis/main/metav1"
 	"github.com/octelium/octelium/client/common/cliutils"
 	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
 	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/objdiff"
 	"github.com/octelium/octelium/pkg/common/pbutils"
 	"github.com/octelium/octelium/pkg/grpcerr"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-func (c *diffCtl) isEqual(a, b umetav1.ResourceObjectI) bool {
-	return pbutils.IsEqual(c.getCmpMetadata(a), c.getCmpMetadata(b)) &&
-		pbutils.IsEqual(getFieldSpec(a), getFieldSpec(b))
-}
-
-func (c *diffCtl) getCmpMetadata(itm umetav1.ResourceObjectI) *metav1.Metadata {
-	md := itm.GetMetadata()
-	return &metav1.Metadata{
-		Name:        c.getFullName(itm),
-		DisplayName: md.DisplayName,
-		Labels:      md.Labels,
-		Description: md.Description,
-		Annotations: md.Annotations,
-		PicURL:      md.PicURL,
+func DiffCoreResource(ctx context.Context,
+	kind string, conn *grpc.ClientConn, desiredItems []umetav1.ResourceObjectI, doDelete bool) (*DiffCtlResponse, error) {
+	ctl, err := newCoreDiffCtl(kind, conn, desiredItems, doDelete)
+	if err != nil {
+		return nil, err
 	}
-}
 
-func (c *diffCtl) getFullName(itm umetav1.ResourceObjectI) string {
-	name := itm.GetMetadata().Name
-	switch c.api {
-	case ucorev1.API:
-		switch itm.GetKind() {
-		case ucorev1.KindService:
-			if len(strings.Split(name, ".")) == 1 {
-				return fmt.Sprintf("%s.default", name)
-			}
-		}
-
-	}
-	return itm.GetMetadata().Name
+	return ctl.Run(ctx)
 }
 
-func DiffCoreResource(ctx context.Context,
-	kind string, conn *grpc.ClientConn, desiredItems []umetav1.ResourceObjectI, doDelete bool) (*DiffCtlResponse, error) {
-	ctl, err := NewDiffCtl("core", kind, corev1.NewMainServiceClient(conn),
+func newCoreDiffCtl(kind string, conn *grpc.ClientConn,
+	desiredItems []umetav1.ResourceObjectI, doDelete bool) (*diffCtl, error) {
+	return NewDiffCtl("core", kind, corev1.NewMainServiceClient(conn),
 		func() (umetav1.ResourceObjectI, error) {
 			return ucorev1.NewObject(kind)
 		}, func() (protoreflect.ProtoMessage, error) {
 			return ucorev1.NewObjectListOptions(kind)
 		}, desiredItems, doDelete)
+}
+
+// ListCoreResources returns all the non-System Resources of the kind.
+func ListCoreResources(ctx context.Context, kind string, conn *grpc.ClientConn) ([]umetav1.ResourceObjectI, error) {
+	ctl, err := newCoreDiffCtl(kind, conn, nil, false)
 	if err != nil {
 		return nil, err
 	}
 
-	return ctl.Run(ctx)
+	if err := ctl.setCurrentItems(ctx); err != nil {
+		return nil, err
+	}
+
+	return ctl.currentItems, nil
 }
 
 type diffCtl struct {
@@ -92,6 +78,8 @@ type diffCtl struct {
 	updateItems []umetav1.ResourceObjectI
 	deleteItems []umetav1.ResourceObjectI
 
+	diff *objdiff.Diff
+
 	doDelete bool
 
 	getNewObjectFn         func() (umetav1.ResourceObjectI, error)
@@ -184,32 +172,6 @@ func isUserError(err error) bool {
 		grpcerr.AlreadyExists(err) || grpcerr.IsResourceChanged(err)
 }
 
-func getFieldSpec(item umetav1.ResourceObjectI) proto.Message {
-	var spec protoreflect.Value
-
-	item.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
-		if fd.Name() == "spec" {
-			spec = v
-		}
-		return true
-	})
-
-	return spec.Message().Interface()
-}
-
-func hasFieldData(item umetav1.ResourceObjectI) bool {
-	var ret bool
-
-	item.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
-		if fd.Name() == "data" {
-			ret = true
-		}
-		return true
-	})
-
-	return ret
-}
-
 func (c *diffCtl) setCurrentItems(ctx context.Context) error {
 
 	hasMore := true
@@ -306,67 +268,15 @@ func (c *diffCtl) setCurrentItems(ctx context.Context) error {
 }
 
 func (c *diffCtl) setDiff() {
+	diff := objdiff.Compute(c.api, c.desiredItems, c.currentItems)
+	c.diff = diff
 
-	c.createItems = c.getDiffCreate(c.desiredItems, c.currentItems)
-	c.updateItems = c.getDiffUpdate(c.desiredItems, c.currentItems)
-	c.deleteItems = c.getDiffDelete(c.desiredItems, c.currentItems)
-}
-
-func (c *diffCtl) getDiffCreate(desiredItems, currentItems []umetav1.ResourceObjectI) []umetav1.ResourceObjectI {
-	var ret []umetav1.ResourceObjectI
-	for _, itm := range desiredItems {
-		if !c.isInList(currentItems, itm) {
-			ret = append(ret, itm)
-		}
-	}
-
-	return ret
-}
-
-func (c *diffCtl) getDiffUpdate(desiredItems, currentItems []umetav1.ResourceObjectI) []umetav1.ResourceObjectI {
-	var ret []umetav1.ResourceObjectI
-	for _, itm := range desiredItems {
-		cur := c.getInList(currentItems, itm)
-		if cur == nil {
-			continue
-		}
-
-		if hasFieldData(itm) {
-			ret = append(ret, itm)
-		} else if !c.isEqual(itm, cur) {
-			ret = append(ret, itm)
-		}
+	c.createItems = diff.Create
+	c.updateItems = nil
+	for _, itm := range diff.Update {
+		c.updateItems = append(c.updateItems, itm.Desired)
 	}
-
-	return ret
-}
-
-func (c *diffCtl) getDiffDelete(desiredItems, currentItems []umetav1.ResourceObjectI) []umetav1.ResourceObjectI {
-	var ret []umetav1.ResourceObjectI
-	for _, itm := range currentItems {
-		if !c.isInList(desiredItems, itm) {
-			ret = append(ret, itm)
-		}
-	}
-	return ret
-}
-
-func (c *diffCtl) isInList(lst []umetav1.ResourceObjectI, cur umetav1.ResourceObjectI) bool {
-	for _, itm := range lst {
-		if c.getFullName(itm) == c.getFullName(cur) {
-			return true
-		}
-	}
-	return false
-}
-
-func (c *diffCtl) getInList(lst []umetav1.ResourceObjectI, cur umetav1.ResourceObjectI) umetav1.ResourceObjectI {
-	for _, itm := range lst {
-		if c.getFullName(itm) == c.getFullName(cur) {
-			return itm
-		}
-	}
-	return nil
+	c.deleteItems = diff.Delete
 }
 
 func (c diffCtl) doCreateItem(ctx context.Context, item umetav1.ResourceObjectI) error {
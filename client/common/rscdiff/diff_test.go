@@ -91,3 +91,82 @@ func TestDiff(t *testing.T) {
 	assert.Equal(t, "svc1", diffCtl.updateItems[0].GetMetadata().Name)
 	assert.Equal(t, "ns1", diffCtl.deleteItems[0].GetMetadata().Name)
 }
+
+func TestPlan(t *testing.T) {
+	currentItems := []umetav1.ResourceObjectI{
+		&corev1.User{
+			Metadata: &metav1.Metadata{
+				Name: "usr1",
+			},
+			Spec: &corev1.User_Spec{
+				Type: corev1.User_Spec_HUMAN,
+			},
+		},
+		&corev1.User{
+			Metadata: &metav1.Metadata{
+				Name: "usr3",
+			},
+			Spec: &corev1.User_Spec{
+				Type: corev1.User_Spec_HUMAN,
+			},
+		},
+	}
+
+	desiredItems := []umetav1.ResourceObjectI{
+		&corev1.User{
+			Metadata: &metav1.Metadata{
+				Name: "usr1",
+			},
+			Spec: &corev1.User_Spec{
+				Type: corev1.User_Spec_WORKLOAD,
+			},
+		},
+		&corev1.User{
+			Metadata: &metav1.Metadata{
+				Name: "usr2",
+			},
+			Spec: &corev1.User_Spec{
+				Type: corev1.User_Spec_HUMAN,
+			},
+		},
+	}
+
+	{
+		diffCtl := &diffCtl{
+			kind:         "User",
+			currentItems: currentItems,
+			desiredItems: desiredItems,
+		}
+		diffCtl.setDiff()
+
+		items, err := diffCtl.getPlanItems()
+		assert.Nil(t, err)
+		assert.Len(t, items, 2)
+
+		assert.Equal(t, ActionCreate, items[0].Action)
+		assert.Equal(t, "usr2", items[0].Name)
+
+		assert.Equal(t, ActionUpdate, items[1].Action)
+		assert.Equal(t, "usr1", items[1].Name)
+		assert.Len(t, items[1].Changes, 1)
+		assert.Equal(t, "spec.type", items[1].Changes[0].Path)
+		assert.Equal(t, "HUMAN", items[1].Changes[0].Old)
+		assert.Equal(t, "WORKLOAD", items[1].Changes[0].New)
+	}
+
+	{
+		diffCtl := &diffCtl{
+			kind:         "User",
+			currentItems: currentItems,
+			desiredItems: desiredItems,
+			doDelete:     true,
+		}
+		diffCtl.setDiff()
+
+		items, err := diffCtl.getPlanItems()
+		assert.Nil(t, err)
+		assert.Len(t, items, 3)
+		assert.Equal(t, ActionDelete, items[2].Action)
+		assert.Equal(t, "usr3", items[2].Name)
+	}
+}
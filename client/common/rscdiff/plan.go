@@ -0,0 +1,103 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rscdiff
+
+import (
+	"context"
+
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/objdiff"
+	"google.golang.org/grpc"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+type PlanItem struct {
+	Kind    string                 `json:"kind"`
+	Name    string                 `json:"name"`
+	Action  string                 `json:"action"`
+	Changes []*objdiff.FieldChange `json:"changes,omitempty"`
+}
+
+// PlanCoreResource computes the changes that DiffCoreResource would apply
+// for the kind without applying them.
+func PlanCoreResource(ctx context.Context,
+	kind string, conn *grpc.ClientConn, desiredItems []umetav1.ResourceObjectI, doDelete bool) ([]*PlanItem, error) {
+	ctl, err := newCoreDiffCtl(kind, conn, desiredItems, doDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctl.Plan(ctx)
+}
+
+// Plan returns the changes that Run would apply.
+func (c *diffCtl) Plan(ctx context.Context) ([]*PlanItem, error) {
+	if err := c.setCurrentItems(ctx); err != nil {
+		return nil, err
+	}
+
+	c.setDiff()
+
+	return c.getPlanItems()
+}
+
+func (c *diffCtl) getPlanItems() ([]*PlanItem, error) {
+	var ret []*PlanItem
+
+	for _, itm := range c.diff.Create {
+		changes, err := objdiff.GetFieldChanges(c.api, nil, itm)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, &PlanItem{
+			Kind:    c.kind,
+			Name:    objdiff.GetFullName(c.api, itm),
+			Action:  ActionCreate,
+			Changes: changes,
+		})
+	}
+
+	for _, itm := range c.diff.Update {
+		changes, err := objdiff.GetFieldChanges(c.api, itm.Current, itm.Desired)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, &PlanItem{
+			Kind:    c.kind,
+			Name:    objdiff.GetFullName(c.api, itm.Desired),
+			Action:  ActionUpdate,
+			Changes: changes,
+		})
+	}
+
+	if c.doDelete {
+		for _, itm := range c.diff.Delete {
+			ret = append(ret, &PlanItem{
+				Kind:   c.kind,
+				Name:   objdiff.GetFullName(c.api, itm),
+				Action: ActionDelete,
+			})
+		}
+	}
+
+	return ret, nil
+}
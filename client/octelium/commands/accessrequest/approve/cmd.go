@@ -0,0 +1,73 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approve
+
+import (
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/spf13/cobra"
+)
+
+type args struct {
+	Reason string
+}
+
+var cmdArgs args
+
+var Cmd = &cobra.Command{
+	Use:   "approve [NAME]",
+	Short: "Approve a pending AccessRequest",
+	Example: `
+octelium access-request approve alice-p4wbr9kc
+octelium ar approve alice-p4wbr9kc --reason "Tracked in INC-1234"
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+func init() {
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Reason, "reason", "", "An optional comment on the decision")
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := userv1.NewMainServiceClient(conn)
+
+	if _, err := c.ApproveAccessRequest(ctx, &userv1.ApproveAccessRequestRequest{
+		Name:   i.FirstArg(),
+		Reason: cmdArgs.Reason,
+	}); err != nil {
+		return err
+	}
+
+	cliutils.LineNotify("AccessRequest %s successfully approved\n", i.FirstArg())
+
+	return nil
+}
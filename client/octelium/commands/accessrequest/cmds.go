@@ -0,0 +1,34 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"github.com/octelium/octelium/client/octelium/commands/accessrequest/approve"
+	"github.com/octelium/octelium/client/octelium/commands/accessrequest/create"
+	"github.com/octelium/octelium/client/octelium/commands/accessrequest/deny"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "access-request",
+	Short:   "Request just-in-time access to Services and decide AccessRequests",
+	Aliases: []string{"ar", "accessrequest"},
+}
+
+func AddSubcommands() {
+	Cmd.AddCommand(create.Cmd)
+	Cmd.AddCommand(approve.Cmd)
+	Cmd.AddCommand(deny.Cmd)
+}
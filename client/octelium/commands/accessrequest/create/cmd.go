@@ -0,0 +1,115 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package create
+
+import (
+	"time"
+
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type args struct {
+	Namespace     string
+	Duration      string
+	Justification string
+}
+
+var cmdArgs args
+
+const example = `
+octelium access-request create db.production --justification "Fixing a failed migration"
+octelium ar create db.production --duration 2h -j "Investigating an incident"
+octelium ar create --namespace production --duration 30m -j "Deploying a hotfix"
+`
+
+var Cmd = &cobra.Command{
+	Use:     "create [SERVICE]",
+	Short:   "Request just-in-time access to a Service or a Namespace",
+	Example: example,
+	Aliases: []string{"new"},
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+func init() {
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Namespace, "namespace", "n", "", "Request access to all the Services of a Namespace")
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Duration, "duration", "d", "", "The duration of the access (e.g. 30m, 2h). The default is 1 hour")
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Justification, "justification", "j", "", "The reason of the request")
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	if i.FirstArg() == "" && cmdArgs.Namespace == "" {
+		return errors.Errorf("You must set either a Service or a Namespace")
+	}
+
+	if cmdArgs.Justification == "" {
+		return errors.Errorf("You must provide a justification")
+	}
+
+	req := &userv1.AccessRequest{
+		Spec: &userv1.AccessRequest_Spec{
+			Service:       i.FirstArg(),
+			Namespace:     cmdArgs.Namespace,
+			Justification: cmdArgs.Justification,
+		},
+	}
+
+	if cmdArgs.Duration != "" {
+		d, err := time.ParseDuration(cmdArgs.Duration)
+		if err != nil {
+			return errors.Errorf("Invalid duration: %s", cmdArgs.Duration)
+		}
+		if d < time.Minute {
+			return errors.Errorf("The duration must be at least 1 minute")
+		}
+
+		req.Spec.Duration = &metav1.Duration{
+			Type: &metav1.Duration_Minutes{
+				Minutes: uint32(d.Minutes()),
+			},
+		}
+	}
+
+	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := userv1.NewMainServiceClient(conn)
+
+	res, err := c.CreateAccessRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	cliutils.LineNotify("AccessRequest %s successfully created and is now pending approval\n", res.Metadata.Name)
+
+	return nil
+}
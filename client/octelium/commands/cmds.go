@@ -20,13 +20,18 @@ import (
 	"github.com/octelium/octelium/client/common/commands/login"
 	"github.com/octelium/octelium/client/common/commands/logout"
 	"github.com/octelium/octelium/client/common/commands/version"
+	"github.com/octelium/octelium/client/octelium/commands/accessrequest"
 	"github.com/octelium/octelium/client/octelium/commands/connect"
 	"github.com/octelium/octelium/client/octelium/commands/cp"
+	"github.com/octelium/octelium/client/octelium/commands/daemon"
 	"github.com/octelium/octelium/client/octelium/commands/disconnect"
 	"github.com/octelium/octelium/client/octelium/commands/get"
+	"github.com/octelium/octelium/client/octelium/commands/kube"
+	"github.com/octelium/octelium/client/octelium/commands/portforward"
 	"github.com/octelium/octelium/client/octelium/commands/serviceconfig"
 	"github.com/octelium/octelium/client/octelium/commands/ssh"
 	"github.com/octelium/octelium/client/octelium/commands/status"
+	"github.com/octelium/octelium/client/octelium/commands/tui"
 	"github.com/spf13/cobra"
 )
 
@@ -48,17 +53,24 @@ func InitCmds() {
 	Cmd.AddCommand(disconnect.Cmd)
 	Cmd.AddCommand(get.Cmd)
 	Cmd.AddCommand(cp.Cmd)
+	Cmd.AddCommand(portforward.Cmd)
 	Cmd.AddCommand(ssh.Cmd)
 	Cmd.AddCommand(version.Cmd)
 	Cmd.AddCommand(status.Cmd)
 	Cmd.AddCommand(serviceconfig.Cmd)
 	Cmd.AddCommand(auth.Cmd)
+	Cmd.AddCommand(accessrequest.Cmd)
+	Cmd.AddCommand(daemon.Cmd)
+	Cmd.AddCommand(kube.Cmd)
+	Cmd.AddCommand(tui.Cmd)
 
 	Cmd.AddCommand(login.Cmd)
 	Cmd.AddCommand(logout.Cmd)
 
 	get.AddSubcommands()
 	auth.AddSubcommands()
+	accessrequest.AddSubcommands()
+	kube.AddSubcommands()
 }
 
 func init() {
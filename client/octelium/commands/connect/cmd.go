@@ -23,6 +23,9 @@ import (
 	"github.com/octelium/octelium/client/common/authenticator"
 	"github.com/octelium/octelium/client/common/cliutils"
 	"github.com/octelium/octelium/client/common/cliutils/vhome"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+	"github.com/octelium/octelium/client/octelium/commands/daemon/daemonc"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -50,6 +53,9 @@ type args struct {
 	LocalDNSListenAddr string
 
 	TunnelMode string
+
+	NoDaemon    bool
+	UseNetstack bool
 }
 
 var example = `
@@ -83,6 +89,10 @@ octelium connect --detach --auth-token <AUTHENTICATION_TOKEN>
 
 # Connect using IPv4 only and skip using the Cluster DNS
 octelium connect --ip-mode v4 --no-dns
+
+# Connect to multiple Clusters at the same time
+octelium connect -d --domain example.com
+octelium connect -d --domain example.org
 `
 
 var Cmd = &cobra.Command{
@@ -144,10 +154,12 @@ You can also use multiple scopes in the same command as follows "--scope service
 	and "quicv0" which uses QUIC. Currently "quicv0" is experimental and not suitable for production environments`)
 
 	Cmd.PersistentFlags().BoolVar(&cmdArgs.UseESOCKS5, "esocks5", false, "Run embedded SOCKS5 server")
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.NoDaemon, "no-daemon", false,
+		"Run the connection in this process even if the octelium daemon is running")
 }
 
-func isQUICV0() bool {
-	return cmdArgs.TunnelMode == "quicv0" || os.Getenv("OCTELIUM_QUIC") == "true"
+func (o *args) isQUICV0() bool {
+	return o.TunnelMode == "quicv0" || os.Getenv("OCTELIUM_QUIC") == "true"
 }
 
 func doCmd(cmd *cobra.Command, args []string) error {
@@ -158,6 +170,11 @@ func doCmd(cmd *cobra.Command, args []string) error {
 
 	domain := i.Domain
 
+	if conn, err := connreg.New().Get(domain); err == nil {
+		return errors.Errorf("There is already an active connection to the Cluster %s on this host (pid %d)",
+			domain, conn.PID)
+	}
+
 	ctx := context.Background()
 
 	authOpts := &authenticator.AuthenticateOpts{
@@ -183,6 +200,13 @@ func doCmd(cmd *cobra.Command, args []string) error {
 		}
 	*/
 
+	if !cmdArgs.NoDaemon {
+		if c, err := daemonc.GetClient(ctx); err == nil {
+			defer c.Close()
+			return connectViaDaemon(ctx, c, domain)
+		}
+	}
+
 	if cmdArgs.Detached {
 		return runDetached(cmd, domain)
 	}
@@ -198,11 +222,11 @@ func doCmd(cmd *cobra.Command, args []string) error {
 
 func runDetached(cmd *cobra.Command, domain string) error {
 
-	args := []string{"connect", fmt.Sprintf("--domain=%s", domain)}
+	args := []string{"connect", fmt.Sprintf("--domain=%s", domain), "--no-daemon"}
 
 	cmd.Flags().VisitAll(func(f *pflag.Flag) {
 		switch f.Name {
-		case "detach", "homedir", "domain", "auth-token", "assertion":
+		case "detach", "homedir", "domain", "auth-token", "assertion", "no-daemon":
 			return
 		}
 		if !f.Changed {
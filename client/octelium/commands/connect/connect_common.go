@@ -30,6 +30,7 @@ import (
 	"github.com/octelium/octelium/apis/main/userv1"
 	"github.com/octelium/octelium/client/common/client"
 	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
 	"github.com/octelium/octelium/client/octelium/commands/connect/controller"
 	"github.com/octelium/octelium/client/octelium/commands/connect/l3mode"
 	"github.com/octelium/octelium/client/octelium/commands/connect/pprofsrv"
@@ -48,16 +49,16 @@ const (
 	defaultESOCKS5Port = 1080
 )
 
-func sendInitializeRequest(streamC userv1.MainService_ConnectClient,
+func sendInitializeRequest(o *args, streamC userv1.MainService_ConnectClient,
 	publishedServices []*cliconfigv1.Connection_Preferences_PublishedService) error {
 
-	l3Mode, err := l3mode.GetL3Mode(cmdArgs.L3Mode)
+	l3Mode, err := l3mode.GetL3Mode(o.L3Mode)
 	if err != nil {
 		return err
 	}
 
 	var servedServices []*userv1.ConnectRequest_Initialize_ServiceOptions_Service
-	for _, svcStr := range cmdArgs.ServeServices {
+	for _, svcStr := range o.ServeServices {
 		_, err := cliutils.ParseServiceNamespace(svcStr)
 		if err != nil {
 			return err
@@ -72,7 +73,7 @@ func sendInitializeRequest(streamC userv1.MainService_ConnectClient,
 		Type: &userv1.ConnectRequest_Initialize_{
 			Initialize: &userv1.ConnectRequest_Initialize{
 				ConnectionType: func() userv1.ConnectRequest_Initialize_ConnectionType {
-					if isQUICV0() {
+					if o.isQUICV0() {
 						return userv1.ConnectRequest_Initialize_QUICV0
 					}
 					return userv1.ConnectRequest_Initialize_UNSET
@@ -90,17 +91,17 @@ func sendInitializeRequest(streamC userv1.MainService_ConnectClient,
 					return ret
 				}(),
 
-				IgnoreDNS: cmdArgs.IgnoreDNS,
+				IgnoreDNS: o.IgnoreDNS,
 
 				ServiceOptions: func() *userv1.ConnectRequest_Initialize_ServiceOptions {
-					if cmdArgs.ServeAll {
+					if o.ServeAll {
 						return &userv1.ConnectRequest_Initialize_ServiceOptions{
 							ServeAll:  true,
 							PortStart: int32(utilrand.GetRandomRangeMath(20000, 24000)),
 						}
 					}
 
-					if len(cmdArgs.ServeServices) > 0 {
+					if len(o.ServeServices) > 0 {
 						return &userv1.ConnectRequest_Initialize_ServiceOptions{
 							Services:  servedServices,
 							PortStart: int32(utilrand.GetRandomRangeMath(20000, 24000)),
@@ -109,7 +110,7 @@ func sendInitializeRequest(streamC userv1.MainService_ConnectClient,
 
 					return nil
 				}(),
-				ESSHEnable: cmdArgs.UseESSH || os.Getenv("OCTELIUM_ESSH") == "true",
+				ESSHEnable: o.UseESSH || os.Getenv("OCTELIUM_ESSH") == "true",
 				ESSHPort: func() int32 {
 					if port, err := strconv.ParseInt(os.Getenv("OCTELIUM_ESSH_PORT"), 10, 32); err == nil {
 						return int32(port)
@@ -117,7 +118,7 @@ func sendInitializeRequest(streamC userv1.MainService_ConnectClient,
 
 					return defaultESSHPort
 				}(),
-				ESOCKS5Enable: cmdArgs.UseESOCKS5 || os.Getenv("OCTELIUM_ESOCKS5") == "true",
+				ESOCKS5Enable: o.UseESOCKS5 || os.Getenv("OCTELIUM_ESOCKS5") == "true",
 				ESOCKS5Port: func() int32 {
 					if port, err := strconv.ParseInt(os.Getenv("OCTELIUM_ESOCKS5_PORT"), 10, 32); err == nil {
 						return int32(port)
@@ -173,6 +174,7 @@ func getStateMsg(ctx context.Context, streamC userv1.MainService_ConnectClient)
 }
 
 func getConnectionConfig(ctx context.Context,
+	o *args,
 	c userv1.MainServiceClient,
 	streamC userv1.MainService_ConnectClient,
 	publishedSevices []*cliconfigv1.Connection_Preferences_PublishedService,
@@ -197,14 +199,15 @@ func getConnectionConfig(ctx context.Context,
 			DeviceName:  fmt.Sprintf("octelium-%s", utilrand.GetRandomStringLowercase(6)),
 
 			ConnectionType: func() cliconfigv1.Connection_Preferences_ConnectionType {
-				if isQUICV0() {
+				if o.isQUICV0() {
 					return cliconfigv1.Connection_Preferences_CONNECTION_TYPE_QUICV0
 				}
 				return cliconfigv1.Connection_Preferences_CONNECTION_TYPE_UNSET
 			}(),
 
-			IgnoreDNS:         cmdArgs.IgnoreDNS,
+			IgnoreDNS:         o.IgnoreDNS,
 			PublishedServices: publishedSevices,
+			EnforceNetstack:   o.UseNetstack,
 			KeepAliveSeconds: func() int32 {
 				ka, err := strconv.ParseInt(os.Getenv("OCTELIUM_KEEPALIVE"), 10, 32)
 				if err != nil {
@@ -239,7 +242,7 @@ func getConnectionConfig(ctx context.Context,
 
 			ServeOpts: &cliconfigv1.Connection_Preferences_ServeOpts{
 				IsEnabled: func() bool {
-					if cmdArgs.ServeAll || len(cmdArgs.ServeServices) > 0 {
+					if o.ServeAll || len(o.ServeServices) > 0 {
 						return true
 					}
 					return false
@@ -247,10 +250,10 @@ func getConnectionConfig(ctx context.Context,
 				ProxyMode: cliconfigv1.Connection_Preferences_ServeOpts_USERSPACE,
 			},
 			ESSH: &cliconfigv1.Connection_Preferences_ESSH{
-				IsEnabled: cmdArgs.UseESSH || os.Getenv("OCTELIUM_ESSH") == "true",
+				IsEnabled: o.UseESSH || os.Getenv("OCTELIUM_ESSH") == "true",
 				User: func() string {
-					if cmdArgs.ESSHUser != "" {
-						return cmdArgs.ESSHUser
+					if o.ESSHUser != "" {
+						return o.ESSHUser
 					}
 					if val := os.Getenv("OCTELIUM_ESSH_USER"); val != "" {
 						return val
@@ -283,7 +286,7 @@ func getConnectionConfig(ctx context.Context,
 			},
 
 			ESOCKS5: &cliconfigv1.Connection_Preferences_ESOCKS5{
-				IsEnabled: cmdArgs.UseESOCKS5 || os.Getenv("OCTELIUM_ESOCKS5") == "true",
+				IsEnabled: o.UseESOCKS5 || os.Getenv("OCTELIUM_ESOCKS5") == "true",
 				Port: func() int32 {
 					if port, err := strconv.ParseInt(os.Getenv("OCTELIUM_ESOCKS5_PORT"), 10, 32); err == nil {
 						return int32(port)
@@ -309,15 +312,15 @@ func getConnectionConfig(ctx context.Context,
 			},
 
 			LocalDNS: &cliconfigv1.Connection_Preferences_LocalDNS{
-				IsEnabled: cmdArgs.UseLocalDNS || os.Getenv("OCTELIUM_LOCAL_DNS_SERVER") == "true" ||
+				IsEnabled: o.UseLocalDNS || os.Getenv("OCTELIUM_LOCAL_DNS_SERVER") == "true" ||
 					os.Getenv("OCTELIUM_CONTAINER_MODE") == "true",
 				ListenAddress: func() string {
-					if cmdArgs.LocalDNSListenAddr != "" {
-						if _, _, err := net.SplitHostPort(cmdArgs.LocalDNSListenAddr); err == nil {
-							return cmdArgs.LocalDNSListenAddr
+					if o.LocalDNSListenAddr != "" {
+						if _, _, err := net.SplitHostPort(o.LocalDNSListenAddr); err == nil {
+							return o.LocalDNSListenAddr
 						}
-						if govalidator.IsIP(cmdArgs.LocalDNSListenAddr) {
-							return net.JoinHostPort(cmdArgs.LocalDNSListenAddr, "53")
+						if govalidator.IsIP(o.LocalDNSListenAddr) {
+							return net.JoinHostPort(o.LocalDNSListenAddr, "53")
 						}
 						return ""
 					}
@@ -335,7 +338,7 @@ func getConnectionConfig(ctx context.Context,
 	case cliutils.IsLinux():
 		connCfg.Preferences.LinuxPrefs = &cliconfigv1.Connection_Preferences_Linux{
 			ImplementationMode: func() cliconfigv1.Connection_Preferences_Linux_ImplementationMode {
-				switch cmdArgs.ImplementationMode {
+				switch o.ImplementationMode {
 				case "kernel":
 					return cliconfigv1.Connection_Preferences_Linux_WG_KERNEL
 				case "tun":
@@ -346,11 +349,10 @@ func getConnectionConfig(ctx context.Context,
 					return cliconfigv1.Connection_Preferences_Linux_WG_KERNEL
 				}
 			}(),
-			EnforceImplementationMode: cmdArgs.ImplementationMode != "",
+			EnforceImplementationMode: o.ImplementationMode != "",
 		}
 	case cliutils.IsWindows():
 		connCfg.Preferences.WindowsPrefs = &cliconfigv1.Connection_Preferences_Windows{}
-		connCfg.Preferences.DeviceName = "octelium"
 	case cliutils.IsDarwin():
 		connCfg.Preferences.MacosPrefs = &cliconfigv1.Connection_Preferences_MacOS{}
 		connCfg.Preferences.DeviceName = "utun"
@@ -368,14 +370,14 @@ func getConnectionConfig(ctx context.Context,
 	return connCfg, initAt, nil
 }
 
-func getPublishedServices(ctx context.Context, c userv1.MainServiceClient, domain string) ([]*cliconfigv1.Connection_Preferences_PublishedService, error) {
+func getPublishedServices(ctx context.Context, o *args, c userv1.MainServiceClient, domain string) ([]*cliconfigv1.Connection_Preferences_PublishedService, error) {
 	var ret []*cliconfigv1.Connection_Preferences_PublishedService
 
-	for _, svc := range cmdArgs.PublishServices {
+	for _, svc := range o.PublishServices {
 		publishedService, err := doGetPublishedService(ctx, c, svc, domain)
 		if err != nil {
 			if grpcerr.IsUnimplemented(err) {
-				return getPublishedServicesWithList(ctx, c, domain)
+				return getPublishedServicesWithList(ctx, o, c, domain)
 			}
 			return nil, err
 		}
@@ -402,11 +404,6 @@ func doGetPublishedService(ctx context.Context,
 		return nil, err
 	}
 
-	switch svc.Spec.Type {
-	case userv1.Service_Spec_DNS, userv1.Service_Spec_UDP:
-		return nil, errors.Errorf("UDP-based published Services are currently unsupported.")
-	}
-
 	return &cliconfigv1.Connection_Preferences_PublishedService{
 		Fqdn:        fmt.Sprintf("%s.local.%s", svc.Metadata.Name, domain),
 		Name:        svc.Metadata.Name,
@@ -537,11 +534,110 @@ func connect(ctx context.Context, domain string) error {
 		defer srv.Close()
 	}
 
+	return doRun(ctx, domain, &cmdArgs, nil, nil)
+}
+
+type reporter struct {
+	reg            *connreg.Handle
+	onUpdate       func(*connreg.Connection)
+	onServiceEvent func(*userv1.ConnectResponse)
+
+	mu   sync.Mutex
+	conn *connreg.Connection
+}
+
+func (r *reporter) update(conn *connreg.Connection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.conn = conn
+	r.doUpdate(conn)
+}
+
+func (r *reporter) doUpdate(conn *connreg.Connection) {
+	if err := r.reg.Update(conn); err != nil {
+		zap.L().Debug("Could not update the connection record", zap.Error(err))
+	}
+
+	if r.onUpdate != nil {
+		r.onUpdate(conn)
+	}
+}
+
+// updateStats sets the traffic counters of the current record. Nothing is
+// reported if the counters have not changed since the last update.
+func (r *reporter) updateStats(stats *controller.Stats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn == nil ||
+		(r.conn.RxBytes == stats.RxBytes && r.conn.TxBytes == stats.TxBytes) {
+		return
+	}
+
+	conn := *r.conn
+	conn.RxBytes = stats.RxBytes
+	conn.TxBytes = stats.TxBytes
+	r.conn = &conn
+
+	r.doUpdate(&conn)
+}
+
+const statsInterval = 5 * time.Second
+
+func (r *reporter) runStatsLoop(ctx context.Context, devCtl *controller.Controller) {
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats, err := devCtl.GetStats()
+			if err != nil {
+				zap.L().Debug("Could not get the connection stats", zap.Error(err))
+				continue
+			}
+
+			r.updateStats(stats)
+		}
+	}
+}
+
+func doRun(ctx context.Context, domain string, o *args,
+	onUpdate func(*connreg.Connection), onServiceEvent func(*userv1.ConnectResponse)) error {
+	reg, err := connreg.New().Register(domain)
+	if err != nil {
+		if errors.Is(err, connreg.ErrAlreadyConnected) {
+			return errors.Errorf("There is already an active connection to the Cluster %s on this host", domain)
+		}
+		return err
+	}
+	defer reg.Close()
+
+	r := &reporter{
+		reg:            reg,
+		onUpdate:       onUpdate,
+		onServiceEvent: onServiceEvent,
+	}
+
+	var lastErr error
 	for {
+		r.update(&connreg.Connection{
+			State: connreg.StateConnecting,
+			LastError: func() string {
+				if lastErr == nil {
+					return ""
+				}
+				return lastErr.Error()
+			}(),
+		})
+
 		ret := make(chan tryConnectRet, 1)
 		doneCh := make(chan struct{})
 		go func() {
-			ret <- tryConnect(ctx, domain, doneCh)
+			ret <- tryConnect(ctx, domain, o, r, doneCh)
 		}()
 
 		select {
@@ -562,6 +658,7 @@ func connect(ctx context.Context, domain string) error {
 			}
 
 			time.Sleep(2 * time.Second)
+			lastErr = ret.err
 			if ret.err != nil {
 				err := ret.err
 
@@ -580,7 +677,7 @@ type tryConnectRet struct {
 	needsReconnect bool
 }
 
-func tryConnect(ctx context.Context, domain string, doneCh chan<- struct{}) tryConnectRet {
+func tryConnect(ctx context.Context, domain string, o *args, r *reporter, doneCh chan<- struct{}) tryConnectRet {
 
 	defer close(doneCh)
 
@@ -606,8 +703,8 @@ func tryConnect(ctx context.Context, domain string, doneCh chan<- struct{}) tryC
 
 	var publishedServices []*cliconfigv1.Connection_Preferences_PublishedService
 
-	if len(cmdArgs.PublishServices) > 0 {
-		publishedServices, err = getPublishedServices(ctx, c, domain)
+	if len(o.PublishServices) > 0 {
+		publishedServices, err = getPublishedServices(ctx, o, c, domain)
 		if err != nil {
 			return tryConnectRet{
 				err:            err,
@@ -625,14 +722,14 @@ func tryConnect(ctx context.Context, domain string, doneCh chan<- struct{}) tryC
 		}
 	}
 
-	if err := sendInitializeRequest(streamC, publishedServices); err != nil {
+	if err := sendInitializeRequest(o, streamC, publishedServices); err != nil {
 		return tryConnectRet{
 			err:            errors.Errorf("Could not send init request to API Server: %s", err),
 			needsReconnect: doNeedReconnect(err),
 		}
 	}
 
-	connCfg, initAt, err := getConnectionConfig(ctx, c, streamC, publishedServices, domain)
+	connCfg, initAt, err := getConnectionConfig(ctx, o, c, streamC, publishedServices, domain)
 	if err != nil {
 		return tryConnectRet{
 			err:            err,
@@ -640,6 +737,12 @@ func tryConnect(ctx context.Context, domain string, doneCh chan<- struct{}) tryC
 		}
 	}
 
+	if err := resolveConflicts(connCfg, getOtherConnections(domain)); err != nil {
+		return tryConnectRet{
+			err: err,
+		}
+	}
+
 	ctl, err := newCtl(ctx, streamC, connCfg, initAt)
 	if err != nil {
 		return tryConnectRet{
@@ -648,6 +751,8 @@ func tryConnect(ctx context.Context, domain string, doneCh chan<- struct{}) tryC
 		}
 	}
 
+	ctl.stateController.onServiceEvent = r.onServiceEvent
+
 	if err := ctl.start(ctx); err != nil {
 		zap.L().Warn("Could not start controller", zap.Error(err))
 		return tryConnectRet{
@@ -656,6 +761,15 @@ func tryConnect(ctx context.Context, domain string, doneCh chan<- struct{}) tryC
 		}
 	}
 
+	r.update(getRegistryConnection(connCfg, ctl.devCtl))
+
+	statsCtx, statsCancel := context.WithCancel(ctx)
+	statsDoneCh := make(chan struct{})
+	go func() {
+		defer close(statsDoneCh)
+		r.runStatsLoop(statsCtx, ctl.devCtl)
+	}()
+
 	needsReconnect := false
 	var retErr error
 	cliutils.LineNotify("Connected successfully...\n")
@@ -669,6 +783,9 @@ func tryConnect(ctx context.Context, domain string, doneCh chan<- struct{}) tryC
 		cliutils.LineInfo("Disconnected by API Server\n")
 	}
 
+	statsCancel()
+	<-statsDoneCh
+
 	ctl.close()
 
 	return tryConnectRet{
@@ -677,7 +794,7 @@ func tryConnect(ctx context.Context, domain string, doneCh chan<- struct{}) tryC
 	}
 }
 
-func getPublishedServicesWithList(ctx context.Context, c userv1.MainServiceClient, domain string) ([]*cliconfigv1.Connection_Preferences_PublishedService, error) {
+func getPublishedServicesWithList(ctx context.Context, o *args, c userv1.MainServiceClient, domain string) ([]*cliconfigv1.Connection_Preferences_PublishedService, error) {
 	var ret []*cliconfigv1.Connection_Preferences_PublishedService
 
 	zap.L().Debug("Listing available Services to publish ports")
@@ -686,7 +803,7 @@ func getPublishedServicesWithList(ctx context.Context, c userv1.MainServiceClien
 		return nil, err
 	}
 
-	for _, svc := range cmdArgs.PublishServices {
+	for _, svc := range o.PublishServices {
 		publishedService, err := doGetPublishedServiceWithList(svcList, svc, domain)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,330 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connreg
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ErrAlreadyConnected is returned by Register when another process on this host
+// is already connected to the same Cluster.
+var ErrAlreadyConnected = errors.Errorf("There is already an active connection to this Cluster")
+
+const (
+	StateConnecting = "connecting"
+	StateConnected  = "connected"
+)
+
+// Connection is the host-local record of an active `octelium connect` process.
+// It is used to avoid conflicts between concurrent connections to different
+// Clusters and to show them in `octelium status`.
+type Connection struct {
+	Domain          string    `json:"domain"`
+	PID             int       `json:"pid"`
+	State           string    `json:"state"`
+	DeviceName      string    `json:"deviceName,omitempty"`
+	Netstack        bool      `json:"netstack,omitempty"`
+	L3Mode          string    `json:"l3Mode,omitempty"`
+	Addresses       []string  `json:"addresses,omitempty"`
+	Routes          []string  `json:"routes,omitempty"`
+	DNSServers      []string  `json:"dnsServers,omitempty"`
+	LocalDNSAddress string    `json:"localDNSAddress,omitempty"`
+	StartedAt       time.Time `json:"startedAt"`
+	ConnectedAt     time.Time `json:"connectedAt,omitempty"`
+	LastError       string    `json:"lastError,omitempty"`
+	RxBytes         uint64    `json:"rxBytes,omitempty"`
+	TxBytes         uint64    `json:"txBytes,omitempty"`
+}
+
+type Registry struct {
+	dirs []string
+}
+
+func New() *Registry {
+	var dirs []string
+	switch {
+	case cliutils.IsWindows():
+		if programData := os.Getenv("ProgramData"); programData != "" {
+			dirs = append(dirs, filepath.Join(programData, "octelium", "connections"))
+		}
+	default:
+		dirs = append(dirs, filepath.Join("/run", "octelium", "connections"))
+	}
+
+	return &Registry{
+		dirs: append(dirs, filepath.Join(os.TempDir(), "octelium", "connections")),
+	}
+}
+
+type Handle struct {
+	domain    string
+	path      string
+	lock      *flock.Flock
+	startedAt time.Time
+}
+
+// Register marks the Cluster domain as connected by the current process until
+// the returned Handle is closed. A nil Handle is returned if there is no
+// writable directory for the records, in which case the connection proceeds
+// without being registered.
+func (r *Registry) Register(domain string) (*Handle, error) {
+	key, err := getKey(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if conn, err := r.Get(domain); err == nil && conn != nil {
+		return nil, ErrAlreadyConnected
+	}
+
+	for _, dir := range r.dirs {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			continue
+		}
+
+		lock := flock.New(filepath.Join(dir, key+".lock"), flock.SetPermissions(0644))
+		locked, err := lock.TryLock()
+		if err != nil {
+			zap.L().Debug("Could not try to acquire the connection lock",
+				zap.String("path", lock.Path()), zap.Error(err))
+			continue
+		}
+		if !locked {
+			return nil, ErrAlreadyConnected
+		}
+
+		return &Handle{
+			domain:    domain,
+			path:      filepath.Join(dir, key+".json"),
+			lock:      lock,
+			startedAt: time.Now(),
+		}, nil
+	}
+
+	zap.L().Debug("Could not create a connection record. Proceeding without registering the connection",
+		zap.String("domain", domain))
+
+	return nil, nil
+}
+
+// Update writes the current record of the connection.
+func (h *Handle) Update(conn *Connection) error {
+	if h == nil {
+		return nil
+	}
+
+	conn.Domain = h.domain
+	conn.PID = os.Getpid()
+	conn.StartedAt = h.startedAt
+
+	content, err := json.MarshalIndent(conn, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(h.path), filepath.Base(h.path)+".*")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, h.path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+// Close removes the record and releases the lock of the connection.
+func (h *Handle) Close() error {
+	if h == nil {
+		return nil
+	}
+
+	if err := os.Remove(h.path); err != nil && !os.IsNotExist(err) {
+		zap.L().Debug("Could not remove the connection record",
+			zap.String("path", h.path), zap.Error(err))
+	}
+
+	return h.lock.Close()
+}
+
+// List returns the connections of all the live `octelium connect` processes
+// sorted by their domain. Records left behind by processes that have exited
+// are skipped.
+func (r *Registry) List() ([]*Connection, error) {
+	var ret []*Connection
+	seen := make(map[string]bool)
+
+	for _, dir := range r.dirs {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, path := range paths {
+			conn, err := readConnection(path)
+			if err != nil {
+				zap.L().Debug("Could not read the connection record",
+					zap.String("path", path), zap.Error(err))
+				continue
+			}
+
+			if seen[conn.Domain] || !isLive(strings.TrimSuffix(path, ".json")+".lock") {
+				continue
+			}
+
+			seen[conn.Domain] = true
+			ret = append(ret, conn)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Domain < ret[j].Domain
+	})
+
+	return ret, nil
+}
+
+func (r *Registry) Get(domain string) (*Connection, error) {
+	conns, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, conn := range conns {
+		if conn.Domain == domain {
+			return conn, nil
+		}
+	}
+
+	return nil, errors.Errorf("There is no active connection to the Cluster %s", domain)
+}
+
+func readConnection(path string) (*Connection, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Connection{}
+	if err := json.Unmarshal(content, ret); err != nil {
+		return nil, err
+	}
+
+	if ret.Domain == "" {
+		return nil, errors.Errorf("Empty domain")
+	}
+
+	return ret, nil
+}
+
+func isLive(lockPath string) bool {
+	lock := flock.New(lockPath, flock.SetFlag(os.O_RDONLY))
+	locked, err := lock.TryRLock()
+	if err != nil {
+		return false
+	}
+
+	if locked {
+		lock.Close()
+		return false
+	}
+
+	return true
+}
+
+func getKey(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" || strings.ContainsAny(domain, `/\`) || strings.Contains(domain, "..") {
+		return "", errors.Errorf("Invalid Cluster domain: %q", domain)
+	}
+
+	return domain, nil
+}
+
+// FindOverlap returns the first connection to a different Cluster whose routes
+// overlap with the given CIDR.
+func FindOverlap(conns []*Connection, domain string, cidr string) *Connection {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil
+	}
+
+	for _, conn := range conns {
+		if conn.Domain == domain || conn.Netstack {
+			continue
+		}
+
+		for _, route := range conn.Routes {
+			_, r, err := net.ParseCIDR(route)
+			if err != nil {
+				continue
+			}
+
+			if r.Contains(n.IP) || n.Contains(r.IP) {
+				return conn
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetFreeAddress returns the first candidate that is not used as a local DNS
+// server address by any of the connections.
+func GetFreeAddress(conns []*Connection, candidates []string) string {
+	for _, candidate := range candidates {
+		used := false
+		for _, conn := range conns {
+			if conn.LocalDNSAddress == candidate {
+				used = true
+				break
+			}
+		}
+
+		if !used {
+			return candidate
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,125 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connreg
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := &Registry{
+		dirs: []string{t.TempDir()},
+	}
+
+	conns, err := r.List()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(conns))
+
+	h, err := r.Register("example.com")
+	assert.Nil(t, err)
+	assert.NotNil(t, h)
+
+	{
+		conns, err := r.List()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(conns))
+	}
+
+	err = h.Update(&Connection{
+		State:  StateConnected,
+		Routes: []string{"100.64.0.0/16"},
+	})
+	assert.Nil(t, err)
+
+	_, err = r.Register("example.com")
+	assert.ErrorIs(t, err, ErrAlreadyConnected)
+
+	h2, err := r.Register("example.org")
+	assert.Nil(t, err)
+	err = h2.Update(&Connection{
+		State: StateConnecting,
+	})
+	assert.Nil(t, err)
+
+	{
+		conns, err := r.List()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(conns))
+		assert.Equal(t, "example.com", conns[0].Domain)
+		assert.Equal(t, os.Getpid(), conns[0].PID)
+		assert.Equal(t, StateConnected, conns[0].State)
+		assert.False(t, conns[0].StartedAt.IsZero())
+		assert.Equal(t, "example.org", conns[1].Domain)
+	}
+
+	assert.Nil(t, h.Close())
+
+	{
+		conns, err := r.List()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(conns))
+		assert.Equal(t, "example.org", conns[0].Domain)
+
+		_, err = r.Get("example.com")
+		assert.NotNil(t, err)
+	}
+
+	h, err = r.Register("example.com")
+	assert.Nil(t, err)
+	assert.Nil(t, h.Close())
+	assert.Nil(t, h2.Close())
+
+	_, err = r.Register("../example.com")
+	assert.NotNil(t, err)
+}
+
+func TestFindOverlap(t *testing.T) {
+	conns := []*Connection{
+		{
+			Domain: "example.com",
+			Routes: []string{"100.64.0.0/16", "fdee:1:2:3::/80"},
+		},
+		{
+			Domain:   "example.net",
+			Netstack: true,
+			Routes:   []string{"10.0.0.0/8"},
+		},
+	}
+
+	assert.Equal(t, "example.com", FindOverlap(conns, "example.org", "100.64.0.0/16").Domain)
+	assert.Equal(t, "example.com", FindOverlap(conns, "example.org", "100.64.10.0/24").Domain)
+	assert.Equal(t, "example.com", FindOverlap(conns, "example.org", "100.0.0.0/8").Domain)
+	assert.Nil(t, FindOverlap(conns, "example.com", "100.64.0.0/16"))
+	assert.Nil(t, FindOverlap(conns, "example.org", "100.65.0.0/16"))
+	assert.Nil(t, FindOverlap(conns, "example.org", "fdee:1:2:4::/80"))
+	assert.Nil(t, FindOverlap(conns, "example.org", "10.0.0.0/16"))
+	assert.Nil(t, FindOverlap(conns, "example.org", "invalid"))
+}
+
+func TestGetFreeAddress(t *testing.T) {
+	conns := []*Connection{
+		{
+			Domain:          "example.com",
+			LocalDNSAddress: "127.0.0.100:53",
+		},
+	}
+
+	assert.Equal(t, "127.0.0.101:53",
+		GetFreeAddress(conns, []string{"127.0.0.100:53", "127.0.0.101:53"}))
+	assert.Equal(t, "", GetFreeAddress(conns, []string{"127.0.0.100:53"}))
+}
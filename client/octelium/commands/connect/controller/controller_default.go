@@ -28,7 +28,7 @@ import (
 func (c *Controller) InitDev(ctx context.Context) error {
 
 	zap.L().Debug("initializing dev")
-	if err := c.doInitDev(ctx); err != nil {
+	if err := c.initDev(ctx); err != nil {
 		return err
 	}
 
@@ -70,7 +70,7 @@ func (c *Controller) doStart(ctx context.Context) error {
 		return err
 	}
 
-	if err := c.doInitDev(ctx); err != nil {
+	if err := c.initDev(ctx); err != nil {
 		return errors.Errorf("Could not init dev: %+v", err)
 	}
 
@@ -18,11 +18,21 @@ import (
 	"context"
 	"fmt"
 
+	"go.uber.org/zap"
 	"golang.zx2c4.com/wireguard/conn"
 	"golang.zx2c4.com/wireguard/device"
 	"golang.zx2c4.com/wireguard/tun"
 )
 
+func (c *Controller) initDev(ctx context.Context) error {
+	if c.c.Preferences.EnforceNetstack {
+		zap.L().Debug("Enforcing gVisor netstack mode")
+		return c.doInitDevNetstack(ctx)
+	}
+
+	return c.doInitDev(ctx)
+}
+
 func (c *Controller) setDevUp() error {
 
 	return c.doSetDevUp()
@@ -0,0 +1,71 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+)
+
+// Stats are the traffic counters of the connection summed over all Gateways.
+type Stats struct {
+	RxBytes uint64
+	TxBytes uint64
+}
+
+// GetStats returns the current traffic counters of the WireGuard device.
+// QUIC-based connections currently do not report any traffic.
+func (c *Controller) GetStats() (*Stats, error) {
+	if c.isQUIC {
+		return &Stats{}, nil
+	}
+
+	if c.dev != nil {
+		uapiCfg, err := c.dev.IpcGet()
+		if err != nil {
+			return nil, err
+		}
+
+		return parseUAPIStats(uapiCfg), nil
+	}
+
+	return c.getDeviceStats()
+}
+
+func parseUAPIStats(uapiCfg string) *Stats {
+	ret := &Stats{}
+
+	scanner := bufio.NewScanner(strings.NewReader(uapiCfg))
+	for scanner.Scan() {
+		key, val, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "rx_bytes":
+			if n, err := strconv.ParseUint(val, 10, 64); err == nil {
+				ret.RxBytes += n
+			}
+		case "tx_bytes":
+			if n, err := strconv.ParseUint(val, 10, 64); err == nil {
+				ret.TxBytes += n
+			}
+		}
+	}
+
+	return ret
+}
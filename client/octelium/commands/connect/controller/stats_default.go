@@ -0,0 +1,39 @@
+//go:build !windows
+// +build !windows
+
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import "github.com/pkg/errors"
+
+func (c *Controller) getDeviceStats() (*Stats, error) {
+	if c.wgC == nil {
+		return nil, errors.Errorf("The WireGuard control client is not available")
+	}
+
+	dev, err := c.wgC.Device(c.c.Preferences.DeviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Stats{}
+	for _, peer := range dev.Peers {
+		ret.RxBytes += uint64(peer.ReceiveBytes)
+		ret.TxBytes += uint64(peer.TransmitBytes)
+	}
+
+	return ret, nil
+}
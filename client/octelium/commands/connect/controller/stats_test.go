@@ -0,0 +1,40 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUAPIStats(t *testing.T) {
+	assert.Equal(t, &Stats{}, parseUAPIStats(""))
+
+	assert.Equal(t, &Stats{
+		RxBytes: 1500,
+		TxBytes: 3000,
+	}, parseUAPIStats(`private_key=abcd
+listen_port=51820
+public_key=efgh
+endpoint=1.2.3.4:51820
+rx_bytes=1000
+tx_bytes=2000
+public_key=ijkl
+rx_bytes=500
+tx_bytes=1000
+tx_bytes=invalid
+`))
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/miekg/dns"
 	"github.com/octelium/octelium/apis/client/cliconfigv1"
 	"github.com/octelium/octelium/client/octelium/commands/connect/proxy/proxy/userspace/tcp"
+	"github.com/octelium/octelium/client/octelium/commands/connect/proxy/proxy/userspace/udp"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"gvisor.dev/gvisor/pkg/sync"
@@ -63,7 +64,7 @@ func (s *serviceProxy) Start(ctx context.Context) error {
 		case cliconfigv1.Connection_Preferences_PublishedService_TCP:
 			l.startTCP(ctx)
 		case cliconfigv1.Connection_Preferences_PublishedService_UDP:
-			zap.L().Warn("UDP-based published Services are currently unsupported. Skipping...")
+			l.startUDP(ctx)
 		}
 	}
 
@@ -103,7 +104,8 @@ type listener struct {
 	gonet *Net
 	typ   cliconfigv1.Connection_Preferences_PublishedService_L4Type
 
-	lis net.Listener
+	lis    net.Listener
+	udpLis *udp.Listener
 
 	mu       sync.Mutex
 	isClosed bool
@@ -120,6 +122,9 @@ func (l *listener) close() error {
 	if l.lis != nil {
 		l.lis.Close()
 	}
+	if l.udpLis != nil {
+		l.udpLis.Close()
+	}
 
 	for conn := range l.conns {
 		conn.Close()
@@ -161,6 +166,17 @@ func (l *listener) setLis(lis net.Listener) bool {
 	return true
 }
 
+func (l *listener) setUDPLis(lis *udp.Listener) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.isClosed {
+		return false
+	}
+	l.udpLis = lis
+
+	return true
+}
+
 func newListener(svc *cliconfigv1.Connection_Preferences_PublishedService, ctl *Controller) *listener {
 	return &listener{
 		ctl:      ctl,
@@ -262,46 +278,137 @@ func (l *listener) doStartTCP(ctx context.Context) error {
 	}
 }
 
-func (l *listener) getConnBackendTCP() (tcp.WriteCloser, error) {
-	var connBackend tcp.WriteCloser
+func (l *listener) startUDP(ctx context.Context) error {
+	go l.doStartUDP(ctx)
+	return nil
+}
 
-	if l.gonet != nil {
-		addrs, err := l.gonet.LookupHost(l.svcFQDN)
-		if err != nil {
-			return nil, errors.Errorf("Could not lookupHost via gVisor: %s", err)
-		}
-		if len(addrs) == 0 {
-			return nil, errors.Errorf("Could not resolve Service: %s", l.svcFQDN)
-		}
+func (l *listener) doStartUDP(ctx context.Context) error {
+	pp, err := udp.NewProxy(l.svcFQDN)
+	if err != nil {
+		zap.L().Error("Could not initialize new UDP proxy", zap.Error(err))
+		return err
+	}
+
+	listenerAddr := net.JoinHostPort(l.hostAddress, fmt.Sprintf("%d", l.hostPort))
+
+	addr, err := net.ResolveUDPAddr("udp", listenerAddr)
+	if err != nil {
+		zap.L().Error("Could not resolve UDP listener addr", zap.String("addr", listenerAddr), zap.Error(err))
+		return err
+	}
+
+	lis, err := udp.Listen("udp", addr)
+	if err != nil {
+		zap.L().Error("Could not listen on UDP", zap.String("addr", listenerAddr), zap.Error(err))
+		return err
+	}
+
+	if !l.setUDPLis(lis) {
+		zap.L().Debug("UDP listener was closed before it started", zap.String("addr", listenerAddr))
+		return lis.Close()
+	}
 
-		tcpAddr, err := net.ResolveTCPAddr("tcp", net.JoinHostPort(addrs[0], fmt.Sprintf("%d", l.port)))
+	zap.L().Debug("UDP listener successfully started", zap.String("addr", listenerAddr))
+
+	defer l.close()
+
+	for {
+		conn, err := lis.Accept()
 		if err != nil {
-			return nil, err
+			zap.L().Debug("UDP listener closed", zap.String("addr", listenerAddr), zap.Error(err))
+			return nil
 		}
-		connBackend, err = l.gonet.DialTCP(tcpAddr)
+
+		go func(conn *udp.Conn) {
+			zap.L().Debug("Starting serving UDP session", zap.String("addr", listenerAddr))
+			connBackend, err := l.getConnBackendUDP()
+			if err != nil {
+				zap.L().Error("Could not get conn backend", zap.Error(err))
+				conn.Close()
+				return
+			}
+			pp.ServeUDPWithBackend(conn, connBackend)
+			zap.L().Debug("Done serving UDP session", zap.String("addr", listenerAddr))
+		}(conn)
+	}
+}
+
+func (l *listener) getConnBackendTCP() (tcp.WriteCloser, error) {
+	ip, err := l.getBackendIP()
+	if err != nil {
+		return nil, err
+	}
+
+	tcpAddr, err := net.ResolveTCPAddr("tcp", net.JoinHostPort(ip, fmt.Sprintf("%d", l.port)))
+	if err != nil {
+		return nil, err
+	}
+
+	if l.gonet != nil {
+		connBackend, err := l.gonet.DialTCP(tcpAddr)
 		if err != nil {
 			return nil, errors.Errorf("Could not dialTCP via gVisor: %s", err)
 		}
+		return connBackend, nil
+	}
 
-	} else {
+	connBackend, err := net.DialTCP("tcp", nil, tcpAddr)
+	if err != nil {
+		return nil, err
+	}
 
-		resolvedServiceIP, err := l.resolveService()
-		if err != nil {
-			return nil, err
-		}
+	return connBackend, nil
+}
+
+func (l *listener) getConnBackendUDP() (net.Conn, error) {
+	ip, err := l.getBackendIP()
+	if err != nil {
+		return nil, err
+	}
 
-		tcpAddr, err := net.ResolveTCPAddr("tcp",
-			net.JoinHostPort(resolvedServiceIP.String(), fmt.Sprintf("%d", l.port)))
+	udpAddr, err := net.ResolveUDPAddr("udp", net.JoinHostPort(ip, fmt.Sprintf("%d", l.port)))
+	if err != nil {
+		return nil, err
+	}
+
+	if l.gonet != nil {
+		connBackend, err := l.gonet.DialUDP(nil, udpAddr)
 		if err != nil {
-			return nil, err
+			return nil, errors.Errorf("Could not dialUDP via gVisor: %s", err)
 		}
+		return connBackend, nil
+	}
 
-		connBackend, err = net.DialTCP("tcp", nil, tcpAddr)
+	connBackend, err := net.DialUDP("udp", nil, udpAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return connBackend, nil
+}
+
+// getBackendIP resolves the Service's IP address either via the gVisor
+// netstack or via the Cluster's DNS server.
+func (l *listener) getBackendIP() (string, error) {
+	if l.gonet != nil {
+		addrs, err := l.gonet.LookupHost(l.svcFQDN)
 		if err != nil {
-			return nil, err
+			return "", errors.Errorf("Could not lookupHost via gVisor: %s", err)
 		}
+		if len(addrs) == 0 {
+			return "", errors.Errorf("Could not resolve Service: %s", l.svcFQDN)
+		}
+
+		return addrs[0], nil
 	}
-	return connBackend, nil
+
+	ip, err := l.resolveService()
+	if err != nil {
+		return "", err
+	}
+
+	return ip.String(), nil
 }
 
 func (l *listener) resolveService() (net.IP, error) {
@@ -0,0 +1,55 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connect
+
+import (
+	"context"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/daemon/daemonc"
+)
+
+func connectViaDaemon(ctx context.Context, c *daemonc.Client, domain string) error {
+	conn, err := c.Connect(ctx, &clidaemonv1.ConnectRequest{
+		Domain:  domain,
+		Options: cmdArgs.toDaemonOptions(),
+	})
+	if err != nil {
+		return err
+	}
+
+	cliutils.LineNotify("The connection to %s is now managed by the octelium daemon (pid %d).\n",
+		conn.Domain, conn.Pid)
+
+	return nil
+}
+
+func (o *args) toDaemonOptions() *clidaemonv1.ConnectRequest_Options {
+	return &clidaemonv1.ConnectRequest_Options{
+		L3Mode:             o.L3Mode,
+		IgnoreDNS:          o.IgnoreDNS,
+		ServeServices:      o.ServeServices,
+		ServeAll:           o.ServeAll,
+		PublishServices:    o.PublishServices,
+		ImplementationMode: o.ImplementationMode,
+		TunnelMode:         o.TunnelMode,
+		EsshEnable:         o.UseESSH,
+		EsshUser:           o.ESSHUser,
+		Esocks5Enable:      o.UseESOCKS5,
+		LocalDNSEnable:     o.UseLocalDNS,
+		LocalDNSAddress:    o.LocalDNSListenAddr,
+	}
+}
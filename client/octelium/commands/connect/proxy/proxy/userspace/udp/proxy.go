@@ -42,6 +42,14 @@ func (p *Proxy) ServeUDP(conn *Conn) {
 		return
 	}
 
+	p.ServeUDPWithBackend(conn, connBackend)
+}
+
+// ServeUDPWithBackend proxies the session to an already dialed backend
+// (e.g. a connection dialed via the gVisor netstack).
+func (p *Proxy) ServeUDPWithBackend(conn *Conn, connBackend net.Conn) {
+	defer conn.Close()
+
 	// maybe not needed, but just in case
 	defer connBackend.Close()
 
@@ -49,11 +57,7 @@ func (p *Proxy) ServeUDP(conn *Conn) {
 	go p.connCopy(conn, connBackend, errChan)
 	go p.connCopy(connBackend, conn, errChan)
 
-	err = <-errChan
-	if err != nil {
-
-	}
-
+	<-errChan
 	<-errChan
 }
 
@@ -0,0 +1,203 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connect
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/octelium/octelium/apis/client/cliconfigv1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+	"github.com/octelium/octelium/client/octelium/commands/connect/controller"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const localDNSAddrCount = 20
+
+func getOtherConnections(domain string) []*connreg.Connection {
+	conns, err := connreg.New().List()
+	if err != nil {
+		zap.L().Debug("Could not list the active connections", zap.Error(err))
+		return nil
+	}
+
+	var ret []*connreg.Connection
+	for _, conn := range conns {
+		if conn.Domain != domain {
+			ret = append(ret, conn)
+		}
+	}
+
+	return ret
+}
+
+// resolveConflicts adjusts the Connection config so that it can run alongside
+// the active connections to other Clusters on the same host. Clusters share the
+// same default IPv4 range, hence a Cluster whose IPv4 routes are already taken
+// falls back to IPv6-only and vice versa.
+func resolveConflicts(connCfg *cliconfigv1.Connection, conns []*connreg.Connection) error {
+	setLocalDNSAddr(connCfg, conns)
+
+	if connCfg.Connection.Cidr == nil || isNetstackEnforced(connCfg) {
+		return nil
+	}
+
+	domain := connCfg.Info.Cluster.Domain
+	cidr := connCfg.Connection.Cidr
+
+	hasV4 := cidr.V4 != "" && connCfg.Preferences.L3Mode != cliconfigv1.Connection_Preferences_V6
+	hasV6 := cidr.V6 != "" && connCfg.Preferences.L3Mode != cliconfigv1.Connection_Preferences_V4
+
+	var v4Conflict, v6Conflict *connreg.Connection
+	if hasV4 {
+		v4Conflict = connreg.FindOverlap(conns, domain, cidr.V4)
+	}
+	if hasV6 {
+		v6Conflict = connreg.FindOverlap(conns, domain, cidr.V6)
+	}
+
+	switch {
+	case v4Conflict == nil && v6Conflict == nil:
+		return nil
+	case v4Conflict != nil && hasV6 && v6Conflict == nil:
+		cliutils.LineWarn("The IPv4 range %s overlaps with the active connection to %s. Using IPv6 only for this connection\n",
+			cidr.V4, v4Conflict.Domain)
+		setL3Mode(connCfg, cliconfigv1.Connection_Preferences_V6)
+		return nil
+	case v6Conflict != nil && hasV4 && v4Conflict == nil:
+		cliutils.LineWarn("The IPv6 range %s overlaps with the active connection to %s. Using IPv4 only for this connection\n",
+			cidr.V6, v6Conflict.Domain)
+		setL3Mode(connCfg, cliconfigv1.Connection_Preferences_V4)
+		return nil
+	default:
+		conflict := v4Conflict
+		if conflict == nil {
+			conflict = v6Conflict
+		}
+
+		return errors.Errorf(
+			"The address range of the Cluster %s overlaps with the active connection to the Cluster %s. "+
+				"Disconnect from %s first or connect using the gvisor implementation mode via `--implementation gvisor`",
+			domain, conflict.Domain, conflict.Domain)
+	}
+}
+
+func isNetstackEnforced(connCfg *cliconfigv1.Connection) bool {
+	if connCfg.Preferences.EnforceNetstack {
+		return true
+	}
+
+	prefs := connCfg.Preferences.LinuxPrefs
+	return prefs != nil && prefs.EnforceImplementationMode &&
+		prefs.ImplementationMode == cliconfigv1.Connection_Preferences_Linux_WG_NETSTACK
+}
+
+func setL3Mode(connCfg *cliconfigv1.Connection, mode cliconfigv1.Connection_Preferences_L3Mode) {
+	connCfg.Preferences.L3Mode = mode
+	switch mode {
+	case cliconfigv1.Connection_Preferences_V4:
+		connCfg.Connection.L3Mode = userv1.ConnectionState_V4
+	case cliconfigv1.Connection_Preferences_V6:
+		connCfg.Connection.L3Mode = userv1.ConnectionState_V6
+	}
+}
+
+// setLocalDNSAddr picks a loopback address for the local DNS server that is not
+// used by another connection. On Linux and Windows, the DNS servers are set per
+// device and the whole 127.0.0.0/8 range is available. On macOS, only 127.0.0.1
+// is configured on the loopback interface by default, hence the local DNS
+// server is disabled, falling back to the Cluster DNS servers, when another
+// connection already uses it.
+func setLocalDNSAddr(connCfg *cliconfigv1.Connection, conns []*connreg.Connection) {
+	doSetLocalDNSAddr(connCfg, conns, runtime.GOOS)
+}
+
+func doSetLocalDNSAddr(connCfg *cliconfigv1.Connection, conns []*connreg.Connection, goos string) {
+	if connCfg.Preferences.LocalDNS == nil ||
+		!connCfg.Preferences.LocalDNS.IsEnabled ||
+		connCfg.Preferences.LocalDNS.ListenAddress != "" {
+		return
+	}
+
+	var candidates []string
+
+	switch goos {
+	case "darwin":
+		candidates = []string{"127.0.0.1:53"}
+	case "windows":
+		candidates = []string{"127.0.0.1:53"}
+		fallthrough
+	case "linux":
+		for i := 0; i < localDNSAddrCount; i++ {
+			candidates = append(candidates, fmt.Sprintf("127.0.0.%d:53", 100+i))
+		}
+	default:
+		return
+	}
+
+	addr := connreg.GetFreeAddress(conns, candidates)
+	if addr == "" {
+		cliutils.LineWarn("The local DNS server addresses are used by the other active connections. " +
+			"Using the Cluster DNS servers for this connection\n")
+		connCfg.Preferences.LocalDNS.IsEnabled = false
+		return
+	}
+
+	connCfg.Preferences.LocalDNS.ListenAddress = addr
+}
+
+func getRegistryConnection(connCfg *cliconfigv1.Connection, devCtl *controller.Controller) *connreg.Connection {
+	ret := &connreg.Connection{
+		State:       connreg.StateConnected,
+		DeviceName:  connCfg.Preferences.DeviceName,
+		Netstack:    devCtl.GetNetstackNet() != nil,
+		L3Mode:      connCfg.Preferences.L3Mode.String(),
+		DNSServers:  devCtl.GetClusterDNSServers(),
+		ConnectedAt: time.Now(),
+	}
+
+	hasV4 := connCfg.Preferences.L3Mode != cliconfigv1.Connection_Preferences_V6
+	hasV6 := connCfg.Preferences.L3Mode != cliconfigv1.Connection_Preferences_V4
+
+	for _, addr := range connCfg.Connection.Addresses {
+		ip := umetav1.ToDualStackNetwork(addr).ToIP()
+		if hasV4 && ip.Ipv4 != "" {
+			ret.Addresses = append(ret.Addresses, ip.Ipv4)
+		}
+		if hasV6 && ip.Ipv6 != "" {
+			ret.Addresses = append(ret.Addresses, ip.Ipv6)
+		}
+	}
+
+	if cidr := connCfg.Connection.Cidr; cidr != nil {
+		if hasV4 && cidr.V4 != "" {
+			ret.Routes = append(ret.Routes, cidr.V4)
+		}
+		if hasV6 && cidr.V6 != "" {
+			ret.Routes = append(ret.Routes, cidr.V6)
+		}
+	}
+
+	if connCfg.Preferences.LocalDNS != nil && connCfg.Preferences.LocalDNS.IsEnabled {
+		ret.LocalDNSAddress = connCfg.Preferences.LocalDNS.ListenAddress
+	}
+
+	return ret
+}
@@ -0,0 +1,198 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connect
+
+import (
+	"testing"
+
+	"github.com/octelium/octelium/apis/client/cliconfigv1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveConflicts(t *testing.T) {
+	getConnCfg := func() *cliconfigv1.Connection {
+		return &cliconfigv1.Connection{
+			Connection: &userv1.ConnectionState{
+				L3Mode: userv1.ConnectionState_BOTH,
+				Cidr: &metav1.DualStackNetwork{
+					V4: "100.64.0.0/16",
+					V6: "fdee:1:2:3::/80",
+				},
+			},
+			Info: &cliconfigv1.Connection_Info{
+				Cluster: &cliconfigv1.Connection_Info_Cluster{
+					Domain: "example.org",
+				},
+			},
+			Preferences: &cliconfigv1.Connection_Preferences{
+				L3Mode:   cliconfigv1.Connection_Preferences_BOTH,
+				LocalDNS: &cliconfigv1.Connection_Preferences_LocalDNS{},
+			},
+		}
+	}
+
+	{
+		connCfg := getConnCfg()
+		err := resolveConflicts(connCfg, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, cliconfigv1.Connection_Preferences_BOTH, connCfg.Preferences.L3Mode)
+	}
+
+	{
+		connCfg := getConnCfg()
+		err := resolveConflicts(connCfg, []*connreg.Connection{
+			{
+				Domain: "example.com",
+				Routes: []string{"100.64.0.0/16", "fdee:4:5:6::/80"},
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, cliconfigv1.Connection_Preferences_V6, connCfg.Preferences.L3Mode)
+		assert.Equal(t, userv1.ConnectionState_V6, connCfg.Connection.L3Mode)
+	}
+
+	{
+		connCfg := getConnCfg()
+		err := resolveConflicts(connCfg, []*connreg.Connection{
+			{
+				Domain: "example.com",
+				Routes: []string{"fdee:1:2:3::/80"},
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, cliconfigv1.Connection_Preferences_V4, connCfg.Preferences.L3Mode)
+	}
+
+	{
+		connCfg := getConnCfg()
+		err := resolveConflicts(connCfg, []*connreg.Connection{
+			{
+				Domain: "example.com",
+				Routes: []string{"100.64.0.0/16", "fdee:1:2:3::/80"},
+			},
+		})
+		assert.NotNil(t, err)
+	}
+
+	{
+		connCfg := getConnCfg()
+		connCfg.Preferences.L3Mode = cliconfigv1.Connection_Preferences_V4
+		err := resolveConflicts(connCfg, []*connreg.Connection{
+			{
+				Domain: "example.com",
+				Routes: []string{"100.64.0.0/16"},
+			},
+		})
+		assert.NotNil(t, err)
+	}
+
+	{
+		connCfg := getConnCfg()
+		connCfg.Preferences.LinuxPrefs = &cliconfigv1.Connection_Preferences_Linux{
+			ImplementationMode:        cliconfigv1.Connection_Preferences_Linux_WG_NETSTACK,
+			EnforceImplementationMode: true,
+		}
+		err := resolveConflicts(connCfg, []*connreg.Connection{
+			{
+				Domain: "example.com",
+				Routes: []string{"100.64.0.0/16", "fdee:1:2:3::/80"},
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, cliconfigv1.Connection_Preferences_BOTH, connCfg.Preferences.L3Mode)
+	}
+}
+
+func TestSetLocalDNSAddr(t *testing.T) {
+	if !cliutils.IsLinux() {
+		t.Skip()
+	}
+
+	connCfg := &cliconfigv1.Connection{
+		Preferences: &cliconfigv1.Connection_Preferences{
+			LocalDNS: &cliconfigv1.Connection_Preferences_LocalDNS{
+				IsEnabled: true,
+			},
+		},
+	}
+
+	setLocalDNSAddr(connCfg, []*connreg.Connection{
+		{
+			Domain:          "example.com",
+			LocalDNSAddress: "127.0.0.100:53",
+		},
+	})
+
+	assert.Equal(t, "127.0.0.101:53", connCfg.Preferences.LocalDNS.ListenAddress)
+}
+
+func TestDoSetLocalDNSAddr(t *testing.T) {
+	getConnCfg := func() *cliconfigv1.Connection {
+		return &cliconfigv1.Connection{
+			Preferences: &cliconfigv1.Connection_Preferences{
+				LocalDNS: &cliconfigv1.Connection_Preferences_LocalDNS{
+					IsEnabled: true,
+				},
+			},
+		}
+	}
+
+	{
+		connCfg := getConnCfg()
+		doSetLocalDNSAddr(connCfg, nil, "windows")
+		assert.Equal(t, "127.0.0.1:53", connCfg.Preferences.LocalDNS.ListenAddress)
+	}
+
+	{
+		connCfg := getConnCfg()
+		doSetLocalDNSAddr(connCfg, []*connreg.Connection{
+			{
+				Domain:          "example.com",
+				LocalDNSAddress: "127.0.0.1:53",
+			},
+		}, "windows")
+		assert.Equal(t, "127.0.0.100:53", connCfg.Preferences.LocalDNS.ListenAddress)
+		assert.True(t, connCfg.Preferences.LocalDNS.IsEnabled)
+	}
+
+	{
+		connCfg := getConnCfg()
+		doSetLocalDNSAddr(connCfg, nil, "darwin")
+		assert.Equal(t, "127.0.0.1:53", connCfg.Preferences.LocalDNS.ListenAddress)
+	}
+
+	{
+		connCfg := getConnCfg()
+		doSetLocalDNSAddr(connCfg, []*connreg.Connection{
+			{
+				Domain:          "example.com",
+				LocalDNSAddress: "127.0.0.1:53",
+			},
+		}, "darwin")
+		assert.Equal(t, "", connCfg.Preferences.LocalDNS.ListenAddress)
+		assert.False(t, connCfg.Preferences.LocalDNS.IsEnabled)
+	}
+
+	{
+		connCfg := getConnCfg()
+		connCfg.Preferences.LocalDNS.ListenAddress = "127.0.0.53:53"
+		doSetLocalDNSAddr(connCfg, nil, "darwin")
+		assert.Equal(t, "127.0.0.53:53", connCfg.Preferences.LocalDNS.ListenAddress)
+	}
+}
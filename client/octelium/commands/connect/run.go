@@ -0,0 +1,77 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connect
+
+import (
+	"context"
+
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/authenticator"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+)
+
+// Options are the options of a connection started via Run. They correspond
+// to the flags of `octelium connect`.
+type Options struct {
+	L3Mode             string
+	IgnoreDNS          bool
+	ServeServices      []string
+	ServeAll           bool
+	PublishServices    []string
+	ImplementationMode string
+	TunnelMode         string
+
+	UseESSH    bool
+	ESSHUser   string
+	UseESOCKS5 bool
+
+	UseLocalDNS        bool
+	LocalDNSListenAddr string
+
+	// UseNetstack forces the gVisor netstack implementation on all OSes so
+	// that no network device, routes or DNS configuration are installed.
+	UseNetstack bool
+
+	// OnUpdate is called whenever the state of the connection changes.
+	OnUpdate func(*connreg.Connection)
+
+	// OnServiceEvent is called whenever the Cluster adds, updates or deletes
+	// a Service served by the connection.
+	OnServiceEvent func(*userv1.ConnectResponse)
+}
+
+// Run connects to the Cluster and keeps the connection alive, reconnecting
+// whenever it is lost, until ctx is done or the connection is closed by the
+// Cluster. The User must already be authenticated to the Cluster. Run is used
+// by the client daemon to run connections to multiple Clusters in-process.
+func Run(ctx context.Context, domain string, o *Options) error {
+	authenticator.StartGetAccessToken(ctx, domain)
+
+	return doRun(ctx, domain, &args{
+		L3Mode:             o.L3Mode,
+		IgnoreDNS:          o.IgnoreDNS,
+		ServeServices:      o.ServeServices,
+		ServeAll:           o.ServeAll,
+		PublishServices:    o.PublishServices,
+		ImplementationMode: o.ImplementationMode,
+		TunnelMode:         o.TunnelMode,
+		UseESSH:            o.UseESSH,
+		ESSHUser:           o.ESSHUser,
+		UseESOCKS5:         o.UseESOCKS5,
+		UseLocalDNS:        o.UseLocalDNS,
+		LocalDNSListenAddr: o.LocalDNSListenAddr,
+		UseNetstack:        o.UseNetstack,
+	}, o.OnUpdate, o.OnServiceEvent)
+}
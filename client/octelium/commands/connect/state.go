@@ -20,9 +20,12 @@ import (
 
 	"github.com/octelium/octelium/apis/client/cliconfigv1"
 	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/common/commands/auth/authcommon/deviceprobe"
 	"github.com/octelium/octelium/client/octelium/commands/connect/controller"
 	"github.com/octelium/octelium/client/octelium/commands/connect/proxy"
 	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/octelium/octelium/pkg/grpcerr"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -35,6 +38,7 @@ type stateController struct {
 	getConnErrCh          chan error
 	apiserverDisconnectCh chan struct{}
 	streamC               userv1.MainService_ConnectClient
+	onServiceEvent        func(*userv1.ConnectResponse)
 }
 
 func newStateController(c *cliconfigv1.Connection,
@@ -58,9 +62,42 @@ func (c *stateController) Start(ctx context.Context) error {
 	zap.L().Debug("Starting state controller")
 	go c.doStartLoop(ctx)
 	go c.doStartKeepAliveLoop(ctx)
+	go c.doStartDeviceProbeLoop(ctx)
 	return nil
 }
 
+const deviceProbeInterval = 5 * time.Minute
+
+func (c *stateController) doStartDeviceProbeLoop(ctx context.Context) {
+	tickerCh := time.NewTicker(deviceProbeInterval)
+	defer tickerCh.Stop()
+
+	defer zap.L().Debug("doStartDeviceProbeLoop exiting....")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tickerCh.C:
+			if err := c.runDeviceProbe(ctx); err != nil {
+				if grpcerr.IsPermissionDenied(err) {
+					return
+				}
+				zap.L().Debug("Could not run Device probes in doStartDeviceProbeLoop", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (c *stateController) runDeviceProbe(ctx context.Context) error {
+	authC, err := cliutils.NewAuthClient(ctx, c.c.Info.Cluster.Domain, nil)
+	if err != nil {
+		return err
+	}
+	defer authC.Close()
+
+	return deviceprobe.Run(ctx, authC.C())
+}
+
 func (c *stateController) doStartKeepAliveLoop(ctx context.Context) {
 	tickerCh := time.NewTicker(5 * time.Minute)
 	defer tickerCh.Stop()
@@ -139,6 +176,12 @@ func (c *stateController) isDisconnect(state *userv1.ConnectResponse) bool {
 	}
 }
 
+func (c *stateController) notifyServiceEvent(state *userv1.ConnectResponse) {
+	if c.onServiceEvent != nil {
+		c.onServiceEvent(state)
+	}
+}
+
 func (c *stateController) handleState(ctx context.Context, state *userv1.ConnectResponse) error {
 
 	switch state.Event.(type) {
@@ -172,6 +215,7 @@ func (c *stateController) handleState(ctx context.Context, state *userv1.Connect
 	case *userv1.ConnectResponse_AddService_:
 		svc := state.Event.(*userv1.ConnectResponse_AddService_).AddService.Service
 		zap.L().Debug("Adding Service", zap.Any("svc", svc))
+		c.notifyServiceEvent(state)
 
 		if c.c.Preferences.ServeOpts.ProxyMode == cliconfigv1.Connection_Preferences_ServeOpts_NONE {
 			return nil
@@ -186,6 +230,7 @@ func (c *stateController) handleState(ctx context.Context, state *userv1.Connect
 	case *userv1.ConnectResponse_UpdateService_:
 		svc := state.Event.(*userv1.ConnectResponse_UpdateService_).UpdateService.Service
 		zap.L().Debug("Updating Service", zap.Any("svc", svc))
+		c.notifyServiceEvent(state)
 
 		if c.c.Preferences.ServeOpts.ProxyMode == cliconfigv1.Connection_Preferences_ServeOpts_NONE {
 			return nil
@@ -201,6 +246,7 @@ func (c *stateController) handleState(ctx context.Context, state *userv1.Connect
 		svcName := state.Event.(*userv1.ConnectResponse_DeleteService_).DeleteService.Name
 
 		zap.L().Debug("Deleting Service", zap.String("svc", svcName))
+		c.notifyServiceEvent(state)
 
 		if c.c.Preferences.ServeOpts.ProxyMode == cliconfigv1.Connection_Preferences_ServeOpts_NONE {
 			return nil
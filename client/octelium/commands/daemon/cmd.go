@@ -0,0 +1,164 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/daemon/daemonc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+type args struct {
+	Socket string
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Socket, "socket", "",
+		"The path of the Unix socket of the local API. It can also be set via the OCTELIUM_DAEMON_SOCKET environment variable")
+}
+
+var Cmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run the octelium client daemon",
+	Long: `
+Run the octelium client daemon. The daemon runs the connections to one or more Clusters and exposes
+a local gRPC API over a Unix socket to connect, disconnect, get the status, list Services, refresh
+the authentication and stream the connection events. The connections are automatically restarted
+whenever the host's network changes. While the daemon is running, the connect, disconnect and status
+commands are run through the daemon.
+`,
+	Example: `
+# Run the daemon for the current user's Octelium home directory
+sudo octelium daemon --homedir $HOME/.octelium
+
+# Connect, check the status and disconnect through the daemon
+octelium connect --domain example.com
+octelium status --all
+octelium disconnect --all
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	path := cmdArgs.Socket
+	if path == "" {
+		path = daemonc.GetSocketPath()
+	}
+
+	lis, err := listen(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path)
+
+	srv := newServer()
+	grpcSrv := grpc.NewServer()
+	clidaemonv1.RegisterDaemonServiceServer(grpcSrv, srv)
+
+	mon := &networkMonitor{
+		getInterfaces: getNetInterfaces,
+		isIgnored:     srv.isTunnelInterface,
+		onChange:      srv.onNetworkChange,
+		interval:      networkCheckInterval,
+	}
+	go mon.run(ctx)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- grpcSrv.Serve(lis)
+	}()
+
+	cliutils.LineNotify("The octelium daemon is listening on %s\n", path)
+
+	select {
+	case <-ctx.Done():
+		cliutils.LineInfo("Received shutdown signal\n")
+	case err := <-errCh:
+		srv.close()
+		return err
+	}
+
+	srv.close()
+	grpcSrv.Stop()
+
+	return nil
+}
+
+func listen(ctx context.Context, path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if c, err := daemonc.Dial(ctx, path); err == nil {
+			c.Close()
+			return nil, errors.Errorf("Another octelium daemon is already listening on %s", path)
+		}
+
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		zap.L().Debug("Could not set the socket permissions", zap.Error(err))
+	}
+
+	setSocketOwner(path)
+
+	return lis, nil
+}
+
+// setSocketOwner hands the socket over to the user who ran the daemon via sudo
+// so that the user's CLI can access it without root privileges.
+func setSocketOwner(path string) {
+	uid, err := strconv.Atoi(os.Getenv("SUDO_UID"))
+	if err != nil {
+		return
+	}
+	gid, err := strconv.Atoi(os.Getenv("SUDO_GID"))
+	if err != nil {
+		gid = -1
+	}
+
+	if err := os.Chown(path, uid, gid); err != nil {
+		zap.L().Debug("Could not set the socket owner", zap.Error(err))
+	}
+}
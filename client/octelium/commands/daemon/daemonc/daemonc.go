@@ -0,0 +1,133 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemonc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrNotRunning is returned by Dial when there is no daemon listening on the
+// socket.
+var ErrNotRunning = errors.Errorf("The octelium daemon is not running")
+
+// GetSocketPath returns the path of the Unix socket of the daemon's local API.
+// It can be overridden via the OCTELIUM_DAEMON_SOCKET environment variable.
+func GetSocketPath() string {
+	if val := os.Getenv("OCTELIUM_DAEMON_SOCKET"); val != "" {
+		return val
+	}
+
+	if cliutils.IsWindows() {
+		if programData := os.Getenv("ProgramData"); programData != "" {
+			return filepath.Join(programData, "octelium", "daemon.sock")
+		}
+		return filepath.Join(os.TempDir(), "octelium", "daemon.sock")
+	}
+
+	return filepath.Join("/run", "octelium", "daemon.sock")
+}
+
+type Client struct {
+	clidaemonv1.DaemonServiceClient
+	conn *grpc.ClientConn
+}
+
+// Dial connects to the daemon listening on the given socket path and checks
+// that it is responsive.
+func Dial(ctx context.Context, path string) (*Client, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, ErrNotRunning
+	}
+
+	conn, err := grpc.NewClient("unix://"+path,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Client{
+		DaemonServiceClient: clidaemonv1.NewDaemonServiceClient(conn),
+		conn:                conn,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if _, err := ret.GetStatus(ctx, &clidaemonv1.GetStatusRequest{}, grpc.WaitForReady(true)); err != nil {
+		conn.Close()
+		return nil, ErrNotRunning
+	}
+
+	return ret, nil
+}
+
+// GetClient returns a Client of the daemon at the default socket path. It
+// returns ErrNotRunning if the daemon is not running.
+func GetClient(ctx context.Context) (*Client, error) {
+	return Dial(ctx, GetSocketPath())
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// FromRegistry converts the host-local record of a connection to its API
+// representation.
+func FromRegistry(conn *connreg.Connection) *clidaemonv1.Connection {
+	ret := &clidaemonv1.Connection{
+		Domain:          conn.Domain,
+		Pid:             int32(conn.PID),
+		DeviceName:      conn.DeviceName,
+		IsNetstack:      conn.Netstack,
+		L3Mode:          conn.L3Mode,
+		Addresses:       conn.Addresses,
+		DnsServers:      conn.DNSServers,
+		LocalDNSAddress: conn.LocalDNSAddress,
+		LastError:       conn.LastError,
+	}
+
+	switch conn.State {
+	case connreg.StateConnecting:
+		ret.State = clidaemonv1.Connection_CONNECTING
+	case connreg.StateConnected:
+		ret.State = clidaemonv1.Connection_CONNECTED
+	}
+
+	if !conn.StartedAt.IsZero() {
+		ret.StartedAt = timestamppb.New(conn.StartedAt)
+	}
+	if !conn.ConnectedAt.IsZero() {
+		ret.ConnectedAt = timestamppb.New(conn.ConnectedAt)
+	}
+	if conn.RxBytes > 0 || conn.TxBytes > 0 {
+		ret.Stats = &clidaemonv1.Connection_Stats{
+			RxBytes: conn.RxBytes,
+			TxBytes: conn.TxBytes,
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,192 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"context"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
+	"go.uber.org/zap"
+)
+
+const networkCheckInterval = 5 * time.Second
+
+type netInterface struct {
+	name  string
+	up    bool
+	addrs []string
+}
+
+// networkMonitor periodically inspects the host's network interfaces and calls
+// onChange whenever the set of addresses of the physical interfaces changes
+// (e.g. switching between Wi-Fi networks or waking up from sleep).
+type networkMonitor struct {
+	getInterfaces func() ([]*netInterface, error)
+	isIgnored     func(name string) bool
+	onChange      func()
+	interval      time.Duration
+
+	last string
+}
+
+func (m *networkMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	m.check()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check()
+		}
+	}
+}
+
+func (m *networkMonitor) check() bool {
+	ifaces, err := m.getInterfaces()
+	if err != nil {
+		zap.L().Debug("Could not get the network interfaces", zap.Error(err))
+		return false
+	}
+
+	cur := getNetworkFingerprint(ifaces, m.isIgnored)
+	if cur == m.last {
+		return false
+	}
+
+	isFirst := m.last == ""
+	m.last = cur
+
+	if isFirst {
+		return false
+	}
+
+	zap.L().Debug("Detected a network change", zap.String("fingerprint", cur))
+	m.onChange()
+
+	return true
+}
+
+func getNetworkFingerprint(ifaces []*netInterface, isIgnored func(name string) bool) string {
+	var ret []string
+	for _, iface := range ifaces {
+		if !iface.up || isIgnored(iface.name) {
+			continue
+		}
+
+		addrs := slices.Clone(iface.addrs)
+		sort.Strings(addrs)
+		ret = append(ret, iface.name+"="+strings.Join(addrs, ","))
+	}
+
+	sort.Strings(ret)
+
+	if len(ret) == 0 {
+		return "none"
+	}
+
+	return strings.Join(ret, ";")
+}
+
+func getNetInterfaces() ([]*netInterface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*netInterface
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		itm := &netInterface{
+			name: iface.Name,
+			up:   iface.Flags&net.FlagUp != 0,
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.IsLinkLocalUnicast() {
+				continue
+			}
+			itm.addrs = append(itm.addrs, ipNet.String())
+		}
+
+		ret = append(ret, itm)
+	}
+
+	return ret, nil
+}
+
+// isTunnelInterface returns whether the interface is one of the devices created
+// by the Octelium connections so that their own changes are not considered
+// network changes.
+func (s *server) isTunnelInterface(name string) bool {
+	if strings.HasPrefix(name, "octelium") || strings.HasPrefix(name, "utun") {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, mc := range s.conns {
+		if mc.get().DeviceName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *server) onNetworkChange() {
+	s.publish(clidaemonv1.Event_NETWORK_CHANGED, nil)
+
+	s.mu.Lock()
+	hasConns := len(s.conns) > 0
+	s.mu.Unlock()
+
+	if !hasConns {
+		return
+	}
+
+	if !s.isRestarting.CompareAndSwap(false, true) {
+		return
+	}
+
+	zap.L().Info("The network has changed. Reconnecting the active connections")
+	go func() {
+		defer s.isRestarting.Store(false)
+		s.restartAll()
+	}()
+}
+
+func sortConnections(conns []*clidaemonv1.Connection) {
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].Domain < conns[j].Domain
+	})
+}
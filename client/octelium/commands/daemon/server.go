@@ -0,0 +1,549 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/authenticator"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/connect"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+	"github.com/octelium/octelium/client/octelium/commands/daemon/daemonc"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/octelium/octelium/pkg/utils/ldflags"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const stopTimeout = 30 * time.Second
+
+type runFn func(ctx context.Context, domain string, o *connect.Options) error
+
+type server struct {
+	clidaemonv1.UnimplementedDaemonServiceServer
+
+	startedAt *timestamppb.Timestamp
+	run       runFn
+
+	// registryFn lists the connections run by other processes on this host
+	registryFn func() ([]*connreg.Connection, error)
+	// disconnectFn asks the Cluster to close the User's connection
+	disconnectFn func(ctx context.Context, domain string) error
+	// isLoggedInFn checks whether the User has a Session in the Cluster
+	isLoggedInFn func(domain string) bool
+
+	mu    sync.Mutex
+	conns map[string]*managedConn
+	// restarting holds the connections that restartAll is currently stopping
+	// so that they can still be disconnected before they are started again
+	restarting map[string]*managedConn
+
+	isRestarting atomic.Bool
+
+	watchers struct {
+		sync.Mutex
+		chs map[chan *clidaemonv1.Event]struct{}
+	}
+}
+
+type managedConn struct {
+	cancelFn context.CancelFunc
+	doneCh   chan struct{}
+	opts     *clidaemonv1.ConnectRequest_Options
+
+	mu   sync.Mutex
+	conn *clidaemonv1.Connection
+
+	// isDisconnected is set by Disconnect so that the connection is not
+	// started again by restartAll. It is guarded by server.mu.
+	isDisconnected bool
+}
+
+func (c *managedConn) get() *clidaemonv1.Connection {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return proto.Clone(c.conn).(*clidaemonv1.Connection)
+}
+
+func newServer() *server {
+	ret := &server{
+		startedAt: pbutils.Now(),
+		run:       connect.Run,
+		registryFn: func() ([]*connreg.Connection, error) {
+			return connreg.New().List()
+		},
+		disconnectFn: disconnectCluster,
+		isLoggedInFn: func(domain string) bool {
+			_, err := cliutils.GetDB().GetSessionToken(domain)
+			return err == nil
+		},
+		conns:      make(map[string]*managedConn),
+		restarting: make(map[string]*managedConn),
+	}
+	ret.watchers.chs = make(map[chan *clidaemonv1.Event]struct{})
+
+	return ret
+}
+
+func (s *server) Connect(ctx context.Context, req *clidaemonv1.ConnectRequest) (*clidaemonv1.Connection, error) {
+	if req.Domain == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "The Cluster domain is not set")
+	}
+
+	if !s.isLoggedInFn(req.Domain) {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"You are not logged in to %s. Log in via `octelium login --domain %s` first", req.Domain, req.Domain)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.conns[req.Domain]; ok {
+		return nil, status.Errorf(codes.AlreadyExists,
+			"There is already an active connection to the Cluster %s", req.Domain)
+	}
+
+	if conns, err := s.registryFn(); err == nil {
+		for _, conn := range conns {
+			if conn.Domain == req.Domain {
+				return nil, status.Errorf(codes.AlreadyExists,
+					"There is already an active connection to the Cluster %s by the process %d",
+					req.Domain, conn.PID)
+			}
+		}
+	}
+
+	opts := req.Options
+	if opts == nil {
+		opts = &clidaemonv1.ConnectRequest_Options{}
+	}
+
+	mc := s.doStart(req.Domain, opts)
+
+	return mc.get(), nil
+}
+
+// doStart runs the connection in the background. s.mu must be held.
+func (s *server) doStart(domain string, opts *clidaemonv1.ConnectRequest_Options) *managedConn {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	mc := &managedConn{
+		cancelFn: cancelFn,
+		doneCh:   make(chan struct{}),
+		opts:     opts,
+		conn: &clidaemonv1.Connection{
+			Domain:    domain,
+			State:     clidaemonv1.Connection_CONNECTING,
+			IsManaged: true,
+			StartedAt: pbutils.Now(),
+			Options:   opts,
+		},
+	}
+	s.conns[domain] = mc
+
+	s.publish(clidaemonv1.Event_CONNECTION_UPDATED, mc.get())
+
+	go func() {
+		defer close(mc.doneCh)
+
+		err := s.run(ctx, domain, &connect.Options{
+			L3Mode:             opts.L3Mode,
+			IgnoreDNS:          opts.IgnoreDNS,
+			ServeServices:      opts.ServeServices,
+			ServeAll:           opts.ServeAll,
+			PublishServices:    opts.PublishServices,
+			ImplementationMode: opts.ImplementationMode,
+			TunnelMode:         opts.TunnelMode,
+			UseESSH:            opts.EsshEnable,
+			ESSHUser:           opts.EsshUser,
+			UseESOCKS5:         opts.Esocks5Enable,
+			UseLocalDNS:        opts.LocalDNSEnable,
+			LocalDNSListenAddr: opts.LocalDNSAddress,
+			OnUpdate: func(conn *connreg.Connection) {
+				s.onUpdate(mc, conn)
+			},
+			OnServiceEvent: func(resp *userv1.ConnectResponse) {
+				s.onServiceEvent(mc, resp)
+			},
+		})
+		if err != nil {
+			zap.L().Warn("The connection exited with an error",
+				zap.String("domain", domain), zap.Error(err))
+		}
+
+		mc.mu.Lock()
+		mc.conn.State = clidaemonv1.Connection_DISCONNECTED
+		if err != nil {
+			mc.conn.LastError = err.Error()
+		}
+		mc.mu.Unlock()
+
+		s.mu.Lock()
+		if s.conns[domain] == mc {
+			delete(s.conns, domain)
+		}
+		s.mu.Unlock()
+
+		s.publish(clidaemonv1.Event_CONNECTION_REMOVED, mc.get())
+	}()
+
+	return mc
+}
+
+func (s *server) onUpdate(mc *managedConn, conn *connreg.Connection) {
+	mc.mu.Lock()
+	cur := daemonc.FromRegistry(conn)
+	cur.IsManaged = true
+	cur.Options = mc.opts
+	cur.StartedAt = mc.conn.StartedAt
+	mc.conn = cur
+	mc.mu.Unlock()
+
+	s.publish(clidaemonv1.Event_CONNECTION_UPDATED, mc.get())
+}
+
+// stop cancels the managed connection of the domain, if any, and waits for it
+// to stop. It returns false if there is no managed connection.
+func (s *server) stop(domain string) (bool, error) {
+	s.mu.Lock()
+	mc, ok := s.conns[domain]
+	s.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+
+	mc.cancelFn()
+
+	select {
+	case <-mc.doneCh:
+		return true, nil
+	case <-time.After(stopTimeout):
+		zap.L().Warn("Timed out waiting for the connection to stop", zap.String("domain", domain))
+		return true, errors.Errorf("Timed out waiting for the connection to stop")
+	}
+}
+
+// restartAll restarts all the managed connections with their current options.
+// A connection that is disconnected while being restarted is not started again.
+func (s *server) restartAll() {
+	s.mu.Lock()
+	mcs := make(map[string]*managedConn)
+	for domain, mc := range s.conns {
+		mcs[domain] = mc
+	}
+	s.mu.Unlock()
+
+	for domain, mc := range mcs {
+		zap.L().Debug("Restarting the connection", zap.String("domain", domain))
+
+		s.mu.Lock()
+		if s.conns[domain] != mc || mc.isDisconnected {
+			s.mu.Unlock()
+			continue
+		}
+		s.restarting[domain] = mc
+		s.mu.Unlock()
+
+		s.stop(domain)
+
+		s.mu.Lock()
+		delete(s.restarting, domain)
+		if _, ok := s.conns[domain]; !ok && !mc.isDisconnected {
+			s.doStart(domain, mc.opts)
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *server) Disconnect(ctx context.Context, req *clidaemonv1.DisconnectRequest) (*clidaemonv1.DisconnectResponse, error) {
+	if !req.All && req.Domain == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Either the Cluster domain or all must be set")
+	}
+
+	conns, err := s.listConnections()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not list connections: %s", err)
+	}
+
+	var domains []string
+	for _, conn := range conns {
+		if req.All || conn.Domain == req.Domain {
+			domains = append(domains, conn.Domain)
+		}
+	}
+
+	if !req.All && len(domains) == 0 {
+		return nil, status.Errorf(codes.NotFound, "There is no connection to the Cluster %s", req.Domain)
+	}
+
+	ret := &clidaemonv1.DisconnectResponse{}
+	for _, domain := range domains {
+		if err := s.doDisconnect(ctx, domain); err != nil {
+			zap.L().Debug("Could not disconnect from the Cluster",
+				zap.String("domain", domain), zap.Error(err))
+			ret.Failures = append(ret.Failures, &clidaemonv1.DisconnectResponse_Failure{
+				Domain: domain,
+				Error:  err.Error(),
+			})
+			continue
+		}
+
+		ret.Domains = append(ret.Domains, domain)
+	}
+
+	return ret, nil
+}
+
+// doDisconnect asks the Cluster to close the connection and stops it if it is
+// managed by the daemon. A connection run by another process is only
+// considered disconnected if the Cluster closed it.
+func (s *server) doDisconnect(ctx context.Context, domain string) error {
+	s.mu.Lock()
+	if mc, ok := s.conns[domain]; ok {
+		mc.isDisconnected = true
+	}
+	if mc, ok := s.restarting[domain]; ok {
+		mc.isDisconnected = true
+	}
+	s.mu.Unlock()
+
+	disconnectErr := s.disconnectFn(ctx, domain)
+
+	isManaged, err := s.stop(domain)
+	if isManaged {
+		return err
+	}
+
+	return disconnectErr
+}
+
+func (s *server) listConnections() ([]*clidaemonv1.Connection, error) {
+	var ret []*clidaemonv1.Connection
+	managed := make(map[string]bool)
+
+	s.mu.Lock()
+	for domain, mc := range s.conns {
+		managed[domain] = true
+		ret = append(ret, mc.get())
+	}
+	for domain, mc := range s.restarting {
+		if !managed[domain] {
+			managed[domain] = true
+			ret = append(ret, mc.get())
+		}
+	}
+	s.mu.Unlock()
+
+	conns, err := s.registryFn()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, conn := range conns {
+		if !managed[conn.Domain] {
+			ret = append(ret, daemonc.FromRegistry(conn))
+		}
+	}
+
+	sortConnections(ret)
+
+	return ret, nil
+}
+
+func (s *server) GetStatus(ctx context.Context, req *clidaemonv1.GetStatusRequest) (*clidaemonv1.Status, error) {
+	conns, err := s.listConnections()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not list connections: %s", err)
+	}
+
+	return &clidaemonv1.Status{
+		StartedAt:   s.startedAt,
+		Version:     ldflags.GetVersion(),
+		Connections: conns,
+	}, nil
+}
+
+func (s *server) ListService(ctx context.Context, req *clidaemonv1.ListServiceRequest) (*userv1.ServiceList, error) {
+	if req.Domain == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "The Cluster domain is not set")
+	}
+
+	conn, err := client.GetGRPCClientConn(ctx, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return userv1.NewMainServiceClient(conn).ListService(ctx, &userv1.ListServiceOptions{})
+}
+
+func (s *server) RefreshAuth(ctx context.Context, req *clidaemonv1.RefreshAuthRequest) (*clidaemonv1.RefreshAuthResponse, error) {
+	if req.Domain == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "The Cluster domain is not set")
+	}
+
+	if !s.isLoggedInFn(req.Domain) {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"You are not logged in to %s. Log in via `octelium login --domain %s` first", req.Domain, req.Domain)
+	}
+
+	if _, err := authenticator.GetAccessToken(ctx, req.Domain); err != nil {
+		return nil, err
+	}
+
+	return &clidaemonv1.RefreshAuthResponse{}, nil
+}
+
+func (s *server) WatchEvents(req *clidaemonv1.WatchEventsRequest, stream clidaemonv1.DaemonService_WatchEventsServer) error {
+	ch := s.subscribe()
+	defer s.unsubscribe(ch)
+
+	conns, err := s.listConnections()
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not list connections: %s", err)
+	}
+
+	for _, conn := range conns {
+		if err := stream.Send(&clidaemonv1.Event{
+			CreatedAt:  pbutils.Now(),
+			Type:       clidaemonv1.Event_CONNECTION_UPDATED,
+			Connection: conn,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *server) onServiceEvent(mc *managedConn, resp *userv1.ConnectResponse) {
+	event := &clidaemonv1.Event{
+		CreatedAt:  pbutils.Now(),
+		Connection: mc.get(),
+	}
+
+	switch resp.Event.(type) {
+	case *userv1.ConnectResponse_AddService_:
+		event.Type = clidaemonv1.Event_SERVICE_ADDED
+		event.Service = resp.GetAddService().Service
+	case *userv1.ConnectResponse_UpdateService_:
+		event.Type = clidaemonv1.Event_SERVICE_UPDATED
+		event.Service = resp.GetUpdateService().Service
+	case *userv1.ConnectResponse_DeleteService_:
+		event.Type = clidaemonv1.Event_SERVICE_DELETED
+		event.Service = &userv1.HostedService{
+			Name: resp.GetDeleteService().Name,
+		}
+	default:
+		return
+	}
+
+	s.publishEvent(event)
+}
+
+const watcherBufferSize = 64
+
+func (s *server) subscribe() chan *clidaemonv1.Event {
+	ch := make(chan *clidaemonv1.Event, watcherBufferSize)
+
+	s.watchers.Lock()
+	s.watchers.chs[ch] = struct{}{}
+	s.watchers.Unlock()
+
+	return ch
+}
+
+func (s *server) unsubscribe(ch chan *clidaemonv1.Event) {
+	s.watchers.Lock()
+	delete(s.watchers.chs, ch)
+	s.watchers.Unlock()
+}
+
+func (s *server) publish(typ clidaemonv1.Event_Type, conn *clidaemonv1.Connection) {
+	s.publishEvent(&clidaemonv1.Event{
+		CreatedAt:  pbutils.Now(),
+		Type:       typ,
+		Connection: conn,
+	})
+}
+
+func (s *server) publishEvent(event *clidaemonv1.Event) {
+	s.watchers.Lock()
+	defer s.watchers.Unlock()
+
+	for ch := range s.watchers.chs {
+		select {
+		case ch <- event:
+		default:
+			zap.L().Debug("Dropping an event for a slow watcher")
+		}
+	}
+}
+
+func (s *server) close() {
+	s.mu.Lock()
+	var domains []string
+	for domain := range s.conns {
+		domains = append(domains, domain)
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain string) {
+			defer wg.Done()
+			s.stop(domain)
+		}(domain)
+	}
+	wg.Wait()
+}
+
+func disconnectCluster(ctx context.Context, domain string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	conn, err := client.GetGRPCClientConn(ctx, domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = userv1.NewMainServiceClient(conn).Disconnect(ctx, &userv1.DisconnectRequest{})
+	return err
+}
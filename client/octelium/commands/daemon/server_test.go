@@ -0,0 +1,515 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/octelium/commands/connect"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+	"github.com/octelium/octelium/client/octelium/commands/daemon/daemonc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeCluster struct {
+	mu            sync.Mutex
+	runs          map[string]int
+	disconnected  []string
+	disconnectErr error
+}
+
+func (f *fakeCluster) run(ctx context.Context, domain string, o *connect.Options) error {
+	f.mu.Lock()
+	f.runs[domain]++
+	f.mu.Unlock()
+
+	o.OnUpdate(&connreg.Connection{
+		Domain:     domain,
+		PID:        os.Getpid(),
+		State:      connreg.StateConnected,
+		DeviceName: "octelium-test",
+		L3Mode:     o.L3Mode,
+		Addresses:  []string{"100.64.0.2"},
+	})
+
+	if o.ServeAll {
+		o.OnUpdate(&connreg.Connection{
+			Domain:  domain,
+			State:   connreg.StateConnected,
+			RxBytes: 1000,
+			TxBytes: 2000,
+		})
+		o.OnServiceEvent(&userv1.ConnectResponse{
+			Event: &userv1.ConnectResponse_AddService_{
+				AddService: &userv1.ConnectResponse_AddService{
+					Service: &userv1.HostedService{
+						Name: "svc1.default",
+						Port: 8080,
+					},
+				},
+			},
+		})
+		o.OnServiceEvent(&userv1.ConnectResponse{
+			Event: &userv1.ConnectResponse_DeleteService_{
+				DeleteService: &userv1.ConnectResponse_DeleteService{
+					Name: "svc1.default",
+				},
+			},
+		})
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func (f *fakeCluster) getRuns(domain string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.runs[domain]
+}
+
+func newTestServer(t *testing.T) (*server, *fakeCluster, *daemonc.Client) {
+	f := &fakeCluster{
+		runs: make(map[string]int),
+	}
+
+	s := newServer()
+	s.run = f.run
+	s.registryFn = func() ([]*connreg.Connection, error) {
+		return []*connreg.Connection{
+			{
+				Domain: "other.example.com",
+				PID:    1234,
+				State:  connreg.StateConnected,
+			},
+		}, nil
+	}
+	s.disconnectFn = func(ctx context.Context, domain string) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.disconnectErr != nil {
+			return f.disconnectErr
+		}
+		f.disconnected = append(f.disconnected, domain)
+		return nil
+	}
+	s.isLoggedInFn = func(domain string) bool {
+		return domain != "loggedout.example.com"
+	}
+
+	dir, err := os.MkdirTemp("", "octelium-daemon")
+	assert.Nil(t, err)
+	path := filepath.Join(dir, "daemon.sock")
+
+	lis, err := net.Listen("unix", path)
+	assert.Nil(t, err)
+
+	grpcSrv := grpc.NewServer()
+	clidaemonv1.RegisterDaemonServiceServer(grpcSrv, s)
+	go grpcSrv.Serve(lis)
+
+	c, err := daemonc.Dial(context.Background(), path)
+	assert.Nil(t, err)
+
+	t.Cleanup(func() {
+		c.Close()
+		s.close()
+		grpcSrv.Stop()
+		os.RemoveAll(dir)
+	})
+
+	return s, f, c
+}
+
+func waitForState(t *testing.T, c *daemonc.Client, domain string, state clidaemonv1.Connection_State) *clidaemonv1.Connection {
+	ctx := context.Background()
+	for range 100 {
+		st, err := c.GetStatus(ctx, &clidaemonv1.GetStatusRequest{})
+		assert.Nil(t, err)
+		for _, conn := range st.Connections {
+			if conn.Domain == domain && conn.State == state {
+				return conn
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatalf("Timed out waiting for %s to be %s", domain, state)
+	return nil
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	_, f, c := newTestServer(t)
+
+	{
+		_, err := daemonc.Dial(ctx, filepath.Join(t.TempDir(), "none.sock"))
+		assert.Equal(t, daemonc.ErrNotRunning, err)
+	}
+
+	{
+		_, err := c.Connect(ctx, &clidaemonv1.ConnectRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	{
+		_, err := c.Connect(ctx, &clidaemonv1.ConnectRequest{
+			Domain: "loggedout.example.com",
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	}
+
+	{
+		_, err := c.Connect(ctx, &clidaemonv1.ConnectRequest{
+			Domain: "other.example.com",
+		})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	}
+
+	conn, err := c.Connect(ctx, &clidaemonv1.ConnectRequest{
+		Domain: "example.com",
+		Options: &clidaemonv1.ConnectRequest_Options{
+			L3Mode: "v6",
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "example.com", conn.Domain)
+	assert.True(t, conn.IsManaged)
+
+	conn = waitForState(t, c, "example.com", clidaemonv1.Connection_CONNECTED)
+	assert.Equal(t, "v6", conn.L3Mode)
+	assert.Equal(t, "v6", conn.Options.L3Mode)
+	assert.Equal(t, "octelium-test", conn.DeviceName)
+	assert.Equal(t, int32(os.Getpid()), conn.Pid)
+
+	{
+		_, err := c.Connect(ctx, &clidaemonv1.ConnectRequest{
+			Domain: "example.com",
+		})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	}
+
+	st, err := c.GetStatus(ctx, &clidaemonv1.GetStatusRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(st.Connections))
+	assert.Equal(t, "example.com", st.Connections[0].Domain)
+	assert.True(t, st.Connections[0].IsManaged)
+	assert.Equal(t, "other.example.com", st.Connections[1].Domain)
+	assert.False(t, st.Connections[1].IsManaged)
+	assert.Equal(t, int32(1234), st.Connections[1].Pid)
+
+	resp, err := c.Disconnect(ctx, &clidaemonv1.DisconnectRequest{
+		Domain: "example.com",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"example.com"}, resp.Domains)
+	assert.Equal(t, []string{"example.com"}, f.disconnected)
+
+	st, err = c.GetStatus(ctx, &clidaemonv1.GetStatusRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(st.Connections))
+	assert.Equal(t, "other.example.com", st.Connections[0].Domain)
+
+	{
+		_, err := c.Disconnect(ctx, &clidaemonv1.DisconnectRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	{
+		_, err := c.Disconnect(ctx, &clidaemonv1.DisconnectRequest{
+			Domain: "example.com",
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	}
+
+	{
+		f.mu.Lock()
+		f.disconnectErr = errors.New("unavailable")
+		f.mu.Unlock()
+
+		resp, err := c.Disconnect(ctx, &clidaemonv1.DisconnectRequest{
+			Domain: "other.example.com",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(resp.Domains))
+		assert.Equal(t, 1, len(resp.Failures))
+		assert.Equal(t, "other.example.com", resp.Failures[0].Domain)
+		assert.Equal(t, "unavailable", resp.Failures[0].Error)
+
+		resp, err = c.Disconnect(ctx, &clidaemonv1.DisconnectRequest{
+			All: true,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(resp.Failures))
+
+		f.mu.Lock()
+		f.disconnectErr = nil
+		f.mu.Unlock()
+
+		resp, err = c.Disconnect(ctx, &clidaemonv1.DisconnectRequest{
+			All: true,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"other.example.com"}, resp.Domains)
+		assert.Equal(t, 0, len(resp.Failures))
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s, f, c := newTestServer(t)
+
+	stream, err := c.WatchEvents(ctx, &clidaemonv1.WatchEventsRequest{})
+	assert.Nil(t, err)
+
+	evt, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, clidaemonv1.Event_CONNECTION_UPDATED, evt.Type)
+	assert.Equal(t, "other.example.com", evt.Connection.Domain)
+
+	_, err = c.Connect(ctx, &clidaemonv1.ConnectRequest{
+		Domain: "example.com",
+	})
+	assert.Nil(t, err)
+
+	for {
+		evt, err := stream.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, clidaemonv1.Event_CONNECTION_UPDATED, evt.Type)
+		assert.Equal(t, "example.com", evt.Connection.Domain)
+		if evt.Connection.State == clidaemonv1.Connection_CONNECTED {
+			break
+		}
+	}
+
+	s.onNetworkChange()
+
+	evt, err = stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, clidaemonv1.Event_NETWORK_CHANGED, evt.Type)
+
+	var isRemoved bool
+	for {
+		evt, err := stream.Recv()
+		assert.Nil(t, err)
+		if evt.Type == clidaemonv1.Event_CONNECTION_REMOVED {
+			isRemoved = true
+			continue
+		}
+		if isRemoved && evt.Connection.GetState() == clidaemonv1.Connection_CONNECTED {
+			break
+		}
+	}
+
+	assert.Equal(t, 2, f.getRuns("example.com"))
+
+	_, err = c.Disconnect(ctx, &clidaemonv1.DisconnectRequest{
+		All: true,
+	})
+	assert.Nil(t, err)
+
+	evt, err = stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, clidaemonv1.Event_CONNECTION_REMOVED, evt.Type)
+	assert.Equal(t, clidaemonv1.Connection_DISCONNECTED, evt.Connection.State)
+}
+
+func TestServiceEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, _, c := newTestServer(t)
+
+	stream, err := c.WatchEvents(ctx, &clidaemonv1.WatchEventsRequest{})
+	assert.Nil(t, err)
+
+	evt, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, "other.example.com", evt.Connection.Domain)
+
+	_, err = c.Connect(ctx, &clidaemonv1.ConnectRequest{
+		Domain: "example.com",
+		Options: &clidaemonv1.ConnectRequest_Options{
+			ServeAll: true,
+		},
+	})
+	assert.Nil(t, err)
+
+	var events []*clidaemonv1.Event
+	for {
+		evt, err := stream.Recv()
+		assert.Nil(t, err)
+		if evt.Connection.GetDomain() != "example.com" {
+			continue
+		}
+		events = append(events, evt)
+		if evt.Type == clidaemonv1.Event_SERVICE_DELETED {
+			break
+		}
+	}
+
+	assert.True(t, len(events) >= 2)
+
+	added := events[len(events)-2]
+	assert.Equal(t, clidaemonv1.Event_SERVICE_ADDED, added.Type)
+	assert.Equal(t, "svc1.default", added.Service.Name)
+	assert.Equal(t, uint32(8080), added.Service.Port)
+	assert.Equal(t, uint64(1000), added.Connection.Stats.RxBytes)
+	assert.Equal(t, uint64(2000), added.Connection.Stats.TxBytes)
+
+	deleted := events[len(events)-1]
+	assert.Equal(t, "svc1.default", deleted.Service.Name)
+
+	conn := waitForState(t, c, "example.com", clidaemonv1.Connection_CONNECTED)
+	assert.Equal(t, uint64(2000), conn.Stats.TxBytes)
+}
+
+func TestRestartAllDisconnect(t *testing.T) {
+	ctx := context.Background()
+	s, f, c := newTestServer(t)
+
+	stoppingCh := make(chan struct{}, 1)
+	releaseCh := make(chan struct{})
+	s.run = func(ctx context.Context, domain string, o *connect.Options) error {
+		f.mu.Lock()
+		f.runs[domain]++
+		f.mu.Unlock()
+
+		o.OnUpdate(&connreg.Connection{
+			Domain: domain,
+			State:  connreg.StateConnected,
+		})
+
+		<-ctx.Done()
+		stoppingCh <- struct{}{}
+		<-releaseCh
+		return nil
+	}
+
+	_, err := c.Connect(ctx, &clidaemonv1.ConnectRequest{
+		Domain:  "example.com",
+		Options: &clidaemonv1.ConnectRequest_Options{},
+	})
+	assert.Nil(t, err)
+	waitForState(t, c, "example.com", clidaemonv1.Connection_CONNECTED)
+
+	restartDoneCh := make(chan struct{})
+	go func() {
+		s.restartAll()
+		close(restartDoneCh)
+	}()
+
+	<-stoppingCh
+
+	disconnectDoneCh := make(chan *clidaemonv1.DisconnectResponse)
+	go func() {
+		resp, err := s.Disconnect(ctx, &clidaemonv1.DisconnectRequest{
+			Domain: "example.com",
+		})
+		assert.Nil(t, err)
+		disconnectDoneCh <- resp
+	}()
+
+	isDisconnected := func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		mc, ok := s.restarting["example.com"]
+		return ok && mc.isDisconnected
+	}
+	for range 100 {
+		if isDisconnected() {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.True(t, isDisconnected())
+
+	close(releaseCh)
+	<-restartDoneCh
+	resp := <-disconnectDoneCh
+	assert.Equal(t, []string{"example.com"}, resp.Domains)
+
+	s.mu.Lock()
+	_, ok := s.conns["example.com"]
+	s.mu.Unlock()
+	assert.False(t, ok)
+	assert.Equal(t, 1, f.getRuns("example.com"))
+}
+
+func TestNetworkMonitor(t *testing.T) {
+	ifaces := []*netInterface{
+		{
+			name:  "eth0",
+			up:    true,
+			addrs: []string{"192.168.1.10/24", "fe80::1/64"},
+		},
+		{
+			name:  "octelium-example",
+			up:    true,
+			addrs: []string{"100.64.0.2/32"},
+		},
+		{
+			name: "wlan0",
+		},
+	}
+
+	var changes int
+	m := &networkMonitor{
+		getInterfaces: func() ([]*netInterface, error) {
+			return ifaces, nil
+		},
+		isIgnored: func(name string) bool {
+			return name == "octelium-example"
+		},
+		onChange: func() {
+			changes++
+		},
+	}
+
+	assert.False(t, m.check())
+	assert.Equal(t, "eth0=192.168.1.10/24,fe80::1/64", m.last)
+	assert.False(t, m.check())
+
+	ifaces[1].addrs = []string{"100.64.0.3/32"}
+	assert.False(t, m.check())
+
+	ifaces[0].addrs = []string{"fe80::1/64", "192.168.1.10/24"}
+	assert.False(t, m.check())
+
+	ifaces[2].up = true
+	ifaces[2].addrs = []string{"10.0.0.5/24"}
+	assert.True(t, m.check())
+	assert.Equal(t, 1, changes)
+
+	ifaces[0].up = false
+	ifaces[2].up = false
+	assert.True(t, m.check())
+	assert.Equal(t, "none", m.last)
+	assert.Equal(t, 2, changes)
+}
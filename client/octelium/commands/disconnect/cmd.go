@@ -15,17 +15,38 @@
 package disconnect
 
 import (
+	"context"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
 	"github.com/octelium/octelium/apis/main/userv1"
 	"github.com/octelium/octelium/client/common/client"
 	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+	"github.com/octelium/octelium/client/octelium/commands/daemon/daemonc"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+type args struct {
+	All bool
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().BoolVarP(&cmdArgs.All, "all", "A", false,
+		"Disconnect from all the Clusters that this host is connected to")
+}
+
 var Cmd = &cobra.Command{
 	Use:   "disconnect",
 	Short: "Disconnect from the Cluster",
 	Example: `
 octelium disconnect
+octelium disconnect --domain example.com
+
+# Disconnect from all Clusters
+octelium disconnect --all
 	`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -36,23 +57,101 @@ octelium disconnect
 func doCmd(cmd *cobra.Command, args []string) error {
 
 	ctx := cmd.Context()
+
+	if c, err := daemonc.GetClient(ctx); err == nil {
+		defer c.Close()
+		return doDisconnectDaemon(cmd, args, c)
+	}
+
+	if cmdArgs.All {
+		return doDisconnectAll(ctx)
+	}
+
 	i, err := cliutils.GetCLIInfo(cmd, args)
 	if err != nil {
 		return err
 	}
 
-	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
-	if err != nil {
+	if err := doDisconnect(ctx, i.Domain); err != nil {
 		return err
 	}
-	defer conn.Close()
 
-	c := userv1.NewMainServiceClient(conn)
+	cliutils.LineInfo("You're now disconnected.\n")
+	return nil
+}
+
+func doDisconnectDaemon(cmd *cobra.Command, args []string, c *daemonc.Client) error {
+	req := &clidaemonv1.DisconnectRequest{
+		All: cmdArgs.All,
+	}
 
-	if _, err = c.Disconnect(ctx, &userv1.DisconnectRequest{}); err != nil {
+	if !cmdArgs.All {
+		i, err := cliutils.GetCLIInfo(cmd, args)
+		if err != nil {
+			return err
+		}
+		req.Domain = i.Domain
+	}
+
+	resp, err := c.Disconnect(cmd.Context(), req)
+	if err != nil {
 		return err
 	}
 
-	cliutils.LineInfo("You're now disconnected.\n")
+	if len(resp.Domains) == 0 && len(resp.Failures) == 0 {
+		cliutils.LineInfo("There are no active connections on this host\n")
+		return nil
+	}
+
+	for _, domain := range resp.Domains {
+		cliutils.LineInfo("Disconnected from %s\n", domain)
+	}
+
+	for _, failure := range resp.Failures {
+		cliutils.LineWarn("Could not disconnect from %s: %s\n", failure.Domain, failure.Error)
+	}
+
+	if len(resp.Failures) > 0 {
+		return errors.Errorf("Could not disconnect from %d Cluster(s)", len(resp.Failures))
+	}
+
 	return nil
 }
+
+func doDisconnectAll(ctx context.Context) error {
+	conns, err := connreg.New().List()
+	if err != nil {
+		return err
+	}
+
+	if len(conns) == 0 {
+		cliutils.LineInfo("There are no active connections on this host\n")
+		return nil
+	}
+
+	var retErr error
+	for _, conn := range conns {
+		if err := doDisconnect(ctx, conn.Domain); err != nil {
+			cliutils.LineWarn("Could not disconnect from %s: %s\n", conn.Domain, err)
+			retErr = err
+			continue
+		}
+
+		cliutils.LineInfo("Disconnected from %s\n", conn.Domain)
+	}
+
+	return retErr
+}
+
+func doDisconnect(ctx context.Context, domain string) error {
+	conn, err := client.GetGRPCClientConn(ctx, domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := userv1.NewMainServiceClient(conn)
+
+	_, err = c.Disconnect(ctx, &userv1.DisconnectRequest{})
+	return err
+}
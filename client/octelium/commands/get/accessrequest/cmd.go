@@ -0,0 +1,112 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"fmt"
+
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/common/printer"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/spf13/cobra"
+)
+
+var cmdArgs args
+
+type args struct {
+	Out      string
+	ToDecide bool
+}
+
+func init() {
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Out, "out", "o", "", "Output format")
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.ToDecide, "to-decide", false,
+		"List the pending AccessRequests of other Users that you can approve or deny")
+}
+
+var Cmd = &cobra.Command{
+	Use:   "accessrequest",
+	Short: "List your AccessRequests",
+	Example: `
+octelium get accessrequest
+octelium get ar -o json
+octelium get ar --to-decide
+	`,
+	Aliases: []string{"ar", "accessrequests", "access-request", "access-requests"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := userv1.NewMainServiceClient(conn)
+
+	itmList, err := c.ListAccessRequest(ctx, &userv1.ListAccessRequestOptions{
+		Common:   cliutils.GetCommonListOptions(cmd),
+		ToDecide: cmdArgs.ToDecide,
+	})
+	if err != nil {
+		return err
+	}
+
+	if cmdArgs.Out != "" {
+		out, err := cliutils.OutFormatPrint(cmdArgs.Out, itmList)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", string(out))
+		return nil
+	}
+
+	if len(itmList.Items) == 0 {
+		cliutils.LineInfo("No AccessRequests Found\n")
+		return nil
+	}
+
+	p := printer.NewPrinter("Name", "User", "Target", "Duration", "State", "Justification")
+	for _, itm := range itmList.Items {
+		target := itm.Spec.Service
+		if target == "" {
+			target = fmt.Sprintf("ns:%s", itm.Spec.Namespace)
+		}
+
+		duration := "1h0m0s"
+		if itm.Spec.Duration != nil {
+			duration = umetav1.ToDuration(itm.Spec.Duration).ToGo().String()
+		}
+
+		p.AppendRow(itm.Metadata.Name, itm.Status.User, target, duration,
+			itm.Status.State.String(), itm.Spec.Justification)
+	}
+
+	p.Render()
+	return nil
+}
@@ -15,6 +15,7 @@
 package get
 
 import (
+	"github.com/octelium/octelium/client/octelium/commands/get/accessrequest"
 	"github.com/octelium/octelium/client/octelium/commands/get/namespace"
 	"github.com/octelium/octelium/client/octelium/commands/get/service"
 	"github.com/spf13/cobra"
@@ -27,6 +28,7 @@ var Cmd = &cobra.Command{
 func AddSubcommands() {
 	Cmd.AddCommand(service.Cmd)
 	Cmd.AddCommand(namespace.Cmd)
+	Cmd.AddCommand(accessrequest.Cmd)
 }
 
 func init() {
@@ -0,0 +1,39 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"github.com/octelium/octelium/client/octelium/commands/kube/credential"
+	"github.com/octelium/octelium/client/octelium/commands/kube/list"
+	"github.com/octelium/octelium/client/octelium/commands/kube/login"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "kube",
+	Short:   "Access Kubernetes Services via kubectl",
+	Aliases: []string{"k8s"},
+	Example: `
+octelium kube list
+octelium kube login k8s.prod
+kubectl get pods
+	`,
+}
+
+func AddSubcommands() {
+	Cmd.AddCommand(login.Cmd)
+	Cmd.AddCommand(list.Cmd)
+	Cmd.AddCommand(credential.Cmd)
+}
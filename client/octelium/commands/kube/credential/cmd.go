@@ -0,0 +1,118 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/octelium/octelium/client/common/authenticator"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/kube/kubeconfig"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var Cmd = &cobra.Command{
+	Use:    "credential",
+	Short:  "Print a Kubernetes exec credential for the current Session",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+// The token is used by the Service only to authorize the request at the
+// Octelium Cluster's side and then replaced with the upstream credentials.
+// In the auth-proxy mode there is no token at all and a placeholder is used
+// since kubectl rejects empty tokens.
+const placeholderToken = "authenticated-by-octelium-session"
+
+type execCredential struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Status     execCredentialStatus `json:"status"`
+}
+
+type execCredentialStatus struct {
+	Token               string `json:"token"`
+	ExpirationTimestamp string `json:"expirationTimestamp,omitempty"`
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	// kubectl reads the credential from stdout. Any notice printed while
+	// refreshing the Session must go to stderr instead.
+	color.Output = os.Stderr
+
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	accessToken, err := authenticator.GetAccessToken(ctx, i.Domain)
+	if err != nil {
+		return err
+	}
+
+	var expiresAt time.Time
+	if accessToken != "" {
+		if st, err := cliutils.GetDB().Get(i.Domain); err == nil &&
+			st.SessionToken != nil && st.SessionToken.ExpiresIn > 0 {
+			expiresAt = getExpiresAt(st.SessionTokenSetAt, st.SessionToken.ExpiresIn)
+		}
+	}
+
+	out, err := getExecCredential(accessToken, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", string(out))
+	return nil
+}
+
+// getExpiresAt returns a time slightly before the access token actually
+// expires so that kubectl calls the plugin again to refresh the Session in time.
+func getExpiresAt(setAt *timestamppb.Timestamp, expiresIn int64) time.Time {
+	return setAt.AsTime().
+		Add(time.Duration(expiresIn) * time.Second).
+		Add(-1 * time.Minute)
+}
+
+func getExecCredential(accessToken string, expiresAt time.Time) ([]byte, error) {
+	ret := &execCredential{
+		APIVersion: kubeconfig.ExecAPIVersion,
+		Kind:       "ExecCredential",
+		Status: execCredentialStatus{
+			Token: accessToken,
+		},
+	}
+
+	if ret.Status.Token == "" {
+		ret.Status.Token = placeholderToken
+	}
+
+	if !expiresAt.IsZero() {
+		ret.Status.ExpirationTimestamp = expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return json.Marshal(ret)
+}
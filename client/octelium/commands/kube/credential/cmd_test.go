@@ -0,0 +1,53 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetExecCredential(t *testing.T) {
+	setAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	expiresAt := getExpiresAt(timestamppb.New(setAt), 3600)
+	assert.Equal(t, setAt.Add(59*time.Minute), expiresAt)
+
+	out, err := getExecCredential("tkn", expiresAt)
+	assert.Nil(t, err)
+
+	var res map[string]any
+	assert.Nil(t, json.Unmarshal(out, &res))
+	assert.Equal(t, map[string]any{
+		"apiVersion": "client.authentication.k8s.io/v1",
+		"kind":       "ExecCredential",
+		"status": map[string]any{
+			"token":               "tkn",
+			"expirationTimestamp": "2025-01-01T10:59:00Z",
+		},
+	}, res)
+
+	out, err = getExecCredential("", time.Time{})
+	assert.Nil(t, err)
+
+	res = nil
+	assert.Nil(t, json.Unmarshal(out, &res))
+	assert.Equal(t, map[string]any{
+		"token": placeholderToken,
+	}, res["status"])
+}
@@ -0,0 +1,198 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/pkg/errors"
+)
+
+const ExecAPIVersion = "client.authentication.k8s.io/v1"
+
+// Config is a kubeconfig kept as a generic map so that the fields and entries
+// that are not managed by Octelium are preserved as-is when merging.
+type Config map[string]any
+
+type Entry struct {
+	// Name is the name of both the context and the cluster entries
+	Name string
+	// UserName is the name of the user entry
+	UserName string
+	Server   string
+	Exec     *Exec
+}
+
+type Exec struct {
+	Command string
+	Args    []string
+}
+
+// GetPath returns the kubeconfig file to be updated, which is the first path
+// set in the KUBECONFIG environment variable or ~/.kube/config by default.
+func GetPath() (string, error) {
+	if paths := filepath.SplitList(os.Getenv("KUBECONFIG")); len(paths) > 0 && paths[0] != "" {
+		return paths[0], nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, ".kube", "config"), nil
+}
+
+func GetContextName(svc, domain string) string {
+	return fmt.Sprintf("%s@%s", cliutils.GetServiceFullNameFromName(svc), domain)
+}
+
+func GetUserName(domain string) string {
+	return fmt.Sprintf("octelium@%s", domain)
+}
+
+// Load reads the kubeconfig at path. An empty Config is returned if the file
+// does not exist.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Unmarshal(nil)
+		}
+		return nil, err
+	}
+
+	return Unmarshal(data)
+}
+
+func Unmarshal(data []byte) (Config, error) {
+	ret := Config{}
+
+	jsonBytes, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.Errorf("Could not parse kubeconfig: %s", err)
+	}
+
+	if len(jsonBytes) > 0 && string(jsonBytes) != "null" {
+		if err := json.Unmarshal(jsonBytes, &ret); err != nil {
+			return nil, errors.Errorf("Could not parse kubeconfig: %s", err)
+		}
+	}
+
+	if _, ok := ret["apiVersion"]; !ok {
+		ret["apiVersion"] = "v1"
+	}
+	if _, ok := ret["kind"]; !ok {
+		ret["kind"] = "Config"
+	}
+
+	return ret, nil
+}
+
+func (c Config) Marshal() ([]byte, error) {
+	jsonBytes, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.JSONToYAML(jsonBytes)
+}
+
+// Save writes the kubeconfig to path with permissions restricted to the
+// current user.
+func (c Config) Save(path string) error {
+	data, err := c.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// Set adds or replaces the cluster, user and context entries of e.
+func (c Config) Set(e *Entry) {
+	c.setNamedItem("clusters", e.Name, "cluster", map[string]any{
+		"server": e.Server,
+	})
+
+	user := map[string]any{}
+	if e.Exec != nil {
+		user["exec"] = map[string]any{
+			"apiVersion":         ExecAPIVersion,
+			"command":            e.Exec.Command,
+			"args":               e.Exec.Args,
+			"interactiveMode":    "IfAvailable",
+			"provideClusterInfo": false,
+			"installHint":        "The octelium CLI is required to authenticate to this Cluster. Visit https://octelium.com/docs to install it",
+		}
+	}
+	c.setNamedItem("users", e.UserName, "user", user)
+
+	c.setNamedItem("contexts", e.Name, "context", map[string]any{
+		"cluster": e.Name,
+		"user":    e.UserName,
+	})
+}
+
+func (c Config) SetCurrentContext(name string) {
+	c["current-context"] = name
+}
+
+func (c Config) GetCurrentContext() string {
+	ret, _ := c["current-context"].(string)
+	return ret
+}
+
+func (c Config) HasContext(name string) bool {
+	return c.getNamedItem("contexts", name) != nil
+}
+
+func (c Config) getNamedItem(key, name string) map[string]any {
+	items, _ := c[key].([]any)
+	for _, itm := range items {
+		if m, ok := itm.(map[string]any); ok && m["name"] == name {
+			return m
+		}
+	}
+
+	return nil
+}
+
+func (c Config) setNamedItem(key, name, itemKey string, val map[string]any) {
+	item := map[string]any{
+		"name":  name,
+		itemKey: val,
+	}
+
+	items, _ := c[key].([]any)
+	for i, itm := range items {
+		if m, ok := itm.(map[string]any); ok && m["name"] == name {
+			items[i] = item
+			c[key] = items
+			return
+		}
+	}
+
+	c[key] = append(items, item)
+}
@@ -0,0 +1,130 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	cfg, err := Unmarshal([]byte(`
+apiVersion: v1
+kind: Config
+current-context: kind
+preferences:
+  colors: true
+clusters:
+- name: kind
+  cluster:
+    server: https://127.0.0.1:6443
+    certificate-authority-data: abc
+contexts:
+- name: kind
+  context:
+    cluster: kind
+    user: kind
+users:
+- name: kind
+  user:
+    token: xyz
+`))
+	assert.Nil(t, err)
+
+	entry := &Entry{
+		Name:     GetContextName("k8s", "example.com"),
+		UserName: GetUserName("example.com"),
+		Server:   "https://k8s.example.com",
+		Exec: &Exec{
+			Command: "/usr/local/bin/octelium",
+			Args:    []string{"kube", "credential", "--domain", "example.com"},
+		},
+	}
+	assert.Equal(t, "k8s.default@example.com", entry.Name)
+
+	cfg.Set(entry)
+	assert.True(t, cfg.HasContext("kind"))
+	assert.True(t, cfg.HasContext(entry.Name))
+	assert.Equal(t, "kind", cfg.GetCurrentContext())
+
+	cfg.SetCurrentContext(entry.Name)
+
+	entry.Server = "http://k8s.local.example.com:8080"
+	cfg.Set(entry)
+
+	data, err := cfg.Marshal()
+	assert.Nil(t, err)
+
+	cfg, err = Unmarshal(data)
+	assert.Nil(t, err)
+
+	assert.Equal(t, entry.Name, cfg.GetCurrentContext())
+	assert.Equal(t, map[string]any{"colors": true}, cfg["preferences"])
+
+	clusters := cfg["clusters"].([]any)
+	assert.Len(t, clusters, 2)
+	assert.Equal(t, "abc", clusters[0].(map[string]any)["cluster"].(map[string]any)["certificate-authority-data"])
+	assert.Equal(t, "http://k8s.local.example.com:8080",
+		cfg.getNamedItem("clusters", entry.Name)["cluster"].(map[string]any)["server"])
+
+	assert.Len(t, cfg["users"].([]any), 2)
+	exec := cfg.getNamedItem("users", entry.UserName)["user"].(map[string]any)["exec"].(map[string]any)
+	assert.Equal(t, ExecAPIVersion, exec["apiVersion"])
+	assert.Equal(t, "/usr/local/bin/octelium", exec["command"])
+	assert.Equal(t, []any{"kube", "credential", "--domain", "example.com"}, exec["args"])
+
+	assert.Len(t, cfg["contexts"].([]any), 2)
+	assert.Equal(t, map[string]any{
+		"cluster": entry.Name,
+		"user":    entry.UserName,
+	}, cfg.getNamedItem("contexts", entry.Name)["context"])
+}
+
+func TestLoadSave(t *testing.T) {
+	dir, err := os.MkdirTemp("", "octelium-kube-*")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ".kube", "config")
+
+	cfg, err := Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "v1", cfg["apiVersion"])
+	assert.Equal(t, "Config", cfg["kind"])
+	assert.False(t, cfg.HasContext("k8s.default@example.com"))
+
+	cfg.Set(&Entry{
+		Name:     "k8s.default@example.com",
+		UserName: "octelium@example.com",
+		Server:   "https://k8s.example.com",
+	})
+	assert.Nil(t, cfg.Save(path))
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	cfg, err = Load(path)
+	assert.Nil(t, err)
+	assert.True(t, cfg.HasContext("k8s.default@example.com"))
+
+	t.Setenv("KUBECONFIG", path+string(filepath.ListSeparator)+"/tmp/other")
+	kubeconfigPath, err := GetPath()
+	assert.Nil(t, err)
+	assert.Equal(t, path, kubeconfigPath)
+}
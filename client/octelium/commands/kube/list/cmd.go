@@ -0,0 +1,137 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"fmt"
+
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/common/printer"
+	"github.com/octelium/octelium/client/octelium/commands/kube/kubeconfig"
+	"github.com/spf13/cobra"
+)
+
+type args struct {
+	Out        string
+	Namespace  string
+	Kubeconfig string
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Namespace, "namespace", "n", "", "Filter by Namespace")
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Out, "out", "o", "", "Output format")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Kubeconfig, "kubeconfig", "",
+		"The kubeconfig file to look up the contexts in. By default, the first path in $KUBECONFIG or ~/.kube/config")
+}
+
+var Cmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the Kubernetes Services available to you",
+	Example: `
+octelium kube list
+octelium kube ls -n prod
+octelium kube list -o yaml
+	`,
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := userv1.NewMainServiceClient(conn)
+
+	svcList, err := c.ListService(ctx, &userv1.ListServiceOptions{
+		Namespace: cmdArgs.Namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	svcList.Items = filterKubernetes(svcList.Items)
+
+	if cmdArgs.Out != "" {
+		out, err := cliutils.OutFormatPrint(cmdArgs.Out, svcList)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", string(out))
+		return nil
+	}
+
+	if len(svcList.Items) == 0 {
+		cliutils.LineInfo("No Kubernetes Services Found\n")
+		return nil
+	}
+
+	kubeconfigPath := cmdArgs.Kubeconfig
+	if kubeconfigPath == "" {
+		kubeconfigPath, err = kubeconfig.GetPath()
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	p := printer.NewPrinter("Name", "Namespace", "Public", "Context")
+	for _, svc := range svcList.Items {
+		svcNs, _ := cliutils.ParseServiceNamespace(svc.Metadata.Name)
+		ctxName := kubeconfig.GetContextName(svc.Metadata.Name, i.Domain)
+		if !cfg.HasContext(ctxName) {
+			ctxName = ""
+		} else if cfg.GetCurrentContext() == ctxName {
+			ctxName = fmt.Sprintf("%s (current)", ctxName)
+		}
+
+		p.AppendRow(svcNs.Service, svcNs.Namespace, cliutils.PrintBoolean(svc.Spec.IsPublic), ctxName)
+	}
+
+	p.Render()
+
+	return nil
+}
+
+func filterKubernetes(svcs []*userv1.Service) []*userv1.Service {
+	var ret []*userv1.Service
+	for _, svc := range svcs {
+		if svc.Spec.Type == userv1.Service_Spec_KUBERNETES {
+			ret = append(ret, svc)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,195 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/kube/kubeconfig"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type args struct {
+	Kubeconfig         string
+	Mode               string
+	Context            string
+	KeepCurrentContext bool
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Kubeconfig, "kubeconfig", "",
+		"The kubeconfig file to update. By default, the first path in $KUBECONFIG or ~/.kube/config")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Mode, "mode", "auto",
+		`How to reach the Kubernetes Service. "tunnel" uses the private address of the Service and requires being connected to the Cluster,
+"public" uses the public endpoint of the Service which has to be public, "auto" uses the public endpoint if available and the tunnel otherwise`)
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Context, "context", "",
+		"Override the context name. By default, it is set to <SERVICE>.<NAMESPACE>@<DOMAIN>")
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.KeepCurrentContext, "keep-current-context", false,
+		"Do not switch the current context to the Service's context")
+}
+
+var Cmd = &cobra.Command{
+	Use:   "login",
+	Short: "Add a kubeconfig context for a Kubernetes Service",
+	Example: `
+octelium kube login k8s
+octelium kube login k8s.prod --domain example.com
+
+# Always use the public endpoint of the Service
+octelium kube login k8s --mode public
+
+# Write the context to a specific kubeconfig file
+octelium kube login k8s --kubeconfig ~/.kube/octelium
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := userv1.NewMainServiceClient(conn)
+
+	svc, err := c.GetService(ctx, &metav1.GetOptions{
+		Name: i.FirstArg(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if svc.Spec.Type != userv1.Service_Spec_KUBERNETES {
+		return errors.Errorf("The Service %s is not a Kubernetes Service", svc.Metadata.Name)
+	}
+
+	server, err := getServer(ctx, c, svc, i.Domain, cmdArgs.Mode)
+	if err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	credArgs := []string{"kube", "credential", "--domain", i.Domain}
+	if f := cmd.Flags().Lookup("homedir"); f != nil && f.Value.String() != "" {
+		credArgs = append(credArgs, "--homedir", f.Value.String())
+	}
+
+	entry := &kubeconfig.Entry{
+		Name:     cmdArgs.Context,
+		UserName: kubeconfig.GetUserName(i.Domain),
+		Server:   server,
+		Exec: &kubeconfig.Exec{
+			Command: exe,
+			Args:    credArgs,
+		},
+	}
+	if entry.Name == "" {
+		entry.Name = kubeconfig.GetContextName(svc.Metadata.Name, i.Domain)
+	}
+
+	kubeconfigPath := cmdArgs.Kubeconfig
+	if kubeconfigPath == "" {
+		kubeconfigPath, err = kubeconfig.GetPath()
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	cfg.Set(entry)
+	if !cmdArgs.KeepCurrentContext {
+		cfg.SetCurrentContext(entry.Name)
+	}
+
+	if err := cfg.Save(kubeconfigPath); err != nil {
+		return err
+	}
+
+	cliutils.LineNotify("The context %s has been set in %s\n", entry.Name, kubeconfigPath)
+	if cmdArgs.KeepCurrentContext {
+		cliutils.LineInfo("Use it via: kubectl --context %s get pods\n", entry.Name)
+	}
+
+	return nil
+}
+
+func getServer(ctx context.Context,
+	c userv1.MainServiceClient, svc *userv1.Service, domain, mode string) (string, error) {
+	switch mode {
+	case "", "auto":
+		if svc.Spec.IsPublic {
+			return getPublicServer(svc, domain), nil
+		}
+		return getTunnelServer(ctx, c, svc)
+	case "public":
+		if !svc.Spec.IsPublic {
+			return "", errors.Errorf("The Service %s is not public. Use the tunnel mode instead", svc.Metadata.Name)
+		}
+		return getPublicServer(svc, domain), nil
+	case "tunnel":
+		return getTunnelServer(ctx, c, svc)
+	default:
+		return "", errors.Errorf("Invalid mode: %s. It must be either auto, tunnel or public", mode)
+	}
+}
+
+func getPublicServer(svc *userv1.Service, domain string) string {
+	return fmt.Sprintf("https://%s.%s", svc.Status.PrimaryHostname, domain)
+}
+
+func getTunnelServer(ctx context.Context, c userv1.MainServiceClient, svc *userv1.Service) (string, error) {
+	resp, err := c.SetServiceConfigs(ctx, &userv1.SetServiceConfigsRequest{
+		Name: svc.Metadata.Name,
+	})
+	if err != nil {
+		return "", errors.Errorf("Could not get the Service's private address. Make sure that you are connected to the Cluster first: %s", err)
+	}
+
+	scheme := "http"
+	if svc.Spec.IsTLS {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(resp.Host, fmt.Sprintf("%d", resp.Port))), nil
+}
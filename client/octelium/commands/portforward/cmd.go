@@ -0,0 +1,279 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/authenticator"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/octelium/commands/connect"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type args struct {
+	Address string
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Address, "address", "localhost",
+		"The default local address to listen on for forwards that do not explicitly set one")
+}
+
+var Cmd = &cobra.Command{
+	Use:     "port-forward <service> [local-port]",
+	Aliases: []string{"pf"},
+	Short:   "Forward local ports to Services without a full connection",
+	Long: `
+Forward one or more local ports to Services through a userspace tunnel without installing
+any network device, routes or DNS configuration on the host. TCP as well as UDP-based Services
+(e.g. DNS and UDP Services) are supported. Each forward is in the format SERVICE[:[ADDRESS:]PORT].
+If the local port is not set, the Service's port is used instead.
+`,
+	Example: `
+# Forward localhost:5432 to the Service "postgres" in the "default" Namespace
+octelium port-forward postgres 5432
+
+# Forward localhost:15432 to the Service "db" in the "production" Namespace
+octelium port-forward db.production 15432
+
+# Forward multiple Services from the same process
+octelium port-forward postgres:5432 redis:6379 dns.infra:5353
+
+# Listen on all interfaces
+octelium port-forward postgres:0.0.0.0:5432
+	`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	domain := i.Domain
+
+	forwards, err := parseForwards(args, cmdArgs.Address)
+	if err != nil {
+		return err
+	}
+
+	if conn, err := connreg.New().Get(domain); err == nil {
+		return errors.Errorf("There is already an active connection to the Cluster %s on this host (pid %d). "+
+			"Use `octelium connect --publish` instead", domain, conn.PID)
+	}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := authenticator.Authenticate(ctx, &authenticator.AuthenticateOpts{
+		Domain: domain,
+	}); err != nil {
+		return err
+	}
+
+	if err := resolveForwards(ctx, domain, forwards); err != nil {
+		return err
+	}
+
+	var once sync.Once
+	return connect.Run(ctx, domain, &connect.Options{
+		IgnoreDNS:       true,
+		UseNetstack:     true,
+		PublishServices: getPublishServices(forwards),
+		OnUpdate: func(conn *connreg.Connection) {
+			if conn.State != connreg.StateConnected {
+				return
+			}
+			once.Do(func() {
+				for _, f := range forwards {
+					cliutils.LineNotify("Forwarding %s -> %s\n", f.local(), f.remote())
+				}
+			})
+		},
+	})
+}
+
+type forward struct {
+	svc  string
+	addr string
+	port int
+
+	svcPort int
+	l4Type  string
+}
+
+func (f *forward) local() string {
+	return fmt.Sprintf("%s (%s)", joinHostPort(f.addr, f.port), f.l4Type)
+}
+
+func (f *forward) remote() string {
+	return joinHostPort(cliutils.GetServiceFullNameFromName(f.svc), f.svcPort)
+}
+
+func joinHostPort(host string, port int) string {
+	if strings.Contains(host, ":") {
+		return fmt.Sprintf("[%s]:%d", host, port)
+	}
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+func resolveForwards(ctx context.Context, domain string, forwards []*forward) error {
+	conn, err := client.GetGRPCClientConn(ctx, domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	svcList, err := userv1.NewMainServiceClient(conn).ListService(ctx, &userv1.ListServiceOptions{})
+	if err != nil {
+		return err
+	}
+
+	return setForwardsFromServices(forwards, svcList)
+}
+
+func setForwardsFromServices(forwards []*forward, svcList *userv1.ServiceList) error {
+	getService := func(name string) *userv1.Service {
+		for _, itm := range svcList.Items {
+			if cliutils.GetServiceFullNameFromName(itm.Metadata.Name) == cliutils.GetServiceFullNameFromName(name) {
+				return itm
+			}
+		}
+		return nil
+	}
+
+	type listenerKey struct {
+		addr   string
+		port   int
+		l4Type string
+	}
+	listeners := make(map[listenerKey]string)
+
+	for _, f := range forwards {
+		svc := getService(f.svc)
+		if svc == nil {
+			return errors.Errorf("The Service %s does not exist", f.svc)
+		}
+
+		f.svcPort = int(svc.Spec.Port)
+		if f.port == 0 {
+			f.port = f.svcPort
+		}
+
+		switch svc.Spec.Type {
+		case userv1.Service_Spec_UDP, userv1.Service_Spec_DNS:
+			f.l4Type = "UDP"
+		default:
+			f.l4Type = "TCP"
+		}
+
+		key := listenerKey{addr: f.addr, port: f.port, l4Type: f.l4Type}
+		if other, ok := listeners[key]; ok {
+			return errors.Errorf("The Services %s and %s are forwarded to the same local %s port %s",
+				other, f.svc, f.l4Type, joinHostPort(f.addr, f.port))
+		}
+		listeners[key] = f.svc
+	}
+
+	return nil
+}
+
+// parseForwards parses the command's arguments. The arguments are either a
+// Service followed by a local port or a list of forwards, each in the format
+// SERVICE[:[ADDRESS:]PORT].
+func parseForwards(args []string, defaultAddr string) ([]*forward, error) {
+	if len(args) == 2 && !strings.Contains(args[0], ":") && isPort(args[1]) {
+		args = []string{fmt.Sprintf("%s:%s", args[0], args[1])}
+	}
+
+	var ret []*forward
+	for _, arg := range args {
+		f, err := parseForward(arg, defaultAddr)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, f)
+	}
+
+	return ret, nil
+}
+
+func parseForward(arg, defaultAddr string) (*forward, error) {
+	parts := strings.SplitN(arg, ":", 2)
+
+	ret := &forward{
+		svc:  parts[0],
+		addr: defaultAddr,
+	}
+
+	if ret.svc == "" || isPort(ret.svc) {
+		return nil, errors.Errorf("Invalid forward %s. It must be in the format SERVICE[:[ADDRESS:]PORT]", arg)
+	}
+
+	if _, err := cliutils.ParseServiceNamespace(ret.svc); err != nil {
+		return nil, err
+	}
+
+	if len(parts) == 2 {
+		portStr := parts[1]
+		if idx := strings.LastIndex(parts[1], ":"); idx >= 0 {
+			ret.addr = strings.Trim(parts[1][:idx], "[]")
+			portStr = parts[1][idx+1:]
+		}
+
+		if !isPort(portStr) {
+			return nil, errors.Errorf("Invalid port in the forward %s", arg)
+		}
+		ret.port, _ = strconv.Atoi(portStr)
+	}
+
+	if ret.addr != "localhost" && !govalidator.IsIP(ret.addr) {
+		return nil, errors.Errorf("Invalid address %s in the forward %s", ret.addr, arg)
+	}
+
+	return ret, nil
+}
+
+func isPort(arg string) bool {
+	port, err := strconv.Atoi(arg)
+	return err == nil && strconv.Itoa(port) == arg && port > 0 && port < 65536
+}
+
+func getPublishServices(forwards []*forward) []string {
+	var ret []string
+	for _, f := range forwards {
+		ret = append(ret, fmt.Sprintf("%s:%s", f.svc, joinHostPort(f.addr, f.port)))
+	}
+	return ret
+}
@@ -0,0 +1,147 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package portforward
+
+import (
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseForwards(t *testing.T) {
+	{
+		res, err := parseForwards([]string{"postgres", "5432"}, "localhost")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(res))
+		assert.Equal(t, "postgres", res[0].svc)
+		assert.Equal(t, "localhost", res[0].addr)
+		assert.Equal(t, 5432, res[0].port)
+	}
+
+	{
+		res, err := parseForwards([]string{"postgres"}, "127.0.0.1")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(res))
+		assert.Equal(t, "127.0.0.1", res[0].addr)
+		assert.Equal(t, 0, res[0].port)
+	}
+
+	{
+		res, err := parseForwards([]string{"postgres", "redis"}, "localhost")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(res))
+		assert.Equal(t, "redis", res[1].svc)
+	}
+
+	{
+		res, err := parseForwards([]string{
+			"db.prod:15432",
+			"redis:0.0.0.0:6379",
+			"dns.infra:[::1]:5353",
+		}, "localhost")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(res))
+
+		assert.Equal(t, "db.prod", res[0].svc)
+		assert.Equal(t, 15432, res[0].port)
+
+		assert.Equal(t, "0.0.0.0", res[1].addr)
+		assert.Equal(t, 6379, res[1].port)
+
+		assert.Equal(t, "::1", res[2].addr)
+		assert.Equal(t, 5353, res[2].port)
+
+		assert.Equal(t, []string{
+			"db.prod:localhost:15432",
+			"redis:0.0.0.0:6379",
+			"dns.infra:[::1]:5353",
+		}, getPublishServices(res))
+	}
+
+	invalids := [][]string{
+		{":5432"},
+		{"a.b.c:5432"},
+		{"postgres:0"},
+		{"postgres:70000"},
+		{"postgres:abc"},
+		{"postgres:example.com:5432"},
+		{"postgres", "5432", "redis"},
+	}
+	for _, args := range invalids {
+		_, err := parseForwards(args, "localhost")
+		assert.NotNil(t, err, "%v", args)
+	}
+
+	{
+		_, err := parseForwards([]string{"postgres"}, "example.com")
+		assert.NotNil(t, err)
+	}
+}
+
+func TestSetForwardsFromServices(t *testing.T) {
+	svcList := &userv1.ServiceList{
+		Items: []*userv1.Service{
+			{
+				Metadata: &metav1.Metadata{Name: "postgres.default"},
+				Spec: &userv1.Service_Spec{
+					Port: 5432,
+					Type: userv1.Service_Spec_POSTGRES,
+				},
+			},
+			{
+				Metadata: &metav1.Metadata{Name: "dns.infra"},
+				Spec: &userv1.Service_Spec{
+					Port: 53,
+					Type: userv1.Service_Spec_DNS,
+				},
+			},
+		},
+	}
+
+	{
+		forwards, err := parseForwards([]string{"postgres", "dns.infra:5353"}, "localhost")
+		assert.Nil(t, err)
+		assert.Nil(t, setForwardsFromServices(forwards, svcList))
+
+		assert.Equal(t, 5432, forwards[0].port)
+		assert.Equal(t, "TCP", forwards[0].l4Type)
+		assert.Equal(t, "localhost:5432 (TCP)", forwards[0].local())
+		assert.Equal(t, "postgres.default:5432", forwards[0].remote())
+
+		assert.Equal(t, 5353, forwards[1].port)
+		assert.Equal(t, 53, forwards[1].svcPort)
+		assert.Equal(t, "UDP", forwards[1].l4Type)
+	}
+
+	{
+		forwards, err := parseForwards([]string{"postgres:5353", "dns.infra:5353"}, "localhost")
+		assert.Nil(t, err)
+		assert.Nil(t, setForwardsFromServices(forwards, svcList))
+	}
+
+	{
+		forwards, err := parseForwards([]string{"postgres:6000", "postgres.default:6000"}, "localhost")
+		assert.Nil(t, err)
+		assert.NotNil(t, setForwardsFromServices(forwards, svcList))
+	}
+
+	{
+		forwards, err := parseForwards([]string{"redis"}, "localhost")
+		assert.Nil(t, err)
+		assert.NotNil(t, setForwardsFromServices(forwards, svcList))
+	}
+}
@@ -15,22 +15,38 @@
 package status
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
 	"github.com/octelium/octelium/apis/main/userv1"
 	"github.com/octelium/octelium/client/common/client"
 	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/common/printer"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+	"github.com/octelium/octelium/client/octelium/commands/daemon/daemonc"
+	utils_types "github.com/octelium/octelium/pkg/utils/types"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type args struct {
-	Out string
+	Out   string
+	All   bool
+	Watch bool
 }
 
 var cmdArgs args
 
 func init() {
 	Cmd.PersistentFlags().StringVarP(&cmdArgs.Out, "out", "o", "yaml", "Output format")
+	Cmd.PersistentFlags().BoolVarP(&cmdArgs.All, "all", "A", false,
+		"Show the active connections to all Clusters on this host. This is the default if no Cluster domain is set")
+	Cmd.PersistentFlags().BoolVarP(&cmdArgs.Watch, "watch", "w", false,
+		"Watch the connection events of the octelium daemon. This implies --all")
 }
 
 var Cmd = &cobra.Command{
@@ -40,6 +56,12 @@ var Cmd = &cobra.Command{
 octelium status
 octelium status -o yaml
 octelium status -o json
+
+# Show the active connections to all Clusters on this host
+octelium status --all
+
+# Watch the connection events while the octelium daemon is running
+octelium status --watch
 	`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -48,9 +70,13 @@ octelium status -o json
 }
 
 func doCmd(cmd *cobra.Command, args []string) error {
+	if cmdArgs.All || cmdArgs.Watch {
+		return doShowConnections(cmd)
+	}
+
 	i, err := cliutils.GetCLIInfo(cmd, args)
 	if err != nil {
-		return err
+		return doShowConnections(cmd)
 	}
 
 	conn, err := client.GetGRPCClientConn(cmd.Context(), i.Domain)
@@ -77,3 +103,156 @@ func doCmd(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func doShowConnections(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	if cmdArgs.Watch {
+		return doWatch(cmd)
+	}
+
+	st, err := getConnectionsStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("out") {
+		out, err := cliutils.OutFormatPrint(cmdArgs.Out, st)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", string(out))
+		return nil
+	}
+
+	if len(st.Connections) == 0 {
+		cliutils.LineInfo("There are no active connections on this host\n")
+		return nil
+	}
+
+	p := printer.NewPrinter("Domain", "State", "Managed", "Device", "IP Mode", "Addresses", "Local DNS",
+		"Received", "Sent", "PID", "Age")
+	for _, conn := range st.Connections {
+		p.AppendRow(getConnectionRow(conn)...)
+	}
+
+	p.Render()
+
+	return nil
+}
+
+// getConnectionsStatus returns the status from the octelium daemon if it is
+// running, otherwise it builds the status from the host's connection registry.
+func getConnectionsStatus(ctx context.Context) (*clidaemonv1.Status, error) {
+	if c, err := daemonc.GetClient(ctx); err == nil {
+		defer c.Close()
+		return c.GetStatus(ctx, &clidaemonv1.GetStatusRequest{})
+	}
+
+	conns, err := connreg.New().List()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &clidaemonv1.Status{}
+	for _, conn := range conns {
+		ret.Connections = append(ret.Connections, daemonc.FromRegistry(conn))
+	}
+
+	return ret, nil
+}
+
+func doWatch(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	c, err := daemonc.GetClient(ctx)
+	if err != nil {
+		return errors.Errorf("Watching the connections requires the octelium daemon to be running")
+	}
+	defer c.Close()
+
+	stream, err := c.WatchEvents(ctx, &clidaemonv1.WatchEventsRequest{})
+	if err != nil {
+		return err
+	}
+
+	// CONNECTION_UPDATED events are also sent whenever the traffic counters
+	// change. Only the changes of the state are printed.
+	lastStates := make(map[string]string)
+
+	for {
+		evt, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if cmd.Flags().Changed("out") {
+			out, err := cliutils.OutFormatPrint(cmdArgs.Out, evt)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", string(out))
+			continue
+		}
+
+		switch evt.Type {
+		case clidaemonv1.Event_NETWORK_CHANGED:
+			cliutils.LineInfo("Network changed. Reconnecting\n")
+		case clidaemonv1.Event_CONNECTION_REMOVED:
+			delete(lastStates, evt.Connection.GetDomain())
+			cliutils.LineInfo("%s: disconnected\n", evt.Connection.GetDomain())
+		case clidaemonv1.Event_SERVICE_ADDED:
+			cliutils.LineInfo("%s: serving the Service %s\n",
+				evt.Connection.GetDomain(), evt.Service.GetName())
+		case clidaemonv1.Event_SERVICE_DELETED:
+			cliutils.LineInfo("%s: stopped serving the Service %s\n",
+				evt.Connection.GetDomain(), evt.Service.GetName())
+		case clidaemonv1.Event_CONNECTION_UPDATED:
+			conn := evt.Connection
+			state := fmt.Sprintf("%s/%s", getStateStr(conn), conn.LastError)
+			if lastStates[conn.Domain] == state {
+				continue
+			}
+			lastStates[conn.Domain] = state
+
+			if conn.LastError != "" {
+				cliutils.LineWarn("%s: %s (%s)\n", conn.Domain, getStateStr(conn), conn.LastError)
+			} else {
+				cliutils.LineInfo("%s: %s\n", conn.Domain, getStateStr(conn))
+			}
+		}
+	}
+}
+
+func getStateStr(conn *clidaemonv1.Connection) string {
+	return strings.ToLower(conn.State.String())
+}
+
+func getConnectionRow(conn *clidaemonv1.Connection) []string {
+	device := conn.DeviceName
+	if conn.IsNetstack {
+		device = "gvisor"
+	}
+
+	age := ""
+	if conn.StartedAt.IsValid() {
+		age = utils_types.HumanDuration(time.Since(conn.StartedAt.AsTime()))
+	}
+
+	return []string{
+		conn.Domain,
+		getStateStr(conn),
+		fmt.Sprintf("%t", conn.IsManaged),
+		device,
+		conn.L3Mode,
+		strings.Join(conn.Addresses, ", "),
+		conn.LocalDNSAddress,
+		utils_types.HumanBytes(conn.GetStats().GetRxBytes()),
+		utils_types.HumanBytes(conn.GetStats().GetTxBytes()),
+		fmt.Sprintf("%d", conn.Pid),
+		age,
+	}
+}
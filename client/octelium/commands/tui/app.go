@@ -0,0 +1,296 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/octelium/commands/connect/connreg"
+	"github.com/octelium/octelium/client/octelium/commands/daemon/daemonc"
+	"go.uber.org/zap"
+)
+
+const (
+	redrawInterval   = 500 * time.Millisecond
+	reloadInterval   = 30 * time.Second
+	pollInterval     = 2 * time.Second
+	listItemsPerPage = 500
+)
+
+type app struct {
+	domain string
+	// globalArgs are the global flags passed to the launched octelium commands
+	globalArgs []string
+	c          userv1.MainServiceClient
+
+	scr      *screen
+	m        *model
+	reader   *inputReader
+	inputCh  chan []byte
+	updateCh chan func(*model)
+	reloadCh chan struct{}
+}
+
+// update schedules fn to be applied to the model by the main loop.
+func (a *app) update(ctx context.Context, fn func(*model)) {
+	select {
+	case a.updateCh <- fn:
+	case <-ctx.Done():
+	}
+}
+
+func (a *app) triggerReload() {
+	select {
+	case a.reloadCh <- struct{}{}:
+	default:
+	}
+}
+
+func (a *app) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := a.scr.enter(); err != nil {
+		return err
+	}
+	defer a.scr.leave()
+
+	a.reader = startInputReader(a.scr.in, a.inputCh)
+	defer func() {
+		a.reader.stop()
+	}()
+
+	go a.startReloadLoop(ctx)
+	go a.watchConnection(ctx)
+
+	ticker := time.NewTicker(redrawInterval)
+	defer ticker.Stop()
+
+	for {
+		width, height := a.scr.size()
+		if err := a.scr.draw(a.m.view(width, height)); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case fn := <-a.updateCh:
+			fn(a.m)
+		case <-ticker.C:
+		case b := <-a.inputCh:
+			for _, k := range parseKeys(b) {
+				act := a.m.handleKey(k)
+				switch act.typ {
+				case actionQuit:
+					return nil
+				case actionRefresh:
+					a.triggerReload()
+				case actionExec:
+					if err := a.exec(ctx, act.args); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+}
+
+func (a *app) startReloadLoop(ctx context.Context) {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-a.reloadCh:
+		}
+
+		res, err := a.load(ctx)
+		a.update(ctx, func(m *model) {
+			if err != nil {
+				m.setError(err)
+				return
+			}
+			res.apply(m)
+		})
+	}
+}
+
+type loadResult struct {
+	status     *userv1.GetStatusResponse
+	namespaces []string
+	services   []*userv1.Service
+}
+
+func (r *loadResult) apply(m *model) {
+	m.status = r.status
+	m.setNamespaces(r.namespaces, "")
+	m.setServices(r.services)
+}
+
+func (a *app) load(ctx context.Context) (*loadResult, error) {
+	ret := &loadResult{}
+	var err error
+
+	ret.status, err = a.c.GetStatus(ctx, &userv1.GetStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	nsList, err := a.c.ListNamespace(ctx, &userv1.ListNamespaceOptions{
+		Common: &metav1.CommonListOptions{
+			ItemsPerPage: listItemsPerPage,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range nsList.Items {
+		ret.namespaces = append(ret.namespaces, ns.Metadata.Name)
+	}
+
+	for page := uint32(0); ; page++ {
+		svcList, err := a.c.ListService(ctx, &userv1.ListServiceOptions{
+			Common: &metav1.CommonListOptions{
+				Page:         page,
+				ItemsPerPage: listItemsPerPage,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ret.services = append(ret.services, svcList.Items...)
+		if !svcList.GetListResponseMeta().GetHasMore() || len(svcList.Items) == 0 {
+			break
+		}
+	}
+
+	return ret, nil
+}
+
+// watchConnection follows the state of the connection to the Cluster via the
+// events of the octelium daemon if it is running. Otherwise, the connection
+// registry of the host is polled.
+func (a *app) watchConnection(ctx context.Context) {
+	if c, err := daemonc.GetClient(ctx); err == nil {
+		defer c.Close()
+		if err := a.watchDaemonEvents(ctx, c); err != nil && ctx.Err() == nil {
+			zap.L().Debug("Could not watch the daemon events", zap.Error(err))
+		}
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		var conn *clidaemonv1.Connection
+		if regConn, err := connreg.New().Get(a.domain); err == nil {
+			conn = daemonc.FromRegistry(regConn)
+		}
+
+		a.update(ctx, func(m *model) {
+			m.setConnection(conn, time.Now())
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *app) watchDaemonEvents(ctx context.Context, c *daemonc.Client) error {
+	stream, err := c.WatchEvents(ctx, &clidaemonv1.WatchEventsRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		evt, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		a.update(ctx, func(m *model) {
+			if m.onEvent(evt, time.Now()) {
+				a.triggerReload()
+			}
+		})
+	}
+}
+
+// exec runs an octelium command in the foreground and returns to the UI
+// once it exits and the User presses enter.
+func (a *app) exec(ctx context.Context, args []string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		a.m.setError(err)
+		return nil
+	}
+
+	cmdArgs := append([]string{args[0]}, a.globalArgs...)
+	cmdArgs = append(cmdArgs, args[1:]...)
+
+	a.reader.stop()
+	a.scr.leave()
+
+	// The command handles SIGINT itself, e.g. to stop port-forwarding, which
+	// must not quit the UI.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+
+	fmt.Fprintf(a.scr.out, "$ octelium %s\n", strings.Join(args, " "))
+
+	cmd := exec.CommandContext(ctx, exe, cmdArgs...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+
+	signal.Stop(sigCh)
+
+	fmt.Fprintf(a.scr.out, "\nPress enter to return to the Services list")
+	bufio.NewReader(a.scr.in).ReadString('\n')
+
+	if err := a.scr.enter(); err != nil {
+		return err
+	}
+	a.reader = startInputReader(a.scr.in, a.inputCh)
+
+	if runErr != nil {
+		a.m.setError(fmt.Errorf("octelium %s: %s", args[0], runErr))
+	} else {
+		a.m.message = ""
+	}
+
+	a.triggerReload()
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+type args struct {
+	Namespace string
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Namespace, "namespace", "n", "", "Initially show the Services of this Namespace only")
+}
+
+var Cmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse your Services and connections in an interactive terminal UI",
+	Long: `Browse the Namespaces and Services available to you in an interactive terminal UI.
+The UI shows the live state and traffic of the connection to the Cluster, the Services
+served by this host and lets you port-forward Services or ssh/cp to Sessions.`,
+	Example: `
+octelium tui
+octelium tui --domain example.com -n prod
+	`,
+	Aliases: []string{"ui"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.Errorf("The terminal UI requires an interactive terminal")
+	}
+
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGTERM)
+	defer cancel()
+
+	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	a := &app{
+		domain:     i.Domain,
+		globalArgs: getGlobalArgs(cmd, i.Domain),
+		c:          userv1.NewMainServiceClient(conn),
+		scr:        newScreen(os.Stdin, os.Stdout),
+		m:          newModel(i.Domain),
+		inputCh:    make(chan []byte, 16),
+		updateCh:   make(chan func(*model), 64),
+		reloadCh:   make(chan struct{}, 1),
+	}
+
+	res, err := a.load(ctx)
+	if err != nil {
+		return err
+	}
+	res.apply(a.m)
+	a.m.setNamespaces(res.namespaces, cmdArgs.Namespace)
+
+	return a.run(ctx)
+}
+
+func getGlobalArgs(cmd *cobra.Command, domain string) []string {
+	ret := []string{fmt.Sprintf("--domain=%s", domain)}
+	if f := cmd.Flags().Lookup("homedir"); f != nil && f.Value.String() != "" {
+		ret = append(ret, fmt.Sprintf("--homedir=%s", f.Value.String()))
+	}
+
+	return ret
+}
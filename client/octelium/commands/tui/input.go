@@ -0,0 +1,81 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const inputPollInterval = 100 * time.Millisecond
+
+// inputReader reads the terminal input in the background. Reads are only
+// issued once input is available so that stopping the reader does not leave
+// a pending read behind, which would otherwise steal the input of the
+// commands launched from the UI.
+type inputReader struct {
+	cancelFn context.CancelFunc
+	doneCh   chan struct{}
+}
+
+func startInputReader(f *os.File, ch chan<- []byte) *inputReader {
+	ctx, cancelFn := context.WithCancel(context.Background())
+	ret := &inputReader{
+		cancelFn: cancelFn,
+		doneCh:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(ret.doneCh)
+		readInput(ctx, f, ch)
+	}()
+
+	return ret
+}
+
+func (r *inputReader) stop() {
+	r.cancelFn()
+	<-r.doneCh
+}
+
+func readInput(ctx context.Context, f *os.File, ch chan<- []byte) {
+	buf := make([]byte, 256)
+
+	for ctx.Err() == nil {
+		isReady, err := waitInput(f, inputPollInterval)
+		if err != nil {
+			zap.L().Debug("Could not wait for the terminal input", zap.Error(err))
+			return
+		}
+		if !isReady {
+			continue
+		}
+
+		n, err := f.Read(buf)
+		if err != nil {
+			zap.L().Debug("Could not read the terminal input", zap.Error(err))
+			return
+		}
+
+		select {
+		case ch <- append([]byte(nil), buf[:n]...):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
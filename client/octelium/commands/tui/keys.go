@@ -0,0 +1,115 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import "unicode/utf8"
+
+type keyType int
+
+const (
+	keyRune keyType = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyTab
+	keyBackTab
+	keyBackspace
+	keyEsc
+	keyCtrlC
+)
+
+type key struct {
+	typ keyType
+	r   rune
+}
+
+var csiKeys = map[string]keyType{
+	"A":  keyUp,
+	"B":  keyDown,
+	"C":  keyRight,
+	"D":  keyLeft,
+	"H":  keyHome,
+	"F":  keyEnd,
+	"Z":  keyBackTab,
+	"1~": keyHome,
+	"4~": keyEnd,
+	"5~": keyPageUp,
+	"6~": keyPageDown,
+	"7~": keyHome,
+	"8~": keyEnd,
+}
+
+// parseKeys converts the raw terminal input to keys. Unknown escape
+// sequences are ignored.
+func parseKeys(b []byte) []key {
+	var ret []key
+
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			if len(b) == 1 {
+				return append(ret, key{typ: keyEsc})
+			}
+
+			if b[1] != '[' && b[1] != 'O' {
+				ret = append(ret, key{typ: keyEsc})
+				b = b[1:]
+				continue
+			}
+
+			// CSI/SS3 sequences end with a byte in the range 0x40-0x7e
+			end := 2
+			for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+				end++
+			}
+			if end == len(b) {
+				return ret
+			}
+
+			if typ, ok := csiKeys[string(b[2:end+1])]; ok {
+				ret = append(ret, key{typ: typ})
+			}
+			b = b[end+1:]
+		case c == '\r' || c == '\n':
+			ret = append(ret, key{typ: keyEnter})
+			b = b[1:]
+		case c == '\t':
+			ret = append(ret, key{typ: keyTab})
+			b = b[1:]
+		case c == 0x7f || c == 0x08:
+			ret = append(ret, key{typ: keyBackspace})
+			b = b[1:]
+		case c == 0x03:
+			ret = append(ret, key{typ: keyCtrlC})
+			b = b[1:]
+		case c < 0x20:
+			b = b[1:]
+		default:
+			r, size := utf8.DecodeRune(b)
+			if r != utf8.RuneError {
+				ret = append(ret, key{typ: keyRune, r: r})
+			}
+			b = b[size:]
+		}
+	}
+
+	return ret
+}
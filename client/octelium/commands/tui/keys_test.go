@@ -0,0 +1,67 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeys(t *testing.T) {
+	tstCases := []struct {
+		arg string
+		res []key
+	}{
+		{
+			arg: "q",
+			res: []key{{typ: keyRune, r: 'q'}},
+		},
+		{
+			arg: "\x1b[A\x1b[B\x1bOC\x1b[D",
+			res: []key{{typ: keyUp}, {typ: keyDown}, {typ: keyRight}, {typ: keyLeft}},
+		},
+		{
+			arg: "\x1b[5~\x1b[6~\x1b[Z",
+			res: []key{{typ: keyPageUp}, {typ: keyPageDown}, {typ: keyBackTab}},
+		},
+		{
+			arg: "ab\r\t\x7f\x03",
+			res: []key{
+				{typ: keyRune, r: 'a'}, {typ: keyRune, r: 'b'},
+				{typ: keyEnter}, {typ: keyTab}, {typ: keyBackspace}, {typ: keyCtrlC},
+			},
+		},
+		{
+			arg: "\x1b",
+			res: []key{{typ: keyEsc}},
+		},
+		{
+			arg: "\x1bx",
+			res: []key{{typ: keyEsc}, {typ: keyRune, r: 'x'}},
+		},
+		{
+			arg: "\x1b[1;5Aé",
+			res: []key{{typ: keyRune, r: 'é'}},
+		},
+		{
+			arg: "\x1b[12",
+		},
+	}
+
+	for _, tstCase := range tstCases {
+		assert.Equal(t, tstCase.res, parseKeys([]byte(tstCase.arg)), "%q", tstCase.arg)
+	}
+}
@@ -0,0 +1,555 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/client/common/cliutils"
+	utils_types "github.com/octelium/octelium/pkg/utils/types"
+)
+
+type inputMode int
+
+const (
+	modeList inputMode = iota
+	modeFilter
+	modePrompt
+)
+
+type actionType int
+
+const (
+	actionNone actionType = iota
+	actionQuit
+	actionRefresh
+	actionExec
+)
+
+// action is the result of a key press that has to be carried out by the app.
+type action struct {
+	typ actionType
+	// args are the octelium command and its arguments for actionExec
+	args []string
+}
+
+type promptType int
+
+const (
+	promptSSH promptType = iota
+	promptCP
+)
+
+type prompt struct {
+	typ   promptType
+	label string
+	input string
+}
+
+type rates struct {
+	rx float64
+	tx float64
+}
+
+// model is the state of the UI. It is only accessed from the app's main loop.
+type model struct {
+	domain string
+
+	status     *userv1.GetStatusResponse
+	namespaces []string
+	// nsIdx is the index of the selected Namespace in namespaces plus one.
+	// 0 means all Namespaces
+	nsIdx    int
+	services []*userv1.Service
+	// hosted are the Services served by this host's connection
+	hosted map[string]*userv1.HostedService
+
+	conn        *clidaemonv1.Connection
+	rates       rates
+	lastStatsAt time.Time
+
+	mode     inputMode
+	filter   string
+	prompt   prompt
+	selected int
+	offset   int
+
+	message string
+	isError bool
+}
+
+func newModel(domain string) *model {
+	return &model{
+		domain: domain,
+		hosted: make(map[string]*userv1.HostedService),
+	}
+}
+
+func (m *model) setInfo(msg string, args ...any) {
+	m.message = fmt.Sprintf(msg, args...)
+	m.isError = false
+}
+
+func (m *model) setError(err error) {
+	m.message = err.Error()
+	m.isError = true
+}
+
+func (m *model) setNamespaces(namespaces []string, current string) {
+	if current == "" {
+		current = m.getNamespace()
+	}
+
+	m.namespaces = append([]string(nil), namespaces...)
+	sort.Strings(m.namespaces)
+
+	m.nsIdx = 0
+	for i, ns := range m.namespaces {
+		if ns == current {
+			m.nsIdx = i + 1
+		}
+	}
+}
+
+func (m *model) getNamespace() string {
+	if m.nsIdx == 0 || m.nsIdx > len(m.namespaces) {
+		return ""
+	}
+	return m.namespaces[m.nsIdx-1]
+}
+
+func (m *model) setServices(svcs []*userv1.Service) {
+	cur := m.getSelectedService()
+
+	m.services = append([]*userv1.Service(nil), svcs...)
+	sort.SliceStable(m.services, func(i, j int) bool {
+		return m.services[i].GetMetadata().GetName() < m.services[j].GetMetadata().GetName()
+	})
+
+	m.selectService(cur)
+}
+
+func (m *model) selectService(svc *userv1.Service) {
+	if svc == nil {
+		m.clampSelection()
+		return
+	}
+
+	for i, itm := range m.getVisibleServices() {
+		if itm.GetMetadata().GetName() == svc.GetMetadata().GetName() {
+			m.selected = i
+			return
+		}
+	}
+
+	m.clampSelection()
+}
+
+func (m *model) isConnected() bool {
+	return m.conn != nil && m.conn.State == clidaemonv1.Connection_CONNECTED
+}
+
+// setConnection sets the connection to the Cluster, if any, and updates the
+// traffic rates whenever the counters change.
+func (m *model) setConnection(conn *clidaemonv1.Connection, now time.Time) {
+	if conn == nil || conn.State == clidaemonv1.Connection_DISCONNECTED {
+		m.conn = nil
+		m.rates = rates{}
+		m.lastStatsAt = time.Time{}
+		return
+	}
+
+	prev := m.conn.GetStats()
+	cur := conn.GetStats()
+
+	switch {
+	case cur == nil:
+		m.rates = rates{}
+		m.lastStatsAt = time.Time{}
+	case prev == nil || m.lastStatsAt.IsZero():
+		m.lastStatsAt = now
+	case cur.RxBytes != prev.RxBytes || cur.TxBytes != prev.TxBytes:
+		if elapsed := now.Sub(m.lastStatsAt).Seconds(); elapsed > 0 {
+			m.rates = rates{
+				rx: float64(cur.RxBytes-min(cur.RxBytes, prev.RxBytes)) / elapsed,
+				tx: float64(cur.TxBytes-min(cur.TxBytes, prev.TxBytes)) / elapsed,
+			}
+		}
+		m.lastStatsAt = now
+	}
+
+	m.conn = conn
+}
+
+// onEvent applies an event of the octelium daemon. It returns true if the
+// Services have to be reloaded.
+func (m *model) onEvent(evt *clidaemonv1.Event, now time.Time) bool {
+	if evt.Type != clidaemonv1.Event_NETWORK_CHANGED && evt.Connection.GetDomain() != m.domain {
+		return false
+	}
+
+	switch evt.Type {
+	case clidaemonv1.Event_CONNECTION_UPDATED:
+		m.setConnection(evt.Connection, now)
+	case clidaemonv1.Event_CONNECTION_REMOVED:
+		m.setConnection(nil, now)
+		m.hosted = make(map[string]*userv1.HostedService)
+	case clidaemonv1.Event_NETWORK_CHANGED:
+		m.setInfo("Network changed. Reconnecting")
+	case clidaemonv1.Event_SERVICE_ADDED, clidaemonv1.Event_SERVICE_UPDATED:
+		if evt.Service == nil {
+			return false
+		}
+		name := cliutils.GetServiceFullNameFromName(evt.Service.Name)
+		m.hosted[name] = evt.Service
+		m.updateConnection(evt.Connection, now)
+
+		if m.getService(name) == nil {
+			m.setServices(append(m.services, getServiceFromHosted(name, evt.Service)))
+			m.setInfo("Serving the new Service %s", name)
+		}
+		return true
+	case clidaemonv1.Event_SERVICE_DELETED:
+		if evt.Service == nil {
+			return false
+		}
+		delete(m.hosted, cliutils.GetServiceFullNameFromName(evt.Service.Name))
+		return true
+	}
+
+	return false
+}
+
+// updateConnection sets the connection sent along with the Service events.
+func (m *model) updateConnection(conn *clidaemonv1.Connection, now time.Time) {
+	if conn != nil && conn.State != clidaemonv1.Connection_STATE_UNKNOWN {
+		m.setConnection(conn, now)
+	}
+}
+
+// getServiceFromHosted returns a placeholder for a newly served Service
+// until the Services are reloaded from the Cluster.
+func getServiceFromHosted(name string, hs *userv1.HostedService) *userv1.Service {
+	svcNs, _ := cliutils.ParseServiceNamespace(name)
+
+	ret := &userv1.Service{
+		Metadata: &metav1.Metadata{Name: name},
+		Spec: &userv1.Service_Spec{
+			Port: hs.Port,
+			Type: userv1.Service_Spec_TCP,
+		},
+		Status: &userv1.Service_Status{},
+	}
+
+	if hs.L4Type == userv1.HostedService_UDP {
+		ret.Spec.Type = userv1.Service_Spec_UDP
+	}
+
+	if svcNs != nil {
+		ret.Status.Namespace = svcNs.Namespace
+		ret.Status.PrimaryHostname = svcNs.Service
+		if svcNs.Namespace != "default" {
+			ret.Status.PrimaryHostname = name
+		}
+	}
+
+	return ret
+}
+
+func (m *model) getService(name string) *userv1.Service {
+	for _, svc := range m.services {
+		if svc.GetMetadata().GetName() == name {
+			return svc
+		}
+	}
+	return nil
+}
+
+func getServiceNamespace(svc *userv1.Service) string {
+	if ns := svc.GetStatus().GetNamespace(); ns != "" {
+		return ns
+	}
+
+	if svcNs, err := cliutils.ParseServiceNamespace(svc.GetMetadata().GetName()); err == nil {
+		return svcNs.Namespace
+	}
+
+	return ""
+}
+
+func (m *model) getVisibleServices() []*userv1.Service {
+	ns := m.getNamespace()
+	filter := strings.ToLower(m.filter)
+
+	var ret []*userv1.Service
+	for _, svc := range m.services {
+		if ns != "" && getServiceNamespace(svc) != ns {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(svc.GetMetadata().GetName()), filter) {
+			continue
+		}
+		ret = append(ret, svc)
+	}
+
+	return ret
+}
+
+func (m *model) getSelectedService() *userv1.Service {
+	svcs := m.getVisibleServices()
+	if m.selected < 0 || m.selected >= len(svcs) {
+		return nil
+	}
+	return svcs[m.selected]
+}
+
+func (m *model) clampSelection() {
+	count := len(m.getVisibleServices())
+	if m.selected >= count {
+		m.selected = count - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+}
+
+func (m *model) moveSelection(delta int) {
+	m.selected += delta
+	m.clampSelection()
+}
+
+func (m *model) cycleNamespace(delta int) {
+	count := len(m.namespaces) + 1
+	m.nsIdx = ((m.nsIdx+delta)%count + count) % count
+	m.selected = 0
+	m.offset = 0
+}
+
+const pageSize = 10
+
+func (m *model) handleKey(k key) action {
+	switch m.mode {
+	case modeFilter:
+		return m.handleFilterKey(k)
+	case modePrompt:
+		return m.handlePromptKey(k)
+	}
+
+	switch k.typ {
+	case keyCtrlC:
+		return action{typ: actionQuit}
+	case keyUp:
+		m.moveSelection(-1)
+	case keyDown:
+		m.moveSelection(1)
+	case keyPageUp:
+		m.moveSelection(-pageSize)
+	case keyPageDown:
+		m.moveSelection(pageSize)
+	case keyHome:
+		m.selected = 0
+	case keyEnd:
+		m.selected = len(m.getVisibleServices()) - 1
+		m.clampSelection()
+	case keyTab, keyRight:
+		m.cycleNamespace(1)
+	case keyBackTab, keyLeft:
+		m.cycleNamespace(-1)
+	case keyEnter:
+		return m.doPortForward()
+	case keyEsc:
+		m.message = ""
+		m.filter = ""
+		m.clampSelection()
+	case keyRune:
+		switch k.r {
+		case 'q':
+			return action{typ: actionQuit}
+		case 'k':
+			m.moveSelection(-1)
+		case 'j':
+			m.moveSelection(1)
+		case 'g':
+			m.selected = 0
+		case 'G':
+			return m.handleKey(key{typ: keyEnd})
+		case 'l':
+			m.cycleNamespace(1)
+		case 'h':
+			m.cycleNamespace(-1)
+		case '/':
+			m.mode = modeFilter
+		case 'r':
+			return action{typ: actionRefresh}
+		case 'p':
+			return m.doPortForward()
+		case 's':
+			m.mode = modePrompt
+			m.prompt = prompt{
+				typ:   promptSSH,
+				label: "SSH to the Session: ",
+			}
+		case 'c':
+			m.mode = modePrompt
+			m.prompt = prompt{
+				typ:   promptCP,
+				label: "Copy [-r] <source> <destination>: ",
+			}
+		}
+	}
+
+	return action{}
+}
+
+func (m *model) handleFilterKey(k key) action {
+	switch k.typ {
+	case keyEnter:
+		m.mode = modeList
+	case keyEsc, keyCtrlC:
+		m.mode = modeList
+		m.filter = ""
+	case keyBackspace:
+		if r := []rune(m.filter); len(r) > 0 {
+			m.filter = string(r[:len(r)-1])
+		}
+	case keyUp:
+		m.moveSelection(-1)
+	case keyDown:
+		m.moveSelection(1)
+	case keyRune:
+		m.filter += string(k.r)
+	}
+
+	m.clampSelection()
+	return action{}
+}
+
+func (m *model) handlePromptKey(k key) action {
+	switch k.typ {
+	case keyEsc, keyCtrlC:
+		m.mode = modeList
+	case keyBackspace:
+		if r := []rune(m.prompt.input); len(r) > 0 {
+			m.prompt.input = string(r[:len(r)-1])
+		}
+	case keyRune:
+		m.prompt.input += string(k.r)
+	case keyEnter:
+		m.mode = modeList
+		return m.submitPrompt()
+	}
+
+	return action{}
+}
+
+func (m *model) submitPrompt() action {
+	args := strings.Fields(m.prompt.input)
+
+	switch m.prompt.typ {
+	case promptSSH:
+		if len(args) != 1 {
+			m.setError(fmt.Errorf("A single Session name is required"))
+			return action{}
+		}
+		return action{
+			typ:  actionExec,
+			args: []string{"ssh", args[0]},
+		}
+	case promptCP:
+		paths := args
+		if len(paths) > 0 && paths[0] == "-r" {
+			paths = paths[1:]
+		}
+		if len(paths) != 2 {
+			m.setError(fmt.Errorf("Both the source and the destination paths are required"))
+			return action{}
+		}
+		return action{
+			typ:  actionExec,
+			args: append([]string{"cp"}, args...),
+		}
+	}
+
+	return action{}
+}
+
+func (m *model) doPortForward() action {
+	svc := m.getSelectedService()
+	if svc == nil {
+		return action{}
+	}
+
+	if m.isConnected() {
+		m.setInfo("You are already connected to %s. The Service is reachable at %s",
+			m.domain, m.getPrivateAddress(svc))
+		return action{}
+	}
+
+	return action{
+		typ:  actionExec,
+		args: []string{"port-forward", svc.Metadata.Name},
+	}
+}
+
+func (m *model) getPrivateHostname(svc *userv1.Service) string {
+	return fmt.Sprintf("%s.local.%s", getPrimaryHostname(svc), m.domain)
+}
+
+func (m *model) getPrivateAddress(svc *userv1.Service) string {
+	return fmt.Sprintf("%s:%d", m.getPrivateHostname(svc), svc.GetSpec().GetPort())
+}
+
+func getPrimaryHostname(svc *userv1.Service) string {
+	if hostname := svc.GetStatus().GetPrimaryHostname(); hostname != "" {
+		return hostname
+	}
+
+	svcNs, err := cliutils.ParseServiceNamespace(svc.GetMetadata().GetName())
+	if err != nil {
+		return svc.GetMetadata().GetName()
+	}
+	if svcNs.Namespace == "default" {
+		return svcNs.Service
+	}
+	return svcNs.String()
+}
+
+func (m *model) getModes(svc *userv1.Service) string {
+	var ret []string
+	if svc.GetSpec().GetIsTLS() {
+		ret = append(ret, "tls")
+	}
+	if svc.GetSpec().GetIsPublic() {
+		ret = append(ret, "public")
+	}
+	if _, ok := m.hosted[svc.GetMetadata().GetName()]; ok {
+		ret = append(ret, "serving")
+	}
+	return strings.Join(ret, ",")
+}
+
+func getTypeStr(svc *userv1.Service) string {
+	return strings.ToLower(svc.GetSpec().GetType().String())
+}
+
+func humanRate(rate float64) string {
+	return fmt.Sprintf("%s/s", utils_types.HumanBytes(uint64(rate)))
+}
@@ -0,0 +1,322 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestService(name, ns string, typ userv1.Service_Spec_Type, port uint32) *userv1.Service {
+	fullName := name + "." + ns
+	hostname := name
+	if ns != "default" {
+		hostname = fullName
+	}
+
+	return &userv1.Service{
+		Metadata: &metav1.Metadata{
+			Name: fullName,
+		},
+		Spec: &userv1.Service_Spec{
+			Port: port,
+			Type: typ,
+		},
+		Status: &userv1.Service_Status{
+			Namespace:       ns,
+			PrimaryHostname: hostname,
+		},
+	}
+}
+
+func newTestModel() *model {
+	m := newModel("example.com")
+	m.setNamespaces([]string{"prod", "default"}, "")
+	m.setServices([]*userv1.Service{
+		newTestService("web", "default", userv1.Service_Spec_HTTP, 8080),
+		newTestService("db", "prod", userv1.Service_Spec_POSTGRES, 5432),
+		newTestService("api", "default", userv1.Service_Spec_HTTP, 443),
+	})
+	return m
+}
+
+func getNames(svcs []*userv1.Service) []string {
+	var ret []string
+	for _, svc := range svcs {
+		ret = append(ret, svc.Metadata.Name)
+	}
+	return ret
+}
+
+func TestModelNavigation(t *testing.T) {
+	m := newTestModel()
+
+	assert.Equal(t, []string{"default", "prod"}, m.namespaces)
+	assert.Equal(t, []string{"api.default", "db.prod", "web.default"}, getNames(m.getVisibleServices()))
+	assert.Equal(t, "api.default", m.getSelectedService().Metadata.Name)
+
+	m.handleKey(key{typ: keyDown})
+	m.handleKey(key{typ: keyRune, r: 'j'})
+	m.handleKey(key{typ: keyDown})
+	assert.Equal(t, "web.default", m.getSelectedService().Metadata.Name)
+
+	m.handleKey(key{typ: keyRune, r: 'k'})
+	assert.Equal(t, "db.prod", m.getSelectedService().Metadata.Name)
+
+	m.handleKey(key{typ: keyTab})
+	assert.Equal(t, "default", m.getNamespace())
+	assert.Equal(t, []string{"api.default", "web.default"}, getNames(m.getVisibleServices()))
+
+	m.handleKey(key{typ: keyTab})
+	m.handleKey(key{typ: keyTab})
+	assert.Equal(t, "", m.getNamespace())
+
+	m.handleKey(key{typ: keyBackTab})
+	assert.Equal(t, "prod", m.getNamespace())
+	m.handleKey(key{typ: keyBackTab})
+	m.handleKey(key{typ: keyBackTab})
+
+	m.handleKey(key{typ: keyRune, r: '/'})
+	for _, r := range "WE" {
+		m.handleKey(key{typ: keyRune, r: r})
+	}
+	assert.Equal(t, modeFilter, m.mode)
+	assert.Equal(t, []string{"web.default"}, getNames(m.getVisibleServices()))
+
+	m.handleKey(key{typ: keyBackspace})
+	m.handleKey(key{typ: keyEnter})
+	assert.Equal(t, modeList, m.mode)
+	assert.Equal(t, "W", m.filter)
+
+	m.handleKey(key{typ: keyEsc})
+	assert.Equal(t, "", m.filter)
+	assert.Len(t, m.getVisibleServices(), 3)
+
+	m.setServices(append(m.services, newTestService("cache", "prod", userv1.Service_Spec_TCP, 6379)))
+	m.handleKey(key{typ: keyEnd})
+	assert.Equal(t, "web.default", m.getSelectedService().Metadata.Name)
+	m.setServices(m.services[1:])
+	assert.Equal(t, "web.default", m.getSelectedService().Metadata.Name)
+
+	assert.Equal(t, actionQuit, m.handleKey(key{typ: keyRune, r: 'q'}).typ)
+	assert.Equal(t, actionRefresh, m.handleKey(key{typ: keyRune, r: 'r'}).typ)
+}
+
+func TestModelActions(t *testing.T) {
+	m := newTestModel()
+
+	act := m.handleKey(key{typ: keyEnter})
+	assert.Equal(t, actionExec, act.typ)
+	assert.Equal(t, []string{"port-forward", "api.default"}, act.args)
+
+	m.setConnection(&clidaemonv1.Connection{
+		Domain: "example.com",
+		State:  clidaemonv1.Connection_CONNECTED,
+	}, time.Now())
+
+	act = m.handleKey(key{typ: keyRune, r: 'p'})
+	assert.Equal(t, actionNone, act.typ)
+	assert.Contains(t, m.message, "api.local.example.com:443")
+
+	typeKeys := func(s string) action {
+		var ret action
+		for _, r := range s {
+			ret = m.handleKey(key{typ: keyRune, r: r})
+		}
+		return ret
+	}
+
+	m.handleKey(key{typ: keyRune, r: 's'})
+	assert.Equal(t, modePrompt, m.mode)
+	typeKeys("john-abc")
+	act = m.handleKey(key{typ: keyEnter})
+	assert.Equal(t, modeList, m.mode)
+	assert.Equal(t, actionExec, act.typ)
+	assert.Equal(t, []string{"ssh", "john-abc"}, act.args)
+
+	m.handleKey(key{typ: keyRune, r: 'c'})
+	typeKeys("-r ./dist")
+	act = m.handleKey(key{typ: keyEnter})
+	assert.Equal(t, actionNone, act.typ)
+	assert.True(t, m.isError)
+
+	m.handleKey(key{typ: keyRune, r: 'c'})
+	typeKeys("-r ./dist john-abc:/tmp/dist")
+	act = m.handleKey(key{typ: keyEnter})
+	assert.Equal(t, []string{"cp", "-r", "./dist", "john-abc:/tmp/dist"}, act.args)
+
+	m.handleKey(key{typ: keyRune, r: 's'})
+	typeKeys("john")
+	act = m.handleKey(key{typ: keyEsc})
+	assert.Equal(t, actionNone, act.typ)
+	assert.Equal(t, modeList, m.mode)
+}
+
+func TestModelEvents(t *testing.T) {
+	m := newTestModel()
+	now := time.Now()
+
+	conn := &clidaemonv1.Connection{
+		Domain: "example.com",
+		State:  clidaemonv1.Connection_CONNECTED,
+		Stats: &clidaemonv1.Connection_Stats{
+			RxBytes: 1000,
+			TxBytes: 500,
+		},
+	}
+
+	assert.False(t, m.onEvent(&clidaemonv1.Event{
+		Type: clidaemonv1.Event_CONNECTION_UPDATED,
+		Connection: &clidaemonv1.Connection{
+			Domain: "example.org",
+			State:  clidaemonv1.Connection_CONNECTED,
+		},
+	}, now))
+	assert.Nil(t, m.conn)
+
+	m.onEvent(&clidaemonv1.Event{
+		Type:       clidaemonv1.Event_CONNECTION_UPDATED,
+		Connection: conn,
+	}, now)
+	assert.True(t, m.isConnected())
+	assert.Equal(t, rates{}, m.rates)
+
+	m.onEvent(&clidaemonv1.Event{
+		Type: clidaemonv1.Event_CONNECTION_UPDATED,
+		Connection: &clidaemonv1.Connection{
+			Domain: "example.com",
+			State:  clidaemonv1.Connection_CONNECTED,
+			Stats: &clidaemonv1.Connection_Stats{
+				RxBytes: 11000,
+				TxBytes: 2500,
+			},
+		},
+	}, now.Add(5*time.Second))
+	assert.Equal(t, rates{rx: 2000, tx: 400}, m.rates)
+
+	needsReload := m.onEvent(&clidaemonv1.Event{
+		Type:       clidaemonv1.Event_SERVICE_ADDED,
+		Connection: m.conn,
+		Service: &userv1.HostedService{
+			Name:   "dns.prod",
+			Port:   53,
+			L4Type: userv1.HostedService_UDP,
+		},
+	}, now.Add(6*time.Second))
+	assert.True(t, needsReload)
+	assert.Equal(t, []string{"api.default", "db.prod", "dns.prod", "web.default"}, getNames(m.getVisibleServices()))
+
+	svc := m.getService("dns.prod")
+	assert.Equal(t, userv1.Service_Spec_UDP, svc.Spec.Type)
+	assert.Equal(t, "prod", getServiceNamespace(svc))
+	assert.Equal(t, "serving", m.getModes(svc))
+
+	m.onEvent(&clidaemonv1.Event{
+		Type:       clidaemonv1.Event_SERVICE_ADDED,
+		Connection: m.conn,
+		Service: &userv1.HostedService{
+			Name: "web",
+			Port: 8080,
+		},
+	}, now.Add(7*time.Second))
+	assert.Len(t, m.services, 4)
+	assert.Equal(t, "serving", m.getModes(m.getService("web.default")))
+
+	m.onEvent(&clidaemonv1.Event{
+		Type:       clidaemonv1.Event_SERVICE_DELETED,
+		Connection: m.conn,
+		Service: &userv1.HostedService{
+			Name: "web.default",
+		},
+	}, now.Add(8*time.Second))
+	assert.Equal(t, "", m.getModes(m.getService("web.default")))
+
+	m.onEvent(&clidaemonv1.Event{
+		Type:       clidaemonv1.Event_CONNECTION_REMOVED,
+		Connection: conn,
+	}, now.Add(9*time.Second))
+	assert.False(t, m.isConnected())
+	assert.Len(t, m.hosted, 0)
+}
+
+func TestView(t *testing.T) {
+	m := newTestModel()
+	m.status = &userv1.GetStatusResponse{
+		Session: &userv1.GetStatusResponse_Session{
+			Metadata: &metav1.Metadata{Name: "john-abc"},
+		},
+		User: &userv1.GetStatusResponse_User{
+			Metadata: &metav1.Metadata{Name: "john"},
+		},
+	}
+	m.setConnection(&clidaemonv1.Connection{
+		Domain:     "example.com",
+		State:      clidaemonv1.Connection_CONNECTED,
+		DeviceName: "octelium0",
+		Addresses:  []string{"100.64.0.2"},
+		Stats: &clidaemonv1.Connection_Stats{
+			RxBytes: 2048,
+		},
+	}, time.Now())
+
+	for _, size := range [][2]int{{100, 30}, {60, 12}, {20, 5}} {
+		lines := m.view(size[0], size[1])
+		assert.LessOrEqual(t, len(lines), size[1])
+
+		for _, line := range lines {
+			assert.LessOrEqual(t, utf8.RuneCountInString(stripStyles(line)), size[0], "%q", line)
+		}
+	}
+
+	lines := m.view(120, 30)
+	assert.Equal(t, 30, len(lines))
+
+	out := stripStyles(strings.Join(lines, "\n"))
+	assert.Contains(t, out, "example.com │ Session john-abc (john)")
+	assert.Contains(t, out, "Connection: connected · octelium0 · 100.64.0.2 · rx 2.0KiB")
+	assert.Contains(t, out, "Private: api.local.example.com:443")
+	assert.Contains(t, out, "db.prod")
+
+	for range 20 {
+		m.setServices(append(m.services, newTestService(
+			"svc"+string(rune('a'+len(m.services))), "default", userv1.Service_Spec_TCP, 80)))
+	}
+
+	m.handleKey(key{typ: keyEnd})
+	out = stripStyles(strings.Join(m.view(120, 20), "\n"))
+	assert.Contains(t, out, m.getSelectedService().Status.PrimaryHostname)
+	assert.True(t, m.offset > 0)
+}
+
+func stripStyles(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0x1b {
+			for i < len(s) && s[i] != 'm' {
+				i++
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	seqEnterAltScreen = "\x1b[?1049h\x1b[?25l"
+	seqLeaveAltScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+// screen is a full-screen terminal drawn via ANSI escape sequences.
+type screen struct {
+	in         *os.File
+	out        *os.File
+	oldState   *term.State
+	restoreOut func()
+}
+
+func newScreen(in, out *os.File) *screen {
+	return &screen{
+		in:  in,
+		out: out,
+	}
+}
+
+func (s *screen) enter() error {
+	oldState, err := term.MakeRaw(int(s.in.Fd()))
+	if err != nil {
+		return err
+	}
+	s.oldState = oldState
+	s.restoreOut = enableVirtualTerminal(s.out)
+
+	_, err = s.out.WriteString(seqEnterAltScreen)
+	return err
+}
+
+func (s *screen) leave() {
+	s.out.WriteString(seqLeaveAltScreen)
+
+	if s.restoreOut != nil {
+		s.restoreOut()
+		s.restoreOut = nil
+	}
+
+	if s.oldState != nil {
+		term.Restore(int(s.in.Fd()), s.oldState)
+		s.oldState = nil
+	}
+}
+
+func (s *screen) size() (int, int) {
+	width, height, err := term.GetSize(int(s.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+
+	return width, height
+}
+
+func (s *screen) draw(lines []string) error {
+	var b strings.Builder
+
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString("\x1b[K")
+	}
+	b.WriteString("\x1b[J")
+
+	_, err := s.out.WriteString(b.String())
+	return err
+}
@@ -0,0 +1,48 @@
+//go:build !windows
+// +build !windows
+
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func enableVirtualTerminal(_ *os.File) func() {
+	return func() {}
+}
+
+func waitInput(f *os.File, timeout time.Duration) (bool, error) {
+	fds := []unix.PollFd{
+		{
+			Fd:     int32(f.Fd()),
+			Events: unix.POLLIN,
+		},
+	}
+
+	n, err := unix.Poll(fds, int(timeout.Milliseconds()))
+	if err != nil {
+		if err == unix.EINTR {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return n > 0, nil
+}
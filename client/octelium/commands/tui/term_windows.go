@@ -0,0 +1,51 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func enableVirtualTerminal(f *os.File) func() {
+	handle := windows.Handle(f.Fd())
+
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return func() {}
+	}
+
+	if err := windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+		return func() {}
+	}
+
+	return func() {
+		windows.SetConsoleMode(handle, mode)
+	}
+}
+
+// waitInput waits for the console input handle to be signaled. The handle is
+// also signaled by console events other than key presses, in which case the
+// subsequent read blocks until the next key press.
+func waitInput(f *os.File, timeout time.Duration) (bool, error) {
+	event, err := windows.WaitForSingleObject(windows.Handle(f.Fd()), uint32(timeout.Milliseconds()))
+	if err != nil {
+		return false, err
+	}
+
+	return event == windows.WAIT_OBJECT_0, nil
+}
@@ -0,0 +1,343 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/octelium/octelium/apis/client/clidaemonv1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	utils_types "github.com/octelium/octelium/pkg/utils/types"
+)
+
+const (
+	styleReset   = "\x1b[0m"
+	styleBold    = "\x1b[1m"
+	styleDim     = "\x1b[2m"
+	styleReverse = "\x1b[7m"
+	styleRed     = "\x1b[31m"
+	styleGreen   = "\x1b[32m"
+	styleYellow  = "\x1b[33m"
+)
+
+const helpText = "↑/↓ move  ←/→ namespace  / filter  enter port-forward  s ssh  c cp  r refresh  q quit"
+
+// fit truncates or pads s to exactly width runes.
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	if n := utf8.RuneCountInString(s); n <= width {
+		return s + strings.Repeat(" ", width-n)
+	}
+
+	r := []rune(s)
+	if width == 1 {
+		return string(r[:1])
+	}
+	return string(r[:width-1]) + "…"
+}
+
+func style(s string, styles ...string) string {
+	if len(styles) == 0 {
+		return s
+	}
+	return strings.Join(styles, "") + s + styleReset
+}
+
+type column struct {
+	title    string
+	minWidth int
+	maxWidth int
+	value    func(*userv1.Service) string
+}
+
+// view renders the UI as a list of lines that fit in the given size.
+func (m *model) view(width, height int) []string {
+	var ret []string
+
+	ret = append(ret, style(fit(m.getHeader(), width), styleReverse, styleBold))
+	ret = append(ret, fit(m.getConnectionLine(), width))
+	ret = append(ret, m.getNamespacesLine(width))
+	ret = append(ret, "")
+
+	details := m.getDetails(width)
+	footer := m.getFooter(width)
+
+	svcs := m.getVisibleServices()
+	cols := m.getColumns()
+	widths := getColumnWidths(cols, svcs, width)
+
+	listHeight := height - len(ret) - 1 - len(details) - len(footer)
+	if listHeight < 1 {
+		listHeight = 1
+	}
+
+	ret = append(ret, style(renderRow(cols, widths, func(c column) string {
+		return c.title
+	}, width), styleBold))
+
+	m.scrollTo(listHeight)
+
+	for i := m.offset; i < len(svcs) && i < m.offset+listHeight; i++ {
+		svc := svcs[i]
+		line := renderRow(cols, widths, func(c column) string {
+			return c.value(svc)
+		}, width)
+
+		switch {
+		case i == m.selected:
+			line = style(line, styleReverse)
+		case m.hosted[svc.GetMetadata().GetName()] != nil:
+			line = style(line, styleGreen)
+		}
+		ret = append(ret, line)
+	}
+
+	if len(svcs) == 0 {
+		if len(m.services) == 0 {
+			ret = append(ret, style(fit("No Services Found", width), styleDim))
+		} else {
+			ret = append(ret, style(fit("No Services match the current Namespace and filter", width), styleDim))
+		}
+	}
+
+	for len(ret) < height-len(details)-len(footer) {
+		ret = append(ret, "")
+	}
+
+	ret = append(ret, details...)
+	ret = append(ret, footer...)
+
+	if len(ret) > height {
+		ret = ret[:height]
+	}
+
+	return ret
+}
+
+func (m *model) scrollTo(listHeight int) {
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+	if m.selected >= m.offset+listHeight {
+		m.offset = m.selected - listHeight + 1
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+func (m *model) getHeader() string {
+	ret := fmt.Sprintf(" Octelium │ %s", m.domain)
+
+	if st := m.status; st != nil {
+		if name := st.Session.GetMetadata().GetName(); name != "" {
+			ret += fmt.Sprintf(" │ Session %s", name)
+		}
+
+		user := st.User.GetMetadata().GetName()
+		if email := st.User.GetSpec().GetEmail(); email != "" {
+			user = email
+		}
+		if user != "" {
+			ret += fmt.Sprintf(" (%s)", user)
+		}
+	}
+
+	return ret
+}
+
+func (m *model) getConnectionLine() string {
+	conn := m.conn
+	if conn == nil {
+		return " Connection: not connected"
+	}
+
+	parts := []string{
+		fmt.Sprintf(" Connection: %s", strings.ToLower(conn.State.String())),
+	}
+
+	switch {
+	case conn.IsNetstack:
+		parts = append(parts, "gvisor")
+	case conn.DeviceName != "":
+		parts = append(parts, conn.DeviceName)
+	}
+
+	if len(conn.Addresses) > 0 {
+		parts = append(parts, strings.Join(conn.Addresses, ", "))
+	}
+
+	if conn.State == clidaemonv1.Connection_CONNECTED {
+		parts = append(parts,
+			fmt.Sprintf("rx %s (%s)", utils_types.HumanBytes(conn.GetStats().GetRxBytes()), humanRate(m.rates.rx)),
+			fmt.Sprintf("tx %s (%s)", utils_types.HumanBytes(conn.GetStats().GetTxBytes()), humanRate(m.rates.tx)))
+	}
+
+	if conn.LastError != "" {
+		parts = append(parts, conn.LastError)
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+func (m *model) getNamespacesLine(width int) string {
+	var b strings.Builder
+	b.WriteString(" Namespaces:")
+	used := utf8.RuneCountInString(b.String())
+
+	names := append([]string{"All"}, m.namespaces...)
+	for i, name := range names {
+		item := fmt.Sprintf(" %s ", name)
+		if used+1+utf8.RuneCountInString(item) > width {
+			break
+		}
+		used += 1 + utf8.RuneCountInString(item)
+
+		b.WriteString(" ")
+		if i == m.nsIdx {
+			b.WriteString(style(item, styleReverse))
+		} else {
+			b.WriteString(item)
+		}
+	}
+
+	return b.String()
+}
+
+func (m *model) getColumns() []column {
+	return []column{
+		{
+			title:    "NAME",
+			minWidth: 4,
+			maxWidth: 32,
+			value:    getPrimaryHostname,
+		},
+		{
+			title:    "NAMESPACE",
+			minWidth: 9,
+			maxWidth: 20,
+			value:    getServiceNamespace,
+		},
+		{
+			title:    "TYPE",
+			minWidth: 4,
+			maxWidth: 10,
+			value:    getTypeStr,
+		},
+		{
+			title:    "PORT",
+			minWidth: 5,
+			maxWidth: 5,
+			value: func(svc *userv1.Service) string {
+				return fmt.Sprintf("%d", svc.GetSpec().GetPort())
+			},
+		},
+		{
+			title:    "MODES",
+			minWidth: 5,
+			maxWidth: 20,
+			value:    m.getModes,
+		},
+	}
+}
+
+func getColumnWidths(cols []column, svcs []*userv1.Service, width int) []int {
+	ret := make([]int, len(cols))
+	for i, c := range cols {
+		ret[i] = c.minWidth
+		for _, svc := range svcs {
+			ret[i] = max(ret[i], utf8.RuneCountInString(c.value(svc)))
+		}
+		ret[i] = min(ret[i], c.maxWidth)
+	}
+
+	return ret
+}
+
+func renderRow(cols []column, widths []int, value func(column) string, width int) string {
+	var b strings.Builder
+	b.WriteString(" ")
+	for i, c := range cols {
+		b.WriteString(fit(value(c), widths[i]))
+		b.WriteString("  ")
+	}
+
+	return fit(b.String(), width)
+}
+
+func (m *model) getDetails(width int) []string {
+	ret := []string{
+		style(strings.Repeat("─", max(width, 0)), styleDim),
+	}
+
+	svc := m.getSelectedService()
+	if svc == nil {
+		return append(ret, "", "", "")
+	}
+
+	hostnames := fmt.Sprintf(" Private: %s", m.getPrivateAddress(svc))
+	if svc.GetSpec().GetIsPublic() {
+		hostnames += fmt.Sprintf("   Public: https://%s.%s", getPrimaryHostname(svc), m.domain)
+	}
+
+	addrs := " Addresses: " + strings.Join(svc.GetStatus().GetAddresses(), ", ")
+	if len(svc.GetStatus().GetAddresses()) == 0 {
+		addrs = " Addresses: -"
+	}
+
+	serving := " Not served by this host"
+	if hs := m.hosted[svc.GetMetadata().GetName()]; hs != nil {
+		serving = fmt.Sprintf(" Served by this host (%s)", strings.ToLower(hs.L4Type.String()))
+		if up := hs.GetUpstream(); up != nil && up.Host != "" {
+			serving = fmt.Sprintf(" Served by this host from %s:%d (%s)",
+				up.Host, up.Port, strings.ToLower(hs.L4Type.String()))
+		}
+	}
+
+	return append(ret,
+		style(fit(fmt.Sprintf(" %s", svc.GetMetadata().GetName()), width), styleBold),
+		fit(hostnames, width),
+		fit(addrs+"  │"+serving, width),
+	)
+}
+
+func (m *model) getFooter(width int) []string {
+	var status string
+	switch m.mode {
+	case modeFilter:
+		status = style(fit(fmt.Sprintf(" Filter: %s█", m.filter), width), styleYellow)
+	case modePrompt:
+		status = style(fit(fmt.Sprintf(" %s%s█", m.prompt.label, m.prompt.input), width), styleYellow)
+	default:
+		switch {
+		case m.message != "" && m.isError:
+			status = style(fit(" "+m.message, width), styleRed)
+		case m.message != "":
+			status = fit(" "+m.message, width)
+		case m.filter != "":
+			status = style(fit(fmt.Sprintf(" Filter: %s", m.filter), width), styleDim)
+		}
+	}
+
+	return []string{
+		status,
+		style(fit(" "+helpText, width), styleDim),
+	}
+}
@@ -21,7 +21,9 @@ import (
 	"github.com/octelium/octelium/client/common/resources"
 	"github.com/octelium/octelium/client/common/rscdiff"
 	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
 	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
@@ -31,6 +33,12 @@ type args struct {
 	ResourceIncludes []string
 	ResourceExcludes []string
 	IncludeSecret    bool
+	DryRun           bool
+	Out              string
+	ValuesFiles      []string
+	Sets             []string
+	Overlays         []string
+	Render           bool
 }
 
 var examples = `
@@ -48,6 +56,17 @@ octeliumctl apply --include User --include Group /path/to/file.yaml
 
 # Exclude changes in Services
 octeliumctl apply --exclude Service /path/to/file.yaml
+
+# Show the changes without applying them. Exits with code 2 if there are any changes
+octeliumctl apply --dry-run /path/to/directory
+octeliumctl apply --dry-run --prune -o json /path/to/directory
+
+# Render the manifests as templates with per-environment variables and overlays
+octeliumctl apply --values ./envs/production.yaml --overlay ./overlays/production /path/to/directory
+octeliumctl apply --values ./envs/staging.yaml --set upstream.domain=staging.example.com /path/to/directory
+
+# Only print the rendered manifests
+octeliumctl apply --render --values ./envs/production.yaml --overlay ./overlays/production /path/to/directory
 `
 
 var Cmd = &cobra.Command{
@@ -78,27 +97,53 @@ Only include this resource kind. This overrides the default list of included Res
 		"Exclude this resource kind from the default list of included Resources")
 	Cmd.PersistentFlags().BoolVar(&cmdArgs.IncludeSecret, "include-secret", false,
 		"Include Secret resources. This by default is disabled in order to not encourage defining your Secrets inside configs that are meant to be stored in git repos for example")
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.DryRun, "dry-run", false,
+		"Only show the field-level changes that would be applied, including the deletions if --prune is set, without applying them. The Conditions of the Policies and the inlinePolicies are also type-checked by the Cluster. The command exits with code 2 if there are any changes, which can be used to detect drift in CI pipelines")
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Out, "out", "o", "",
+		"Output format of --dry-run. Set to json for machine-readable output")
+	Cmd.PersistentFlags().StringSliceVar(&cmdArgs.ValuesFiles, "values", nil,
+		"A yaml file of variables. The manifests are then rendered as Go templates where the variables are available as .Values, e.g. {{ .Values.domain }}. Using an undefined variable is an error. Later files override earlier ones")
+	Cmd.PersistentFlags().StringArrayVar(&cmdArgs.Sets, "set", nil,
+		"Set a variable in the form key=value, overriding the values files. Nested keys are dot-separated, e.g. upstream.domain=example.com")
+	Cmd.PersistentFlags().StringSliceVar(&cmdArgs.Overlays, "overlay", nil,
+		"A file or directory of patches merged into the manifests. Every patch must have the kind and metadata name of an existing Resource. Objects are merged recursively, null values remove fields and other values, including lists, are replaced")
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.Render, "render", false,
+		"Only print the rendered manifests without applying them")
 }
 
 func doCmd(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
+	switch cmdArgs.Out {
+	case "":
+	case "json":
+		if !cmdArgs.DryRun {
+			return errors.Errorf("The json output format requires --dry-run")
+		}
+	default:
+		return errors.Errorf("Invalid output format: %s", cmdArgs.Out)
+	}
+
+	if cmdArgs.Render {
+		return doRender(cmd, args[0])
+	}
+
 	i, err := cliutils.GetCLIInfo(cmd, args)
 	if err != nil {
 		return err
 	}
 
-	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
+	resources, err := loadResources(i.FirstArg())
 	if err != nil {
 		return err
 	}
-	defer conn.Close()
-	client := corev1.NewMainServiceClient(conn)
 
-	resources, err := resources.LoadCoreResources(i.FirstArg())
+	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
 	if err != nil {
 		return err
 	}
+	defer conn.Close()
+	client := corev1.NewMainServiceClient(conn)
 
 	doDelete := cmdArgs.DoDelete
 
@@ -110,6 +155,19 @@ func doCmd(cmd *cobra.Command, args []string) error {
 
 	zap.L().Debug("All available resource kinds set for diff", zap.Strings("kinds", allKinds))
 
+	cc := func() *corev1.ClusterConfig {
+		for _, itm := range resources {
+			if itm.GetKind() == ucorev1.KindClusterConfig {
+				return itm.(*corev1.ClusterConfig)
+			}
+		}
+		return nil
+	}()
+
+	if cmdArgs.DryRun {
+		return doPlan(cmd, conn, allKinds, resources, cc, doDelete)
+	}
+
 	totalDiffResp := &rscdiff.DiffCtlResponse{}
 
 	for _, kindRsc := range allKinds {
@@ -122,15 +180,6 @@ func doCmd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	cc := func() *corev1.ClusterConfig {
-		for _, itm := range resources {
-			if itm.GetKind() == ucorev1.KindClusterConfig {
-				return itm.(*corev1.ClusterConfig)
-			}
-		}
-		return nil
-	}()
-
 	if totalDiffResp.CountCreated+totalDiffResp.CountUpdated+totalDiffResp.CountDeleted > 0 {
 		cliutils.LineNotify("Cluster Core resources successfully applied\n")
 		if totalDiffResp.CountCreated > 0 {
@@ -162,6 +211,55 @@ func doCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func getRenderOpts() (*resources.RenderOpts, error) {
+	ret := &resources.RenderOpts{
+		Overlays: cmdArgs.Overlays,
+	}
+
+	if len(cmdArgs.ValuesFiles) > 0 || len(cmdArgs.Sets) > 0 {
+		values, err := resources.LoadValues(cmdArgs.ValuesFiles, cmdArgs.Sets)
+		if err != nil {
+			return nil, err
+		}
+		ret.Values = values
+	}
+
+	return ret, nil
+}
+
+func loadResources(fPath string) ([]umetav1.ResourceObjectI, error) {
+	if len(cmdArgs.ValuesFiles) == 0 && len(cmdArgs.Sets) == 0 && len(cmdArgs.Overlays) == 0 {
+		return resources.LoadCoreResources(fPath)
+	}
+
+	o, err := getRenderOpts()
+	if err != nil {
+		return nil, err
+	}
+
+	return resources.LoadCoreResourcesRendered(fPath, o)
+}
+
+func doRender(cmd *cobra.Command, fPath string) error {
+	o, err := getRenderOpts()
+	if err != nil {
+		return err
+	}
+
+	docs, err := resources.RenderDocuments(fPath, o)
+	if err != nil {
+		return err
+	}
+
+	out, err := resources.MarshalDocuments(docs)
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.OutOrStdout().Write(out)
+	return err
+}
+
 func getIncludes() []string {
 	if len(deduplicateItems(cmdArgs.ResourceIncludes)) > 0 {
 		return deduplicateItems(cmdArgs.ResourceIncludes)
@@ -0,0 +1,198 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/common/rscdiff"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/objdiff"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// exitCodeChanges is the exit code of a dry run that found changes.
+const exitCodeChanges = 2
+
+type planSummary struct {
+	Create int `json:"create"`
+	Update int `json:"update"`
+	Delete int `json:"delete"`
+}
+
+type planOutput struct {
+	HasChanges   bool                `json:"hasChanges"`
+	Summary      planSummary         `json:"summary"`
+	Items        []*rscdiff.PlanItem `json:"items"`
+	PolicyErrors []*policyError      `json:"policyErrors,omitempty"`
+}
+
+func doPlan(cmd *cobra.Command, conn *grpc.ClientConn, kinds []string,
+	resources []umetav1.ResourceObjectI, cc *corev1.ClusterConfig, doDelete bool) error {
+	ctx := cmd.Context()
+
+	out := &planOutput{
+		Items: []*rscdiff.PlanItem{},
+	}
+
+	for _, kind := range kinds {
+		items, err := rscdiff.PlanCoreResource(ctx, kind, conn, resources, doDelete)
+		if err != nil {
+			return err
+		}
+		out.Items = append(out.Items, items...)
+	}
+
+	if cc != nil {
+		curCC, err := corev1.NewMainServiceClient(conn).GetClusterConfig(ctx, &corev1.GetClusterConfigRequest{})
+		if err != nil {
+			return err
+		}
+
+		if !pbutils.IsEqual(cc.Spec, curCC.Spec) {
+			item, err := getClusterConfigPlanItem(curCC, cc)
+			if err != nil {
+				return err
+			}
+			out.Items = append(out.Items, item)
+		}
+	}
+
+	for _, itm := range out.Items {
+		switch itm.Action {
+		case rscdiff.ActionCreate:
+			out.Summary.Create++
+		case rscdiff.ActionUpdate:
+			out.Summary.Update++
+		case rscdiff.ActionDelete:
+			out.Summary.Delete++
+		}
+	}
+	out.HasChanges = len(out.Items) > 0
+
+	policyErrs, err := checkPolicies(ctx, corev1.NewMainServiceClient(conn), resources)
+	if err != nil {
+		return err
+	}
+	out.PolicyErrors = policyErrs
+
+	if cmdArgs.Out == "json" {
+		outBytes, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(outBytes))
+	} else {
+		printPlan(cmd.OutOrStdout(), out)
+	}
+
+	if len(out.PolicyErrors) > 0 {
+		cmd.SilenceUsage = true
+		return errors.Errorf("The manifests have %d Policy errors", len(out.PolicyErrors))
+	}
+
+	if out.HasChanges {
+		cmd.SilenceUsage = true
+		return &cliutils.ExitCodeError{Code: exitCodeChanges}
+	}
+
+	return nil
+}
+
+func getClusterConfigPlanItem(current, desired *corev1.ClusterConfig) (*rscdiff.PlanItem, error) {
+	// Only the spec of the ClusterConfig is applied.
+	changes, err := objdiff.GetFieldChanges(ucorev1.API, current, &corev1.ClusterConfig{
+		Kind:     desired.Kind,
+		Metadata: current.Metadata,
+		Spec:     desired.Spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rscdiff.PlanItem{
+		Kind:    ucorev1.KindClusterConfig,
+		Name:    current.GetMetadata().GetName(),
+		Action:  rscdiff.ActionUpdate,
+		Changes: changes,
+	}, nil
+}
+
+func printPlan(w io.Writer, out *planOutput) {
+	if len(out.PolicyErrors) > 0 {
+		printPolicyErrors(w, out.PolicyErrors)
+	}
+
+	if !out.HasChanges {
+		color.New(color.FgCyan, color.Bold).Fprintf(w, "No changes. The Cluster matches the desired state\n")
+		return
+	}
+
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	red := color.New(color.FgRed)
+	greenBold := color.New(color.FgGreen, color.Bold)
+	yellowBold := color.New(color.FgYellow, color.Bold)
+	redBold := color.New(color.FgRed, color.Bold)
+
+	for _, itm := range out.Items {
+		switch itm.Action {
+		case rscdiff.ActionCreate:
+			greenBold.Fprintf(w, "+ %s %s will be created\n", itm.Kind, itm.Name)
+		case rscdiff.ActionUpdate:
+			yellowBold.Fprintf(w, "~ %s %s will be updated\n", itm.Kind, itm.Name)
+		case rscdiff.ActionDelete:
+			redBold.Fprintf(w, "- %s %s will be deleted\n", itm.Kind, itm.Name)
+		}
+
+		for _, change := range itm.Changes {
+			switch {
+			case change.Old == nil:
+				green.Fprintf(w, "    + %s: %s\n", change.Path, printValue(change.New))
+			case change.New == nil:
+				red.Fprintf(w, "    - %s: %s\n", change.Path, printValue(change.Old))
+			default:
+				yellow.Fprintf(w, "    ~ %s: %s -> %s\n",
+					change.Path, printValue(change.Old), printValue(change.New))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	color.New(color.Bold).Fprintf(w, "Plan: %d to create, %d to update, %d to delete\n",
+		out.Summary.Create, out.Summary.Update, out.Summary.Delete)
+}
+
+func printValue(arg any) string {
+	if str, ok := arg.(string); ok && str == objdiff.SensitiveValue {
+		return str
+	}
+
+	ret, err := json.Marshal(arg)
+	if err != nil {
+		return fmt.Sprintf("%v", arg)
+	}
+	return strings.TrimSpace(string(ret))
+}
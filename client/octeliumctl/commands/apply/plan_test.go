@@ -0,0 +1,113 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/client/common/rscdiff"
+	"github.com/octelium/octelium/pkg/common/objdiff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintPlan(t *testing.T) {
+	color.NoColor = true
+
+	{
+		buf := &bytes.Buffer{}
+		printPlan(buf, &planOutput{})
+		assert.Contains(t, buf.String(), "No changes")
+	}
+
+	{
+		buf := &bytes.Buffer{}
+		printPlan(buf, &planOutput{
+			HasChanges: true,
+			Summary: planSummary{
+				Create: 1,
+				Update: 1,
+				Delete: 1,
+			},
+			Items: []*rscdiff.PlanItem{
+				{
+					Kind:   "Secret",
+					Name:   "sec1",
+					Action: rscdiff.ActionCreate,
+					Changes: []*objdiff.FieldChange{
+						{Path: "data", New: objdiff.SensitiveValue},
+					},
+				},
+				{
+					Kind:   "Service",
+					Name:   "svc1.default",
+					Action: rscdiff.ActionUpdate,
+					Changes: []*objdiff.FieldChange{
+						{Path: "spec.port", Old: float64(8080), New: float64(8081)},
+						{Path: "metadata.description", Old: "old"},
+					},
+				},
+				{
+					Kind:   "User",
+					Name:   "usr1",
+					Action: rscdiff.ActionDelete,
+				},
+			},
+		})
+
+		out := buf.String()
+		assert.Contains(t, out, "+ Secret sec1 will be created")
+		assert.Contains(t, out, "+ data: (sensitive)")
+		assert.Contains(t, out, "~ Service svc1.default will be updated")
+		assert.Contains(t, out, "~ spec.port: 8080 -> 8081")
+		assert.Contains(t, out, `- metadata.description: "old"`)
+		assert.Contains(t, out, "- User usr1 will be deleted")
+		assert.Contains(t, out, "Plan: 1 to create, 1 to update, 1 to delete")
+	}
+}
+
+func TestGetClusterConfigPlanItem(t *testing.T) {
+	current := &corev1.ClusterConfig{
+		Metadata: &metav1.Metadata{
+			Name:            "default",
+			ResourceVersion: "v1",
+		},
+		Spec: &corev1.ClusterConfig_Spec{
+			Ingress: &corev1.ClusterConfig_Spec_Ingress{
+				XffNumTrustedHops: 1,
+			},
+		},
+	}
+
+	desired := &corev1.ClusterConfig{
+		Metadata: &metav1.Metadata{
+			Name: "default",
+		},
+		Spec: &corev1.ClusterConfig_Spec{
+			Ingress: &corev1.ClusterConfig_Spec_Ingress{
+				XffNumTrustedHops: 2,
+			},
+		},
+	}
+
+	itm, err := getClusterConfigPlanItem(current, desired)
+	assert.Nil(t, err)
+	assert.Equal(t, rscdiff.ActionUpdate, itm.Action)
+	assert.Len(t, itm.Changes, 1)
+	assert.Equal(t, "spec.ingress.xffNumTrustedHops", itm.Changes[0].Path)
+}
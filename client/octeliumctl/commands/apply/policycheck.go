@@ -0,0 +1,158 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// policyError is an error of a Policy spec found by the Cluster. The spec is
+// either that of a Policy or of an InlinePolicy of any Resource.
+type policyError struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *policyError) String() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", e.Path, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+type policySpecRef struct {
+	path string
+	spec *corev1.Policy_Spec
+}
+
+func checkPolicies(ctx context.Context,
+	c corev1.MainServiceClient, resources []umetav1.ResourceObjectI) ([]*policyError, error) {
+	ret := []*policyError{}
+
+	for _, rsc := range resources {
+		for _, ref := range getPolicySpecs(rsc.ProtoReflect(), "") {
+			resp, err := c.CheckPolicy(ctx, &corev1.CheckPolicyRequest{
+				Spec: ref.spec,
+			})
+			if err != nil {
+				if grpcerr.IsUnimplemented(err) {
+					zap.L().Debug("The Cluster does not support checking Policies")
+					return ret, nil
+				}
+				return nil, err
+			}
+
+			for _, itm := range resp.Errors {
+				path := ref.path
+				if itm.Path != "" {
+					path = fmt.Sprintf("%s.%s", ref.path, itm.Path)
+				}
+
+				ret = append(ret, &policyError{
+					Kind:    rsc.GetKind(),
+					Name:    rsc.GetMetadata().GetName(),
+					Path:    path,
+					Line:    int(itm.Line),
+					Column:  int(itm.Column),
+					Message: itm.Message,
+				})
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+var policySpecFullName = (&corev1.Policy_Spec{}).ProtoReflect().Descriptor().FullName()
+
+// getPolicySpecs returns all the Policy specs set within msg along with their
+// paths named after the fields' JSON names.
+func getPolicySpecs(msg protoreflect.Message, path string) []*policySpecRef {
+	if msg.Descriptor().FullName() == policySpecFullName {
+		return []*policySpecRef{
+			{
+				path: path,
+				spec: msg.Interface().(*corev1.Policy_Spec),
+			},
+		}
+	}
+
+	var ret []*policySpecRef
+
+	join := func(fd protoreflect.FieldDescriptor) string {
+		if path == "" {
+			return fd.JSONName()
+		}
+		return fmt.Sprintf("%s.%s", path, fd.JSONName())
+	}
+
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !msg.Has(fd) {
+			continue
+		}
+		v := msg.Get(fd)
+
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				ret = append(ret, getPolicySpecs(v.Message(),
+					fmt.Sprintf("%s[%s]", join(fd), k.String()))...)
+				return true
+			})
+		case fd.IsList():
+			if fd.Message() == nil {
+				continue
+			}
+			lst := v.List()
+			for j := 0; j < lst.Len(); j++ {
+				ret = append(ret, getPolicySpecs(lst.Get(j).Message(),
+					fmt.Sprintf("%s[%d]", join(fd), j))...)
+			}
+		case fd.Message() != nil:
+			ret = append(ret, getPolicySpecs(v.Message(), join(fd))...)
+		}
+	}
+
+	return ret
+}
+
+func printPolicyErrors(w io.Writer, errs []*policyError) {
+	red := color.New(color.FgRed)
+	redBold := color.New(color.FgRed, color.Bold)
+
+	redBold.Fprintf(w, "Found %d Policy errors\n", len(errs))
+	for _, itm := range errs {
+		red.Fprintf(w, "  ! %s %s: %s\n", itm.Kind, itm.Name, itm.String())
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,128 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type fakeCheckPolicyClient struct {
+	corev1.MainServiceClient
+	reqs []*corev1.CheckPolicyRequest
+}
+
+func (c *fakeCheckPolicyClient) CheckPolicy(ctx context.Context,
+	req *corev1.CheckPolicyRequest, opts ...grpc.CallOption) (*corev1.CheckPolicyResponse, error) {
+	c.reqs = append(c.reqs, req)
+
+	if len(req.Spec.Rules) == 0 {
+		return &corev1.CheckPolicyResponse{}, nil
+	}
+
+	return &corev1.CheckPolicyResponse{
+		Errors: []*corev1.CheckPolicyResponse_Error{
+			{
+				Path:    "rules[0].condition.match",
+				Line:    1,
+				Column:  23,
+				Message: "undefined field 'grups'",
+			},
+		},
+	}, nil
+}
+
+func TestCheckPolicies(t *testing.T) {
+	ctx := context.Background()
+
+	rule := &corev1.Policy_Spec_Rule{
+		Effect: corev1.Policy_Spec_Rule_ALLOW,
+		Condition: &corev1.Condition{
+			Type: &corev1.Condition_Match{
+				Match: `"ops" in ctx.user.spec.grups`,
+			},
+		},
+	}
+
+	resources := []umetav1.ResourceObjectI{
+		&corev1.Policy{
+			Kind: "Policy",
+			Metadata: &metav1.Metadata{
+				Name: "pol1",
+			},
+			Spec: &corev1.Policy_Spec{
+				Rules: []*corev1.Policy_Spec_Rule{rule},
+			},
+		},
+		&corev1.Service{
+			Kind: "Service",
+			Metadata: &metav1.Metadata{
+				Name: "svc1.default",
+			},
+			Spec: &corev1.Service_Spec{
+				Authorization: &corev1.Service_Spec_Authorization{
+					InlinePolicies: []*corev1.InlinePolicy{
+						{
+							Spec: &corev1.Policy_Spec{},
+						},
+						{
+							Spec: &corev1.Policy_Spec{
+								Rules: []*corev1.Policy_Spec_Rule{rule},
+							},
+						},
+					},
+				},
+			},
+		},
+		&corev1.User{
+			Kind: "User",
+			Metadata: &metav1.Metadata{
+				Name: "usr1",
+			},
+			Spec: &corev1.User_Spec{},
+		},
+	}
+
+	c := &fakeCheckPolicyClient{}
+	errs, err := checkPolicies(ctx, c, resources)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(c.reqs))
+	assert.Equal(t, 2, len(errs))
+
+	assert.Equal(t, "Policy", errs[0].Kind)
+	assert.Equal(t, "pol1", errs[0].Name)
+	assert.Equal(t, "spec.rules[0].condition.match", errs[0].Path)
+
+	assert.Equal(t, "Service", errs[1].Kind)
+	assert.Equal(t, "svc1.default", errs[1].Name)
+	assert.Equal(t, "spec.authorization.inlinePolicies[1].spec.rules[0].condition.match", errs[1].Path)
+	assert.Equal(t, 1, errs[1].Line)
+	assert.Equal(t, 23, errs[1].Column)
+
+	color.NoColor = true
+	buf := &bytes.Buffer{}
+	printPolicyErrors(buf, errs)
+	assert.Contains(t, buf.String(), "Found 2 Policy errors")
+	assert.Contains(t, buf.String(),
+		"! Policy pol1: spec.rules[0].condition.match:1:23: undefined field 'grups'")
+}
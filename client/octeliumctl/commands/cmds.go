@@ -23,7 +23,9 @@ import (
 	"github.com/octelium/octelium/client/octeliumctl/commands/apply"
 	"github.com/octelium/octelium/client/octeliumctl/commands/create"
 	"github.com/octelium/octelium/client/octeliumctl/commands/delete"
+	"github.com/octelium/octelium/client/octeliumctl/commands/export"
 	"github.com/octelium/octelium/client/octeliumctl/commands/get"
+	"github.com/octelium/octelium/client/octeliumctl/commands/policy"
 	"github.com/octelium/octelium/client/octeliumctl/commands/update"
 	"github.com/spf13/cobra"
 )
@@ -45,10 +47,12 @@ func InitCmds() {
 	Cmd.AddCommand(create.Cmd)
 	Cmd.AddCommand(delete.Cmd)
 	Cmd.AddCommand(apply.Cmd)
+	Cmd.AddCommand(export.Cmd)
 	Cmd.AddCommand(get.Cmd)
 	Cmd.AddCommand(version.Cmd)
 	Cmd.AddCommand(auth.Cmd)
 	Cmd.AddCommand(update.Cmd)
+	Cmd.AddCommand(policy.Cmd)
 
 	Cmd.AddCommand(login.Cmd)
 	Cmd.AddCommand(logout.Cmd)
@@ -58,6 +62,7 @@ func InitCmds() {
 	get.AddSubcommands()
 	auth.AddSubcommands()
 	update.AddSubcommands()
+	policy.AddSubcommands()
 }
 
 func init() {
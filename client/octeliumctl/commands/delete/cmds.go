@@ -15,6 +15,7 @@
 package delete
 
 import (
+	"github.com/octelium/octelium/client/octeliumctl/commands/delete/accessrequest"
 	"github.com/octelium/octelium/client/octeliumctl/commands/delete/authenticator"
 	"github.com/octelium/octelium/client/octeliumctl/commands/delete/config"
 	"github.com/octelium/octelium/client/octeliumctl/commands/delete/credential"
@@ -49,4 +50,5 @@ func AddSubcommands() {
 	Cmd.AddCommand(authenticator.Cmd)
 	Cmd.AddCommand(identityprovider.Cmd)
 	Cmd.AddCommand(config.Cmd)
+	Cmd.AddCommand(accessrequest.Cmd)
 }
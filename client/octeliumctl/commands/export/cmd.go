@@ -0,0 +1,238 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/common/rscdiff"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type args struct {
+	ResourceIncludes []string
+	ResourceExcludes []string
+	IncludeSecret    bool
+	Overwrite        bool
+}
+
+var examples = `
+# Export all the Resources handled by apply to a directory
+octeliumctl export /path/to/directory
+
+# Only export Users and Groups
+octeliumctl export --include User --include Group /path/to/directory
+
+# Also export Secrets without their data
+octeliumctl export --include-secret /path/to/directory
+`
+
+var Cmd = &cobra.Command{
+	Use:   "export [DIRECTORY]",
+	Short: "Export the Cluster Resources to declarative yaml files",
+	Long: `
+Export the current Cluster Resources to a directory of yaml files, one file per Resource, that can be later applied via "octeliumctl apply".
+Server-managed fields such as the UID, the resource version and the status as well as System Resources are not exported.
+Applying the exported directory to the same Cluster therefore results in no changes.
+`,
+	Example: examples,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().StringSliceVar(&cmdArgs.ResourceIncludes, "include", nil,
+		`
+Only include this resource kind. This overrides the default list of included Resources:
+["ClusterConfig", "User", "Group", "Policy", "Service", "Namespace", "Credential", "IdentityProvider"]`)
+	Cmd.PersistentFlags().StringSliceVar(&cmdArgs.ResourceExcludes, "exclude", nil,
+		"Exclude this resource kind from the default list of included Resources")
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.IncludeSecret, "include-secret", false,
+		"Include Secret resources. The Secret data is never returned by the Cluster and must be set in the exported files before applying them")
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.Overwrite, "overwrite", false,
+		"Allow exporting to a non-empty directory, overwriting existing files")
+}
+
+var allResourceNames = []string{
+	ucorev1.KindClusterConfig,
+	ucorev1.KindPolicy,
+	ucorev1.KindIdentityProvider,
+	ucorev1.KindNamespace,
+	ucorev1.KindGroup,
+	ucorev1.KindUser,
+	ucorev1.KindService,
+	ucorev1.KindCredential,
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	dir := i.FirstArg()
+	if err := checkDir(dir, cmdArgs.Overwrite); err != nil {
+		return err
+	}
+
+	kinds := getKinds(cmdArgs.ResourceIncludes, cmdArgs.ResourceExcludes, cmdArgs.IncludeSecret)
+	if len(kinds) == 0 {
+		return errors.Errorf("No Resource kinds to export")
+	}
+
+	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	count := 0
+	for _, kind := range kinds {
+		var items []umetav1.ResourceObjectI
+		if kind == ucorev1.KindClusterConfig {
+			cc, err := corev1.NewMainServiceClient(conn).GetClusterConfig(ctx, &corev1.GetClusterConfigRequest{})
+			if err != nil {
+				return err
+			}
+			items = append(items, cc)
+		} else {
+			items, err = rscdiff.ListCoreResources(ctx, kind, conn)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, itm := range items {
+			if err := writeResource(dir, itm); err != nil {
+				return err
+			}
+			count++
+		}
+	}
+
+	cliutils.LineNotify("%d Resources exported to %s\n", count, dir)
+	if cmdArgs.IncludeSecret {
+		cliutils.LineWarn("Secrets are exported without their data. Set it before applying them\n")
+	}
+
+	return nil
+}
+
+func checkDir(dir string, overwrite bool) error {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case os.IsNotExist(err):
+		return os.MkdirAll(dir, 0o755)
+	case err != nil:
+		return err
+	case len(entries) > 0 && !overwrite:
+		return errors.Errorf("The directory %s is not empty. Use --overwrite to export to it anyway", dir)
+	default:
+		return nil
+	}
+}
+
+func getKinds(includes, excludes []string, includeSecret bool) []string {
+	isInList := func(lst []string, arg string) bool {
+		for _, itm := range lst {
+			if itm == arg {
+				return true
+			}
+		}
+		return false
+	}
+
+	var ret []string
+	if includeSecret {
+		ret = append(ret, ucorev1.KindSecret)
+	}
+
+	for _, kind := range allResourceNames {
+		if len(includes) > 0 && !isInList(includes, kind) {
+			continue
+		}
+		if isInList(excludes, kind) {
+			continue
+		}
+		ret = append(ret, kind)
+	}
+
+	return ret
+}
+
+func writeResource(dir string, itm umetav1.ResourceObjectI) error {
+	out := toExported(itm)
+
+	outBytes, err := pbutils.MarshalYAML(out)
+	if err != nil {
+		return err
+	}
+
+	if out.GetKind() == ucorev1.KindSecret {
+		outBytes = append([]byte(
+			"# The Secret data is not exported. Set it, e.g. via data.value, before applying this file.\n"),
+			outBytes...)
+	}
+
+	fPath := filepath.Join(dir, getResourcePath(out))
+	if err := os.MkdirAll(filepath.Dir(fPath), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(fPath, outBytes, 0o644)
+}
+
+// getResourcePath returns the path of the file of the Resource relative to
+// the export directory. Services are grouped by their Namespace.
+func getResourcePath(itm umetav1.ResourceObjectI) string {
+	kind := itm.GetKind()
+	name := itm.GetMetadata().GetName()
+
+	switch kind {
+	case ucorev1.KindClusterConfig:
+		return "clusterconfig.yaml"
+	case ucorev1.KindService:
+		if svc, ns, ok := strings.Cut(name, "."); ok {
+			return filepath.Join("services", ns, fmt.Sprintf("%s.yaml", svc))
+		}
+	}
+
+	return filepath.Join(getKindDir(kind), fmt.Sprintf("%s.yaml", name))
+}
+
+func getKindDir(kind string) string {
+	switch kind {
+	case ucorev1.KindPolicy:
+		return "policies"
+	default:
+		return fmt.Sprintf("%ss", strings.ToLower(kind))
+	}
+}
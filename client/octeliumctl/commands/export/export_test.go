@@ -0,0 +1,125 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/client/common/resources"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/objdiff"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	current := []umetav1.ResourceObjectI{
+		&corev1.Service{
+			ApiVersion: ucorev1.APIVersion,
+			Kind:       ucorev1.KindService,
+			Metadata: &metav1.Metadata{
+				Name:            "svc1.default",
+				Uid:             "5d0f7f56-47c3-4d86-8b6b-3c8f8f4cc1d1",
+				ResourceVersion: "v1",
+				CreatedAt:       pbutils.Now(),
+				Labels: map[string]string{
+					"env": "prod",
+				},
+			},
+			Spec: &corev1.Service_Spec{
+				Port: 8080,
+			},
+			Status: &corev1.Service_Status{
+				Addresses: []*corev1.Service_Status_Address{
+					{
+						DualStackIP: &metav1.DualStackIP{Ipv4: "10.0.0.1"},
+					},
+				},
+			},
+		},
+		&corev1.User{
+			ApiVersion: ucorev1.APIVersion,
+			Kind:       ucorev1.KindUser,
+			Metadata: &metav1.Metadata{
+				Name:        "usr1",
+				Uid:         "b0c0f3a8-5c92-4cf5-a4bb-6c86d1b7a8b5",
+				Description: "A User",
+			},
+			Spec: &corev1.User_Spec{
+				Type: corev1.User_Spec_HUMAN,
+			},
+			Status: &corev1.User_Status{},
+		},
+	}
+
+	dir := t.TempDir()
+	for _, itm := range current {
+		assert.Nil(t, writeResource(dir, itm))
+	}
+
+	_, err := os.Stat(filepath.Join(dir, "services", "default", "svc1.yaml"))
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(dir, "users", "usr1.yaml"))
+	assert.Nil(t, err)
+
+	exported, err := resources.LoadCoreResources(dir)
+	assert.Nil(t, err)
+	assert.Len(t, exported, 2)
+
+	for _, itm := range exported {
+		assert.Empty(t, itm.GetMetadata().Uid)
+		assert.Empty(t, itm.GetMetadata().ResourceVersion)
+		assert.Nil(t, itm.GetMetadata().CreatedAt)
+	}
+
+	for _, kind := range []string{ucorev1.KindService, ucorev1.KindUser} {
+		var desiredItems, currentItems []umetav1.ResourceObjectI
+		for _, itm := range exported {
+			if itm.GetKind() == kind {
+				desiredItems = append(desiredItems, itm)
+			}
+		}
+		for _, itm := range current {
+			if itm.GetKind() == kind {
+				currentItems = append(currentItems, itm)
+			}
+		}
+
+		assert.True(t, objdiff.Compute(ucorev1.API, desiredItems, currentItems).IsEmpty())
+	}
+}
+
+func TestGetKinds(t *testing.T) {
+	assert.Equal(t, allResourceNames, getKinds(nil, nil, false))
+	assert.Equal(t, []string{ucorev1.KindSecret, ucorev1.KindUser},
+		getKinds([]string{ucorev1.KindUser}, nil, true))
+	assert.NotContains(t, getKinds(nil, []string{ucorev1.KindService}, false), ucorev1.KindService)
+}
+
+func TestCheckDir(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.Nil(t, checkDir(filepath.Join(dir, "new"), false))
+	assert.Nil(t, checkDir(filepath.Join(dir, "new"), false))
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "new", "file.yaml"), nil, 0o644))
+	assert.NotNil(t, checkDir(filepath.Join(dir, "new"), false))
+	assert.Nil(t, checkDir(filepath.Join(dir, "new"), true))
+}
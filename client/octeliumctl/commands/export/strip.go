@@ -0,0 +1,60 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// toExported returns a copy of the Resource that only contains the fields
+// that apply sets: the kind, the API version, the user-settable metadata and
+// the spec.
+func toExported(itm umetav1.ResourceObjectI) umetav1.ResourceObjectI {
+	ret := proto.Clone(itm).(umetav1.ResourceObjectI)
+	msg := ret.ProtoReflect()
+
+	var toClear []protoreflect.FieldDescriptor
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		switch fd.Name() {
+		case "kind", "apiVersion", "metadata", "spec":
+		default:
+			toClear = append(toClear, fd)
+		}
+		return true
+	})
+	for _, fd := range toClear {
+		msg.Clear(fd)
+	}
+
+	md := itm.GetMetadata()
+	exportedMD := &metav1.Metadata{
+		Name:        md.GetName(),
+		DisplayName: md.GetDisplayName(),
+		Description: md.GetDescription(),
+		Labels:      md.GetLabels(),
+		Annotations: md.GetAnnotations(),
+		PicURL:      md.GetPicURL(),
+		Tags:        md.GetTags(),
+	}
+
+	if fd := msg.Descriptor().Fields().ByName("metadata"); fd != nil {
+		msg.Set(fd, protoreflect.ValueOfMessage(exportedMD.ProtoReflect()))
+	}
+
+	return ret
+}
@@ -0,0 +1,151 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/common/printer"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type args struct {
+	Out   string
+	User  string
+	State string
+}
+
+const example = `
+octeliumctl get accessrequest
+octeliumctl get ar
+octeliumctl get accessrequests -o json
+octeliumctl get ar --user alice
+octeliumctl get ar --state pending
+`
+
+var Cmd = &cobra.Command{
+	Use:     "accessrequest",
+	Short:   "List/get AccessRequests",
+	Example: example,
+	Aliases: []string{"ar", "accessrequests", "access-request", "access-requests"},
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Out, "out", "o", "", "Output format")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.User, "user", "", "Filter the list by a User")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.State, "state", "",
+		"Filter the list by a state (i.e. pending, approved, denied or expired)")
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	conn, err := client.GetGRPCClientConn(cmd.Context(), i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := corev1.NewMainServiceClient(conn)
+
+	if i.FirstArg() != "" {
+		res, err := c.GetAccessRequest(cmd.Context(), &metav1.GetOptions{
+			Name: i.FirstArg(),
+		})
+		if err != nil {
+			return err
+		}
+		out, err := cliutils.OutFormatPrint(cmdArgs.Out, res)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", string(out))
+		return nil
+	}
+
+	req := &corev1.ListAccessRequestOptions{
+		Common: cliutils.GetCommonListOptions(cmd),
+	}
+
+	if cmdArgs.User != "" {
+		req.UserRef = &metav1.ObjectReference{
+			Name: cmdArgs.User,
+		}
+	}
+
+	if cmdArgs.State != "" {
+		state, ok := corev1.AccessRequest_Status_State_value[strings.ToUpper(cmdArgs.State)]
+		if !ok {
+			return errors.Errorf("Invalid state: %s", cmdArgs.State)
+		}
+		req.State = corev1.AccessRequest_Status_State(state)
+	}
+
+	itmList, err := c.ListAccessRequest(cmd.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	if len(itmList.Items) == 0 {
+		cliutils.LineInfo("No AccessRequests found\n")
+		return nil
+	}
+
+	if cmdArgs.Out != "" {
+		out, err := cliutils.OutFormatPrint(cmdArgs.Out, itmList)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", string(out))
+		return nil
+	}
+
+	p := printer.NewPrinter("Name", "User", "Target", "Duration", "State", "Age")
+
+	for _, itm := range itmList.Items {
+		target := itm.Spec.Service
+		if target == "" {
+			target = fmt.Sprintf("ns:%s", itm.Spec.Namespace)
+		}
+
+		duration := umetav1.ToDuration(itm.Spec.Duration).ToGo().String()
+		if itm.Spec.Duration == nil {
+			duration = "1h0m0s"
+		}
+
+		p.AppendRow(itm.Metadata.Name, itm.Status.UserRef.GetName(), target, duration,
+			itm.Status.State.String(), cliutils.GetResourceAge(itm))
+	}
+
+	p.Render()
+
+	return nil
+}
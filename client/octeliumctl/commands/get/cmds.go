@@ -15,6 +15,7 @@
 package get
 
 import (
+	"github.com/octelium/octelium/client/octeliumctl/commands/get/accessrequest"
 	"github.com/octelium/octelium/client/octeliumctl/commands/get/authenticator"
 	"github.com/octelium/octelium/client/octeliumctl/commands/get/clusterconfig"
 	"github.com/octelium/octelium/client/octeliumctl/commands/get/config"
@@ -54,6 +55,7 @@ func AddSubcommands() {
 	Cmd.AddCommand(gateway.Cmd)
 	Cmd.AddCommand(authenticator.Cmd)
 	Cmd.AddCommand(config.Cmd)
+	Cmd.AddCommand(accessrequest.Cmd)
 }
 
 func init() {
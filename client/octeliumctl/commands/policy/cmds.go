@@ -0,0 +1,32 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"github.com/octelium/octelium/client/octeliumctl/commands/policy/simulate"
+	"github.com/octelium/octelium/client/octeliumctl/commands/policy/test"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "policy",
+	Short:   "Troubleshoot and test Policies",
+	Aliases: []string{"policies"},
+}
+
+func AddSubcommands() {
+	Cmd.AddCommand(simulate.Cmd)
+	Cmd.AddCommand(test.Cmd)
+}
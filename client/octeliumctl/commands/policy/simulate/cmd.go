@@ -0,0 +1,340 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const example = `
+ octeliumctl policy simulate --user alice --service db.prod
+ octeliumctl policy simulate --session alice-4hd8s --service api.prod --http-method DELETE --http-path /users/42
+ octeliumctl policy simulate --user alice --device dev-2k8hd --service db.prod --request req.yaml
+ octeliumctl policy simulate --user alice --service app --http-path /admin -o json
+ `
+
+// exitCodeDenied is the exit code of a simulation whose request is denied.
+const exitCodeDenied = 2
+
+var Cmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate whether a request to a Service is allowed and why",
+	Long: `Simulate computes the authorization decision of a synthetic request to a Service
+as if it were sent by the given User, Session and Device. It prints the decision,
+every Policy rule that was evaluated and the enforcement rules that were applied.
+The command exits with code 2 if the request is denied.`,
+	Example: example,
+	Aliases: []string{"sim"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+type args struct {
+	User        string
+	Session     string
+	Device      string
+	Service     string
+	Request     string
+	HTTPMethod  string
+	HTTPPath    string
+	HTTPHeaders []string
+	Out         string
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().StringVar(&cmdArgs.User, "user", "", "The name of the User")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Session, "session", "", "The name of the Session. If not set, an active Session of the User is simulated")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Device, "device", "", "The name of the Device")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Service, "service", "", "The name of the Service")
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Request, "request", "f", "",
+		`Path to a YAML or JSON file of the request (e.g. {"postgres": {"connect": {"user": "alice", "database": "prod"}}}). Use "-" to read from stdin`)
+	Cmd.PersistentFlags().StringVar(&cmdArgs.HTTPMethod, "http-method", "", "The method of the HTTP request")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.HTTPPath, "http-path", "", "The path of the HTTP request")
+	Cmd.PersistentFlags().StringSliceVar(&cmdArgs.HTTPHeaders, "http-header", nil,
+		`HTTP header in the form of key=value. Use the flag multiple times to add more headers.`)
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Out, "out", "o", "", "Output format")
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	req, err := getRequest()
+	if err != nil {
+		return err
+	}
+
+	conn, err := client.GetGRPCClientConn(ctx, i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := corev1.NewMainServiceClient(conn)
+
+	resp, err := c.SimulatePolicy(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if cmdArgs.Out != "" {
+		out, err := cliutils.OutFormatPrint(cmdArgs.Out, resp)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", string(out))
+	} else {
+		printResponse(cmd.OutOrStdout(), resp)
+	}
+
+	if !resp.IsAuthorized {
+		cmd.SilenceUsage = true
+		return &cliutils.ExitCodeError{Code: exitCodeDenied}
+	}
+
+	return nil
+}
+
+func getRequest() (*corev1.SimulatePolicyRequest, error) {
+	if cmdArgs.Service == "" {
+		return nil, errors.Errorf("You must set the Service via --service")
+	}
+	if cmdArgs.User == "" && cmdArgs.Session == "" {
+		return nil, errors.Errorf("You must set either --user or --session")
+	}
+
+	ret := &corev1.SimulatePolicyRequest{
+		ServiceRef: &metav1.ObjectReference{
+			Name: cmdArgs.Service,
+		},
+	}
+
+	if cmdArgs.User != "" {
+		ret.UserRef = &metav1.ObjectReference{
+			Name: cmdArgs.User,
+		}
+	}
+	if cmdArgs.Session != "" {
+		ret.SessionRef = &metav1.ObjectReference{
+			Name: cmdArgs.Session,
+		}
+	}
+	if cmdArgs.Device != "" {
+		ret.DeviceRef = &metav1.ObjectReference{
+			Name: cmdArgs.Device,
+		}
+	}
+
+	hasHTTP := cmdArgs.HTTPMethod != "" || cmdArgs.HTTPPath != "" || len(cmdArgs.HTTPHeaders) > 0
+
+	switch {
+	case cmdArgs.Request != "" && hasHTTP:
+		return nil, errors.Errorf("--request cannot be used together with the --http-* flags")
+	case cmdArgs.Request != "":
+		var reqBytes []byte
+		var err error
+		if cmdArgs.Request == "-" {
+			reqBytes, err = io.ReadAll(os.Stdin)
+		} else {
+			reqBytes, err = os.ReadFile(cmdArgs.Request)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ret.Request = &corev1.RequestContext_Request{}
+		if err := pbutils.UnmarshalYAML(reqBytes, ret.Request); err != nil {
+			return nil, errors.Errorf("Could not parse the request: %+v", err)
+		}
+	case hasHTTP:
+		httpReq := &corev1.RequestContext_Request_HTTP{
+			Method: strings.ToUpper(cmdArgs.HTTPMethod),
+			Path:   cmdArgs.HTTPPath,
+		}
+		if httpReq.Method == "" {
+			httpReq.Method = "GET"
+		}
+		if httpReq.Path == "" {
+			httpReq.Path = "/"
+		}
+
+		for _, hdr := range cmdArgs.HTTPHeaders {
+			key, val, ok := strings.Cut(hdr, "=")
+			if !ok || key == "" {
+				return nil, errors.Errorf("Invalid HTTP header: %s. It must be in the form of key=value", hdr)
+			}
+			if httpReq.Headers == nil {
+				httpReq.Headers = make(map[string]string)
+			}
+			httpReq.Headers[strings.ToLower(key)] = val
+		}
+
+		ret.Request = &corev1.RequestContext_Request{
+			Type: &corev1.RequestContext_Request_Http{
+				Http: httpReq,
+			},
+		}
+	}
+
+	return ret, nil
+}
+
+func printResponse(w io.Writer, resp *corev1.SimulatePolicyResponse) {
+	bold := color.New(color.Bold)
+
+	if resp.IsAuthorized {
+		color.New(color.FgGreen, color.Bold).Fprintf(w, "ALLOWED\n")
+	} else {
+		color.New(color.FgRed, color.Bold).Fprintf(w, "DENIED\n")
+	}
+
+	if resp.Reason != nil {
+		fmt.Fprintf(w, "Reason: %s\n", getReasonStr(resp.Reason))
+	}
+	if resp.Error != "" {
+		color.New(color.FgRed).Fprintf(w, "Error: %s\n", resp.Error)
+	}
+
+	fmt.Fprintln(w)
+
+	if len(resp.Rules) == 0 {
+		fmt.Fprintf(w, "No Policy rules were evaluated\n")
+	} else {
+		bold.Fprintf(w, "Rules (in evaluation order):\n")
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, rule := range resp.Rules {
+			marker := " "
+			if rule.IsDecisive {
+				marker = ">"
+			}
+
+			name := rule.Name
+			if name == "" {
+				name = "-"
+			}
+
+			fmt.Fprintf(tw, "%s %s\t%s\tpriority %d\t%s\t%s",
+				marker, name, getRuleEffectStr(rule.Effect), rule.Priority,
+				getSourceStr(rule.Source), rule.Result.String())
+			if note := getRuleNote(rule); note != "" {
+				fmt.Fprintf(tw, "\t%s", note)
+			}
+			fmt.Fprintln(tw)
+		}
+		tw.Flush()
+	}
+
+	if len(resp.EnforcementRules) > 0 {
+		fmt.Fprintln(w)
+		bold.Fprintf(w, "Enforcement rules:\n")
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, rule := range resp.EnforcementRules {
+			fmt.Fprintf(tw, "  #%d\t%s\t%s\t%s",
+				rule.Index, rule.Effect.String(), getSourceStr(rule.Source), rule.Result.String())
+			if rule.Error != "" {
+				fmt.Fprintf(tw, "\terror: %s", rule.Error)
+			}
+			fmt.Fprintln(tw)
+		}
+		tw.Flush()
+	}
+}
+
+func getRuleNote(rule *corev1.SimulatePolicyResponse_Rule) string {
+	switch {
+	case rule.Error != "":
+		return fmt.Sprintf("error: %s", rule.Error)
+	case rule.IsStepUpRequired:
+		return "step-up required"
+	case rule.IsDecisive:
+		return "decisive"
+	default:
+		return ""
+	}
+}
+
+func getRuleEffectStr(arg corev1.Policy_Spec_Rule_Effect) string {
+	if arg == corev1.Policy_Spec_Rule_EFFECT_UNKNOWN {
+		return "-"
+	}
+	return arg.String()
+}
+
+func getSourceStr(src *corev1.SimulatePolicyResponse_Source) string {
+	switch {
+	case src.GetPolicyRef() != nil:
+		return fmt.Sprintf("Policy %s", src.GetPolicyRef().Name)
+	case src.GetInlinePolicy() != nil:
+		return getInlinePolicyStr(src.GetInlinePolicy().Name, src.GetInlinePolicy().ResourceRef)
+	default:
+		return "-"
+	}
+}
+
+func getInlinePolicyStr(name string, resourceRef *metav1.ObjectReference) string {
+	ret := "InlinePolicy"
+	if name != "" {
+		ret = fmt.Sprintf("%s %s", ret, name)
+	}
+	if resourceRef != nil {
+		ret = fmt.Sprintf("%s of %s %s", ret, resourceRef.Kind, resourceRef.Name)
+	}
+	return ret
+}
+
+func getReasonStr(reason *corev1.AccessLog_Entry_Common_Reason) string {
+	match := reason.GetDetails().GetPolicyMatch()
+	if match == nil {
+		return reason.Type.String()
+	}
+
+	var src string
+	switch {
+	case match.GetPolicy() != nil:
+		src = fmt.Sprintf("Policy %s", match.GetPolicy().PolicyRef.GetName())
+	case match.GetInlinePolicy() != nil:
+		src = getInlinePolicyStr(match.GetInlinePolicy().Name, match.GetInlinePolicy().ResourceRef)
+	}
+
+	ret := fmt.Sprintf("%s (priority %d", reason.Type.String(), match.Priority)
+	if match.RuleName != "" {
+		ret = fmt.Sprintf("%s, rule %s", ret, match.RuleName)
+	}
+	if src != "" {
+		ret = fmt.Sprintf("%s, %s", ret, src)
+	}
+
+	return ret + ")"
+}
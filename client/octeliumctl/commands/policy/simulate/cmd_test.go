@@ -0,0 +1,199 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRequest(t *testing.T) {
+	t.Cleanup(func() {
+		cmdArgs = args{}
+	})
+
+	{
+		cmdArgs = args{User: "alice"}
+		_, err := getRequest()
+		assert.NotNil(t, err)
+	}
+
+	{
+		cmdArgs = args{Service: "db.prod"}
+		_, err := getRequest()
+		assert.NotNil(t, err)
+	}
+
+	{
+		cmdArgs = args{
+			User:        "alice",
+			Device:      "dev-1",
+			Service:     "api.prod",
+			HTTPMethod:  "delete",
+			HTTPPath:    "/users/42",
+			HTTPHeaders: []string{"X-Custom=val"},
+		}
+		req, err := getRequest()
+		assert.Nil(t, err)
+		assert.Equal(t, "alice", req.UserRef.Name)
+		assert.Equal(t, "dev-1", req.DeviceRef.Name)
+		assert.Equal(t, "api.prod", req.ServiceRef.Name)
+		assert.Nil(t, req.SessionRef)
+		assert.Equal(t, "DELETE", req.Request.GetHttp().Method)
+		assert.Equal(t, "/users/42", req.Request.GetHttp().Path)
+		assert.Equal(t, "val", req.Request.GetHttp().Headers["x-custom"])
+	}
+
+	{
+		cmdArgs = args{
+			Session:     "alice-abcd",
+			Service:     "api.prod",
+			HTTPHeaders: []string{"invalid"},
+		}
+		_, err := getRequest()
+		assert.NotNil(t, err)
+	}
+
+	{
+		reqPath := filepath.Join(t.TempDir(), "req.yaml")
+		err := os.WriteFile(reqPath, []byte(`
+postgres:
+  connect:
+    user: alice
+    database: prod
+`), 0600)
+		assert.Nil(t, err)
+
+		cmdArgs = args{
+			Session: "alice-abcd",
+			Service: "db.prod",
+			Request: reqPath,
+		}
+		req, err := getRequest()
+		assert.Nil(t, err)
+		assert.Equal(t, "alice-abcd", req.SessionRef.Name)
+		assert.Equal(t, "alice", req.Request.GetPostgres().GetConnect().User)
+		assert.Equal(t, "prod", req.Request.GetPostgres().GetConnect().Database)
+
+		cmdArgs.HTTPPath = "/"
+		_, err = getRequest()
+		assert.NotNil(t, err)
+	}
+}
+
+func TestPrintResponse(t *testing.T) {
+	color.NoColor = true
+
+	svcRef := &metav1.ObjectReference{
+		Kind: "Service",
+		Name: "db.prod",
+	}
+
+	{
+		buf := &bytes.Buffer{}
+		printResponse(buf, &corev1.SimulatePolicyResponse{
+			Reason: &corev1.AccessLog_Entry_Common_Reason{
+				Type: corev1.AccessLog_Entry_Common_Reason_NO_POLICY_MATCH,
+			},
+		})
+		assert.Contains(t, buf.String(), "DENIED")
+		assert.Contains(t, buf.String(), "Reason: NO_POLICY_MATCH")
+		assert.Contains(t, buf.String(), "No Policy rules were evaluated")
+	}
+
+	{
+		buf := &bytes.Buffer{}
+		printResponse(buf, &corev1.SimulatePolicyResponse{
+			IsAuthorized: true,
+			Reason: &corev1.AccessLog_Entry_Common_Reason{
+				Type: corev1.AccessLog_Entry_Common_Reason_POLICY_MATCH,
+				Details: &corev1.AccessLog_Entry_Common_Reason_Details{
+					Type: &corev1.AccessLog_Entry_Common_Reason_Details_PolicyMatch_{
+						PolicyMatch: &corev1.AccessLog_Entry_Common_Reason_Details_PolicyMatch{
+							RuleName: "allow-dba",
+							Type: &corev1.AccessLog_Entry_Common_Reason_Details_PolicyMatch_InlinePolicy_{
+								InlinePolicy: &corev1.AccessLog_Entry_Common_Reason_Details_PolicyMatch_InlinePolicy{
+									Name:        "dba",
+									ResourceRef: svcRef,
+								},
+							},
+						},
+					},
+				},
+			},
+			Rules: []*corev1.SimulatePolicyResponse_Rule{
+				{
+					Name:   "allow-dba",
+					Effect: corev1.Policy_Spec_Rule_ALLOW,
+					Source: &corev1.SimulatePolicyResponse_Source{
+						Type: &corev1.SimulatePolicyResponse_Source_InlinePolicy_{
+							InlinePolicy: &corev1.SimulatePolicyResponse_Source_InlinePolicy{
+								Name:        "dba",
+								ResourceRef: svcRef,
+							},
+						},
+					},
+					Result:     corev1.SimulatePolicyResponse_MATCHED,
+					IsDecisive: true,
+				},
+				{
+					Effect:   corev1.Policy_Spec_Rule_DENY,
+					Priority: 2,
+					Source: &corev1.SimulatePolicyResponse_Source{
+						Type: &corev1.SimulatePolicyResponse_Source_PolicyRef{
+							PolicyRef: &metav1.ObjectReference{
+								Name: "deny-contractors",
+							},
+						},
+					},
+					Result: corev1.SimulatePolicyResponse_NOT_MATCHED,
+				},
+			},
+			EnforcementRules: []*corev1.SimulatePolicyResponse_EnforcementRule{
+				{
+					Index:  1,
+					Effect: corev1.Policy_Spec_EnforcementRule_IGNORE,
+					Source: &corev1.SimulatePolicyResponse_Source{
+						Type: &corev1.SimulatePolicyResponse_Source_PolicyRef{
+							PolicyRef: &metav1.ObjectReference{
+								Name: "office-hours",
+							},
+						},
+					},
+					Result: corev1.SimulatePolicyResponse_ERROR,
+					Error:  "no such key",
+				},
+			},
+		})
+
+		out := buf.String()
+		assert.Contains(t, out, "ALLOWED")
+		assert.Contains(t, out, "Reason: POLICY_MATCH (priority 0, rule allow-dba, InlinePolicy dba of Service db.prod)")
+		assert.Contains(t, out, "> allow-dba")
+		assert.Contains(t, out, "decisive")
+		assert.Contains(t, out, "Policy deny-contractors")
+		assert.Contains(t, out, "NOT_MATCHED")
+		assert.Contains(t, out, "#1")
+		assert.Contains(t, out, "Policy office-hours")
+		assert.Contains(t, out, "error: no such key")
+	}
+}
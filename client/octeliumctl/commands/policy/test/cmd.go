@@ -0,0 +1,376 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/client/common/resources"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const example = `
+ octeliumctl policy test policies_test.yaml
+ octeliumctl policy test policies_test.yaml --fixtures ./policies --fixtures ./services
+ octeliumctl policy test policies_test.yaml --fail-on-uncovered
+ octeliumctl policy test policies_test.yaml -o json
+ octeliumctl policy test policies_test.yaml --local
+ octeliumctl policy test policies_test.yaml --local --local-evaluator ./bin/octelium-policytest
+ `
+
+var Cmd = &cobra.Command{
+	Use:   "test [FILE]",
+	Short: "Test Policies against fixtures and expected decisions",
+	Long: `Test evaluates the test cases of a test file and compares their authorization
+decisions with the expected ones. A test file consists of fixtures, which are the
+Policies, Users, Groups, Devices, Sessions, Services and Namespaces used by the test,
+and a list of cases, each of which is a request to a Service by a User or a Session
+together with its expected decision and, optionally, the expected reason and the
+name of the rule that decides it. Fixtures can also be loaded from resource manifests
+via --fixtures.
+
+By default, the cases are sent to the Cluster and evaluated there by the same decision
+logic that authorizes the real requests, but against the fixtures only. The command
+therefore needs access to a Cluster as an admin User, however no Cluster resource is
+read or modified. With --local, the cases are evaluated by the same decision logic on
+this host without any Cluster. Local evaluation is done by the octelium-policytest
+binary, which is built from the Cluster source code (e.g. via "make build-policytest")
+and is looked up in the PATH unless set via --local-evaluator. The command reports the
+rules that never matched any case and fails if any case fails, which makes it suitable
+for gating Policy changes in CI.
+
+Example of a test file:
+
+fixtures:
+  policies:
+    - metadata:
+        name: allow-ops
+      spec:
+        rules:
+          - name: ops
+            effect: ALLOW
+            condition:
+              match: '"ops" in ctx.user.spec.groups'
+  users:
+    - metadata:
+        name: alice
+      spec:
+        type: HUMAN
+        groups: ["ops"]
+  services:
+    - metadata:
+        name: db.prod
+      spec:
+        authorization:
+          policies: ["allow-ops"]
+cases:
+  - name: ops-can-connect
+    user: alice
+    service: db.prod
+    expect:
+      decision: ALLOW
+      ruleName: ops`,
+	Example: example,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+type args struct {
+	Fixtures        []string
+	FailOnUncovered bool
+	Out             string
+	Local           bool
+	LocalEvaluator  string
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().StringSliceVar(&cmdArgs.Fixtures, "fixtures", nil,
+		"Path to a file or a directory of resource manifests to be added to the fixtures. Use the flag multiple times to add more paths")
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.FailOnUncovered, "fail-on-uncovered", false,
+		"Fail if any Policy rule of the fixtures never matched any test case")
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Out, "out", "o", "", "Output format")
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.Local, "local", false,
+		"Evaluate the test cases on this host without a Cluster")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.LocalEvaluator, "local-evaluator", "",
+		fmt.Sprintf("Path to the %s binary used by --local. By default, it is looked up in the PATH", localEvaluator))
+}
+
+const localEvaluator = "octelium-policytest"
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	req, err := getRequest(args[0])
+	if err != nil {
+		return err
+	}
+
+	var resp *corev1.TestPolicyResponse
+	if cmdArgs.Local {
+		resp, err = testPolicyLocal(ctx, req)
+		if err != nil {
+			return err
+		}
+	} else {
+		i, err := cliutils.GetCLIInfo(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		conn, err := client.GetGRPCClientConn(ctx, i.Domain)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		c := corev1.NewMainServiceClient(conn)
+
+		resp, err = c.TestPolicy(ctx, req)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmdArgs.Out != "" {
+		out, err := cliutils.OutFormatPrint(cmdArgs.Out, resp)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", string(out))
+	} else {
+		printResponse(cmd.OutOrStdout(), resp)
+	}
+
+	cmd.SilenceUsage = true
+
+	if failed := getFailedCount(resp); failed > 0 {
+		return errors.Errorf("%d of %d test cases failed", failed, len(resp.Results))
+	}
+
+	if cmdArgs.FailOnUncovered {
+		if uncovered := len(getUncoveredRules(resp)); uncovered > 0 {
+			return errors.Errorf("%d Policy rules never matched any test case", uncovered)
+		}
+	}
+
+	return nil
+}
+
+// testPolicyLocal evaluates the request by running the local evaluator, which
+// reads the request in JSON from its stdin and writes the response in JSON to
+// its stdout.
+func testPolicyLocal(ctx context.Context, req *corev1.TestPolicyRequest) (*corev1.TestPolicyResponse, error) {
+	evaluatorPath := cmdArgs.LocalEvaluator
+	if evaluatorPath == "" {
+		var err error
+		evaluatorPath, err = exec.LookPath(localEvaluator)
+		if err != nil {
+			return nil, errors.Errorf(
+				"Could not find %s in the PATH. Build it from the Cluster source code or set its path via --local-evaluator",
+				localEvaluator)
+		}
+	}
+
+	reqBytes, err := pbutils.MarshalJSON(req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, evaluatorPath)
+	c.Stdin = bytes.NewReader(reqBytes)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+
+	if err := c.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Errorf("Local evaluation failed: %s", msg)
+		}
+		return nil, errors.Errorf("Local evaluation failed: %+v", err)
+	}
+
+	ret := &corev1.TestPolicyResponse{}
+	if err := pbutils.UnmarshalJSON(stdout.Bytes(), ret); err != nil {
+		return nil, errors.Errorf("Could not parse the output of %s: %+v", evaluatorPath, err)
+	}
+
+	return ret, nil
+}
+
+func getRequest(fPath string) (*corev1.TestPolicyRequest, error) {
+	var reqBytes []byte
+	var err error
+	if fPath == "-" {
+		reqBytes, err = io.ReadAll(os.Stdin)
+	} else {
+		reqBytes, err = os.ReadFile(fPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &corev1.TestPolicyRequest{}
+	if err := pbutils.UnmarshalYAML(reqBytes, ret); err != nil {
+		return nil, errors.Errorf("Could not parse the test file: %+v", err)
+	}
+	if ret.Fixtures == nil {
+		ret.Fixtures = &corev1.TestPolicyRequest_Fixtures{}
+	}
+
+	for _, p := range cmdArgs.Fixtures {
+		rscs, err := resources.LoadCoreResources(p)
+		if err != nil {
+			return nil, errors.Errorf("Could not load the fixtures from %s: %+v", p, err)
+		}
+
+		for _, rsc := range rscs {
+			switch rsc := rsc.(type) {
+			case *corev1.Policy:
+				ret.Fixtures.Policies = append(ret.Fixtures.Policies, rsc)
+			case *corev1.User:
+				ret.Fixtures.Users = append(ret.Fixtures.Users, rsc)
+			case *corev1.Group:
+				ret.Fixtures.Groups = append(ret.Fixtures.Groups, rsc)
+			case *corev1.Device:
+				ret.Fixtures.Devices = append(ret.Fixtures.Devices, rsc)
+			case *corev1.Service:
+				ret.Fixtures.Services = append(ret.Fixtures.Services, rsc)
+			case *corev1.Namespace:
+				ret.Fixtures.Namespaces = append(ret.Fixtures.Namespaces, rsc)
+			case *corev1.Session:
+				ret.Fixtures.Sessions = append(ret.Fixtures.Sessions, rsc)
+			}
+		}
+	}
+
+	if len(ret.Cases) == 0 {
+		return nil, errors.Errorf("The test file has no cases")
+	}
+
+	return ret, nil
+}
+
+func getFailedCount(resp *corev1.TestPolicyResponse) int {
+	ret := 0
+	for _, res := range resp.Results {
+		if !res.IsPassed {
+			ret++
+		}
+	}
+	return ret
+}
+
+func getUncoveredRules(resp *corev1.TestPolicyResponse) []*corev1.TestPolicyResponse_Rule {
+	var ret []*corev1.TestPolicyResponse_Rule
+	for _, rule := range resp.Rules {
+		if rule.MatchCount == 0 {
+			ret = append(ret, rule)
+		}
+	}
+	return ret
+}
+
+func printResponse(w io.Writer, resp *corev1.TestPolicyResponse) {
+	bold := color.New(color.Bold)
+	green := color.New(color.FgGreen, color.Bold)
+	red := color.New(color.FgRed, color.Bold)
+
+	for _, res := range resp.Results {
+		if res.IsPassed {
+			green.Fprintf(w, "PASS")
+		} else {
+			red.Fprintf(w, "FAIL")
+		}
+
+		decision := "DENIED"
+		if res.IsAuthorized {
+			decision = "ALLOWED"
+		}
+		fmt.Fprintf(w, "  %s (%s, %s)\n", res.Name, decision, res.Reason.GetType().String())
+
+		for _, failure := range res.Failures {
+			fmt.Fprintf(w, "      %s\n", failure)
+		}
+		if res.Error != "" {
+			color.New(color.FgRed).Fprintf(w, "      Error: %s\n", res.Error)
+		}
+	}
+
+	fmt.Fprintln(w)
+
+	failed := getFailedCount(resp)
+	fmt.Fprintf(w, "%d passed, %d failed\n", len(resp.Results)-failed, failed)
+
+	if len(resp.Rules) == 0 {
+		return
+	}
+
+	uncovered := getUncoveredRules(resp)
+	fmt.Fprintf(w, "Coverage: %d of %d rules matched\n", len(resp.Rules)-len(uncovered), len(resp.Rules))
+
+	if len(uncovered) > 0 {
+		fmt.Fprintln(w)
+		bold.Fprintf(w, "Rules that never matched:\n")
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, rule := range uncovered {
+			fmt.Fprintf(tw, "  %s\t#%d\t%s\t%s\n",
+				getRuleNameStr(rule.Name), rule.Index, rule.Effect.String(), getSourceStr(rule.Source))
+		}
+		tw.Flush()
+	}
+}
+
+func getRuleNameStr(name string) string {
+	if name == "" {
+		return "-"
+	}
+	return name
+}
+
+func getSourceStr(src *corev1.SimulatePolicyResponse_Source) string {
+	switch {
+	case src.GetPolicyRef() != nil:
+		return fmt.Sprintf("Policy %s", src.GetPolicyRef().Name)
+	case src.GetInlinePolicy() != nil:
+		inline := src.GetInlinePolicy()
+		ret := "InlinePolicy"
+		if inline.Name != "" {
+			ret = fmt.Sprintf("%s %s", ret, inline.Name)
+		}
+		if inline.ResourceRef != nil {
+			ret = fmt.Sprintf("%s of %s %s", ret, inline.ResourceRef.Kind, inline.ResourceRef.Name)
+		}
+		return ret
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRequest(t *testing.T) {
+	t.Cleanup(func() {
+		cmdArgs = args{}
+	})
+
+	dir := t.TempDir()
+
+	testPath := filepath.Join(dir, "policies_test.yaml")
+	err := os.WriteFile(testPath, []byte(`
+fixtures:
+  users:
+    - metadata:
+        name: alice
+      spec:
+        type: HUMAN
+        groups: ["ops"]
+cases:
+  - name: ops-can-connect
+    user: alice
+    service: db.prod
+    expect:
+      decision: ALLOW
+      reason: POLICY_MATCH
+      ruleName: ops
+`), 0600)
+	assert.Nil(t, err)
+
+	fixturesPath := filepath.Join(dir, "fixtures.yaml")
+	err = os.WriteFile(fixturesPath, []byte(`
+kind: Policy
+metadata:
+  name: allow-ops
+spec:
+  rules:
+    - name: ops
+      effect: ALLOW
+      condition:
+        match: '"ops" in ctx.user.spec.groups'
+---
+kind: Service
+metadata:
+  name: db.prod
+spec:
+  mode: POSTGRES
+  port: 5432
+  authorization:
+    policies: ["allow-ops"]
+`), 0600)
+	assert.Nil(t, err)
+
+	{
+		cmdArgs = args{}
+		req, err := getRequest(testPath)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(req.Fixtures.Users))
+		assert.Equal(t, []string{"ops"}, req.Fixtures.Users[0].Spec.Groups)
+		assert.Equal(t, 0, len(req.Fixtures.Policies))
+
+		assert.Equal(t, 1, len(req.Cases))
+		c := req.Cases[0]
+		assert.Equal(t, "ops-can-connect", c.Name)
+		assert.Equal(t, "alice", c.User)
+		assert.Equal(t, "db.prod", c.Service)
+		assert.Equal(t, corev1.TestPolicyRequest_Case_Expect_ALLOW, c.Expect.Decision)
+		assert.Equal(t, corev1.AccessLog_Entry_Common_Reason_POLICY_MATCH, c.Expect.Reason)
+		assert.Equal(t, "ops", c.Expect.RuleName)
+	}
+
+	{
+		cmdArgs = args{
+			Fixtures: []string{fixturesPath},
+		}
+		req, err := getRequest(testPath)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(req.Fixtures.Users))
+		assert.Equal(t, 1, len(req.Fixtures.Policies))
+		assert.Equal(t, "allow-ops", req.Fixtures.Policies[0].Metadata.Name)
+		assert.Equal(t, 1, len(req.Fixtures.Services))
+		assert.Equal(t, []string{"allow-ops"}, req.Fixtures.Services[0].Spec.Authorization.Policies)
+	}
+
+	{
+		cmdArgs = args{}
+		emptyPath := filepath.Join(dir, "empty.yaml")
+		assert.Nil(t, os.WriteFile(emptyPath, []byte(`fixtures: {}`), 0600))
+		_, err := getRequest(emptyPath)
+		assert.NotNil(t, err)
+	}
+
+	{
+		cmdArgs = args{}
+		_, err := getRequest(filepath.Join(dir, "unknown.yaml"))
+		assert.NotNil(t, err)
+	}
+}
+
+func TestPrintResponse(t *testing.T) {
+	color.NoColor = true
+
+	resp := &corev1.TestPolicyResponse{
+		Results: []*corev1.TestPolicyResponse_Result{
+			{
+				Name:         "ops-can-connect",
+				IsPassed:     true,
+				IsAuthorized: true,
+				Reason: &corev1.AccessLog_Entry_Common_Reason{
+					Type: corev1.AccessLog_Entry_Common_Reason_POLICY_MATCH,
+				},
+			},
+			{
+				Name: "contractors-can-connect",
+				Reason: &corev1.AccessLog_Entry_Common_Reason{
+					Type: corev1.AccessLog_Entry_Common_Reason_NO_POLICY_MATCH,
+				},
+				Failures: []string{"Expected the request to be allowed but it was denied"},
+			},
+		},
+		Rules: []*corev1.TestPolicyResponse_Rule{
+			{
+				Name:       "ops",
+				Effect:     corev1.Policy_Spec_Rule_ALLOW,
+				MatchCount: 1,
+				Source: &corev1.SimulatePolicyResponse_Source{
+					Type: &corev1.SimulatePolicyResponse_Source_PolicyRef{
+						PolicyRef: &metav1.ObjectReference{
+							Name: "allow-ops",
+						},
+					},
+				},
+			},
+			{
+				Index:  1,
+				Effect: corev1.Policy_Spec_Rule_DENY,
+				Source: &corev1.SimulatePolicyResponse_Source{
+					Type: &corev1.SimulatePolicyResponse_Source_InlinePolicy_{
+						InlinePolicy: &corev1.SimulatePolicyResponse_Source_InlinePolicy{
+							Name: "deny-all",
+							ResourceRef: &metav1.ObjectReference{
+								Kind: "Service",
+								Name: "db.prod",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, 1, getFailedCount(resp))
+	assert.Equal(t, 1, len(getUncoveredRules(resp)))
+
+	buf := &bytes.Buffer{}
+	printResponse(buf, resp)
+
+	out := buf.String()
+	assert.Contains(t, out, "PASS  ops-can-connect (ALLOWED, POLICY_MATCH)")
+	assert.Contains(t, out, "FAIL  contractors-can-connect (DENIED, NO_POLICY_MATCH)")
+	assert.Contains(t, out, "Expected the request to be allowed but it was denied")
+	assert.Contains(t, out, "1 passed, 1 failed")
+	assert.Contains(t, out, "Coverage: 1 of 2 rules matched")
+	assert.Contains(t, out, "InlinePolicy deny-all of Service db.prod")
+	assert.NotContains(t, out, "Policy allow-ops")
+}
+
+func TestTestPolicyLocal(t *testing.T) {
+	t.Cleanup(func() {
+		cmdArgs = args{}
+	})
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	req := &corev1.TestPolicyRequest{
+		Cases: []*corev1.TestPolicyRequest_Case{
+			{
+				Name:    "case-1",
+				User:    "alice",
+				Service: "svc1",
+			},
+		},
+	}
+
+	{
+		evaluatorPath := filepath.Join(dir, "evaluator")
+		err := os.WriteFile(evaluatorPath, []byte(`#!/bin/sh
+cat > /dev/null
+echo '{"results":[{"name":"case-1","isPassed":true,"isAuthorized":true}]}'
+`), 0700)
+		assert.Nil(t, err)
+		cmdArgs.LocalEvaluator = evaluatorPath
+
+		resp, err := testPolicyLocal(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(resp.Results))
+		assert.Equal(t, "case-1", resp.Results[0].Name)
+		assert.True(t, resp.Results[0].IsPassed)
+	}
+
+	{
+		evaluatorPath := filepath.Join(dir, "evaluator-err")
+		err := os.WriteFile(evaluatorPath, []byte(`#!/bin/sh
+echo 'The User alice is not in the fixtures' >&2
+exit 1
+`), 0700)
+		assert.Nil(t, err)
+		cmdArgs.LocalEvaluator = evaluatorPath
+
+		_, err = testPolicyLocal(ctx, req)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "The User alice is not in the fixtures")
+	}
+
+	{
+		cmdArgs.LocalEvaluator = ""
+		t.Setenv("PATH", dir)
+
+		_, err := testPolicyLocal(ctx, req)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), localEvaluator)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/spf13/cobra"
+)
+
+const example = `
+ octeliumctl update accessrequest --approve alice-p4wbr9kc
+ octeliumctl update ar --deny alice-p4wbr9kc --reason "Not needed for this ticket"
+ `
+
+var Cmd = &cobra.Command{
+	Use:     "accessrequest",
+	Short:   "Approve or deny an AccessRequest",
+	Example: example,
+	Aliases: []string{"ar", "access-request"},
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+type args struct {
+	Approve bool
+	Deny    bool
+	Reason  string
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.Approve, "approve", false, "Approve the AccessRequest")
+	Cmd.PersistentFlags().BoolVar(&cmdArgs.Deny, "deny", false, "Deny the AccessRequest")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Reason, "reason", "", "An optional comment on the decision")
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	conn, err := client.GetGRPCClientConn(cmd.Context(), i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := corev1.NewMainServiceClient(conn)
+
+	ref := &metav1.ObjectReference{
+		Name: i.FirstArg(),
+	}
+
+	switch {
+	case cmdArgs.Approve:
+		_, err = c.ApproveAccessRequest(ctx, &corev1.ApproveAccessRequestRequest{
+			AccessRequestRef: ref,
+			Reason:           cmdArgs.Reason,
+		})
+	case cmdArgs.Deny:
+		_, err = c.DenyAccessRequest(ctx, &corev1.DenyAccessRequestRequest{
+			AccessRequestRef: ref,
+			Reason:           cmdArgs.Reason,
+		})
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cliutils.LineNotify("AccessRequest %s successfully updated\n", i.FirstArg())
+
+	return nil
+}
@@ -15,9 +15,11 @@
 package update
 
 import (
+	"github.com/octelium/octelium/client/octeliumctl/commands/update/accessrequest"
 	"github.com/octelium/octelium/client/octeliumctl/commands/update/authenticator"
 	"github.com/octelium/octelium/client/octeliumctl/commands/update/config"
 	"github.com/octelium/octelium/client/octeliumctl/commands/update/device"
+	"github.com/octelium/octelium/client/octeliumctl/commands/update/deviceposture"
 	"github.com/octelium/octelium/client/octeliumctl/commands/update/secret"
 	"github.com/spf13/cobra"
 )
@@ -30,6 +32,8 @@ var Cmd = &cobra.Command{
 func AddSubcommands() {
 	Cmd.AddCommand(secret.Cmd)
 	Cmd.AddCommand(device.Cmd)
+	Cmd.AddCommand(deviceposture.Cmd)
 	Cmd.AddCommand(authenticator.Cmd)
 	Cmd.AddCommand(config.Cmd)
+	Cmd.AddCommand(accessrequest.Cmd)
 }
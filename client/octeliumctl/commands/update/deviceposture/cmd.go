@@ -0,0 +1,228 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviceposture
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/karrick/tparse/v2"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/client/common/client"
+	"github.com/octelium/octelium/client/common/cliutils"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const example = `
+ octeliumctl update device-posture --serial-number C02XK1ZZJGH5 --disk-encryption pass --compliant fail
+ octeliumctl update device-posture --hostname laptop-01 --risk-level high --signal edrRunning=fail --expire-in 6hours
+ octeliumctl update device-posture --id 4c4c4544-0051 --threat-free pass --attr vendor=crowdstrike
+ octeliumctl update device-posture --serial-number C02XK1ZZJGH5 --source jamf --compliant pass
+ `
+
+var Cmd = &cobra.Command{
+	Use:     "device-posture",
+	Short:   "Set the Posture of a Device from an external provider",
+	Example: example,
+	Aliases: []string{"dev-posture"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCmd(cmd, args)
+	},
+}
+
+type args struct {
+	SerialNumber string
+	Hostname     string
+	ID           string
+	Source       string
+
+	RiskLevel      string
+	DiskEncryption string
+	Compliant      string
+	ThreatFree     string
+	Signals        []string
+	Attrs          []string
+	ExpiresIn      string
+	Out            string
+}
+
+var cmdArgs args
+
+func init() {
+	Cmd.PersistentFlags().StringVar(&cmdArgs.SerialNumber, "serial-number", "", "Select the Device by its serial number")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Hostname, "hostname", "", "Select the Device by its hostname")
+	Cmd.PersistentFlags().StringVar(&cmdArgs.ID, "id", "", "Select the Device by its host-derived ID")
+
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Source, "source", "",
+		`The name of the reporter of the Posture. Only the Posture previously set by the same source is replaced. The default is "api"`)
+
+	Cmd.PersistentFlags().StringVar(&cmdArgs.RiskLevel, "risk-level", "", `Risk level (Can take the values: "low", "medium", "high" or "critical")`)
+	Cmd.PersistentFlags().StringVar(&cmdArgs.DiskEncryption, "disk-encryption", "", `Disk encryption signal state (Can take the values: "pass", "fail" or "not-applicable")`)
+	Cmd.PersistentFlags().StringVar(&cmdArgs.Compliant, "compliant", "", `Compliance signal state (Can take the values: "pass", "fail" or "not-applicable")`)
+	Cmd.PersistentFlags().StringVar(&cmdArgs.ThreatFree, "threat-free", "", `Threat-free signal state (Can take the values: "pass", "fail" or "not-applicable")`)
+	Cmd.PersistentFlags().StringSliceVar(&cmdArgs.Signals, "signal", nil,
+		`Additional signal in the form of name=state (e.g. "edrRunning=pass"). Use the flag multiple times to add more signals.`)
+	Cmd.PersistentFlags().StringSliceVar(&cmdArgs.Attrs, "attr", nil,
+		`Additional attribute in the form of key=value. Use the flag multiple times to add more attributes.`)
+	Cmd.PersistentFlags().StringVar(&cmdArgs.ExpiresIn, "expire-in", "", "Set the duration after which the Posture expires (e.g. `6hours`, `1day`). The default is 24 hours")
+	Cmd.PersistentFlags().StringVarP(&cmdArgs.Out, "out", "o", "", "Output format")
+}
+
+func doCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	i, err := cliutils.GetCLIInfo(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	req, err := getRequest()
+	if err != nil {
+		return err
+	}
+
+	conn, err := client.GetGRPCClientConn(cmd.Context(), i.Domain)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := corev1.NewMainServiceClient(conn)
+
+	dev, err := c.SetDevicePosture(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if cmdArgs.Out != "" {
+		out, err := cliutils.OutFormatPrint(cmdArgs.Out, dev)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", string(out))
+		return nil
+	}
+
+	cliutils.LineNotify("Posture of the Device %s successfully updated\n", dev.Metadata.Name)
+
+	return nil
+}
+
+func getRequest() (*corev1.SetDevicePostureRequest, error) {
+	req := &corev1.SetDevicePostureRequest{
+		Source:  cmdArgs.Source,
+		Posture: &corev1.Device_Status_Posture{},
+	}
+
+	switch {
+	case cmdArgs.SerialNumber != "":
+		req.Device = &corev1.SetDevicePostureRequest_SerialNumber{
+			SerialNumber: cmdArgs.SerialNumber,
+		}
+	case cmdArgs.Hostname != "":
+		req.Device = &corev1.SetDevicePostureRequest_Hostname{
+			Hostname: cmdArgs.Hostname,
+		}
+	case cmdArgs.ID != "":
+		req.Device = &corev1.SetDevicePostureRequest_Id{
+			Id: cmdArgs.ID,
+		}
+	default:
+		return nil, errors.Errorf("You must set either --serial-number, --hostname or --id")
+	}
+
+	var err error
+	posture := req.Posture
+
+	if cmdArgs.RiskLevel != "" {
+		val, ok := corev1.Device_Status_Posture_RiskLevel_value[toEnumName(cmdArgs.RiskLevel)]
+		if !ok {
+			return nil, errors.Errorf("Invalid risk level: %s", cmdArgs.RiskLevel)
+		}
+		posture.RiskLevel = corev1.Device_Status_Posture_RiskLevel(val)
+	}
+
+	if posture.DiskEncryption, err = getSignalState(cmdArgs.DiskEncryption); err != nil {
+		return nil, err
+	}
+	if posture.Compliant, err = getSignalState(cmdArgs.Compliant); err != nil {
+		return nil, err
+	}
+	if posture.ThreatFree, err = getSignalState(cmdArgs.ThreatFree); err != nil {
+		return nil, err
+	}
+
+	for _, sig := range cmdArgs.Signals {
+		name, stateStr, ok := strings.Cut(sig, "=")
+		if !ok || name == "" {
+			return nil, errors.Errorf("Invalid signal: %s. It must be in the form of name=state", sig)
+		}
+
+		state, err := getSignalState(stateStr)
+		if err != nil {
+			return nil, err
+		}
+
+		if posture.Signals == nil {
+			posture.Signals = make(map[string]corev1.Device_Status_Posture_SignalState)
+		}
+		posture.Signals[name] = state
+	}
+
+	if len(cmdArgs.Attrs) > 0 {
+		attrs := make(map[string]any)
+		for _, attr := range cmdArgs.Attrs {
+			key, val, ok := strings.Cut(attr, "=")
+			if !ok || key == "" {
+				return nil, errors.Errorf("Invalid attr: %s. It must be in the form of key=value", attr)
+			}
+			attrs[key] = val
+		}
+
+		if posture.Attrs, err = pbutils.MapToStruct(attrs); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmdArgs.ExpiresIn != "" {
+		t, err := tparse.AddDuration(time.Now(), cmdArgs.ExpiresIn)
+		if err != nil {
+			return nil, err
+		}
+		posture.ExpiresAt = pbutils.Timestamp(t)
+	}
+
+	return req, nil
+}
+
+func getSignalState(arg string) (corev1.Device_Status_Posture_SignalState, error) {
+	if arg == "" {
+		return corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN, nil
+	}
+
+	val, ok := corev1.Device_Status_Posture_SignalState_value[toEnumName(arg)]
+	if !ok {
+		return corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN, errors.Errorf("Invalid signal state: %s", arg)
+	}
+
+	return corev1.Device_Status_Posture_SignalState(val), nil
+}
+
+func toEnumName(arg string) string {
+	return strings.ToUpper(strings.ReplaceAll(arg, "-", "_"))
+}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/fatih/color"
@@ -32,6 +33,14 @@ func init() {
 
 func main() {
 	if err := commands.Cmd.Execute(); err != nil {
+		var exitErr *cliutils.ExitCodeError
+		if errors.As(err, &exitErr) {
+			if exitErr.Err != nil {
+				color.New(color.FgRed, color.Bold).Printf("%s\n", cliutils.GrpcErr(exitErr.Err))
+			}
+			os.Exit(exitErr.Code)
+		}
+
 		color.New(color.FgRed, color.Bold).Printf("%s\n", cliutils.GrpcErr(err))
 		os.Exit(1)
 	}
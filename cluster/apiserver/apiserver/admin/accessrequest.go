@@ -0,0 +1,130 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/serr"
+	"github.com/octelium/octelium/cluster/common/accessrequest"
+	"github.com/octelium/octelium/cluster/common/apivalidation"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/urscsrv"
+	"github.com/octelium/octelium/cluster/common/userctx"
+	"github.com/octelium/octelium/pkg/utils/ldflags"
+)
+
+const maxAccessRequestDuration = 30 * 24 * time.Hour
+
+func (s *Server) ListAccessRequest(ctx context.Context, req *corev1.ListAccessRequestOptions) (*corev1.AccessRequestList, error) {
+
+	var listOpts []*rmetav1.ListOptions_Filter
+
+	if req.UserRef != nil {
+		if err := apivalidation.CheckObjectRef(req.UserRef, &apivalidation.CheckGetOptionsOpts{}); err != nil {
+			return nil, err
+		}
+		usr, err := s.octeliumC.CoreC().GetUser(ctx, apivalidation.ObjectReferenceToRGetOptions(req.UserRef))
+		if err != nil {
+			return nil, err
+		}
+		listOpts = append(listOpts, urscsrv.FilterStatusUserUID(usr.Metadata.Uid))
+	}
+
+	switch req.State {
+	case corev1.AccessRequest_Status_STATE_UNKNOWN:
+	case corev1.AccessRequest_Status_PENDING,
+		corev1.AccessRequest_Status_APPROVED,
+		corev1.AccessRequest_Status_DENIED,
+		corev1.AccessRequest_Status_EXPIRED:
+		listOpts = append(listOpts, urscsrv.FilterFieldEQValStr("status.state", req.State.String()))
+	default:
+		return nil, grpcutils.InvalidArg("Invalid state")
+	}
+
+	ret, err := s.octeliumC.CoreC().ListAccessRequest(ctx, urscsrv.GetPublicListOptions(req, listOpts...))
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *Server) GetAccessRequest(ctx context.Context, req *metav1.GetOptions) (*corev1.AccessRequest, error) {
+	if err := apivalidation.CheckGetOptions(req, nil); err != nil {
+		return nil, err
+	}
+
+	ret, err := s.octeliumC.CoreC().GetAccessRequest(ctx, apivalidation.GetOptionsToRGetOptions(req))
+	if err != nil {
+		return nil, serr.K8sNotFoundOrInternalWithErr(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) DeleteAccessRequest(ctx context.Context, req *metav1.DeleteOptions) (*metav1.OperationResult, error) {
+	if err := apivalidation.CheckDeleteOptions(req, nil); err != nil {
+		return nil, err
+	}
+
+	ar, err := s.octeliumC.CoreC().GetAccessRequest(ctx, apivalidation.DeleteOptionsToRGetOptions(req))
+	if err != nil {
+		return nil, serr.K8sNotFoundOrInternalWithErr(err)
+	}
+
+	if _, err := s.octeliumC.CoreC().DeleteAccessRequest(ctx, apivalidation.ObjectToRDeleteOptions(ar)); err != nil {
+		return nil, serr.InternalWithErr(err)
+	}
+
+	return &metav1.OperationResult{}, nil
+}
+
+func (s *Server) ApproveAccessRequest(ctx context.Context, req *corev1.ApproveAccessRequestRequest) (*corev1.AccessRequest, error) {
+	return s.doDecideAccessRequest(ctx, req.GetAccessRequestRef(), true, req.GetReason())
+}
+
+func (s *Server) DenyAccessRequest(ctx context.Context, req *corev1.DenyAccessRequestRequest) (*corev1.AccessRequest, error) {
+	return s.doDecideAccessRequest(ctx, req.GetAccessRequestRef(), false, req.GetReason())
+}
+
+func (s *Server) doDecideAccessRequest(ctx context.Context,
+	ref *metav1.ObjectReference, isApproved bool, reason string) (*corev1.AccessRequest, error) {
+	if err := apivalidation.CheckObjectRef(ref, &apivalidation.CheckGetOptionsOpts{}); err != nil {
+		return nil, err
+	}
+
+	ar, err := s.octeliumC.CoreC().GetAccessRequest(ctx, apivalidation.ObjectReferenceToRGetOptions(ref))
+	if err != nil {
+		return nil, serr.K8sNotFoundOrInternalWithErr(err)
+	}
+
+	var approver *corev1.User
+	if !ldflags.IsTest() {
+		i, err := userctx.GetUserCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		approver = i.User
+	}
+
+	return accessrequest.Decide(ctx, s.octeliumC, ar, approver, isApproved, reason)
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/cluster/common/accessrequest"
+	"github.com/octelium/octelium/cluster/common/tests"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessRequest(t *testing.T) {
+	ctx := context.Background()
+
+	tst, err := tests.Initialize(nil)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		tst.Destroy()
+	})
+	srv := newFakeServer(tst.C)
+
+	usr, err := srv.CreateUser(ctx, tests.GenUser(nil))
+	assert.Nil(t, err)
+
+	ns, err := srv.CreateNamespace(ctx, tests.GenNamespace())
+	assert.Nil(t, err)
+
+	ar, err := accessrequest.Create(ctx, srv.octeliumC, usr, &corev1.AccessRequest_Spec{
+		Namespace:     ns.Metadata.Name,
+		Justification: "Deploying a hotfix",
+		Duration: &metav1.Duration{
+			Type: &metav1.Duration_Minutes{Minutes: 30},
+		},
+	})
+	assert.Nil(t, err, "%+v", err)
+	assert.Equal(t, corev1.AccessRequest_Status_PENDING, ar.Status.State)
+	assert.Equal(t, ns.Metadata.Uid, ar.Status.NamespaceRef.Uid)
+
+	{
+		res, err := srv.GetAccessRequest(ctx, &metav1.GetOptions{Name: ar.Metadata.Name})
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, ar.Metadata.Uid, res.Metadata.Uid)
+	}
+
+	{
+		itmList, err := srv.ListAccessRequest(ctx, &corev1.ListAccessRequestOptions{
+			UserRef: umetav1.GetObjectReference(usr),
+			State:   corev1.AccessRequest_Status_PENDING,
+		})
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, 1, len(itmList.Items))
+
+		itmList, err = srv.ListAccessRequest(ctx, &corev1.ListAccessRequestOptions{
+			UserRef: umetav1.GetObjectReference(usr),
+			State:   corev1.AccessRequest_Status_APPROVED,
+		})
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, 0, len(itmList.Items))
+	}
+
+	{
+		res, err := srv.ApproveAccessRequest(ctx, &corev1.ApproveAccessRequestRequest{
+			AccessRequestRef: umetav1.GetObjectReference(ar),
+		})
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, corev1.AccessRequest_Status_APPROVED, res.Status.State)
+		assert.NotNil(t, res.Status.Decision)
+		assert.True(t, res.Status.ExpiresAt.IsValid())
+	}
+
+	{
+		_, err := srv.DenyAccessRequest(ctx, &corev1.DenyAccessRequestRequest{
+			AccessRequestRef: umetav1.GetObjectReference(ar),
+		})
+		assert.NotNil(t, err)
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+
+	{
+		_, err := srv.DeleteAccessRequest(ctx, &metav1.DeleteOptions{Name: ar.Metadata.Name})
+		assert.Nil(t, err, "%+v", err)
+
+		_, err = srv.GetAccessRequest(ctx, &metav1.GetOptions{Name: ar.Metadata.Name})
+		assert.NotNil(t, err)
+		assert.True(t, grpcerr.IsNotFound(err))
+	}
+}
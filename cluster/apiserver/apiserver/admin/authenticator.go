@@ -92,6 +92,10 @@ func (s *Server) UpdateAuthenticator(ctx context.Context, req *corev1.Authentica
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(item, req); err != nil {
+		return nil, err
+	}
+
 	item.Spec = req.Spec
 
 	item, err = s.octeliumC.CoreC().UpdateAuthenticator(ctx, item)
@@ -18,6 +18,9 @@ package admin
 
 import (
 	"context"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/asaskevich/govalidator"
@@ -27,6 +30,7 @@ import (
 	"github.com/octelium/octelium/cluster/apiserver/apiserver/serr"
 	"github.com/octelium/octelium/cluster/common/apivalidation"
 	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
 	"github.com/octelium/octelium/pkg/grpcerr"
 )
 
@@ -37,11 +41,24 @@ const (
 	ccMaxDevicesPerUser    = 1000
 	ccMaxDNSServers        = 32
 	ccMaxDNSServerLen      = 256
+	ccMaxDNSZones          = 128
+	ccMaxDNSRecords        = 1024
+	ccMaxDNSRecordValues   = 32
 	ccMaxXFFNumTrustedHops = 64
 	ccMaxURLLen            = 1024
 	ccMaxNameLen           = 256
+	ccMaxPostureProbes     = 64
+	ccMaxProbeArgs         = 64
+	ccMaxProbePathLen      = 1024
+	ccMaxPostureConnectors = 16
+	ccMaxApproverGroups    = 32
+	ccMaxRiskScore         = 100
+	ccMaxTravelSpeed       = 100000
+	ccMaxDeniedBurstLimit  = 100000
 )
 
+var rgxPostureName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,63}$`)
+
 func (s *Server) GetClusterConfig(ctx context.Context, req *corev1.GetClusterConfigRequest) (*corev1.ClusterConfig, error) {
 	cc, err := s.octeliumC.CoreV1Utils().GetClusterConfig(ctx)
 	if err != nil {
@@ -62,6 +79,10 @@ func (s *Server) UpdateClusterConfig(ctx context.Context, req *corev1.ClusterCon
 		return nil, serr.InternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(cfg, req); err != nil {
+		return nil, err
+	}
+
 	apisrvcommon.MetadataUpdate(cfg.Metadata, req.Metadata)
 	cfg.Spec = req.Spec
 
@@ -102,6 +123,10 @@ func (s *Server) validateClusterConfigSpec(ctx context.Context, c *corev1.Cluste
 		return err
 	}
 
+	if err := s.validateCCDevicePosture(ctx, c); err != nil {
+		return err
+	}
+
 	if err := validateIngress(c); err != nil {
 		return err
 	}
@@ -114,6 +139,10 @@ func (s *Server) validateClusterConfigSpec(ctx context.Context, c *corev1.Cluste
 		return err
 	}
 
+	if err := s.validateDNSFilter(ctx, c); err != nil {
+		return err
+	}
+
 	if err := s.validateCCAuthorization(ctx, c); err != nil {
 		return err
 	}
@@ -126,6 +155,10 @@ func (s *Server) validateClusterConfigSpec(ctx context.Context, c *corev1.Cluste
 		return err
 	}
 
+	if err := s.validateCCAccessRequest(ctx, c); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -184,6 +217,49 @@ func validateSession(c *corev1.ClusterConfig) error {
 		}
 	}
 
+	if err := validateSessionRisk(c.Spec.Session.Risk); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateSessionRisk(risk *corev1.ClusterConfig_Spec_Session_Risk) error {
+	if risk == nil {
+		return nil
+	}
+
+	if risk.MaxTravelSpeed > ccMaxTravelSpeed {
+		return grpcutils.InvalidArg("Session risk maxTravelSpeed is too large: %d", risk.MaxTravelSpeed)
+	}
+
+	if risk.ReauthenticateScore > ccMaxRiskScore {
+		return grpcutils.InvalidArg("Session risk reauthenticateScore must be at most %d", ccMaxRiskScore)
+	}
+
+	if risk.TerminateScore > ccMaxRiskScore {
+		return grpcutils.InvalidArg("Session risk terminateScore must be at most %d", ccMaxRiskScore)
+	}
+
+	if risk.ReauthenticateScore > 0 && risk.TerminateScore > 0 &&
+		risk.ReauthenticateScore >= risk.TerminateScore {
+		return grpcutils.InvalidArg("Session risk reauthenticateScore must be less than terminateScore")
+	}
+
+	if err := apivalidation.ValidateDuration(risk.SignalDuration); err != nil {
+		return err
+	}
+
+	if burst := risk.DeniedBurst; burst != nil {
+		if burst.Limit > ccMaxDeniedBurstLimit {
+			return grpcutils.InvalidArg("Session risk deniedBurst limit is too large: %d", burst.Limit)
+		}
+
+		if err := apivalidation.ValidateDuration(burst.Window); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -226,6 +302,256 @@ func validateCCDevice(c *corev1.ClusterConfig) error {
 	return nil
 }
 
+func (s *Server) validateCCDevicePosture(ctx context.Context, c *corev1.ClusterConfig) error {
+	cfg := c.Spec.GetDevice().GetPosture()
+	if cfg == nil {
+		return nil
+	}
+
+	if err := apivalidation.ValidateDuration(cfg.Interval); err != nil {
+		return err
+	}
+
+	if err := apivalidation.ValidateDuration(cfg.Ttl); err != nil {
+		return err
+	}
+
+	if len(cfg.Probes) > ccMaxPostureProbes {
+		return grpcutils.InvalidArg("Too many posture Probes")
+	}
+
+	probeNames := make(map[string]bool)
+	for _, p := range cfg.Probes {
+		if p == nil {
+			return grpcutils.InvalidArg("Nil posture Probe")
+		}
+
+		if !rgxPostureName.MatchString(p.Name) {
+			return grpcutils.InvalidArg("Invalid posture Probe name: %s", p.Name)
+		}
+
+		if probeNames[p.Name] {
+			return grpcutils.InvalidArg("Duplicate posture Probe name: %s", p.Name)
+		}
+		probeNames[p.Name] = true
+
+		switch p.OsType {
+		case corev1.Device_Status_LINUX, corev1.Device_Status_WINDOWS, corev1.Device_Status_MAC:
+		default:
+			return grpcutils.InvalidArg("Invalid posture Probe osType: %s", p.OsType.String())
+		}
+
+		if p.Condition != nil {
+			if err := s.validateCondition(ctx, p.Condition); err != nil {
+				return err
+			}
+		}
+
+		if err := validatePostureProbeType(p); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.Signals) > ccMaxRules {
+		return grpcutils.InvalidArg("Too many posture Signals")
+	}
+
+	signalNames := make(map[string]bool)
+	for _, sig := range cfg.Signals {
+		if sig == nil {
+			return grpcutils.InvalidArg("Nil posture Signal")
+		}
+
+		if !rgxPostureName.MatchString(sig.Name) {
+			return grpcutils.InvalidArg("Invalid posture Signal name: %s", sig.Name)
+		}
+
+		if signalNames[sig.Name] {
+			return grpcutils.InvalidArg("Duplicate posture Signal name: %s", sig.Name)
+		}
+		signalNames[sig.Name] = true
+
+		if sig.Condition == nil {
+			return grpcutils.InvalidArg("Posture Signal %s must have a condition", sig.Name)
+		}
+
+		if err := s.validateCondition(ctx, sig.Condition); err != nil {
+			return err
+		}
+
+		if sig.NotApplicable != nil {
+			if err := s.validateCondition(ctx, sig.NotApplicable); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(cfg.Risks) > ccMaxRules {
+		return grpcutils.InvalidArg("Too many posture Risks")
+	}
+
+	for _, r := range cfg.Risks {
+		if r == nil {
+			return grpcutils.InvalidArg("Nil posture Risk")
+		}
+
+		switch r.Level {
+		case corev1.Device_Status_Posture_LOW,
+			corev1.Device_Status_Posture_MEDIUM,
+			corev1.Device_Status_Posture_HIGH,
+			corev1.Device_Status_Posture_CRITICAL:
+		default:
+			return grpcutils.InvalidArg("Invalid posture Risk level: %s", r.Level.String())
+		}
+
+		if r.Condition == nil {
+			return grpcutils.InvalidArg("Posture Risk must have a condition")
+		}
+
+		if err := s.validateCondition(ctx, r.Condition); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.Connectors) > ccMaxPostureConnectors {
+		return grpcutils.InvalidArg("Too many posture Connectors")
+	}
+
+	connectorNames := make(map[string]bool)
+	for _, conn := range cfg.Connectors {
+		if conn == nil {
+			return grpcutils.InvalidArg("Nil posture Connector")
+		}
+
+		if !rgxPostureName.MatchString(conn.Name) {
+			return grpcutils.InvalidArg("Invalid posture Connector name: %s", conn.Name)
+		}
+
+		if connectorNames[conn.Name] {
+			return grpcutils.InvalidArg("Duplicate posture Connector name: %s", conn.Name)
+		}
+		connectorNames[conn.Name] = true
+
+		if err := s.validatePostureConnector(ctx, conn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) validatePostureConnector(ctx context.Context,
+	conn *corev1.ClusterConfig_Spec_Device_Posture_Connector) error {
+
+	if conn.Url == "" {
+		return grpcutils.InvalidArg("Posture Connector URL must be set: %s", conn.Name)
+	}
+
+	if len(conn.Url) > ccMaxURLLen {
+		return grpcutils.InvalidArg("Posture Connector URL is too long: %s", conn.Name)
+	}
+
+	if !govalidator.IsURL(conn.Url) || !strings.HasPrefix(conn.Url, "https://") {
+		return grpcutils.InvalidArg("Posture Connector URL must be a valid HTTPS URL: %s", conn.Url)
+	}
+
+	if err := apivalidation.ValidateDuration(conn.Interval); err != nil {
+		return err
+	}
+
+	auth := conn.Auth
+	if auth == nil {
+		return nil
+	}
+
+	switch {
+	case auth.GetBearer() != nil:
+		if err := s.validateSecretOwner(ctx, auth.GetBearer()); err != nil {
+			return err
+		}
+	case auth.GetBasic() != nil:
+		basic := auth.GetBasic()
+		if basic.Username == "" {
+			return grpcutils.InvalidArg("Posture Connector basic auth must set username")
+		}
+		if len(basic.Username) > ccMaxNameLen {
+			return grpcutils.InvalidArg("Posture Connector basic auth username is too long")
+		}
+
+		if err := s.validateSecretOwner(ctx, basic.GetPassword()); err != nil {
+			return err
+		}
+	case auth.GetCustom() != nil:
+		custom := auth.GetCustom()
+		if custom.Header == "" {
+			return grpcutils.InvalidArg("Posture Connector custom auth must set header")
+		}
+		if len(custom.Header) > ccMaxNameLen {
+			return grpcutils.InvalidArg("Posture Connector custom auth header is too long")
+		}
+
+		if err := s.validateSecretOwner(ctx, custom.GetValue()); err != nil {
+			return err
+		}
+	default:
+		return grpcutils.InvalidArg("Posture Connector auth type must be set: %s", conn.Name)
+	}
+
+	return nil
+}
+
+func validatePostureProbeType(p *corev1.ClusterConfig_Spec_Device_Posture_Probe) error {
+	switch t := p.Type.(type) {
+	case *corev1.ClusterConfig_Spec_Device_Posture_Probe_RunCommand:
+		if t.RunCommand == nil {
+			return grpcutils.InvalidArg("Nil runCommand")
+		}
+		if len(t.RunCommand.Command) > ccMaxProbePathLen ||
+			!isAbsProbePath(p.OsType, t.RunCommand.Command) {
+			return grpcutils.InvalidArg("Probe command must be an absolute path: %s", p.Name)
+		}
+		if len(t.RunCommand.Args) > ccMaxProbeArgs {
+			return grpcutils.InvalidArg("Too many Probe command args: %s", p.Name)
+		}
+		for _, arg := range t.RunCommand.Args {
+			if len(arg) > ccMaxProbePathLen {
+				return grpcutils.InvalidArg("Probe command arg is too long: %s", p.Name)
+			}
+		}
+	case *corev1.ClusterConfig_Spec_Device_Posture_Probe_ReadFile:
+		if t.ReadFile == nil {
+			return grpcutils.InvalidArg("Nil readFile")
+		}
+		if len(t.ReadFile.Path) > ccMaxProbePathLen ||
+			!isAbsProbePath(p.OsType, t.ReadFile.Path) {
+			return grpcutils.InvalidArg("Probe file path must be absolute: %s", p.Name)
+		}
+	case *corev1.ClusterConfig_Spec_Device_Posture_Probe_ReadRegistry:
+		if t.ReadRegistry == nil {
+			return grpcutils.InvalidArg("Nil readRegistry")
+		}
+		if p.OsType != corev1.Device_Status_WINDOWS {
+			return grpcutils.InvalidArg("readRegistry Probes are only supported on WINDOWS: %s", p.Name)
+		}
+		if t.ReadRegistry.Key == "" || len(t.ReadRegistry.Key) > ccMaxProbePathLen ||
+			len(t.ReadRegistry.Name) > ccMaxProbePathLen {
+			return grpcutils.InvalidArg("Invalid Probe registry key: %s", p.Name)
+		}
+	default:
+		return grpcutils.InvalidArg("Probe type must be set: %s", p.Name)
+	}
+
+	return nil
+}
+
+func isAbsProbePath(osType corev1.Device_Status_OSType, arg string) bool {
+	if osType == corev1.Device_Status_WINDOWS {
+		return len(arg) > 2 && arg[1] == ':' && (arg[2] == '\\' || arg[2] == '/')
+	}
+
+	return filepath.IsAbs(arg)
+}
+
 func validateDeviceDefaultState(state corev1.Device_Spec_State) error {
 	switch state {
 	case corev1.Device_Spec_STATE_UNKNOWN,
@@ -265,11 +591,87 @@ func validateDNS(c *corev1.ClusterConfig) error {
 		return nil
 	}
 
-	zone := c.Spec.Dns.FallbackZone
-	if zone == nil {
+	if zone := c.Spec.Dns.FallbackZone; zone != nil {
+		if err := validateDNSZoneServers(zone); err != nil {
+			return err
+		}
+	}
+
+	if len(c.Spec.Dns.Zones) > ccMaxDNSZones {
+		return grpcutils.InvalidArg("Too many DNS zones")
+	}
+
+	for _, zone := range c.Spec.Dns.Zones {
+		if err := validateDNSZone(zone); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) validateDNSFilter(ctx context.Context, c *corev1.ClusterConfig) error {
+	filter := c.Spec.GetDns().GetFilter()
+	if filter == nil {
 		return nil
 	}
 
+	if len(filter.Rules) > ccMaxRules {
+		return grpcutils.InvalidArg("Too many DNS filter rules")
+	}
+
+	for _, r := range filter.Rules {
+		if r == nil {
+			return grpcutils.InvalidArg("Nil DNS filter rule")
+		}
+
+		if len(r.Name) > ccMaxRuleNameLen {
+			return grpcutils.InvalidArg("DNS filter rule name is too long")
+		}
+
+		if r.Condition != nil {
+			if err := s.validateCondition(ctx, r.Condition); err != nil {
+				return err
+			}
+		}
+
+		switch r.Effect {
+		case corev1.ClusterConfig_Spec_DNS_Filter_Rule_ALLOW,
+			corev1.ClusterConfig_Spec_DNS_Filter_Rule_BLOCK:
+		default:
+			return grpcutils.InvalidArg("DNS filter rule effect must be set")
+		}
+
+		if len(r.Domains) == 0 && len(r.Lists) == 0 {
+			return grpcutils.InvalidArg("DNS filter rule must have either domains or lists")
+		}
+
+		if len(r.Domains) > ccMaxDNSRecords {
+			return grpcutils.InvalidArg("Too many domains in DNS filter rule")
+		}
+
+		for _, domain := range r.Domains {
+			domain = strings.TrimSuffix(strings.TrimPrefix(domain, "*."), ".")
+			if len(domain) > ccMaxDNSServerLen || !govalidator.IsDNSName(domain) {
+				return grpcutils.InvalidArg("Invalid DNS filter domain: %s", domain)
+			}
+		}
+
+		if len(r.Lists) > ccMaxDNSServers {
+			return grpcutils.InvalidArg("Too many lists in DNS filter rule")
+		}
+
+		for _, list := range r.Lists {
+			if err := apivalidation.ValidateName(list, 0, 0); err != nil {
+				return grpcutils.InvalidArg("Invalid DNS filter list Config name: %s", list)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateDNSZoneServers(zone *corev1.ClusterConfig_Spec_DNS_Zone) error {
 	if err := apivalidation.ValidateDuration(zone.CacheDuration); err != nil {
 		return err
 	}
@@ -297,6 +699,95 @@ func validateDNS(c *corev1.ClusterConfig) error {
 	return nil
 }
 
+func validateDNSZone(zone *corev1.ClusterConfig_Spec_DNS_Zone) error {
+	if zone == nil {
+		return grpcutils.InvalidArg("Nil DNS zone")
+	}
+
+	zoneName := strings.TrimSuffix(zone.Name, ".")
+	if zoneName == "" || len(zoneName) > ccMaxDNSServerLen || !govalidator.IsDNSName(zoneName) {
+		return grpcutils.InvalidArg("Invalid DNS zone name: %s", zone.Name)
+	}
+
+	if err := validateDNSZoneServers(zone); err != nil {
+		return err
+	}
+
+	if len(zone.Records) > ccMaxDNSRecords {
+		return grpcutils.InvalidArg("Too many records in DNS zone: %s", zone.Name)
+	}
+
+	if len(zone.Servers) == 0 && len(zone.Records) == 0 {
+		return grpcutils.InvalidArg("DNS zone %s must have either servers or records", zone.Name)
+	}
+
+	if len(zone.Groups) > ccMaxApproverGroups {
+		return grpcutils.InvalidArg("Too many Groups in DNS zone: %s", zone.Name)
+	}
+
+	for _, g := range zone.Groups {
+		if err := apivalidation.ValidateName(g, 0, 0); err != nil {
+			return grpcutils.InvalidArg("Invalid Group name in DNS zone: %s", g)
+		}
+	}
+
+	for _, rec := range zone.Records {
+		if err := validateDNSRecord(rec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDNSRecord(rec *corev1.ClusterConfig_Spec_DNS_Zone_Record) error {
+	if rec == nil {
+		return grpcutils.InvalidArg("Nil DNS record")
+	}
+
+	switch name := strings.TrimPrefix(rec.Name, "*."); {
+	case rec.Name == "", rec.Name == "@", rec.Name == "*":
+	case len(rec.Name) > ccMaxDNSServerLen || !govalidator.IsDNSName(name):
+		return grpcutils.InvalidArg("Invalid DNS record name: %s", rec.Name)
+	}
+
+	if len(rec.Values) == 0 {
+		return grpcutils.InvalidArg("DNS record %s has no values", rec.Name)
+	}
+
+	if len(rec.Values) > ccMaxDNSRecordValues {
+		return grpcutils.InvalidArg("Too many values in DNS record: %s", rec.Name)
+	}
+
+	for _, val := range rec.Values {
+		switch rec.Type {
+		case corev1.ClusterConfig_Spec_DNS_Zone_Record_A:
+			if !govalidator.IsIPv4(val) {
+				return grpcutils.InvalidArg("Invalid A record value: %s", val)
+			}
+		case corev1.ClusterConfig_Spec_DNS_Zone_Record_AAAA:
+			if !govalidator.IsIPv6(val) {
+				return grpcutils.InvalidArg("Invalid AAAA record value: %s", val)
+			}
+		case corev1.ClusterConfig_Spec_DNS_Zone_Record_CNAME:
+			if len(rec.Values) != 1 {
+				return grpcutils.InvalidArg("CNAME record must have a single value")
+			}
+			if !govalidator.IsDNSName(strings.TrimSuffix(val, ".")) {
+				return grpcutils.InvalidArg("Invalid CNAME record value: %s", val)
+			}
+		case corev1.ClusterConfig_Spec_DNS_Zone_Record_TXT:
+			if len(val) > 255 {
+				return grpcutils.InvalidArg("TXT record value is too long")
+			}
+		default:
+			return grpcutils.InvalidArg("DNS record type must be set")
+		}
+	}
+
+	return nil
+}
+
 func (s *Server) validateCCAuthenticator(ctx context.Context, c *corev1.ClusterConfig) error {
 	if c.Spec.Authenticator == nil {
 		return nil
@@ -363,6 +854,53 @@ func (s *Server) validateCCAuthenticator(ctx context.Context, c *corev1.ClusterC
 		return err
 	}
 
+	if err := apivalidation.ValidateDuration(cfg.StepUpDuration); err != nil {
+		return err
+	}
+
+	if umetav1.ToDuration(cfg.StepUpDuration).ToGo() > maxStepUpDuration {
+		return grpcutils.InvalidArg("stepUpDuration is too long")
+	}
+
+	return nil
+}
+
+func (s *Server) validateCCAccessRequest(ctx context.Context, c *corev1.ClusterConfig) error {
+	if c.Spec.AccessRequest == nil {
+		return nil
+	}
+
+	cfg := c.Spec.AccessRequest
+
+	if len(cfg.ApproverGroups) > ccMaxApproverGroups {
+		return grpcutils.InvalidArg("Too many approverGroups")
+	}
+
+	for i, grp := range cfg.ApproverGroups {
+		if err := apivalidation.ValidateName(grp, 0, 0); err != nil {
+			return err
+		}
+
+		if slices.Contains(cfg.ApproverGroups[:i], grp) {
+			return grpcutils.InvalidArg("Duplicate approver Group: %s", grp)
+		}
+
+		if _, err := s.octeliumC.CoreC().GetGroup(ctx, &rmetav1.GetOptions{Name: grp}); err != nil {
+			if grpcerr.IsNotFound(err) {
+				return grpcutils.InvalidArg("The approver Group %s does not exist", grp)
+			}
+			return grpcutils.InternalWithErr(err)
+		}
+	}
+
+	if err := apivalidation.ValidateDuration(cfg.MaxDuration); err != nil {
+		return err
+	}
+
+	if umetav1.ToDuration(cfg.MaxDuration).ToGo() > maxAccessRequestDuration {
+		return grpcutils.InvalidArg("AccessRequest maxDuration is too long")
+	}
+
 	return nil
 }
 
@@ -340,6 +340,61 @@ func TestValidateClusterConfig(t *testing.T) {
 			assert.NotNil(t, srv.validateClusterConfig(ctx, cc))
 		}
 
+		{
+			cc := getTestClusterConfig(ctx, t, srv)
+			cc.Spec.Session = &corev1.ClusterConfig_Spec_Session{
+				Risk: &corev1.ClusterConfig_Spec_Session_Risk{
+					IsEnabled:           true,
+					MaxTravelSpeed:      900,
+					ReauthenticateScore: 40,
+					TerminateScore:      90,
+					DeniedBurst: &corev1.ClusterConfig_Spec_Session_Risk_DeniedBurst{
+						Limit: 30,
+						Window: &metav1.Duration{
+							Type: &metav1.Duration_Minutes{Minutes: 1},
+						},
+					},
+					SignalDuration: &metav1.Duration{
+						Type: &metav1.Duration_Hours{Hours: 12},
+					},
+				},
+			}
+			assert.Nil(t, srv.validateClusterConfig(ctx, cc))
+		}
+
+		{
+			cc := getTestClusterConfig(ctx, t, srv)
+			cc.Spec.Session = &corev1.ClusterConfig_Spec_Session{
+				Risk: &corev1.ClusterConfig_Spec_Session_Risk{
+					TerminateScore: ccMaxRiskScore + 1,
+				},
+			}
+			assert.NotNil(t, srv.validateClusterConfig(ctx, cc))
+		}
+
+		{
+			cc := getTestClusterConfig(ctx, t, srv)
+			cc.Spec.Session = &corev1.ClusterConfig_Spec_Session{
+				Risk: &corev1.ClusterConfig_Spec_Session_Risk{
+					ReauthenticateScore: 60,
+					TerminateScore:      50,
+				},
+			}
+			assert.NotNil(t, srv.validateClusterConfig(ctx, cc))
+		}
+
+		{
+			cc := getTestClusterConfig(ctx, t, srv)
+			cc.Spec.Session = &corev1.ClusterConfig_Spec_Session{
+				Risk: &corev1.ClusterConfig_Spec_Session_Risk{
+					DeniedBurst: &corev1.ClusterConfig_Spec_Session_Risk_DeniedBurst{
+						Limit: ccMaxDeniedBurstLimit + 1,
+					},
+				},
+			}
+			assert.NotNil(t, srv.validateClusterConfig(ctx, cc))
+		}
+
 	})
 
 	t.Run("device", func(t *testing.T) {
@@ -405,6 +460,131 @@ func TestValidateClusterConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("devicePosture", func(t *testing.T) {
+		getPosture := func() *corev1.ClusterConfig_Spec_Device_Posture {
+			return &corev1.ClusterConfig_Spec_Device_Posture{
+				Probes: []*corev1.ClusterConfig_Spec_Device_Posture_Probe{
+					{
+						Name:   "luks",
+						OsType: corev1.Device_Status_LINUX,
+						Type: &corev1.ClusterConfig_Spec_Device_Posture_Probe_RunCommand{
+							RunCommand: &corev1.ClusterConfig_Status_Device_Probe_RunCommand{
+								Command: "/usr/bin/lsblk",
+								Args:    []string{"-o", "TYPE"},
+							},
+						},
+					},
+					{
+						Name:   "bitlocker",
+						OsType: corev1.Device_Status_WINDOWS,
+						Type: &corev1.ClusterConfig_Spec_Device_Posture_Probe_ReadRegistry{
+							ReadRegistry: &corev1.ClusterConfig_Status_Device_Probe_ReadRegistry{
+								Key:  `HKLM\SYSTEM\CurrentControlSet\Control\BitLocker`,
+								Name: "Enabled",
+							},
+						},
+					},
+				},
+				Signals: []*corev1.ClusterConfig_Spec_Device_Posture_Signal{
+					{
+						Name:      "diskEncryption",
+						Condition: getTestCondition(),
+					},
+				},
+				Risks: []*corev1.ClusterConfig_Spec_Device_Posture_Risk{
+					{
+						Level:     corev1.Device_Status_Posture_HIGH,
+						Condition: getTestCondition(),
+					},
+				},
+				Interval: &metav1.Duration{
+					Type: &metav1.Duration_Minutes{Minutes: 10},
+				},
+				Connectors: []*corev1.ClusterConfig_Spec_Device_Posture_Connector{
+					{
+						Name: "mdm",
+						Url:  "https://mdm.example.com/v1/postures",
+						Auth: &corev1.ClusterConfig_Spec_Device_Posture_Connector_Auth{
+							Type: &corev1.ClusterConfig_Spec_Device_Posture_Connector_Auth_Bearer_{
+								Bearer: &corev1.ClusterConfig_Spec_Device_Posture_Connector_Auth_Bearer{
+									Type: &corev1.ClusterConfig_Spec_Device_Posture_Connector_Auth_Bearer_FromSecret{
+										FromSecret: secret.Metadata.Name,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		{
+			cc := getTestClusterConfig(ctx, t, srv)
+			cc.Spec.Device = &corev1.ClusterConfig_Spec_Device{
+				Posture: getPosture(),
+			}
+			assert.Nil(t, srv.validateClusterConfig(ctx, cc))
+		}
+
+		invalids := []func(p *corev1.ClusterConfig_Spec_Device_Posture){
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Probes[0].Name = "invalid-name"
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Probes[1].Name = p.Probes[0].Name
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Probes[0].OsType = corev1.Device_Status_OS_TYPE_UNKNOWN
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Probes[0].GetRunCommand().Command = "lsblk"
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Probes[1].OsType = corev1.Device_Status_LINUX
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Probes[0].Type = nil
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Signals[0].Condition = nil
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Signals = append(p.Signals, p.Signals[0])
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Risks[0].Level = corev1.Device_Status_Posture_RISK_LEVEL_UNKNOWN
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Risks[0].Condition = nil
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Connectors[0].Url = "http://mdm.example.com/v1/postures"
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Connectors = append(p.Connectors, p.Connectors[0])
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Connectors[0].GetAuth().GetBearer().Type =
+					&corev1.ClusterConfig_Spec_Device_Posture_Connector_Auth_Bearer_FromSecret{
+						FromSecret: utilrand.GetRandomStringCanonical(8),
+					}
+			},
+			func(p *corev1.ClusterConfig_Spec_Device_Posture) {
+				p.Connectors[0].Auth = &corev1.ClusterConfig_Spec_Device_Posture_Connector_Auth{}
+			},
+		}
+
+		for _, fn := range invalids {
+			cc := getTestClusterConfig(ctx, t, srv)
+			posture := getPosture()
+			fn(posture)
+			cc.Spec.Device = &corev1.ClusterConfig_Spec_Device{
+				Posture: posture,
+			}
+			assert.NotNil(t, srv.validateClusterConfig(ctx, cc))
+		}
+	})
+
 	t.Run("ingress", func(t *testing.T) {
 		{
 			cc := getTestClusterConfig(ctx, t, srv)
@@ -526,6 +706,125 @@ func TestValidateClusterConfig(t *testing.T) {
 			}
 			assert.NotNil(t, srv.validateClusterConfig(ctx, cc))
 		}
+
+		getZone := func() *corev1.ClusterConfig_Spec_DNS_Zone {
+			return &corev1.ClusterConfig_Spec_DNS_Zone{
+				Name:    "corp.internal",
+				Servers: []string{"10.0.0.2"},
+				Groups:  []string{"dev"},
+				Records: []*corev1.ClusterConfig_Spec_DNS_Zone_Record{
+					{
+						Name:   "db",
+						Type:   corev1.ClusterConfig_Spec_DNS_Zone_Record_A,
+						Values: []string{"10.0.0.10"},
+					},
+					{
+						Name:   "*.apps",
+						Type:   corev1.ClusterConfig_Spec_DNS_Zone_Record_AAAA,
+						Values: []string{"fd00::10"},
+					},
+					{
+						Name:   "www",
+						Type:   corev1.ClusterConfig_Spec_DNS_Zone_Record_CNAME,
+						Values: []string{"db.corp.internal"},
+					},
+					{
+						Type:   corev1.ClusterConfig_Spec_DNS_Zone_Record_TXT,
+						Values: []string{"v=spf1 -all"},
+					},
+				},
+			}
+		}
+
+		{
+			cc := getTestClusterConfig(ctx, t, srv)
+			cc.Spec.Dns = &corev1.ClusterConfig_Spec_DNS{
+				Zones: []*corev1.ClusterConfig_Spec_DNS_Zone{getZone()},
+			}
+			assert.Nil(t, srv.validateClusterConfig(ctx, cc))
+		}
+
+		invalidZones := []func(z *corev1.ClusterConfig_Spec_DNS_Zone){
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) { z.Name = "" },
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) { z.Name = "invalid zone" },
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) { z.Servers, z.Records = nil, nil },
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) { z.Servers = []string{"invalid dns server"} },
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) { z.Groups = []string{"Invalid Group"} },
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) {
+				z.Records[0].Type = corev1.ClusterConfig_Spec_DNS_Zone_Record_TYPE_UNKNOWN
+			},
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) { z.Records[0].Values = []string{"fd00::10"} },
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) { z.Records[0].Values = nil },
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) { z.Records[0].Name = "invalid name" },
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) { z.Records[1].Values = []string{"10.0.0.10"} },
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) {
+				z.Records[2].Values = []string{"a.internal", "b.internal"}
+			},
+			func(z *corev1.ClusterConfig_Spec_DNS_Zone) { z.Records[3].Values = []string{strings.Repeat("a", 256)} },
+		}
+
+		for _, fn := range invalidZones {
+			cc := getTestClusterConfig(ctx, t, srv)
+			zone := getZone()
+			fn(zone)
+			cc.Spec.Dns = &corev1.ClusterConfig_Spec_DNS{
+				Zones: []*corev1.ClusterConfig_Spec_DNS_Zone{zone},
+			}
+			assert.NotNil(t, srv.validateClusterConfig(ctx, cc))
+		}
+
+		getFilter := func() *corev1.ClusterConfig_Spec_DNS_Filter {
+			return &corev1.ClusterConfig_Spec_DNS_Filter{
+				Rules: []*corev1.ClusterConfig_Spec_DNS_Filter_Rule{
+					{
+						Name:      "allow-ops",
+						Condition: getTestCondition(),
+						Effect:    corev1.ClusterConfig_Spec_DNS_Filter_Rule_ALLOW,
+						Domains:   []string{"example.com", "*.example.org"},
+					},
+					{
+						Name:   "block",
+						Effect: corev1.ClusterConfig_Spec_DNS_Filter_Rule_BLOCK,
+						Lists:  []string{"blocklist"},
+					},
+				},
+			}
+		}
+
+		{
+			cc := getTestClusterConfig(ctx, t, srv)
+			cc.Spec.Dns = &corev1.ClusterConfig_Spec_DNS{
+				Filter: getFilter(),
+			}
+			assert.Nil(t, srv.validateClusterConfig(ctx, cc))
+		}
+
+		invalidFilters := []func(f *corev1.ClusterConfig_Spec_DNS_Filter){
+			func(f *corev1.ClusterConfig_Spec_DNS_Filter) {
+				f.Rules[0].Name = strings.Repeat("a", ccMaxRuleNameLen+1)
+			},
+			func(f *corev1.ClusterConfig_Spec_DNS_Filter) {
+				f.Rules[0].Effect = corev1.ClusterConfig_Spec_DNS_Filter_Rule_EFFECT_UNKNOWN
+			},
+			func(f *corev1.ClusterConfig_Spec_DNS_Filter) { f.Rules[0].Domains = nil },
+			func(f *corev1.ClusterConfig_Spec_DNS_Filter) { f.Rules[0].Domains = []string{"invalid domain"} },
+			func(f *corev1.ClusterConfig_Spec_DNS_Filter) { f.Rules[1].Lists = []string{"Invalid List"} },
+			func(f *corev1.ClusterConfig_Spec_DNS_Filter) {
+				f.Rules[0].Condition = &corev1.Condition{
+					Type: &corev1.Condition_Match{Match: "invalid ++ expression"},
+				}
+			},
+		}
+
+		for _, fn := range invalidFilters {
+			cc := getTestClusterConfig(ctx, t, srv)
+			filter := getFilter()
+			fn(filter)
+			cc.Spec.Dns = &corev1.ClusterConfig_Spec_DNS{
+				Filter: filter,
+			}
+			assert.NotNil(t, srv.validateClusterConfig(ctx, cc))
+		}
 	})
 
 	t.Run("authorization", func(t *testing.T) {
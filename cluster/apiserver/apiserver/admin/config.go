@@ -137,6 +137,10 @@ func (s *Server) UpdateConfig(ctx context.Context, req *corev1.Config) (*corev1.
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(itm, req); err != nil {
+		return nil, err
+	}
+
 	if err := apivalidation.CheckIsSystem(itm); err != nil {
 		return nil, err
 	}
@@ -414,6 +414,10 @@ func (s *Server) UpdateCredential(ctx context.Context, req *corev1.Credential) (
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(item, req); err != nil {
+		return nil, err
+	}
+
 	if err := apivalidation.CheckIsSystem(item); err != nil {
 		return nil, err
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/octelium/octelium/apis/rsc/rmetav1"
 	"github.com/octelium/octelium/cluster/apiserver/apiserver/serr"
 	"github.com/octelium/octelium/cluster/common/apivalidation"
+	"github.com/octelium/octelium/cluster/common/deviceposture"
 	"github.com/octelium/octelium/cluster/common/grpcutils"
 	"github.com/octelium/octelium/cluster/common/urscsrv"
 	"github.com/octelium/octelium/cluster/common/userctx"
@@ -94,6 +95,10 @@ func (s *Server) UpdateDevice(ctx context.Context, req *corev1.Device) (*corev1.
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(item, req); err != nil {
+		return nil, err
+	}
+
 	if !ldflags.IsTest() {
 		i, err := userctx.GetUserCtx(ctx)
 		if err != nil {
@@ -118,6 +123,10 @@ func (s *Server) UpdateDevice(ctx context.Context, req *corev1.Device) (*corev1.
 	return item, nil
 }
 
+func (s *Server) SetDevicePosture(ctx context.Context, req *corev1.SetDevicePostureRequest) (*corev1.Device, error) {
+	return deviceposture.SetPosture(ctx, s.octeliumC, req)
+}
+
 func (s *Server) validateDevice(ctx context.Context, itm *corev1.Device) error {
 	if err := apivalidation.ValidateCommon(itm, &apivalidation.ValidateCommonOpts{
 		ValidateMetadataOpts: apivalidation.ValidateMetadataOpts{
@@ -70,6 +70,10 @@ func (s *Server) UpdateGroup(ctx context.Context, req *corev1.Group) (*corev1.Gr
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(item, req); err != nil {
+		return nil, err
+	}
+
 	if err := apivalidation.CheckIsSystem(item); err != nil {
 		return nil, err
 	}
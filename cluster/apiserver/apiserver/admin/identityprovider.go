@@ -18,13 +18,16 @@ package admin
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"encoding/xml"
 	"io"
 	"net/url"
 	"strings"
+	"time"
 	"unicode/utf8"
 
+	"github.com/asaskevich/govalidator"
 	"github.com/go-jose/go-jose/v4"
 	"github.com/octelium/octelium/apis/main/corev1"
 	"github.com/octelium/octelium/apis/main/metav1"
@@ -34,6 +37,7 @@ import (
 	"github.com/octelium/octelium/cluster/common/apivalidation"
 	"github.com/octelium/octelium/cluster/common/grpcutils"
 	"github.com/octelium/octelium/cluster/common/urscsrv"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
 	"github.com/octelium/octelium/pkg/grpcerr"
 	"github.com/octelium/octelium/pkg/utils/ldflags"
 )
@@ -49,6 +53,14 @@ const (
 	idpMaxJWKSContentLen  = 256 * 1024
 	idpMaxSAMLMetadataLen = 20000
 	idpMaxRules           = 128
+	idpMaxLDAPDNLen       = 1024
+	idpMaxLDAPFilterLen   = 1024
+	idpMaxLDAPAttrs       = 32
+	idpMaxLDAPCACertLen   = 64 * 1024
+	idpMaxEmailSubjectLen = 256
+	idpMaxEmailRequests   = 100
+	idpMinEmailCodeDur    = 1 * time.Minute
+	idpMaxEmailCodeDur    = 8 * time.Minute
 )
 
 func (s *Server) CreateIdentityProvider(ctx context.Context, req *corev1.IdentityProvider) (*corev1.IdentityProvider, error) {
@@ -155,6 +167,10 @@ func (s *Server) UpdateIdentityProvider(ctx context.Context, req *corev1.Identit
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(item, req); err != nil {
+		return nil, err
+	}
+
 	if err := apivalidation.CheckIsSystem(item); err != nil {
 		return nil, err
 	}
@@ -367,6 +383,20 @@ func (s *Server) validateIdentityProvider(ctx context.Context, req *corev1.Ident
 
 		req.Status.Type = corev1.IdentityProvider_Status_SAML
 
+	case *corev1.IdentityProvider_Spec_Ldap:
+		if err := s.validateIdentityProviderLDAP(ctx, spec.GetLdap()); err != nil {
+			return err
+		}
+
+		req.Status.Type = corev1.IdentityProvider_Status_LDAP
+
+	case *corev1.IdentityProvider_Spec_Email_:
+		if err := s.validateIdentityProviderEmail(ctx, spec.GetEmail()); err != nil {
+			return err
+		}
+
+		req.Status.Type = corev1.IdentityProvider_Status_EMAIL
+
 	default:
 		return grpcutils.InvalidArg("Must specify a type for the IdentityProvider")
 	}
@@ -599,6 +629,268 @@ func canonicalHTTPSURL(arg string, field string, allowQuery bool) (string, error
 	return u.String(), nil
 }
 
+func (s *Server) validateIdentityProviderLDAP(ctx context.Context, typ *corev1.IdentityProvider_Spec_LDAP) error {
+	ldapURL, err := canonicalLDAPURL(typ.Url, typ.IsStartTLS)
+	if err != nil {
+		return err
+	}
+	typ.Url = ldapURL
+
+	if typ.Tls != nil {
+		if typ.Tls.CaCertificate != "" {
+			if len(typ.Tls.CaCertificate) > idpMaxLDAPCACertLen {
+				return grpcutils.InvalidArg("caCertificate is too large")
+			}
+			if !x509.NewCertPool().AppendCertsFromPEM([]byte(typ.Tls.CaCertificate)) {
+				return grpcutils.InvalidArg("caCertificate must be a valid PEM-encoded certificate")
+			}
+		}
+
+		if err := validateIdentityProviderStr(typ.Tls.ServerName,
+			"serverName", idpMaxURLLen, false); err != nil {
+			return err
+		}
+	}
+
+	if err := validateLDAPDN(typ.BindDN, "bindDN", true); err != nil {
+		return err
+	}
+
+	if err := s.validateSecretOwner(ctx, typ.BindPassword); err != nil {
+		return err
+	}
+
+	if typ.User == nil {
+		return grpcutils.InvalidArg("user must be set")
+	}
+
+	if err := validateLDAPDN(typ.User.BaseDN, "user.baseDN", true); err != nil {
+		return err
+	}
+
+	if err := validateLDAPFilter(typ.User.Filter, "user.filter"); err != nil {
+		return err
+	}
+
+	if err := validateIdentityProviderStr(typ.User.UsernameAttribute,
+		"user.usernameAttribute", idpMaxClaimLen, false); err != nil {
+		return err
+	}
+
+	if err := validateIdentityProviderStr(typ.User.EmailAttribute,
+		"user.emailAttribute", idpMaxClaimLen, false); err != nil {
+		return err
+	}
+
+	if len(typ.User.Attributes) > idpMaxLDAPAttrs {
+		return grpcutils.InvalidArg("Too many user attributes")
+	}
+
+	for _, attr := range typ.User.Attributes {
+		if err := validateIdentityProviderStr(attr, "user.attributes", idpMaxClaimLen, true); err != nil {
+			return err
+		}
+	}
+
+	if typ.Group != nil {
+		if err := validateLDAPDN(typ.Group.BaseDN, "group.baseDN", true); err != nil {
+			return err
+		}
+
+		if err := validateLDAPFilter(typ.Group.Filter, "group.filter"); err != nil {
+			return err
+		}
+
+		if err := validateIdentityProviderStr(typ.Group.NameAttribute,
+			"group.nameAttribute", idpMaxClaimLen, false); err != nil {
+			return err
+		}
+
+		if err := validateIdentityProviderStr(typ.Group.MemberAttribute,
+			"group.memberAttribute", idpMaxClaimLen, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func canonicalLDAPURL(arg string, isStartTLS bool) (string, error) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return "", grpcutils.InvalidArg("url must be set")
+	}
+	if len(arg) > idpMaxURLLen {
+		return "", grpcutils.InvalidArg("url is too long")
+	}
+
+	u, err := url.Parse(arg)
+	if err != nil {
+		return "", grpcutils.InvalidArg("url is invalid")
+	}
+
+	if u.User != nil {
+		return "", grpcutils.InvalidArg("url must not contain userinfo")
+	}
+
+	if u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", grpcutils.InvalidArg("url must not contain a path, query or fragment")
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+
+	switch u.Scheme {
+	case "ldaps":
+		if isStartTLS {
+			return "", grpcutils.InvalidArg("isStartTLS cannot be used with the ldaps scheme")
+		}
+	case "ldap":
+		if !isStartTLS {
+			return "", grpcutils.InvalidArg("The ldap scheme requires isStartTLS to be enabled")
+		}
+	default:
+		return "", grpcutils.InvalidArg("url scheme must be either ldaps or ldap")
+	}
+
+	if u.Hostname() == "" {
+		return "", grpcutils.InvalidArg("url host must be set")
+	}
+
+	return u.String(), nil
+}
+
+func validateLDAPDN(arg string, field string, required bool) error {
+	if strings.TrimSpace(arg) == "" {
+		if required {
+			return grpcutils.InvalidArg("%s must be set", field)
+		}
+		return nil
+	}
+
+	if len(arg) > idpMaxLDAPDNLen {
+		return grpcutils.InvalidArg("%s is too long", field)
+	}
+
+	if !utf8.ValidString(arg) {
+		return grpcutils.InvalidArg("%s must be valid UTF-8", field)
+	}
+
+	if !strings.Contains(arg, "=") {
+		return grpcutils.InvalidArg("%s is not a valid DN", field)
+	}
+
+	for _, r := range arg {
+		if r < 0x20 || r == 0x7f {
+			return grpcutils.InvalidArg("%s must not contain control characters", field)
+		}
+	}
+
+	return nil
+}
+
+func validateLDAPFilter(arg string, field string) error {
+	if arg == "" {
+		return nil
+	}
+
+	if len(arg) > idpMaxLDAPFilterLen {
+		return grpcutils.InvalidArg("%s is too long", field)
+	}
+
+	if !utf8.ValidString(arg) {
+		return grpcutils.InvalidArg("%s must be valid UTF-8", field)
+	}
+
+	if !strings.HasPrefix(arg, "(") || !strings.HasSuffix(arg, ")") {
+		return grpcutils.InvalidArg("%s must be enclosed in parentheses", field)
+	}
+
+	depth := 0
+	for _, r := range arg {
+		switch {
+		case r < 0x20 || r == 0x7f:
+			return grpcutils.InvalidArg("%s must not contain control characters", field)
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return grpcutils.InvalidArg("%s has unbalanced parentheses", field)
+			}
+		}
+	}
+
+	if depth != 0 {
+		return grpcutils.InvalidArg("%s has unbalanced parentheses", field)
+	}
+
+	return nil
+}
+
+func (s *Server) validateIdentityProviderEmail(ctx context.Context, typ *corev1.IdentityProvider_Spec_Email) error {
+	if typ.Smtp == nil {
+		return grpcutils.InvalidArg("smtp must be set")
+	}
+
+	if err := validateIdentityProviderStr(typ.Smtp.Host, "smtp.host", idpMaxURLLen, true); err != nil {
+		return err
+	}
+	typ.Smtp.Host = strings.ToLower(strings.TrimSpace(typ.Smtp.Host))
+
+	if !govalidator.IsHost(typ.Smtp.Host) {
+		return grpcutils.InvalidArg("smtp.host is not a valid host")
+	}
+
+	if typ.Smtp.Port > 65535 {
+		return grpcutils.InvalidArg("smtp.port is invalid")
+	}
+
+	if err := validateIdentityProviderStr(typ.Smtp.Username,
+		"smtp.username", idpMaxClaimLen, false); err != nil {
+		return err
+	}
+
+	if typ.Smtp.Username != "" {
+		if err := s.validateSecretOwner(ctx, typ.Smtp.Password); err != nil {
+			return err
+		}
+	} else if typ.Smtp.Password != nil {
+		return grpcutils.InvalidArg("smtp.password requires smtp.username to be set")
+	}
+
+	if typ.Smtp.From == "" || len(typ.Smtp.From) > idpMaxClaimLen || !govalidator.IsEmail(typ.Smtp.From) {
+		return grpcutils.InvalidArg("smtp.from must be a valid email address")
+	}
+
+	if typ.CodeDuration != nil {
+		dur := umetav1.ToDuration(typ.CodeDuration).ToGo()
+		if dur < idpMinEmailCodeDur || dur > idpMaxEmailCodeDur {
+			return grpcutils.InvalidArg("codeDuration must be between 1 and 8 minutes")
+		}
+	}
+
+	if typ.MaxRequestsPerHour > idpMaxEmailRequests {
+		return grpcutils.InvalidArg("maxRequestsPerHour cannot exceed %d", idpMaxEmailRequests)
+	}
+
+	if len(typ.Subject) > idpMaxEmailSubjectLen {
+		return grpcutils.InvalidArg("subject is too long")
+	}
+
+	if !utf8.ValidString(typ.Subject) {
+		return grpcutils.InvalidArg("subject must be valid UTF-8")
+	}
+
+	for _, r := range typ.Subject {
+		if r < 0x20 || r == 0x7f {
+			return grpcutils.InvalidArg("subject must not contain control characters")
+		}
+	}
+
+	return nil
+}
+
 func validateIdentityProviderDisplayName(arg string) error {
 	if arg == "" {
 		return nil
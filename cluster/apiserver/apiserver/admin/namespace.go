@@ -42,6 +42,10 @@ func (s *Server) UpdateNamespace(ctx context.Context, req *corev1.Namespace) (*c
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(item, req); err != nil {
+		return nil, err
+	}
+
 	if err := apivalidation.CheckIsSystem(item); err != nil {
 		return nil, err
 	}
@@ -19,6 +19,7 @@ package admin
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/octelium/octelium/apis/main/corev1"
 	"github.com/octelium/octelium/apis/main/metav1"
@@ -43,6 +44,7 @@ const (
 	maxConditionDepth    = 16
 	maxCELExpressionLen  = 10000
 	maxOPAScriptLen      = 100000
+	maxStepUpDuration    = 24 * time.Hour
 )
 
 func (s *Server) CreatePolicy(ctx context.Context, req *corev1.Policy) (*corev1.Policy, error) {
@@ -104,6 +106,10 @@ func (s *Server) UpdatePolicy(ctx context.Context, req *corev1.Policy) (*corev1.
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(item, req); err != nil {
+		return nil, err
+	}
+
 	if err := apivalidation.CheckIsSystem(item); err != nil {
 		return nil, err
 	}
@@ -207,6 +213,14 @@ func (s *Server) validatePolicy(ctx context.Context, p *corev1.Policy) error {
 }
 
 func (s *Server) validatePolicySpec(ctx context.Context, p *corev1.Policy_Spec) error {
+	if err := s.validatePolicySpecFields(ctx, p); err != nil {
+		return err
+	}
+
+	return checkPolicySpecConditions(ctx, p)
+}
+
+func (s *Server) validatePolicySpecFields(ctx context.Context, p *corev1.Policy_Spec) error {
 	if p == nil {
 		return grpcutils.InvalidArg("Nil spec")
 	}
@@ -261,6 +275,10 @@ func (s *Server) validatePolicySpec(ctx context.Context, p *corev1.Policy_Spec)
 		if err := s.validateCondition(ctx, rule.Condition); err != nil {
 			return err
 		}
+
+		if err := validatePolicyRuleStepUp(rule); err != nil {
+			return err
+		}
 	}
 
 	for _, rule := range p.EnforcementRules {
@@ -282,6 +300,49 @@ func (s *Server) validatePolicySpec(ctx context.Context, p *corev1.Policy_Spec)
 	return nil
 }
 
+func validatePolicyRuleStepUp(rule *corev1.Policy_Spec_Rule) error {
+	if rule.StepUp == nil {
+		return nil
+	}
+
+	if rule.Effect != corev1.Policy_Spec_Rule_ALLOW {
+		return grpcutils.InvalidArg("stepUp can only be set for ALLOW rules")
+	}
+
+	if err := apivalidation.ValidateDuration(rule.StepUp.MaxAge); err != nil {
+		return err
+	}
+
+	if umetav1.ToDuration(rule.StepUp.MaxAge).ToGo() > maxStepUpDuration {
+		return grpcutils.InvalidArg("stepUp maxAge is too long")
+	}
+
+	if len(rule.StepUp.AuthenticatorTypes) > len(corev1.Authenticator_Status_Type_name) {
+		return grpcutils.InvalidArg("Too many stepUp authenticatorTypes")
+	}
+
+	for _, typ := range rule.StepUp.AuthenticatorTypes {
+		switch typ {
+		case corev1.Authenticator_Status_FIDO,
+			corev1.Authenticator_Status_TOTP,
+			corev1.Authenticator_Status_TPM:
+		default:
+			return grpcutils.InvalidArg("Invalid stepUp authenticatorType: %s", typ.String())
+		}
+	}
+
+	switch rule.StepUp.MinAAL {
+	case corev1.Session_Status_Authentication_Info_AAL_UNSET,
+		corev1.Session_Status_Authentication_Info_AAL1,
+		corev1.Session_Status_Authentication_Info_AAL2,
+		corev1.Session_Status_Authentication_Info_AAL3:
+	default:
+		return grpcutils.InvalidArg("Invalid stepUp minAAL")
+	}
+
+	return nil
+}
+
 func (s *Server) validateCondition(ctx context.Context, c *corev1.Condition) error {
 	return s.validateConditionAtDepth(ctx, c, 0)
 }
@@ -316,6 +316,58 @@ func TestValidatePolicy(t *testing.T) {
 	}
 }
 
+func TestValidatePolicyRuleStepUp(t *testing.T) {
+	getRule := func() *corev1.Policy_Spec_Rule {
+		return &corev1.Policy_Spec_Rule{
+			Effect:    corev1.Policy_Spec_Rule_ALLOW,
+			Condition: genMatchAnyCondition(),
+			StepUp: &corev1.Policy_Spec_Rule_StepUp{
+				MaxAge: &metav1.Duration{
+					Type: &metav1.Duration_Minutes{Minutes: 5},
+				},
+				AuthenticatorTypes: []corev1.Authenticator_Status_Type{
+					corev1.Authenticator_Status_FIDO,
+				},
+				MinAAL: corev1.Session_Status_Authentication_Info_AAL3,
+			},
+		}
+	}
+
+	assert.Nil(t, validatePolicyRuleStepUp(getRule()))
+
+	{
+		rule := getRule()
+		rule.StepUp = nil
+		rule.Effect = corev1.Policy_Spec_Rule_DENY
+		assert.Nil(t, validatePolicyRuleStepUp(rule))
+	}
+
+	invalids := []func(rule *corev1.Policy_Spec_Rule){
+		func(rule *corev1.Policy_Spec_Rule) {
+			rule.Effect = corev1.Policy_Spec_Rule_DENY
+		},
+		func(rule *corev1.Policy_Spec_Rule) {
+			rule.StepUp.MaxAge = &metav1.Duration{
+				Type: &metav1.Duration_Days{Days: 2},
+			}
+		},
+		func(rule *corev1.Policy_Spec_Rule) {
+			rule.StepUp.AuthenticatorTypes = []corev1.Authenticator_Status_Type{
+				corev1.Authenticator_Status_TYPE_UNKNOWN,
+			}
+		},
+		func(rule *corev1.Policy_Spec_Rule) {
+			rule.StepUp.MinAAL = corev1.Session_Status_Authentication_Info_AAL(100)
+		},
+	}
+
+	for _, fn := range invalids {
+		rule := getRule()
+		fn(rule)
+		assert.NotNil(t, validatePolicyRuleStepUp(rule))
+	}
+}
+
 func TestPolicyConditionDepth(t *testing.T) {
 	ctx := context.Background()
 	tst, err := tests.Initialize(nil)
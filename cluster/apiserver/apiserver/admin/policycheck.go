@@ -0,0 +1,113 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) CheckPolicy(ctx context.Context, req *corev1.CheckPolicyRequest) (*corev1.CheckPolicyResponse, error) {
+	if req.Spec == nil {
+		return nil, grpcutils.InvalidArg("Nil spec")
+	}
+
+	ret := &corev1.CheckPolicyResponse{}
+
+	if err := s.validatePolicySpecFields(ctx, req.Spec); err != nil {
+		if grpcerr.IsInternal(err) {
+			return nil, err
+		}
+		ret.Errors = append(ret.Errors, &corev1.CheckPolicyResponse_Error{
+			Message: status.Convert(err).Message(),
+		})
+		return ret, nil
+	}
+
+	checkErrs, err := getPolicySpecCheckErrors(ctx, req.Spec)
+	if err != nil {
+		return nil, grpcutils.InternalWithErr(err)
+	}
+
+	for _, checkErr := range checkErrs {
+		ret.Errors = append(ret.Errors, &corev1.CheckPolicyResponse_Error{
+			Path:    checkErr.Path,
+			Line:    int32(checkErr.Line),
+			Column:  int32(checkErr.Column),
+			Message: checkErr.Message,
+		})
+	}
+
+	return ret, nil
+}
+
+// checkPolicySpecConditions type-checks the Conditions of the rules and the
+// enforcement rules against the request context they are evaluated against.
+// The other Conditions, such as those of the IdentityProviders, have a
+// different input and are only checked by validateCondition.
+func checkPolicySpecConditions(ctx context.Context, p *corev1.Policy_Spec) error {
+	checkErrs, err := getPolicySpecCheckErrors(ctx, p)
+	if err != nil {
+		return grpcutils.InternalWithErr(err)
+	}
+
+	if len(checkErrs) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(checkErrs))
+	for _, checkErr := range checkErrs {
+		msgs = append(msgs, checkErr.Error())
+	}
+
+	return grpcutils.InvalidArg("Invalid Condition: %s", strings.Join(msgs, "; "))
+}
+
+// getPolicyChecker returns the Checker shared by all the validations since
+// building its CEL environment is expensive
+var getPolicyChecker = sync.OnceValues(func() (*celengine.Checker, error) {
+	return celengine.NewChecker(context.Background())
+})
+
+func getPolicySpecCheckErrors(ctx context.Context, p *corev1.Policy_Spec) ([]*celengine.CheckError, error) {
+	checker, err := getPolicyChecker()
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*celengine.CheckError
+
+	for i, rule := range p.Rules {
+		ret = append(ret, checker.CheckCondition(ctx,
+			rule.GetCondition(), fmt.Sprintf("rules[%d].condition", i))...)
+	}
+
+	for i, rule := range p.EnforcementRules {
+		ret = append(ret, checker.CheckCondition(ctx,
+			rule.GetCondition(), fmt.Sprintf("enforcementRules[%d].condition", i))...)
+	}
+
+	return ret, nil
+}
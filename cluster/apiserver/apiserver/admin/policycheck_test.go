@@ -0,0 +1,120 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(&Opts{})
+
+	getSpec := func(exp string) *corev1.Policy_Spec {
+		return &corev1.Policy_Spec{
+			Rules: []*corev1.Policy_Spec_Rule{
+				{
+					Effect: corev1.Policy_Spec_Rule_ALLOW,
+					Condition: &corev1.Condition{
+						Type: &corev1.Condition_MatchAny{
+							MatchAny: true,
+						},
+					},
+				},
+				{
+					Effect: corev1.Policy_Spec_Rule_DENY,
+					Condition: &corev1.Condition{
+						Type: &corev1.Condition_Match{
+							Match: exp,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	{
+		spec := getSpec(`"ops" in ctx.user.spec.groups`)
+		assert.Nil(t, srv.validatePolicySpec(ctx, spec))
+
+		res, err := srv.CheckPolicy(ctx, &corev1.CheckPolicyRequest{
+			Spec: spec,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(res.Errors))
+	}
+
+	{
+		spec := getSpec(`"ops" in ctx.user.spec.grups`)
+		err := srv.validatePolicySpec(ctx, spec)
+		assert.True(t, grpcerr.IsInvalidArg(err))
+		assert.Contains(t, err.Error(), "rules[1].condition.match:1:23")
+
+		res, err := srv.CheckPolicy(ctx, &corev1.CheckPolicyRequest{
+			Spec: spec,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(res.Errors))
+		assert.Equal(t, "rules[1].condition.match", res.Errors[0].Path)
+		assert.Equal(t, int32(1), res.Errors[0].Line)
+		assert.Equal(t, int32(23), res.Errors[0].Column)
+	}
+
+	{
+		spec := getSpec(`"ops" in ctx.user.spec.groups`)
+		spec.EnforcementRules = []*corev1.Policy_Spec_EnforcementRule{
+			{
+				Effect: corev1.Policy_Spec_EnforcementRule_ENFORCE,
+				Condition: &corev1.Condition{
+					Type: &corev1.Condition_Not{
+						Not: `ctx.service.spec.mod == "HTTP"`,
+					},
+				},
+			},
+		}
+
+		res, err := srv.CheckPolicy(ctx, &corev1.CheckPolicyRequest{
+			Spec: spec,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(res.Errors))
+		assert.Equal(t, "enforcementRules[0].condition.not", res.Errors[0].Path)
+	}
+
+	{
+		spec := getSpec(`"ops" in ctx.user.spec.groups`)
+		spec.Rules[0].Effect = corev1.Policy_Spec_Rule_EFFECT_UNKNOWN
+
+		res, err := srv.CheckPolicy(ctx, &corev1.CheckPolicyRequest{
+			Spec: spec,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(res.Errors))
+		assert.Equal(t, "", res.Errors[0].Path)
+		assert.Equal(t, "Rule's effect must be set", res.Errors[0].Message)
+	}
+
+	{
+		_, err := srv.CheckPolicy(ctx, &corev1.CheckPolicyRequest{})
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+}
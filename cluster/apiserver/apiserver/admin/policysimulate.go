@@ -0,0 +1,207 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/octelium/octelium/apis/cluster/coctovigilv1"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/serr"
+	"github.com/octelium/octelium/cluster/common/apivalidation"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/vutils"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/octelium/octelium/pkg/grpcerr"
+)
+
+func (s *Server) SimulatePolicy(ctx context.Context, req *corev1.SimulatePolicyRequest) (*corev1.SimulatePolicyResponse, error) {
+	if s.octovigilC == nil {
+		return nil, serr.Internal("Policy simulation is not available")
+	}
+
+	if err := s.validateSimulatePolicyRequest(req); err != nil {
+		return nil, err
+	}
+
+	svc, err := s.octeliumC.CoreC().GetService(ctx, &rmetav1.GetOptions{
+		Uid:  req.ServiceRef.Uid,
+		Name: vutils.GetServiceFullNameFromName(req.ServiceRef.Name),
+	})
+	if err != nil {
+		return nil, serr.K8sNotFoundOrInternalWithErr(err)
+	}
+
+	if err := apivalidation.CheckIsSystemHidden(svc); err != nil {
+		return nil, err
+	}
+
+	var usr *corev1.User
+	var sess *corev1.Session
+	var dev *corev1.Device
+
+	if req.SessionRef != nil {
+		sess, err = s.octeliumC.CoreC().GetSession(ctx, apivalidation.ObjectReferenceToRGetOptions(req.SessionRef))
+		if err != nil {
+			return nil, serr.K8sNotFoundOrInternalWithErr(err)
+		}
+
+		usr, err = s.octeliumC.CoreC().GetUser(ctx, &rmetav1.GetOptions{Uid: sess.Status.UserRef.Uid})
+		if err != nil {
+			return nil, serr.K8sNotFoundOrInternalWithErr(err)
+		}
+		if req.UserRef != nil && !isObjectRefOf(req.UserRef, usr.Metadata) {
+			return nil, grpcutils.InvalidArg("The Session does not belong to the User")
+		}
+
+		if sess.Status.DeviceRef != nil {
+			dev, err = s.octeliumC.CoreC().GetDevice(ctx, &rmetav1.GetOptions{Uid: sess.Status.DeviceRef.Uid})
+			if err != nil {
+				return nil, serr.K8sNotFoundOrInternalWithErr(err)
+			}
+		}
+		if req.DeviceRef != nil && (dev == nil || !isObjectRefOf(req.DeviceRef, dev.Metadata)) {
+			return nil, grpcutils.InvalidArg("The Session does not belong to the Device")
+		}
+	} else {
+		usr, err = s.octeliumC.CoreC().GetUser(ctx, apivalidation.ObjectReferenceToRGetOptions(req.UserRef))
+		if err != nil {
+			return nil, serr.K8sNotFoundOrInternalWithErr(err)
+		}
+
+		if req.DeviceRef != nil {
+			dev, err = s.octeliumC.CoreC().GetDevice(ctx, apivalidation.ObjectReferenceToRGetOptions(req.DeviceRef))
+			if err != nil {
+				return nil, serr.K8sNotFoundOrInternalWithErr(err)
+			}
+			if dev.Status.UserRef == nil || dev.Status.UserRef.Uid != usr.Metadata.Uid {
+				return nil, grpcutils.InvalidArg("The Device does not belong to the User")
+			}
+		}
+
+		sess = getSimulatedSession(usr, dev, svc)
+	}
+
+	var groups []*corev1.Group
+	for _, g := range usr.Spec.Groups {
+		grp, err := s.octeliumC.CoreC().GetGroup(ctx, &rmetav1.GetOptions{Name: g})
+		if err != nil {
+			if grpcerr.IsNotFound(err) {
+				continue
+			}
+			return nil, serr.InternalWithErr(err)
+		}
+		groups = append(groups, grp)
+	}
+
+	request := req.Request
+	if request == nil {
+		request = &corev1.RequestContext_Request{}
+	}
+
+	ret, err := s.octovigilC.InternalC().SimulatePolicy(ctx, &coctovigilv1.SimulatePolicyRequest{
+		RequestContext: &corev1.RequestContext{
+			Request: request,
+			Session: sess,
+			User:    usr,
+			Groups:  groups,
+			Device:  dev,
+			Service: svc,
+		},
+	})
+	if err != nil {
+		return nil, serr.InternalWithErr(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) validateSimulatePolicyRequest(req *corev1.SimulatePolicyRequest) error {
+	if req.ServiceRef == nil {
+		return grpcutils.InvalidArg("You must provide a Service")
+	}
+	if err := apivalidation.CheckObjectRef(req.ServiceRef, &apivalidation.CheckGetOptionsOpts{
+		ParentsMax: 1,
+	}); err != nil {
+		return err
+	}
+
+	if req.UserRef == nil && req.SessionRef == nil {
+		return grpcutils.InvalidArg("You must provide either a User or a Session")
+	}
+
+	for _, ref := range []*metav1.ObjectReference{req.UserRef, req.SessionRef, req.DeviceRef} {
+		if ref == nil {
+			continue
+		}
+		if err := apivalidation.CheckObjectRef(ref, nil); err != nil {
+			return err
+		}
+	}
+
+	if req.Request != nil {
+		if protoBytes, err := pbutils.Marshal(req.Request); err != nil {
+			return grpcutils.InvalidArg("Could not marshal request")
+		} else if len(protoBytes) > maxPolicyBytes {
+			return grpcutils.InvalidArg("Request is too large")
+		}
+	}
+
+	return nil
+}
+
+func isObjectRefOf(ref *metav1.ObjectReference, md *metav1.Metadata) bool {
+	return (ref.Uid == "" || ref.Uid == md.Uid) &&
+		(ref.Name == "" || ref.Name == md.Name)
+}
+
+// getSimulatedSession returns an active Session of the User with a valid
+// access token as if it has just authenticated
+func getSimulatedSession(usr *corev1.User, dev *corev1.Device, svc *corev1.Service) *corev1.Session {
+	ret := &corev1.Session{
+		ApiVersion: ucorev1.APIVersion,
+		Kind:       ucorev1.KindSession,
+		Metadata: &metav1.Metadata{
+			Name: "simulated",
+		},
+		Spec: &corev1.Session_Spec{
+			State: corev1.Session_Spec_ACTIVE,
+		},
+		Status: &corev1.Session_Status{
+			UserRef: umetav1.GetObjectReference(usr),
+			Type:    corev1.Session_Status_CLIENT,
+			Authentication: &corev1.Session_Status_Authentication{
+				SetAt: pbutils.Timestamp(time.Now()),
+			},
+		},
+	}
+
+	if svc.Spec.IsPublic && dev == nil {
+		ret.Status.Type = corev1.Session_Status_CLIENTLESS
+	}
+
+	if dev != nil {
+		ret.Status.DeviceRef = umetav1.GetObjectReference(dev)
+	}
+
+	return ret
+}
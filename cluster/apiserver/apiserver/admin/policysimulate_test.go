@@ -0,0 +1,181 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/cluster/coctovigilv1"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/cluster/common/tests"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"github.com/octelium/octelium/pkg/utils/utilrand"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type fakeOctovigilC struct {
+	coctovigilv1.InternalServiceClient
+	lastReq *coctovigilv1.SimulatePolicyRequest
+}
+
+func (c *fakeOctovigilC) InternalC() coctovigilv1.InternalServiceClient {
+	return c
+}
+
+func (c *fakeOctovigilC) SimulatePolicy(ctx context.Context,
+	in *coctovigilv1.SimulatePolicyRequest, opts ...grpc.CallOption) (*corev1.SimulatePolicyResponse, error) {
+	c.lastReq = in
+	return &corev1.SimulatePolicyResponse{
+		IsAuthorized: true,
+	}, nil
+}
+
+func TestSimulatePolicy(t *testing.T) {
+	ctx := context.Background()
+
+	tst, err := tests.Initialize(nil)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		tst.Destroy()
+	})
+	srv := newFakeServer(tst.C)
+
+	{
+		_, err := srv.SimulatePolicy(ctx, &corev1.SimulatePolicyRequest{})
+		assert.NotNil(t, err)
+	}
+
+	octovigilC := &fakeOctovigilC{}
+	srv.octovigilC = octovigilC
+
+	usr, err := srv.CreateUser(ctx, tests.GenUser(nil))
+	assert.Nil(t, err)
+
+	usr2, err := srv.CreateUser(ctx, tests.GenUser(nil))
+	assert.Nil(t, err)
+
+	svc, err := srv.CreateService(ctx, tests.GenService(""))
+	assert.Nil(t, err)
+
+	svcRef := umetav1.GetObjectReference(svc)
+	usrRef := umetav1.GetObjectReference(usr)
+
+	{
+		_, err := srv.SimulatePolicy(ctx, &corev1.SimulatePolicyRequest{
+			UserRef: usrRef,
+		})
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+
+	{
+		_, err := srv.SimulatePolicy(ctx, &corev1.SimulatePolicyRequest{
+			ServiceRef: svcRef,
+		})
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+
+	{
+		_, err := srv.SimulatePolicy(ctx, &corev1.SimulatePolicyRequest{
+			ServiceRef: &metav1.ObjectReference{
+				Name: utilrand.GetRandomStringCanonical(8),
+			},
+			UserRef: usrRef,
+		})
+		assert.True(t, grpcerr.IsNotFound(err))
+	}
+
+	{
+		resp, err := srv.SimulatePolicy(ctx, &corev1.SimulatePolicyRequest{
+			ServiceRef: svcRef,
+			UserRef: &metav1.ObjectReference{
+				Name: usr.Metadata.Name,
+			},
+			Request: &corev1.RequestContext_Request{
+				Type: &corev1.RequestContext_Request_Http{
+					Http: &corev1.RequestContext_Request_HTTP{
+						Path:   "/admin",
+						Method: "GET",
+					},
+				},
+			},
+		})
+		assert.Nil(t, err, "%+v", err)
+		assert.True(t, resp.IsAuthorized)
+
+		reqCtx := octovigilC.lastReq.RequestContext
+		assert.Equal(t, usr.Metadata.Uid, reqCtx.User.Metadata.Uid)
+		assert.Equal(t, svc.Metadata.Uid, reqCtx.Service.Metadata.Uid)
+		assert.Equal(t, "/admin", reqCtx.Request.GetHttp().Path)
+		assert.Equal(t, corev1.Session_Spec_ACTIVE, reqCtx.Session.Spec.State)
+		assert.Equal(t, corev1.Session_Status_CLIENT, reqCtx.Session.Status.Type)
+		assert.Equal(t, usr.Metadata.Uid, reqCtx.Session.Status.UserRef.Uid)
+		assert.Nil(t, reqCtx.Device)
+	}
+
+	sess, err := srv.octeliumC.CoreC().CreateSession(ctx, &corev1.Session{
+		Metadata: &metav1.Metadata{
+			Name: utilrand.GetRandomStringCanonical(8),
+		},
+		Spec: &corev1.Session_Spec{
+			State:     corev1.Session_Spec_ACTIVE,
+			ExpiresAt: pbutils.Timestamp(time.Now().Add(time.Hour)),
+		},
+		Status: &corev1.Session_Status{
+			UserRef: usrRef,
+			Type:    corev1.Session_Status_CLIENT,
+		},
+	})
+	assert.Nil(t, err)
+
+	{
+		_, err := srv.SimulatePolicy(ctx, &corev1.SimulatePolicyRequest{
+			ServiceRef: svcRef,
+			SessionRef: umetav1.GetObjectReference(sess),
+		})
+		assert.Nil(t, err, "%+v", err)
+
+		reqCtx := octovigilC.lastReq.RequestContext
+		assert.Equal(t, sess.Metadata.Uid, reqCtx.Session.Metadata.Uid)
+		assert.Equal(t, usr.Metadata.Uid, reqCtx.User.Metadata.Uid)
+	}
+
+	{
+		_, err := srv.SimulatePolicy(ctx, &corev1.SimulatePolicyRequest{
+			ServiceRef: svcRef,
+			SessionRef: umetav1.GetObjectReference(sess),
+			UserRef:    umetav1.GetObjectReference(usr2),
+		})
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+
+	{
+		_, err := srv.SimulatePolicy(ctx, &corev1.SimulatePolicyRequest{
+			ServiceRef: svcRef,
+			SessionRef: umetav1.GetObjectReference(sess),
+			DeviceRef: &metav1.ObjectReference{
+				Name: utilrand.GetRandomStringCanonical(8),
+			},
+		})
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+}
@@ -0,0 +1,523 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/octelium/octelium/apis/cluster/coctovigilv1"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/serr"
+	"github.com/octelium/octelium/cluster/common/apivalidation"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/vutils"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxPolicyTestCases    = 1000
+	maxPolicyTestFixtures = 1000
+)
+
+func (s *Server) TestPolicy(ctx context.Context, req *corev1.TestPolicyRequest) (*corev1.TestPolicyResponse, error) {
+	if s.octovigilC == nil {
+		return nil, serr.Internal("Policy testing is not available")
+	}
+
+	if len(req.Cases) == 0 {
+		return nil, grpcutils.InvalidArg("No test cases")
+	}
+	if len(req.Cases) > maxPolicyTestCases {
+		return nil, grpcutils.InvalidArg("Too many test cases")
+	}
+
+	fixtures, err := s.getPolicyTestFixtures(ctx, req.Fixtures)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqCtxs []*corev1.RequestContext
+	for i, c := range req.Cases {
+		if c == nil {
+			return nil, grpcutils.InvalidArg("Nil test case")
+		}
+
+		reqCtx, err := fixtures.getRequestContext(c)
+		if err != nil {
+			return nil, grpcutils.InvalidArg("Test case %s: %s", getPolicyTestCaseName(c, i), err)
+		}
+		reqCtxs = append(reqCtxs, reqCtx)
+	}
+
+	res, err := s.octovigilC.InternalC().TestPolicy(ctx, &coctovigilv1.TestPolicyRequest{
+		Policies:        req.Fixtures.GetPolicies(),
+		Namespaces:      req.Fixtures.GetNamespaces(),
+		RequestContexts: reqCtxs,
+	})
+	if err != nil {
+		return nil, serr.InternalWithErr(err)
+	}
+	if len(res.Results) != len(req.Cases) {
+		return nil, serr.Internal("Unexpected number of test results")
+	}
+
+	ret := &corev1.TestPolicyResponse{
+		Rules: fixtures.getRules(),
+	}
+
+	coverage := make(map[string]*corev1.TestPolicyResponse_Rule)
+	for _, rule := range ret.Rules {
+		coverage[getPolicyTestRuleKey(rule.Source, rule.Index)] = rule
+	}
+
+	for i, c := range req.Cases {
+		sim := res.Results[i]
+		ret.Results = append(ret.Results, getPolicyTestResult(c, getPolicyTestCaseName(c, i), sim))
+
+		for _, rule := range sim.Rules {
+			itm, ok := coverage[getPolicyTestRuleKey(rule.Source, rule.Index)]
+			if !ok {
+				continue
+			}
+			if rule.Result == corev1.SimulatePolicyResponse_MATCHED {
+				itm.MatchCount++
+			}
+			if rule.IsDecisive {
+				itm.DecisiveCount++
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func getPolicyTestCaseName(c *corev1.TestPolicyRequest_Case, idx int) string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return fmt.Sprintf("#%d", idx)
+}
+
+func getPolicyTestResult(c *corev1.TestPolicyRequest_Case,
+	name string, sim *corev1.SimulatePolicyResponse) *corev1.TestPolicyResponse_Result {
+	ret := &corev1.TestPolicyResponse_Result{
+		Name:         name,
+		IsAuthorized: sim.IsAuthorized,
+		Reason:       sim.Reason,
+		Error:        sim.Error,
+	}
+
+	expect := c.Expect
+
+	switch expect.Decision {
+	case corev1.TestPolicyRequest_Case_Expect_ALLOW:
+		if !sim.IsAuthorized {
+			ret.Failures = append(ret.Failures, "Expected the request to be allowed but it was denied")
+		}
+	case corev1.TestPolicyRequest_Case_Expect_DENY:
+		if sim.IsAuthorized {
+			ret.Failures = append(ret.Failures, "Expected the request to be denied but it was allowed")
+		}
+	}
+
+	if expect.Reason != corev1.AccessLog_Entry_Common_Reason_TYPE_UNKNOWN_REASON &&
+		expect.Reason != sim.Reason.GetType() {
+		ret.Failures = append(ret.Failures, fmt.Sprintf("Expected the reason %s but got %s",
+			expect.Reason.String(), sim.Reason.GetType().String()))
+	}
+
+	if expect.RuleName != "" {
+		var decisive *corev1.SimulatePolicyResponse_Rule
+		for _, rule := range sim.Rules {
+			if rule.IsDecisive {
+				decisive = rule
+				break
+			}
+		}
+
+		switch {
+		case decisive == nil:
+			ret.Failures = append(ret.Failures, fmt.Sprintf(
+				"Expected the request to be decided by the rule %s but no rule decided it", expect.RuleName))
+		case decisive.Name != expect.RuleName:
+			ret.Failures = append(ret.Failures, fmt.Sprintf(
+				"Expected the request to be decided by the rule %s but it was decided by the rule %q",
+				expect.RuleName, decisive.Name))
+		}
+	}
+
+	ret.IsPassed = len(ret.Failures) == 0
+
+	return ret
+}
+
+func getPolicyTestRuleKey(src *corev1.SimulatePolicyResponse_Source, idx int32) string {
+	switch {
+	case src.GetPolicyRef() != nil:
+		return fmt.Sprintf("policy/%s/%d", src.GetPolicyRef().Name, idx)
+	case src.GetInlinePolicy() != nil:
+		inline := src.GetInlinePolicy()
+		return fmt.Sprintf("inline/%s/%s/%s/%d",
+			inline.ResourceRef.GetKind(), inline.ResourceRef.GetName(), inline.Name, idx)
+	default:
+		return ""
+	}
+}
+
+// policyTestFixtures is the set of the resources of a policy test indexed by
+// their names. The test cases are resolved against the fixtures only, never
+// against the Cluster's resources.
+type policyTestFixtures struct {
+	req        *corev1.TestPolicyRequest_Fixtures
+	policies   map[string]*corev1.Policy
+	users      map[string]*corev1.User
+	groups     map[string]*corev1.Group
+	devices    map[string]*corev1.Device
+	services   map[string]*corev1.Service
+	namespaces map[string]*corev1.Namespace
+	sessions   map[string]*corev1.Session
+}
+
+type policyTestOwner struct {
+	rsc   umetav1.ResourceObjectI
+	owner policyOwner
+}
+
+func (f *policyTestFixtures) getOwners() []*policyTestOwner {
+	var ret []*policyTestOwner
+	for _, itm := range f.req.Services {
+		ret = append(ret, &policyTestOwner{rsc: itm, owner: itm.Spec.Authorization})
+	}
+	for _, itm := range f.req.Namespaces {
+		ret = append(ret, &policyTestOwner{rsc: itm, owner: itm.Spec.Authorization})
+	}
+	for _, itm := range f.req.Users {
+		ret = append(ret, &policyTestOwner{rsc: itm, owner: itm.Spec.Authorization})
+	}
+	for _, itm := range f.req.Groups {
+		ret = append(ret, &policyTestOwner{rsc: itm, owner: itm.Spec.Authorization})
+	}
+	for _, itm := range f.req.Devices {
+		ret = append(ret, &policyTestOwner{rsc: itm, owner: itm.Spec.Authorization})
+	}
+	for _, itm := range f.req.Sessions {
+		ret = append(ret, &policyTestOwner{rsc: itm, owner: itm.Spec.Authorization})
+	}
+	return ret
+}
+
+// getRules returns all the rules of the Policies and the InlinePolicies of the
+// fixtures with their coverage unset.
+func (f *policyTestFixtures) getRules() []*corev1.TestPolicyResponse_Rule {
+	var ret []*corev1.TestPolicyResponse_Rule
+
+	for _, policy := range f.req.Policies {
+		src := &corev1.SimulatePolicyResponse_Source{
+			Type: &corev1.SimulatePolicyResponse_Source_PolicyRef{
+				PolicyRef: umetav1.GetObjectReference(policy),
+			},
+		}
+		for idx, rule := range policy.Spec.Rules {
+			ret = append(ret, &corev1.TestPolicyResponse_Rule{
+				Source: src,
+				Index:  int32(idx),
+				Name:   rule.Name,
+				Effect: rule.Effect,
+			})
+		}
+	}
+
+	for _, o := range f.getOwners() {
+		for _, inline := range o.owner.GetInlinePolicies() {
+			src := &corev1.SimulatePolicyResponse_Source{
+				Type: &corev1.SimulatePolicyResponse_Source_InlinePolicy_{
+					InlinePolicy: &corev1.SimulatePolicyResponse_Source_InlinePolicy{
+						Name:        inline.Name,
+						ResourceRef: umetav1.GetObjectReference(o.rsc),
+					},
+				},
+			}
+			for idx, rule := range inline.Spec.Rules {
+				ret = append(ret, &corev1.TestPolicyResponse_Rule{
+					Source: src,
+					Index:  int32(idx),
+					Name:   rule.Name,
+					Effect: rule.Effect,
+				})
+			}
+		}
+	}
+
+	return ret
+}
+
+// getPolicyTestFixtures validates the fixtures and fills the fields that are
+// normally set by the Cluster, such as the Kind or the Namespace of a Service.
+func (s *Server) getPolicyTestFixtures(ctx context.Context,
+	req *corev1.TestPolicyRequest_Fixtures) (*policyTestFixtures, error) {
+	if req == nil {
+		req = &corev1.TestPolicyRequest_Fixtures{}
+	}
+
+	ret := &policyTestFixtures{
+		req:        req,
+		policies:   make(map[string]*corev1.Policy),
+		users:      make(map[string]*corev1.User),
+		groups:     make(map[string]*corev1.Group),
+		devices:    make(map[string]*corev1.Device),
+		services:   make(map[string]*corev1.Service),
+		namespaces: make(map[string]*corev1.Namespace),
+		sessions:   make(map[string]*corev1.Session),
+	}
+
+	for _, lst := range []int{
+		len(req.Policies), len(req.Users), len(req.Groups), len(req.Devices),
+		len(req.Services), len(req.Namespaces), len(req.Sessions),
+	} {
+		if lst > maxPolicyTestFixtures {
+			return nil, grpcutils.InvalidArg("Too many fixtures")
+		}
+	}
+
+	validateMetadata := func(kind string, md *metav1.Metadata, parentsMax uint64) error {
+		if md == nil {
+			return grpcutils.InvalidArg("Nil metadata of a %s fixture", kind)
+		}
+		if err := apivalidation.ValidateName(md.Name, 0, parentsMax); err != nil {
+			return grpcutils.InvalidArg("Invalid %s fixture name: %s", kind, md.Name)
+		}
+		return nil
+	}
+
+	for _, itm := range req.Policies {
+		if err := validateMetadata(ucorev1.KindPolicy, itm.GetMetadata(), vutils.MaxPolicyParents); err != nil {
+			return nil, err
+		}
+		itm.ApiVersion, itm.Kind = ucorev1.APIVersion, ucorev1.KindPolicy
+		if err := s.validatePolicySpec(ctx, itm.Spec); err != nil {
+			return nil, grpcutils.InvalidArg("Policy fixture %s: %s", itm.Metadata.Name, err)
+		}
+		ret.policies[itm.Metadata.Name] = itm
+	}
+
+	for _, itm := range req.Users {
+		if err := validateMetadata(ucorev1.KindUser, itm.GetMetadata(), 0); err != nil {
+			return nil, err
+		}
+		itm.ApiVersion, itm.Kind = ucorev1.APIVersion, ucorev1.KindUser
+		if itm.Spec == nil {
+			itm.Spec = &corev1.User_Spec{}
+		}
+		if itm.Status == nil {
+			itm.Status = &corev1.User_Status{}
+		}
+		ret.users[itm.Metadata.Name] = itm
+	}
+
+	for _, itm := range req.Groups {
+		if err := validateMetadata(ucorev1.KindGroup, itm.GetMetadata(), 0); err != nil {
+			return nil, err
+		}
+		itm.ApiVersion, itm.Kind = ucorev1.APIVersion, ucorev1.KindGroup
+		if itm.Spec == nil {
+			itm.Spec = &corev1.Group_Spec{}
+		}
+		if itm.Status == nil {
+			itm.Status = &corev1.Group_Status{}
+		}
+		ret.groups[itm.Metadata.Name] = itm
+	}
+
+	for _, itm := range req.Devices {
+		if err := validateMetadata(ucorev1.KindDevice, itm.GetMetadata(), 0); err != nil {
+			return nil, err
+		}
+		itm.ApiVersion, itm.Kind = ucorev1.APIVersion, ucorev1.KindDevice
+		if itm.Spec == nil {
+			itm.Spec = &corev1.Device_Spec{
+				State: corev1.Device_Spec_ACTIVE,
+			}
+		}
+		if itm.Status == nil {
+			itm.Status = &corev1.Device_Status{}
+		}
+		ret.devices[itm.Metadata.Name] = itm
+	}
+
+	for _, itm := range req.Namespaces {
+		if err := validateMetadata(ucorev1.KindNamespace, itm.GetMetadata(), 0); err != nil {
+			return nil, err
+		}
+		itm.ApiVersion, itm.Kind = ucorev1.APIVersion, ucorev1.KindNamespace
+		if itm.Spec == nil {
+			itm.Spec = &corev1.Namespace_Spec{}
+		}
+		if itm.Status == nil {
+			itm.Status = &corev1.Namespace_Status{}
+		}
+		ret.namespaces[itm.Metadata.Name] = itm
+	}
+
+	for _, itm := range req.Services {
+		if err := validateMetadata(ucorev1.KindService, itm.GetMetadata(), 1); err != nil {
+			return nil, err
+		}
+		itm.ApiVersion, itm.Kind = ucorev1.APIVersion, ucorev1.KindService
+		itm.Metadata.Name = vutils.GetServiceFullNameFromName(itm.Metadata.Name)
+		if itm.Spec == nil {
+			itm.Spec = &corev1.Service_Spec{}
+		}
+		if itm.Status == nil {
+			itm.Status = &corev1.Service_Status{}
+		}
+		if itm.Status.NamespaceRef == nil {
+			nsName, err := getNamespace(itm.Metadata.Name)
+			if err != nil {
+				return nil, err
+			}
+			itm.Status.NamespaceRef = &metav1.ObjectReference{
+				ApiVersion: ucorev1.APIVersion,
+				Kind:       ucorev1.KindNamespace,
+				Name:       nsName,
+			}
+		}
+		ret.services[itm.Metadata.Name] = itm
+	}
+
+	for _, itm := range req.Sessions {
+		if err := validateMetadata(ucorev1.KindSession, itm.GetMetadata(), 0); err != nil {
+			return nil, err
+		}
+		itm.ApiVersion, itm.Kind = ucorev1.APIVersion, ucorev1.KindSession
+		if itm.Spec == nil {
+			itm.Spec = &corev1.Session_Spec{
+				State: corev1.Session_Spec_ACTIVE,
+			}
+		}
+		if itm.Status == nil {
+			itm.Status = &corev1.Session_Status{
+				Type: corev1.Session_Status_CLIENT,
+			}
+		}
+		if itm.Status.Authentication == nil {
+			itm.Status.Authentication = &corev1.Session_Status_Authentication{}
+		}
+		ret.sessions[itm.Metadata.Name] = itm
+	}
+
+	for _, o := range ret.getOwners() {
+		name := fmt.Sprintf("%s fixture %s", o.rsc.GetKind(), o.rsc.GetMetadata().GetName())
+		if len(o.owner.GetPolicies()) > maxPolicyRefs || len(o.owner.GetInlinePolicies()) > maxPolicyRefs {
+			return nil, grpcutils.InvalidArg("%s: Too many Policies", name)
+		}
+
+		for _, p := range o.owner.GetPolicies() {
+			if _, ok := ret.policies[p]; !ok {
+				return nil, grpcutils.InvalidArg("%s: The Policy %s is not in the fixtures", name, p)
+			}
+		}
+
+		for _, inline := range o.owner.GetInlinePolicies() {
+			if inline == nil {
+				return nil, grpcutils.InvalidArg("%s: Nil inlinePolicy", name)
+			}
+			if err := s.validatePolicySpec(ctx, inline.Spec); err != nil {
+				return nil, grpcutils.InvalidArg("%s: inlinePolicy %s: %s", name, inline.Name, err)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func (f *policyTestFixtures) getRequestContext(c *corev1.TestPolicyRequest_Case) (*corev1.RequestContext, error) {
+	if c.Expect == nil || c.Expect.Decision == corev1.TestPolicyRequest_Case_Expect_DECISION_UNKNOWN {
+		return nil, errors.Errorf("expect.decision must be set")
+	}
+
+	svc, ok := f.services[vutils.GetServiceFullNameFromName(c.Service)]
+	if !ok {
+		return nil, errors.Errorf("The Service %s is not in the fixtures", c.Service)
+	}
+
+	request := c.Request
+	if request == nil {
+		request = &corev1.RequestContext_Request{}
+	}
+
+	ret := &corev1.RequestContext{
+		Service: svc,
+		Request: request,
+	}
+
+	userName := c.User
+	if c.Session != "" {
+		sess, ok := f.sessions[c.Session]
+		if !ok {
+			return nil, errors.Errorf("The Session %s is not in the fixtures", c.Session)
+		}
+		if userName == "" {
+			userName = sess.Status.UserRef.GetName()
+		}
+		ret.Session = sess
+	}
+
+	if userName == "" {
+		if svc.Spec.IsAnonymous {
+			return ret, nil
+		}
+		return nil, errors.Errorf("Either a User or a Session must be set")
+	}
+
+	usr, ok := f.users[userName]
+	if !ok {
+		return nil, errors.Errorf("The User %s is not in the fixtures", userName)
+	}
+	ret.User = usr
+
+	if ret.Session != nil && ret.Session.Status.UserRef != nil &&
+		ret.Session.Status.UserRef.Name != usr.Metadata.Name {
+		return nil, errors.Errorf("The Session does not belong to the User")
+	}
+
+	if c.Device != "" {
+		dev, ok := f.devices[c.Device]
+		if !ok {
+			return nil, errors.Errorf("The Device %s is not in the fixtures", c.Device)
+		}
+		if dev.Status.UserRef != nil && dev.Status.UserRef.Name != usr.Metadata.Name {
+			return nil, errors.Errorf("The Device does not belong to the User")
+		}
+		ret.Device = dev
+	}
+
+	if ret.Session == nil {
+		ret.Session = getSimulatedSession(usr, ret.Device, svc)
+	}
+
+	for _, g := range usr.Spec.Groups {
+		if grp, ok := f.groups[g]; ok {
+			ret.Groups = append(ret.Groups, grp)
+		}
+	}
+
+	return ret, nil
+}
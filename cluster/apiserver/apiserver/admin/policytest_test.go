@@ -0,0 +1,293 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/octelium/octelium/apis/cluster/coctovigilv1"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type fakePolicyTestOctovigilC struct {
+	coctovigilv1.InternalServiceClient
+	lastReq *coctovigilv1.TestPolicyRequest
+}
+
+func (c *fakePolicyTestOctovigilC) InternalC() coctovigilv1.InternalServiceClient {
+	return c
+}
+
+// TestPolicy allows the requests of the User "alice" via the first rule of the
+// Policy "allow-alice" and denies everything else.
+func (c *fakePolicyTestOctovigilC) TestPolicy(ctx context.Context,
+	in *coctovigilv1.TestPolicyRequest, opts ...grpc.CallOption) (*coctovigilv1.TestPolicyResponse, error) {
+	c.lastReq = in
+	ret := &coctovigilv1.TestPolicyResponse{}
+
+	src := &corev1.SimulatePolicyResponse_Source{
+		Type: &corev1.SimulatePolicyResponse_Source_PolicyRef{
+			PolicyRef: &metav1.ObjectReference{
+				Name: "allow-alice",
+			},
+		},
+	}
+
+	for _, reqCtx := range in.RequestContexts {
+		if reqCtx.User.GetMetadata().GetName() == "alice" {
+			ret.Results = append(ret.Results, &corev1.SimulatePolicyResponse{
+				IsAuthorized: true,
+				Reason: &corev1.AccessLog_Entry_Common_Reason{
+					Type: corev1.AccessLog_Entry_Common_Reason_POLICY_MATCH,
+				},
+				Rules: []*corev1.SimulatePolicyResponse_Rule{
+					{
+						Name:       "alice",
+						Source:     src,
+						Index:      0,
+						Result:     corev1.SimulatePolicyResponse_MATCHED,
+						IsDecisive: true,
+					},
+				},
+			})
+		} else {
+			ret.Results = append(ret.Results, &corev1.SimulatePolicyResponse{
+				Reason: &corev1.AccessLog_Entry_Common_Reason{
+					Type: corev1.AccessLog_Entry_Common_Reason_NO_POLICY_MATCH,
+				},
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+func TestTestPolicy(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(&Opts{})
+
+	getFixtures := func() *corev1.TestPolicyRequest_Fixtures {
+		return &corev1.TestPolicyRequest_Fixtures{
+			Policies: []*corev1.Policy{
+				{
+					Metadata: &metav1.Metadata{
+						Name: "allow-alice",
+					},
+					Spec: &corev1.Policy_Spec{
+						Rules: []*corev1.Policy_Spec_Rule{
+							{
+								Name:   "alice",
+								Effect: corev1.Policy_Spec_Rule_ALLOW,
+								Condition: &corev1.Condition{
+									Type: &corev1.Condition_Match{
+										Match: `ctx.user.metadata.name == "alice"`,
+									},
+								},
+							},
+							{
+								Name:   "never",
+								Effect: corev1.Policy_Spec_Rule_DENY,
+								Condition: &corev1.Condition{
+									Type: &corev1.Condition_Match{
+										Match: `ctx.user.metadata.name == "nobody"`,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Users: []*corev1.User{
+				{
+					Metadata: &metav1.Metadata{Name: "alice"},
+					Spec:     &corev1.User_Spec{Type: corev1.User_Spec_HUMAN},
+				},
+				{
+					Metadata: &metav1.Metadata{Name: "bob"},
+					Spec:     &corev1.User_Spec{Type: corev1.User_Spec_HUMAN},
+				},
+			},
+			Services: []*corev1.Service{
+				{
+					Metadata: &metav1.Metadata{Name: "svc"},
+					Spec: &corev1.Service_Spec{
+						Authorization: &corev1.Service_Spec_Authorization{
+							Policies: []string{"allow-alice"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	{
+		_, err := srv.TestPolicy(ctx, &corev1.TestPolicyRequest{})
+		assert.NotNil(t, err)
+	}
+
+	octovigilC := &fakePolicyTestOctovigilC{}
+	srv.octovigilC = octovigilC
+
+	{
+		_, err := srv.TestPolicy(ctx, &corev1.TestPolicyRequest{
+			Fixtures: getFixtures(),
+		})
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+
+	invalidCases := []*corev1.TestPolicyRequest_Case{
+		{
+			User:    "alice",
+			Service: "svc",
+		},
+		{
+			User:    "alice",
+			Service: "unknown",
+			Expect: &corev1.TestPolicyRequest_Case_Expect{
+				Decision: corev1.TestPolicyRequest_Case_Expect_ALLOW,
+			},
+		},
+		{
+			Service: "svc",
+			Expect: &corev1.TestPolicyRequest_Case_Expect{
+				Decision: corev1.TestPolicyRequest_Case_Expect_ALLOW,
+			},
+		},
+		{
+			User:    "carol",
+			Service: "svc",
+			Expect: &corev1.TestPolicyRequest_Case_Expect{
+				Decision: corev1.TestPolicyRequest_Case_Expect_ALLOW,
+			},
+		},
+	}
+
+	for _, c := range invalidCases {
+		_, err := srv.TestPolicy(ctx, &corev1.TestPolicyRequest{
+			Fixtures: getFixtures(),
+			Cases:    []*corev1.TestPolicyRequest_Case{c},
+		})
+		assert.True(t, grpcerr.IsInvalidArg(err), "%+v", c)
+	}
+
+	{
+		fixtures := getFixtures()
+		fixtures.Services[0].Spec.Authorization.Policies = []string{"unknown"}
+		_, err := srv.TestPolicy(ctx, &corev1.TestPolicyRequest{
+			Fixtures: fixtures,
+			Cases: []*corev1.TestPolicyRequest_Case{
+				{
+					User:    "alice",
+					Service: "svc",
+					Expect: &corev1.TestPolicyRequest_Case_Expect{
+						Decision: corev1.TestPolicyRequest_Case_Expect_ALLOW,
+					},
+				},
+			},
+		})
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+
+	{
+		fixtures := getFixtures()
+		fixtures.Policies[0].Spec.Rules[0].Condition.Type = &corev1.Condition_Match{
+			Match: `ctx.user.metadata.nme == "alice"`,
+		}
+		_, err := srv.TestPolicy(ctx, &corev1.TestPolicyRequest{
+			Fixtures: fixtures,
+			Cases: []*corev1.TestPolicyRequest_Case{
+				{
+					User:    "alice",
+					Service: "svc",
+					Expect: &corev1.TestPolicyRequest_Case_Expect{
+						Decision: corev1.TestPolicyRequest_Case_Expect_ALLOW,
+					},
+				},
+			},
+		})
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+
+	{
+		res, err := srv.TestPolicy(ctx, &corev1.TestPolicyRequest{
+			Fixtures: getFixtures(),
+			Cases: []*corev1.TestPolicyRequest_Case{
+				{
+					Name:    "alice-allowed",
+					User:    "alice",
+					Service: "svc",
+					Expect: &corev1.TestPolicyRequest_Case_Expect{
+						Decision: corev1.TestPolicyRequest_Case_Expect_ALLOW,
+						Reason:   corev1.AccessLog_Entry_Common_Reason_POLICY_MATCH,
+						RuleName: "alice",
+					},
+				},
+				{
+					Name:    "bob-denied",
+					User:    "bob",
+					Service: "svc.default",
+					Expect: &corev1.TestPolicyRequest_Case_Expect{
+						Decision: corev1.TestPolicyRequest_Case_Expect_DENY,
+					},
+				},
+				{
+					User:    "bob",
+					Service: "svc",
+					Expect: &corev1.TestPolicyRequest_Case_Expect{
+						Decision: corev1.TestPolicyRequest_Case_Expect_ALLOW,
+						RuleName: "alice",
+					},
+				},
+			},
+		})
+		assert.Nil(t, err)
+
+		assert.Equal(t, 3, len(octovigilC.lastReq.RequestContexts))
+		reqCtx := octovigilC.lastReq.RequestContexts[0]
+		assert.Equal(t, "svc.default", reqCtx.Service.Metadata.Name)
+		assert.Equal(t, "default", reqCtx.Service.Status.NamespaceRef.Name)
+		assert.Equal(t, "alice", reqCtx.User.Metadata.Name)
+		assert.NotNil(t, reqCtx.Session)
+		assert.Equal(t, 1, len(octovigilC.lastReq.Policies))
+
+		assert.Equal(t, 3, len(res.Results))
+
+		assert.Equal(t, "alice-allowed", res.Results[0].Name)
+		assert.True(t, res.Results[0].IsPassed)
+		assert.True(t, res.Results[0].IsAuthorized)
+
+		assert.True(t, res.Results[1].IsPassed)
+		assert.False(t, res.Results[1].IsAuthorized)
+
+		assert.Equal(t, "#2", res.Results[2].Name)
+		assert.False(t, res.Results[2].IsPassed)
+		assert.Equal(t, 2, len(res.Results[2].Failures))
+
+		assert.Equal(t, 2, len(res.Rules))
+		assert.Equal(t, "alice", res.Rules[0].Name)
+		assert.Equal(t, int32(1), res.Rules[0].MatchCount)
+		assert.Equal(t, int32(1), res.Rules[0].DecisiveCount)
+		assert.Equal(t, "never", res.Rules[1].Name)
+		assert.Equal(t, int32(1), res.Rules[1].Index)
+		assert.Equal(t, int32(0), res.Rules[1].MatchCount)
+	}
+}
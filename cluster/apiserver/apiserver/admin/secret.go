@@ -133,6 +133,10 @@ func (s *Server) UpdateSecret(ctx context.Context, req *corev1.Secret) (*corev1.
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(sec, req); err != nil {
+		return nil, err
+	}
+
 	if err := apivalidation.CheckIsSystem(sec); err != nil {
 		return nil, err
 	}
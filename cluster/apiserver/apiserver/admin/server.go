@@ -19,22 +19,26 @@ package admin
 import (
 	"github.com/octelium/octelium/apis/main/corev1"
 	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/cluster/common/octovigilc"
 )
 
 type Server struct {
 	octeliumC octeliumc.ClientInterface
 	corev1.UnimplementedMainServiceServer
 	isEmbedded bool
+	octovigilC octovigilc.ClientInterface
 }
 
 type Opts struct {
 	OcteliumC  octeliumc.ClientInterface
 	IsEmbedded bool
+	OctovigilC octovigilc.ClientInterface
 }
 
 func NewServer(opts *Opts) *Server {
 	return &Server{
 		octeliumC:  opts.OcteliumC,
 		isEmbedded: opts.IsEmbedded,
+		octovigilC: opts.OctovigilC,
 	}
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"mime"
+	"net/http"
 	"net/url"
 	"regexp"
 	"slices"
@@ -106,6 +107,10 @@ func (s *Server) UpdateService(ctx context.Context, req *corev1.Service) (*corev
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(item, req); err != nil {
+		return nil, err
+	}
+
 	if err := apivalidation.CheckIsSystem(item); err != nil {
 		return nil, err
 	}
@@ -956,6 +961,10 @@ func (s *Server) validateServiceConfig(ctx context.Context,
 			return err
 		}
 
+		if err := s.validateHTTPIdentityAssertion(cfg.GetHttp().IdentityAssertion); err != nil {
+			return err
+		}
+
 	case *corev1.Service_Spec_Config_Kubernetes_:
 		if spec.Mode != corev1.Service_Spec_KUBERNETES {
 			return grpcutils.InvalidArg("KUBERNETES mode must be set for KUBERNETES config to be used")
@@ -1834,6 +1843,39 @@ func (s *Server) validateHTTPVisibility(visibility *corev1.Service_Spec_Config_H
 	return nil
 }
 
+func (s *Server) validateHTTPIdentityAssertion(a *corev1.Service_Spec_Config_HTTP_IdentityAssertion) error {
+	if a == nil {
+		return nil
+	}
+
+	if a.Header != "" {
+		if len(a.Header) > 128 || !httpguts.ValidHeaderFieldName(a.Header) {
+			return grpcutils.InvalidArg("Invalid identityAssertion header: %s", a.Header)
+		}
+
+		switch http.CanonicalHeaderKey(a.Header) {
+		case "Authorization", "Cookie", "Host", "Content-Type", "Content-Length":
+			return grpcutils.InvalidArg("identityAssertion header cannot be: %s", a.Header)
+		}
+	}
+
+	if err := s.validateGenStr(a.Audience, false, "audience"); err != nil {
+		return err
+	}
+
+	if a.Ttl != nil {
+		if err := apivalidation.ValidateDuration(a.Ttl); err != nil {
+			return err
+		}
+
+		if umetav1.ToDuration(a.Ttl).ToSeconds() > 60*60 {
+			return grpcutils.InvalidArg("identityAssertion ttl cannot exceed 1 hour")
+		}
+	}
+
+	return nil
+}
+
 const (
 	maxMCPProtocolVersions = 16
 	maxMCPEndpointPathLen  = 256
@@ -1675,6 +1675,36 @@ func TestValidateMCPVisibility(t *testing.T) {
 	}
 }
 
+func TestValidateHTTPIdentityAssertion(t *testing.T) {
+	s := &Server{}
+
+	assert.Nil(t, s.validateHTTPIdentityAssertion(nil))
+	assert.Nil(t, s.validateHTTPIdentityAssertion(&corev1.Service_Spec_Config_HTTP_IdentityAssertion{}))
+	assert.Nil(t, s.validateHTTPIdentityAssertion(&corev1.Service_Spec_Config_HTTP_IdentityAssertion{
+		Header:   "X-Identity",
+		Audience: "https://app.example.com",
+		Ttl: &metav1.Duration{
+			Type: &metav1.Duration_Minutes{Minutes: 60},
+		},
+	}))
+
+	for _, hdr := range []string{"X Identity", "authorization", "Host", strings.Repeat("a", 129)} {
+		assert.NotNil(t, s.validateHTTPIdentityAssertion(&corev1.Service_Spec_Config_HTTP_IdentityAssertion{
+			Header: hdr,
+		}), hdr)
+	}
+
+	assert.NotNil(t, s.validateHTTPIdentityAssertion(&corev1.Service_Spec_Config_HTTP_IdentityAssertion{
+		Ttl: &metav1.Duration{
+			Type: &metav1.Duration_Minutes{Minutes: 61},
+		},
+	}))
+
+	assert.NotNil(t, s.validateHTTPIdentityAssertion(&corev1.Service_Spec_Config_HTTP_IdentityAssertion{
+		Audience: "app\xff",
+	}))
+}
+
 func TestValidateLLMModel(t *testing.T) {
 	ctx := context.Background()
 	s := &Server{}
@@ -115,6 +115,10 @@ func (s *Server) UpdateSession(ctx context.Context, req *corev1.Session) (*corev
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(ret, req); err != nil {
+		return nil, err
+	}
+
 	if err := apivalidation.CheckIsSystem(ret); err != nil {
 		return nil, err
 	}
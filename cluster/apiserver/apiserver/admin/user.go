@@ -87,6 +87,10 @@ func (s *Server) UpdateUser(ctx context.Context, req *corev1.User) (*corev1.User
 		return nil, serr.K8sNotFoundOrInternalWithErr(err)
 	}
 
+	if err := apivalidation.CheckResourceVersion(item, req); err != nil {
+		return nil, err
+	}
+
 	if err := apivalidation.CheckIsSystem(item); err != nil {
 		return nil, err
 	}
@@ -149,7 +153,9 @@ func (s *Server) DeleteUser(ctx context.Context, req *metav1.DeleteOptions) (*me
 		return nil, err
 	}
 
-	if !ldflags.IsTest() {
+	switch {
+	case ldflags.IsTest() || s.isEmbedded:
+	default:
 		i, err := userctx.GetUserCtx(ctx)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,506 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/urscsrv"
+	"go.uber.org/zap"
+)
+
+func groupDisplayName(grp *corev1.Group) string {
+	if grp.Metadata.DisplayName != "" {
+		return grp.Metadata.DisplayName
+	}
+	return grp.Metadata.Name
+}
+
+func (s *Server) listGroupMembers(ctx context.Context, grp *corev1.Group) ([]*corev1.User, error) {
+	usrList, err := s.octeliumC.CoreC().ListUser(ctx, &rmetav1.ListOptions{
+		Filters: []*rmetav1.ListOptions_Filter{
+			urscsrv.FilterFieldIncludesValStr("spec.groups", grp.Metadata.Name),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*corev1.User
+	for _, usr := range usrList.Items {
+		if isManaged(usr.Metadata) {
+			ret = append(ret, usr)
+		}
+	}
+
+	return ret, nil
+}
+
+func (s *Server) toSCIMGroup(ctx context.Context, grp *corev1.Group) (*group, error) {
+	ret := &group{
+		Schemas:     []string{schemaGroup},
+		ID:          grp.Metadata.Uid,
+		DisplayName: groupDisplayName(grp),
+		ExternalID:  grp.Metadata.Annotations[annotationExternalID],
+		Meta:        getMeta(grp.Metadata, "Group"),
+	}
+
+	usrs, err := s.listGroupMembers(ctx, grp)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, usr := range usrs {
+		ret.Members = append(ret.Members, memberValue{
+			Value:   usr.Metadata.Uid,
+			Display: usr.Metadata.Name,
+			Ref:     getMeta(usr.Metadata, "User").Location,
+		})
+	}
+
+	return ret, nil
+}
+
+func (s *Server) getGroup(ctx context.Context, id string) (*corev1.Group, error) {
+	if !govalidator.IsUUIDv4(id) {
+		return nil, grpcutils.NotFound("Group not found")
+	}
+
+	grp, err := s.octeliumC.CoreC().GetGroup(ctx, &rmetav1.GetOptions{Uid: id})
+	if err != nil {
+		return nil, err
+	}
+
+	if !isManaged(grp.Metadata) {
+		return nil, grpcutils.NotFound("Group not found")
+	}
+
+	return grp, nil
+}
+
+func groupMatchesFilter(grp *corev1.Group, f *filter) bool {
+	if f == nil {
+		return true
+	}
+
+	switch f.attr {
+	case "id":
+		return grp.Metadata.Uid == f.value
+	case "displayname":
+		return groupDisplayName(grp) == f.value
+	case "externalid":
+		return grp.Metadata.Annotations[annotationExternalID] == f.value
+	default:
+		return false
+	}
+}
+
+func (s *Server) handleListGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params, err := getListParams(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	grpList, err := s.octeliumC.CoreC().ListGroup(ctx, &rmetav1.ListOptions{
+		Filters: []*rmetav1.ListOptions_Filter{
+			urscsrv.FilterFieldBooleanFalse("metadata.isSystem"),
+		},
+	})
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	var matched []*corev1.Group
+	for _, grp := range grpList.Items {
+		if isManaged(grp.Metadata) && groupMatchesFilter(grp, params.filter) {
+			matched = append(matched, grp)
+		}
+	}
+
+	resp := &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: len(matched),
+		StartIndex:   params.startIndex,
+		Resources:    []any{},
+	}
+
+	for _, grp := range paginate(matched, params.startIndex, params.count) {
+		item, err := s.toSCIMGroup(ctx, grp)
+		if err != nil {
+			writeErrorFromErr(w, err)
+			return
+		}
+		resp.Resources = append(resp.Resources, item)
+	}
+	resp.ItemsPerPage = len(resp.Resources)
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleGetGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	grp, err := s.getGroup(ctx, r.PathValue("id"))
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	s.writeGroup(w, r, grp, http.StatusOK)
+}
+
+func (s *Server) writeGroup(w http.ResponseWriter, r *http.Request, grp *corev1.Group, statusCode int) {
+	ret, err := s.toSCIMGroup(r.Context(), grp)
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	writeJSON(w, statusCode, ret)
+}
+
+func (s *Server) handleCreateGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req group
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.DisplayName == "" {
+		writeError(w, http.StatusBadRequest, "displayName is required")
+		return
+	}
+
+	rscName, err := toResourceName(req.DisplayName)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	grp := &corev1.Group{
+		Metadata: &metav1.Metadata{
+			Name:        rscName,
+			DisplayName: req.DisplayName,
+		},
+		Spec: &corev1.Group_Spec{},
+	}
+	setManaged(grp.Metadata)
+
+	if err := setAnnotation(grp.Metadata, annotationExternalID, req.ExternalID); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	grp, err = s.adminSrv.CreateGroup(ctx, grp)
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	zap.L().Info("SCIM: created Group", zap.String("name", grp.Metadata.Name))
+
+	if err := s.addGroupMembers(ctx, grp, getMemberIDs(req.Members)); err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	s.writeGroup(w, r, grp, http.StatusCreated)
+}
+
+func (s *Server) handleReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	grp, err := s.getGroup(ctx, r.PathValue("id"))
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	var req group
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.DisplayName != "" {
+		grp.Metadata.DisplayName = req.DisplayName
+	}
+
+	if err := setAnnotation(grp.Metadata, annotationExternalID, req.ExternalID); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	grp, err = s.adminSrv.UpdateGroup(ctx, grp)
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	if err := s.setGroupMembers(ctx, grp, getMemberIDs(req.Members)); err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	s.writeGroup(w, r, grp, http.StatusOK)
+}
+
+func (s *Server) handlePatchGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	grp, err := s.getGroup(ctx, r.PathValue("id"))
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	var req patchRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attrs := make(map[string]string)
+	for _, op := range req.Operations {
+		if err := s.doPatchGroup(ctx, grp, &op, attrs); err != nil {
+			writeErrorFromErr(w, err)
+			return
+		}
+	}
+
+	if len(attrs) > 0 {
+		if err := s.updateGroupAttrs(ctx, grp, attrs); err != nil {
+			writeErrorFromErr(w, err)
+			return
+		}
+	}
+
+	grp, err = s.octeliumC.CoreC().GetGroup(ctx, &rmetav1.GetOptions{Uid: grp.Metadata.Uid})
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	s.writeGroup(w, r, grp, http.StatusOK)
+}
+
+// doPatchGroup applies the members changes of the patch operation and collects
+// its attribute changes into attrs so that the Group itself is updated only
+// once after all the operations of the PATCH request.
+func (s *Server) doPatchGroup(ctx context.Context,
+	grp *corev1.Group, op *patchOperation, attrs map[string]string) error {
+	opType := strings.ToLower(op.Op)
+	path := strings.ToLower(strings.TrimSpace(op.Path))
+
+	if memberID, ok := parseMembersPath(op.Path); ok {
+		if opType != "remove" {
+			return grpcutils.InvalidArg("Unsupported patch op on a member path: %s", op.Op)
+		}
+		return s.removeGroupMembers(ctx, grp, []string{memberID})
+	}
+
+	switch path {
+	case "members":
+		var members []memberValue
+		if len(op.Value) > 0 {
+			if err := json.Unmarshal(op.Value, &members); err != nil {
+				return grpcutils.InvalidArg("Invalid members value")
+			}
+		}
+
+		switch opType {
+		case "add":
+			return s.addGroupMembers(ctx, grp, getMemberIDs(members))
+		case "remove":
+			if len(members) == 0 {
+				return s.setGroupMembers(ctx, grp, nil)
+			}
+			return s.removeGroupMembers(ctx, grp, getMemberIDs(members))
+		case "replace":
+			return s.setGroupMembers(ctx, grp, getMemberIDs(members))
+		}
+	case "displayname", "externalid":
+		val, err := parseString(op.Value)
+		if err != nil {
+			return grpcutils.InvalidArg("%s", err.Error())
+		}
+		attrs[path] = val
+		return nil
+	case "":
+		var valAttrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &valAttrs); err != nil {
+			return grpcutils.InvalidArg("Invalid patch value")
+		}
+
+		for k, v := range valAttrs {
+			switch strings.ToLower(k) {
+			case "displayname", "externalid":
+				val, err := parseString(v)
+				if err != nil {
+					return grpcutils.InvalidArg("%s", err.Error())
+				}
+				attrs[strings.ToLower(k)] = val
+			case "members":
+				if err := s.doPatchGroup(ctx, grp, &patchOperation{
+					Op:    op.Op,
+					Path:  "members",
+					Value: v,
+				}, attrs); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	return grpcutils.InvalidArg("Unsupported patch operation: %s %s", op.Op, op.Path)
+}
+
+func (s *Server) updateGroupAttrs(ctx context.Context, grp *corev1.Group, attrs map[string]string) error {
+	for k, v := range attrs {
+		switch k {
+		case "displayname":
+			grp.Metadata.DisplayName = v
+		case "externalid":
+			if err := setAnnotation(grp.Metadata, annotationExternalID, v); err != nil {
+				return grpcutils.InvalidArg("%s", err.Error())
+			}
+		}
+	}
+
+	_, err := s.adminSrv.UpdateGroup(ctx, grp)
+	return err
+}
+
+func getMemberIDs(members []memberValue) []string {
+	var ret []string
+	for _, m := range members {
+		if m.Value != "" {
+			ret = append(ret, m.Value)
+		}
+	}
+	return ret
+}
+
+func (s *Server) addGroupMembers(ctx context.Context, grp *corev1.Group, userIDs []string) error {
+	for _, id := range userIDs {
+		usr, err := s.getUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(usr.Spec.Groups, grp.Metadata.Name) {
+			continue
+		}
+
+		usr.Spec.Groups = append(usr.Spec.Groups, grp.Metadata.Name)
+		if _, err := s.adminSrv.UpdateUser(ctx, usr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) removeGroupMembers(ctx context.Context, grp *corev1.Group, userIDs []string) error {
+	for _, id := range userIDs {
+		usr, err := s.getUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.removeUserFromGroup(ctx, usr, grp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) removeUserFromGroup(ctx context.Context, usr *corev1.User, grp *corev1.Group) error {
+	if !slices.Contains(usr.Spec.Groups, grp.Metadata.Name) {
+		return nil
+	}
+
+	usr.Spec.Groups = slices.DeleteFunc(usr.Spec.Groups, func(g string) bool {
+		return g == grp.Metadata.Name
+	})
+
+	_, err := s.adminSrv.UpdateUser(ctx, usr)
+	return err
+}
+
+func (s *Server) setGroupMembers(ctx context.Context, grp *corev1.Group, userIDs []string) error {
+	members, err := s.listGroupMembers(ctx, grp)
+	if err != nil {
+		return err
+	}
+
+	for _, usr := range members {
+		if slices.Contains(userIDs, usr.Metadata.Uid) {
+			continue
+		}
+
+		if err := s.removeUserFromGroup(ctx, usr, grp); err != nil {
+			return err
+		}
+	}
+
+	return s.addGroupMembers(ctx, grp, userIDs)
+}
+
+func (s *Server) handleDeleteGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	grp, err := s.getGroup(ctx, r.PathValue("id"))
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	// The API server refuses to delete Groups that still have Users. SCIM
+	// deletes the Group along with its memberships.
+	if err := s.setGroupMembers(ctx, grp, nil); err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	if _, err := s.adminSrv.DeleteGroup(ctx, &metav1.DeleteOptions{Uid: grp.Metadata.Uid}); err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	zap.L().Info("SCIM: deleted Group", zap.String("name", grp.Metadata.Name))
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,327 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/admin"
+	"github.com/octelium/octelium/cluster/common/healthcheck"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/cluster/common/urscsrv"
+	"github.com/octelium/octelium/cluster/common/vutils"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// LabelCredential is the Credential label that marks a Credential as
+// dedicated to SCIM provisioning. Only Sessions created by such Credentials
+// are allowed to use the SCIM API.
+const LabelCredential = "octelium-scim"
+
+// LabelManaged is the label that marks the Users and Groups provisioned via
+// SCIM. The SCIM API only sees and modifies the Users and Groups that have
+// this label so that a SCIM Credential cannot be used to disable or delete
+// any other User such as the Cluster admins. Existing Users and Groups can be
+// handed over to SCIM by setting this label to "true".
+const LabelManaged = "octelium-scim-managed"
+
+const maxBodySize = 1024 * 1024
+
+type Server struct {
+	octeliumC octeliumc.ClientInterface
+	adminSrv  *admin.Server
+}
+
+func NewServer(octeliumC octeliumc.ClientInterface) *Server {
+	return &Server{
+		octeliumC: octeliumC,
+		adminSrv: admin.NewServer(&admin.Opts{
+			OcteliumC:  octeliumC,
+			IsEmbedded: true,
+		}),
+	}
+}
+
+func (s *Server) getMux() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /scim/v2/ServiceProviderConfig", s.handleServiceProviderConfig)
+
+	mux.HandleFunc("GET /scim/v2/Users", s.handleListUsers)
+	mux.HandleFunc("POST /scim/v2/Users", s.handleCreateUser)
+	mux.HandleFunc("GET /scim/v2/Users/{id}", s.handleGetUser)
+	mux.HandleFunc("PUT /scim/v2/Users/{id}", s.handleReplaceUser)
+	mux.HandleFunc("PATCH /scim/v2/Users/{id}", s.handlePatchUser)
+	mux.HandleFunc("DELETE /scim/v2/Users/{id}", s.handleDeleteUser)
+
+	mux.HandleFunc("GET /scim/v2/Groups", s.handleListGroups)
+	mux.HandleFunc("POST /scim/v2/Groups", s.handleCreateGroup)
+	mux.HandleFunc("GET /scim/v2/Groups/{id}", s.handleGetGroup)
+	mux.HandleFunc("PUT /scim/v2/Groups/{id}", s.handleReplaceGroup)
+	mux.HandleFunc("PATCH /scim/v2/Groups/{id}", s.handlePatchGroup)
+	mux.HandleFunc("DELETE /scim/v2/Groups/{id}", s.handleDeleteGroup)
+
+	return mux
+}
+
+func (s *Server) handler() http.Handler {
+	mux := s.getMux()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := s.authenticate(r.Context(), r); err != nil {
+			zap.L().Debug("Could not authenticate SCIM request", zap.Error(err))
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// authenticate checks that the request comes from a Session that was created
+// by a SCIM-dedicated Credential. The Session UID header is set by Vigil for
+// managed Services after the downstream has already been authenticated.
+func (s *Server) authenticate(ctx context.Context, r *http.Request) error {
+	sessUID := r.Header.Get("X-Octelium-Session-Uid")
+	if sessUID == "" {
+		return errors.Errorf("Could not find the Session UID header")
+	}
+
+	sess, err := s.octeliumC.CoreC().GetSession(ctx, &rmetav1.GetOptions{Uid: sessUID})
+	if err != nil {
+		return err
+	}
+
+	if sess.Status.CredentialRef == nil {
+		return errors.Errorf("The Session is not created by a Credential")
+	}
+
+	cred, err := s.octeliumC.CoreC().GetCredential(ctx, &rmetav1.GetOptions{
+		Uid: sess.Status.CredentialRef.Uid,
+	})
+	if err != nil {
+		return err
+	}
+
+	if cred.Spec.IsDisabled {
+		return errors.Errorf("The Credential is disabled")
+	}
+
+	if cred.Metadata.Labels[LabelCredential] != "true" {
+		return errors.Errorf("The Credential is not a SCIM Credential")
+	}
+
+	return nil
+}
+
+func isManaged(md *metav1.Metadata) bool {
+	return !md.IsSystem && md.Labels[LabelManaged] == "true"
+}
+
+func setManaged(md *metav1.Metadata) {
+	if md.Labels == nil {
+		md.Labels = make(map[string]string)
+	}
+	md.Labels[LabelManaged] = "true"
+}
+
+func (s *Server) handleServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"schemas": []string{schemaServiceProviderConfig},
+		"patch": map[string]any{
+			"supported": true,
+		},
+		"bulk": map[string]any{
+			"supported": false,
+		},
+		"filter": map[string]any{
+			"supported":  true,
+			"maxResults": maxListResults,
+		},
+		"changePassword": map[string]any{
+			"supported": false,
+		},
+		"sort": map[string]any{
+			"supported": false,
+		},
+		"etag": map[string]any{
+			"supported": false,
+		},
+		"authenticationSchemes": []map[string]any{
+			{
+				"type":    "oauthbearertoken",
+				"name":    "OAuth Bearer Token",
+				"primary": true,
+			},
+		},
+	})
+}
+
+// revokeUserSessions deletes all the Sessions of a deactivated User so that
+// the deactivation takes effect immediately instead of at Session expiry.
+func (s *Server) revokeUserSessions(ctx context.Context, usr *corev1.User) error {
+	sessList, err := s.octeliumC.CoreC().ListSession(ctx, urscsrv.FilterByUser(usr))
+	if err != nil {
+		return err
+	}
+
+	for _, sess := range sessList.Items {
+		if _, err := s.octeliumC.CoreC().DeleteSession(ctx,
+			&rmetav1.DeleteOptions{Uid: sess.Metadata.Uid}); err != nil && !grpcerr.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, resp any) {
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(statusCode)
+	w.Write(respBytes)
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func writeError(w http.ResponseWriter, statusCode int, detail string) {
+	writeJSON(w, statusCode, &errorResponse{
+		Schemas: []string{schemaError},
+		Status:  strconv.Itoa(statusCode),
+		Detail:  detail,
+	})
+}
+
+const (
+	scimTypeInvalidPath = "invalidPath"
+	scimTypeNoTarget    = "noTarget"
+	scimTypeMutability  = "mutability"
+)
+
+// patchError is an error of a PATCH operation that is returned with its
+// scimType as defined in RFC 7644 section 3.12.
+type patchError struct {
+	scimType string
+	detail   string
+}
+
+func newPatchError(scimType, detail string) *patchError {
+	return &patchError{
+		scimType: scimType,
+		detail:   detail,
+	}
+}
+
+func (e *patchError) Error() string {
+	return e.detail
+}
+
+func writePatchError(w http.ResponseWriter, err error) {
+	if pErr, ok := err.(*patchError); ok {
+		writeJSON(w, http.StatusBadRequest, &errorResponse{
+			Schemas:  []string{schemaError},
+			Status:   strconv.Itoa(http.StatusBadRequest),
+			ScimType: pErr.scimType,
+			Detail:   pErr.detail,
+		})
+		return
+	}
+
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+func writeErrorFromErr(w http.ResponseWriter, err error) {
+	switch {
+	case grpcerr.IsNotFound(err):
+		writeError(w, http.StatusNotFound, "Resource not found")
+	case grpcerr.AlreadyExists(err):
+		writeJSON(w, http.StatusConflict, &errorResponse{
+			Schemas:  []string{schemaError},
+			Status:   strconv.Itoa(http.StatusConflict),
+			ScimType: "uniqueness",
+			Detail:   "Resource already exists",
+		})
+	case grpcerr.IsInvalidArg(err):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case grpcerr.IsResourceChanged(err):
+		writeError(w, http.StatusPreconditionFailed, "Resource has changed on the server")
+	default:
+		zap.L().Warn("SCIM internal error", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "Internal error")
+	}
+}
+
+func decodeBody(r *http.Request, out any) error {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		return errors.Errorf("Invalid JSON body: %s", err)
+	}
+	return nil
+}
+
+func (s *Server) run(ctx context.Context) error {
+	go func() {
+		srv := &http.Server{
+			Handler:           s.handler(),
+			Addr:              vutils.ManagedServiceAddr,
+			WriteTimeout:      30 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			MaxHeaderBytes:    32 * 1024,
+		}
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Error("SCIM HTTP server exited", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func Run(ctx context.Context) error {
+	octeliumC, err := octeliumc.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	s := NewServer(octeliumC)
+	if err := s.run(ctx); err != nil {
+		return err
+	}
+
+	healthcheck.Run(vutils.HealthCheckPortManagedService)
+	zap.L().Info("SCIM server is running")
+	<-ctx.Done()
+	return nil
+}
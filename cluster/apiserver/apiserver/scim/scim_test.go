@@ -0,0 +1,704 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rcorev1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/cluster/common/sessionc"
+	"github.com/octelium/octelium/cluster/common/tests"
+	"github.com/octelium/octelium/cluster/common/urscsrv"
+	"github.com/octelium/octelium/cluster/common/vutils"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/octelium/octelium/pkg/common/rgx"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"github.com/octelium/octelium/pkg/utils/utilrand"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestParseFilter(t *testing.T) {
+	{
+		f, err := parseFilter("")
+		assert.Nil(t, err)
+		assert.Nil(t, f)
+	}
+
+	{
+		f, err := parseFilter(`userName eq "john@example.com"`)
+		assert.Nil(t, err)
+		assert.Equal(t, "username", f.attr)
+		assert.Equal(t, "john@example.com", f.value)
+	}
+
+	{
+		f, err := parseFilter(`externalId EQ "abc \"def\""`)
+		assert.Nil(t, err)
+		assert.Equal(t, "externalid", f.attr)
+		assert.Equal(t, `abc "def"`, f.value)
+	}
+
+	invalids := []string{
+		`userName sw "john"`,
+		`userName eq john`,
+		`userName eq "a" and active eq true`,
+	}
+	for _, invalid := range invalids {
+		_, err := parseFilter(invalid)
+		assert.NotNil(t, err, "%s", invalid)
+	}
+}
+
+func TestToResourceName(t *testing.T) {
+	tstCases := []struct {
+		arg    string
+		output string
+	}{
+		{arg: "john.doe@example.com", output: "john-doe-example-com"},
+		{arg: "Engineering Team", output: "engineering-team"},
+		{arg: "1st-group", output: "x-1st-group"},
+		{arg: "a", output: "a-x"},
+		{arg: "--__--Ops--", output: "ops"},
+	}
+
+	for _, tstCase := range tstCases {
+		out, err := toResourceName(tstCase.arg)
+		assert.Nil(t, err)
+		assert.Equal(t, tstCase.output, out)
+		assert.True(t, rgx.NameMain.MatchString(out), "%s", out)
+	}
+
+	{
+		out, err := toResourceName(utilrand.GetRandomStringLowercase(100))
+		assert.Nil(t, err)
+		assert.True(t, rgx.NameMain.MatchString(out))
+	}
+
+	{
+		_, err := toResourceName("@@@")
+		assert.NotNil(t, err)
+	}
+}
+
+func TestParseMembersPath(t *testing.T) {
+	id, ok := parseMembersPath(`members[value eq "2819c223-7f76-453a-919d-413861904646"]`)
+	assert.True(t, ok)
+	assert.Equal(t, "2819c223-7f76-453a-919d-413861904646", id)
+
+	_, ok = parseMembersPath("members")
+	assert.False(t, ok)
+}
+
+func TestRemoveUserAttr(t *testing.T) {
+	usr := &corev1.User{
+		Metadata: &metav1.Metadata{
+			DisplayName: "Alice",
+			Annotations: map[string]string{
+				annotationExternalID: "ext-1",
+			},
+		},
+		Spec: &corev1.User_Spec{
+			Email: "alice@example.com",
+			Info: &corev1.User_Spec_Info{
+				FirstName: "Alice",
+				LastName:  "Smith",
+			},
+		},
+	}
+
+	assert.Nil(t, removeUserAttr(usr, "externalId"))
+	assert.Equal(t, "", usr.Metadata.Annotations[annotationExternalID])
+
+	assert.Nil(t, removeUserAttr(usr, "displayName"))
+	assert.Equal(t, "", usr.Metadata.DisplayName)
+
+	assert.Nil(t, removeUserAttr(usr, "name.familyName"))
+	assert.Equal(t, "Alice", usr.Spec.Info.FirstName)
+	assert.Equal(t, "", usr.Spec.Info.LastName)
+
+	assert.Nil(t, removeUserAttr(usr, `emails[type eq "work"].value`))
+	assert.Equal(t, "", usr.Spec.Email)
+
+	for arg, scimType := range map[string]string{
+		"":         scimTypeNoTarget,
+		"active":   scimTypeMutability,
+		"userName": scimTypeMutability,
+		"groups":   scimTypeMutability,
+		"title":    scimTypeInvalidPath,
+	} {
+		err := removeUserAttr(usr, arg)
+		pErr, ok := err.(*patchError)
+		assert.True(t, ok, "%s", arg)
+		assert.Equal(t, scimType, pErr.scimType, "%s", arg)
+	}
+
+	w := httptest.NewRecorder()
+	writePatchError(w, newPatchError(scimTypeInvalidPath, "Unsupported path: title"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"scimType":"invalidPath"`)
+}
+
+func TestParseBool(t *testing.T) {
+	for arg, expected := range map[string]bool{
+		`true`:    true,
+		`false`:   false,
+		`"False"`: false,
+		`"True"`:  true,
+	} {
+		ret, err := parseBool(json.RawMessage(arg))
+		assert.Nil(t, err)
+		assert.Equal(t, expected, ret)
+	}
+
+	_, err := parseBool(json.RawMessage(`"invalid"`))
+	assert.NotNil(t, err)
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, paginate(items, 1, 100))
+	assert.Equal(t, []int{2, 3}, paginate(items, 2, 2))
+	assert.Equal(t, []int{1}, paginate(items, 0, 1))
+	assert.Nil(t, paginate(items, 6, 1))
+	assert.Equal(t, []int{}, paginate(items, 1, 0))
+}
+
+func TestIsManaged(t *testing.T) {
+	md := &metav1.Metadata{}
+	assert.False(t, isManaged(md))
+
+	setManaged(md)
+	assert.True(t, isManaged(md))
+
+	md.IsSystem = true
+	assert.False(t, isManaged(md))
+
+	assert.False(t, isManaged(&metav1.Metadata{
+		Labels: map[string]string{
+			LabelManaged: "false",
+		},
+	}))
+}
+
+func TestWriteErrorFromErr(t *testing.T) {
+	tstCases := []struct {
+		err        error
+		statusCode int
+	}{
+		{err: grpcutils.NotFound(""), statusCode: http.StatusNotFound},
+		{err: grpcutils.AlreadyExists(""), statusCode: http.StatusConflict},
+		{err: grpcutils.InvalidArg(""), statusCode: http.StatusBadRequest},
+		{err: grpcutils.ResourceChanged(""), statusCode: http.StatusPreconditionFailed},
+		{err: grpcutils.InternalWithErr(errors.New("")), statusCode: http.StatusInternalServerError},
+	}
+
+	for _, tstCase := range tstCases {
+		w := httptest.NewRecorder()
+		writeErrorFromErr(w, tstCase.err)
+		assert.Equal(t, tstCase.statusCode, w.Code, "%+v", tstCase.err)
+	}
+}
+
+func doRequest(t *testing.T, h http.Handler, method, path string, body any, sessUID string) *httptest.ResponseRecorder {
+	var reqBody bytes.Buffer
+	if body != nil {
+		assert.Nil(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+
+	req := httptest.NewRequest(method, fmt.Sprintf("http://localhost%s", path), &reqBody)
+	if sessUID != "" {
+		req.Header.Set("X-Octelium-Session-Uid", sessUID)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestSCIM(t *testing.T) {
+	ctx := context.Background()
+	tst, err := tests.Initialize(nil)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		tst.Destroy()
+	})
+
+	srv := NewServer(tst.C.OcteliumC)
+
+	sessUID := func(labels map[string]string) string {
+		usr, err := srv.adminSrv.CreateUser(ctx, tests.GenUser(nil))
+		assert.Nil(t, err)
+
+		cred, err := srv.adminSrv.CreateCredential(ctx, &corev1.Credential{
+			Metadata: &metav1.Metadata{
+				Name:   utilrand.GetRandomStringCanonical(8),
+				Labels: labels,
+			},
+			Spec: &corev1.Credential_Spec{
+				User:        usr.Metadata.Name,
+				Type:        corev1.Credential_Spec_ACCESS_TOKEN,
+				SessionType: corev1.Session_Status_CLIENTLESS,
+			},
+		})
+		assert.Nil(t, err)
+
+		sess, err := sessionc.CreateSession(ctx, &sessionc.CreateSessionOpts{
+			OcteliumC:     tst.C.OcteliumC,
+			Usr:           usr,
+			SessType:      corev1.Session_Status_CLIENTLESS,
+			CredentialRef: umetav1.GetObjectReference(cred),
+		})
+		assert.Nil(t, err)
+		return sess.Metadata.Uid
+	}
+
+	h := srv.handler()
+
+	{
+		w := doRequest(t, h, http.MethodGet, "/scim/v2/Users", nil, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = doRequest(t, h, http.MethodGet, "/scim/v2/Users", nil, sessUID(nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	}
+
+	adminSess := sessUID(map[string]string{
+		LabelCredential: "true",
+	})
+
+	{
+		usr, err := srv.adminSrv.CreateUser(ctx, tests.GenUser(nil))
+		assert.Nil(t, err)
+
+		w := doRequest(t, h, http.MethodGet, "/scim/v2/Users/"+usr.Metadata.Uid, nil, adminSess)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = doRequest(t, h, http.MethodDelete, "/scim/v2/Users/"+usr.Metadata.Uid, nil, adminSess)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = doRequest(t, h, http.MethodGet, "/scim/v2/Users", nil, adminSess)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), usr.Metadata.Uid)
+
+		_, err = tst.C.OcteliumC.CoreC().GetUser(ctx, &rmetav1.GetOptions{Uid: usr.Metadata.Uid})
+		assert.Nil(t, err)
+	}
+
+	var scimUsr user
+	{
+		w := doRequest(t, h, http.MethodPost, "/scim/v2/Users", map[string]any{
+			"schemas":    []string{schemaUser},
+			"userName":   "john.doe@example.com",
+			"externalId": "ext-1234",
+			"active":     true,
+			"name": map[string]any{
+				"givenName":  "John",
+				"familyName": "Doe",
+			},
+			"emails": []map[string]any{
+				{"value": "John.Doe@example.com", "primary": true},
+			},
+		}, adminSess)
+		assert.Equal(t, http.StatusCreated, w.Code, "%s", w.Body.String())
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &scimUsr))
+		assert.Equal(t, "john.doe@example.com", scimUsr.UserName)
+		assert.Equal(t, "ext-1234", scimUsr.ExternalID)
+		assert.True(t, *scimUsr.Active)
+
+		usr, err := tst.C.OcteliumC.CoreC().GetUser(ctx, &rmetav1.GetOptions{Uid: scimUsr.ID})
+		assert.Nil(t, err)
+		assert.Equal(t, "john-doe-example-com", usr.Metadata.Name)
+		assert.Equal(t, "john.doe@example.com", usr.Spec.Email)
+		assert.Equal(t, "John", usr.Spec.Info.FirstName)
+		assert.Equal(t, corev1.User_Spec_HUMAN, usr.Spec.Type)
+	}
+
+	{
+		w := doRequest(t, h, http.MethodPost, "/scim/v2/Users", map[string]any{
+			"userName": "john.doe@example.com",
+		}, adminSess)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	}
+
+	{
+		w := doRequest(t, h, http.MethodGet,
+			`/scim/v2/Users?filter=userName+eq+%22john.doe%40example.com%22`, nil, adminSess)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp listResponse
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.TotalResults)
+	}
+
+	var scimGrp group
+	{
+		w := doRequest(t, h, http.MethodPost, "/scim/v2/Groups", map[string]any{
+			"schemas":     []string{schemaGroup},
+			"displayName": "Engineering",
+			"members": []map[string]any{
+				{"value": scimUsr.ID},
+			},
+		}, adminSess)
+		assert.Equal(t, http.StatusCreated, w.Code, "%s", w.Body.String())
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &scimGrp))
+		assert.Equal(t, 1, len(scimGrp.Members))
+
+		usr, err := tst.C.OcteliumC.CoreC().GetUser(ctx, &rmetav1.GetOptions{Uid: scimUsr.ID})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"engineering"}, usr.Spec.Groups)
+	}
+
+	{
+		w := doRequest(t, h, http.MethodPatch, "/scim/v2/Groups/"+scimGrp.ID, map[string]any{
+			"schemas": []string{schemaPatchOp},
+			"Operations": []map[string]any{
+				{
+					"op":    "replace",
+					"path":  "displayName",
+					"value": "Engineering Team",
+				},
+				{
+					"op":    "replace",
+					"path":  "externalId",
+					"value": "ext-grp-1",
+				},
+			},
+		}, adminSess)
+		assert.Equal(t, http.StatusOK, w.Code, "%s", w.Body.String())
+
+		grp, err := tst.C.OcteliumC.CoreC().GetGroup(ctx, &rmetav1.GetOptions{Uid: scimGrp.ID})
+		assert.Nil(t, err)
+		assert.Equal(t, "Engineering Team", grp.Metadata.DisplayName)
+		assert.Equal(t, "ext-grp-1", grp.Metadata.Annotations[annotationExternalID])
+	}
+
+	{
+		w := doRequest(t, h, http.MethodPatch, "/scim/v2/Groups/"+scimGrp.ID, map[string]any{
+			"schemas": []string{schemaPatchOp},
+			"Operations": []map[string]any{
+				{
+					"op":   "remove",
+					"path": fmt.Sprintf(`members[value eq "%s"]`, scimUsr.ID),
+				},
+			},
+		}, adminSess)
+		assert.Equal(t, http.StatusOK, w.Code, "%s", w.Body.String())
+
+		usr, err := tst.C.OcteliumC.CoreC().GetUser(ctx, &rmetav1.GetOptions{Uid: scimUsr.ID})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(usr.Spec.Groups))
+	}
+
+	{
+		usr, err := tst.C.OcteliumC.CoreC().GetUser(ctx, &rmetav1.GetOptions{Uid: scimUsr.ID})
+		assert.Nil(t, err)
+
+		_, err = sessionc.CreateSession(ctx, &sessionc.CreateSessionOpts{
+			OcteliumC: tst.C.OcteliumC,
+			Usr:       usr,
+			SessType:  corev1.Session_Status_CLIENTLESS,
+		})
+		assert.Nil(t, err)
+
+		sessList, err := tst.C.OcteliumC.CoreC().ListSession(ctx, urscsrv.FilterByUser(usr))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(sessList.Items))
+
+		w := doRequest(t, h, http.MethodPatch, "/scim/v2/Users/"+scimUsr.ID, map[string]any{
+			"schemas": []string{schemaPatchOp},
+			"Operations": []map[string]any{
+				{
+					"op":    "Replace",
+					"path":  "active",
+					"value": "False",
+				},
+			},
+		}, adminSess)
+		assert.Equal(t, http.StatusOK, w.Code, "%s", w.Body.String())
+
+		usr, err = tst.C.OcteliumC.CoreC().GetUser(ctx, &rmetav1.GetOptions{Uid: scimUsr.ID})
+		assert.Nil(t, err)
+		assert.True(t, usr.Spec.IsDisabled)
+
+		sessList, err = tst.C.OcteliumC.CoreC().ListSession(ctx, urscsrv.FilterByUser(usr))
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(sessList.Items))
+	}
+
+	{
+		w := doRequest(t, h, http.MethodPatch, "/scim/v2/Users/"+scimUsr.ID, map[string]any{
+			"schemas": []string{schemaPatchOp},
+			"Operations": []map[string]any{
+				{
+					"op":   "remove",
+					"path": "displayName",
+				},
+			},
+		}, adminSess)
+		assert.Equal(t, http.StatusOK, w.Code, "%s", w.Body.String())
+
+		usr, err := tst.C.OcteliumC.CoreC().GetUser(ctx, &rmetav1.GetOptions{Uid: scimUsr.ID})
+		assert.Nil(t, err)
+		assert.Equal(t, "", usr.Metadata.DisplayName)
+
+		w = doRequest(t, h, http.MethodPatch, "/scim/v2/Users/"+scimUsr.ID, map[string]any{
+			"schemas": []string{schemaPatchOp},
+			"Operations": []map[string]any{
+				{
+					"op":   "remove",
+					"path": "title",
+				},
+			},
+		}, adminSess)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "%s", w.Body.String())
+		assert.Contains(t, w.Body.String(), "invalidPath")
+	}
+
+	{
+		w := doRequest(t, h, http.MethodDelete, "/scim/v2/Groups/"+scimGrp.ID, nil, adminSess)
+		assert.Equal(t, http.StatusNoContent, w.Code, "%s", w.Body.String())
+
+		w = doRequest(t, h, http.MethodDelete, "/scim/v2/Users/"+scimUsr.ID, nil, adminSess)
+		assert.Equal(t, http.StatusNoContent, w.Code, "%s", w.Body.String())
+
+		w = doRequest(t, h, http.MethodGet, "/scim/v2/Users/"+scimUsr.ID, nil, adminSess)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	}
+}
+
+// fakeCoreC stores the Users and Groups in memory and bumps their
+// resourceVersion upon every update like the real resource server
+type fakeCoreC struct {
+	rcorev1.ResourceServiceClient
+	users        map[string]*corev1.User
+	groups       map[string]*corev1.Group
+	groupUpdates int
+}
+
+func (c *fakeCoreC) GetUser(ctx context.Context,
+	req *rmetav1.GetOptions, opts ...grpc.CallOption) (*corev1.User, error) {
+	for _, usr := range c.users {
+		if usr.Metadata.Uid == req.Uid || usr.Metadata.Name == req.Name {
+			return pbutils.Clone(usr).(*corev1.User), nil
+		}
+	}
+	return nil, grpcutils.NotFound("")
+}
+
+func (c *fakeCoreC) UpdateUser(ctx context.Context,
+	req *corev1.User, opts ...grpc.CallOption) (*corev1.User, error) {
+	req = pbutils.Clone(req).(*corev1.User)
+	req.Metadata.ResourceVersion = bumpResourceVersion(req.Metadata.ResourceVersion)
+	c.users[req.Metadata.Uid] = req
+	return pbutils.Clone(req).(*corev1.User), nil
+}
+
+func (c *fakeCoreC) ListUser(ctx context.Context,
+	req *rmetav1.ListOptions, opts ...grpc.CallOption) (*corev1.UserList, error) {
+	ret := &corev1.UserList{}
+	for _, usr := range c.users {
+		isMatch := true
+		for _, f := range req.Filters {
+			if f.Field == "spec.groups" && !slices.Contains(usr.Spec.Groups, f.Value.GetStringValue()) {
+				isMatch = false
+			}
+		}
+		if isMatch {
+			ret.Items = append(ret.Items, pbutils.Clone(usr).(*corev1.User))
+		}
+	}
+	return ret, nil
+}
+
+func (c *fakeCoreC) GetGroup(ctx context.Context,
+	req *rmetav1.GetOptions, opts ...grpc.CallOption) (*corev1.Group, error) {
+	for _, grp := range c.groups {
+		if grp.Metadata.Uid == req.Uid || grp.Metadata.Name == req.Name {
+			return pbutils.Clone(grp).(*corev1.Group), nil
+		}
+	}
+	return nil, grpcutils.NotFound("")
+}
+
+func (c *fakeCoreC) UpdateGroup(ctx context.Context,
+	req *corev1.Group, opts ...grpc.CallOption) (*corev1.Group, error) {
+	c.groupUpdates++
+	req = pbutils.Clone(req).(*corev1.Group)
+	req.Metadata.ResourceVersion = bumpResourceVersion(req.Metadata.ResourceVersion)
+	c.groups[req.Metadata.Uid] = req
+	return pbutils.Clone(req).(*corev1.Group), nil
+}
+
+func bumpResourceVersion(rv string) string {
+	n, _ := strconv.Atoi(rv)
+	return strconv.Itoa(n + 1)
+}
+
+type fakeOcteliumC struct {
+	octeliumc.ClientInterface
+	coreC *fakeCoreC
+}
+
+func (c *fakeOcteliumC) CoreC() rcorev1.ResourceServiceClient {
+	return c.coreC
+}
+
+// TestSCIMResourceVersion checks that the SCIM handlers that update the same
+// Resource more than once per request always pass the latest resourceVersion
+// to the embedded admin server, which rejects stale updates.
+func TestSCIMResourceVersion(t *testing.T) {
+	ctx := context.Background()
+
+	usr := &corev1.User{
+		ApiVersion: "core/v1",
+		Kind:       "User",
+		Metadata: &metav1.Metadata{
+			Uid:             vutils.UUIDv4(),
+			Name:            "alice",
+			ResourceVersion: "1",
+			Labels: map[string]string{
+				LabelManaged: "true",
+			},
+		},
+		Spec: &corev1.User_Spec{
+			Type: corev1.User_Spec_HUMAN,
+		},
+		Status: &corev1.User_Status{},
+	}
+	grp := &corev1.Group{
+		ApiVersion: "core/v1",
+		Kind:       "Group",
+		Metadata: &metav1.Metadata{
+			Uid:             vutils.UUIDv4(),
+			Name:            "engineering",
+			ResourceVersion: "1",
+			Labels: map[string]string{
+				LabelManaged: "true",
+			},
+		},
+		Spec:   &corev1.Group_Spec{},
+		Status: &corev1.Group_Status{},
+	}
+
+	coreC := &fakeCoreC{
+		users: map[string]*corev1.User{
+			usr.Metadata.Uid: usr,
+		},
+		groups: map[string]*corev1.Group{
+			grp.Metadata.Uid: grp,
+		},
+	}
+	srv := NewServer(&fakeOcteliumC{
+		coreC: coreC,
+	})
+	h := srv.getMux()
+
+	{
+		w := doRequest(t, h, http.MethodPatch, "/scim/v2/Groups/"+grp.Metadata.Uid, map[string]any{
+			"schemas": []string{schemaPatchOp},
+			"Operations": []map[string]any{
+				{
+					"op":    "replace",
+					"path":  "displayName",
+					"value": "Engineering Team",
+				},
+				{
+					"op":   "add",
+					"path": "members",
+					"value": []map[string]any{
+						{"value": usr.Metadata.Uid},
+					},
+				},
+				{
+					"op":    "replace",
+					"path":  "externalId",
+					"value": "ext-grp-1",
+				},
+				{
+					"op": "replace",
+					"value": map[string]any{
+						"displayName": "Engineering",
+					},
+				},
+			},
+		}, "")
+		assert.Equal(t, http.StatusOK, w.Code, "%s", w.Body.String())
+		assert.Equal(t, 1, coreC.groupUpdates)
+
+		stored := coreC.groups[grp.Metadata.Uid]
+		assert.Equal(t, "Engineering", stored.Metadata.DisplayName)
+		assert.Equal(t, "ext-grp-1", stored.Metadata.Annotations[annotationExternalID])
+		assert.Equal(t, []string{"engineering"}, coreC.users[usr.Metadata.Uid].Spec.Groups)
+	}
+
+	{
+		w := doRequest(t, h, http.MethodPut, "/scim/v2/Groups/"+grp.Metadata.Uid, map[string]any{
+			"schemas":     []string{schemaGroup},
+			"displayName": "Engineering",
+			"members":     []map[string]any{},
+		}, "")
+		assert.Equal(t, http.StatusOK, w.Code, "%s", w.Body.String())
+		assert.Equal(t, 0, len(coreC.users[usr.Metadata.Uid].Spec.Groups))
+	}
+
+	{
+		w := doRequest(t, h, http.MethodPatch, "/scim/v2/Users/"+usr.Metadata.Uid, map[string]any{
+			"schemas": []string{schemaPatchOp},
+			"Operations": []map[string]any{
+				{
+					"op":    "replace",
+					"path":  "displayName",
+					"value": "Alice",
+				},
+				{
+					"op":    "replace",
+					"path":  "externalId",
+					"value": "ext-usr-1",
+				},
+			},
+		}, "")
+		assert.Equal(t, http.StatusOK, w.Code, "%s", w.Body.String())
+		assert.Equal(t, "Alice", coreC.users[usr.Metadata.Uid].Metadata.DisplayName)
+	}
+
+	{
+		stale, err := coreC.GetGroup(ctx, &rmetav1.GetOptions{Uid: grp.Metadata.Uid})
+		assert.Nil(t, err)
+
+		_, err = srv.adminSrv.UpdateGroup(ctx, pbutils.Clone(stale).(*corev1.Group))
+		assert.Nil(t, err)
+
+		_, err = srv.adminSrv.UpdateGroup(ctx, stale)
+		assert.True(t, grpcerr.IsResourceChanged(err))
+	}
+}
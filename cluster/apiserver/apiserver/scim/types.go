@@ -0,0 +1,305 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/pkg/errors"
+)
+
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+const (
+	annotationUserName   = "scim-username"
+	annotationExternalID = "scim-external-id"
+	maxAnnotationLen     = 63
+	maxListResults       = 1000
+	maxNameLen           = 40
+)
+
+type user struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	UserName    string        `json:"userName,omitempty"`
+	DisplayName string        `json:"displayName,omitempty"`
+	Name        *name         `json:"name,omitempty"`
+	Emails      []multiValue  `json:"emails,omitempty"`
+	Active      *bool         `json:"active,omitempty"`
+	Groups      []memberValue `json:"groups,omitempty"`
+	Meta        *meta         `json:"meta,omitempty"`
+}
+
+type name struct {
+	GivenName  string `json:"givenName,omitempty"`
+	MiddleName string `json:"middleName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type multiValue struct {
+	Value   string `json:"value,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type memberValue struct {
+	Value   string `json:"value,omitempty"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type group struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	DisplayName string        `json:"displayName,omitempty"`
+	Members     []memberValue `json:"members,omitempty"`
+	Meta        *meta         `json:"meta,omitempty"`
+}
+
+type meta struct {
+	ResourceType string `json:"resourceType,omitempty"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Version      string `json:"version,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type listResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func getMeta(md *metav1.Metadata, resourceType string) *meta {
+	ret := &meta{
+		ResourceType: resourceType,
+		Version:      fmt.Sprintf(`W/"%s"`, md.ResourceVersion),
+		Location:     fmt.Sprintf("/scim/v2/%ss/%s", resourceType, md.Uid),
+	}
+
+	if md.CreatedAt.IsValid() {
+		ret.Created = md.CreatedAt.AsTime().UTC().Format("2006-01-02T15:04:05Z")
+	}
+
+	switch {
+	case md.UpdatedAt.IsValid():
+		ret.LastModified = md.UpdatedAt.AsTime().UTC().Format("2006-01-02T15:04:05Z")
+	default:
+		ret.LastModified = ret.Created
+	}
+
+	return ret
+}
+
+var rgxNameInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// toResourceName converts an arbitrary SCIM identifier such as a userName
+// or a Group displayName to a valid Octelium resource name.
+func toResourceName(arg string) (string, error) {
+	ret := rgxNameInvalidChars.ReplaceAllString(strings.ToLower(arg), "-")
+	ret = strings.Trim(ret, "-")
+	if ret == "" {
+		return "", errors.Errorf("Could not derive a valid name from: %s", arg)
+	}
+
+	if ret[0] < 'a' || ret[0] > 'z' {
+		ret = "x-" + ret
+	}
+
+	if len(ret) > maxNameLen {
+		ret = strings.TrimRight(ret[:maxNameLen], "-")
+	}
+
+	if len(ret) < 2 {
+		ret = ret + "-x"
+	}
+
+	return ret, nil
+}
+
+func setAnnotation(md *metav1.Metadata, key, val string) error {
+	if val == "" {
+		delete(md.Annotations, key)
+		return nil
+	}
+
+	if len(val) > maxAnnotationLen {
+		return errors.Errorf("%s is too long", key)
+	}
+
+	if md.Annotations == nil {
+		md.Annotations = make(map[string]string)
+	}
+
+	md.Annotations[key] = val
+	return nil
+}
+
+func parseBool(arg json.RawMessage) (bool, error) {
+	var ret bool
+	if err := json.Unmarshal(arg, &ret); err == nil {
+		return ret, nil
+	}
+
+	// Some IdPs (e.g. Entra ID) send booleans as strings such as "False"
+	var str string
+	if err := json.Unmarshal(arg, &str); err != nil {
+		return false, errors.Errorf("Invalid boolean value")
+	}
+
+	return strconv.ParseBool(strings.ToLower(str))
+}
+
+func parseString(arg json.RawMessage) (string, error) {
+	var ret string
+	if err := json.Unmarshal(arg, &ret); err != nil {
+		return "", errors.Errorf("Invalid string value")
+	}
+	return ret, nil
+}
+
+type filter struct {
+	attr  string
+	value string
+}
+
+var rgxFilter = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9.$]*)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// parseFilter parses the simple `attr eq "value"` filter expressions which
+// are, in practice, the only filters sent by the major IdPs while
+// provisioning.
+func parseFilter(arg string) (*filter, error) {
+	if arg == "" {
+		return nil, nil
+	}
+
+	m := rgxFilter.FindStringSubmatch(arg)
+	if m == nil {
+		return nil, errors.Errorf("Unsupported filter: %s", arg)
+	}
+
+	val, err := strconv.Unquote(fmt.Sprintf(`"%s"`, m[2]))
+	if err != nil {
+		return nil, errors.Errorf("Invalid filter value: %s", m[2])
+	}
+
+	return &filter{
+		attr:  strings.ToLower(m[1]),
+		value: val,
+	}, nil
+}
+
+var rgxMembersPath = regexp.MustCompile(`^\s*(?i:members)\s*\[\s*(?i:value)\s+(?i:eq)\s+"([^"]+)"\s*\]\s*$`)
+
+// parseMembersPath returns the member ID of a path such as
+// `members[value eq "<id>"]` which is used to remove a single member.
+func parseMembersPath(arg string) (string, bool) {
+	m := rgxMembersPath.FindStringSubmatch(arg)
+	if m == nil {
+		return "", false
+	}
+
+	return m[1], true
+}
+
+func paginate[T any](items []T, startIndex, count int) []T {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+
+	if startIndex > len(items) {
+		return nil
+	}
+
+	items = items[startIndex-1:]
+	if count >= 0 && count < len(items) {
+		items = items[:count]
+	}
+
+	return items
+}
+
+type listParams struct {
+	filter     *filter
+	startIndex int
+	count      int
+}
+
+func getListParams(query map[string][]string) (*listParams, error) {
+	ret := &listParams{
+		startIndex: 1,
+		count:      maxListResults,
+	}
+
+	get := func(key string) string {
+		if vals := query[key]; len(vals) > 0 {
+			return vals[0]
+		}
+		return ""
+	}
+
+	if val := get("startIndex"); val != "" {
+		idx, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, errors.Errorf("Invalid startIndex")
+		}
+		ret.startIndex = idx
+	}
+
+	if val := get("count"); val != "" {
+		count, err := strconv.Atoi(val)
+		if err != nil || count < 0 {
+			return nil, errors.Errorf("Invalid count")
+		}
+		ret.count = min(count, maxListResults)
+	}
+
+	f, err := parseFilter(get("filter"))
+	if err != nil {
+		return nil, err
+	}
+	ret.filter = f
+
+	return ret, nil
+}
@@ -0,0 +1,514 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/urscsrv"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+func (s *Server) toSCIMUser(ctx context.Context, usr *corev1.User) *user {
+	ret := &user{
+		Schemas:     []string{schemaUser},
+		ID:          usr.Metadata.Uid,
+		UserName:    usr.Metadata.Name,
+		DisplayName: usr.Metadata.DisplayName,
+		Active:      new(!usr.Spec.IsDisabled),
+		Meta:        getMeta(usr.Metadata, "User"),
+	}
+
+	if val := usr.Metadata.Annotations[annotationUserName]; val != "" {
+		ret.UserName = val
+	}
+	ret.ExternalID = usr.Metadata.Annotations[annotationExternalID]
+
+	if info := usr.Spec.Info; info != nil &&
+		(info.FirstName != "" || info.MiddleName != "" || info.LastName != "") {
+		ret.Name = &name{
+			GivenName:  info.FirstName,
+			MiddleName: info.MiddleName,
+			FamilyName: info.LastName,
+		}
+	}
+
+	if usr.Spec.Email != "" {
+		ret.Emails = []multiValue{
+			{
+				Value:   usr.Spec.Email,
+				Type:    "work",
+				Primary: true,
+			},
+		}
+	}
+
+	for _, g := range usr.Spec.Groups {
+		grp, err := s.octeliumC.CoreC().GetGroup(ctx, &rmetav1.GetOptions{Name: g})
+		if err != nil {
+			zap.L().Debug("Could not get the Group of the User", zap.String("group", g), zap.Error(err))
+			continue
+		}
+
+		ret.Groups = append(ret.Groups, memberValue{
+			Value:   grp.Metadata.Uid,
+			Display: groupDisplayName(grp),
+			Ref:     getMeta(grp.Metadata, "Group").Location,
+		})
+	}
+
+	return ret
+}
+
+// setUserAttr sets a single SCIM User attribute on the Octelium User. It is
+// shared between POST/PUT, where the whole resource is set, and PATCH, where
+// attributes are set individually.
+func setUserAttr(usr *corev1.User, attr string, val json.RawMessage) error {
+	if usr.Spec.Info == nil {
+		usr.Spec.Info = &corev1.User_Spec_Info{}
+	}
+
+	switch strings.ToLower(attr) {
+	case "active":
+		active, err := parseBool(val)
+		if err != nil {
+			return err
+		}
+		usr.Spec.IsDisabled = !active
+	case "username":
+		userName, err := parseString(val)
+		if err != nil {
+			return err
+		}
+		return setAnnotation(usr.Metadata, annotationUserName, userName)
+	case "externalid":
+		externalID, err := parseString(val)
+		if err != nil {
+			return err
+		}
+		return setAnnotation(usr.Metadata, annotationExternalID, externalID)
+	case "displayname":
+		displayName, err := parseString(val)
+		if err != nil {
+			return err
+		}
+		usr.Metadata.DisplayName = displayName
+	case "name":
+		var n name
+		if err := json.Unmarshal(val, &n); err != nil {
+			return errors.Errorf("Invalid name")
+		}
+		usr.Spec.Info.FirstName = n.GivenName
+		usr.Spec.Info.MiddleName = n.MiddleName
+		usr.Spec.Info.LastName = n.FamilyName
+	case "name.givenname":
+		return setString(val, &usr.Spec.Info.FirstName)
+	case "name.middlename":
+		return setString(val, &usr.Spec.Info.MiddleName)
+	case "name.familyname":
+		return setString(val, &usr.Spec.Info.LastName)
+	case "emails":
+		var emails []multiValue
+		if err := json.Unmarshal(val, &emails); err != nil {
+			return errors.Errorf("Invalid emails")
+		}
+		usr.Spec.Email = getPrimaryEmail(emails)
+	case `emails[type eq "work"].value`, `emails[primary eq true].value`:
+		email, err := parseString(val)
+		if err != nil {
+			return err
+		}
+		usr.Spec.Email = strings.ToLower(email)
+	default:
+		zap.L().Debug("Ignoring unsupported SCIM User attribute", zap.String("attr", attr))
+	}
+
+	return nil
+}
+
+// removeUserAttr unsets a User attribute for a PATCH remove operation. As
+// specified by RFC 7644 section 3.5.2.2, a missing path, a path to a required
+// or a read-only attribute and an unsupported path are rejected.
+func removeUserAttr(usr *corev1.User, attr string) error {
+	if usr.Spec.Info == nil {
+		usr.Spec.Info = &corev1.User_Spec_Info{}
+	}
+
+	switch strings.ToLower(attr) {
+	case "":
+		return newPatchError(scimTypeNoTarget, "A remove operation requires a path")
+	case "active", "username", "id", "groups", "meta", "schemas":
+		return newPatchError(scimTypeMutability, "The attribute cannot be removed: "+attr)
+	case "externalid":
+		delete(usr.Metadata.Annotations, annotationExternalID)
+	case "displayname":
+		usr.Metadata.DisplayName = ""
+	case "name":
+		usr.Spec.Info.FirstName = ""
+		usr.Spec.Info.MiddleName = ""
+		usr.Spec.Info.LastName = ""
+	case "name.givenname":
+		usr.Spec.Info.FirstName = ""
+	case "name.middlename":
+		usr.Spec.Info.MiddleName = ""
+	case "name.familyname":
+		usr.Spec.Info.LastName = ""
+	case "emails", `emails[type eq "work"]`, `emails[primary eq true]`,
+		`emails[type eq "work"].value`, `emails[primary eq true].value`:
+		usr.Spec.Email = ""
+	default:
+		return newPatchError(scimTypeInvalidPath, "Unsupported path: "+attr)
+	}
+
+	return nil
+}
+
+func setString(val json.RawMessage, to *string) error {
+	ret, err := parseString(val)
+	if err != nil {
+		return err
+	}
+	*to = ret
+	return nil
+}
+
+func getPrimaryEmail(emails []multiValue) string {
+	for _, email := range emails {
+		if email.Primary {
+			return strings.ToLower(email.Value)
+		}
+	}
+
+	if len(emails) > 0 {
+		return strings.ToLower(emails[0].Value)
+	}
+
+	return ""
+}
+
+func setUserAttrs(usr *corev1.User, attrs map[string]json.RawMessage) error {
+	for k, v := range attrs {
+		switch strings.ToLower(k) {
+		case "schemas", "id", "meta", "groups":
+			continue
+		}
+
+		if err := setUserAttr(usr, k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) listUsers(ctx context.Context) ([]*corev1.User, error) {
+	usrList, err := s.octeliumC.CoreC().ListUser(ctx, &rmetav1.ListOptions{
+		Filters: []*rmetav1.ListOptions_Filter{
+			urscsrv.FilterFieldBooleanFalse("metadata.isSystem"),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*corev1.User
+	for _, usr := range usrList.Items {
+		if usr.Spec.Type == corev1.User_Spec_HUMAN && isManaged(usr.Metadata) {
+			ret = append(ret, usr)
+		}
+	}
+
+	return ret, nil
+}
+
+func userMatchesFilter(usr *corev1.User, f *filter) bool {
+	if f == nil {
+		return true
+	}
+
+	switch f.attr {
+	case "id":
+		return usr.Metadata.Uid == f.value
+	case "username":
+		if val := usr.Metadata.Annotations[annotationUserName]; val != "" {
+			return strings.EqualFold(val, f.value)
+		}
+		return usr.Metadata.Name == f.value
+	case "externalid":
+		return usr.Metadata.Annotations[annotationExternalID] == f.value
+	case "emails", "emails.value":
+		return usr.Spec.Email != "" && strings.EqualFold(usr.Spec.Email, f.value)
+	case "displayname":
+		return usr.Metadata.DisplayName == f.value
+	default:
+		return false
+	}
+}
+
+func (s *Server) getUser(ctx context.Context, id string) (*corev1.User, error) {
+	if !govalidator.IsUUIDv4(id) {
+		return nil, grpcutils.NotFound("User not found")
+	}
+
+	usr, err := s.octeliumC.CoreC().GetUser(ctx, &rmetav1.GetOptions{Uid: id})
+	if err != nil {
+		return nil, err
+	}
+
+	if !isManaged(usr.Metadata) || usr.Spec.Type != corev1.User_Spec_HUMAN {
+		return nil, grpcutils.NotFound("User not found")
+	}
+
+	return usr, nil
+}
+
+func (s *Server) handleListUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params, err := getListParams(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	usrs, err := s.listUsers(ctx)
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	var matched []*corev1.User
+	for _, usr := range usrs {
+		if userMatchesFilter(usr, params.filter) {
+			matched = append(matched, usr)
+		}
+	}
+
+	resp := &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: len(matched),
+		StartIndex:   params.startIndex,
+		Resources:    []any{},
+	}
+
+	for _, usr := range paginate(matched, params.startIndex, params.count) {
+		resp.Resources = append(resp.Resources, s.toSCIMUser(ctx, usr))
+	}
+	resp.ItemsPerPage = len(resp.Resources)
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	usr, err := s.getUser(ctx, r.PathValue("id"))
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.toSCIMUser(ctx, usr))
+}
+
+func (s *Server) handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var attrs map[string]json.RawMessage
+	if err := decodeBody(r, &attrs); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userName, err := parseString(attrs["userName"])
+	if err != nil || userName == "" {
+		writeError(w, http.StatusBadRequest, "userName is required")
+		return
+	}
+
+	rscName, err := toResourceName(userName)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	usr := &corev1.User{
+		Metadata: &metav1.Metadata{
+			Name: rscName,
+		},
+		Spec: &corev1.User_Spec{
+			Type: corev1.User_Spec_HUMAN,
+		},
+	}
+	setManaged(usr.Metadata)
+
+	if err := setUserAttrs(usr, attrs); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	usr, err = s.adminSrv.CreateUser(ctx, usr)
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	zap.L().Info("SCIM: created User", zap.String("name", usr.Metadata.Name))
+
+	writeJSON(w, http.StatusCreated, s.toSCIMUser(ctx, usr))
+}
+
+func (s *Server) handleReplaceUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	usr, err := s.getUser(ctx, r.PathValue("id"))
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	var attrs map[string]json.RawMessage
+	if err := decodeBody(r, &attrs); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// PUT replaces the whole resource so the attributes that are not set in
+	// the request are reset
+	usr.Metadata.DisplayName = ""
+	usr.Spec.Info = nil
+	usr.Spec.Email = ""
+	delete(usr.Metadata.Annotations, annotationExternalID)
+
+	if err := setUserAttrs(usr, attrs); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	usr, err = s.updateUser(ctx, usr)
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.toSCIMUser(ctx, usr))
+}
+
+func (s *Server) handlePatchUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	usr, err := s.getUser(ctx, r.PathValue("id"))
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	var req patchRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, op := range req.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+		case "remove":
+			if err := removeUserAttr(usr, strings.TrimSpace(op.Path)); err != nil {
+				writePatchError(w, err)
+				return
+			}
+			continue
+		default:
+			writeError(w, http.StatusBadRequest, "Invalid patch op: "+op.Op)
+			return
+		}
+
+		if op.Path == "" {
+			var attrs map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				writeError(w, http.StatusBadRequest, "Invalid patch value")
+				return
+			}
+			if err := setUserAttrs(usr, attrs); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		} else if err := setUserAttr(usr, op.Path, op.Value); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	usr, err = s.updateUser(ctx, usr)
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.toSCIMUser(ctx, usr))
+}
+
+// updateUser updates the User via the API server logic and revokes all of its
+// Sessions if the User is deactivated.
+func (s *Server) updateUser(ctx context.Context, usr *corev1.User) (*corev1.User, error) {
+	ret, err := s.adminSrv.UpdateUser(ctx, usr)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret.Spec.IsDisabled {
+		if err := s.revokeUserSessions(ctx, ret); err != nil {
+			return nil, err
+		}
+		zap.L().Info("SCIM: deactivated User", zap.String("name", ret.Metadata.Name))
+	}
+
+	return ret, nil
+}
+
+func (s *Server) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	usr, err := s.getUser(ctx, r.PathValue("id"))
+	if err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	if _, err := s.adminSrv.DeleteUser(ctx, &metav1.DeleteOptions{Uid: usr.Metadata.Uid}); err != nil {
+		writeErrorFromErr(w, err)
+		return
+	}
+
+	if err := s.revokeUserSessions(ctx, usr); err != nil && !grpcerr.IsNotFound(err) {
+		zap.L().Warn("Could not revoke the Sessions of the deleted User", zap.Error(err))
+	}
+
+	zap.L().Info("SCIM: deleted User", zap.String("name", usr.Metadata.Name))
+
+	w.WriteHeader(http.StatusNoContent)
+}
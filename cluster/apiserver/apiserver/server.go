@@ -26,11 +26,14 @@ import (
 	"github.com/octelium/octelium/cluster/apiserver/apiserver/admin"
 	"github.com/octelium/octelium/cluster/apiserver/apiserver/user"
 	"github.com/octelium/octelium/cluster/common/commoninit"
+	"github.com/octelium/octelium/cluster/common/geoipctl"
 	"github.com/octelium/octelium/cluster/common/healthcheck"
 	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/cluster/common/octovigilc"
 	"github.com/octelium/octelium/cluster/common/userctx"
 	"github.com/octelium/octelium/cluster/common/vutils"
 	"github.com/octelium/octelium/cluster/common/watchers"
+	"github.com/octelium/octelium/pkg/common/pbutils"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
@@ -57,11 +60,57 @@ func Run(ctx context.Context) error {
 		return err
 	}
 
+	octovigilC, err := octovigilc.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+
 	srv := admin.NewServer(&admin.Opts{
-		OcteliumC: octeliumC,
+		OcteliumC:  octeliumC,
+		OctovigilC: octovigilC,
 	})
 	usrSrv := user.NewServer(octeliumC)
 
+	geoipCtl, err := geoipctl.New(ctx, &geoipctl.Opts{
+		OcteliumC: octeliumC,
+	})
+	if err != nil {
+		return err
+	}
+
+	setGeoIP := func(ctx context.Context, cc *corev1.ClusterConfig) {
+		if cc.Spec.Authentication != nil && cc.Spec.Authentication.Geolocation != nil &&
+			cc.Spec.Authentication.Geolocation.GetMmdb() != nil {
+			if err := geoipCtl.Set(ctx, cc.Spec.Authentication.Geolocation.GetMmdb()); err != nil {
+				zap.L().Warn("Could not set geoipCtl", zap.Error(err))
+			}
+		}
+	}
+
+	{
+		cc, err := octeliumC.CoreV1Utils().GetClusterConfig(ctx)
+		if err != nil {
+			return err
+		}
+		setGeoIP(ctx, cc)
+
+		if err := geoipCtl.Run(ctx); err != nil {
+			return err
+		}
+
+		if err := watchers.NewCoreV1(octeliumC).ClusterConfig(ctx, nil,
+			func(ctx context.Context, new, old *corev1.ClusterConfig) error {
+				if !pbutils.IsEqual(new.Spec.Authentication, old.Spec.Authentication) {
+					setGeoIP(ctx, new)
+				}
+				return nil
+			}); err != nil {
+			return err
+		}
+	}
+
+	usrSrv.SetGeoIPCtl(geoipCtl)
+
 	gwCtl := gwcontroller.NewController(octeliumC, usrSrv.ConnServer())
 	svcCtl := svccontroller.NewController(octeliumC, usrSrv.ConnServer())
 	sessCtl := sesscontroller.NewController(octeliumC, usrSrv.ConnServer())
@@ -0,0 +1,137 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package user
+
+import (
+	"context"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/serr"
+	"github.com/octelium/octelium/cluster/common/accessrequest"
+	"github.com/octelium/octelium/cluster/common/apivalidation"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/urscsrv"
+	"github.com/octelium/octelium/cluster/common/userctx"
+)
+
+func (s *Server) CreateAccessRequest(ctx context.Context, req *userv1.AccessRequest) (*userv1.AccessRequest, error) {
+	i, err := userctx.GetUserCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Spec == nil {
+		return nil, grpcutils.InvalidArg("You must provide spec")
+	}
+
+	ar, err := accessrequest.Create(ctx, s.octeliumC, i.User, &corev1.AccessRequest_Spec{
+		Service:       req.Spec.Service,
+		Namespace:     req.Spec.Namespace,
+		Duration:      req.Spec.Duration,
+		Justification: req.Spec.Justification,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return AccessRequestTo(ar), nil
+}
+
+func (s *Server) ListAccessRequest(ctx context.Context, req *userv1.ListAccessRequestOptions) (*userv1.AccessRequestList, error) {
+	i, err := userctx.GetUserCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var filters []*rmetav1.ListOptions_Filter
+	if req.ToDecide {
+		if err := s.checkIsAccessRequestApprover(ctx, i); err != nil {
+			return nil, err
+		}
+
+		filters = append(filters,
+			urscsrv.FilterFieldEQValStr("status.state", corev1.AccessRequest_Status_PENDING.String()))
+	} else {
+		filters = append(filters, urscsrv.FilterStatusUserUID(i.User.Metadata.Uid))
+	}
+
+	arList, err := s.octeliumC.CoreC().ListAccessRequest(ctx, urscsrv.GetUserPublicListOptions(req, filters...))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &userv1.AccessRequestList{
+		ApiVersion:       "user/v1",
+		Kind:             "AccessRequestList",
+		ListResponseMeta: arList.ListResponseMeta,
+	}
+	for _, ar := range arList.Items {
+		ret.Items = append(ret.Items, AccessRequestTo(ar))
+	}
+
+	return ret, nil
+}
+
+func (s *Server) ApproveAccessRequest(ctx context.Context, req *userv1.ApproveAccessRequestRequest) (*userv1.AccessRequest, error) {
+	return s.doDecideAccessRequest(ctx, req.GetName(), true, req.GetReason())
+}
+
+func (s *Server) DenyAccessRequest(ctx context.Context, req *userv1.DenyAccessRequestRequest) (*userv1.AccessRequest, error) {
+	return s.doDecideAccessRequest(ctx, req.GetName(), false, req.GetReason())
+}
+
+func (s *Server) doDecideAccessRequest(ctx context.Context, name string, isApproved bool, reason string) (*userv1.AccessRequest, error) {
+	i, err := userctx.GetUserCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apivalidation.ValidateName(name, 0, 0); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkIsAccessRequestApprover(ctx, i); err != nil {
+		return nil, err
+	}
+
+	ar, err := s.octeliumC.CoreC().GetAccessRequest(ctx, &rmetav1.GetOptions{Name: name})
+	if err != nil {
+		return nil, serr.K8sNotFoundOrInternalWithErr(err)
+	}
+
+	ar, err = accessrequest.Decide(ctx, s.octeliumC, ar, i.User, isApproved, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return AccessRequestTo(ar), nil
+}
+
+func (s *Server) checkIsAccessRequestApprover(ctx context.Context, i *userctx.UserCtx) error {
+	cc, err := s.octeliumC.CoreV1Utils().GetClusterConfig(ctx)
+	if err != nil {
+		return serr.InternalWithErr(err)
+	}
+
+	if !accessrequest.IsApprover(cc, i.Groups) {
+		return grpcutils.Unauthorized("You are not an approver of AccessRequests")
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/cluster/common/tests"
+	"github.com/octelium/octelium/cluster/common/tests/tstuser"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessRequest(t *testing.T) {
+	ctx := context.Background()
+
+	tst, err := tests.Initialize(nil)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		tst.Destroy()
+	})
+	usrSrv, adminSrv := newFakeServers(tst.C)
+
+	grp, err := adminSrv.CreateGroup(ctx, tests.GenGroup())
+	assert.Nil(t, err, "%+v", err)
+
+	svc, err := adminSrv.CreateService(ctx, tests.GenService(""))
+	assert.Nil(t, err, "%+v", err)
+
+	{
+		cc, err := tst.C.OcteliumC.CoreV1Utils().GetClusterConfig(ctx)
+		assert.Nil(t, err, "%+v", err)
+		cc.Spec.AccessRequest = &corev1.ClusterConfig_Spec_AccessRequest{
+			ApproverGroups: []string{grp.Metadata.Name},
+		}
+		_, err = tst.C.OcteliumC.CoreC().UpdateClusterConfig(ctx, cc)
+		assert.Nil(t, err, "%+v", err)
+	}
+
+	usrT, err := tstuser.NewUser(tst.C.OcteliumC, adminSrv, usrSrv, nil)
+	assert.Nil(t, err, "%+v", err)
+
+	approverT, err := tstuser.NewUser(tst.C.OcteliumC, adminSrv, usrSrv, []string{grp.Metadata.Name})
+	assert.Nil(t, err, "%+v", err)
+
+	{
+		_, err := usrSrv.CreateAccessRequest(usrT.Ctx(), &userv1.AccessRequest{
+			Spec: &userv1.AccessRequest_Spec{
+				Service: svc.Metadata.Name,
+			},
+		})
+		assert.NotNil(t, err)
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+
+	ar, err := usrSrv.CreateAccessRequest(usrT.Ctx(), &userv1.AccessRequest{
+		Spec: &userv1.AccessRequest_Spec{
+			Service:       svc.Metadata.Name,
+			Justification: "Investigating an incident",
+		},
+	})
+	assert.Nil(t, err, "%+v", err)
+	assert.Equal(t, userv1.AccessRequest_Status_PENDING, ar.Status.State)
+	assert.Equal(t, usrT.Usr.Metadata.Name, ar.Status.User)
+
+	{
+		itmList, err := usrSrv.ListAccessRequest(usrT.Ctx(), &userv1.ListAccessRequestOptions{})
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, 1, len(itmList.Items))
+		assert.Equal(t, ar.Metadata.Name, itmList.Items[0].Metadata.Name)
+	}
+
+	{
+		_, err := usrSrv.ListAccessRequest(usrT.Ctx(), &userv1.ListAccessRequestOptions{
+			ToDecide: true,
+		})
+		assert.NotNil(t, err)
+		assert.True(t, grpcerr.IsUnauthorized(err))
+
+		itmList, err := usrSrv.ListAccessRequest(approverT.Ctx(), &userv1.ListAccessRequestOptions{
+			ToDecide: true,
+		})
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, 1, len(itmList.Items))
+	}
+
+	{
+		_, err := usrSrv.ApproveAccessRequest(usrT.Ctx(), &userv1.ApproveAccessRequestRequest{
+			Name: ar.Metadata.Name,
+		})
+		assert.NotNil(t, err)
+		assert.True(t, grpcerr.IsUnauthorized(err))
+	}
+
+	{
+		res, err := usrSrv.ApproveAccessRequest(approverT.Ctx(), &userv1.ApproveAccessRequestRequest{
+			Name:   ar.Metadata.Name,
+			Reason: "Approved",
+		})
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, userv1.AccessRequest_Status_APPROVED, res.Status.State)
+		assert.Equal(t, approverT.Usr.Metadata.Name, res.Status.Approver)
+		assert.Equal(t, "Approved", res.Status.Reason)
+	}
+
+	{
+		_, err := usrSrv.DenyAccessRequest(approverT.Ctx(), &userv1.DenyAccessRequestRequest{
+			Name: ar.Metadata.Name,
+		})
+		assert.NotNil(t, err)
+		assert.True(t, grpcerr.IsInvalidArg(err))
+	}
+}
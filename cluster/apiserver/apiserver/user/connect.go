@@ -29,6 +29,7 @@ import (
 	"github.com/octelium/octelium/cluster/apiserver/apiserver/serr"
 	"github.com/octelium/octelium/cluster/common/apivalidation"
 	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/sessionrisk"
 	"github.com/octelium/octelium/cluster/common/upstream"
 	"github.com/octelium/octelium/cluster/common/userctx"
 	"github.com/octelium/octelium/cluster/common/vutils"
@@ -180,6 +181,10 @@ func (s *Server) DoInitConnect(ctx context.Context, req *userv1.ConnectRequest_I
 	hasV4 := req.L3Mode == userv1.ConnectRequest_Initialize_V4 || req.L3Mode == userv1.ConnectRequest_Initialize_BOTH
 	hasV6 := req.L3Mode == userv1.ConnectRequest_Initialize_V6 || req.L3Mode == userv1.ConnectRequest_Initialize_BOTH
 
+	if err := s.checkSessionRisk(ctx, cc, sess); err != nil {
+		return nil, err
+	}
+
 	if hasV4 && !ucorev1.ToClusterConfig(cc).HasV4() {
 		return nil, serr.InvalidArg("The Cluster does not support v4 only networking")
 	}
@@ -452,6 +457,45 @@ func (s *Server) DoInitConnect(ctx context.Context, req *userv1.ConnectRequest_I
 	return connState, nil
 }
 
+// checkSessionRisk evaluates the new connection against the Session's history
+// and applies the action required by the Session's risk score
+func (s *Server) checkSessionRisk(ctx context.Context, cc *corev1.ClusterConfig, sess *corev1.Session) error {
+	if !sessionrisk.IsEnabled(cc) {
+		return nil
+	}
+
+	obs := &sessionrisk.Observation{
+		At:        time.Now(),
+		UserAgent: grpcutils.GetHeaderValueMust(ctx, "User-Agent"),
+	}
+
+	if s.geoipCtl != nil && cc.Spec.Ingress != nil && cc.Spec.Ingress.UseForwardedForHeader {
+		if addr := grpcutils.GetHeaderValueMust(ctx,
+			vutils.GetDownstreamIPHeaderCanonical()); govalidator.IsIP(addr) {
+			obs.GeoIP = s.geoipCtl.ResolveStr(addr)
+		}
+	}
+
+	sessionrisk.Evaluate(cc, sess, obs)
+
+	action, err := sessionrisk.Enforce(ctx, s.octeliumC, cc, sess)
+	if err != nil {
+		return serr.InternalWithErr(err)
+	}
+
+	switch action {
+	case sessionrisk.ActionTerminate:
+		return grpcutils.Unauthenticated("The Session has been terminated")
+	case sessionrisk.ActionReauthenticate:
+		if _, err := s.octeliumC.CoreC().UpdateSession(ctx, sess); err != nil {
+			return serr.InternalWithErr(err)
+		}
+		return grpcutils.PermissionDenied("Authenticator authentication is required")
+	}
+
+	return nil
+}
+
 func (s *Server) Disconnect(ctx context.Context, req *userv1.DisconnectRequest) (*userv1.DisconnectResponse, error) {
 
 	i, err := userctx.GetUserCtx(ctx)
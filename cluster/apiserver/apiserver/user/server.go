@@ -23,6 +23,7 @@ import (
 
 	"github.com/octelium/octelium/apis/main/corev1"
 	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/octelium/octelium/cluster/common/geoipctl"
 	"github.com/octelium/octelium/cluster/common/octeliumc"
 	"github.com/octelium/octelium/pkg/common/pbutils"
 	"go.uber.org/zap"
@@ -35,6 +36,7 @@ const (
 
 type Server struct {
 	octeliumC octeliumc.ClientInterface
+	geoipCtl  *geoipctl.Controller
 
 	userv1.UnimplementedMainServiceServer
 	connServer *connServer
@@ -53,6 +55,12 @@ func (s *Server) ConnServer() ConnServerI {
 	return s.connServer
 }
 
+// SetGeoIPCtl sets the GeoIP controller used to resolve the location of new
+// connections for the Session risk evaluation
+func (s *Server) SetGeoIPCtl(geoipCtl *geoipctl.Controller) {
+	s.geoipCtl = geoipCtl
+}
+
 type ConnServerI interface {
 	BroadcastMessage(msg *userv1.ConnectResponse) error
 	SendMessage(msg *userv1.ConnectResponse, sessUID string) error
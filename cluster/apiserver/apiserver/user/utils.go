@@ -62,3 +62,33 @@ func ServiceTo(p *corev1.Service) *userv1.Service {
 
 	return ret
 }
+
+func AccessRequestTo(p *corev1.AccessRequest) *userv1.AccessRequest {
+	ret := &userv1.AccessRequest{
+		ApiVersion: "user/v1",
+		Kind:       "AccessRequest",
+		Metadata: &metav1.Metadata{
+			Uid:       p.Metadata.Uid,
+			Name:      p.Metadata.Name,
+			CreatedAt: p.Metadata.CreatedAt,
+		},
+		Spec: &userv1.AccessRequest_Spec{
+			Service:       p.Spec.Service,
+			Namespace:     p.Spec.Namespace,
+			Duration:      p.Spec.Duration,
+			Justification: p.Spec.Justification,
+		},
+		Status: &userv1.AccessRequest_Status{
+			State:     userv1.AccessRequest_Status_State(p.Status.State),
+			User:      p.Status.UserRef.GetName(),
+			ExpiresAt: p.Status.ExpiresAt,
+		},
+	}
+
+	if p.Status.Decision != nil {
+		ret.Status.Approver = p.Status.Decision.UserRef.GetName()
+		ret.Status.Reason = p.Status.Decision.Reason
+	}
+
+	return ret
+}
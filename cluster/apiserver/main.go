@@ -17,15 +17,39 @@
 package main
 
 import (
+	"context"
+
 	"github.com/octelium/octelium/cluster/apiserver/apiserver"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/scim"
 	"github.com/octelium/octelium/cluster/common/components"
+	"github.com/spf13/cobra"
 )
 
+var scimCmd = &cobra.Command{
+	Use: "scim",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scim.Run(cmd.Context())
+	},
+}
+
+var rootCmd = &cobra.Command{
+	Use:  "apiserver",
+	Long: `apiserver`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return apiserver.Run(cmd.Context())
+	},
+}
+
 func init() {
 	components.SetComponentNamespace(components.ComponentNamespaceOctelium)
 	components.SetComponentType(components.APIServer)
 }
 
 func main() {
-	components.RunComponent(apiserver.Run, nil)
+	components.RunComponent(func(ctx context.Context) error {
+		rootCmd.SetContext(ctx)
+
+		rootCmd.AddCommand(scimCmd)
+		return rootCmd.Execute()
+	}, nil)
 }
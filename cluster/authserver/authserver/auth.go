@@ -969,7 +969,9 @@ func (s *server) handleAuthenticatorAuthenticate(w http.ResponseWriter, r *http.
 		return
 	}
 
-	if !ucorev1.ToSession(sess).ShouldRefresh() {
+	isStepUp := r.URL.Query().Get("stepUp") == "true"
+
+	if !isStepUp && !ucorev1.ToSession(sess).ShouldRefresh() {
 		s.redirectToPortal(w, r)
 		return
 	}
@@ -191,6 +191,8 @@ func (s *server) doAuthenticateAuthenticator(ctx context.Context,
 		},
 	}
 
+	authInfo.Aal = getAuthenticatorAAL(authInfo.GetAuthenticator())
+
 	if err := s.doPostAuthenticatorAuthenticationRules(ctx, cc, authn, sess, usr, authInfo); err != nil {
 		return nil, s.errPermissionDeniedErr(err)
 	}
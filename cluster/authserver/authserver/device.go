@@ -536,11 +536,19 @@ func (s *server) doRunDeviceProbeBegin(ctx context.Context,
 		}
 	}
 
+	cc, err := s.octeliumC.CoreV1Utils().GetClusterConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postureCfg := cc.GetSpec().GetDevice().GetPosture()
+
 	if attempt := dev.Status.ProbeAttempt; attempt != nil {
 		if len(attempt.Results) > 0 {
-			return &authv1.RunDeviceProbeBeginResponse{}, nil
-		}
-		if attempt.StartedAt.IsValid() &&
+			if !hasPostureRules(postureCfg) ||
+				time.Since(attempt.StartedAt.AsTime()) < getPostureInterval(postureCfg) {
+				return &authv1.RunDeviceProbeBeginResponse{}, nil
+			}
+		} else if attempt.StartedAt.IsValid() &&
 			time.Since(attempt.StartedAt.AsTime()) <= probeAttemptTTL {
 			return &authv1.RunDeviceProbeBeginResponse{
 				AttemptUID: attempt.Uid,
@@ -549,12 +557,8 @@ func (s *server) doRunDeviceProbeBegin(ctx context.Context,
 		}
 	}
 
-	cc, err := s.octeliumC.CoreV1Utils().GetClusterConfig(ctx)
-	if err != nil {
-		return nil, err
-	}
 	cfg := cc.GetStatus().GetDevice()
-	if cfg == nil || len(cfg.Probes) == 0 {
+	if len(cfg.GetProbes()) == 0 && len(postureCfg.GetProbes()) == 0 {
 		return &authv1.RunDeviceProbeBeginResponse{}, nil
 	}
 
@@ -568,7 +572,7 @@ func (s *server) doRunDeviceProbeBegin(ctx context.Context,
 
 	var issued []*corev1.ClusterConfig_Status_Device_Probe
 
-	for _, p := range cfg.Probes {
+	for _, p := range cfg.GetProbes() {
 		if p.GetOwnerRef() == nil || p.OsType != dev.Status.OsType {
 			continue
 		}
@@ -589,6 +593,12 @@ func (s *server) doRunDeviceProbeBegin(ctx context.Context,
 			break
 		}
 	}
+
+	issued = append(issued, s.getPostureProbes(ctx, postureCfg, dev, inputMap)...)
+	if len(issued) > maxProbesPerAttempt {
+		issued = issued[:maxProbesPerAttempt]
+	}
+
 	if len(issued) == 0 {
 		return &authv1.RunDeviceProbeBeginResponse{}, nil
 	}
@@ -676,6 +686,9 @@ func (s *server) doRunDeviceProbeFinish(ctx context.Context,
 				return nil, grpcutils.InvalidArg("Output is too large for probeID: %s", r.ProbeID)
 			}
 			res.Type = &corev1.Device_Status_ProbeAttempt_Result_Output{Output: t.Output}
+			if attempt.Probes[idx].GetRunCommand() != nil {
+				res.ExitCode = r.ExitCode
+			}
 		case *authv1.DeviceProbeResult_Error:
 			res.Type = &corev1.Device_Status_ProbeAttempt_Result_Error{Error: t.Error}
 		default:
@@ -687,6 +700,27 @@ func (s *server) doRunDeviceProbeFinish(ctx context.Context,
 
 	attempt.Results = results
 
+	cc, err := s.octeliumC.CoreV1Utils().GetClusterConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if postureCfg := cc.GetSpec().GetDevice().GetPosture(); hasPostureRules(postureCfg) {
+		usr, err := s.getUserFromSession(ctx, sess)
+		if err != nil {
+			return nil, err
+		}
+
+		dev.Status.Posture, err = s.evalDevicePosture(ctx, postureCfg, map[string]any{
+			"user":    pbutils.MustConvertToMap(usr),
+			"session": pbutils.MustConvertToMap(sess),
+			"device":  pbutils.MustConvertToMap(dev),
+		}, attempt, dev.Status.Posture)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if _, err := s.octeliumC.CoreC().UpdateDevice(ctx, dev); err != nil {
 		return nil, err
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/octelium/octelium/cluster/common/apivalidation"
 	"github.com/octelium/octelium/cluster/common/oscope"
 	"github.com/octelium/octelium/cluster/common/sessionc"
+	"github.com/octelium/octelium/cluster/common/sessionrisk"
 	"github.com/octelium/octelium/cluster/common/urscsrv"
 	"github.com/octelium/octelium/cluster/common/vutils"
 	"github.com/octelium/octelium/pkg/apiutils/umetav1"
@@ -158,6 +159,15 @@ func (s *server) handleOAuth2TokenClientCredentials(w http.ResponseWriter, r *ht
 			},
 		}, r.Header.Get("User-Agent"), cc, r.Header.Get(vutils.GetDownstreamIPHeaderCanonical()))
 
+		switch action, err := s.enforceSessionRisk(ctx, cc, sess); {
+		case err != nil:
+			s.returnOAuth2Err(w, "server_error", 500)
+			return
+		case action == sessionrisk.ActionTerminate:
+			s.returnOAuth2Err(w, "invalid_client", 400)
+			return
+		}
+
 		sess, err = s.octeliumC.CoreC().UpdateSession(ctx, sess)
 		if err != nil {
 			s.returnOAuth2Err(w, "server_error", 500)
@@ -299,6 +309,14 @@ func (s *server) handleOAuth2Metadata(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleJWKS publishes the public keys used to sign the identity assertions
+// that vigil attaches to the requests sent to HTTP upstreams
+func (s *server) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(s.identityAssertionJWKCtl.GetJWKS())
+}
+
 func (s *server) handleOAuth2TokenClientCredentialsOIDC(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	w.Header().Set("Content-Type", "application/json")
@@ -17,16 +17,21 @@
 package authserver
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"io/fs"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/authserver/authserver/providers/email"
 	"github.com/octelium/octelium/cluster/common/apivalidation"
 	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/utils"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -102,7 +107,9 @@ func (s *server) handleLogin(w http.ResponseWriter, r *http.Request) {
 		switch info.GetIdentityProvider().Type {
 		case corev1.IdentityProvider_Status_GITHUB,
 			corev1.IdentityProvider_Status_OIDC,
-			corev1.IdentityProvider_Status_SAML:
+			corev1.IdentityProvider_Status_SAML,
+			corev1.IdentityProvider_Status_LDAP,
+			corev1.IdentityProvider_Status_EMAIL:
 		default:
 			s.setLogoutCookies(w)
 			s.renderIndex(w)
@@ -227,3 +234,116 @@ func (s *server) handleStatic() http.Handler {
 
 	return http.FileServer(httpFS)
 }
+
+// handleLDAPLogin renders the username/password form of LDAP
+// IdentityProviders. The form is submitted to the callback endpoint where the
+// login state is consumed and the credentials are verified against the
+// directory.
+func (s *server) handleLDAPLogin(w http.ResponseWriter, r *http.Request) {
+	stateID := r.URL.Query().Get("state")
+	if !rgxStateID.MatchString(stateID) {
+		s.redirectToLogin(w, r)
+		return
+	}
+
+	stateIDCookie, err := getStateIDFromCookie(r)
+	if err != nil || !utils.SecureStringEqual(stateID, stateIDCookie) {
+		s.redirectToLogin(w, r)
+		return
+	}
+
+	provider, err := s.getWebProviderFromUID(r.URL.Query().Get("idp"))
+	if err != nil || provider.Provider().Status.Type != corev1.IdentityProvider_Status_LDAP {
+		s.redirectToLogin(w, r)
+		return
+	}
+
+	s.renderLDAPLogin(w, &ldapLoginTemplateArgs{
+		DisplayName: getIdentityProviderDisplayName(provider.Provider()),
+		State:       stateID,
+		ActionURL:   fmt.Sprintf("%s/callback", s.rootURL),
+	})
+}
+
+func getIdentityProviderDisplayName(idp *corev1.IdentityProvider) string {
+	if idp.Spec.DisplayName != "" {
+		return idp.Spec.DisplayName
+	}
+
+	return idp.Metadata.Name
+}
+
+func (s *server) getEmailLoginProvider(r *http.Request, stateID, idpUID string) (*email.Connector, error) {
+	if !rgxStateID.MatchString(stateID) {
+		return nil, errors.Errorf("Invalid state ID")
+	}
+
+	stateIDCookie, err := getStateIDFromCookie(r)
+	if err != nil || !utils.SecureStringEqual(stateID, stateIDCookie) {
+		return nil, errors.Errorf("state ID and cookie don't match")
+	}
+
+	provider, err := s.getWebProviderFromUID(idpUID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, ok := provider.(*email.Connector)
+	if !ok {
+		return nil, errors.Errorf("Not an email IdentityProvider")
+	}
+
+	return ret, nil
+}
+
+// handleEmailLogin renders the form where the User enters the email address
+// to which the one-time code and the magic link are sent.
+func (s *server) handleEmailLogin(w http.ResponseWriter, r *http.Request) {
+	stateID := r.URL.Query().Get("state")
+	provider, err := s.getEmailLoginProvider(r, stateID, r.URL.Query().Get("idp"))
+	if err != nil {
+		s.redirectToLogin(w, r)
+		return
+	}
+
+	s.renderEmailLogin(w, &emailLoginTemplateArgs{
+		DisplayName: getIdentityProviderDisplayName(provider.Provider()),
+		State:       stateID,
+		IDP:         provider.Provider().Metadata.Uid,
+		SendURL:     fmt.Sprintf("%s/login/email", s.rootURL),
+		ActionURL:   fmt.Sprintf("%s/callback", s.rootURL),
+	})
+}
+
+// handleEmailLoginSend sends the one-time code and renders the form where it
+// is entered. The code form is rendered even if no User owns the email
+// address so that the form cannot be used to enumerate the Users.
+func (s *server) handleEmailLoginSend(w http.ResponseWriter, r *http.Request) {
+	stateID := r.PostFormValue("state")
+	provider, err := s.getEmailLoginProvider(r, stateID, r.PostFormValue("idp"))
+	if err != nil {
+		s.redirectToLogin(w, r)
+		return
+	}
+
+	args := &emailLoginTemplateArgs{
+		DisplayName: getIdentityProviderDisplayName(provider.Provider()),
+		State:       stateID,
+		IDP:         provider.Provider().Metadata.Uid,
+		SendURL:     fmt.Sprintf("%s/login/email", s.rootURL),
+		ActionURL:   fmt.Sprintf("%s/callback", s.rootURL),
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := provider.SendCodeAsync(ctx, stateID, r.PostFormValue("email")); err != nil {
+		zap.L().Debug("Could not send email login code", zap.Error(err))
+		args.Error = "Could not send the code. Please try again later."
+		s.renderEmailLogin(w, args)
+		return
+	}
+
+	args.IsCodeSent = true
+	s.renderEmailLogin(w, args)
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package authserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/common/deviceposture"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPostureInterval = 15 * time.Minute
+	defaultPostureTTL      = 1 * time.Hour
+)
+
+func getPostureInterval(cfg *corev1.ClusterConfig_Spec_Device_Posture) time.Duration {
+	if ret := umetav1.ToDuration(cfg.GetInterval()).ToGo(); ret > 0 {
+		return ret
+	}
+	return defaultPostureInterval
+}
+
+func getPostureTTL(cfg *corev1.ClusterConfig_Spec_Device_Posture) time.Duration {
+	if ret := umetav1.ToDuration(cfg.GetTtl()).ToGo(); ret > 0 {
+		return ret
+	}
+	return defaultPostureTTL
+}
+
+func hasPostureRules(cfg *corev1.ClusterConfig_Spec_Device_Posture) bool {
+	return cfg != nil && (len(cfg.Probes) > 0 || len(cfg.Signals) > 0 || len(cfg.Risks) > 0)
+}
+
+func (s *server) getPostureProbes(ctx context.Context,
+	cfg *corev1.ClusterConfig_Spec_Device_Posture,
+	dev *corev1.Device, inputMap map[string]any) []*corev1.ClusterConfig_Status_Device_Probe {
+	var ret []*corev1.ClusterConfig_Status_Device_Probe
+
+	for _, p := range cfg.GetProbes() {
+		if p.Name == "" || p.OsType != dev.Status.OsType {
+			continue
+		}
+
+		if p.Condition != nil {
+			ok, err := s.celEngine.EvalCondition(ctx, p.Condition, inputMap)
+			if err != nil {
+				zap.L().Warn("Could not evaluate posture probe condition",
+					zap.String("probe", p.Name), zap.Error(err))
+				continue
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		probe := &corev1.ClusterConfig_Status_Device_Probe{
+			Name:             p.Name,
+			OsType:           p.OsType,
+			RequireElevation: p.RequireElevation,
+		}
+
+		switch t := p.Type.(type) {
+		case *corev1.ClusterConfig_Spec_Device_Posture_Probe_RunCommand:
+			probe.Type = &corev1.ClusterConfig_Status_Device_Probe_RunCommand_{
+				RunCommand: pbutils.Clone(t.RunCommand).(*corev1.ClusterConfig_Status_Device_Probe_RunCommand),
+			}
+		case *corev1.ClusterConfig_Spec_Device_Posture_Probe_ReadFile:
+			probe.Type = &corev1.ClusterConfig_Status_Device_Probe_ReadFile_{
+				ReadFile: pbutils.Clone(t.ReadFile).(*corev1.ClusterConfig_Status_Device_Probe_ReadFile),
+			}
+		case *corev1.ClusterConfig_Spec_Device_Posture_Probe_ReadRegistry:
+			probe.Type = &corev1.ClusterConfig_Status_Device_Probe_ReadRegistry_{
+				ReadRegistry: pbutils.Clone(t.ReadRegistry).(*corev1.ClusterConfig_Status_Device_Probe_ReadRegistry),
+			}
+		default:
+			continue
+		}
+
+		ret = append(ret, probe)
+	}
+
+	return ret
+}
+
+func (s *server) evalPostureSignal(ctx context.Context,
+	sig *corev1.ClusterConfig_Spec_Device_Posture_Signal,
+	inputMap map[string]any) corev1.Device_Status_Posture_SignalState {
+
+	if sig.NotApplicable != nil {
+		ok, err := s.celEngine.EvalCondition(ctx, sig.NotApplicable, inputMap)
+		if err != nil {
+			zap.L().Debug("Could not evaluate posture signal notApplicable condition",
+				zap.String("signal", sig.Name), zap.Error(err))
+			return corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN
+		}
+		if ok {
+			return corev1.Device_Status_Posture_NOT_APPLICABLE
+		}
+	}
+
+	if sig.Condition == nil {
+		return corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN
+	}
+
+	ok, err := s.celEngine.EvalCondition(ctx, sig.Condition, inputMap)
+	if err != nil {
+		zap.L().Debug("Could not evaluate posture signal condition",
+			zap.String("signal", sig.Name), zap.Error(err))
+		return corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN
+	}
+	if ok {
+		return corev1.Device_Status_Posture_PASS
+	}
+
+	return corev1.Device_Status_Posture_FAIL
+}
+
+// evalDevicePosture evaluates the posture signals from the probe results and
+// returns the Device's current Posture with its probe source replaced. The
+// risks are evaluated over the union of the probe signals and the signals of
+// the other sources (e.g. the posture Connectors) and only set the riskLevel of
+// the returned Posture, never the riskLevel of the probe source.
+func (s *server) evalDevicePosture(ctx context.Context,
+	cfg *corev1.ClusterConfig_Spec_Device_Posture,
+	reqCtx map[string]any,
+	attempt *corev1.Device_Status_ProbeAttempt,
+	current *corev1.Device_Status_Posture) (*corev1.Device_Status_Posture, error) {
+
+	now := time.Now()
+	probePosture := &corev1.Device_Status_Posture{
+		LastSyncAt: timestamppb.New(now),
+		LastSeenAt: timestamppb.New(now),
+		ExpiresAt:  timestamppb.New(now.Add(getPostureTTL(cfg))),
+	}
+
+	reqCtx["probes"] = deviceposture.GetProbesMap(attempt)
+	inputMap := map[string]any{
+		"ctx": reqCtx,
+	}
+
+	for _, sig := range cfg.GetSignals() {
+		if sig.Name == "" {
+			continue
+		}
+		state := s.evalPostureSignal(ctx, sig, inputMap)
+
+		switch sig.Name {
+		case "diskEncryption":
+			probePosture.DiskEncryption = state
+		case "compliant":
+			probePosture.Compliant = state
+		case "threatFree":
+			probePosture.ThreatFree = state
+		default:
+			if probePosture.Signals == nil {
+				probePosture.Signals = make(map[string]corev1.Device_Status_Posture_SignalState)
+			}
+			probePosture.Signals[sig.Name] = state
+		}
+	}
+
+	ret, err := deviceposture.SetSource(current, deviceposture.SourceProbe, probePosture, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return deviceposture.SetRiskLevel(ctx, s.celEngine, cfg, ret, reqCtx), nil
+}
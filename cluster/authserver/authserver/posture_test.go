@@ -0,0 +1,284 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package authserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/cluster/common/deviceposture"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEvalDevicePosture(t *testing.T) {
+	ctx := context.Background()
+
+	celEngine, err := celengine.New(ctx, &celengine.Opts{})
+	assert.Nil(t, err)
+
+	s := &server{
+		celEngine: celEngine,
+	}
+
+	match := func(exp string) *corev1.Condition {
+		return &corev1.Condition{
+			Type: &corev1.Condition_Match{
+				Match: exp,
+			},
+		}
+	}
+
+	cfg := &corev1.ClusterConfig_Spec_Device_Posture{
+		Signals: []*corev1.ClusterConfig_Spec_Device_Posture_Signal{
+			{
+				Name:      "diskEncryption",
+				Condition: match(`ctx.probes.luks.exitCode == 0 && ctx.probes.luks.output.contains("crypt")`),
+			},
+			{
+				Name:          "compliant",
+				NotApplicable: match(`ctx.device.status.osType == "LINUX"`),
+				Condition:     match(`true`),
+			},
+			{
+				Name:      "firewall",
+				Condition: match(`ctx.probes.ufw.output.contains("Status: active")`),
+			},
+			{
+				Name:      "sshd",
+				Condition: match(`ctx.probes.sshd.exitCode != 0`),
+			},
+		},
+		Risks: []*corev1.ClusterConfig_Spec_Device_Posture_Risk{
+			{
+				Level:     corev1.Device_Status_Posture_MEDIUM,
+				Condition: match(`ctx.posture.signals.firewall != "PASS"`),
+			},
+			{
+				Level:     corev1.Device_Status_Posture_CRITICAL,
+				Condition: match(`ctx.posture.diskEncryption != "PASS"`),
+			},
+		},
+		Ttl: &metav1.Duration{
+			Type: &metav1.Duration_Minutes{
+				Minutes: 30,
+			},
+		},
+	}
+
+	reqCtx := func() map[string]any {
+		return map[string]any{
+			"device": map[string]any{
+				"status": map[string]any{
+					"osType": "LINUX",
+				},
+			},
+		}
+	}
+
+	getAttempt := func(luksOut string, luksExitCode int32) *corev1.Device_Status_ProbeAttempt {
+		return &corev1.Device_Status_ProbeAttempt{
+			Probes: []*corev1.ClusterConfig_Status_Device_Probe{
+				{
+					Name: "luks",
+				},
+				{
+					Name: "ufw",
+				},
+				{},
+			},
+			Results: []*corev1.Device_Status_ProbeAttempt_Result{
+				{
+					ProbeID: "0",
+					Type: &corev1.Device_Status_ProbeAttempt_Result_Output{
+						Output: []byte(luksOut),
+					},
+					ExitCode: luksExitCode,
+				},
+				{
+					ProbeID: "1",
+					Type: &corev1.Device_Status_ProbeAttempt_Result_Error{
+						Error: "permission denied",
+					},
+				},
+				{
+					ProbeID: "2",
+					Type: &corev1.Device_Status_ProbeAttempt_Result_Output{
+						Output: []byte("unnamed"),
+					},
+				},
+			},
+		}
+	}
+
+	{
+		posture, err := s.evalDevicePosture(ctx, cfg, reqCtx(), getAttempt("disk\ncrypt\n", 0), nil)
+		assert.Nil(t, err)
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, posture.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_NOT_APPLICABLE, posture.Compliant)
+		assert.Equal(t, corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN, posture.ThreatFree)
+		assert.Equal(t, corev1.Device_Status_Posture_FAIL, posture.Signals["firewall"])
+		assert.Equal(t, corev1.Device_Status_Posture_FAIL, posture.Signals["sshd"])
+		assert.Equal(t, corev1.Device_Status_Posture_MEDIUM, posture.RiskLevel)
+		assert.Equal(t, corev1.Device_Status_Posture_RISK_LEVEL_UNKNOWN,
+			posture.Sources[0].Posture.RiskLevel)
+
+		assert.True(t, posture.LastSyncAt.IsValid())
+		assert.True(t, posture.ExpiresAt.AsTime().After(time.Now().Add(29*time.Minute)))
+		assert.True(t, posture.ExpiresAt.AsTime().Before(time.Now().Add(31*time.Minute)))
+	}
+
+	{
+		posture, err := s.evalDevicePosture(ctx, cfg, reqCtx(), getAttempt("disk\ncrypt\n", 1), nil)
+		assert.Nil(t, err)
+		assert.Equal(t, corev1.Device_Status_Posture_FAIL, posture.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_CRITICAL, posture.RiskLevel)
+	}
+
+	{
+		posture, err := s.evalDevicePosture(ctx, cfg, reqCtx(), getAttempt("disk\n", 0), nil)
+		assert.Nil(t, err)
+		assert.Equal(t, corev1.Device_Status_Posture_FAIL, posture.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_CRITICAL, posture.RiskLevel)
+	}
+
+	{
+		current, err := deviceposture.SetSource(nil, deviceposture.GetConnectorSource("mdm"),
+			&corev1.Device_Status_Posture{
+				Compliant: corev1.Device_Status_Posture_FAIL,
+				Signals: map[string]corev1.Device_Status_Posture_SignalState{
+					"firewall": corev1.Device_Status_Posture_PASS,
+				},
+				ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+			}, time.Now())
+		assert.Nil(t, err)
+
+		posture, err := s.evalDevicePosture(ctx, cfg, reqCtx(), getAttempt("disk\ncrypt\n", 0), current)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(posture.Sources))
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, posture.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_FAIL, posture.Compliant)
+		assert.Equal(t, corev1.Device_Status_Posture_FAIL, posture.Signals["firewall"])
+		assert.Equal(t, corev1.Device_Status_Posture_MEDIUM, posture.RiskLevel)
+		assert.Equal(t, corev1.Device_Status_Posture_PASS,
+			posture.Sources[0].Posture.Signals["firewall"])
+	}
+
+	{
+		cfg := &corev1.ClusterConfig_Spec_Device_Posture{
+			Signals: []*corev1.ClusterConfig_Spec_Device_Posture_Signal{
+				{
+					Name:      "diskEncryption",
+					Condition: match(`true`),
+				},
+			},
+		}
+		posture, err := s.evalDevicePosture(ctx, cfg, reqCtx(), getAttempt("", 0), nil)
+		assert.Nil(t, err)
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, posture.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_RISK_LEVEL_UNKNOWN, posture.RiskLevel)
+		assert.True(t, posture.ExpiresAt.AsTime().After(time.Now().Add(defaultPostureTTL-time.Minute)))
+	}
+}
+
+func TestGetPostureProbes(t *testing.T) {
+	ctx := context.Background()
+
+	celEngine, err := celengine.New(ctx, &celengine.Opts{})
+	assert.Nil(t, err)
+
+	s := &server{
+		celEngine: celEngine,
+	}
+
+	cfg := &corev1.ClusterConfig_Spec_Device_Posture{
+		Probes: []*corev1.ClusterConfig_Spec_Device_Posture_Probe{
+			{
+				Name:   "luks",
+				OsType: corev1.Device_Status_LINUX,
+				Type: &corev1.ClusterConfig_Spec_Device_Posture_Probe_RunCommand{
+					RunCommand: &corev1.ClusterConfig_Status_Device_Probe_RunCommand{
+						Command: "/usr/bin/lsblk",
+					},
+				},
+			},
+			{
+				Name:   "bitlocker",
+				OsType: corev1.Device_Status_WINDOWS,
+				Type: &corev1.ClusterConfig_Spec_Device_Posture_Probe_ReadRegistry{
+					ReadRegistry: &corev1.ClusterConfig_Status_Device_Probe_ReadRegistry{
+						Key: `HKLM\SYSTEM`,
+					},
+				},
+			},
+			{
+				Name:   "osRelease",
+				OsType: corev1.Device_Status_LINUX,
+				Condition: &corev1.Condition{
+					Type: &corev1.Condition_Match{
+						Match: `ctx.user.metadata.name == "alice"`,
+					},
+				},
+				Type: &corev1.ClusterConfig_Spec_Device_Posture_Probe_ReadFile{
+					ReadFile: &corev1.ClusterConfig_Status_Device_Probe_ReadFile{
+						Path: "/etc/os-release",
+					},
+				},
+			},
+		},
+	}
+
+	dev := &corev1.Device{
+		Status: &corev1.Device_Status{
+			OsType: corev1.Device_Status_LINUX,
+		},
+	}
+
+	getInput := func(name string) map[string]any {
+		return map[string]any{
+			"ctx": map[string]any{
+				"user": map[string]any{
+					"metadata": map[string]any{
+						"name": name,
+					},
+				},
+			},
+		}
+	}
+
+	{
+		probes := s.getPostureProbes(ctx, cfg, dev, getInput("alice"))
+		assert.Equal(t, 2, len(probes))
+		assert.Equal(t, "luks", probes[0].Name)
+		assert.Equal(t, "/usr/bin/lsblk", probes[0].GetRunCommand().Command)
+		assert.Nil(t, probes[0].OwnerRef)
+		assert.Equal(t, "osRelease", probes[1].Name)
+		assert.Nil(t, probes[1].Condition)
+	}
+
+	{
+		probes := s.getPostureProbes(ctx, cfg, dev, getInput("bob"))
+		assert.Equal(t, 1, len(probes))
+		assert.Equal(t, "luks", probes[0].Name)
+	}
+
+	assert.Nil(t, s.getPostureProbes(ctx, nil, dev, getInput("alice")))
+}
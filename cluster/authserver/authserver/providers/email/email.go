@@ -0,0 +1,531 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/octelium/octelium/apis/main/authv1"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rcachev1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/apis/rsc/rratelimitv1"
+	"github.com/octelium/octelium/cluster/authserver/authserver/providers/utils"
+	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/cluster/common/urscsrv"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	vutils "github.com/octelium/octelium/pkg/utils"
+	"github.com/octelium/octelium/pkg/utils/utilrand"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCodeDuration       = 5 * time.Minute
+	defaultMaxRequestsPerHour = 5
+	defaultSubject            = "Your Octelium login code"
+
+	codeLen        = 6
+	tokenLen       = 43
+	maxEmailLen    = 320
+	connTimeout    = 10 * time.Second
+	sendTimeout    = 30 * time.Second
+	methodCode     = "code"
+	methodMagicURL = "link"
+)
+
+// ErrUserNotFound is returned by SendCode when no User can log in with the
+// given email address. Callers should not reveal it to the client.
+var ErrUserNotFound = errors.New("No User with this email")
+
+type Connector struct {
+	c         *corev1.IdentityProvider
+	cc        *corev1.ClusterConfig
+	celEngine *celengine.CELEngine
+	octeliumC octeliumc.ClientInterface
+	password  string
+	tlsConfig *tls.Config
+}
+
+type codeState struct {
+	Email     string `json:"email"`
+	CodeHash  string `json:"codeHash"`
+	TokenHash string `json:"tokenHash,omitempty"`
+}
+
+func NewConnector(ctx context.Context, opts *utils.ProviderOpts) (*Connector, error) {
+
+	conf := opts.Provider.Spec.GetEmail()
+	if conf == nil {
+		return nil, errors.Errorf("Not an email connector")
+	}
+
+	if conf.Smtp == nil {
+		return nil, errors.Errorf("SMTP options must be set")
+	}
+
+	ret := &Connector{
+		c:         opts.Provider,
+		cc:        opts.ClusterConfig,
+		celEngine: opts.CELEngine,
+		octeliumC: opts.OcteliumC,
+		tlsConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ServerName: conf.Smtp.Host,
+		},
+	}
+
+	if conf.Smtp.Username != "" {
+		sec, err := opts.OcteliumC.CoreC().GetSecret(ctx, &rmetav1.GetOptions{
+			Name: conf.Smtp.Password.GetFromSecret(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ret.password = ucorev1.ToSecret(sec).GetValueStr()
+	}
+
+	return ret, nil
+}
+
+func (c *Connector) Name() string {
+	return c.c.Metadata.Name
+}
+
+func (c *Connector) Provider() *corev1.IdentityProvider {
+	return c.c
+}
+
+func (c *Connector) Type() string {
+	return "email"
+}
+
+// GetLogin returns the URL of the authserver's email form of the
+// IdentityProvider. The login state ID is kept as the ReqID since it is also
+// the key of the one-time code that is sent to the User.
+func (c *Connector) GetLogin(r *http.Request, state string) (*utils.GetLoginResponse, error) {
+	return &utils.GetLoginResponse{
+		LoginURL: GetLoginURL(c.cc.Status.Domain, c.c.Metadata.Uid, state),
+		ReqID:    state,
+	}, nil
+}
+
+func GetLoginURL(domain, uid, state string) string {
+	q := url.Values{}
+	q.Set("idp", uid)
+	q.Set("state", state)
+	return fmt.Sprintf("https://%s/login/email?%s", domain, q.Encode())
+}
+
+func getMagicLinkURL(domain, state, token string) string {
+	q := url.Values{}
+	q.Set("state", state)
+	q.Set("token", token)
+	return fmt.Sprintf("https://%s/callback?%s", domain, q.Encode())
+}
+
+func getCodeKey(state string) string {
+	return fmt.Sprintf("authserver.idp.email.%s", state)
+}
+
+func (c *Connector) getCodeDuration() time.Duration {
+	if ret := umetav1.ToDuration(c.c.Spec.GetEmail().CodeDuration).ToGo(); ret > 0 {
+		return ret
+	}
+	return defaultCodeDuration
+}
+
+func (c *Connector) getMaxRequestsPerHour() int64 {
+	if ret := c.c.Spec.GetEmail().MaxRequestsPerHour; ret > 0 {
+		return int64(ret)
+	}
+	return defaultMaxRequestsPerHour
+}
+
+// SendCode sends a one-time code, and unless disabled a magic link, to the
+// given email address for the login state. A new code replaces any code
+// previously sent for the same state.
+func (c *Connector) SendCode(ctx context.Context, state string, email string) error {
+	email, err := c.checkSendCode(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	return c.doSendCode(ctx, state, email)
+}
+
+// SendCodeAsync is SendCode where only the email address and the rate limit
+// are checked before returning. Looking the User up and sending the email are
+// done in the background so that the response time does not reveal whether
+// the email address belongs to a User.
+func (c *Connector) SendCodeAsync(ctx context.Context, state string, email string) error {
+	email, err := c.checkSendCode(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), connTimeout+sendTimeout)
+		defer cancel()
+
+		if err := c.doSendCode(ctx, state, email); err != nil && !errors.Is(err, ErrUserNotFound) {
+			zap.L().Debug("Could not send email login code", zap.String("idp", c.Name()), zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+func (c *Connector) checkSendCode(ctx context.Context, email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || len(email) > maxEmailLen || !govalidator.IsEmail(email) {
+		return "", errors.Errorf("Invalid email address")
+	}
+
+	if err := c.checkRateLimit(ctx, email); err != nil {
+		return "", err
+	}
+
+	return email, nil
+}
+
+func (c *Connector) doSendCode(ctx context.Context, state string, email string) error {
+	if _, err := c.getUser(ctx, email); err != nil {
+		return err
+	}
+
+	code, err := generateCode()
+	if err != nil {
+		return err
+	}
+
+	st := &codeState{
+		Email:    email,
+		CodeHash: hashSecret(code),
+	}
+
+	var magicLinkURL string
+	if !c.c.Spec.GetEmail().IsMagicLinkDisabled {
+		token := utilrand.GetRandomString(tokenLen)
+		st.TokenHash = hashSecret(token)
+		magicLinkURL = getMagicLinkURL(c.cc.Status.Domain, state, token)
+	}
+
+	stBytes, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.octeliumC.CacheC().SetCache(ctx, &rcachev1.SetCacheRequest{
+		Key:  []byte(getCodeKey(state)),
+		Data: stBytes,
+		Duration: &metav1.Duration{
+			Type: &metav1.Duration_Seconds{
+				Seconds: uint32(c.getCodeDuration().Seconds()),
+			},
+		},
+	}); err != nil {
+		return err
+	}
+
+	msg := c.buildMessage(email, code, magicLinkURL, time.Now())
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	if err := c.sendMail(sendCtx, email, msg); err != nil {
+		zap.L().Warn("Could not send login email", zap.String("idp", c.Name()), zap.Error(err))
+		return errors.Errorf("Could not send the email")
+	}
+
+	return nil
+}
+
+func (c *Connector) checkRateLimit(ctx context.Context, email string) error {
+	res, err := c.octeliumC.RateLimitC().CheckSlidingWindow(ctx,
+		&rratelimitv1.CheckSlidingWindowRequest{
+			Key: []byte(fmt.Sprintf("octelium:idp:email:%s:%s",
+				c.c.Metadata.Uid, hashSecret(email))),
+			Window: &metav1.Duration{
+				Type: &metav1.Duration_Minutes{
+					Minutes: 60,
+				},
+			},
+			Limit: c.getMaxRequestsPerHour(),
+		})
+	if err != nil {
+		return err
+	}
+
+	if !res.IsAllowed {
+		return errors.Errorf("Too many email requests. Please try again later.")
+	}
+
+	return nil
+}
+
+// getUser makes sure that the email address belongs to an active HUMAN User
+// so that the SMTP server is never used to send emails to arbitrary addresses.
+func (c *Connector) getUser(ctx context.Context, email string) (*corev1.User, error) {
+	usrs, err := c.octeliumC.CoreC().ListUser(ctx, &rmetav1.ListOptions{
+		Filters: []*rmetav1.ListOptions_Filter{
+			urscsrv.FilterFieldEQValStr("spec.email", email),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(usrs.Items) != 1 {
+		return nil, ErrUserNotFound
+	}
+
+	usr := usrs.Items[0]
+	if usr.Spec.Type != corev1.User_Spec_HUMAN || usr.Spec.IsDisabled || usr.Status.IsLocked {
+		return nil, ErrUserNotFound
+	}
+
+	return usr, nil
+}
+
+func (c *Connector) HandleCallback(r *http.Request, login *utils.GetLoginResponse) (*corev1.Session_Status_Authentication_Info, error) {
+	if login == nil || login.ReqID == "" {
+		return nil, errors.Errorf("Invalid login state")
+	}
+
+	var secret, method string
+
+	switch r.Method {
+	case http.MethodPost:
+		secret = strings.TrimSpace(r.PostFormValue("code"))
+		method = methodCode
+		if len(secret) != codeLen {
+			return nil, errors.Errorf("Invalid code")
+		}
+	case http.MethodGet:
+		if c.c.Spec.GetEmail().IsMagicLinkDisabled {
+			return nil, errors.Errorf("Magic links are disabled")
+		}
+		secret = r.FormValue("token")
+		method = methodMagicURL
+		if len(secret) != tokenLen {
+			return nil, errors.Errorf("Invalid token")
+		}
+	default:
+		return nil, errors.Errorf("Invalid HTTP method")
+	}
+
+	ctx := r.Context()
+
+	// The code is deleted upon the first attempt. Together with the login
+	// state, which is also consumed by the callback, this allows a single
+	// attempt per sent code.
+	res, err := c.octeliumC.CacheC().GetCache(ctx, &rcachev1.GetCacheRequest{
+		Key:    []byte(getCodeKey(login.ReqID)),
+		Delete: true,
+	})
+	if err != nil {
+		return nil, errors.Errorf("Invalid or expired code")
+	}
+
+	var st codeState
+	if err := json.Unmarshal(res.Data, &st); err != nil {
+		return nil, errors.Errorf("Invalid code state")
+	}
+
+	expectedHash := st.CodeHash
+	if method == methodMagicURL {
+		expectedHash = st.TokenHash
+	}
+
+	if expectedHash == "" || !vutils.SecureStringEqual(expectedHash, hashSecret(secret)) {
+		return nil, errors.Errorf("Invalid or expired code")
+	}
+
+	return &corev1.Session_Status_Authentication_Info{
+		Type: corev1.Session_Status_Authentication_Info_IDENTITY_PROVIDER,
+		Details: &corev1.Session_Status_Authentication_Info_IdentityProvider_{
+			IdentityProvider: &corev1.Session_Status_Authentication_Info_IdentityProvider{
+				IdentityProviderRef: umetav1.GetObjectReference(c.c),
+				Type:                corev1.IdentityProvider_Status_EMAIL,
+				Identifier:          st.Email,
+				Email:               st.Email,
+			},
+		},
+		Aal: utils.GetAAL(ctx, &utils.GetAALReq{
+			CelEngine: c.celEngine,
+			Rules:     c.c.Spec.AalRules,
+			AssertionMap: map[string]any{
+				"email":  st.Email,
+				"method": method,
+			},
+		}),
+	}, nil
+}
+
+func (c *Connector) AuthenticateAssertion(ctx context.Context, req *authv1.AuthenticateWithAssertionRequest) (*corev1.User, *corev1.Session_Status_Authentication_Info, error) {
+	return nil, nil, errors.Errorf("AuthenticateAssertion is unimplemented")
+}
+
+func (c *Connector) buildMessage(to, code, magicLinkURL string, now time.Time) []byte {
+	conf := c.c.Spec.GetEmail()
+
+	subject := conf.Subject
+	if subject == "" {
+		subject = defaultSubject
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", conf.Smtp.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.UTC().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n",
+		utilrand.GetRandomStringCanonical(24), c.cc.Status.Domain)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+
+	fmt.Fprintf(&buf, "Your login code for %s is:\r\n\r\n", c.cc.Status.Domain)
+	fmt.Fprintf(&buf, "    %s\r\n\r\n", code)
+	if magicLinkURL != "" {
+		buf.WriteString("Alternatively, open the following link in the same browser to log in:\r\n\r\n")
+		fmt.Fprintf(&buf, "%s\r\n\r\n", magicLinkURL)
+	}
+	fmt.Fprintf(&buf, "The code expires in %d minutes and can only be used once.\r\n",
+		int(c.getCodeDuration().Minutes()))
+	buf.WriteString("If you did not request it, you can safely ignore this email.\r\n")
+
+	return buf.Bytes()
+}
+
+func (c *Connector) sendMail(ctx context.Context, to string, msg []byte) error {
+	conf := c.c.Spec.GetEmail().Smtp
+
+	port := conf.Port
+	if port == 0 {
+		if conf.Mode == corev1.IdentityProvider_Spec_Email_SMTP_TLS {
+			port = 465
+		} else {
+			port = 587
+		}
+	}
+
+	addr := net.JoinHostPort(conf.Host, strconv.Itoa(int(port)))
+	dialer := &net.Dialer{Timeout: connTimeout}
+
+	var conn net.Conn
+	var err error
+
+	switch conf.Mode {
+	case corev1.IdentityProvider_Spec_Email_SMTP_TLS:
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: c.tlsConfig}).DialContext(ctx, "tcp", addr)
+	default:
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return errors.Errorf("Could not connect to the SMTP server: %s", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, conf.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	switch conf.Mode {
+	case corev1.IdentityProvider_Spec_Email_SMTP_TLS,
+		corev1.IdentityProvider_Spec_Email_SMTP_INSECURE:
+	default:
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.Errorf("The SMTP server does not support STARTTLS")
+		}
+		if err := client.StartTLS(c.tlsConfig); err != nil {
+			return errors.Errorf("Could not do STARTTLS: %s", err)
+		}
+	}
+
+	if conf.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", conf.Username, c.password, conf.Host)); err != nil {
+			return errors.Errorf("Could not authenticate to the SMTP server: %s", err)
+		}
+	}
+
+	if err := client.Mail(conf.From); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func generateCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+func hashSecret(arg string) string {
+	h := sha256.Sum256([]byte(arg))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,413 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package email
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/admin"
+	"github.com/octelium/octelium/cluster/authserver/authserver/providers/utils"
+	"github.com/octelium/octelium/cluster/common/tests"
+	"github.com/octelium/octelium/pkg/utils/utilrand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// testSMTPServer is a minimal plaintext SMTP server that records the
+// received messages
+type testSMTPServer struct {
+	lis  net.Listener
+	mu   sync.Mutex
+	msgs []*testMail
+}
+
+func newTestSMTPServer(t *testing.T) *testSMTPServer {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+
+	ret := &testSMTPServer{
+		lis: lis,
+	}
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go ret.handle(conn)
+		}
+	}()
+
+	t.Cleanup(func() {
+		lis.Close()
+	})
+
+	return ret
+}
+
+func (s *testSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	write := func(line string) {
+		fmt.Fprintf(conn, "%s\r\n", line)
+	}
+
+	write("220 localhost ESMTP")
+
+	msg := &testMail{}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			write("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			msg.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			write("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			msg.to = append(msg.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			write("250 OK")
+		case cmd == "DATA":
+			write("354 Go ahead")
+			var data strings.Builder
+			for {
+				dl, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dl == ".\r\n" {
+					break
+				}
+				data.WriteString(dl)
+			}
+			msg.data = data.String()
+			s.mu.Lock()
+			s.msgs = append(s.msgs, msg)
+			s.mu.Unlock()
+			msg = &testMail{}
+			write("250 OK")
+		case cmd == "QUIT":
+			write("221 Bye")
+			return
+		default:
+			write("250 OK")
+		}
+	}
+}
+
+func (s *testSMTPServer) getMessages() []*testMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*testMail{}, s.msgs...)
+}
+
+func (s *testSMTPServer) port() uint32 {
+	return uint32(s.lis.Addr().(*net.TCPAddr).Port)
+}
+
+func newTestConnector(smtpSrv *testSMTPServer) *Connector {
+	return &Connector{
+		c: &corev1.IdentityProvider{
+			Metadata: &metav1.Metadata{
+				Name: "email",
+				Uid:  "uid-1",
+			},
+			Spec: &corev1.IdentityProvider_Spec{
+				Type: &corev1.IdentityProvider_Spec_Email_{
+					Email: &corev1.IdentityProvider_Spec_Email{
+						Smtp: &corev1.IdentityProvider_Spec_Email_SMTP{
+							Host: "127.0.0.1",
+							Port: smtpSrv.port(),
+							From: "no-reply@example.com",
+							Mode: corev1.IdentityProvider_Spec_Email_SMTP_INSECURE,
+						},
+					},
+				},
+			},
+		},
+		cc: &corev1.ClusterConfig{
+			Status: &corev1.ClusterConfig_Status{
+				Domain: "example.com",
+			},
+		},
+	}
+}
+
+var rgxTestCode = regexp.MustCompile(`\r\n    ([0-9]{6})\r\n`)
+var rgxTestLink = regexp.MustCompile(`(https://example\.com/callback\?[^\r\n]+)`)
+
+func TestSendMail(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	smtpSrv := newTestSMTPServer(t)
+	c := newTestConnector(smtpSrv)
+
+	msg := c.buildMessage("alice@example.com", "012345",
+		getMagicLinkURL("example.com", "state-id", "token"), time.Now())
+
+	err := c.sendMail(ctx, "alice@example.com", msg)
+	assert.Nil(t, err, "%+v", err)
+
+	msgs := smtpSrv.getMessages()
+	require.Equal(t, 1, len(msgs))
+	assert.Equal(t, "no-reply@example.com", msgs[0].from)
+	assert.Equal(t, []string{"alice@example.com"}, msgs[0].to)
+	assert.True(t, strings.Contains(msgs[0].data, "Subject: "+defaultSubject))
+	assert.Equal(t, "012345", rgxTestCode.FindStringSubmatch(msgs[0].data)[1])
+	assert.Equal(t, "https://example.com/callback?state=state-id&token=token",
+		rgxTestLink.FindStringSubmatch(msgs[0].data)[1])
+
+	{
+		c := newTestConnector(smtpSrv)
+		c.c.Spec.GetEmail().Smtp.Mode = corev1.IdentityProvider_Spec_Email_SMTP_STARTTLS
+		err := c.sendMail(ctx, "alice@example.com", msg)
+		assert.NotNil(t, err, "STARTTLS must not be silently downgraded")
+	}
+}
+
+func TestBuildMessage(t *testing.T) {
+	c := newTestConnector(newTestSMTPServer(t))
+
+	{
+		msg := string(c.buildMessage("alice@example.com", "123456", "", time.Now()))
+		assert.True(t, strings.Contains(msg, "To: alice@example.com\r\n"))
+		assert.False(t, strings.Contains(msg, "https://"))
+		assert.True(t, strings.Contains(msg, "expires in 5 minutes"))
+	}
+
+	{
+		c.c.Spec.GetEmail().Subject = "Connexion à Octelium"
+		msg := string(c.buildMessage("alice@example.com", "123456", "", time.Now()))
+		assert.True(t, strings.Contains(msg, "Subject: =?utf-8?q?"))
+	}
+}
+
+func TestGenerateCode(t *testing.T) {
+	for range 100 {
+		code, err := generateCode()
+		assert.Nil(t, err)
+		assert.Equal(t, codeLen, len(code))
+		assert.True(t, regexp.MustCompile(`^[0-9]{6}$`).MatchString(code))
+	}
+}
+
+func newCodeRequest(code string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/callback",
+		strings.NewReader(url.Values{"code": {code}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+
+	tst, err := tests.Initialize(nil)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		tst.Destroy()
+	})
+	fakeC := tst.C
+
+	cc, err := tst.C.OcteliumC.CoreV1Utils().GetClusterConfig(ctx)
+	assert.Nil(t, err)
+
+	adminSrv := admin.NewServer(&admin.Opts{
+		OcteliumC:  fakeC.OcteliumC,
+		IsEmbedded: true,
+	})
+
+	smtpSrv := newTestSMTPServer(t)
+
+	idp, err := adminSrv.CreateIdentityProvider(ctx, &corev1.IdentityProvider{
+		Metadata: &metav1.Metadata{
+			Name: utilrand.GetRandomStringCanonical(8),
+		},
+		Spec: &corev1.IdentityProvider_Spec{
+			Type: &corev1.IdentityProvider_Spec_Email_{
+				Email: &corev1.IdentityProvider_Spec_Email{
+					Smtp: &corev1.IdentityProvider_Spec_Email_SMTP{
+						Host: "127.0.0.1",
+						Port: smtpSrv.port(),
+						From: "no-reply@example.com",
+						Mode: corev1.IdentityProvider_Spec_Email_SMTP_INSECURE,
+					},
+					MaxRequestsPerHour: 3,
+				},
+			},
+		},
+	})
+	assert.Nil(t, err, "%+v", err)
+	assert.Equal(t, corev1.IdentityProvider_Status_EMAIL, idp.Status.Type)
+
+	usrEmail := fmt.Sprintf("%s@example.com", utilrand.GetRandomStringCanonical(8))
+	_, err = adminSrv.CreateUser(ctx, &corev1.User{
+		Metadata: &metav1.Metadata{
+			Name: utilrand.GetRandomStringCanonical(8),
+		},
+		Spec: &corev1.User_Spec{
+			Type:  corev1.User_Spec_HUMAN,
+			Email: usrEmail,
+		},
+	})
+	assert.Nil(t, err, "%+v", err)
+
+	provider, err := NewConnector(ctx, &utils.ProviderOpts{
+		OcteliumC:     fakeC.OcteliumC,
+		Provider:      idp,
+		ClusterConfig: cc,
+	})
+	assert.Nil(t, err)
+
+	getLastMail := func() string {
+		msgs := smtpSrv.getMessages()
+		require.True(t, len(msgs) > 0)
+		return msgs[len(msgs)-1].data
+	}
+
+	{
+		state := utilrand.GetRandomString(36)
+		login, err := provider.GetLogin(httptest.NewRequest(http.MethodGet, "/", nil), state)
+		assert.Nil(t, err)
+		assert.Equal(t, state, login.ReqID)
+
+		err = provider.SendCode(ctx, state, strings.ToUpper(usrEmail))
+		assert.Nil(t, err, "%+v", err)
+
+		code := rgxTestCode.FindStringSubmatch(getLastMail())[1]
+
+		wrongCode := fmt.Sprintf("%d%s", (int(code[0]-'0')+1)%10, code[1:])
+		_, err = provider.HandleCallback(newCodeRequest(wrongCode), login)
+		assert.NotNil(t, err)
+
+		_, err = provider.HandleCallback(newCodeRequest(code), login)
+		assert.NotNil(t, err, "the code must be consumed by the first attempt")
+	}
+
+	{
+		state := utilrand.GetRandomString(36)
+		login, err := provider.GetLogin(httptest.NewRequest(http.MethodGet, "/", nil), state)
+		assert.Nil(t, err)
+
+		err = provider.SendCode(ctx, state, usrEmail)
+		assert.Nil(t, err, "%+v", err)
+
+		code := rgxTestCode.FindStringSubmatch(getLastMail())[1]
+
+		info, err := provider.HandleCallback(newCodeRequest(code), login)
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, corev1.IdentityProvider_Status_EMAIL, info.GetIdentityProvider().Type)
+		assert.Equal(t, usrEmail, info.GetIdentityProvider().Identifier)
+		assert.Equal(t, usrEmail, info.GetIdentityProvider().Email)
+
+		_, err = provider.HandleCallback(newCodeRequest(code), login)
+		assert.NotNil(t, err, "the code must be single use")
+	}
+
+	{
+		state := utilrand.GetRandomString(36)
+		login, err := provider.GetLogin(httptest.NewRequest(http.MethodGet, "/", nil), state)
+		assert.Nil(t, err)
+
+		err = provider.SendCode(ctx, state, usrEmail)
+		assert.Nil(t, err, "%+v", err)
+
+		link := rgxTestLink.FindStringSubmatch(getLastMail())[1]
+		u, err := url.Parse(link)
+		assert.Nil(t, err)
+		assert.Equal(t, state, u.Query().Get("state"))
+
+		info, err := provider.HandleCallback(httptest.NewRequest(http.MethodGet,
+			"/callback?"+u.RawQuery, nil), login)
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, usrEmail, info.GetIdentityProvider().Email)
+	}
+
+	{
+		nMsgs := len(smtpSrv.getMessages())
+		err := provider.SendCode(ctx, utilrand.GetRandomString(36),
+			fmt.Sprintf("%s@example.com", utilrand.GetRandomStringCanonical(8)))
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.Equal(t, nMsgs, len(smtpSrv.getMessages()), "no email must be sent to unknown addresses")
+
+		err = provider.SendCode(ctx, utilrand.GetRandomString(36), "not-an-email")
+		assert.NotNil(t, err)
+	}
+
+	{
+		err := provider.SendCode(ctx, utilrand.GetRandomString(36), usrEmail)
+		assert.NotNil(t, err, "the rate limit must be exceeded")
+	}
+	{
+		usr2Email := fmt.Sprintf("%s@example.com", utilrand.GetRandomStringCanonical(8))
+		_, err = adminSrv.CreateUser(ctx, &corev1.User{
+			Metadata: &metav1.Metadata{
+				Name: utilrand.GetRandomStringCanonical(8),
+			},
+			Spec: &corev1.User_Spec{
+				Type:  corev1.User_Spec_HUMAN,
+				Email: usr2Email,
+			},
+		})
+		assert.Nil(t, err, "%+v", err)
+
+		nMsgs := len(smtpSrv.getMessages())
+
+		err = provider.SendCodeAsync(ctx, utilrand.GetRandomString(36),
+			fmt.Sprintf("%s@example.com", utilrand.GetRandomStringCanonical(8)))
+		assert.Nil(t, err, "unknown addresses must not be revealed")
+
+		err = provider.SendCodeAsync(ctx, utilrand.GetRandomString(36), usr2Email)
+		assert.Nil(t, err)
+
+		assert.Eventually(t, func() bool {
+			return len(smtpSrv.getMessages()) == nMsgs+1
+		}, 5*time.Second, 50*time.Millisecond)
+
+		err = provider.SendCodeAsync(ctx, utilrand.GetRandomString(36), "not-an-email")
+		assert.NotNil(t, err)
+
+		err = provider.SendCodeAsync(ctx, utilrand.GetRandomString(36), usrEmail)
+		assert.NotNil(t, err, "the rate limit must be exceeded")
+	}
+}
@@ -0,0 +1,497 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ldap
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/octelium/octelium/apis/main/authv1"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/apis/rsc/rratelimitv1"
+	"github.com/octelium/octelium/cluster/authserver/authserver/providers/utils"
+	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/cluster/common/httputils"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	defaultUsernameAttribute = "uid"
+	defaultEmailAttribute    = "mail"
+	defaultGroupFilter       = "(|(objectClass=group)(objectClass=groupOfNames)(objectClass=groupOfUniqueNames))"
+	defaultGroupNameAttr     = "cn"
+	defaultGroupMemberAttr   = "member"
+
+	maxUsernameLen  = 256
+	maxPasswordLen  = 1024
+	maxGroups       = 512
+	maxNestingDepth = 16
+	connTimeout     = 10 * time.Second
+)
+
+// The login attempts are limited per username so that the form cannot be used
+// to brute-force a password or to lock the directory account out, and per
+// client IP address so that a single client cannot spray many usernames.
+const (
+	rateLimitWindowMinutes = 15
+	maxAttemptsPerUsername = 10
+	maxAttemptsPerIP       = 50
+)
+
+type Connector struct {
+	octeliumC    octeliumc.ClientInterface
+	c            *corev1.IdentityProvider
+	cc           *corev1.ClusterConfig
+	celEngine    *celengine.CELEngine
+	bindPassword string
+	tlsConfig    *tls.Config
+}
+
+func NewConnector(ctx context.Context, opts *utils.ProviderOpts) (*Connector, error) {
+
+	conf := opts.Provider.Spec.GetLdap()
+	if conf == nil {
+		return nil, errors.Errorf("Not an LDAP connector")
+	}
+
+	if conf.User == nil {
+		return nil, errors.Errorf("LDAP User search options must be set")
+	}
+
+	ret := &Connector{
+		octeliumC: opts.OcteliumC,
+		c:         opts.Provider,
+		cc:        opts.ClusterConfig,
+		celEngine: opts.CELEngine,
+	}
+
+	sec, err := opts.OcteliumC.CoreC().GetSecret(ctx, &rmetav1.GetOptions{
+		Name: conf.BindPassword.GetFromSecret(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret.bindPassword = ucorev1.ToSecret(sec).GetValueStr()
+
+	ret.tlsConfig, err = getTLSConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func getTLSConfig(conf *corev1.IdentityProvider_Spec_LDAP) (*tls.Config, error) {
+	u, err := url.Parse(conf.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: u.Hostname(),
+	}
+
+	if conf.Tls == nil {
+		return ret, nil
+	}
+
+	if conf.Tls.ServerName != "" {
+		ret.ServerName = conf.Tls.ServerName
+	}
+
+	if conf.Tls.CaCertificate != "" {
+		ret.RootCAs = x509.NewCertPool()
+		if !ret.RootCAs.AppendCertsFromPEM([]byte(conf.Tls.CaCertificate)) {
+			return nil, errors.Errorf("Could not parse the LDAP CA certificate")
+		}
+	}
+
+	return ret, nil
+}
+
+func (c *Connector) Name() string {
+	return c.c.Metadata.Name
+}
+
+func (c *Connector) Provider() *corev1.IdentityProvider {
+	return c.c
+}
+
+func (c *Connector) Type() string {
+	return "ldap"
+}
+
+// GetLogin returns the URL of the authserver's username/password form of the
+// IdentityProvider. The form is then submitted to the callback URL.
+func (c *Connector) GetLogin(r *http.Request, state string) (*utils.GetLoginResponse, error) {
+	return &utils.GetLoginResponse{
+		LoginURL: GetLoginURL(c.cc.Status.Domain, c.c.Metadata.Uid, state),
+	}, nil
+}
+
+func GetLoginURL(domain, uid, state string) string {
+	q := url.Values{}
+	q.Set("idp", uid)
+	q.Set("state", state)
+	return fmt.Sprintf("https://%s/login/ldap?%s", domain, q.Encode())
+}
+
+func (c *Connector) HandleCallback(r *http.Request, _ *utils.GetLoginResponse) (*corev1.Session_Status_Authentication_Info, error) {
+	if r.Method != http.MethodPost {
+		return nil, errors.Errorf("Invalid HTTP method")
+	}
+
+	username := r.PostFormValue("username")
+	password := r.PostFormValue("password")
+
+	if username == "" || len(username) > maxUsernameLen {
+		return nil, errors.Errorf("Invalid username")
+	}
+
+	// An empty password results in an unauthenticated bind which
+	// succeeds on many LDAP servers regardless of the DN.
+	if password == "" || len(password) > maxPasswordLen {
+		return nil, errors.Errorf("Invalid password")
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
+	defer cancel()
+
+	if err := c.checkRateLimit(ctx, username, httputils.GetDownstreamPublicIP(r)); err != nil {
+		return nil, err
+	}
+
+	res, err := c.authenticate(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs, err := structpb.NewStruct(res.attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &corev1.Session_Status_Authentication_Info{
+		Type: corev1.Session_Status_Authentication_Info_IDENTITY_PROVIDER,
+		Details: &corev1.Session_Status_Authentication_Info_IdentityProvider_{
+			IdentityProvider: &corev1.Session_Status_Authentication_Info_IdentityProvider{
+				IdentityProviderRef: umetav1.GetObjectReference(c.c),
+				Type:                corev1.IdentityProvider_Status_LDAP,
+				Identifier:          res.username,
+				Email:               res.email,
+				Groups:              res.groups,
+				Attrs:               attrs,
+			},
+		},
+		Aal: utils.GetAAL(ctx, &utils.GetAALReq{
+			CelEngine: c.celEngine,
+			Rules:     c.c.Spec.AalRules,
+			AssertionMap: map[string]any{
+				"dn":         res.dn,
+				"username":   res.username,
+				"email":      res.email,
+				"groups":     toAnySlice(res.groups),
+				"attributes": res.attrs,
+			},
+		}),
+	}
+
+	return ret, nil
+}
+
+func (c *Connector) checkRateLimit(ctx context.Context, username, ip string) error {
+	usernameHash := sha256.Sum256([]byte(strings.ToLower(username)))
+
+	type rateLimit struct {
+		key   string
+		limit int64
+	}
+
+	rateLimits := []rateLimit{
+		{
+			key: fmt.Sprintf("octelium:idp:ldap:user:%s:%s",
+				c.c.Metadata.Uid, hex.EncodeToString(usernameHash[:])),
+			limit: maxAttemptsPerUsername,
+		},
+	}
+
+	if ip != "" {
+		rateLimits = append(rateLimits, rateLimit{
+			key:   fmt.Sprintf("octelium:idp:ldap:ip:%s:%s", c.c.Metadata.Uid, ip),
+			limit: maxAttemptsPerIP,
+		})
+	}
+
+	for _, rl := range rateLimits {
+		res, err := c.octeliumC.RateLimitC().CheckSlidingWindow(ctx,
+			&rratelimitv1.CheckSlidingWindowRequest{
+				Key: []byte(rl.key),
+				Window: &metav1.Duration{
+					Type: &metav1.Duration_Minutes{
+						Minutes: rateLimitWindowMinutes,
+					},
+				},
+				Limit: rl.limit,
+			})
+		if err != nil {
+			return err
+		}
+
+		if !res.IsAllowed {
+			zap.L().Warn("LDAP login rate limit exceeded",
+				zap.String("idp", c.Name()), zap.String("ip", ip))
+			return errors.Errorf("Too many login attempts. Please try again later.")
+		}
+	}
+
+	return nil
+}
+
+func (c *Connector) AuthenticateAssertion(ctx context.Context, req *authv1.AuthenticateWithAssertionRequest) (*corev1.User, *corev1.Session_Status_Authentication_Info, error) {
+	return nil, nil, errors.Errorf("AuthenticateAssertion is unimplemented")
+}
+
+type authResult struct {
+	dn       string
+	username string
+	email    string
+	groups   []string
+	attrs    map[string]any
+}
+
+func (c *Connector) dial(ctx context.Context) (*ldap.Conn, error) {
+	conf := c.c.Spec.GetLdap()
+
+	conn, err := ldap.DialURL(conf.Url,
+		ldap.DialWithTLSConfig(c.tlsConfig),
+		ldap.DialWithDialer(&net.Dialer{Timeout: connTimeout}))
+	if err != nil {
+		return nil, errors.Errorf("Could not connect to the LDAP server: %s", err)
+	}
+
+	conn.SetTimeout(connTimeout)
+
+	if conf.IsStartTLS {
+		if err := conn.StartTLS(c.tlsConfig); err != nil {
+			conn.Close()
+			return nil, errors.Errorf("Could not do StartTLS: %s", err)
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	return conn, nil
+}
+
+func (c *Connector) authenticate(ctx context.Context, username, password string) (*authResult, error) {
+	conf := c.c.Spec.GetLdap()
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.Bind(conf.BindDN, c.bindPassword); err != nil {
+		return nil, errors.Errorf("Could not bind the LDAP service account: %s", err)
+	}
+
+	entry, err := c.findUser(conn, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		zap.L().Debug("Could not bind LDAP User", zap.String("dn", entry.DN), zap.Error(err))
+		return nil, errors.Errorf("Invalid credentials")
+	}
+
+	// Re-bind as the service account since regular Users are not
+	// necessarily allowed to search the directory for Groups.
+	if err := conn.Bind(conf.BindDN, c.bindPassword); err != nil {
+		return nil, errors.Errorf("Could not re-bind the LDAP service account: %s", err)
+	}
+
+	ret := &authResult{
+		dn:       entry.DN,
+		username: entry.GetAttributeValue(c.usernameAttribute()),
+		email:    entry.GetAttributeValue(c.emailAttribute()),
+		attrs:    make(map[string]any),
+	}
+
+	if ret.username == "" {
+		return nil, errors.Errorf("The LDAP User entry has no username attribute")
+	}
+
+	for _, attr := range conf.User.Attributes {
+		vals := entry.GetAttributeValues(attr)
+		switch len(vals) {
+		case 0:
+		case 1:
+			ret.attrs[attr] = vals[0]
+		default:
+			ret.attrs[attr] = toAnySlice(vals)
+		}
+	}
+
+	if conf.Group != nil {
+		ret.groups, err = c.findGroups(conn, entry.DN)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+func (c *Connector) usernameAttribute() string {
+	if attr := c.c.Spec.GetLdap().User.UsernameAttribute; attr != "" {
+		return attr
+	}
+	return defaultUsernameAttribute
+}
+
+func (c *Connector) emailAttribute() string {
+	if attr := c.c.Spec.GetLdap().User.EmailAttribute; attr != "" {
+		return attr
+	}
+	return defaultEmailAttribute
+}
+
+func (c *Connector) findUser(conn *ldap.Conn, username string) (*ldap.Entry, error) {
+	conf := c.c.Spec.GetLdap().User
+
+	filter := fmt.Sprintf("(%s=%s)", c.usernameAttribute(), ldap.EscapeFilter(username))
+	if conf.Filter != "" {
+		filter = fmt.Sprintf("(&%s%s)", conf.Filter, filter)
+	}
+
+	attrs := []string{c.usernameAttribute(), c.emailAttribute()}
+	attrs = append(attrs, conf.Attributes...)
+
+	res, err := conn.Search(ldap.NewSearchRequest(
+		conf.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(connTimeout.Seconds()), false,
+		filter, attrs, nil))
+	if err != nil {
+		return nil, errors.Errorf("Could not search for the LDAP User: %s", err)
+	}
+
+	switch len(res.Entries) {
+	case 1:
+		return res.Entries[0], nil
+	case 0:
+		return nil, errors.Errorf("Invalid credentials")
+	default:
+		return nil, errors.Errorf("Multiple LDAP entries match the username")
+	}
+}
+
+// findGroups returns the names of the Groups that the given DN is a member of.
+// Nested Groups are resolved via a breadth-first search over the member
+// attribute instead of Active Directory's LDAP_MATCHING_RULE_IN_CHAIN so that
+// the same logic works for all LDAP servers.
+func (c *Connector) findGroups(conn *ldap.Conn, dn string) ([]string, error) {
+	conf := c.c.Spec.GetLdap().Group
+
+	groupFilter := conf.Filter
+	if groupFilter == "" {
+		groupFilter = defaultGroupFilter
+	}
+
+	nameAttr := conf.NameAttribute
+	if nameAttr == "" {
+		nameAttr = defaultGroupNameAttr
+	}
+
+	memberAttr := conf.MemberAttribute
+	if memberAttr == "" {
+		memberAttr = defaultGroupMemberAttr
+	}
+
+	var ret []string
+	visited := map[string]bool{dn: true}
+	current := []string{dn}
+
+	for depth := 0; len(current) > 0 && depth < maxNestingDepth; depth++ {
+		var next []string
+
+		for _, memberDN := range current {
+			res, err := conn.Search(ldap.NewSearchRequest(
+				conf.BaseDN,
+				ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, maxGroups, int(connTimeout.Seconds()), false,
+				fmt.Sprintf("(&%s(%s=%s))", groupFilter, memberAttr, ldap.EscapeFilter(memberDN)),
+				[]string{nameAttr}, nil))
+			if err != nil {
+				return nil, errors.Errorf("Could not search for the LDAP Groups: %s", err)
+			}
+
+			for _, entry := range res.Entries {
+				if visited[entry.DN] {
+					continue
+				}
+				visited[entry.DN] = true
+
+				if name := entry.GetAttributeValue(nameAttr); name != "" {
+					ret = append(ret, name)
+				}
+
+				if len(ret) >= maxGroups {
+					return ret, nil
+				}
+
+				if conf.IsNested {
+					next = append(next, entry.DN)
+				}
+			}
+		}
+
+		current = next
+	}
+
+	return ret, nil
+}
+
+func toAnySlice(arg []string) []any {
+	ret := make([]any, 0, len(arg))
+	for _, itm := range arg {
+		ret = append(ret, itm)
+	}
+	return ret
+}
@@ -0,0 +1,556 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/jimlambrt/gldap"
+	"github.com/jimlambrt/gldap/testdirectory"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rratelimitv1"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/admin"
+	"github.com/octelium/octelium/cluster/authserver/authserver/providers/utils"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/cluster/common/tests"
+	"github.com/octelium/octelium/pkg/utils/utilrand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const (
+	testBindDN       = "cn=octelium,ou=services,dc=example,dc=com"
+	testBindPassword = "service-password"
+)
+
+type testDirectory struct {
+	entries   []*gldap.Entry
+	serverTLS *tls.Config
+	clientTLS *tls.Config
+	addr      string
+}
+
+func newTestDirectory(t *testing.T, isStartTLS bool) *testDirectory {
+	serverTLS, clientTLS := testdirectory.GetTLSConfig(t)
+	clientTLS.ServerName = "127.0.0.1"
+
+	d := &testDirectory{
+		serverTLS: serverTLS,
+		clientTLS: clientTLS,
+		entries: []*gldap.Entry{
+			gldap.NewEntry(testBindDN, map[string][]string{
+				"cn":           {"octelium"},
+				"userPassword": {testBindPassword},
+			}),
+			gldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+				"objectClass":      {"person"},
+				"uid":              {"alice"},
+				"mail":             {"alice@example.com"},
+				"departmentNumber": {"42"},
+				"title":            {"engineer", "oncall"},
+				"userPassword":     {"alice-password"},
+			}),
+			gldap.NewEntry("uid=bob,ou=people,dc=example,dc=com", map[string][]string{
+				"objectClass":  {"person"},
+				"uid":          {"bob"},
+				"userPassword": {"bob-password"},
+			}),
+			gldap.NewEntry("uid=carol,ou=disabled,dc=example,dc=com", map[string][]string{
+				"objectClass":  {"person"},
+				"uid":          {"carol"},
+				"userPassword": {"carol-password"},
+			}),
+			gldap.NewEntry("cn=devs,ou=groups,dc=example,dc=com", map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"devs"},
+				"member": {
+					"uid=alice,ou=people,dc=example,dc=com",
+					"cn=all,ou=groups,dc=example,dc=com",
+				},
+			}),
+			gldap.NewEntry("cn=engineering,ou=groups,dc=example,dc=com", map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"engineering"},
+				"member":      {"cn=devs,ou=groups,dc=example,dc=com"},
+			}),
+			gldap.NewEntry("cn=all,ou=groups,dc=example,dc=com", map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"all"},
+				"member":      {"cn=engineering,ou=groups,dc=example,dc=com"},
+			}),
+			gldap.NewEntry("cn=ops,ou=groups,dc=example,dc=com", map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"ops"},
+				"member":      {"uid=bob,ou=people,dc=example,dc=com"},
+			}),
+		},
+	}
+
+	srv, err := gldap.NewServer()
+	require.Nil(t, err)
+
+	mux, err := gldap.NewMux()
+	require.Nil(t, err)
+	require.Nil(t, mux.Bind(d.handleBind))
+	require.Nil(t, mux.Search(d.handleSearch))
+	require.Nil(t, mux.ExtendedOperation(d.handleStartTLS, gldap.ExtendedOperationStartTLS))
+	require.Nil(t, srv.Router(mux))
+
+	port := testdirectory.FreePort(t)
+	d.addr = fmt.Sprintf("127.0.0.1:%d", port)
+
+	var opts []gldap.Option
+	if !isStartTLS {
+		opts = append(opts, gldap.WithTLSConfig(serverTLS))
+	}
+
+	go srv.Run(d.addr, opts...)
+	t.Cleanup(func() {
+		srv.Stop()
+	})
+
+	for !srv.Ready() {
+	}
+
+	return d
+}
+
+func (d *testDirectory) handleBind(w *gldap.ResponseWriter, r *gldap.Request) {
+	resp := r.NewBindResponse(gldap.WithResponseCode(gldap.ResultInvalidCredentials))
+	defer w.Write(resp)
+
+	m, err := r.GetSimpleBindMessage()
+	if err != nil {
+		return
+	}
+
+	for _, e := range d.entries {
+		if strings.EqualFold(e.DN, m.UserName) &&
+			slices.Contains(e.GetAttributeValues("userPassword"), string(m.Password)) {
+			resp.SetResultCode(gldap.ResultSuccess)
+			return
+		}
+	}
+}
+
+func (d *testDirectory) handleStartTLS(w *gldap.ResponseWriter, r *gldap.Request) {
+	res := r.NewExtendedResponse(gldap.WithResponseCode(gldap.ResultSuccess))
+	res.SetResponseName(gldap.ExtendedOperationStartTLS)
+	w.Write(res)
+	r.StartTLS(d.serverTLS)
+}
+
+func (d *testDirectory) handleSearch(w *gldap.ResponseWriter, r *gldap.Request) {
+	res := r.NewSearchDoneResponse(gldap.WithResponseCode(gldap.ResultSuccess))
+	defer w.Write(res)
+
+	m, err := r.GetSearchMessage()
+	if err != nil {
+		res.SetResultCode(gldap.ResultOperationsError)
+		return
+	}
+
+	filter, err := ldap.CompileFilter(m.Filter)
+	if err != nil {
+		res.SetResultCode(gldap.ResultFilterError)
+		return
+	}
+
+	for _, e := range d.entries {
+		if !strings.HasSuffix(strings.ToLower(e.DN), ","+strings.ToLower(m.BaseDN)) {
+			continue
+		}
+
+		if !matchFilter(filter, e) {
+			continue
+		}
+
+		attrs := make(map[string][]string)
+		for _, attr := range m.Attributes {
+			if vals := e.GetAttributeValues(attr); len(vals) > 0 {
+				attrs[attr] = vals
+			}
+		}
+
+		w.Write(r.NewSearchResponseEntry(e.DN, gldap.WithAttributes(attrs)))
+	}
+}
+
+func matchFilter(p *ber.Packet, e *gldap.Entry) bool {
+	switch p.Tag {
+	case ldap.FilterAnd:
+		for _, child := range p.Children {
+			if !matchFilter(child, e) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range p.Children {
+			if matchFilter(child, e) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matchFilter(p.Children[0], e)
+	case ldap.FilterPresent:
+		return len(e.GetAttributeValues(p.Data.String())) > 0
+	case ldap.FilterEqualityMatch:
+		attr := p.Children[0].Value.(string)
+		val := p.Children[1].Value.(string)
+		return slices.ContainsFunc(e.GetAttributeValues(attr), func(itm string) bool {
+			return strings.EqualFold(itm, val)
+		})
+	default:
+		return false
+	}
+}
+
+type fakeRateLimitC struct {
+	rratelimitv1.MainServiceClient
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (c *fakeRateLimitC) CheckSlidingWindow(ctx context.Context,
+	in *rratelimitv1.CheckSlidingWindowRequest, opts ...grpc.CallOption) (*rratelimitv1.CheckSlidingWindowResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]int64)
+	}
+	c.counts[string(in.Key)]++
+	return &rratelimitv1.CheckSlidingWindowResponse{
+		IsAllowed: c.counts[string(in.Key)] <= in.Limit,
+	}, nil
+}
+
+type fakeOcteliumC struct {
+	octeliumc.ClientInterface
+	rateLimitC *fakeRateLimitC
+}
+
+func (c *fakeOcteliumC) RateLimitC() rratelimitv1.MainServiceClient {
+	return c.rateLimitC
+}
+
+func newTestConnector(d *testDirectory, isStartTLS bool, group *corev1.IdentityProvider_Spec_LDAP_Group) *Connector {
+	scheme := "ldaps"
+	if isStartTLS {
+		scheme = "ldap"
+	}
+
+	return &Connector{
+		octeliumC: &fakeOcteliumC{
+			rateLimitC: &fakeRateLimitC{},
+		},
+		c: &corev1.IdentityProvider{
+			Metadata: &metav1.Metadata{
+				Name: "ldap",
+				Uid:  "6e0f8c3c-5b9f-4a3e-8c7e-2f1c8d2b7a11",
+			},
+			Spec: &corev1.IdentityProvider_Spec{
+				Type: &corev1.IdentityProvider_Spec_Ldap{
+					Ldap: &corev1.IdentityProvider_Spec_LDAP{
+						Url:        fmt.Sprintf("%s://%s", scheme, d.addr),
+						IsStartTLS: isStartTLS,
+						BindDN:     testBindDN,
+						User: &corev1.IdentityProvider_Spec_LDAP_User{
+							BaseDN:     "ou=people,dc=example,dc=com",
+							Filter:     "(objectClass=person)",
+							Attributes: []string{"departmentNumber", "title"},
+						},
+						Group: group,
+					},
+				},
+			},
+			Status: &corev1.IdentityProvider_Status{
+				Type: corev1.IdentityProvider_Status_LDAP,
+			},
+		},
+		cc: &corev1.ClusterConfig{
+			Status: &corev1.ClusterConfig_Status{
+				Domain: "example.com",
+			},
+		},
+		bindPassword: testBindPassword,
+		tlsConfig:    d.clientTLS,
+	}
+}
+
+func newCallbackRequest(username, password string) *http.Request {
+	form := url.Values{}
+	form.Set("username", username)
+	form.Set("password", password)
+
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+
+	for _, isStartTLS := range []bool{false, true} {
+		d := newTestDirectory(t, isStartTLS)
+
+		{
+			c := newTestConnector(d, isStartTLS, &corev1.IdentityProvider_Spec_LDAP_Group{
+				BaseDN:   "ou=groups,dc=example,dc=com",
+				IsNested: true,
+			})
+
+			res, err := c.authenticate(ctx, "alice", "alice-password")
+			assert.Nil(t, err, "%+v", err)
+			assert.Equal(t, "uid=alice,ou=people,dc=example,dc=com", res.dn)
+			assert.Equal(t, "alice", res.username)
+			assert.Equal(t, "alice@example.com", res.email)
+			assert.ElementsMatch(t, []string{"devs", "engineering", "all"}, res.groups)
+			assert.Equal(t, "42", res.attrs["departmentNumber"])
+			assert.Equal(t, []any{"engineer", "oncall"}, res.attrs["title"])
+
+			_, err = c.authenticate(ctx, "alice", "wrong-password")
+			assert.NotNil(t, err)
+
+			_, err = c.authenticate(ctx, "unknown", "alice-password")
+			assert.NotNil(t, err)
+
+			_, err = c.authenticate(ctx, "carol", "carol-password")
+			assert.NotNil(t, err, "User outside the baseDN")
+
+			_, err = c.authenticate(ctx, "alice)(uid=*", "alice-password")
+			assert.NotNil(t, err)
+		}
+
+		{
+			c := newTestConnector(d, isStartTLS, &corev1.IdentityProvider_Spec_LDAP_Group{
+				BaseDN: "ou=groups,dc=example,dc=com",
+			})
+
+			res, err := c.authenticate(ctx, "alice", "alice-password")
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"devs"}, res.groups)
+
+			res, err = c.authenticate(ctx, "bob", "bob-password")
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"ops"}, res.groups)
+			assert.Equal(t, "", res.email)
+		}
+
+		{
+			c := newTestConnector(d, isStartTLS, nil)
+
+			res, err := c.authenticate(ctx, "alice", "alice-password")
+			assert.Nil(t, err)
+			assert.Nil(t, res.groups)
+		}
+
+		{
+			c := newTestConnector(d, isStartTLS, nil)
+			c.bindPassword = "wrong"
+
+			_, err := c.authenticate(ctx, "alice", "alice-password")
+			assert.NotNil(t, err)
+		}
+
+		{
+			c := newTestConnector(d, isStartTLS, nil)
+			c.tlsConfig = &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				ServerName: "localhost",
+			}
+
+			_, err := c.authenticate(ctx, "alice", "alice-password")
+			assert.NotNil(t, err, "untrusted server certificate")
+		}
+	}
+}
+
+func TestHandleCallback(t *testing.T) {
+	d := newTestDirectory(t, false)
+	c := newTestConnector(d, false, &corev1.IdentityProvider_Spec_LDAP_Group{
+		BaseDN:   "ou=groups,dc=example,dc=com",
+		IsNested: true,
+	})
+
+	{
+		loginResp, err := c.GetLogin(httptest.NewRequest(http.MethodGet, "/", nil), "state-id")
+		assert.Nil(t, err)
+
+		u, err := url.Parse(loginResp.LoginURL)
+		assert.Nil(t, err)
+		assert.Equal(t, "example.com", u.Host)
+		assert.Equal(t, "/login/ldap", u.Path)
+		assert.Equal(t, "state-id", u.Query().Get("state"))
+		assert.Equal(t, c.c.Metadata.Uid, u.Query().Get("idp"))
+	}
+
+	{
+		info, err := c.HandleCallback(newCallbackRequest("alice", "alice-password"), nil)
+		assert.Nil(t, err)
+
+		idpInfo := info.GetIdentityProvider()
+		assert.Equal(t, corev1.IdentityProvider_Status_LDAP, idpInfo.Type)
+		assert.Equal(t, "alice", idpInfo.Identifier)
+		assert.Equal(t, "alice@example.com", idpInfo.Email)
+		assert.ElementsMatch(t, []string{"devs", "engineering", "all"}, idpInfo.Groups)
+		assert.Equal(t, "42", idpInfo.Attrs.AsMap()["departmentNumber"])
+		assert.Equal(t, c.c.Metadata.Name, idpInfo.IdentityProviderRef.Name)
+	}
+
+	{
+		_, err := c.HandleCallback(newCallbackRequest("alice", ""), nil)
+		assert.NotNil(t, err, "empty passwords must never be sent to the directory")
+
+		_, err = c.HandleCallback(newCallbackRequest("", "alice-password"), nil)
+		assert.NotNil(t, err)
+
+		_, err = c.HandleCallback(newCallbackRequest(strings.Repeat("a", maxUsernameLen+1), "pass"), nil)
+		assert.NotNil(t, err)
+
+		_, err = c.HandleCallback(httptest.NewRequest(http.MethodGet, "/callback?username=alice&password=alice-password", nil), nil)
+		assert.NotNil(t, err)
+	}
+
+	{
+		for range maxAttemptsPerUsername {
+			_, err := c.HandleCallback(newCallbackRequest("bob", "wrong-password"), nil)
+			assert.NotNil(t, err)
+			assert.NotContains(t, err.Error(), "Too many login attempts")
+		}
+
+		_, err := c.HandleCallback(newCallbackRequest("BOB", "bob-password"), nil)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "Too many login attempts")
+
+		_, err = c.HandleCallback(newCallbackRequest("alice", "alice-password"), nil)
+		assert.Nil(t, err)
+	}
+
+	{
+		c.octeliumC = &fakeOcteliumC{
+			rateLimitC: &fakeRateLimitC{},
+		}
+
+		newReq := func(username string) *http.Request {
+			req := newCallbackRequest(username, "wrong-password")
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+			return req
+		}
+
+		for i := range maxAttemptsPerIP {
+			_, err := c.HandleCallback(newReq(fmt.Sprintf("user-%d", i)), nil)
+			assert.NotNil(t, err)
+			assert.NotContains(t, err.Error(), "Too many login attempts")
+		}
+
+		_, err := c.HandleCallback(newReq("alice"), nil)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "Too many login attempts")
+	}
+}
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+
+	tst, err := tests.Initialize(nil)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		tst.Destroy()
+	})
+	fakeC := tst.C
+
+	cc, err := tst.C.OcteliumC.CoreV1Utils().GetClusterConfig(ctx)
+	assert.Nil(t, err)
+
+	adminSrv := admin.NewServer(&admin.Opts{
+		OcteliumC:  fakeC.OcteliumC,
+		IsEmbedded: true,
+	})
+
+	d := newTestDirectory(t, false)
+
+	sec, err := adminSrv.CreateSecret(ctx, &corev1.Secret{
+		Metadata: &metav1.Metadata{
+			Name: utilrand.GetRandomStringCanonical(8),
+		},
+		Spec: &corev1.Secret_Spec{},
+		Data: &corev1.Secret_Data{
+			Type: &corev1.Secret_Data_Value{
+				Value: testBindPassword,
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	idp, err := adminSrv.CreateIdentityProvider(ctx, &corev1.IdentityProvider{
+		Metadata: &metav1.Metadata{
+			Name: utilrand.GetRandomStringCanonical(8),
+		},
+		Spec: &corev1.IdentityProvider_Spec{
+			Type: &corev1.IdentityProvider_Spec_Ldap{
+				Ldap: &corev1.IdentityProvider_Spec_LDAP{
+					Url:    fmt.Sprintf("ldaps://%s", d.addr),
+					BindDN: testBindDN,
+					BindPassword: &corev1.IdentityProvider_Spec_LDAP_BindPassword{
+						Type: &corev1.IdentityProvider_Spec_LDAP_BindPassword_FromSecret{
+							FromSecret: sec.Metadata.Name,
+						},
+					},
+					User: &corev1.IdentityProvider_Spec_LDAP_User{
+						BaseDN: "ou=people,dc=example,dc=com",
+					},
+					Group: &corev1.IdentityProvider_Spec_LDAP_Group{
+						BaseDN:   "ou=groups,dc=example,dc=com",
+						IsNested: true,
+					},
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, corev1.IdentityProvider_Status_LDAP, idp.Status.Type)
+
+	provider, err := NewConnector(ctx, &utils.ProviderOpts{
+		OcteliumC:     fakeC.OcteliumC,
+		Provider:      idp,
+		ClusterConfig: cc,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, testBindPassword, provider.bindPassword)
+
+	provider.tlsConfig = d.clientTLS
+
+	info, err := provider.HandleCallback(newCallbackRequest("alice", "alice-password"), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", info.GetIdentityProvider().Identifier)
+	assert.ElementsMatch(t, []string{"devs", "engineering", "all"}, info.GetIdentityProvider().Groups)
+}
@@ -33,7 +33,9 @@ import (
 	"github.com/octelium/octelium/apis/main/corev1"
 	"github.com/octelium/octelium/apis/rsc/rmetav1"
 	"github.com/octelium/octelium/cluster/authserver/authserver/authncache"
+	"github.com/octelium/octelium/cluster/authserver/authserver/providers/email"
 	"github.com/octelium/octelium/cluster/authserver/authserver/providers/github"
+	"github.com/octelium/octelium/cluster/authserver/authserver/providers/ldap"
 	"github.com/octelium/octelium/cluster/authserver/authserver/providers/oidc"
 	"github.com/octelium/octelium/cluster/authserver/authserver/providers/oidcassertion"
 	"github.com/octelium/octelium/cluster/authserver/authserver/providers/saml"
@@ -79,6 +81,8 @@ type server struct {
 	jwkCtl *jwkctl.Controller
 	ccCtl  *ccctl.Controller
 
+	identityAssertionJWKCtl *jwkctl.Controller
+
 	celEngine *celengine.CELEngine
 
 	passkeyCtl  *webauthn.WebAuthn
@@ -139,6 +143,14 @@ func initServer(ctx context.Context,
 	}
 	ret.jwkCtl = jwkCtl
 
+	ret.identityAssertionJWKCtl, err = jwkctl.NewJWKController(ctx, octeliumC, &jwkctl.Opts{
+		IsVerificationMode:  true,
+		IsIdentityAssertion: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	if err := ret.setIdentityProviders(ctx); err != nil {
 		return nil, err
 	}
@@ -306,6 +318,26 @@ func (s *server) setIdentityProviders(ctx context.Context) error {
 			if err != nil {
 				return err
 			}
+		case *corev1.IdentityProvider_Spec_Ldap:
+			c, err = ldap.NewConnector(ctx, &utils.ProviderOpts{
+				OcteliumC:     s.octeliumC,
+				Provider:      idp,
+				ClusterConfig: clusterCfg,
+				CELEngine:     s.celEngine,
+			})
+			if err != nil {
+				return err
+			}
+		case *corev1.IdentityProvider_Spec_Email_:
+			c, err = email.NewConnector(ctx, &utils.ProviderOpts{
+				OcteliumC:     s.octeliumC,
+				Provider:      idp,
+				ClusterConfig: clusterCfg,
+				CELEngine:     s.celEngine,
+			})
+			if err != nil {
+				return err
+			}
 
 		default:
 			return nil
@@ -367,6 +399,10 @@ func (s *server) run(ctx context.Context, grpcMode bool) error {
 		return err
 	}
 
+	if err := s.identityAssertionJWKCtl.Run(ctx); err != nil {
+		return err
+	}
+
 	if err := s.ccCtl.Run(ctx); err != nil {
 		return err
 	}
@@ -427,6 +463,9 @@ func (s *server) run(ctx context.Context, grpcMode bool) error {
 
 		mux.HandleFunc("GET /", s.handleLogin)
 		mux.HandleFunc("GET /login", s.handleLogin)
+		mux.HandleFunc("GET /login/ldap", s.handleLDAPLogin)
+		mux.HandleFunc("GET /login/email", s.handleEmailLogin)
+		mux.HandleFunc("POST /login/email", s.handleEmailLoginSend)
 
 		mux.HandleFunc("POST /begin", s.handleAuth)
 		mux.HandleFunc("GET /callback", s.handleAuthCallback)
@@ -437,6 +476,7 @@ func (s *server) run(ctx context.Context, grpcMode bool) error {
 		mux.HandleFunc("POST /oauth2/token", s.handleOAuth2Token)
 
 		mux.HandleFunc("GET /.well-known/oauth-authorization-server", s.handleOAuth2Metadata)
+		mux.HandleFunc("GET /.well-known/jwks.json", s.handleJWKS)
 
 		mux.Handle("GET /assets/{file}", s.handleStatic())
 
@@ -558,7 +598,9 @@ func (s *server) getWebProviderFromUID(uid string) (utils.Provider, error) {
 		switch idp.Status.Type {
 		case corev1.IdentityProvider_Status_GITHUB,
 			corev1.IdentityProvider_Status_OIDC,
-			corev1.IdentityProvider_Status_SAML:
+			corev1.IdentityProvider_Status_SAML,
+			corev1.IdentityProvider_Status_LDAP,
+			corev1.IdentityProvider_Status_EMAIL:
 		default:
 			return nil, s.errPermissionDenied("This is not a web IdentityProvider")
 		}
@@ -31,6 +31,7 @@ import (
 	"github.com/octelium/octelium/cluster/common/grpcutils"
 	"github.com/octelium/octelium/cluster/common/oscope"
 	"github.com/octelium/octelium/cluster/common/sessionc"
+	"github.com/octelium/octelium/cluster/common/sessionrisk"
 	"github.com/octelium/octelium/cluster/common/vutils"
 	"github.com/octelium/octelium/pkg/apiutils/umetav1"
 	"github.com/octelium/octelium/pkg/common/opkce"
@@ -374,6 +375,13 @@ func (s *server) doAuthenticateWithRefreshToken(ctx context.Context, _ *authv1.A
 		Type: corev1.Session_Status_Authentication_Info_REFRESH_TOKEN,
 	})
 
+	switch action, err := s.enforceSessionRisk(ctx, cc, sess); {
+	case err != nil:
+		return nil, err
+	case action == sessionrisk.ActionTerminate:
+		return nil, s.errPermissionDenied("The Session has been terminated due to its risk score")
+	}
+
 	if _, err := s.octeliumC.CoreC().UpdateSession(ctx, sess); err != nil {
 		return nil, s.errInternalErr(err)
 	}
@@ -589,6 +597,8 @@ func (s *server) doAuthenticateWithAuthenticator(ctx context.Context,
 
 	s.setCurrAuthenticationGRPC(ctx, sess, cc, authInfo)
 	sess.Status.AuthenticatorAction = corev1.Session_Status_AUTHENTICATOR_ACTION_UNSET
+	sessionrisk.Reset(sess)
+	sess.Status.StepUp = getSessionStepUp(cc, authInfo)
 
 	if sess.Status.RequiredAuthenticatorRef == nil {
 		switch authn.Status.Type {
@@ -0,0 +1,65 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package authserver
+
+import (
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+)
+
+const defaultStepUpDuration = 15 * time.Minute
+
+func getAuthenticatorAAL(
+	info *corev1.Session_Status_Authentication_Info_Authenticator) corev1.Session_Status_Authentication_Info_AAL {
+	switch info.GetType() {
+	case corev1.Authenticator_Status_FIDO:
+		if fido := info.GetInfo().GetFido(); fido != nil && fido.IsHardware && fido.UserVerified {
+			return corev1.Session_Status_Authentication_Info_AAL3
+		}
+		return corev1.Session_Status_Authentication_Info_AAL2
+	case corev1.Authenticator_Status_TOTP, corev1.Authenticator_Status_TPM:
+		return corev1.Session_Status_Authentication_Info_AAL2
+	default:
+		return corev1.Session_Status_Authentication_Info_AAL_UNSET
+	}
+}
+
+func getSessionStepUp(cc *corev1.ClusterConfig,
+	authInfo *corev1.Session_Status_Authentication_Info) *corev1.Session_Status_StepUp {
+	info := authInfo.GetAuthenticator()
+	if info == nil {
+		return nil
+	}
+
+	duration := umetav1.ToDuration(cc.GetSpec().GetAuthenticator().GetStepUpDuration()).ToGo()
+	if duration <= 0 {
+		duration = defaultStepUpDuration
+	}
+
+	now := time.Now()
+
+	return &corev1.Session_Status_StepUp{
+		SetAt:             pbutils.Timestamp(now),
+		ExpiresAt:         pbutils.Timestamp(now.Add(duration)),
+		AuthenticatorRef:  info.AuthenticatorRef,
+		AuthenticatorType: info.Type,
+		Aal:               authInfo.Aal,
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package authserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSessionStepUp(t *testing.T) {
+	getAuthInfo := func(typ corev1.Authenticator_Status_Type,
+		fido *corev1.Session_Status_Authentication_Info_Authenticator_Info_FIDO) *corev1.Session_Status_Authentication_Info {
+		ret := &corev1.Session_Status_Authentication_Info{
+			Type: corev1.Session_Status_Authentication_Info_AUTHENTICATOR,
+			Details: &corev1.Session_Status_Authentication_Info_Authenticator_{
+				Authenticator: &corev1.Session_Status_Authentication_Info_Authenticator{
+					AuthenticatorRef: &metav1.ObjectReference{
+						Name: "fido-abcdef",
+						Uid:  "6a2d1f2c-3d4b-4e8a-9b1a-0c1d2e3f4a5b",
+					},
+					Type: typ,
+				},
+			},
+		}
+		if fido != nil {
+			ret.GetAuthenticator().Info = &corev1.Session_Status_Authentication_Info_Authenticator_Info{
+				Type: &corev1.Session_Status_Authentication_Info_Authenticator_Info_Fido{
+					Fido: fido,
+				},
+			}
+		}
+		ret.Aal = getAuthenticatorAAL(ret.GetAuthenticator())
+		return ret
+	}
+
+	{
+		authInfo := getAuthInfo(corev1.Authenticator_Status_FIDO,
+			&corev1.Session_Status_Authentication_Info_Authenticator_Info_FIDO{
+				IsHardware:   true,
+				UserVerified: true,
+			})
+		assert.Equal(t, corev1.Session_Status_Authentication_Info_AAL3, authInfo.Aal)
+
+		stepUp := getSessionStepUp(&corev1.ClusterConfig{}, authInfo)
+		assert.Equal(t, corev1.Authenticator_Status_FIDO, stepUp.AuthenticatorType)
+		assert.Equal(t, corev1.Session_Status_Authentication_Info_AAL3, stepUp.Aal)
+		assert.Equal(t, "fido-abcdef", stepUp.AuthenticatorRef.Name)
+		assert.Equal(t, defaultStepUpDuration, stepUp.ExpiresAt.AsTime().Sub(stepUp.SetAt.AsTime()))
+	}
+
+	{
+		authInfo := getAuthInfo(corev1.Authenticator_Status_FIDO,
+			&corev1.Session_Status_Authentication_Info_Authenticator_Info_FIDO{
+				IsSoftware:   true,
+				UserVerified: true,
+			})
+		assert.Equal(t, corev1.Session_Status_Authentication_Info_AAL2, authInfo.Aal)
+	}
+
+	{
+		authInfo := getAuthInfo(corev1.Authenticator_Status_TOTP, nil)
+		assert.Equal(t, corev1.Session_Status_Authentication_Info_AAL2, authInfo.Aal)
+
+		stepUp := getSessionStepUp(&corev1.ClusterConfig{
+			Spec: &corev1.ClusterConfig_Spec{
+				Authenticator: &corev1.ClusterConfig_Spec_Authenticator{
+					StepUpDuration: &metav1.Duration{
+						Type: &metav1.Duration_Minutes{Minutes: 3},
+					},
+				},
+			},
+		}, authInfo)
+		assert.Equal(t, 3*time.Minute, stepUp.ExpiresAt.AsTime().Sub(stepUp.SetAt.AsTime()))
+	}
+
+	assert.Nil(t, getSessionStepUp(&corev1.ClusterConfig{}, &corev1.Session_Status_Authentication_Info{}))
+}
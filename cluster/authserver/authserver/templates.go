@@ -214,3 +214,117 @@ func (s *server) setDomainCookie(w http.ResponseWriter) {
 		SameSite: http.SameSiteNoneMode,
 	})
 }
+
+var ldapLoginTmpl = template.Must(template.New("ldap-login.html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Sign in with {{ .DisplayName }}</title>
+<style>
+body { font-family: system-ui, sans-serif; background: #f4f5f7; margin: 0; }
+main { max-width: 360px; margin: 12vh auto; background: #fff; padding: 32px; border-radius: 8px; box-shadow: 0 1px 4px rgba(0,0,0,.1); }
+h1 { font-size: 1.2rem; margin: 0 0 24px; }
+label { display: block; font-size: .9rem; margin-bottom: 4px; }
+input[type=text], input[type=password] { width: 100%; box-sizing: border-box; padding: 8px; margin-bottom: 16px; border: 1px solid #ccc; border-radius: 4px; }
+button { width: 100%; padding: 10px; border: 0; border-radius: 4px; background: #1e293b; color: #fff; font-weight: 600; cursor: pointer; }
+</style>
+</head>
+<body>
+<main>
+<h1>Sign in with {{ .DisplayName }}</h1>
+<form method="POST" action="{{ .ActionURL }}" autocomplete="on">
+<input type="hidden" name="RelayState" value="{{ .State }}">
+<label for="username">Username</label>
+<input type="text" id="username" name="username" autocomplete="username" maxlength="256" required autofocus>
+<label for="password">Password</label>
+<input type="password" id="password" name="password" autocomplete="current-password" maxlength="1024" required>
+<button type="submit">Sign in</button>
+</form>
+</main>
+</body>
+</html>
+`))
+
+type ldapLoginTemplateArgs struct {
+	DisplayName string
+	State       string
+	ActionURL   string
+}
+
+func (s *server) renderLDAPLogin(w http.ResponseWriter, args *ldapLoginTemplateArgs) {
+	var out bytes.Buffer
+	if err := ldapLoginTmpl.Execute(&out, args); err != nil {
+		zap.L().Error("Could not execute LDAP login template", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	s.setHTMLSecurityHeaders(w, utilrand.GetRandomStringCanonical(24))
+	w.Write(out.Bytes())
+}
+
+var emailLoginTmpl = template.Must(template.New("email-login.html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Sign in with {{ .DisplayName }}</title>
+<style>
+body { font-family: system-ui, sans-serif; background: #f4f5f7; margin: 0; }
+main { max-width: 360px; margin: 12vh auto; background: #fff; padding: 32px; border-radius: 8px; box-shadow: 0 1px 4px rgba(0,0,0,.1); }
+h1 { font-size: 1.2rem; margin: 0 0 24px; }
+p { font-size: .9rem; color: #475569; margin: 0 0 16px; }
+p.error { color: #dc2626; font-weight: 600; }
+label { display: block; font-size: .9rem; margin-bottom: 4px; }
+input[type=email], input[type=text] { width: 100%; box-sizing: border-box; padding: 8px; margin-bottom: 16px; border: 1px solid #ccc; border-radius: 4px; }
+button { width: 100%; padding: 10px; border: 0; border-radius: 4px; background: #1e293b; color: #fff; font-weight: 600; cursor: pointer; }
+</style>
+</head>
+<body>
+<main>
+<h1>Sign in with {{ .DisplayName }}</h1>
+{{ if .Error }}<p class="error">{{ .Error }}</p>{{ end }}
+{{ if .IsCodeSent }}
+<p>If the email address belongs to a User, a one-time code has been sent to it. The code can only be used once.</p>
+<form method="POST" action="{{ .ActionURL }}" autocomplete="off">
+<input type="hidden" name="RelayState" value="{{ .State }}">
+<label for="code">Code</label>
+<input type="text" id="code" name="code" inputmode="numeric" pattern="[0-9]{6}" maxlength="6" autocomplete="one-time-code" required autofocus>
+<button type="submit">Sign in</button>
+</form>
+{{ else }}
+<form method="POST" action="{{ .SendURL }}" autocomplete="on">
+<input type="hidden" name="state" value="{{ .State }}">
+<input type="hidden" name="idp" value="{{ .IDP }}">
+<label for="email">Email</label>
+<input type="email" id="email" name="email" autocomplete="email" maxlength="320" required autofocus>
+<button type="submit">Send code</button>
+</form>
+{{ end }}
+</main>
+</body>
+</html>
+`))
+
+type emailLoginTemplateArgs struct {
+	DisplayName string
+	State       string
+	IDP         string
+	SendURL     string
+	ActionURL   string
+	IsCodeSent  bool
+	Error       string
+}
+
+func (s *server) renderEmailLogin(w http.ResponseWriter, args *emailLoginTemplateArgs) {
+	var out bytes.Buffer
+	if err := emailLoginTmpl.Execute(&out, args); err != nil {
+		zap.L().Error("Could not execute email login template", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	s.setHTMLSecurityHeaders(w, utilrand.GetRandomStringCanonical(24))
+	w.Write(out.Bytes())
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/octelium/octelium/cluster/common/apivalidation"
 	"github.com/octelium/octelium/cluster/common/grpcutils"
 	"github.com/octelium/octelium/cluster/common/sessionc"
+	"github.com/octelium/octelium/cluster/common/sessionrisk"
 	"github.com/octelium/octelium/cluster/common/urscsrv"
 	"github.com/octelium/octelium/cluster/common/vutils"
 	"go.uber.org/zap"
@@ -93,6 +94,13 @@ func (s *server) createOrUpdateSessWeb(r *http.Request,
 
 	sess.Status.AuthenticatorAction = authenticatorAction
 
+	switch action, err := s.enforceSessionRisk(ctx, cc, sess); {
+	case err != nil:
+		return nil, err
+	case action == sessionrisk.ActionTerminate:
+		return doCreate()
+	}
+
 	sess, err = s.octeliumC.CoreC().UpdateSession(ctx, sess)
 	if err != nil {
 		return nil, err
@@ -221,6 +229,18 @@ func (s *server) setCurrAuthenticationGRPC(ctx context.Context, sess *corev1.Ses
 		grpcutils.GetHeaderValueMust(ctx, vutils.GetDownstreamIPHeaderCanonical()))
 }
 
+// enforceSessionRisk applies the action required by the Session's risk score
+// as evaluated upon setting its current authentication
+func (s *server) enforceSessionRisk(ctx context.Context,
+	cc *corev1.ClusterConfig, sess *corev1.Session) (sessionrisk.Action, error) {
+	action, err := sessionrisk.Enforce(ctx, s.octeliumC, cc, sess)
+	if err != nil {
+		return action, s.errInternalErr(err)
+	}
+
+	return action, nil
+}
+
 func (s *server) setCurrAuthentication(sess *corev1.Session, authInfo *corev1.Session_Status_Authentication_Info, userAgent string, cc *corev1.ClusterConfig, xff string) {
 	sessionc.SetCurrAuthentication(&sessionc.SetCurrAuthenticationOpts{
 		Session:       sess,
@@ -0,0 +1,292 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package accessrequest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/common/apivalidation"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/cluster/common/vutils"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/octelium/octelium/pkg/utils/utilrand"
+)
+
+const (
+	defaultDuration     = 1 * time.Hour
+	defaultMaxDuration  = 24 * time.Hour
+	pendingDuration     = 24 * time.Hour
+	maxJustificationLen = 1024
+	maxReasonLen        = 1024
+)
+
+// GetDuration returns the requested duration of the access
+func GetDuration(ar *corev1.AccessRequest) time.Duration {
+	if ret := umetav1.ToDuration(ar.GetSpec().GetDuration()).ToGo(); ret > 0 {
+		return ret
+	}
+	return defaultDuration
+}
+
+func getMaxDuration(cc *corev1.ClusterConfig) time.Duration {
+	if ret := umetav1.ToDuration(cc.GetSpec().GetAccessRequest().GetMaxDuration()).ToGo(); ret > 0 {
+		return ret
+	}
+	return defaultMaxDuration
+}
+
+// ValidateSpec validates the AccessRequest spec against the ClusterConfig
+func ValidateSpec(cc *corev1.ClusterConfig, spec *corev1.AccessRequest_Spec) error {
+	if spec == nil {
+		return grpcutils.InvalidArg("Nil spec")
+	}
+
+	switch {
+	case spec.Service != "" && spec.Namespace != "":
+		return grpcutils.InvalidArg("You cannot set both the service and the namespace")
+	case spec.Service != "":
+		if err := apivalidation.ValidateName(spec.Service, 0, 1); err != nil {
+			return err
+		}
+	case spec.Namespace != "":
+		if err := apivalidation.ValidateName(spec.Namespace, 0, 0); err != nil {
+			return err
+		}
+	default:
+		return grpcutils.InvalidArg("You must set either the service or the namespace")
+	}
+
+	if err := apivalidation.ValidateDuration(spec.Duration); err != nil {
+		return err
+	}
+
+	if umetav1.ToDuration(spec.Duration).ToGo() > getMaxDuration(cc) {
+		return grpcutils.InvalidArg("The duration exceeds the maximum duration: %s", getMaxDuration(cc))
+	}
+
+	if spec.Justification == "" {
+		return grpcutils.InvalidArg("You must provide a justification")
+	}
+
+	if len(spec.Justification) > maxJustificationLen {
+		return grpcutils.InvalidArg("Justification is too long")
+	}
+
+	return nil
+}
+
+// Create creates a PENDING AccessRequest for the User after resolving its
+// requested Service or Namespace
+func Create(ctx context.Context, octeliumC octeliumc.ClientInterface,
+	usr *corev1.User, spec *corev1.AccessRequest_Spec) (*corev1.AccessRequest, error) {
+	cc, err := octeliumC.CoreV1Utils().GetClusterConfig(ctx)
+	if err != nil {
+		return nil, grpcutils.InternalWithErr(err)
+	}
+
+	if err := ValidateSpec(cc, spec); err != nil {
+		return nil, err
+	}
+
+	status := &corev1.AccessRequest_Status{
+		UserRef:   umetav1.GetObjectReference(usr),
+		State:     corev1.AccessRequest_Status_PENDING,
+		ExpiresAt: pbutils.Timestamp(time.Now().Add(pendingDuration)),
+	}
+
+	if spec.Service != "" {
+		svc, err := octeliumC.CoreC().GetService(ctx, &rmetav1.GetOptions{
+			Name: vutils.GetServiceFullNameFromName(spec.Service),
+		})
+		if err != nil {
+			return nil, grpcutils.K8sNotFoundOrInternalWithErr(err)
+		}
+		if err := apivalidation.CheckIsUserHidden(svc); err != nil {
+			return nil, err
+		}
+		status.ServiceRef = umetav1.GetObjectReference(svc)
+	} else {
+		ns, err := octeliumC.CoreC().GetNamespace(ctx, &rmetav1.GetOptions{
+			Name: spec.Namespace,
+		})
+		if err != nil {
+			return nil, grpcutils.K8sNotFoundOrInternalWithErr(err)
+		}
+		if err := apivalidation.CheckIsUserHidden(ns); err != nil {
+			return nil, err
+		}
+		status.NamespaceRef = umetav1.GetObjectReference(ns)
+	}
+
+	return octeliumC.CoreC().CreateAccessRequest(ctx, &corev1.AccessRequest{
+		Metadata: &metav1.Metadata{
+			Name: fmt.Sprintf("%s-%s", usr.Metadata.Name, utilrand.GetRandomStringCanonical(8)),
+		},
+		Spec:   spec,
+		Status: status,
+	})
+}
+
+// IsApprover checks whether the User is a member of one of the approver
+// Groups set by the ClusterConfig
+func IsApprover(cc *corev1.ClusterConfig, groups []*corev1.Group) bool {
+	approverGroups := cc.GetSpec().GetAccessRequest().GetApproverGroups()
+	for _, grp := range groups {
+		if grp.GetMetadata() != nil && slices.Contains(approverGroups, grp.Metadata.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Decide approves or denies a PENDING AccessRequest by the approver
+func Decide(ctx context.Context, octeliumC octeliumc.ClientInterface,
+	ar *corev1.AccessRequest, approver *corev1.User, isApproved bool, reason string) (*corev1.AccessRequest, error) {
+	if len(reason) > maxReasonLen {
+		return nil, grpcutils.InvalidArg("Reason is too long")
+	}
+
+	if ar.Status.State != corev1.AccessRequest_Status_PENDING {
+		return nil, grpcutils.InvalidArg("The AccessRequest is not PENDING")
+	}
+
+	if ar.Status.ExpiresAt.IsValid() && time.Now().After(ar.Status.ExpiresAt.AsTime()) {
+		return nil, grpcutils.InvalidArg("The AccessRequest has expired")
+	}
+
+	if approver != nil && ar.Status.UserRef.GetUid() == approver.Metadata.Uid {
+		return nil, grpcutils.Unauthorized("You cannot decide your own AccessRequest")
+	}
+
+	ar.Status.Decision = &corev1.AccessRequest_Status_Decision{
+		DecidedAt: pbutils.Now(),
+		Reason:    reason,
+	}
+	if approver != nil {
+		ar.Status.Decision.UserRef = umetav1.GetObjectReference(approver)
+	}
+
+	if isApproved {
+		ar.Status.State = corev1.AccessRequest_Status_APPROVED
+		ar.Status.ExpiresAt = pbutils.Timestamp(time.Now().Add(GetDuration(ar)))
+	} else {
+		ar.Status.State = corev1.AccessRequest_Status_DENIED
+	}
+
+	ret, err := octeliumC.CoreC().UpdateAccessRequest(ctx, ar)
+	if err != nil {
+		return nil, grpcutils.InternalWithErr(err)
+	}
+
+	return ret, nil
+}
+
+// GetPolicyTriggerName returns the name of the PolicyTrigger that grants the
+// access of the AccessRequest
+func GetPolicyTriggerName(ar *corev1.AccessRequest) string {
+	return fmt.Sprintf("access-request-%s", ar.Metadata.Uid)
+}
+
+// NewPolicyTrigger returns the time-bounded PolicyTrigger that grants the
+// access of an APPROVED AccessRequest to its User
+func NewPolicyTrigger(ar *corev1.AccessRequest) *corev1.PolicyTrigger {
+	conditions := []*corev1.PolicyTrigger_Status_PreCondition{
+		{
+			Type: &corev1.PolicyTrigger_Status_PreCondition_UserRef{
+				UserRef: ar.Status.UserRef,
+			},
+		},
+		{
+			Type: &corev1.PolicyTrigger_Status_PreCondition_NotBefore{
+				NotBefore: ar.Status.Decision.GetDecidedAt(),
+			},
+		},
+		{
+			Type: &corev1.PolicyTrigger_Status_PreCondition_NotAfter{
+				NotAfter: ar.Status.ExpiresAt,
+			},
+		},
+	}
+
+	if ar.Status.ServiceRef != nil {
+		conditions = append(conditions, &corev1.PolicyTrigger_Status_PreCondition{
+			Type: &corev1.PolicyTrigger_Status_PreCondition_ServiceRef{
+				ServiceRef: ar.Status.ServiceRef,
+			},
+		})
+	} else {
+		conditions = append(conditions, &corev1.PolicyTrigger_Status_PreCondition{
+			Type: &corev1.PolicyTrigger_Status_PreCondition_NamespaceRef{
+				NamespaceRef: ar.Status.NamespaceRef,
+			},
+		})
+	}
+
+	return &corev1.PolicyTrigger{
+		Metadata: &metav1.Metadata{
+			Name: GetPolicyTriggerName(ar),
+		},
+		Spec: &corev1.PolicyTrigger_Spec{},
+		Status: &corev1.PolicyTrigger_Status{
+			OwnerRef: umetav1.GetObjectReference(ar),
+			PreCondition: &corev1.PolicyTrigger_Status_PreCondition{
+				Type: &corev1.PolicyTrigger_Status_PreCondition_All_{
+					All: &corev1.PolicyTrigger_Status_PreCondition_All{
+						Of: conditions,
+					},
+				},
+			},
+			InlinePolicies: []*corev1.InlinePolicy{
+				{
+					Name: "access-request",
+					Spec: &corev1.Policy_Spec{
+						Rules: []*corev1.Policy_Spec_Rule{
+							{
+								Name:   "allow",
+								Effect: corev1.Policy_Spec_Rule_ALLOW,
+								Condition: &corev1.Condition{
+									Type: &corev1.Condition_MatchAny{
+										MatchAny: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// IsExpired checks whether a PENDING or an APPROVED AccessRequest has expired
+func IsExpired(ar *corev1.AccessRequest) bool {
+	switch ar.GetStatus().GetState() {
+	case corev1.AccessRequest_Status_PENDING, corev1.AccessRequest_Status_APPROVED:
+		return ar.Status.ExpiresAt.IsValid() && time.Now().After(ar.Status.ExpiresAt.AsTime())
+	default:
+		return false
+	}
+}
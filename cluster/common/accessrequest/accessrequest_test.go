@@ -0,0 +1,192 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package accessrequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSpec(t *testing.T) {
+	cc := &corev1.ClusterConfig{
+		Spec: &corev1.ClusterConfig_Spec{},
+	}
+
+	tstCases := []struct {
+		spec  *corev1.AccessRequest_Spec
+		valid bool
+	}{
+		{
+			spec: nil,
+		},
+		{
+			spec: &corev1.AccessRequest_Spec{
+				Justification: "incident",
+			},
+		},
+		{
+			spec: &corev1.AccessRequest_Spec{
+				Service:       "svc.ns",
+				Namespace:     "ns",
+				Justification: "incident",
+			},
+		},
+		{
+			spec: &corev1.AccessRequest_Spec{
+				Service: "svc.ns",
+			},
+		},
+		{
+			spec: &corev1.AccessRequest_Spec{
+				Service:       "svc.ns",
+				Justification: "incident",
+			},
+			valid: true,
+		},
+		{
+			spec: &corev1.AccessRequest_Spec{
+				Namespace:     "ns",
+				Justification: "incident",
+				Duration: &metav1.Duration{
+					Type: &metav1.Duration_Hours{Hours: 4},
+				},
+			},
+			valid: true,
+		},
+		{
+			spec: &corev1.AccessRequest_Spec{
+				Namespace:     "ns",
+				Justification: "incident",
+				Duration: &metav1.Duration{
+					Type: &metav1.Duration_Days{Days: 2},
+				},
+			},
+		},
+	}
+
+	for _, tstCase := range tstCases {
+		err := ValidateSpec(cc, tstCase.spec)
+		if tstCase.valid {
+			assert.Nil(t, err, "%+v", err)
+		} else {
+			assert.NotNil(t, err, "%+v", tstCase.spec)
+		}
+	}
+
+	cc.Spec.AccessRequest = &corev1.ClusterConfig_Spec_AccessRequest{
+		MaxDuration: &metav1.Duration{
+			Type: &metav1.Duration_Days{Days: 3},
+		},
+	}
+	assert.Nil(t, ValidateSpec(cc, tstCases[len(tstCases)-1].spec))
+}
+
+func TestGetDuration(t *testing.T) {
+	assert.Equal(t, defaultDuration, GetDuration(&corev1.AccessRequest{
+		Spec: &corev1.AccessRequest_Spec{},
+	}))
+	assert.Equal(t, 30*time.Minute, GetDuration(&corev1.AccessRequest{
+		Spec: &corev1.AccessRequest_Spec{
+			Duration: &metav1.Duration{
+				Type: &metav1.Duration_Minutes{Minutes: 30},
+			},
+		},
+	}))
+}
+
+func TestIsApprover(t *testing.T) {
+	cc := &corev1.ClusterConfig{
+		Spec: &corev1.ClusterConfig_Spec{},
+	}
+	groups := []*corev1.Group{
+		{
+			Metadata: &metav1.Metadata{Name: "dev"},
+		},
+		{
+			Metadata: &metav1.Metadata{Name: "sre"},
+		},
+	}
+
+	assert.False(t, IsApprover(cc, groups))
+
+	cc.Spec.AccessRequest = &corev1.ClusterConfig_Spec_AccessRequest{
+		ApproverGroups: []string{"security"},
+	}
+	assert.False(t, IsApprover(cc, groups))
+	assert.False(t, IsApprover(cc, nil))
+
+	cc.Spec.AccessRequest.ApproverGroups = append(cc.Spec.AccessRequest.ApproverGroups, "sre")
+	assert.True(t, IsApprover(cc, groups))
+}
+
+func TestIsExpired(t *testing.T) {
+	ar := &corev1.AccessRequest{
+		Status: &corev1.AccessRequest_Status{
+			State:     corev1.AccessRequest_Status_APPROVED,
+			ExpiresAt: pbutils.Timestamp(time.Now().Add(time.Hour)),
+		},
+	}
+	assert.False(t, IsExpired(ar))
+
+	ar.Status.ExpiresAt = pbutils.Timestamp(time.Now().Add(-time.Second))
+	assert.True(t, IsExpired(ar))
+
+	ar.Status.State = corev1.AccessRequest_Status_PENDING
+	assert.True(t, IsExpired(ar))
+
+	ar.Status.State = corev1.AccessRequest_Status_DENIED
+	assert.False(t, IsExpired(ar))
+}
+
+func TestNewPolicyTrigger(t *testing.T) {
+	ar := &corev1.AccessRequest{
+		Metadata: &metav1.Metadata{
+			Name: "alice-abcd",
+			Uid:  "1234",
+		},
+		Spec: &corev1.AccessRequest_Spec{},
+		Status: &corev1.AccessRequest_Status{
+			State:      corev1.AccessRequest_Status_APPROVED,
+			UserRef:    &metav1.ObjectReference{Name: "alice", Uid: "usr-uid"},
+			ServiceRef: &metav1.ObjectReference{Name: "svc.ns", Uid: "svc-uid"},
+			ExpiresAt:  pbutils.Timestamp(time.Now().Add(time.Hour)),
+			Decision: &corev1.AccessRequest_Status_Decision{
+				DecidedAt: pbutils.Now(),
+			},
+		},
+	}
+
+	pt := NewPolicyTrigger(ar)
+	assert.Equal(t, "access-request-1234", pt.Metadata.Name)
+	assert.Equal(t, "alice-abcd", pt.Status.OwnerRef.Name)
+
+	conditions := pt.Status.PreCondition.GetAll().Of
+	assert.Equal(t, 4, len(conditions))
+	assert.Equal(t, "usr-uid", conditions[0].GetUserRef().Uid)
+	assert.Equal(t, ar.Status.Decision.DecidedAt, conditions[1].GetNotBefore())
+	assert.Equal(t, ar.Status.ExpiresAt, conditions[2].GetNotAfter())
+	assert.Equal(t, "svc-uid", conditions[3].GetServiceRef().Uid)
+
+	assert.Equal(t, 1, len(pt.Status.InlinePolicies))
+	assert.Equal(t, corev1.Policy_Spec_Rule_ALLOW, pt.Status.InlinePolicies[0].Spec.Rules[0].Effect)
+	assert.True(t, pt.Status.InlinePolicies[0].Spec.Rules[0].Condition.GetMatchAny())
+}
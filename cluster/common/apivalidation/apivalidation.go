@@ -501,6 +501,22 @@ func CheckIsSystem(arg umetav1.ResourceObjectI) error {
 	return nil
 }
 
+// CheckResourceVersion rejects an update whose resourceVersion is set and does
+// not match the stored Resource. Requests without a resourceVersion always
+// overwrite the stored Resource.
+func CheckResourceVersion(current, req umetav1.ResourceObjectI) error {
+	if req == nil || req.GetMetadata() == nil || req.GetMetadata().ResourceVersion == "" {
+		return nil
+	}
+
+	if current.GetMetadata().ResourceVersion != req.GetMetadata().ResourceVersion {
+		return grpcutils.ResourceChanged("%s %s has already changed",
+			current.GetKind(), current.GetMetadata().Name)
+	}
+
+	return nil
+}
+
 func CheckIsSystemHidden(arg umetav1.ResourceObjectI) error {
 	if arg == nil || arg.GetMetadata() == nil {
 		return grpcutils.InvalidArg("Resource has no metadata")
@@ -266,3 +266,41 @@ func TestGetNameAndParents(t *testing.T) {
 		assert.NotNil(t, err)
 	}
 }
+
+func TestCheckResourceVersion(t *testing.T) {
+	current := &corev1.User{
+		Kind: "User",
+		Metadata: &metav1.Metadata{
+			Name:            "usr1",
+			ResourceVersion: vutils.UUIDv7(),
+		},
+	}
+
+	{
+		assert.Nil(t, CheckResourceVersion(current, &corev1.User{
+			Metadata: &metav1.Metadata{
+				Name: "usr1",
+			},
+		}))
+	}
+
+	{
+		assert.Nil(t, CheckResourceVersion(current, &corev1.User{
+			Metadata: &metav1.Metadata{
+				Name:            "usr1",
+				ResourceVersion: current.Metadata.ResourceVersion,
+			},
+		}))
+	}
+
+	{
+		err := CheckResourceVersion(current, &corev1.User{
+			Metadata: &metav1.Metadata{
+				Name:            "usr1",
+				ResourceVersion: vutils.UUIDv7(),
+			},
+		})
+		assert.NotNil(t, err)
+		assert.True(t, grpcerr.IsResourceChanged(err))
+	}
+}
@@ -0,0 +1,405 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package celengine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/common/celengine/cellib"
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CheckError is a compile or type error of a Condition's expression.
+// Line and Column are 1-based positions within the expression and are
+// zero if unknown.
+type CheckError struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *CheckError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", e.Path, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Checker type-checks the CEL expressions and the OPA scripts of Policy
+// Conditions against the declared shape of the request context. Unlike the
+// engine, which declares ctx as dyn, a typo in a field name is a compile
+// error rather than a Condition that never matches. A Checker is safe for
+// concurrent use.
+type Checker struct {
+	env  *cel.Env
+	caps *ast.Capabilities
+}
+
+func NewChecker(ctx context.Context) (*Checker, error) {
+	env, err := cel.NewEnv(
+		cel.CustomTypeProvider(&reqCtxTypeProvider{
+			Registry: types.NewEmptyRegistry(),
+		}),
+		cel.Variable("ctx", types.NewObjectType(string(getReqCtxDescriptor().FullName()))),
+		cel.Variable("attrs", cel.DynType),
+		cellib.CELLib(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Checker{
+		env:  env,
+		caps: getOPACapabilities(),
+	}, nil
+}
+
+// CheckCondition returns the errors of all the expressions of the Condition.
+// path is the path of the Condition used to prefix the path of each error.
+func (c *Checker) CheckCondition(ctx context.Context, cond *corev1.Condition, path string) []*CheckError {
+	if cond == nil {
+		return nil
+	}
+
+	var ret []*CheckError
+
+	checkList := func(field string, conds []*corev1.Condition) {
+		for i, cond := range conds {
+			ret = append(ret, c.CheckCondition(ctx, cond, fmt.Sprintf("%s.%s.of[%d]", path, field, i))...)
+		}
+	}
+
+	switch cond.Type.(type) {
+	case *corev1.Condition_Match:
+		ret = append(ret, c.checkCEL(cond.GetMatch(), path+".match")...)
+	case *corev1.Condition_Not:
+		ret = append(ret, c.checkCEL(cond.GetNot(), path+".not")...)
+	case *corev1.Condition_All_:
+		checkList("all", cond.GetAll().Of)
+	case *corev1.Condition_Any_:
+		checkList("any", cond.GetAny().Of)
+	case *corev1.Condition_None_:
+		checkList("none", cond.GetNone().Of)
+	case *corev1.Condition_Opa:
+		if inline := cond.GetOpa().GetInline(); inline != "" {
+			ret = append(ret, c.checkOPA(ctx, inline, path+".opa.inline")...)
+		}
+	}
+
+	return ret
+}
+
+func (c *Checker) checkCEL(exp string, path string) []*CheckError {
+	checked, iss := c.env.Compile(exp)
+	if iss.Err() == nil {
+		// The engine requires a bool while dyn can only be known at runtime
+		outType := checked.OutputType()
+		if !outType.IsExactType(types.BoolType) && !outType.IsExactType(types.DynType) {
+			return []*CheckError{{
+				Path:    path,
+				Message: fmt.Sprintf("The expression must evaluate to a bool but it evaluates to %s", outType),
+			}}
+		}
+		return nil
+	}
+
+	var ret []*CheckError
+	for _, e := range iss.Errors() {
+		ret = append(ret, &CheckError{
+			Path:    path,
+			Line:    e.Location.Line(),
+			Column:  e.Location.Column() + 1,
+			Message: e.Message,
+		})
+	}
+
+	return ret
+}
+
+func (c *Checker) checkOPA(ctx context.Context, script string, path string) []*CheckError {
+	schemas, err := getReqCtxOPASchemas()
+	if err != nil {
+		return []*CheckError{{Path: path, Message: err.Error()}}
+	}
+
+	_, err = rego.New(
+		rego.Query("data.octelium.condition.match"),
+		rego.Module("octelium.condition", script),
+		rego.Capabilities(c.caps),
+		rego.Schemas(schemas),
+		rego.StrictBuiltinErrors(true),
+	).PrepareForEval(ctx)
+	if err == nil {
+		return nil
+	}
+
+	var astErrs ast.Errors
+	if !errors.As(err, &astErrs) {
+		return []*CheckError{{Path: path, Message: err.Error()}}
+	}
+
+	var ret []*CheckError
+	for _, e := range astErrs {
+		itm := &CheckError{
+			Path:    path,
+			Message: e.Message,
+		}
+		if e.Location != nil {
+			itm.Line = e.Location.Row
+			itm.Column = e.Location.Col
+		}
+		ret = append(ret, itm)
+	}
+
+	return ret
+}
+
+func getReqCtxDescriptor() protoreflect.MessageDescriptor {
+	return (&corev1.RequestContext{}).ProtoReflect().Descriptor()
+}
+
+// reqCtxTypeProvider declares the request context and its nested messages as
+// CEL object types. The fields are named and typed after their protojson
+// representation since that is what the expressions are evaluated against.
+type reqCtxTypeProvider struct {
+	*types.Registry
+}
+
+func (p *reqCtxTypeProvider) findMessage(structType string) (protoreflect.MessageDescriptor, bool) {
+	md, ok := getReqCtxMessages()[protoreflect.FullName(strings.TrimPrefix(structType, "."))]
+	return md, ok
+}
+
+func (p *reqCtxTypeProvider) FindStructType(structType string) (*types.Type, bool) {
+	if md, ok := p.findMessage(structType); ok {
+		return types.NewTypeTypeWithParam(types.NewObjectType(string(md.FullName()))), true
+	}
+	return p.Registry.FindStructType(structType)
+}
+
+func (p *reqCtxTypeProvider) FindStructFieldNames(structType string) ([]string, bool) {
+	md, ok := p.findMessage(structType)
+	if !ok {
+		return p.Registry.FindStructFieldNames(structType)
+	}
+
+	fields := md.Fields()
+	ret := make([]string, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		ret = append(ret, fields.Get(i).JSONName())
+	}
+	return ret, true
+}
+
+func (p *reqCtxTypeProvider) FindStructFieldType(structType, fieldName string) (*types.FieldType, bool) {
+	md, ok := p.findMessage(structType)
+	if !ok {
+		return p.Registry.FindStructFieldType(structType, fieldName)
+	}
+
+	fd := md.Fields().ByJSONName(fieldName)
+	if fd == nil {
+		return nil, false
+	}
+
+	return &types.FieldType{
+		Type: getFieldCELType(fd),
+	}, true
+}
+
+func getFieldCELType(fd protoreflect.FieldDescriptor) *types.Type {
+	switch {
+	case fd.IsMap():
+		return types.NewMapType(types.StringType, getSingularCELType(fd.MapValue()))
+	case fd.IsList():
+		return types.NewListType(getSingularCELType(fd))
+	default:
+		return getSingularCELType(fd)
+	}
+}
+
+func getSingularCELType(fd protoreflect.FieldDescriptor) *types.Type {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return types.BoolType
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.EnumKind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return types.StringType
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if typ, ok := wktCELTypes[fd.Message().FullName()]; ok {
+			return typ
+		}
+		return types.NewObjectType(string(fd.Message().FullName()))
+	default:
+		// JSON numbers are decoded as doubles while the expressions usually
+		// compare them to int literals.
+		return types.DynType
+	}
+}
+
+var wktCELTypes = map[protoreflect.FullName]*types.Type{
+	"google.protobuf.Timestamp":   types.StringType,
+	"google.protobuf.Duration":    types.StringType,
+	"google.protobuf.FieldMask":   types.StringType,
+	"google.protobuf.StringValue": types.StringType,
+	"google.protobuf.BytesValue":  types.StringType,
+	"google.protobuf.Int64Value":  types.StringType,
+	"google.protobuf.UInt64Value": types.StringType,
+	"google.protobuf.BoolValue":   types.BoolType,
+	"google.protobuf.Int32Value":  types.DynType,
+	"google.protobuf.UInt32Value": types.DynType,
+	"google.protobuf.FloatValue":  types.DynType,
+	"google.protobuf.DoubleValue": types.DynType,
+	"google.protobuf.Value":       types.DynType,
+	"google.protobuf.ListValue":   types.NewListType(types.DynType),
+	"google.protobuf.Struct":      types.NewMapType(types.StringType, types.DynType),
+	"google.protobuf.Any":         types.NewMapType(types.StringType, types.DynType),
+	"google.protobuf.Empty":       types.NewMapType(types.StringType, types.DynType),
+}
+
+var getReqCtxMessages = sync.OnceValue(func() map[protoreflect.FullName]protoreflect.MessageDescriptor {
+	ret := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+
+	var walk func(md protoreflect.MessageDescriptor)
+	walk = func(md protoreflect.MessageDescriptor) {
+		if _, ok := ret[md.FullName()]; ok {
+			return
+		}
+		if _, ok := wktCELTypes[md.FullName()]; ok {
+			return
+		}
+		ret[md.FullName()] = md
+
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			if fd.IsMap() {
+				fd = fd.MapValue()
+			}
+			if fd.Message() != nil {
+				walk(fd.Message())
+			}
+		}
+	}
+	walk(getReqCtxDescriptor())
+
+	return ret
+})
+
+// getReqCtxOPASchemas returns the JSON schema of the OPA input. Just like the
+// CEL declarations, the schema follows the protojson representation of the
+// request context.
+var getReqCtxOPASchemas = sync.OnceValues(func() (*ast.SchemaSet, error) {
+	definitions := make(map[string]any)
+	for name, md := range getReqCtxMessages() {
+		properties := make(map[string]any)
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			properties[fd.JSONName()] = getFieldOPASchema(fd)
+		}
+		definitions[string(name)] = map[string]any{
+			"type":       "object",
+			"properties": properties,
+		}
+	}
+
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"ctx":   getOPASchemaRef(getReqCtxDescriptor()),
+			"attrs": map[string]any{},
+		},
+		"definitions": definitions,
+	}
+
+	ret := ast.NewSchemaSet()
+	ret.Put(ast.SchemaRootRef, schema)
+	return ret, nil
+})
+
+var wktOPASchemas = map[protoreflect.FullName]map[string]any{
+	"google.protobuf.Timestamp":   {"type": "string"},
+	"google.protobuf.Duration":    {"type": "string"},
+	"google.protobuf.FieldMask":   {"type": "string"},
+	"google.protobuf.StringValue": {"type": "string"},
+	"google.protobuf.BytesValue":  {"type": "string"},
+	"google.protobuf.Int64Value":  {"type": "string"},
+	"google.protobuf.UInt64Value": {"type": "string"},
+	"google.protobuf.BoolValue":   {"type": "boolean"},
+	"google.protobuf.Int32Value":  {"type": "number"},
+	"google.protobuf.UInt32Value": {"type": "number"},
+	"google.protobuf.FloatValue":  {"type": "number"},
+	"google.protobuf.DoubleValue": {"type": "number"},
+	"google.protobuf.Value":       {},
+	"google.protobuf.ListValue":   {"type": "array"},
+	"google.protobuf.Struct":      {"type": "object"},
+	"google.protobuf.Any":         {"type": "object"},
+	"google.protobuf.Empty":       {"type": "object"},
+}
+
+func getOPASchemaRef(md protoreflect.MessageDescriptor) map[string]any {
+	return map[string]any{
+		"$ref": fmt.Sprintf("#/definitions/%s", md.FullName()),
+	}
+}
+
+func getFieldOPASchema(fd protoreflect.FieldDescriptor) map[string]any {
+	switch {
+	case fd.IsMap():
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": getSingularOPASchema(fd.MapValue()),
+		}
+	case fd.IsList():
+		return map[string]any{
+			"type":  "array",
+			"items": getSingularOPASchema(fd),
+		}
+	default:
+		return getSingularOPASchema(fd)
+	}
+}
+
+func getSingularOPASchema(fd protoreflect.FieldDescriptor) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.EnumKind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "string"}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if schema, ok := wktOPASchemas[fd.Message().FullName()]; ok {
+			return schema
+		}
+		return getOPASchemaRef(fd.Message())
+	default:
+		return map[string]any{"type": "number"}
+	}
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package celengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckerCEL(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewChecker(ctx)
+	assert.Nil(t, err)
+
+	match := func(arg string) *corev1.Condition {
+		return &corev1.Condition{
+			Type: &corev1.Condition_Match{
+				Match: arg,
+			},
+		}
+	}
+
+	valid := []string{
+		`ctx.user.spec.groups.exists(g, g == "ops")`,
+		`"ops" in ctx.user.spec.groups`,
+		`ctx.user.spec.type == "HUMAN"`,
+		`ctx.session.status.type == "CLIENT"`,
+		`ctx.user.spec.attrs.team == "sre"`,
+		`ctx.service.metadata.labels["env"] == "prod"`,
+		`ctx.request.http.headers["x-custom"] == "v1" && ctx.request.http.path.startsWith("/v1")`,
+		`has(ctx.request.http) && ctx.request.http.method in ["GET", "HEAD"]`,
+		`ctx.request.grpc.serviceFullName == "octelium.api.main.user.v1.MainService"`,
+		`ctx.namespace.metadata.name == "default" && ctx.service.spec.mode == "GRPC"`,
+		`attrs.anything.goes == 1`,
+	}
+	for _, arg := range valid {
+		assert.Empty(t, c.CheckCondition(ctx, match(arg), "condition"), arg)
+	}
+
+	{
+		errs := c.CheckCondition(ctx, match(`ctx.user.spec.grups.exists(g, g == "ops")`), "condition")
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "condition.match", errs[0].Path)
+		assert.Equal(t, 1, errs[0].Line)
+		assert.Equal(t, 14, errs[0].Column)
+		assert.Contains(t, errs[0].Message, "grups")
+	}
+
+	{
+		errs := c.CheckCondition(ctx, match(`ctx.user.metadata.name == 1`), "condition")
+		assert.Equal(t, 1, len(errs))
+	}
+
+	for _, arg := range []string{
+		`ctx.user.metadata.name`,
+		`1 + 1`,
+		`ctx.user.spec.groups`,
+	} {
+		errs := c.CheckCondition(ctx, match(arg), "condition")
+		assert.Equal(t, 1, len(errs), arg)
+		assert.Equal(t, "condition.match", errs[0].Path)
+		assert.Contains(t, errs[0].Message, "must evaluate to a bool", arg)
+	}
+
+	{
+		errs := c.CheckCondition(ctx, &corev1.Condition{
+			Type: &corev1.Condition_Not{
+				Not: `ctx.user.spec.type`,
+			},
+		}, "condition")
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "condition.not", errs[0].Path)
+	}
+
+	{
+		errs := c.CheckCondition(ctx, &corev1.Condition{
+			Type: &corev1.Condition_All_{
+				All: &corev1.Condition_All{
+					Of: []*corev1.Condition{
+						match(`ctx.user.spec.type == "HUMAN"`),
+						{
+							Type: &corev1.Condition_Not{
+								Not: `ctx.sesion.status.type == "CLIENT"`,
+							},
+						},
+					},
+				},
+			},
+		}, "spec.rules[0].condition")
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "spec.rules[0].condition.all.of[1].not", errs[0].Path)
+	}
+}
+
+func TestCheckerOPA(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewChecker(ctx)
+	assert.Nil(t, err)
+
+	opa := func(arg string) *corev1.Condition {
+		return &corev1.Condition{
+			Type: &corev1.Condition_Opa{
+				Opa: &corev1.Condition_OPA{
+					Type: &corev1.Condition_OPA_Inline{
+						Inline: arg,
+					},
+				},
+			},
+		}
+	}
+
+	assert.Empty(t, c.CheckCondition(ctx, opa(`
+package octelium.condition
+
+match if {
+	input.ctx.user.spec.groups[_] == "ops"
+	input.ctx.request.http.headers["x-custom"] == "v1"
+	input.attrs.anything == 1
+}
+`), "condition"))
+
+	errs := c.CheckCondition(ctx, opa(`
+package octelium.condition
+
+match if {
+	input.ctx.user.spec.grups[_] == "ops"
+}
+`), "condition")
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "condition.opa.inline", errs[0].Path)
+	assert.Equal(t, 5, errs[0].Line)
+	assert.Contains(t, errs[0].Message, "grups")
+}
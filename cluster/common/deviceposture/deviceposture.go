@@ -0,0 +1,227 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package deviceposture
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/cluster/common/urscsrv"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultTTL      = 24 * time.Hour
+	maxTTL          = 30 * 24 * time.Hour
+	maxSignals      = 128
+	maxAttrsSize    = 16384
+	maxSelectorLen  = 256
+	maxClockSkewTTL = 5 * time.Minute
+)
+
+var rgxSignalName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,63}$`)
+var rgxSourceName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.:-]{0,127}$`)
+
+// ValidateRequest validates a SetDevicePostureRequest
+func ValidateRequest(req *corev1.SetDevicePostureRequest) error {
+	if req == nil {
+		return grpcutils.InvalidArg("Nil request")
+	}
+
+	var selector string
+	switch req.Device.(type) {
+	case *corev1.SetDevicePostureRequest_SerialNumber:
+		selector = req.GetSerialNumber()
+	case *corev1.SetDevicePostureRequest_Hostname:
+		selector = req.GetHostname()
+	case *corev1.SetDevicePostureRequest_Id:
+		selector = req.GetId()
+	default:
+		return grpcutils.InvalidArg("You must set the Device serialNumber, hostname or id")
+	}
+
+	if selector == "" {
+		return grpcutils.InvalidArg("Empty Device selector")
+	}
+
+	if len(selector) > maxSelectorLen {
+		return grpcutils.InvalidArg("Device selector is too long")
+	}
+
+	if req.Source != "" {
+		if !rgxSourceName.MatchString(req.Source) {
+			return grpcutils.InvalidArg("Invalid source: %s", req.Source)
+		}
+		if isReservedSource(req.Source) {
+			return grpcutils.InvalidArg("The source %s is reserved", req.Source)
+		}
+	}
+
+	return validatePosture(req.Posture)
+}
+
+func validatePosture(p *corev1.Device_Status_Posture) error {
+	if p == nil {
+		return grpcutils.InvalidArg("You must set the posture")
+	}
+
+	if _, ok := corev1.Device_Status_Posture_RiskLevel_name[int32(p.RiskLevel)]; !ok {
+		return grpcutils.InvalidArg("Invalid riskLevel")
+	}
+
+	for _, state := range []corev1.Device_Status_Posture_SignalState{
+		p.DiskEncryption, p.Compliant, p.ThreatFree,
+	} {
+		if !isValidSignalState(state) {
+			return grpcutils.InvalidArg("Invalid signal state: %d", state)
+		}
+	}
+
+	if len(p.Signals) > maxSignals {
+		return grpcutils.InvalidArg("Too many signals")
+	}
+
+	for name, state := range p.Signals {
+		if !rgxSignalName.MatchString(name) {
+			return grpcutils.InvalidArg("Invalid signal name: %s", name)
+		}
+		if !isValidSignalState(state) {
+			return grpcutils.InvalidArg("Invalid state of signal: %s", name)
+		}
+	}
+
+	if len(p.Sources) > 0 {
+		return grpcutils.InvalidArg("sources cannot be set")
+	}
+
+	if p.Attrs != nil && proto.Size(p.Attrs) > maxAttrsSize {
+		return grpcutils.InvalidArg("attrs is too large")
+	}
+
+	if p.LastSeenAt != nil && !p.LastSeenAt.IsValid() {
+		return grpcutils.InvalidArg("Invalid lastSeenAt")
+	}
+
+	if p.ExpiresAt != nil {
+		if !p.ExpiresAt.IsValid() {
+			return grpcutils.InvalidArg("Invalid expiresAt")
+		}
+
+		expiresAt := p.ExpiresAt.AsTime()
+		if !expiresAt.After(time.Now()) {
+			return grpcutils.InvalidArg("expiresAt must be in the future")
+		}
+		if expiresAt.After(time.Now().Add(maxTTL + maxClockSkewTTL)) {
+			return grpcutils.InvalidArg("expiresAt is too far in the future")
+		}
+	}
+
+	return nil
+}
+
+func isValidSignalState(state corev1.Device_Status_Posture_SignalState) bool {
+	_, ok := corev1.Device_Status_Posture_SignalState_name[int32(state)]
+	return ok
+}
+
+// GetDevice returns the unique Device matched by the request's selector
+func GetDevice(ctx context.Context,
+	octeliumC octeliumc.ClientInterface, req *corev1.SetDevicePostureRequest) (*corev1.Device, error) {
+	var filter *rmetav1.ListOptions_Filter
+
+	switch req.Device.(type) {
+	case *corev1.SetDevicePostureRequest_SerialNumber:
+		filter = urscsrv.FilterFieldEQValStr("status.serialNumber", req.GetSerialNumber())
+	case *corev1.SetDevicePostureRequest_Hostname:
+		filter = urscsrv.FilterFieldEQValStr("status.hostname", req.GetHostname())
+	case *corev1.SetDevicePostureRequest_Id:
+		filter = urscsrv.FilterFieldEQValStr("status.id", req.GetId())
+	default:
+		return nil, grpcutils.InvalidArg("Invalid Device selector")
+	}
+
+	devList, err := octeliumC.CoreC().ListDevice(ctx, &rmetav1.ListOptions{
+		Filters: []*rmetav1.ListOptions_Filter{filter},
+	})
+	if err != nil {
+		return nil, grpcutils.InternalWithErr(err)
+	}
+
+	switch len(devList.Items) {
+	case 0:
+		return nil, grpcutils.NotFound("No Device matches the selector")
+	case 1:
+		return devList.Items[0], nil
+	default:
+		return nil, grpcutils.InvalidArg("More than one Device matches the selector")
+	}
+}
+
+// SetPosture validates the request and replaces the Posture previously
+// reported by the request's source. The Postures of the other sources are kept.
+func SetPosture(ctx context.Context,
+	octeliumC octeliumc.ClientInterface, req *corev1.SetDevicePostureRequest) (*corev1.Device, error) {
+	if err := ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
+	source := req.Source
+	if source == "" {
+		source = SourceAPI
+	}
+
+	return setSourcePosture(ctx, octeliumC, source, req)
+}
+
+// SetConnectorPosture is SetPosture for the Posture reported by the posture
+// Connector of the given name. The source set by the request is ignored.
+func SetConnectorPosture(ctx context.Context,
+	octeliumC octeliumc.ClientInterface, name string, req *corev1.SetDevicePostureRequest) (*corev1.Device, error) {
+	req.Source = ""
+	if err := ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
+	return setSourcePosture(ctx, octeliumC, GetConnectorSource(name), req)
+}
+
+func setSourcePosture(ctx context.Context,
+	octeliumC octeliumc.ClientInterface, source string, req *corev1.SetDevicePostureRequest) (*corev1.Device, error) {
+	dev, err := GetDevice(ctx, octeliumC, req)
+	if err != nil {
+		return nil, err
+	}
+
+	posture := pbutils.Clone(req.Posture).(*corev1.Device_Status_Posture)
+	posture.LastSyncAt = pbutils.Now()
+	if posture.ExpiresAt == nil {
+		posture.ExpiresAt = pbutils.Timestamp(time.Now().Add(defaultTTL))
+	}
+
+	dev.Status.Posture, err = SetSource(dev.Status.Posture, source, posture, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return octeliumC.CoreC().UpdateDevice(ctx, dev)
+}
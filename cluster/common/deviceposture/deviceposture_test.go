@@ -0,0 +1,216 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package deviceposture
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/cluster/common/tests"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"github.com/octelium/octelium/pkg/utils/utilrand"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRequest(t *testing.T) {
+	getReq := func() *corev1.SetDevicePostureRequest {
+		return &corev1.SetDevicePostureRequest{
+			Device: &corev1.SetDevicePostureRequest_SerialNumber{
+				SerialNumber: "C02XK1ZZJGH5",
+			},
+			Posture: &corev1.Device_Status_Posture{
+				RiskLevel:      corev1.Device_Status_Posture_LOW,
+				DiskEncryption: corev1.Device_Status_Posture_PASS,
+				Compliant:      corev1.Device_Status_Posture_PASS,
+				ThreatFree:     corev1.Device_Status_Posture_NOT_APPLICABLE,
+				Signals: map[string]corev1.Device_Status_Posture_SignalState{
+					"firewall": corev1.Device_Status_Posture_PASS,
+				},
+				ExpiresAt: pbutils.Timestamp(time.Now().Add(time.Hour)),
+			},
+		}
+	}
+
+	assert.Nil(t, ValidateRequest(getReq()))
+
+	{
+		req := getReq()
+		req.Posture.ExpiresAt = nil
+		assert.Nil(t, ValidateRequest(req))
+	}
+
+	{
+		req := getReq()
+		req.Source = "jamf"
+		assert.Nil(t, ValidateRequest(req))
+	}
+
+	invalids := []func(req *corev1.SetDevicePostureRequest){
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Device = nil
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Device = &corev1.SetDevicePostureRequest_Hostname{}
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Device = &corev1.SetDevicePostureRequest_Id{
+				Id: utilrand.GetRandomString(maxSelectorLen + 1),
+			}
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Posture = nil
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Source = SourceProbe
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Source = GetConnectorSource("mdm")
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Source = "invalid source"
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Posture.Sources = []*corev1.Device_Status_Posture_Source{
+				{
+					Name: SourceProbe,
+				},
+			}
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Posture.RiskLevel = corev1.Device_Status_Posture_RiskLevel(100)
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Posture.Compliant = corev1.Device_Status_Posture_SignalState(100)
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Posture.Signals["invalid-name"] = corev1.Device_Status_Posture_PASS
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Posture.Signals["firewall"] = corev1.Device_Status_Posture_SignalState(100)
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Posture.ExpiresAt = pbutils.Timestamp(time.Now().Add(-time.Minute))
+		},
+		func(req *corev1.SetDevicePostureRequest) {
+			req.Posture.ExpiresAt = pbutils.Timestamp(time.Now().Add(maxTTL + time.Hour))
+		},
+	}
+
+	for _, fn := range invalids {
+		req := getReq()
+		fn(req)
+		assert.NotNil(t, ValidateRequest(req))
+	}
+}
+
+func TestSetPosture(t *testing.T) {
+	ctx := context.Background()
+	tst, err := tests.Initialize(nil)
+	assert.Nil(t, err, "%+v", err)
+	t.Cleanup(func() {
+		tst.Destroy()
+	})
+
+	octeliumC := tst.C.OcteliumC
+
+	dev, err := octeliumC.CoreC().CreateDevice(ctx, &corev1.Device{
+		Metadata: &metav1.Metadata{
+			Name: utilrand.GetRandomStringCanonical(8),
+		},
+		Spec: &corev1.Device_Spec{
+			State: corev1.Device_Spec_ACTIVE,
+		},
+		Status: &corev1.Device_Status{
+			Hostname:     utilrand.GetRandomStringCanonical(8),
+			SerialNumber: utilrand.GetRandomStringCanonical(12),
+			Id:           utilrand.GetRandomStringCanonical(32),
+		},
+	})
+	assert.Nil(t, err)
+
+	{
+		res, err := SetPosture(ctx, octeliumC, &corev1.SetDevicePostureRequest{
+			Device: &corev1.SetDevicePostureRequest_SerialNumber{
+				SerialNumber: dev.Status.SerialNumber,
+			},
+			Posture: &corev1.Device_Status_Posture{
+				DiskEncryption: corev1.Device_Status_Posture_PASS,
+			},
+		})
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, dev.Metadata.Uid, res.Metadata.Uid)
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, res.Status.Posture.DiskEncryption)
+		assert.True(t, res.Status.Posture.LastSyncAt.IsValid())
+		assert.True(t, res.Status.Posture.ExpiresAt.AsTime().After(time.Now().Add(defaultTTL-time.Minute)))
+	}
+
+	{
+		res, err := SetPosture(ctx, octeliumC, &corev1.SetDevicePostureRequest{
+			Device: &corev1.SetDevicePostureRequest_Hostname{
+				Hostname: dev.Status.Hostname,
+			},
+			Posture: &corev1.Device_Status_Posture{
+				ThreatFree: corev1.Device_Status_Posture_FAIL,
+			},
+		})
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN, res.Status.Posture.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_FAIL, res.Status.Posture.ThreatFree)
+	}
+
+	{
+		_, err := SetConnectorPosture(ctx, octeliumC, "mdm", &corev1.SetDevicePostureRequest{
+			Device: &corev1.SetDevicePostureRequest_Hostname{
+				Hostname: dev.Status.Hostname,
+			},
+			Source: SourceProbe,
+			Posture: &corev1.Device_Status_Posture{
+				Compliant: corev1.Device_Status_Posture_PASS,
+			},
+		})
+		assert.Nil(t, err, "%+v", err)
+
+		res, err := SetPosture(ctx, octeliumC, &corev1.SetDevicePostureRequest{
+			Device: &corev1.SetDevicePostureRequest_Hostname{
+				Hostname: dev.Status.Hostname,
+			},
+			Posture: &corev1.Device_Status_Posture{
+				ThreatFree: corev1.Device_Status_Posture_PASS,
+			},
+		})
+		assert.Nil(t, err, "%+v", err)
+		assert.Equal(t, 2, len(res.Status.Posture.Sources))
+		assert.Equal(t, GetConnectorSource("mdm"), res.Status.Posture.Sources[1].Name)
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, res.Status.Posture.Compliant)
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, res.Status.Posture.ThreatFree)
+	}
+
+	{
+		_, err := SetPosture(ctx, octeliumC, &corev1.SetDevicePostureRequest{
+			Device: &corev1.SetDevicePostureRequest_Id{
+				Id: utilrand.GetRandomStringCanonical(32),
+			},
+			Posture: &corev1.Device_Status_Posture{},
+		})
+		assert.NotNil(t, err)
+		assert.True(t, grpcerr.IsNotFound(err))
+	}
+}
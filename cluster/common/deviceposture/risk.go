@@ -0,0 +1,105 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package deviceposture
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"go.uber.org/zap"
+)
+
+// GetProbesMap returns the results of the named Probes of the ProbeAttempt as
+// they are exposed to the posture conditions via `ctx.probes`
+func GetProbesMap(attempt *corev1.Device_Status_ProbeAttempt) map[string]any {
+	ret := make(map[string]any)
+
+	for _, res := range attempt.GetResults() {
+		idx, err := strconv.Atoi(res.ProbeID)
+		if err != nil || idx < 0 || idx >= len(attempt.Probes) {
+			continue
+		}
+
+		name := attempt.Probes[idx].Name
+		if name == "" {
+			continue
+		}
+
+		ret[name] = map[string]any{
+			"output":   string(res.GetOutput()),
+			"error":    res.GetError(),
+			"isError":  res.GetError() != "",
+			"exitCode": int64(res.ExitCode),
+		}
+	}
+
+	return ret
+}
+
+// SetRiskLevel returns the Posture with its riskLevel set to the highest of
+// the riskLevel reported by its sources and the level of the ClusterConfig
+// risk rules evaluated over the Posture itself. The Posture must be the
+// effective one (see GetEffectivePosture) so that the risk level always
+// follows the current unexpired sources and is never stored in any of them.
+// The Posture is returned unchanged if there are no risk rules.
+func SetRiskLevel(ctx context.Context, celEngine *celengine.CELEngine,
+	cfg *corev1.ClusterConfig_Spec_Device_Posture,
+	p *corev1.Device_Status_Posture, reqCtx map[string]any) *corev1.Device_Status_Posture {
+	if p == nil || len(cfg.GetRisks()) == 0 {
+		return p
+	}
+
+	postureMap, err := pbutils.ConvertToMap(p)
+	if err != nil {
+		zap.L().Warn("Could not convert posture to map", zap.Error(err))
+		return p
+	}
+
+	inputCtx := make(map[string]any, len(reqCtx)+1)
+	for k, v := range reqCtx {
+		inputCtx[k] = v
+	}
+	inputCtx["posture"] = postureMap
+
+	inputMap := map[string]any{
+		"ctx": inputCtx,
+	}
+
+	level := corev1.Device_Status_Posture_LOW
+	for _, risk := range cfg.Risks {
+		if risk.Level <= level {
+			continue
+		}
+
+		ok, err := celEngine.EvalCondition(ctx, risk.Condition, inputMap)
+		if err != nil {
+			zap.L().Debug("Could not evaluate posture risk condition",
+				zap.String("level", risk.Level.String()), zap.Error(err))
+			continue
+		}
+		if ok {
+			level = risk.Level
+		}
+	}
+
+	ret := pbutils.Clone(p).(*corev1.Device_Status_Posture)
+	ret.RiskLevel = max(ret.RiskLevel, level)
+	return ret
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package deviceposture
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRiskLevel(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	celEngine, err := celengine.New(ctx, &celengine.Opts{})
+	assert.Nil(t, err)
+
+	cfg := &corev1.ClusterConfig_Spec_Device_Posture{
+		Risks: []*corev1.ClusterConfig_Spec_Device_Posture_Risk{
+			{
+				Level: corev1.Device_Status_Posture_HIGH,
+				Condition: &corev1.Condition{
+					Type: &corev1.Condition_Match{
+						Match: `ctx.posture.threatFree == "FAIL"`,
+					},
+				},
+			},
+			{
+				Level: corev1.Device_Status_Posture_MEDIUM,
+				Condition: &corev1.Condition{
+					Type: &corev1.Condition_Match{
+						Match: `ctx.probes.ufw.exitCode != 0`,
+					},
+				},
+			},
+		},
+	}
+
+	assert.Nil(t, SetRiskLevel(ctx, celEngine, cfg, nil, nil))
+
+	posture, err := SetSource(nil, SourceProbe, &corev1.Device_Status_Posture{
+		ThreatFree: corev1.Device_Status_Posture_PASS,
+		ExpiresAt:  pbutils.Timestamp(now.Add(time.Hour)),
+	}, now)
+	assert.Nil(t, err)
+
+	posture, err = SetSource(posture, GetConnectorSource("edr"), &corev1.Device_Status_Posture{
+		ThreatFree: corev1.Device_Status_Posture_FAIL,
+		ExpiresAt:  pbutils.Timestamp(now.Add(time.Minute)),
+	}, now)
+	assert.Nil(t, err)
+
+	{
+		ret := SetRiskLevel(ctx, celEngine, cfg, posture, nil)
+		assert.Equal(t, corev1.Device_Status_Posture_HIGH, ret.RiskLevel)
+		assert.Equal(t, corev1.Device_Status_Posture_RISK_LEVEL_UNKNOWN, posture.RiskLevel)
+		for _, src := range ret.Sources {
+			assert.Equal(t, corev1.Device_Status_Posture_RISK_LEVEL_UNKNOWN, src.Posture.RiskLevel)
+		}
+	}
+
+	{
+		// Once the connector source expires, its signals no longer raise the
+		// risk level of the Device
+		later := now.Add(2 * time.Minute)
+		ret := SetRiskLevel(ctx, celEngine, cfg, GetEffectivePosture(posture, later), nil)
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, ret.ThreatFree)
+		assert.Equal(t, corev1.Device_Status_Posture_LOW, ret.RiskLevel)
+	}
+
+	{
+		ret := SetRiskLevel(ctx, celEngine, cfg, GetEffectivePosture(posture, now.Add(2*time.Minute)),
+			map[string]any{
+				"probes": GetProbesMap(&corev1.Device_Status_ProbeAttempt{
+					Probes: []*corev1.ClusterConfig_Status_Device_Probe{
+						{
+							Name: "ufw",
+						},
+					},
+					Results: []*corev1.Device_Status_ProbeAttempt_Result{
+						{
+							ProbeID:  "0",
+							ExitCode: 1,
+						},
+					},
+				}),
+			})
+		assert.Equal(t, corev1.Device_Status_Posture_MEDIUM, ret.RiskLevel)
+	}
+
+	{
+		posture, err := SetSource(posture, SourceAPI, &corev1.Device_Status_Posture{
+			RiskLevel: corev1.Device_Status_Posture_CRITICAL,
+			ExpiresAt: pbutils.Timestamp(now.Add(time.Hour)),
+		}, now)
+		assert.Nil(t, err)
+
+		ret := SetRiskLevel(ctx, celEngine, cfg, posture, nil)
+		assert.Equal(t, corev1.Device_Status_Posture_CRITICAL, ret.RiskLevel)
+	}
+
+	{
+		ret := SetRiskLevel(ctx, celEngine, &corev1.ClusterConfig_Spec_Device_Posture{}, posture, nil)
+		assert.Equal(t, posture, ret)
+	}
+}
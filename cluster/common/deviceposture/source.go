@@ -0,0 +1,197 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package deviceposture
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// SourceProbe is the source of the Posture computed from the client probes
+	SourceProbe = "probe"
+	// SourceAPI is the default source of the SetDevicePosture API
+	SourceAPI = "api"
+
+	sourceConnectorPrefix = "connector:"
+	maxSources            = 16
+)
+
+// GetConnectorSource returns the source name of the posture Connector
+func GetConnectorSource(name string) string {
+	return sourceConnectorPrefix + name
+}
+
+func isReservedSource(name string) bool {
+	return name == SourceProbe || strings.HasPrefix(name, sourceConnectorPrefix)
+}
+
+func isExpired(p *corev1.Device_Status_Posture, now time.Time) bool {
+	return p.GetExpiresAt() != nil && !now.Before(p.ExpiresAt.AsTime())
+}
+
+// HasExpired returns whether the Posture or any of its sources has expired
+// since the Posture was last computed
+func HasExpired(p *corev1.Device_Status_Posture, now time.Time) bool {
+	if isExpired(p, now) {
+		return true
+	}
+
+	return slices.ContainsFunc(p.GetSources(), func(src *corev1.Device_Status_Posture_Source) bool {
+		return isExpired(src.Posture, now)
+	})
+}
+
+// SetSource returns a new Posture where the Posture of the given source is
+// replaced and the Postures of the other unexpired sources are kept. The
+// signals and the riskLevel of the returned Posture are recomputed over the
+// union of all of its sources.
+func SetSource(p *corev1.Device_Status_Posture, name string,
+	sourcePosture *corev1.Device_Status_Posture, now time.Time) (*corev1.Device_Status_Posture, error) {
+	sources := []*corev1.Device_Status_Posture_Source{}
+
+	for _, src := range p.GetSources() {
+		if src.Name == name || src.Posture == nil || isExpired(src.Posture, now) {
+			continue
+		}
+		sources = append(sources, pbutils.Clone(src).(*corev1.Device_Status_Posture_Source))
+	}
+
+	if len(sources) >= maxSources {
+		return nil, grpcutils.InvalidArg("Too many posture sources")
+	}
+
+	sourcePosture = pbutils.Clone(sourcePosture).(*corev1.Device_Status_Posture)
+	sourcePosture.Sources = nil
+
+	sources = append(sources, &corev1.Device_Status_Posture_Source{
+		Name:    name,
+		Posture: sourcePosture,
+	})
+
+	slices.SortFunc(sources, func(a, b *corev1.Device_Status_Posture_Source) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return GetEffectivePosture(&corev1.Device_Status_Posture{
+		Sources: sources,
+	}, now), nil
+}
+
+// GetEffectivePosture returns the Posture computed over the union of its
+// unexpired sources. A FAIL signal state wins over PASS which wins over
+// NOT_APPLICABLE, and the highest riskLevel of the sources wins. A Posture that
+// has no sources is returned with all of its signals set to UNKNOWN if it has
+// expired so that stale signals are never treated as passing.
+func GetEffectivePosture(p *corev1.Device_Status_Posture, now time.Time) *corev1.Device_Status_Posture {
+	if p == nil {
+		return nil
+	}
+
+	if len(p.Sources) == 0 {
+		if !isExpired(p, now) {
+			return p
+		}
+
+		ret := pbutils.Clone(p).(*corev1.Device_Status_Posture)
+		ret.RiskLevel = corev1.Device_Status_Posture_RISK_LEVEL_UNKNOWN
+		ret.DiskEncryption = corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN
+		ret.Compliant = corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN
+		ret.ThreatFree = corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN
+		for name := range ret.Signals {
+			ret.Signals[name] = corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN
+		}
+		ret.Attrs = nil
+		return ret
+	}
+
+	ret := &corev1.Device_Status_Posture{
+		Sources: p.Sources,
+	}
+
+	for _, src := range p.Sources {
+		sp := src.Posture
+		if sp == nil || isExpired(sp, now) {
+			continue
+		}
+
+		ret.RiskLevel = max(ret.RiskLevel, sp.RiskLevel)
+		ret.DiskEncryption = mergeSignalState(ret.DiskEncryption, sp.DiskEncryption)
+		ret.Compliant = mergeSignalState(ret.Compliant, sp.Compliant)
+		ret.ThreatFree = mergeSignalState(ret.ThreatFree, sp.ThreatFree)
+
+		for name, state := range sp.Signals {
+			if ret.Signals == nil {
+				ret.Signals = make(map[string]corev1.Device_Status_Posture_SignalState)
+			}
+			ret.Signals[name] = mergeSignalState(ret.Signals[name], state)
+		}
+
+		for k, v := range sp.Attrs.GetFields() {
+			if ret.Attrs == nil {
+				ret.Attrs = &structpb.Struct{
+					Fields: make(map[string]*structpb.Value),
+				}
+			}
+			ret.Attrs.Fields[k] = v
+		}
+
+		ret.LastSyncAt = getLatest(ret.LastSyncAt, sp.LastSyncAt)
+		ret.LastSeenAt = getLatest(ret.LastSeenAt, sp.LastSeenAt)
+		ret.ExpiresAt = getLatest(ret.ExpiresAt, sp.ExpiresAt)
+	}
+
+	return ret
+}
+
+func getSignalStateRank(state corev1.Device_Status_Posture_SignalState) int {
+	switch state {
+	case corev1.Device_Status_Posture_FAIL:
+		return 3
+	case corev1.Device_Status_Posture_PASS:
+		return 2
+	case corev1.Device_Status_Posture_NOT_APPLICABLE:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func mergeSignalState(a, b corev1.Device_Status_Posture_SignalState) corev1.Device_Status_Posture_SignalState {
+	if getSignalStateRank(b) > getSignalStateRank(a) {
+		return b
+	}
+	return a
+}
+
+func getLatest(a, b *timestamppb.Timestamp) *timestamppb.Timestamp {
+	switch {
+	case b == nil:
+		return a
+	case a == nil, b.AsTime().After(a.AsTime()):
+		return b
+	default:
+		return a
+	}
+}
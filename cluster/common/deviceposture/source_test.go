@@ -0,0 +1,128 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package deviceposture
+
+import (
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSetSource(t *testing.T) {
+	now := time.Now()
+	expiresAt := pbutils.Timestamp(now.Add(time.Hour))
+
+	// A probe finish, then an ingest, then another probe finish must each
+	// keep the signals reported by the other source
+	posture, err := SetSource(nil, SourceProbe, &corev1.Device_Status_Posture{
+		DiskEncryption: corev1.Device_Status_Posture_PASS,
+		RiskLevel:      corev1.Device_Status_Posture_LOW,
+		Signals: map[string]corev1.Device_Status_Posture_SignalState{
+			"firewall": corev1.Device_Status_Posture_PASS,
+		},
+		ExpiresAt: expiresAt,
+	}, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(posture.Sources))
+	assert.Equal(t, corev1.Device_Status_Posture_PASS, posture.DiskEncryption)
+
+	attrs, err := structpb.NewStruct(map[string]any{"vendor": "mdm"})
+	assert.Nil(t, err)
+
+	posture, err = SetSource(posture, GetConnectorSource("mdm"), &corev1.Device_Status_Posture{
+		Compliant:  corev1.Device_Status_Posture_PASS,
+		ThreatFree: corev1.Device_Status_Posture_FAIL,
+		RiskLevel:  corev1.Device_Status_Posture_HIGH,
+		Signals: map[string]corev1.Device_Status_Posture_SignalState{
+			"firewall": corev1.Device_Status_Posture_NOT_APPLICABLE,
+			"edr":      corev1.Device_Status_Posture_PASS,
+		},
+		Attrs:     attrs,
+		ExpiresAt: expiresAt,
+	}, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(posture.Sources))
+	assert.Equal(t, corev1.Device_Status_Posture_PASS, posture.DiskEncryption)
+	assert.Equal(t, corev1.Device_Status_Posture_PASS, posture.Compliant)
+	assert.Equal(t, corev1.Device_Status_Posture_FAIL, posture.ThreatFree)
+	assert.Equal(t, corev1.Device_Status_Posture_PASS, posture.Signals["firewall"])
+	assert.Equal(t, corev1.Device_Status_Posture_PASS, posture.Signals["edr"])
+	assert.Equal(t, corev1.Device_Status_Posture_HIGH, posture.RiskLevel)
+	assert.Equal(t, "mdm", posture.Attrs.AsMap()["vendor"])
+
+	posture, err = SetSource(posture, SourceProbe, &corev1.Device_Status_Posture{
+		DiskEncryption: corev1.Device_Status_Posture_FAIL,
+		RiskLevel:      corev1.Device_Status_Posture_CRITICAL,
+		ExpiresAt:      expiresAt,
+	}, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(posture.Sources))
+	assert.Equal(t, corev1.Device_Status_Posture_FAIL, posture.DiskEncryption)
+	assert.Equal(t, corev1.Device_Status_Posture_PASS, posture.Compliant)
+	assert.Equal(t, corev1.Device_Status_Posture_NOT_APPLICABLE, posture.Signals["firewall"])
+	assert.Equal(t, corev1.Device_Status_Posture_PASS, posture.Signals["edr"])
+	assert.Equal(t, corev1.Device_Status_Posture_CRITICAL, posture.RiskLevel)
+
+	posture, err = SetSource(posture, GetConnectorSource("mdm"), &corev1.Device_Status_Posture{
+		ExpiresAt: expiresAt,
+	}, now)
+	assert.Nil(t, err)
+	assert.Equal(t, corev1.Device_Status_Posture_FAIL, posture.DiskEncryption)
+	assert.Equal(t, corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN, posture.Compliant)
+	assert.Equal(t, corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN, posture.ThreatFree)
+	_, ok := posture.Signals["edr"]
+	assert.False(t, ok)
+	assert.Nil(t, posture.Attrs)
+
+	{
+		later := now.Add(2 * time.Hour)
+		assert.False(t, HasExpired(posture, now))
+		assert.True(t, HasExpired(posture, later))
+
+		ret, err := SetSource(posture, SourceAPI, &corev1.Device_Status_Posture{
+			Compliant: corev1.Device_Status_Posture_PASS,
+			ExpiresAt: pbutils.Timestamp(later.Add(time.Hour)),
+		}, later)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(ret.Sources))
+		assert.Equal(t, SourceAPI, ret.Sources[0].Name)
+		assert.Equal(t, corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN, ret.DiskEncryption)
+
+		ret = GetEffectivePosture(posture, later)
+		assert.Equal(t, corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN, ret.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_RISK_LEVEL_UNKNOWN, ret.RiskLevel)
+	}
+
+	{
+		var ret *corev1.Device_Status_Posture
+		for i := range maxSources {
+			ret, err = SetSource(ret, GetConnectorSource(string(rune('a'+i))), &corev1.Device_Status_Posture{
+				ExpiresAt: expiresAt,
+			}, now)
+			assert.Nil(t, err)
+		}
+		_, err = SetSource(ret, SourceProbe, &corev1.Device_Status_Posture{}, now)
+		assert.NotNil(t, err)
+
+		_, err = SetSource(ret, GetConnectorSource("a"), &corev1.Device_Status_Posture{}, now)
+		assert.Nil(t, err)
+	}
+}
@@ -110,6 +110,11 @@ func AlreadyExists(format string, a ...any) error {
 	return status.Errorf(codes.AlreadyExists, format, a...)
 }
 
+func ResourceChanged(format string, a ...any) error {
+	zap.L().Debug("ResourceChanged error", zap.Error(errors.Errorf(format, a...)))
+	return status.Errorf(codes.OutOfRange, format, a...)
+}
+
 func GetHeaderValue(ctx context.Context, key string) (string, error) {
 
 	md, ok := metadata.FromIncomingContext(ctx)
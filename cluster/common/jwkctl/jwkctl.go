@@ -69,6 +69,10 @@ type jwtKey struct {
 
 type Opts struct {
 	IsVerificationMode bool
+	// IsIdentityAssertion makes the Controller use the identity assertion
+	// Secrets instead of the root Secrets. These keys are separate from the
+	// root keys since they are held by the Services' vigil instances.
+	IsIdentityAssertion bool
 }
 
 func NewJWKController(ctx context.Context, octeliumC octeliumc.ClientInterface, o *Opts) (*Controller, error) {
@@ -76,19 +80,25 @@ func NewJWKController(ctx context.Context, octeliumC octeliumc.ClientInterface,
 		o = &Opts{}
 	}
 
+	label := jwkutils.LabelRootSecret
+	if o.IsIdentityAssertion {
+		label = jwkutils.LabelIdentityAssertionSecret
+	}
+
 	ret := &Controller{
 		octeliumC: octeliumC,
 		ctl: &ctl{
 			octeliumC:          octeliumC,
 			keyMap:             make(map[string]*jwtKey),
 			isVerificationMode: o.IsVerificationMode,
+			label:              label,
 		},
 		opts: o,
 	}
 
 	secrets, err := octeliumC.CoreC().ListSecret(ctx, &rmetav1.ListOptions{
 		SystemLabels: map[string]string{
-			"octelium-root-secret": "true",
+			label: "true",
 		},
 	})
 	if err != nil {
@@ -96,13 +106,19 @@ func NewJWKController(ctx context.Context, octeliumC octeliumc.ClientInterface,
 	}
 
 	if len(secrets.Items) < 1 {
-		if _, err := jwkutils.CreateJWKSecret(ctx, octeliumC); err != nil {
-			return nil, err
+		if o.IsIdentityAssertion {
+			if _, err := jwkutils.CreateIdentityAssertionSecret(ctx, octeliumC); err != nil {
+				return nil, err
+			}
+		} else {
+			if _, err := jwkutils.CreateJWKSecret(ctx, octeliumC); err != nil {
+				return nil, err
+			}
 		}
 
 		secrets, err = octeliumC.CoreC().ListSecret(ctx, &rmetav1.ListOptions{
 			SystemLabels: map[string]string{
-				"octelium-root-secret": "true",
+				label: "true",
 			},
 		})
 		if err != nil {
@@ -139,6 +155,7 @@ type ctl struct {
 	octeliumC          octeliumc.ClientInterface
 	keyMap             map[string]*jwtKey
 	isVerificationMode bool
+	label              string
 	sync.RWMutex
 }
 
@@ -146,7 +163,7 @@ func (c *ctl) isJWKSecret(s *corev1.Secret) bool {
 	if s.Metadata.SystemLabels == nil {
 		return false
 	}
-	if s.Metadata.SystemLabels["octelium-root-secret"] == "true" {
+	if s.Metadata.SystemLabels[c.label] == "true" {
 		return true
 	}
 	return false
@@ -28,7 +28,20 @@ import (
 	"github.com/octelium/octelium/pkg/utils/utilrand"
 )
 
+const (
+	LabelRootSecret              = "octelium-root-secret"
+	LabelIdentityAssertionSecret = "octelium-identity-assertion-secret"
+)
+
 func CreateJWKSecret(ctx context.Context, octeliumC octeliumc.ClientInterface) (*corev1.Secret, error) {
+	return createSecret(ctx, octeliumC, "root-secret", LabelRootSecret)
+}
+
+func CreateIdentityAssertionSecret(ctx context.Context, octeliumC octeliumc.ClientInterface) (*corev1.Secret, error) {
+	return createSecret(ctx, octeliumC, "identity-assertion-secret", LabelIdentityAssertionSecret)
+}
+
+func createSecret(ctx context.Context, octeliumC octeliumc.ClientInterface, prefix, label string) (*corev1.Secret, error) {
 
 	_, priv, err := ed25519.GenerateKey(nil)
 	if err != nil {
@@ -42,10 +55,10 @@ func CreateJWKSecret(ctx context.Context, octeliumC octeliumc.ClientInterface) (
 
 	secret := &corev1.Secret{
 		Metadata: &metav1.Metadata{
-			Name: fmt.Sprintf("sys:root-secret-%s", utilrand.GetRandomStringLowercase(8)),
+			Name: fmt.Sprintf("sys:%s-%s", prefix, utilrand.GetRandomStringLowercase(8)),
 			SystemLabels: map[string]string{
-				"octelium-root-secret": "true",
-				"type":                 "ed25519",
+				label:  "true",
+				"type": "ed25519",
 			},
 			IsSystem:       true,
 			IsSystemHidden: true,
@@ -0,0 +1,233 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package jwkctl
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+// JWK is a public key in the JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+type JWKS struct {
+	Keys []*JWK `json:"keys"`
+}
+
+func getJWTAlg(kind string) (string, error) {
+	switch kind {
+	case KindEd25519:
+		return "EdDSA", nil
+	case KindECDSAP256:
+		return "ES256", nil
+	default:
+		return "", errors.Errorf("Unsupported key kind")
+	}
+}
+
+// SignJWT creates a compact JWS (RFC 7515) of the given claims using the
+// newest key. The key UID is used as the "kid" header
+func (c *Controller) SignJWT(claims any) (string, error) {
+	if c.opts.IsVerificationMode {
+		return "", errors.Errorf("Cannot create tokens in verification mode")
+	}
+
+	key, err := c.chooseJWK()
+	if err != nil {
+		return "", err
+	}
+
+	if key.key == nil {
+		return "", errors.Errorf("No signing key available")
+	}
+
+	alg, err := getJWTAlg(key.kind)
+	if err != nil {
+		return "", err
+	}
+
+	hdrBytes, err := json.Marshal(&jwtHeader{
+		Alg: alg,
+		Typ: "JWT",
+		Kid: key.uid,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claimsBytes, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(hdrBytes) + "." +
+		base64.RawURLEncoding.EncodeToString(claimsBytes)
+
+	var sig []byte
+	switch key.kind {
+	case KindEd25519:
+		priv, ok := key.key.(ed25519.PrivateKey)
+		if !ok {
+			return "", errors.Errorf("Invalid ed25519 signing key")
+		}
+		sig = ed25519.Sign(priv, []byte(signingInput))
+	case KindECDSAP256:
+		priv, ok := key.key.(*ecdsa.PrivateKey)
+		if !ok {
+			return "", errors.Errorf("Invalid ECDSA signing key")
+		}
+		sig, err = signECDSAP256(priv, []byte(signingInput))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// VerifyJWT verifies the signature of a JWT created by SignJWT and
+// unmarshals its claims. Validating the claims is left to the caller
+func (c *Controller) VerifyJWT(tknStr string, claims any) error {
+	if len(tknStr) > 16384 {
+		return errors.Errorf("Token is too large")
+	}
+
+	parts := strings.Split(tknStr, ".")
+	if len(parts) != 3 {
+		return errors.Errorf("Invalid token")
+	}
+
+	hdrBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return errors.Errorf("Could not decode token header")
+	}
+
+	hdr := &jwtHeader{}
+	if err := json.Unmarshal(hdrBytes, hdr); err != nil {
+		return errors.Errorf("Could not parse token header")
+	}
+
+	key, err := c.findKeyByUID(hdr.Kid)
+	if err != nil {
+		return err
+	}
+
+	alg, err := getJWTAlg(key.kind)
+	if err != nil {
+		return err
+	}
+	if hdr.Alg != alg {
+		return errors.Errorf("Invalid token alg: %s", hdr.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errors.Errorf("Could not decode token signature")
+	}
+
+	signingInput := []byte(parts[0] + "." + parts[1])
+
+	switch key.kind {
+	case KindEd25519:
+		pub, ok := key.publicKey.(ed25519.PublicKey)
+		if !ok {
+			return errors.Errorf("Invalid ed25519 public key")
+		}
+		if !ed25519.Verify(pub, signingInput, sig) {
+			return errors.Errorf("Invalid signature")
+		}
+	case KindECDSAP256:
+		pub, ok := key.publicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.Errorf("Invalid ECDSA public key")
+		}
+		if !verifyECDSAP256(pub, signingInput, sig) {
+			return errors.Errorf("Invalid signature")
+		}
+	}
+
+	claimsBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return errors.Errorf("Could not decode token claims")
+	}
+
+	return json.Unmarshal(claimsBytes, claims)
+}
+
+// GetJWKS returns the public keys of the Controller as a JWKS
+func (c *Controller) GetJWKS() *JWKS {
+	c.ctl.RLock()
+	defer c.ctl.RUnlock()
+
+	ret := &JWKS{
+		Keys: []*JWK{},
+	}
+
+	for _, key := range c.ctl.keyMap {
+		alg, err := getJWTAlg(key.kind)
+		if err != nil {
+			continue
+		}
+
+		jwk := &JWK{
+			Kid: key.uid,
+			Use: "sig",
+			Alg: alg,
+		}
+
+		switch pub := key.publicKey.(type) {
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		case *ecdsa.PublicKey:
+			x := make([]byte, 32)
+			y := make([]byte, 32)
+			pub.X.FillBytes(x)
+			pub.Y.FillBytes(y)
+			jwk.Kty = "EC"
+			jwk.Crv = "P-256"
+			jwk.X = base64.RawURLEncoding.EncodeToString(x)
+			jwk.Y = base64.RawURLEncoding.EncodeToString(y)
+		default:
+			continue
+		}
+
+		ret.Keys = append(ret.Keys, jwk)
+	}
+
+	return ret
+}
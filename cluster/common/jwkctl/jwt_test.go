@@ -0,0 +1,130 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package jwkctl
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJWTController(t *testing.T, signers ...crypto.Signer) *Controller {
+	ret := &Controller{
+		ctl: &ctl{
+			keyMap: make(map[string]*jwtKey),
+		},
+		opts: &Opts{},
+	}
+
+	for i, signer := range signers {
+		kind, err := getKeyKind(signer)
+		assert.Nil(t, err)
+		uid := uuid.New().String()
+		ret.ctl.keyMap[uid] = &jwtKey{
+			kind:      kind,
+			key:       signer,
+			publicKey: signer.Public(),
+			uid:       uid,
+			createdAt: time.Now().Add(time.Duration(i) * time.Second),
+		}
+	}
+
+	return ret
+}
+
+func TestJWT(t *testing.T) {
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	type claims struct {
+		Sub string `json:"sub"`
+	}
+
+	for _, signer := range []crypto.Signer{edPriv, ecPriv} {
+		c := newTestJWTController(t, signer)
+
+		tkn, err := c.SignJWT(&claims{Sub: "usr1"})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(strings.Split(tkn, ".")))
+
+		out := &claims{}
+		err = c.VerifyJWT(tkn, out)
+		assert.Nil(t, err)
+		assert.Equal(t, "usr1", out.Sub)
+
+		parts := strings.Split(tkn, ".")
+		err = c.VerifyJWT(parts[0]+"."+parts[1]+"."+parts[1], out)
+		assert.NotNil(t, err)
+
+		other, err := c.SignJWT(&claims{Sub: "usr2"})
+		assert.Nil(t, err)
+		otherParts := strings.Split(other, ".")
+		err = c.VerifyJWT(parts[0]+"."+otherParts[1]+"."+parts[2], out)
+		assert.NotNil(t, err)
+
+		err = newTestJWTController(t, signer).VerifyJWT(tkn, out)
+		assert.NotNil(t, err, "unknown kid")
+
+		jwks := c.GetJWKS()
+		assert.Equal(t, 1, len(jwks.Keys))
+		for _, k := range jwks.Keys {
+			_, ok := c.ctl.keyMap[k.Kid]
+			assert.True(t, ok)
+			assert.Equal(t, "sig", k.Use)
+		}
+	}
+
+	{
+		c := newTestJWTController(t, edPriv, ecPriv)
+		jwks := c.GetJWKS()
+		assert.Equal(t, 2, len(jwks.Keys))
+
+		tkn, err := c.SignJWT(&claims{Sub: "usr1"})
+		assert.Nil(t, err)
+
+		key, err := c.chooseJWK()
+		assert.Nil(t, err)
+		assert.Equal(t, KindECDSAP256, key.kind)
+
+		out := &claims{}
+		assert.Nil(t, c.VerifyJWT(tkn, out))
+	}
+
+	{
+		c := newTestJWTController(t, edPriv)
+		c.opts.IsVerificationMode = true
+		_, err := c.SignJWT(&claims{Sub: "usr1"})
+		assert.NotNil(t, err)
+	}
+
+	{
+		c := newTestJWTController(t, edPriv)
+		for _, tkn := range []string{"", "a.b", "a.b.c", "a.b.c.d"} {
+			assert.NotNil(t, c.VerifyJWT(tkn, &claims{}))
+		}
+	}
+}
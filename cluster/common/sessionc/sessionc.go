@@ -29,6 +29,7 @@ import (
 	"github.com/octelium/octelium/cluster/common/geoipctl"
 	"github.com/octelium/octelium/cluster/common/grpcutils"
 	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/cluster/common/sessionrisk"
 	"github.com/octelium/octelium/cluster/common/urscsrv"
 	"github.com/octelium/octelium/cluster/common/vutils"
 	"github.com/octelium/octelium/pkg/apiutils/umetav1"
@@ -484,6 +485,7 @@ func SetCurrAuthentication(o *SetCurrAuthenticationOpts) {
 		}
 	}
 
+	sessionrisk.Evaluate(cc, sess, sessionrisk.ObservationFromAuthentication(sess.Status.Authentication))
 }
 
 func prependAuthenticationToLastArray(sess *corev1.Session) {
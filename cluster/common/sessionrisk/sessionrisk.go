@@ -0,0 +1,447 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package sessionrisk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/common/apivalidation"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultMaxTravelSpeed = 1000
+	defaultSignalDuration = 24 * time.Hour
+	defaultBurstWindow    = time.Minute
+	maxScore              = 100
+	earthRadiusKM         = 6371.0
+)
+
+var signalScores = map[corev1.Session_Status_Risk_Signal_Type]uint32{
+	corev1.Session_Status_Risk_Signal_IMPOSSIBLE_TRAVEL: 60,
+	corev1.Session_Status_Risk_Signal_NEW_COUNTRY:       30,
+	corev1.Session_Status_Risk_Signal_NEW_ASN:           10,
+	corev1.Session_Status_Risk_Signal_NEW_DEVICE:        20,
+	corev1.Session_Status_Risk_Signal_DENIED_BURST:      30,
+}
+
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionReauthenticate
+	ActionTerminate
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionReauthenticate:
+		return "REAUTHENTICATE"
+	case ActionTerminate:
+		return "TERMINATE"
+	default:
+		return "NONE"
+	}
+}
+
+// Observation is a single use of the Session (e.g. a credential refresh or a
+// new connection) that is evaluated against the Session's history
+type Observation struct {
+	At        time.Time
+	GeoIP     *corev1.GeoIP
+	UserAgent string
+	// TokenID is the ID of the Authentication that produced the Observation,
+	// if any, so that it is excluded from the history
+	TokenID string
+}
+
+// ObservationFromAuthentication returns the Observation of an Authentication
+func ObservationFromAuthentication(authn *corev1.Session_Status_Authentication) *Observation {
+	if authn == nil {
+		return nil
+	}
+
+	ret := &Observation{
+		At:      time.Now(),
+		TokenID: authn.TokenID,
+	}
+	if authn.SetAt != nil {
+		ret.At = authn.SetAt.AsTime()
+	}
+	if authn.Info != nil {
+		ret.GeoIP = authn.Info.Geoip
+		ret.UserAgent = authn.Info.GetDownstream().GetUserAgent()
+	}
+
+	return ret
+}
+
+func getConfig(cc *corev1.ClusterConfig) *corev1.ClusterConfig_Spec_Session_Risk {
+	if cc == nil || cc.Spec == nil || cc.Spec.Session == nil {
+		return nil
+	}
+	return cc.Spec.Session.Risk
+}
+
+// IsEnabled returns whether the Session risk evaluation is enabled
+func IsEnabled(cc *corev1.ClusterConfig) bool {
+	return getConfig(cc).GetIsEnabled()
+}
+
+// GetDeniedBurst returns the denied requests limit and window. A zero limit
+// means that denied requests are not taken into account.
+func GetDeniedBurst(cc *corev1.ClusterConfig) (uint32, time.Duration) {
+	burst := getConfig(cc).GetDeniedBurst()
+	if burst == nil || burst.Limit == 0 {
+		return 0, 0
+	}
+
+	window := umetav1.ToDuration(burst.Window).ToGo()
+	if window <= 0 {
+		window = defaultBurstWindow
+	}
+
+	return burst.Limit, window
+}
+
+func getMaxTravelSpeed(cfg *corev1.ClusterConfig_Spec_Session_Risk) float64 {
+	if cfg.GetMaxTravelSpeed() > 0 {
+		return float64(cfg.MaxTravelSpeed)
+	}
+	return defaultMaxTravelSpeed
+}
+
+func getSignalDuration(cfg *corev1.ClusterConfig_Spec_Session_Risk) time.Duration {
+	if d := umetav1.ToDuration(cfg.GetSignalDuration()).ToGo(); d > 0 {
+		return d
+	}
+	return defaultSignalDuration
+}
+
+// Evaluate compares the Observation against the Session's authentication
+// history, merges the detected signals into the Session's risk and
+// recomputes its score. It returns the newly detected signals.
+func Evaluate(cc *corev1.ClusterConfig, sess *corev1.Session, obs *Observation) []*corev1.Session_Status_Risk_Signal {
+	if !IsEnabled(cc) || sess == nil || sess.Status == nil || obs == nil {
+		return nil
+	}
+
+	cfg := getConfig(cc)
+	history := getHistory(sess, obs.TokenID)
+
+	var signals []*corev1.Session_Status_Risk_Signal
+	add := func(typ corev1.Session_Status_Risk_Signal_Type, format string, args ...any) {
+		signals = append(signals, &corev1.Session_Status_Risk_Signal{
+			Type:       typ,
+			Score:      signalScores[typ],
+			DetectedAt: timestamppb.New(obs.At),
+			Details:    fmt.Sprintf(format, args...),
+		})
+	}
+
+	if len(history) > 0 {
+		if prev := getLastLocated(history); prev != nil && hasCoordinates(obs.GeoIP) {
+			speed := getTravelSpeed(prev.Info.Geoip, obs.GeoIP, obs.At.Sub(prev.SetAt.AsTime()))
+			if speed > getMaxTravelSpeed(cfg) {
+				add(corev1.Session_Status_Risk_Signal_IMPOSSIBLE_TRAVEL,
+					"Travel from %s to %s at %.0f km/h",
+					getLocationName(prev.Info.Geoip), getLocationName(obs.GeoIP), speed)
+			}
+		}
+
+		if country := obs.GeoIP.GetCountry().GetCode(); country != "" {
+			if isNewValue(history, country, func(g *corev1.GeoIP) string {
+				return g.GetCountry().GetCode()
+			}) {
+				add(corev1.Session_Status_Risk_Signal_NEW_COUNTRY, "New country: %s", country)
+			}
+		}
+
+		if asn := obs.GeoIP.GetNetwork().GetAsn(); asn != 0 {
+			if isNewValue(history, fmt.Sprintf("%d", asn), func(g *corev1.GeoIP) string {
+				if g.GetNetwork().GetAsn() == 0 {
+					return ""
+				}
+				return fmt.Sprintf("%d", g.GetNetwork().GetAsn())
+			}) {
+				add(corev1.Session_Status_Risk_Signal_NEW_ASN, "New ASN: %d", asn)
+			}
+		}
+
+		if fp := getDeviceFingerprint(obs.UserAgent); fp != "" {
+			var hasFP, found bool
+			for _, authn := range history {
+				if cur := getDeviceFingerprint(authn.Info.GetDownstream().GetUserAgent()); cur != "" {
+					hasFP = true
+					if cur == fp {
+						found = true
+						break
+					}
+				}
+			}
+			if hasFP && !found {
+				add(corev1.Session_Status_Risk_Signal_NEW_DEVICE, "New user agent: %s", obs.UserAgent)
+			}
+		}
+	}
+
+	for _, signal := range signals {
+		zap.L().Info("Detected Session risk signal",
+			zap.String("session", sess.Metadata.Name),
+			zap.String("type", signal.Type.String()),
+			zap.Uint32("score", signal.Score),
+			zap.String("details", signal.Details))
+	}
+
+	setRisk(cfg, sess, obs.At, signals...)
+
+	return signals
+}
+
+// AddSignal adds a signal to the Session's risk and recomputes its score
+func AddSignal(cc *corev1.ClusterConfig, sess *corev1.Session,
+	typ corev1.Session_Status_Risk_Signal_Type, details string) *corev1.Session_Status_Risk_Signal {
+	if !IsEnabled(cc) || sess == nil || sess.Status == nil {
+		return nil
+	}
+
+	now := time.Now()
+	signal := &corev1.Session_Status_Risk_Signal{
+		Type:       typ,
+		Score:      signalScores[typ],
+		DetectedAt: timestamppb.New(now),
+		Details:    details,
+	}
+
+	zap.L().Info("Detected Session risk signal",
+		zap.String("session", sess.Metadata.Name),
+		zap.String("type", signal.Type.String()),
+		zap.Uint32("score", signal.Score),
+		zap.String("details", signal.Details))
+
+	setRisk(getConfig(cc), sess, now, signal)
+	return signal
+}
+
+// Reset clears the Session's risk signals. It is used once the Session
+// successfully re-authenticates with an Authenticator.
+func Reset(sess *corev1.Session) {
+	if sess == nil || sess.Status == nil || sess.Status.Risk == nil {
+		return
+	}
+
+	sess.Status.Risk = &corev1.Session_Status_Risk{
+		EvaluatedAt: timestamppb.Now(),
+	}
+}
+
+func setRisk(cfg *corev1.ClusterConfig_Spec_Session_Risk,
+	sess *corev1.Session, now time.Time, signals ...*corev1.Session_Status_Risk_Signal) {
+	if sess.Status.Risk == nil {
+		sess.Status.Risk = &corev1.Session_Status_Risk{}
+	}
+	risk := sess.Status.Risk
+
+	for _, signal := range signals {
+		replaced := false
+		for i, cur := range risk.Signals {
+			if cur.Type == signal.Type {
+				risk.Signals[i] = signal
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			risk.Signals = append(risk.Signals, signal)
+		}
+	}
+
+	signalDuration := getSignalDuration(cfg)
+	var active []*corev1.Session_Status_Risk_Signal
+	var score uint32
+	for _, signal := range risk.Signals {
+		if signal.DetectedAt == nil || now.Sub(signal.DetectedAt.AsTime()) > signalDuration {
+			continue
+		}
+		active = append(active, signal)
+		score += signal.Score
+	}
+
+	risk.Signals = active
+	risk.Score = min(score, maxScore)
+	risk.EvaluatedAt = timestamppb.New(now)
+}
+
+// GetAction returns the action that the Session's current risk score requires
+func GetAction(cc *corev1.ClusterConfig, sess *corev1.Session) Action {
+	cfg := getConfig(cc)
+	if !cfg.GetIsEnabled() || sess == nil || sess.Status == nil || sess.Status.Risk == nil {
+		return ActionNone
+	}
+
+	score := sess.Status.Risk.Score
+	switch {
+	case cfg.TerminateScore > 0 && score >= cfg.TerminateScore:
+		return ActionTerminate
+	case cfg.ReauthenticateScore > 0 && score >= cfg.ReauthenticateScore:
+		return ActionReauthenticate
+	default:
+		return ActionNone
+	}
+}
+
+// Enforce applies the action that the Session's current risk score requires.
+// A terminated Session is deleted. A Session that is required to
+// re-authenticate has its AuthenticatorAction set and it is up to the caller
+// to update the Session.
+func Enforce(ctx context.Context,
+	octeliumC octeliumc.ClientInterface, cc *corev1.ClusterConfig, sess *corev1.Session) (Action, error) {
+	action := GetAction(cc, sess)
+
+	switch action {
+	case ActionTerminate:
+		zap.L().Warn("Terminating Session due to its risk score",
+			zap.String("session", sess.Metadata.Name),
+			zap.Uint32("score", sess.Status.Risk.Score))
+		if _, err := octeliumC.CoreC().DeleteSession(ctx,
+			apivalidation.ObjectToRDeleteOptions(sess)); err != nil {
+			return action, err
+		}
+	case ActionReauthenticate:
+		if sess.Status.AuthenticatorAction != corev1.Session_Status_AUTHENTICATION_REQUIRED {
+			zap.L().Warn("Requiring Session re-authentication due to its risk score",
+				zap.String("session", sess.Metadata.Name),
+				zap.Uint32("score", sess.Status.Risk.Score))
+			sess.Status.AuthenticatorAction = corev1.Session_Status_AUTHENTICATION_REQUIRED
+		}
+	}
+
+	return action, nil
+}
+
+func getHistory(sess *corev1.Session, excludeTokenID string) []*corev1.Session_Status_Authentication {
+	var ret []*corev1.Session_Status_Authentication
+
+	appendAuthn := func(authn *corev1.Session_Status_Authentication) {
+		if authn == nil || authn.SetAt == nil {
+			return
+		}
+		if excludeTokenID != "" && authn.TokenID == excludeTokenID {
+			return
+		}
+		ret = append(ret, authn)
+	}
+
+	appendAuthn(sess.Status.Authentication)
+	for _, authn := range sess.Status.LastAuthentications {
+		appendAuthn(authn)
+	}
+	appendAuthn(sess.Status.InitialAuthentication)
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].SetAt.AsTime().After(ret[j].SetAt.AsTime())
+	})
+
+	return ret
+}
+
+func getLastLocated(history []*corev1.Session_Status_Authentication) *corev1.Session_Status_Authentication {
+	for _, authn := range history {
+		if hasCoordinates(authn.Info.GetGeoip()) {
+			return authn
+		}
+	}
+	return nil
+}
+
+func isNewValue(history []*corev1.Session_Status_Authentication, val string, fn func(g *corev1.GeoIP) string) bool {
+	hasAny := false
+	for _, authn := range history {
+		cur := fn(authn.Info.GetGeoip())
+		if cur == "" {
+			continue
+		}
+		hasAny = true
+		if cur == val {
+			return false
+		}
+	}
+
+	return hasAny
+}
+
+func hasCoordinates(g *corev1.GeoIP) bool {
+	c := g.GetCoordinates()
+	return c != nil && (c.Latitude != 0 || c.Longitude != 0)
+}
+
+func getLocationName(g *corev1.GeoIP) string {
+	if g.GetCountry().GetCode() != "" {
+		return g.GetCountry().GetCode()
+	}
+	return g.GetIp()
+}
+
+// getTravelSpeed returns the speed in km/h needed to travel between the two
+// locations within the elapsed duration taking their accuracy into account
+func getTravelSpeed(a, b *corev1.GeoIP, elapsed time.Duration) float64 {
+	ca, cb := a.GetCoordinates(), b.GetCoordinates()
+	distance := getDistance(ca.Latitude, ca.Longitude, cb.Latitude, cb.Longitude) -
+		ca.AccuracyRadius - cb.AccuracyRadius
+	if distance <= 0 {
+		return 0
+	}
+
+	hours := math.Max(elapsed.Hours(), time.Minute.Hours())
+	return distance / hours
+}
+
+// getDistance returns the haversine distance in km
+func getDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(d float64) float64 {
+		return d * math.Pi / 180
+	}
+
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusKM * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// getDeviceFingerprint strips the versions from the user agent so that
+// software upgrades are not detected as new devices
+func getDeviceFingerprint(userAgent string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, userAgent))
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package sessionrisk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newGeoIP(country string, asn int64, lat, lon float64) *corev1.GeoIP {
+	return &corev1.GeoIP{
+		Country: &corev1.GeoIP_Country{
+			Code: country,
+		},
+		Network: &corev1.GeoIP_Network{
+			Asn: asn,
+		},
+		Coordinates: &corev1.GeoIP_Coordinates{
+			Latitude:       lat,
+			Longitude:      lon,
+			AccuracyRadius: 20,
+		},
+	}
+}
+
+func newAuthn(tokenID string, at time.Time, geoip *corev1.GeoIP, userAgent string) *corev1.Session_Status_Authentication {
+	return &corev1.Session_Status_Authentication{
+		TokenID: tokenID,
+		SetAt:   timestamppb.New(at),
+		Info: &corev1.Session_Status_Authentication_Info{
+			Geoip: geoip,
+			Downstream: &corev1.Session_Status_Authentication_Info_Downstream{
+				UserAgent: userAgent,
+			},
+		},
+	}
+}
+
+func newCC(risk *corev1.ClusterConfig_Spec_Session_Risk) *corev1.ClusterConfig {
+	return &corev1.ClusterConfig{
+		Spec: &corev1.ClusterConfig_Spec{
+			Session: &corev1.ClusterConfig_Spec_Session{
+				Risk: risk,
+			},
+		},
+	}
+}
+
+func getTypes(signals []*corev1.Session_Status_Risk_Signal) []corev1.Session_Status_Risk_Signal_Type {
+	var ret []corev1.Session_Status_Risk_Signal_Type
+	for _, s := range signals {
+		ret = append(ret, s.Type)
+	}
+	return ret
+}
+
+func TestGetDistance(t *testing.T) {
+	// Paris to New York is roughly 5837 km
+	d := getDistance(48.8566, 2.3522, 40.7128, -74.0060)
+	assert.InDelta(t, 5837, d, 20)
+
+	assert.Equal(t, float64(0), getDistance(10, 10, 10, 10))
+}
+
+func TestGetDeviceFingerprint(t *testing.T) {
+	assert.Equal(t,
+		getDeviceFingerprint("octelium-cli/0.10.1 linux"),
+		getDeviceFingerprint("octelium-cli/0.11.0 linux"))
+	assert.NotEqual(t,
+		getDeviceFingerprint("octelium-cli/0.10.1 linux"),
+		getDeviceFingerprint("octelium-cli/0.10.1 darwin"))
+	assert.Equal(t, "", getDeviceFingerprint(""))
+}
+
+func TestEvaluate(t *testing.T) {
+	paris := newGeoIP("FR", 3215, 48.8566, 2.3522)
+	lyon := newGeoIP("FR", 3215, 45.7640, 4.8357)
+	newYork := newGeoIP("US", 7018, 40.7128, -74.0060)
+	const ua = "octelium-cli/0.10.1 linux"
+
+	now := time.Now()
+
+	newSess := func() *corev1.Session {
+		return &corev1.Session{
+			Metadata: &metav1.Metadata{
+				Name: "sess",
+			},
+			Status: &corev1.Session_Status{
+				InitialAuthentication: newAuthn("init", now.Add(-2*time.Hour), paris, ua),
+				Authentication:        newAuthn("curr", now.Add(-1*time.Hour), paris, ua),
+			},
+		}
+	}
+
+	cc := newCC(&corev1.ClusterConfig_Spec_Session_Risk{
+		IsEnabled:           true,
+		ReauthenticateScore: 30,
+		TerminateScore:      80,
+	})
+
+	{
+		sess := newSess()
+		signals := Evaluate(newCC(nil), sess, &Observation{
+			At:    now,
+			GeoIP: newYork,
+		})
+		assert.Nil(t, signals)
+		assert.Nil(t, sess.Status.Risk)
+		assert.Equal(t, ActionNone, GetAction(newCC(nil), sess))
+	}
+
+	{
+		sess := newSess()
+		signals := Evaluate(cc, sess, &Observation{
+			At:        now,
+			GeoIP:     lyon,
+			UserAgent: "octelium-cli/0.11.0 linux",
+		})
+		assert.Len(t, signals, 0)
+		assert.Equal(t, uint32(0), sess.Status.Risk.Score)
+		assert.NotNil(t, sess.Status.Risk.EvaluatedAt)
+		assert.Equal(t, ActionNone, GetAction(cc, sess))
+	}
+
+	{
+		sess := newSess()
+		signals := Evaluate(cc, sess, &Observation{
+			At:        now,
+			GeoIP:     newYork,
+			UserAgent: "Mozilla/5.0",
+		})
+		assert.ElementsMatch(t, []corev1.Session_Status_Risk_Signal_Type{
+			corev1.Session_Status_Risk_Signal_IMPOSSIBLE_TRAVEL,
+			corev1.Session_Status_Risk_Signal_NEW_COUNTRY,
+			corev1.Session_Status_Risk_Signal_NEW_ASN,
+			corev1.Session_Status_Risk_Signal_NEW_DEVICE,
+		}, getTypes(signals))
+		assert.Equal(t, uint32(maxScore), sess.Status.Risk.Score)
+		assert.Equal(t, ActionTerminate, GetAction(cc, sess))
+	}
+
+	{
+		// Travelling to New York within 12 hours is plausible
+		sess := newSess()
+		signals := Evaluate(cc, sess, &Observation{
+			At:        now.Add(11 * time.Hour),
+			GeoIP:     newYork,
+			UserAgent: ua,
+		})
+		assert.ElementsMatch(t, []corev1.Session_Status_Risk_Signal_Type{
+			corev1.Session_Status_Risk_Signal_NEW_COUNTRY,
+			corev1.Session_Status_Risk_Signal_NEW_ASN,
+		}, getTypes(signals))
+		assert.Equal(t, uint32(40), sess.Status.Risk.Score)
+		assert.Equal(t, ActionReauthenticate, GetAction(cc, sess))
+
+		Reset(sess)
+		assert.Equal(t, uint32(0), sess.Status.Risk.Score)
+		assert.Equal(t, ActionNone, GetAction(cc, sess))
+	}
+
+	{
+		// The Authentication of the Observation itself is excluded from the history
+		sess := newSess()
+		sess.Status.Authentication = newAuthn("new", now, newYork, ua)
+		signals := Evaluate(cc, sess, ObservationFromAuthentication(sess.Status.Authentication))
+		assert.Contains(t, getTypes(signals), corev1.Session_Status_Risk_Signal_IMPOSSIBLE_TRAVEL)
+	}
+}
+
+func TestAddSignal(t *testing.T) {
+	cc := newCC(&corev1.ClusterConfig_Spec_Session_Risk{
+		IsEnabled:      true,
+		TerminateScore: 50,
+		SignalDuration: &metav1.Duration{
+			Type: &metav1.Duration_Hours{
+				Hours: 1,
+			},
+		},
+	})
+
+	sess := &corev1.Session{
+		Metadata: &metav1.Metadata{
+			Name: "sess",
+		},
+		Status: &corev1.Session_Status{},
+	}
+
+	AddSignal(cc, sess, corev1.Session_Status_Risk_Signal_DENIED_BURST, "")
+	AddSignal(cc, sess, corev1.Session_Status_Risk_Signal_DENIED_BURST, "")
+	assert.Len(t, sess.Status.Risk.Signals, 1)
+	assert.Equal(t, uint32(30), sess.Status.Risk.Score)
+	assert.Equal(t, ActionNone, GetAction(cc, sess))
+
+	sess.Status.Risk.Signals[0].DetectedAt = timestamppb.New(time.Now().Add(-2 * time.Hour))
+	AddSignal(cc, sess, corev1.Session_Status_Risk_Signal_NEW_DEVICE, "")
+	assert.Len(t, sess.Status.Risk.Signals, 1)
+	assert.Equal(t, uint32(20), sess.Status.Risk.Score)
+
+	AddSignal(cc, sess, corev1.Session_Status_Risk_Signal_IMPOSSIBLE_TRAVEL, "")
+	assert.Equal(t, uint32(80), sess.Status.Risk.Score)
+	assert.Equal(t, ActionTerminate, GetAction(cc, sess))
+}
+
+func TestGetDeniedBurst(t *testing.T) {
+	limit, _ := GetDeniedBurst(newCC(&corev1.ClusterConfig_Spec_Session_Risk{
+		IsEnabled: true,
+	}))
+	assert.Equal(t, uint32(0), limit)
+
+	limit, window := GetDeniedBurst(newCC(&corev1.ClusterConfig_Spec_Session_Risk{
+		IsEnabled: true,
+		DeniedBurst: &corev1.ClusterConfig_Spec_Session_Risk_DeniedBurst{
+			Limit: 20,
+		},
+	}))
+	assert.Equal(t, uint32(20), limit)
+	assert.Equal(t, defaultBurstWindow, window)
+}
@@ -372,6 +372,11 @@ const MaxPolicyParents = 5
 const MaxNameSubArgs = 7
 const ManagedServicePort = 49999
 
+// ManagedDNSGroupsOptionCode is the EDNS0 local option code used by Vigil to
+// pass the comma-separated Group names of the requesting User to the
+// Cluster's managed DNS server
+const ManagedDNSGroupsOptionCode = 65431
+
 const HealthCheckPortVigil = 49094
 const HealthCheckPortManagedService = 49095
 const HealthCheckPortMain = 49090
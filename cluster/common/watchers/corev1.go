@@ -185,6 +185,16 @@ func (c *CoreV1Watcher) Authenticator(
 	return runWatcherCoreV1(ctx, c.octeliumC, opts, string(ucorev1.KindAuthenticator), onCreate, onUpdate, onDelete)
 }
 
+func (c *CoreV1Watcher) AccessRequest(
+	ctx context.Context,
+	opts *Opts,
+	onCreate func(ctx context.Context, item *corev1.AccessRequest) error,
+	onUpdate func(ctx context.Context, new, old *corev1.AccessRequest) error,
+	onDelete func(ctx context.Context, item *corev1.AccessRequest) error,
+) error {
+	return runWatcherCoreV1(ctx, c.octeliumC, opts, ucorev1.KindAccessRequest, onCreate, onUpdate, onDelete)
+}
+
 func (c *CoreV1Watcher) ClusterConfig(
 	ctx context.Context,
 	opts *Opts,
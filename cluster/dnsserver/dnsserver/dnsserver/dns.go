@@ -52,6 +52,7 @@ type DNSServer struct {
 	ccCtl             *ccctl.Controller
 	mu                sync.RWMutex
 	fallbackZoneCache *zoneCache
+	customZones       []*customZone
 
 	reservedNamespaces []string
 }
@@ -76,6 +77,7 @@ func Initialize(ctx context.Context, octeliumC octeliumc.ClientInterface) (*DNSS
 			if !pbutils.IsEqual(new.Spec.Dns, old.Spec.Dns) {
 				zap.L().Debug("Updating fallback upstreams", zap.Any("dnsConfig", new.Spec.Dns))
 				ret.setDefaultUpstreams(new)
+				ret.setCustomZones(new)
 				ret.fallbackZoneCache.setDuration(getDuration(new))
 			}
 
@@ -88,6 +90,7 @@ func Initialize(ctx context.Context, octeliumC octeliumc.ClientInterface) (*DNSS
 	ret.ccCtl = ccCtl
 	ret.domain = ccCtl.Get().Status.Domain
 	ret.setDefaultUpstreams(ccCtl.Get())
+	ret.setCustomZones(ccCtl.Get())
 
 	ret.fallbackZoneCache = newZoneCache(getDuration(ret.ccCtl.Get()))
 
@@ -159,11 +162,12 @@ func (s *DNSServer) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	msg.SetReply(r)
 
 	domain := strings.ToLower(msg.Question[0].Name)
+	groups := getManagedDNSGroups(r)
 
 	switch msg.Question[0].Qtype {
 	case dns.TypeA, dns.TypeAAAA:
 	default:
-		ret, err := s.getProxiedAnswer(domain, r.Question[0].Qtype)
+		ret, err := s.getProxiedAnswer(domain, r.Question[0].Qtype, groups)
 		if err != nil {
 			msg.SetRcode(r, dns.RcodeServerFailure)
 			w.WriteMsg(&msg)
@@ -186,7 +190,7 @@ func (s *DNSServer) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	hostname, err := s.getHostname(domain)
 	if err != nil {
 		// zap.L().Debug("Could not get hostname", zap.String("domain", domain), zap.Error(err))
-		ret, err := s.getProxiedAnswer(domain, r.Question[0].Qtype)
+		ret, err := s.getProxiedAnswer(domain, r.Question[0].Qtype, groups)
 		if err != nil {
 			msg.SetRcode(r, dns.RcodeServerFailure)
 			w.WriteMsg(&msg)
@@ -342,13 +346,26 @@ func (s *DNSServer) Run(ctx context.Context) error {
 	return nil
 }
 
-func (s *DNSServer) getProxiedAnswer(domain string, typ uint16) (*dns.Msg, error) {
+func (s *DNSServer) getProxiedAnswer(domain string, typ uint16, groups []string) (*dns.Msg, error) {
+	if ret, ok, err := s.getCustomZoneAnswer(domain, typ, groups); ok {
+		return ret, err
+	}
 
 	if cached := s.fallbackZoneCache.get(domain, typ); cached != nil {
 		return cached, nil
 	}
 
-	upstream := s.chooseUpstream()
+	r, err := exchangeUpstream(s.chooseUpstream(), domain, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	s.fallbackZoneCache.set(domain, typ, r)
+
+	return r, nil
+}
+
+func exchangeUpstream(upstream *upstream, domain string, typ uint16) (*dns.Msg, error) {
 	c := dns.Client{
 		Net:     upstream.typ,
 		Timeout: 6 * time.Second,
@@ -362,8 +379,6 @@ func (s *DNSServer) getProxiedAnswer(domain string, typ uint16) (*dns.Msg, error
 		return nil, err
 	}
 
-	s.fallbackZoneCache.set(domain, typ, r)
-
 	return r, nil
 }
 
@@ -378,51 +393,10 @@ func (s *DNSServer) setDefaultUpstreams(cc *corev1.ClusterConfig) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	getPort := func(u *url.URL) int {
-		if u.Port() != "" {
-			if port, err := strconv.Atoi(u.Port()); err == nil && port > 0 && port <= 65535 {
-				return port
-			}
-		}
-		return 0
-	}
-
 	s.upstreams = nil
 	if cc != nil && cc.Spec.Dns != nil && cc.Spec.Dns.FallbackZone != nil &&
 		len(cc.Spec.Dns.FallbackZone.Servers) > 0 {
-		for _, server := range cc.Spec.Dns.FallbackZone.Servers {
-			u, err := url.Parse(server)
-			if err != nil {
-				zap.L().Warn("Could not parse fallback server. Skipping...",
-					zap.Error(err), zap.String("server", server))
-				continue
-			}
-
-			switch u.Scheme {
-			case "dns", "", "udp":
-				upstream := &upstream{
-					host: u.Host,
-					port: getPort(u),
-				}
-				if upstream.port == 0 {
-					upstream.port = 53
-				}
-				s.upstreams = append(s.upstreams, upstream)
-			case "tls":
-				upstream := &upstream{
-					host: u.Host,
-					port: getPort(u),
-					typ:  "tcp-tls",
-				}
-				if upstream.port == 0 {
-					upstream.port = 853
-				}
-				s.upstreams = append(s.upstreams, upstream)
-			default:
-				continue
-			}
-
-		}
+		s.upstreams = parseUpstreams(cc.Spec.Dns.FallbackZone.Servers)
 	}
 
 	if len(s.upstreams) > 0 {
@@ -446,6 +420,54 @@ func (s *DNSServer) setDefaultUpstreams(cc *corev1.ClusterConfig) {
 
 }
 
+func parseUpstreams(servers []string) []*upstream {
+	var ret []*upstream
+
+	getPort := func(u *url.URL) int {
+		if u.Port() != "" {
+			if port, err := strconv.Atoi(u.Port()); err == nil && port > 0 && port <= 65535 {
+				return port
+			}
+		}
+		return 0
+	}
+
+	for _, server := range servers {
+		u, err := url.Parse(server)
+		if err != nil {
+			zap.L().Warn("Could not parse upstream server. Skipping...",
+				zap.Error(err), zap.String("server", server))
+			continue
+		}
+
+		switch u.Scheme {
+		case "dns", "", "udp":
+			upstream := &upstream{
+				host: u.Hostname(),
+				port: getPort(u),
+			}
+			if upstream.port == 0 {
+				upstream.port = 53
+			}
+			ret = append(ret, upstream)
+		case "tls":
+			upstream := &upstream{
+				host: u.Hostname(),
+				port: getPort(u),
+				typ:  "tcp-tls",
+			}
+			if upstream.port == 0 {
+				upstream.port = 853
+			}
+			ret = append(ret, upstream)
+		default:
+			continue
+		}
+	}
+
+	return ret
+}
+
 func (s *DNSServer) setReservedNamespaces(ctx context.Context) {
 
 	resp, err := resty.New().SetDebug(ldflags.IsDev()).
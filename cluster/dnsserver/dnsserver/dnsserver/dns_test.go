@@ -334,7 +334,7 @@ func TestResolve(t *testing.T) {
 				r := dnsSrv.fallbackZoneCache.get("one.one.one.one.", dns.TypeA)
 				assert.Nil(t, r)
 			}
-			res, err := dnsSrv.getProxiedAnswer("one.one.one.one.", dns.TypeA)
+			res, err := dnsSrv.getProxiedAnswer("one.one.one.one.", dns.TypeA, nil)
 			assert.Nil(t, err)
 
 			nip := res.Answer[0].(*dns.A).A
@@ -0,0 +1,296 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dnsserver
+
+import (
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/common/vutils"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/utils/utilrand"
+	"go.uber.org/zap"
+)
+
+const defaultRecordTTL = 60
+
+type customZone struct {
+	name      string
+	upstreams []*upstream
+	records   []*corev1.ClusterConfig_Spec_DNS_Zone_Record
+	groups    []string
+	cache     *zoneCache
+}
+
+func newCustomZones(cc *corev1.ClusterConfig) []*customZone {
+	var ret []*customZone
+	if cc == nil || cc.Spec.Dns == nil {
+		return nil
+	}
+
+	for _, zone := range cc.Spec.Dns.Zones {
+		if zone.Name == "" {
+			continue
+		}
+
+		var duration time.Duration
+		if zone.CacheDuration != nil {
+			duration = umetav1.ToDuration(zone.CacheDuration).ToGo()
+		}
+
+		ret = append(ret, &customZone{
+			name:      strings.ToLower(dns.Fqdn(zone.Name)),
+			upstreams: parseUpstreams(zone.Servers),
+			records:   zone.Records,
+			groups:    zone.Groups,
+			cache:     newZoneCache(duration),
+		})
+	}
+
+	return ret
+}
+
+func (s *DNSServer) setCustomZones(cc *corev1.ClusterConfig) {
+	zones := newCustomZones(cc)
+
+	s.mu.Lock()
+	s.customZones = zones
+	s.mu.Unlock()
+}
+
+// getCustomZone returns the custom zone with the longest name matching the
+// domain among the zones visible to the given Groups. A zone that is
+// restricted to Groups takes precedence over an unrestricted zone of the
+// same name.
+func (s *DNSServer) getCustomZone(domain string, groups []string) *customZone {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ret *customZone
+	for _, zone := range s.customZones {
+		if !dns.IsSubDomain(zone.name, domain) {
+			continue
+		}
+
+		if len(zone.groups) > 0 && !slices.ContainsFunc(zone.groups, func(g string) bool {
+			return slices.Contains(groups, g)
+		}) {
+			continue
+		}
+
+		switch {
+		case ret == nil,
+			len(zone.name) > len(ret.name),
+			len(zone.name) == len(ret.name) && len(zone.groups) > 0 && len(ret.groups) == 0:
+			ret = zone
+		}
+	}
+
+	return ret
+}
+
+func (z *customZone) getRecordName(rec *corev1.ClusterConfig_Spec_DNS_Zone_Record) string {
+	if rec.Name == "" || rec.Name == "@" {
+		return z.name
+	}
+
+	return strings.ToLower(dns.Fqdn(rec.Name + "." + z.name))
+}
+
+func (z *customZone) getRecords(domain string) []*corev1.ClusterConfig_Spec_DNS_Zone_Record {
+	var exact []*corev1.ClusterConfig_Spec_DNS_Zone_Record
+	var wildcard []*corev1.ClusterConfig_Spec_DNS_Zone_Record
+
+	for _, rec := range z.records {
+		name := z.getRecordName(rec)
+		switch {
+		case name == domain:
+			exact = append(exact, rec)
+		case strings.HasPrefix(name, "*."):
+			if suffix := strings.TrimPrefix(name, "*"); strings.HasSuffix(domain, suffix) && domain != suffix[1:] {
+				wildcard = append(wildcard, rec)
+			}
+		}
+	}
+
+	if len(exact) > 0 {
+		return exact
+	}
+
+	return wildcard
+}
+
+func getRecordTTL(rec *corev1.ClusterConfig_Spec_DNS_Zone_Record) uint32 {
+	if rec.Ttl == 0 {
+		return defaultRecordTTL
+	}
+	return rec.Ttl
+}
+
+func getRecordRRs(domain string, rec *corev1.ClusterConfig_Spec_DNS_Zone_Record) []dns.RR {
+	var ret []dns.RR
+
+	hdr := func(typ uint16) dns.RR_Header {
+		return dns.RR_Header{Name: domain, Rrtype: typ, Class: dns.ClassINET, Ttl: getRecordTTL(rec)}
+	}
+
+	switch rec.Type {
+	case corev1.ClusterConfig_Spec_DNS_Zone_Record_A:
+		for _, val := range rec.Values {
+			if ip := net.ParseIP(val); ip != nil && ip.To4() != nil {
+				ret = append(ret, &dns.A{Hdr: hdr(dns.TypeA), A: ip.To4()})
+			}
+		}
+	case corev1.ClusterConfig_Spec_DNS_Zone_Record_AAAA:
+		for _, val := range rec.Values {
+			if ip := net.ParseIP(val); ip != nil && ip.To4() == nil {
+				ret = append(ret, &dns.AAAA{Hdr: hdr(dns.TypeAAAA), AAAA: ip})
+			}
+		}
+	case corev1.ClusterConfig_Spec_DNS_Zone_Record_CNAME:
+		if len(rec.Values) > 0 {
+			ret = append(ret, &dns.CNAME{Hdr: hdr(dns.TypeCNAME), Target: dns.Fqdn(rec.Values[0])})
+		}
+	case corev1.ClusterConfig_Spec_DNS_Zone_Record_TXT:
+		if len(rec.Values) > 0 {
+			ret = append(ret, &dns.TXT{Hdr: hdr(dns.TypeTXT), Txt: rec.Values})
+		}
+	}
+
+	return ret
+}
+
+func getRecordType(typ corev1.ClusterConfig_Spec_DNS_Zone_Record_Type) uint16 {
+	switch typ {
+	case corev1.ClusterConfig_Spec_DNS_Zone_Record_A:
+		return dns.TypeA
+	case corev1.ClusterConfig_Spec_DNS_Zone_Record_AAAA:
+		return dns.TypeAAAA
+	case corev1.ClusterConfig_Spec_DNS_Zone_Record_CNAME:
+		return dns.TypeCNAME
+	case corev1.ClusterConfig_Spec_DNS_Zone_Record_TXT:
+		return dns.TypeTXT
+	default:
+		return dns.TypeNone
+	}
+}
+
+// getRecordsAnswer returns the answer of the zone's static records. It
+// returns false if the domain does not match any record and the zone has
+// Servers to which the question should be forwarded instead.
+func (z *customZone) getRecordsAnswer(domain string, typ uint16) (*dns.Msg, bool) {
+	ret := &dns.Msg{}
+	ret.Authoritative = true
+
+	const maxCNAMEChain = 8
+
+	name := domain
+	for range maxCNAMEChain {
+		records := z.getRecords(name)
+		if len(records) == 0 {
+			if name != domain {
+				return ret, true
+			}
+
+			if len(z.upstreams) > 0 {
+				return nil, false
+			}
+
+			ret.Rcode = dns.RcodeNameError
+			return ret, true
+		}
+
+		var cname *corev1.ClusterConfig_Spec_DNS_Zone_Record
+		found := false
+		for _, rec := range records {
+			recTyp := getRecordType(rec.Type)
+			switch {
+			case recTyp == typ:
+				ret.Answer = append(ret.Answer, getRecordRRs(name, rec)...)
+				found = true
+			case recTyp == dns.TypeCNAME && cname == nil:
+				cname = rec
+			}
+		}
+
+		if found || cname == nil {
+			return ret, true
+		}
+
+		rrs := getRecordRRs(name, cname)
+		if len(rrs) == 0 {
+			return ret, true
+		}
+		ret.Answer = append(ret.Answer, rrs...)
+
+		name = strings.ToLower(rrs[0].(*dns.CNAME).Target)
+		if !dns.IsSubDomain(z.name, name) {
+			return ret, true
+		}
+	}
+
+	return ret, true
+}
+
+func getManagedDNSGroups(r *dns.Msg) []string {
+	opt := r.IsEdns0()
+	if opt == nil {
+		return nil
+	}
+
+	for _, o := range opt.Option {
+		if local, ok := o.(*dns.EDNS0_LOCAL); ok && local.Code == vutils.ManagedDNSGroupsOptionCode {
+			return strings.Split(string(local.Data), ",")
+		}
+	}
+
+	return nil
+}
+
+// getCustomZoneAnswer returns the answer of the custom zone matching the
+// domain, if any
+func (s *DNSServer) getCustomZoneAnswer(domain string, typ uint16, groups []string) (*dns.Msg, bool, error) {
+	zone := s.getCustomZone(domain, groups)
+	if zone == nil {
+		return nil, false, nil
+	}
+
+	if ret, ok := zone.getRecordsAnswer(domain, typ); ok {
+		return ret, true, nil
+	}
+
+	if cached := zone.cache.get(domain, typ); cached != nil {
+		return cached, true, nil
+	}
+
+	upstream := zone.upstreams[utilrand.GetRandomRangeMath(0, len(zone.upstreams)-1)]
+
+	ret, err := exchangeUpstream(upstream, domain, typ)
+	if err != nil {
+		zap.L().Debug("Could not exchange with the custom zone upstream",
+			zap.String("zone", zone.name), zap.String("upstream", upstream.getAddr()), zap.Error(err))
+		return nil, true, err
+	}
+
+	zone.cache.set(domain, typ, ret)
+
+	return ret, true, nil
+}
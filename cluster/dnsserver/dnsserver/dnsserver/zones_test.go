@@ -0,0 +1,197 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dnsserver
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/common/vutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomZones(t *testing.T) {
+	s := &DNSServer{}
+
+	s.setCustomZones(&corev1.ClusterConfig{
+		Spec: &corev1.ClusterConfig_Spec{
+			Dns: &corev1.ClusterConfig_Spec_DNS{
+				Zones: []*corev1.ClusterConfig_Spec_DNS_Zone{
+					{
+						Name:    "corp.internal",
+						Servers: []string{"udp://10.0.0.2:5353"},
+						Records: []*corev1.ClusterConfig_Spec_DNS_Zone_Record{
+							{
+								Name:   "db",
+								Type:   corev1.ClusterConfig_Spec_DNS_Zone_Record_A,
+								Values: []string{"10.0.0.10", "10.0.0.11"},
+							},
+							{
+								Name:   "db",
+								Type:   corev1.ClusterConfig_Spec_DNS_Zone_Record_AAAA,
+								Values: []string{"fd00::10"},
+								Ttl:    300,
+							},
+							{
+								Name:   "www",
+								Type:   corev1.ClusterConfig_Spec_DNS_Zone_Record_CNAME,
+								Values: []string{"db.corp.internal"},
+							},
+							{
+								Type:   corev1.ClusterConfig_Spec_DNS_Zone_Record_TXT,
+								Values: []string{"v=spf1 -all"},
+							},
+						},
+					},
+					{
+						Name: "corp.internal",
+						Records: []*corev1.ClusterConfig_Spec_DNS_Zone_Record{
+							{
+								Name:   "db",
+								Type:   corev1.ClusterConfig_Spec_DNS_Zone_Record_A,
+								Values: []string{"10.1.0.10"},
+							},
+						},
+						Groups: []string{"dev"},
+					},
+					{
+						Name: "aws.internal",
+						Records: []*corev1.ClusterConfig_Spec_DNS_Zone_Record{
+							{
+								Name:   "*",
+								Type:   corev1.ClusterConfig_Spec_DNS_Zone_Record_A,
+								Values: []string{"10.2.0.1"},
+							},
+						},
+					},
+					{
+						Name:    "svc.aws.internal",
+						Servers: []string{"10.2.0.2"},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Len(t, s.customZones, 4)
+	assert.Equal(t, "10.0.0.2", s.customZones[0].upstreams[0].host)
+	assert.Equal(t, 5353, s.customZones[0].upstreams[0].port)
+
+	assert.Nil(t, s.getCustomZone("example.com.", nil))
+	assert.Nil(t, s.getCustomZone("internal.", nil))
+
+	{
+		zone := s.getCustomZone("db.corp.internal.", nil)
+		assert.NotNil(t, zone)
+		assert.Empty(t, zone.groups)
+
+		res, ok := zone.getRecordsAnswer("db.corp.internal.", dns.TypeA)
+		assert.True(t, ok)
+		assert.Equal(t, dns.RcodeSuccess, res.Rcode)
+		assert.True(t, res.Authoritative)
+		assert.Len(t, res.Answer, 2)
+		assert.Equal(t, "10.0.0.10", res.Answer[0].(*dns.A).A.String())
+		assert.Equal(t, uint32(defaultRecordTTL), res.Answer[0].Header().Ttl)
+
+		res, ok = zone.getRecordsAnswer("db.corp.internal.", dns.TypeAAAA)
+		assert.True(t, ok)
+		assert.Len(t, res.Answer, 1)
+		assert.Equal(t, uint32(300), res.Answer[0].Header().Ttl)
+
+		res, ok = zone.getRecordsAnswer("db.corp.internal.", dns.TypeTXT)
+		assert.True(t, ok)
+		assert.Equal(t, dns.RcodeSuccess, res.Rcode)
+		assert.Empty(t, res.Answer)
+
+		res, ok = zone.getRecordsAnswer("corp.internal.", dns.TypeTXT)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"v=spf1 -all"}, res.Answer[0].(*dns.TXT).Txt)
+
+		res, ok = zone.getRecordsAnswer("www.corp.internal.", dns.TypeA)
+		assert.True(t, ok)
+		assert.Len(t, res.Answer, 3)
+		assert.Equal(t, "db.corp.internal.", res.Answer[0].(*dns.CNAME).Target)
+		assert.Equal(t, "db.corp.internal.", res.Answer[1].Header().Name)
+
+		res, ok = zone.getRecordsAnswer("www.corp.internal.", dns.TypeCNAME)
+		assert.True(t, ok)
+		assert.Len(t, res.Answer, 1)
+
+		_, ok = zone.getRecordsAnswer("dc1.corp.internal.", dns.TypeA)
+		assert.False(t, ok)
+	}
+
+	{
+		zone := s.getCustomZone("db.corp.internal.", []string{"ops", "dev"})
+		assert.NotNil(t, zone)
+		assert.Equal(t, []string{"dev"}, zone.groups)
+
+		res, ok := zone.getRecordsAnswer("db.corp.internal.", dns.TypeA)
+		assert.True(t, ok)
+		assert.Len(t, res.Answer, 1)
+		assert.Equal(t, "10.1.0.10", res.Answer[0].(*dns.A).A.String())
+
+		res, ok = zone.getRecordsAnswer("dc1.corp.internal.", dns.TypeA)
+		assert.True(t, ok)
+		assert.Equal(t, dns.RcodeNameError, res.Rcode)
+	}
+
+	{
+		zone := s.getCustomZone("ec2.aws.internal.", nil)
+		assert.NotNil(t, zone)
+
+		res, ok := zone.getRecordsAnswer("ec2.aws.internal.", dns.TypeA)
+		assert.True(t, ok)
+		assert.Equal(t, "ec2.aws.internal.", res.Answer[0].Header().Name)
+
+		res, ok = zone.getRecordsAnswer("aws.internal.", dns.TypeA)
+		assert.True(t, ok)
+		assert.Equal(t, dns.RcodeNameError, res.Rcode)
+	}
+
+	{
+		zone := s.getCustomZone("db.svc.aws.internal.", nil)
+		assert.NotNil(t, zone)
+		assert.Equal(t, "svc.aws.internal.", zone.name)
+		assert.Equal(t, 53, zone.upstreams[0].port)
+
+		_, ok := zone.getRecordsAnswer("db.svc.aws.internal.", dns.TypeA)
+		assert.False(t, ok)
+	}
+
+	s.setCustomZones(&corev1.ClusterConfig{
+		Spec: &corev1.ClusterConfig_Spec{},
+	})
+	assert.Nil(t, s.getCustomZone("db.corp.internal.", nil))
+}
+
+func TestGetManagedDNSGroups(t *testing.T) {
+	m := &dns.Msg{}
+	m.SetQuestion("example.com.", dns.TypeA)
+	assert.Nil(t, getManagedDNSGroups(m))
+
+	m.SetEdns0(dns.DefaultMsgSize, false)
+	assert.Nil(t, getManagedDNSGroups(m))
+
+	opt := m.IsEdns0()
+	opt.Option = append(opt.Option, &dns.EDNS0_LOCAL{
+		Code: vutils.ManagedDNSGroupsOptionCode,
+		Data: []byte("dev,ops"),
+	})
+	assert.Equal(t, []string{"dev", "ops"}, getManagedDNSGroups(m))
+}
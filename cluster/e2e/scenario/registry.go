@@ -128,6 +128,7 @@ func baseScenario() *Scenario {
 				"octelium-ingress",
 				"svc-default-octelium-api",
 				"svc-auth-octelium-api",
+				"svc-scim-octelium-api",
 				"svc-dns-octelium",
 				"svc-demo-nginx-default",
 				"svc-portal-default",
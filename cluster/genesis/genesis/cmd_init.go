@@ -211,6 +211,10 @@ func (g *Genesis) RunInit(ctx context.Context, o *InitOpts) error {
 		return err
 	}
 
+	if _, err := jwkutils.CreateIdentityAssertionSecret(ctx, octeliumC); err != nil {
+		return err
+	}
+
 	if err := g.installComponents(ctx,
 		region, &components.CommonOpts{
 			K8sC:          g.k8sC,
@@ -661,6 +665,60 @@ func (g *Genesis) installOcteliumResources(ctx context.Context, clusterCfg *core
 		}
 	}
 
+	{
+		svc := &corev1.Service{
+			Metadata: &metav1.Metadata{
+				Name:         "scim.octelium-api",
+				IsSystem:     true,
+				IsUserHidden: true,
+				DisplayName:  "SCIM 2.0 Provisioning API",
+			},
+			Spec: &corev1.Service_Spec{
+				Port:     8080,
+				IsPublic: true,
+				Mode:     corev1.Service_Spec_HTTP,
+
+				Authorization: &corev1.Service_Spec_Authorization{
+					InlinePolicies: []*corev1.InlinePolicy{
+						{
+							Spec: &corev1.Policy_Spec{
+								Rules: []*corev1.Policy_Spec_Rule{
+									{
+										Effect: corev1.Policy_Spec_Rule_ALLOW,
+										Condition: &corev1.Condition{
+											Type: &corev1.Condition_Match{
+												Match: `ctx.user.spec.type == "WORKLOAD"`,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: &corev1.Service_Status{
+				ManagedService: &corev1.Service_Status_ManagedService{
+					Type:               "apiserver",
+					Image:              oc.GetImage(oc.APIServer, ""),
+					Args:               []string{"scim"},
+					ReadOnlyFileSystem: true,
+					HealthCheck: &corev1.Service_Status_ManagedService_HealthCheck{
+						Type: &corev1.Service_Status_ManagedService_HealthCheck_Grpc{
+							Grpc: &corev1.Service_Status_ManagedService_HealthCheck_GRPC{
+								Port: vutils.HealthCheckPortManagedService,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		if err := genesisutils.CreateOrUpdateService(ctx, g.octeliumC, svc); err != nil {
+			return err
+		}
+	}
+
 	{
 		svc := &corev1.Service{
 			Metadata: &metav1.Metadata{
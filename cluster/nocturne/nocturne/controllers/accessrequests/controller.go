@@ -0,0 +1,106 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package arcontroller
+
+import (
+	"context"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/common/accessrequest"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"go.uber.org/zap"
+)
+
+type Controller struct {
+	octeliumC octeliumc.ClientInterface
+}
+
+func NewController(
+	octeliumC octeliumc.ClientInterface,
+) *Controller {
+	return &Controller{
+		octeliumC: octeliumC,
+	}
+}
+
+func (c *Controller) OnAdd(ctx context.Context, ar *corev1.AccessRequest) error {
+	return c.setPolicyTrigger(ctx, ar)
+}
+
+func (c *Controller) OnUpdate(ctx context.Context, new, old *corev1.AccessRequest) error {
+	return c.setPolicyTrigger(ctx, new)
+}
+
+func (c *Controller) OnDelete(ctx context.Context, ar *corev1.AccessRequest) error {
+	return c.deletePolicyTrigger(ctx, ar)
+}
+
+func (c *Controller) setPolicyTrigger(ctx context.Context, ar *corev1.AccessRequest) error {
+	switch {
+	case ar.Status.State == corev1.AccessRequest_Status_APPROVED && !accessrequest.IsExpired(ar):
+		if ar.Status.PolicyTriggerRef != nil {
+			return nil
+		}
+
+		pt, err := c.octeliumC.CoreC().CreatePolicyTrigger(ctx, accessrequest.NewPolicyTrigger(ar))
+		if err != nil {
+			if !grpcerr.AlreadyExists(err) {
+				return err
+			}
+
+			pt, err = c.octeliumC.CoreC().GetPolicyTrigger(ctx, &rmetav1.GetOptions{
+				Name: accessrequest.GetPolicyTriggerName(ar),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		zap.L().Debug("Granted AccessRequest access",
+			zap.String("accessRequest", ar.Metadata.Name), zap.String("policyTrigger", pt.Metadata.Name))
+
+		ar.Status.PolicyTriggerRef = umetav1.GetObjectReference(pt)
+		_, err = c.octeliumC.CoreC().UpdateAccessRequest(ctx, ar)
+		return err
+	case ar.Status.PolicyTriggerRef != nil:
+		if err := c.deletePolicyTrigger(ctx, ar); err != nil {
+			return err
+		}
+
+		zap.L().Debug("Revoked AccessRequest access", zap.String("accessRequest", ar.Metadata.Name))
+
+		ar.Status.PolicyTriggerRef = nil
+		_, err := c.octeliumC.CoreC().UpdateAccessRequest(ctx, ar)
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *Controller) deletePolicyTrigger(ctx context.Context, ar *corev1.AccessRequest) error {
+	_, err := c.octeliumC.CoreC().DeletePolicyTrigger(ctx, &rmetav1.DeleteOptions{
+		Name: accessrequest.GetPolicyTriggerName(ar),
+	})
+	if err != nil && !grpcerr.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/octelium/octelium/cluster/common/octeliumc"
 	"github.com/octelium/octelium/cluster/common/vutils"
 	"github.com/octelium/octelium/cluster/common/watchers"
+	arcontroller "github.com/octelium/octelium/cluster/nocturne/nocturne/controllers/accessrequests"
 	cccontroller "github.com/octelium/octelium/cluster/nocturne/nocturne/controllers/cluster_config"
 	devcontroller "github.com/octelium/octelium/cluster/nocturne/nocturne/controllers/devices"
 	k8ssecretcontroller "github.com/octelium/octelium/cluster/nocturne/nocturne/controllers/k8ssecrets"
@@ -77,6 +78,7 @@ func Run(ctx context.Context) error {
 	svcCtl := svccontroller.NewController(octeliumC, k8sC)
 	// sessCtl := sesscontroller.NewController(octeliumC)
 	devCtl := devcontroller.NewController(octeliumC)
+	arCtl := arcontroller.NewController(octeliumC)
 
 	{
 		watcher := watchers.NewCoreV1(octeliumC)
@@ -93,6 +95,10 @@ func Run(ctx context.Context) error {
 			return err
 		}
 
+		if err := watcher.AccessRequest(ctx, nil, arCtl.OnAdd, arCtl.OnUpdate, arCtl.OnDelete); err != nil {
+			return err
+		}
+
 		if err := watcher.ClusterConfig(ctx, nil, cccontroller.NewController(octeliumC, k8sC).OnUpdate); err != nil {
 			return err
 		}
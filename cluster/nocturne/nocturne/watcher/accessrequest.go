@@ -0,0 +1,83 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package watcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/common/accessrequest"
+	"go.uber.org/zap"
+)
+
+func (w *Watcher) runAccessRequests(ctx context.Context) {
+	zap.L().Debug("Starting AccessRequest watcher")
+
+	doRun := func() error {
+		var page uint32
+		for {
+			itmList, err := w.octeliumC.CoreC().ListAccessRequest(ctx, &rmetav1.ListOptions{
+				Paginate:     true,
+				ItemsPerPage: 500,
+				Page:         page,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, ar := range itmList.Items {
+				if err := w.doCheckAccessRequest(ctx, ar); err != nil {
+					zap.L().Warn("Could not doCheckAccessRequest", zap.Error(err), zap.Any("accessRequest", ar))
+				}
+			}
+
+			if itmList.ListResponseMeta == nil || !itmList.ListResponseMeta.HasMore {
+				return nil
+			}
+
+			page = page + 1
+		}
+	}
+
+	tickerCh := time.NewTicker(1 * time.Minute)
+	defer tickerCh.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tickerCh.C:
+			if err := doRun(); err != nil {
+				zap.L().Error("Could not run AccessRequest watcher doFn", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (w *Watcher) doCheckAccessRequest(ctx context.Context, ar *corev1.AccessRequest) error {
+	if !accessrequest.IsExpired(ar) {
+		return nil
+	}
+
+	zap.L().Debug("Expiring AccessRequest", zap.Any("accessRequest", ar))
+
+	ar.Status.State = corev1.AccessRequest_Status_EXPIRED
+	_, err := w.octeliumC.CoreC().UpdateAccessRequest(ctx, ar)
+	return err
+}
@@ -56,19 +56,24 @@ const durationMonth = 24 * 30 * time.Hour
 
 func (w *Watcher) doRunJWKSecret(ctx context.Context) error {
 
-	secrets, err := w.octeliumC.CoreC().ListSecret(ctx, &rmetav1.ListOptions{
-		SystemLabels: map[string]string{
-			"octelium-root-secret": "true",
-		},
-	})
-	if err != nil {
-		return err
-	}
+	for _, label := range []string{
+		jwkutils.LabelRootSecret,
+		jwkutils.LabelIdentityAssertionSecret,
+	} {
+		secrets, err := w.octeliumC.CoreC().ListSecret(ctx, &rmetav1.ListOptions{
+			SystemLabels: map[string]string{
+				label: "true",
+			},
+		})
+		if err != nil {
+			return err
+		}
 
-	for _, secret := range secrets.Items {
-		if err := w.doProcessJWKSecret(ctx, secret); err != nil {
-			zap.L().Warn("Could not doProcessJWKSecret",
-				zap.Any("secretMetadata", secret.Metadata), zap.Error(err))
+		for _, secret := range secrets.Items {
+			if err := w.doProcessJWKSecret(ctx, secret); err != nil {
+				zap.L().Warn("Could not doProcessJWKSecret",
+					zap.Any("secretMetadata", secret.Metadata), zap.Error(err))
+			}
 		}
 	}
 
@@ -84,8 +89,14 @@ func (w *Watcher) doProcessJWKSecret(ctx context.Context, secret *corev1.Secret)
 		zap.L().Debug("The root Secret needs rotation",
 			zap.Any("secretMetadata", secret.Metadata))
 
-		if _, err := jwkutils.CreateJWKSecret(ctx, w.octeliumC); err != nil {
-			return err
+		if secret.Metadata.SystemLabels[jwkutils.LabelIdentityAssertionSecret] == "true" {
+			if _, err := jwkutils.CreateIdentityAssertionSecret(ctx, w.octeliumC); err != nil {
+				return err
+			}
+		} else {
+			if _, err := jwkutils.CreateJWKSecret(ctx, w.octeliumC); err != nil {
+				return err
+			}
 		}
 
 		secret.Metadata.SystemLabels["key-rotated"] = "true"
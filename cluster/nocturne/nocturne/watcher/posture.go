@@ -0,0 +1,212 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/common/deviceposture"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	defaultPostureConnectorInterval = 5 * time.Minute
+	maxPostureConnectorRespSize     = 32 * 1024 * 1024
+)
+
+func getPostureConnectorInterval(conn *corev1.ClusterConfig_Spec_Device_Posture_Connector) time.Duration {
+	if ret := umetav1.ToDuration(conn.GetInterval()).ToGo(); ret > 0 {
+		return ret
+	}
+	return defaultPostureConnectorInterval
+}
+
+func (w *Watcher) runDevicePostureConnectors(ctx context.Context) {
+	zap.L().Debug("Starting Device posture Connectors watcher")
+
+	lastPolledAt := make(map[string]time.Time)
+
+	doRun := func() error {
+		cc, err := w.octeliumC.CoreV1Utils().GetClusterConfig(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, conn := range cc.Spec.GetDevice().GetPosture().GetConnectors() {
+			if conn.Name == "" || conn.Url == "" {
+				continue
+			}
+
+			if time.Since(lastPolledAt[conn.Name]) < getPostureConnectorInterval(conn) {
+				continue
+			}
+			lastPolledAt[conn.Name] = time.Now()
+
+			if err := w.doPollPostureConnector(ctx, conn); err != nil {
+				zap.L().Warn("Could not poll Device posture Connector",
+					zap.String("connector", conn.Name), zap.Error(err))
+			}
+		}
+
+		return nil
+	}
+
+	tickerCh := time.NewTicker(1 * time.Minute)
+	defer tickerCh.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tickerCh.C:
+			if err := doRun(); err != nil {
+				zap.L().Error("Could not run Device posture Connectors watcher doFn", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (w *Watcher) doPollPostureConnector(ctx context.Context,
+	conn *corev1.ClusterConfig_Spec_Device_Posture_Connector) error {
+	resp, err := w.getPostureConnectorResponse(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	reqs, err := parsePostureConnectorResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(3 * getPostureConnectorInterval(conn))
+
+	for _, req := range reqs {
+		if req.Posture != nil && req.Posture.ExpiresAt == nil {
+			req.Posture.ExpiresAt = pbutils.Timestamp(expiresAt)
+		}
+
+		if _, err := deviceposture.SetConnectorPosture(ctx, w.octeliumC, conn.Name, req); err != nil {
+			zap.L().Debug("Could not set Device posture from Connector",
+				zap.String("connector", conn.Name), zap.Any("req", req), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+func parsePostureConnectorResponse(body []byte) ([]*corev1.SetDevicePostureRequest, error) {
+	var resp struct {
+		Items []json.RawMessage `json:"items"`
+	}
+
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Errorf("Invalid Connector response: %+v", err)
+	}
+
+	var ret []*corev1.SetDevicePostureRequest
+	for _, itm := range resp.Items {
+		req := &corev1.SetDevicePostureRequest{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(itm, req); err != nil {
+			zap.L().Debug("Could not unmarshal Connector item", zap.Error(err))
+			continue
+		}
+		ret = append(ret, req)
+	}
+
+	return ret, nil
+}
+
+func (w *Watcher) getPostureConnectorResponse(ctx context.Context,
+	conn *corev1.ClusterConfig_Spec_Device_Posture_Connector) ([]byte, error) {
+	httpC := resty.New().SetRetryCount(3).
+		SetRetryWaitTime(500 * time.Millisecond).SetRetryMaxWaitTime(2 * time.Second).
+		AddRetryCondition(func(r *resty.Response, err error) bool {
+			if r.StatusCode() >= 500 && r.StatusCode() < 600 {
+				return true
+			}
+			return false
+		}).SetTimeout(30 * time.Second).SetLogger(zap.S()).
+		SetResponseBodyLimit(maxPostureConnectorRespSize)
+
+	req := httpC.R().SetContext(ctx).SetHeader("Accept", "application/json")
+
+	type secretOwner interface {
+		GetFromSecret() string
+	}
+
+	getSecret := func(secOwner secretOwner) (string, error) {
+		if secOwner == nil || secOwner.GetFromSecret() == "" {
+			return "", errors.Errorf("Empty Secret name")
+		}
+
+		sec, err := w.octeliumC.CoreC().GetSecret(ctx, &rmetav1.GetOptions{Name: secOwner.GetFromSecret()})
+		if err != nil {
+			return "", err
+		}
+
+		return ucorev1.ToSecret(sec).GetValueStr(), nil
+	}
+
+	if conn.Auth != nil {
+		switch conn.Auth.Type.(type) {
+		case *corev1.ClusterConfig_Spec_Device_Posture_Connector_Auth_Bearer_:
+			secret, err := getSecret(conn.Auth.GetBearer())
+			if err != nil {
+				return nil, err
+			}
+			req = req.SetAuthToken(secret)
+		case *corev1.ClusterConfig_Spec_Device_Posture_Connector_Auth_Basic_:
+			spec := conn.Auth.GetBasic()
+			secret, err := getSecret(spec.GetPassword())
+			if err != nil {
+				return nil, err
+			}
+			req = req.SetBasicAuth(spec.Username, secret)
+		case *corev1.ClusterConfig_Spec_Device_Posture_Connector_Auth_Custom_:
+			spec := conn.Auth.GetCustom()
+			secret, err := getSecret(spec.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			req = req.SetHeader(spec.Header, secret)
+		}
+	}
+
+	resp, err := req.Get(conn.Url)
+	if err != nil {
+		if errors.Is(err, resty.ErrResponseBodyTooLarge) {
+			return nil, errors.Errorf("Connector response is too large")
+		}
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, errors.Errorf("Connector endpoint returned status code: %d", resp.StatusCode())
+	}
+
+	return resp.Body(), nil
+}
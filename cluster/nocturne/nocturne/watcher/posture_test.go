@@ -0,0 +1,108 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package watcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePostureConnectorResponse(t *testing.T) {
+	{
+		reqs, err := parsePostureConnectorResponse([]byte(`{
+			"items": [
+				{
+					"serialNumber": "C02XK1ZZJGH5",
+					"posture": {
+						"diskEncryption": "PASS",
+						"riskLevel": "HIGH",
+						"signals": {"edrRunning": "FAIL"},
+						"vendorField": true
+					}
+				},
+				{
+					"hostname": "laptop-01",
+					"posture": "invalid"
+				},
+				{
+					"id": "abcdef",
+					"posture": {"threatFree": "NOT_APPLICABLE"}
+				}
+			]
+		}`))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(reqs))
+
+		assert.Equal(t, "C02XK1ZZJGH5", reqs[0].GetSerialNumber())
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, reqs[0].Posture.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_HIGH, reqs[0].Posture.RiskLevel)
+		assert.Equal(t, corev1.Device_Status_Posture_FAIL, reqs[0].Posture.Signals["edrRunning"])
+
+		assert.Equal(t, "abcdef", reqs[1].GetId())
+		assert.Equal(t, corev1.Device_Status_Posture_NOT_APPLICABLE, reqs[1].Posture.ThreatFree)
+	}
+
+	{
+		reqs, err := parsePostureConnectorResponse([]byte(`{}`))
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(reqs))
+	}
+
+	{
+		_, err := parsePostureConnectorResponse([]byte(`not-json`))
+		assert.NotNil(t, err)
+	}
+}
+
+func TestGetPostureConnectorResponse(t *testing.T) {
+	ctx := context.Background()
+	w := &Watcher{}
+
+	var respSize int
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"items": [], "padding": "`))
+		rw.Write([]byte(strings.Repeat("a", respSize)))
+		rw.Write([]byte(`"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	conn := &corev1.ClusterConfig_Spec_Device_Posture_Connector{
+		Name: "mdm",
+		Url:  srv.URL,
+	}
+
+	{
+		respSize = 1024
+		body, err := w.getPostureConnectorResponse(ctx, conn)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(string(body), `{"items": []`))
+	}
+
+	{
+		respSize = maxPostureConnectorRespSize
+		_, err := w.getPostureConnectorResponse(ctx, conn)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "too large")
+	}
+}
@@ -248,4 +248,6 @@ func (w *Watcher) Run(ctx context.Context) {
 	go w.runCredentials(ctx)
 	go w.runJWKSecret(ctx)
 	go w.runAuthenticator(ctx)
+	go w.runDevicePostureConnectors(ctx)
+	go w.runAccessRequests(ctx)
 }
@@ -21,6 +21,7 @@ import (
 	"errors"
 
 	"github.com/octelium/octelium/apis/cluster/coctovigilv1"
+	"github.com/octelium/octelium/apis/main/corev1"
 	"github.com/octelium/octelium/apis/rsc/rmetav1"
 	"github.com/octelium/octelium/cluster/common/grpcutils"
 	"github.com/octelium/octelium/cluster/octovigil/octovigil/acache"
@@ -119,7 +120,7 @@ func (s *internalService) Authorize(
 	}
 
 	isAuthorized, reason, err := s.s.isAuthorizedWithMetrics(ctx,
-		s.s.getReqCtx(di, req.Request, svc), req.Additional)
+		s.s.getReqCtx(ctx, di, req.Request, svc), req.Additional)
 	if err != nil {
 		return nil, err
 	}
@@ -147,7 +148,7 @@ func (s *internalService) Evaluate(
 		return nil, grpcutils.InternalWithErr(err)
 	}
 
-	resp, err := s.s.doGetDecision(ctx, reqCtxMap, allRules)
+	resp, err := s.s.doGetDecision(ctx, getSessionFromCtxMap(reqCtxMap), reqCtxMap, allRules)
 	if err != nil {
 		return nil, grpcutils.InternalWithErr(err)
 	}
@@ -156,3 +157,26 @@ func (s *internalService) Evaluate(
 		Effect: resp.effect,
 	}, nil
 }
+
+func (s *internalService) SimulatePolicy(
+	ctx context.Context, req *coctovigilv1.SimulatePolicyRequest) (
+	*corev1.SimulatePolicyResponse, error) {
+	i := req.RequestContext
+	if i == nil || i.Service == nil || i.Service.Status == nil || i.Service.Status.NamespaceRef == nil {
+		return nil, grpcutils.InvalidArg("Nil Service")
+	}
+
+	reqCtx := s.s.getReqCtx(ctx, &acache.DownstreamInfo{
+		User:    i.User,
+		Session: i.Session,
+		Device:  i.Device,
+		Groups:  i.Groups,
+	}, i.Request, i.Service)
+
+	ret, err := s.s.simulatePolicy(ctx, reqCtx)
+	if err != nil {
+		return nil, grpcutils.InternalWithErr(err)
+	}
+
+	return ret, nil
+}
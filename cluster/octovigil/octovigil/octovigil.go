@@ -33,6 +33,7 @@ import (
 	"github.com/octelium/octelium/cluster/common/ccctl"
 	"github.com/octelium/octelium/cluster/common/celengine"
 	"github.com/octelium/octelium/cluster/common/commoninit"
+	"github.com/octelium/octelium/cluster/common/deviceposture"
 	"github.com/octelium/octelium/cluster/common/healthcheck"
 	"github.com/octelium/octelium/cluster/common/jwkctl"
 	"github.com/octelium/octelium/cluster/common/octeliumc"
@@ -78,6 +79,7 @@ type Server struct {
 	ccCtl            *ccctl.Controller
 	policyTriggerCtl *policyTriggerCtl
 	commonMetrics    *commonMetrics
+	sessDeniedCtl    sessionDeniedCtl
 
 	coreSrv *admin.Server
 }
@@ -195,7 +197,8 @@ func (s *Server) Close() error {
 	return err
 }
 
-func (s *Server) getReqCtx(di *acache.DownstreamInfo, req *corev1.RequestContext_Request, svc *corev1.Service) *corev1.RequestContext {
+func (s *Server) getReqCtx(ctx context.Context,
+	di *acache.DownstreamInfo, req *corev1.RequestContext_Request, svc *corev1.Service) *corev1.RequestContext {
 
 	var reqCtx *corev1.RequestContext
 
@@ -207,7 +210,7 @@ func (s *Server) getReqCtx(di *acache.DownstreamInfo, req *corev1.RequestContext
 	} else {
 		reqCtx = &corev1.RequestContext{
 			User:    di.User,
-			Device:  di.Device,
+			Device:  s.getDevice(ctx, di),
 			Service: svc,
 			Session: di.Session,
 			Groups:  di.Groups,
@@ -220,6 +223,47 @@ func (s *Server) getReqCtx(di *acache.DownstreamInfo, req *corev1.RequestContext
 	return reqCtx
 }
 
+// getDevice returns the downstream's Device with a valid Posture whose
+// riskLevel is computed by the ClusterConfig risk rules over the current
+// unexpired sources of the Posture
+func (s *Server) getDevice(ctx context.Context, di *acache.DownstreamInfo) *corev1.Device {
+	dev := getDeviceWithValidPosture(di.Device)
+	cfg := s.ccCtl.Get().GetSpec().GetDevice().GetPosture()
+	if dev.GetStatus().GetPosture() == nil || len(cfg.GetRisks()) == 0 {
+		return dev
+	}
+
+	if dev == di.Device {
+		dev = pbutils.Clone(dev).(*corev1.Device)
+	}
+
+	posture := deviceposture.GetEffectivePosture(dev.Status.Posture, time.Now())
+	dev.Status.Posture = deviceposture.SetRiskLevel(ctx, s.celEngine, cfg, posture, map[string]any{
+		"user":    pbutils.MustConvertToMap(di.User),
+		"session": pbutils.MustConvertToMap(di.Session),
+		"device":  pbutils.MustConvertToMap(di.Device),
+		"probes":  deviceposture.GetProbesMap(dev.Status.ProbeAttempt),
+	})
+
+	return dev
+}
+
+// getDeviceWithValidPosture returns the Device with its Posture recomputed
+// over its unexpired sources if the Posture or any of its sources has expired
+// so that stale signals are never treated as passing
+func getDeviceWithValidPosture(dev *corev1.Device) *corev1.Device {
+	now := time.Now()
+	posture := dev.GetStatus().GetPosture()
+	if posture == nil || !deviceposture.HasExpired(posture, now) {
+		return dev
+	}
+
+	ret := pbutils.Clone(dev).(*corev1.Device)
+	ret.Status.Posture = deviceposture.GetEffectivePosture(ret.Status.Posture, now)
+
+	return ret
+}
+
 func (s *Server) getConnectionIdentifier(r *coctovigilv1.DoAuthenticateAndAuthorizeRequest) (string, *jwkctl.AccessTokenClaims, error) {
 	if r == nil {
 		return "", nil, errors.Errorf("Nil req")
@@ -399,7 +443,7 @@ func (s *Server) AuthenticateAndAuthorize(ctx context.Context, req *coctovigilv1
 	ret := &coctovigilv1.AuthenticateAndAuthorizeResponse{}
 
 	if s.isAnonymousAuthorizationEnabled(req.Service) {
-		ret.RequestContext = s.getReqCtx(nil, req.Request.Request, req.Service)
+		ret.RequestContext = s.getReqCtx(ctx, nil, req.Request.Request, req.Service)
 	} else {
 		downstreamInfo, err := s.authenticate(ctx, req)
 		if err != nil {
@@ -411,7 +455,7 @@ func (s *Server) AuthenticateAndAuthorize(ctx context.Context, req *coctovigilv1
 		}
 
 		ret.IsAuthenticated = true
-		ret.RequestContext = s.getReqCtx(downstreamInfo, req.Request.Request, req.Service)
+		ret.RequestContext = s.getReqCtx(ctx, downstreamInfo, req.Request.Request, req.Service)
 	}
 
 	isAuthorized, reason, err := s.isAuthorizedWithMetrics(ctx, ret.RequestContext, req.Additional)
@@ -446,6 +490,9 @@ func (s *Server) isAuthorizedWithMetrics(ctx context.Context,
 
 	if !isAuthorized {
 		attrs = append(attrs, attribute.String("reason", reason.GetType().String()))
+		if err == nil {
+			s.onSessionDenied(req, reason)
+		}
 	}
 
 	s.commonMetrics.atAuthorizationRequestEnd(startedAt, metric.WithAttributeSet(attribute.NewSet(attrs...)))
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/octelium/octelium/apis/cluster/coctovigilv1"
 	"github.com/octelium/octelium/apis/main/corev1"
@@ -27,12 +28,14 @@ import (
 	"github.com/octelium/octelium/apis/rsc/rmetav1"
 	"github.com/octelium/octelium/cluster/apiserver/apiserver/admin"
 	"github.com/octelium/octelium/cluster/apiserver/apiserver/user"
+	"github.com/octelium/octelium/cluster/common/deviceposture"
 	"github.com/octelium/octelium/cluster/common/jwkctl"
 	"github.com/octelium/octelium/cluster/common/tests"
 	"github.com/octelium/octelium/cluster/common/tests/tstuser"
 	"github.com/octelium/octelium/pkg/apiutils/umetav1"
 	"github.com/octelium/octelium/pkg/utils/utilrand"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestIsAuthorized(t *testing.T) {
@@ -2137,3 +2140,74 @@ func TestIsAuthorizedAnonymous(t *testing.T) {
 	}
 
 }
+
+func TestGetDeviceWithValidPosture(t *testing.T) {
+	assert.Nil(t, getDeviceWithValidPosture(nil))
+
+	{
+		dev := &corev1.Device{
+			Status: &corev1.Device_Status{},
+		}
+		assert.Equal(t, dev, getDeviceWithValidPosture(dev))
+	}
+
+	{
+		dev := &corev1.Device{
+			Status: &corev1.Device_Status{
+				Posture: &corev1.Device_Status_Posture{
+					DiskEncryption: corev1.Device_Status_Posture_PASS,
+					ExpiresAt:      timestamppb.New(time.Now().Add(time.Hour)),
+				},
+			},
+		}
+		assert.Equal(t, dev, getDeviceWithValidPosture(dev))
+	}
+
+	{
+		dev := &corev1.Device{
+			Status: &corev1.Device_Status{
+				Posture: &corev1.Device_Status_Posture{
+					DiskEncryption: corev1.Device_Status_Posture_PASS,
+					RiskLevel:      corev1.Device_Status_Posture_LOW,
+					Signals: map[string]corev1.Device_Status_Posture_SignalState{
+						"firewall": corev1.Device_Status_Posture_PASS,
+					},
+					ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute)),
+				},
+			},
+		}
+		ret := getDeviceWithValidPosture(dev)
+		assert.Equal(t, corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN, ret.Status.Posture.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_RISK_LEVEL_UNKNOWN, ret.Status.Posture.RiskLevel)
+		assert.Equal(t, corev1.Device_Status_Posture_SIGNAL_STATE_UNKNOWN, ret.Status.Posture.Signals["firewall"])
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, dev.Status.Posture.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, dev.Status.Posture.Signals["firewall"])
+	}
+
+	{
+		posture, err := deviceposture.SetSource(nil, deviceposture.SourceProbe, &corev1.Device_Status_Posture{
+			DiskEncryption: corev1.Device_Status_Posture_PASS,
+			ExpiresAt:      timestamppb.New(time.Now().Add(time.Hour)),
+		}, time.Now())
+		assert.Nil(t, err)
+		posture, err = deviceposture.SetSource(posture, deviceposture.SourceAPI, &corev1.Device_Status_Posture{
+			DiskEncryption: corev1.Device_Status_Posture_FAIL,
+			RiskLevel:      corev1.Device_Status_Posture_HIGH,
+			ExpiresAt:      timestamppb.New(time.Now().Add(time.Hour)),
+		}, time.Now())
+		assert.Nil(t, err)
+		assert.Equal(t, corev1.Device_Status_Posture_FAIL, posture.DiskEncryption)
+
+		posture.Sources[0].Posture.ExpiresAt = timestamppb.New(time.Now().Add(-time.Minute))
+		dev := &corev1.Device{
+			Status: &corev1.Device_Status{
+				Posture: posture,
+			},
+		}
+
+		ret := getDeviceWithValidPosture(dev)
+		assert.Equal(t, corev1.Device_Status_Posture_PASS, ret.Status.Posture.DiskEncryption)
+		assert.Equal(t, corev1.Device_Status_Posture_RISK_LEVEL_UNKNOWN, ret.Status.Posture.RiskLevel)
+		assert.Equal(t, corev1.Device_Status_Posture_FAIL, dev.Status.Posture.DiskEncryption)
+	}
+}
@@ -49,6 +49,7 @@ type getDecisionResp struct {
 
 type policyRule struct {
 	rule      *corev1.Policy_Spec_Rule
+	index     int
 	policyRef *metav1.ObjectReference
 	inline    *policyRuleInline
 	attrs     map[string]any
@@ -70,33 +71,19 @@ func (s *Server) getDecision(ctx context.Context, req *getDecisionReq) (*getDeci
 		return nil, err
 	}
 
-	return s.doGetDecision(ctx, reqCtxMap, allRules)
+	return s.doGetDecision(ctx, req.i.GetSession(), reqCtxMap, allRules)
 }
 
-func (s *Server) doGetDecision(ctx context.Context,
+func (s *Server) doGetDecision(ctx context.Context, sess *corev1.Session,
 	reqCtxMap map[string]any, allRules []*policyRule) (*getDecisionResp, error) {
 
-	slices.SortFunc(allRules, func(a, b *policyRule) int {
-		if diff := a.rule.Priority - b.rule.Priority; diff < 0 {
-			return -1
-		} else if diff > 0 {
-			return 1
-		}
-
-		switch {
-		case a.rule.Effect == corev1.Policy_Spec_Rule_DENY && b.rule.Effect == corev1.Policy_Spec_Rule_ALLOW:
-			return -1
-		case a.rule.Effect == corev1.Policy_Spec_Rule_ALLOW && b.rule.Effect == corev1.Policy_Spec_Rule_DENY:
-			return 1
-		}
-
-		return 0
-	})
+	sortPolicyRules(allRules)
 
 	for _, rule := range allRules {
 		res, err := s.getDecisionRule(ctx, &getDecisionRuleReq{
 			rule:      rule,
 			reqCtxMap: reqCtxMap,
+			session:   sess,
 		})
 		if err != nil {
 			return nil, err
@@ -117,6 +104,25 @@ func (s *Server) doGetDecision(ctx context.Context,
 	}, nil
 }
 
+func sortPolicyRules(allRules []*policyRule) {
+	slices.SortFunc(allRules, func(a, b *policyRule) int {
+		if diff := a.rule.Priority - b.rule.Priority; diff < 0 {
+			return -1
+		} else if diff > 0 {
+			return 1
+		}
+
+		switch {
+		case a.rule.Effect == corev1.Policy_Spec_Rule_DENY && b.rule.Effect == corev1.Policy_Spec_Rule_ALLOW:
+			return -1
+		case a.rule.Effect == corev1.Policy_Spec_Rule_ALLOW && b.rule.Effect == corev1.Policy_Spec_Rule_DENY:
+			return 1
+		}
+
+		return 0
+	})
+}
+
 func (s *Server) getPolicyFromName(ctx context.Context, name string) (*corev1.Policy, error) {
 	policy, err := s.cache.GetPolicy(name)
 	if err == nil {
@@ -201,6 +207,7 @@ func (s *Server) getEvaluatePolicyRules(ctx context.Context, req *coctovigilv1.E
 				spec:      policy.Spec,
 				reqCtxMap: reqCtxMap,
 				attrs:     attrs,
+				policyRef: umetav1.GetObjectReference(policy),
 			}) {
 				continue
 			}
@@ -210,9 +217,10 @@ func (s *Server) getEvaluatePolicyRules(ctx context.Context, req *coctovigilv1.E
 			usedPolicies = append(usedPolicies, policy.Metadata.Name)
 
 			policyRef := umetav1.GetObjectReference(policy)
-			for _, rule := range policy.Spec.Rules {
+			for idx, rule := range policy.Spec.Rules {
 				ret = append(ret, &policyRule{
 					rule:      rule,
+					index:     idx,
 					policyRef: policyRef,
 					attrs:     attrs,
 				})
@@ -233,13 +241,18 @@ func (s *Server) getEvaluatePolicyRules(ctx context.Context, req *coctovigilv1.E
 			spec:      p.Policy.Spec,
 			reqCtxMap: reqCtxMap,
 			attrs:     attrs,
+			inline: &policyRuleInline{
+				name:        p.Policy.Name,
+				resourceRef: p.ResourceRef,
+			},
 		}) {
 			continue
 		}
 
-		for _, rule := range p.Policy.Spec.Rules {
+		for idx, rule := range p.Policy.Spec.Rules {
 			ret = append(ret, &policyRule{
-				rule: rule,
+				rule:  rule,
+				index: idx,
 				inline: &policyRuleInline{
 					name:        p.Policy.Name,
 					resourceRef: p.ResourceRef,
@@ -288,6 +301,7 @@ func (s *Server) getResourcePolicyRules(ctx context.Context,
 				spec:      policy.Spec,
 				reqCtxMap: reqCtxMap,
 				attrs:     attrs,
+				policyRef: umetav1.GetObjectReference(policy),
 			}) {
 				continue
 			}
@@ -297,9 +311,10 @@ func (s *Server) getResourcePolicyRules(ctx context.Context,
 			*usedPolicies = append(*usedPolicies, policy.Metadata.Name)
 
 			policyRef := umetav1.GetObjectReference(policy)
-			for _, rule := range policy.Spec.Rules {
+			for idx, rule := range policy.Spec.Rules {
 				ret = append(ret, &policyRule{
 					rule:      rule,
+					index:     idx,
 					policyRef: policyRef,
 					attrs:     attrs,
 				})
@@ -320,13 +335,18 @@ func (s *Server) getResourcePolicyRules(ctx context.Context,
 			spec:      p.Spec,
 			reqCtxMap: reqCtxMap,
 			attrs:     attrs,
+			inline: &policyRuleInline{
+				name:        p.Name,
+				resourceRef: resourceRef,
+			},
 		}) {
 			continue
 		}
 
-		for _, rule := range p.Spec.Rules {
+		for idx, rule := range p.Spec.Rules {
 			ret = append(ret, &policyRule{
-				rule: rule,
+				rule:  rule,
+				index: idx,
 				inline: &policyRuleInline{
 					name:        p.Name,
 					resourceRef: resourceRef,
@@ -448,6 +468,7 @@ func (s *Server) getAllRules(ctx context.Context, req *getDecisionReq, reqCtxMap
 type getDecisionRuleReq struct {
 	rule      *policyRule
 	reqCtxMap map[string]any
+	session   *corev1.Session
 }
 
 func (s *Server) getDecisionRule(ctx context.Context, req *getDecisionRuleReq) (*policyDecisionResult, error) {
@@ -480,6 +501,12 @@ func (s *Server) getDecisionRule(ctx context.Context, req *getDecisionRuleReq) (
 		resp.decision = matchDecisionMATCH_YES
 		resp.effect = rule.Effect
 		resp.reason = s.getMatchedDecisionReason(req.rule)
+
+		if rule.Effect == corev1.Policy_Spec_Rule_ALLOW && rule.StepUp != nil &&
+			!isStepUpSatisfied(req.session, rule.StepUp) {
+			resp.effect = corev1.Policy_Spec_Rule_DENY
+			resp.reason.Type = corev1.AccessLog_Entry_Common_Reason_STEP_UP_REQUIRED
+		}
 	}
 
 	return resp, nil
@@ -533,6 +560,8 @@ type shouldEnforcePolicyReq struct {
 	spec      *corev1.Policy_Spec
 	reqCtxMap map[string]any
 	attrs     map[string]any
+	policyRef *metav1.ObjectReference
+	inline    *policyRuleInline
 }
 
 func (s *Server) shouldEnforcePolicy(ctx context.Context, req *shouldEnforcePolicyReq) bool {
@@ -558,6 +587,7 @@ func (s *Server) shouldEnforcePolicy(ctx context.Context, req *shouldEnforcePoli
 			reqCtxMap: req.reqCtxMap,
 			attrs:     req.attrs,
 		})
+		getPolicyTrace(ctx).addEnforcementRule(req, rule, res, err)
 		if err != nil {
 			zap.L().Error("Could not getDecisionEnforcementRule",
 				zap.Any("rule", rule), zap.Error(err))
@@ -574,6 +604,7 @@ func (s *Server) shouldEnforcePolicy(ctx context.Context, req *shouldEnforcePoli
 			reqCtxMap: req.reqCtxMap,
 			attrs:     req.attrs,
 		})
+		getPolicyTrace(ctx).addEnforcementRule(req, rule, res, err)
 		if err != nil {
 			continue
 		}
@@ -0,0 +1,191 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package octovigil
+
+import (
+	"context"
+
+	"github.com/octelium/octelium/apis/cluster/coctovigilv1"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/rsc/rcachev1"
+	"github.com/octelium/octelium/apis/rsc/rcorev1"
+	"github.com/octelium/octelium/apis/rsc/rlockv1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/apis/rsc/rratelimitv1"
+	"github.com/octelium/octelium/cluster/common/ccctl"
+	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/cluster/common/grpcutils"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/cluster/octovigil/octovigil/acache"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// policyTestOcteliumC serves the Policies and the Namespaces of a policy test
+// from its fixtures so that a policy test never reads any of the Cluster's
+// resources. Any other call fails with codes.Unimplemented.
+type policyTestOcteliumC struct {
+	coreC *policyTestCoreC
+}
+
+func (c *policyTestOcteliumC) CoreC() rcorev1.ResourceServiceClient {
+	return c.coreC
+}
+
+func (c *policyTestOcteliumC) CoreV1Utils() octeliumc.CoreV1Utils {
+	return c
+}
+
+func (c *policyTestOcteliumC) CacheC() rcachev1.MainServiceClient {
+	return rcachev1.NewMainServiceClient(policyTestConn{})
+}
+
+func (c *policyTestOcteliumC) RateLimitC() rratelimitv1.MainServiceClient {
+	return rratelimitv1.NewMainServiceClient(policyTestConn{})
+}
+
+func (c *policyTestOcteliumC) LockC() rlockv1.MainServiceClient {
+	return rlockv1.NewMainServiceClient(policyTestConn{})
+}
+
+func (c *policyTestOcteliumC) GetClusterConfig(ctx context.Context) (*corev1.ClusterConfig, error) {
+	return &corev1.ClusterConfig{
+		Spec:   &corev1.ClusterConfig_Spec{},
+		Status: &corev1.ClusterConfig_Status{},
+	}, nil
+}
+
+// policyTestConn backs the clients of a policy test that are not served from
+// the fixtures and fails every call with codes.Unimplemented.
+type policyTestConn struct{}
+
+func (policyTestConn) Invoke(ctx context.Context,
+	method string, args any, reply any, opts ...grpc.CallOption) error {
+	return status.Errorf(codes.Unimplemented, "%s is not available in a policy test", method)
+}
+
+func (policyTestConn) NewStream(ctx context.Context,
+	desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "%s is not available in a policy test", method)
+}
+
+type policyTestCoreC struct {
+	rcorev1.ResourceServiceClient
+	policies   map[string]*corev1.Policy
+	namespaces map[string]*corev1.Namespace
+}
+
+func newPolicyTestCoreC() *policyTestCoreC {
+	return &policyTestCoreC{
+		ResourceServiceClient: rcorev1.NewResourceServiceClient(policyTestConn{}),
+		policies:              make(map[string]*corev1.Policy),
+		namespaces:            make(map[string]*corev1.Namespace),
+	}
+}
+
+func (c *policyTestCoreC) GetPolicy(ctx context.Context,
+	req *rmetav1.GetOptions, opts ...grpc.CallOption) (*corev1.Policy, error) {
+	if ret, ok := c.policies[req.Name]; ok {
+		return ret, nil
+	}
+	return nil, grpcutils.NotFound("The Policy %s is not in the fixtures", req.Name)
+}
+
+func (c *policyTestCoreC) GetNamespace(ctx context.Context,
+	req *rmetav1.GetOptions, opts ...grpc.CallOption) (*corev1.Namespace, error) {
+	if ret, ok := c.namespaces[req.Name]; ok {
+		return ret, nil
+	}
+	return nil, grpcutils.NotFound("The Namespace %s is not in the fixtures", req.Name)
+}
+
+// newPolicyTestServer returns a Server whose only Policies and Namespaces are
+// the fixtures of the policy test and which has no PolicyTriggers.
+func newPolicyTestServer(ctx context.Context, req *coctovigilv1.TestPolicyRequest) (*Server, error) {
+	coreC := newPolicyTestCoreC()
+	for _, policy := range req.Policies {
+		coreC.policies[policy.Metadata.Name] = policy
+	}
+	for _, ns := range req.Namespaces {
+		coreC.namespaces[ns.Metadata.Name] = ns
+	}
+
+	octeliumC := &policyTestOcteliumC{
+		coreC: coreC,
+	}
+
+	aCache, err := acache.NewCache()
+	if err != nil {
+		return nil, err
+	}
+
+	celEngine, err := celengine.New(ctx, &celengine.Opts{})
+	if err != nil {
+		return nil, err
+	}
+
+	ccCtl, err := ccctl.New(ctx, octeliumC, &ccctl.Opts{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		octeliumC: octeliumC,
+		cache:     aCache,
+		celEngine: celEngine,
+		ccCtl:     ccCtl,
+		policyTriggerCtl: &policyTriggerCtl{
+			ptMap: make(map[string]*corev1.PolicyTrigger),
+		},
+	}, nil
+}
+
+func (s *internalService) TestPolicy(
+	ctx context.Context, req *coctovigilv1.TestPolicyRequest) (
+	*coctovigilv1.TestPolicyResponse, error) {
+	for _, i := range req.RequestContexts {
+		if i == nil || i.Service == nil || i.Service.Status == nil || i.Service.Status.NamespaceRef == nil {
+			return nil, grpcutils.InvalidArg("Nil Service")
+		}
+	}
+
+	srv, err := newPolicyTestServer(ctx, req)
+	if err != nil {
+		return nil, grpcutils.InternalWithErr(err)
+	}
+
+	ret := &coctovigilv1.TestPolicyResponse{}
+
+	for _, i := range req.RequestContexts {
+		reqCtx := srv.getReqCtx(ctx, &acache.DownstreamInfo{
+			User:    i.User,
+			Session: i.Session,
+			Device:  i.Device,
+			Groups:  i.Groups,
+		}, i.Request, i.Service)
+
+		res, err := srv.simulatePolicy(ctx, reqCtx)
+		if err != nil {
+			return nil, grpcutils.InternalWithErr(err)
+		}
+
+		ret.Results = append(ret.Results, res)
+	}
+
+	return ret, nil
+}
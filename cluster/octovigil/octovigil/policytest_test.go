@@ -0,0 +1,312 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package octovigil
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/octelium/octelium/apis/cluster/coctovigilv1"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rcachev1"
+	"github.com/octelium/octelium/apis/rsc/rlockv1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/apis/rsc/rratelimitv1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTestPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	usr := &corev1.User{
+		Kind:     "User",
+		Metadata: &metav1.Metadata{Name: "alice"},
+		Spec: &corev1.User_Spec{
+			Type:   corev1.User_Spec_HUMAN,
+			Groups: []string{"ops"},
+		},
+		Status: &corev1.User_Status{},
+	}
+
+	grp := &corev1.Group{
+		Kind:     "Group",
+		Metadata: &metav1.Metadata{Name: "ops"},
+		Spec:     &corev1.Group_Spec{},
+		Status:   &corev1.Group_Status{},
+	}
+
+	svc := &corev1.Service{
+		Kind:     "Service",
+		Metadata: &metav1.Metadata{Name: "svc1.default"},
+		Spec: &corev1.Service_Spec{
+			Authorization: &corev1.Service_Spec_Authorization{
+				Policies: []string{"pol1", "does-not-exist"},
+			},
+		},
+		Status: &corev1.Service_Status{
+			NamespaceRef: &metav1.ObjectReference{Name: "default"},
+		},
+	}
+
+	ns := &corev1.Namespace{
+		Kind:     "Namespace",
+		Metadata: &metav1.Metadata{Name: "default"},
+		Spec: &corev1.Namespace_Spec{
+			Authorization: &corev1.Namespace_Spec_Authorization{
+				InlinePolicies: []*corev1.InlinePolicy{
+					{
+						Name: "deny-admin",
+						Spec: &corev1.Policy_Spec{
+							Rules: []*corev1.Policy_Spec_Rule{
+								{
+									Effect: corev1.Policy_Spec_Rule_DENY,
+									Condition: &corev1.Condition{
+										Type: &corev1.Condition_Match{
+											Match: `ctx.request.http.path.startsWith("/admin")`,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: &corev1.Namespace_Status{},
+	}
+
+	pol := &corev1.Policy{
+		Kind:     "Policy",
+		Metadata: &metav1.Metadata{Name: "pol1"},
+		Spec: &corev1.Policy_Spec{
+			Rules: []*corev1.Policy_Spec_Rule{
+				{
+					Name:   "allow-ops",
+					Effect: corev1.Policy_Spec_Rule_ALLOW,
+					Condition: &corev1.Condition{
+						Type: &corev1.Condition_Match{
+							Match: `"ops" in ctx.user.spec.groups`,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	getReqCtx := func(path string) *corev1.RequestContext {
+		return &corev1.RequestContext{
+			User:    usr,
+			Groups:  []*corev1.Group{grp},
+			Service: svc,
+			Session: &corev1.Session{
+				Kind:     "Session",
+				Metadata: &metav1.Metadata{Name: "simulated"},
+				Spec: &corev1.Session_Spec{
+					State: corev1.Session_Spec_ACTIVE,
+				},
+				Status: &corev1.Session_Status{
+					Type:           corev1.Session_Status_CLIENT,
+					Authentication: &corev1.Session_Status_Authentication{},
+				},
+			},
+			Request: &corev1.RequestContext_Request{
+				Type: &corev1.RequestContext_Request_Http{
+					Http: &corev1.RequestContext_Request_HTTP{
+						Path: path,
+					},
+				},
+			},
+		}
+	}
+
+	srv := &internalService{}
+
+	res, err := srv.TestPolicy(ctx, &coctovigilv1.TestPolicyRequest{
+		Policies:        []*corev1.Policy{pol},
+		Namespaces:      []*corev1.Namespace{ns},
+		RequestContexts: []*corev1.RequestContext{getReqCtx("/v1"), getReqCtx("/admin/x")},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.Results))
+
+	{
+		r := res.Results[0]
+		assert.True(t, r.IsAuthorized)
+		assert.Equal(t, corev1.AccessLog_Entry_Common_Reason_POLICY_MATCH, r.Reason.Type)
+		assert.Equal(t, 2, len(r.Rules))
+		for _, rule := range r.Rules {
+			if rule.Name == "allow-ops" {
+				assert.True(t, rule.IsDecisive)
+				assert.Equal(t, "pol1", rule.Source.GetPolicyRef().Name)
+				assert.Equal(t, int32(0), rule.Index)
+			} else {
+				assert.Equal(t, corev1.SimulatePolicyResponse_NOT_MATCHED, rule.Result)
+				assert.Equal(t, "deny-admin", rule.Source.GetInlinePolicy().Name)
+			}
+		}
+	}
+
+	{
+		r := res.Results[1]
+		assert.False(t, r.IsAuthorized)
+		assert.Equal(t, corev1.AccessLog_Entry_Common_Reason_POLICY_MATCH, r.Reason.Type)
+	}
+
+	_, err = srv.TestPolicy(ctx, &coctovigilv1.TestPolicyRequest{
+		RequestContexts: []*corev1.RequestContext{{}},
+	})
+	assert.NotNil(t, err)
+}
+
+func TestPolicyTestOcteliumC(t *testing.T) {
+	ctx := context.Background()
+
+	c := &policyTestOcteliumC{
+		coreC: newPolicyTestCoreC(),
+	}
+
+	_, err := c.CoreC().GetUser(ctx, &rmetav1.GetOptions{Name: "alice"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = c.CoreC().GetPolicy(ctx, &rmetav1.GetOptions{Name: "pol1"})
+	assert.True(t, grpcerr.IsNotFound(err))
+
+	_, err = c.CacheC().GetCache(ctx, &rcachev1.GetCacheRequest{Key: []byte("key")})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = c.LockC().Lock(ctx, &rlockv1.LockRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = c.RateLimitC().CheckSlidingWindow(ctx, &rratelimitv1.CheckSlidingWindowRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestRunPolicyTest(t *testing.T) {
+	ctx := context.Background()
+
+	req := &corev1.TestPolicyRequest{
+		Fixtures: &corev1.TestPolicyRequest_Fixtures{
+			Policies: []*corev1.Policy{
+				{
+					Metadata: &metav1.Metadata{Name: "allow-ops"},
+					Spec: &corev1.Policy_Spec{
+						Rules: []*corev1.Policy_Spec_Rule{
+							{
+								Name:   "ops",
+								Effect: corev1.Policy_Spec_Rule_ALLOW,
+								Condition: &corev1.Condition{
+									Type: &corev1.Condition_Match{
+										Match: `"ops" in ctx.user.spec.groups`,
+									},
+								},
+							},
+							{
+								Name:   "never",
+								Effect: corev1.Policy_Spec_Rule_DENY,
+								Condition: &corev1.Condition{
+									Type: &corev1.Condition_Match{
+										Match: `false`,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Users: []*corev1.User{
+				{
+					Metadata: &metav1.Metadata{Name: "alice"},
+					Spec: &corev1.User_Spec{
+						Type:   corev1.User_Spec_HUMAN,
+						Groups: []string{"ops"},
+					},
+				},
+				{
+					Metadata: &metav1.Metadata{Name: "bob"},
+					Spec: &corev1.User_Spec{
+						Type: corev1.User_Spec_HUMAN,
+					},
+				},
+			},
+			Services: []*corev1.Service{
+				{
+					Metadata: &metav1.Metadata{Name: "db.prod"},
+					Spec: &corev1.Service_Spec{
+						Authorization: &corev1.Service_Spec_Authorization{
+							Policies: []string{"allow-ops"},
+						},
+					},
+				},
+			},
+		},
+		Cases: []*corev1.TestPolicyRequest_Case{
+			{
+				Name:    "ops",
+				User:    "alice",
+				Service: "db.prod",
+				Expect: &corev1.TestPolicyRequest_Case_Expect{
+					Decision: corev1.TestPolicyRequest_Case_Expect_ALLOW,
+					RuleName: "ops",
+				},
+			},
+			{
+				Name:    "no-ops",
+				User:    "bob",
+				Service: "db.prod",
+				Expect: &corev1.TestPolicyRequest_Case_Expect{
+					Decision: corev1.TestPolicyRequest_Case_Expect_ALLOW,
+				},
+			},
+		},
+	}
+
+	reqBytes, err := pbutils.MarshalJSON(req, false)
+	assert.Nil(t, err)
+
+	out := &bytes.Buffer{}
+	err = RunPolicyTest(ctx, bytes.NewReader(reqBytes), out)
+	assert.Nil(t, err)
+
+	resp := &corev1.TestPolicyResponse{}
+	assert.Nil(t, pbutils.UnmarshalJSON(out.Bytes(), resp))
+
+	assert.Equal(t, 2, len(resp.Results))
+	assert.True(t, resp.Results[0].IsPassed)
+	assert.True(t, resp.Results[0].IsAuthorized)
+	assert.False(t, resp.Results[1].IsPassed)
+	assert.False(t, resp.Results[1].IsAuthorized)
+
+	assert.Equal(t, 2, len(resp.Rules))
+	assert.Equal(t, int32(1), resp.Rules[0].MatchCount)
+	assert.Equal(t, int32(0), resp.Rules[1].MatchCount)
+
+	{
+		err := RunPolicyTest(ctx, bytes.NewReader([]byte(`{"cases":[]}`)), &bytes.Buffer{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	{
+		err := RunPolicyTest(ctx, bytes.NewReader([]byte(`{`)), &bytes.Buffer{})
+		assert.NotNil(t, err)
+	}
+}
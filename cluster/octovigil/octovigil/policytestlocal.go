@@ -0,0 +1,79 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+
+package octovigil
+
+import (
+	"context"
+	"io"
+
+	"github.com/octelium/octelium/apis/cluster/coctovigilv1"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/admin"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// localPolicyTestC serves the TestPolicy calls of the API Server in-process so
+// that a policy test can be run without a Cluster.
+type localPolicyTestC struct {
+	coctovigilv1.InternalServiceClient
+}
+
+func (c *localPolicyTestC) InternalC() coctovigilv1.InternalServiceClient {
+	return c
+}
+
+func (c *localPolicyTestC) TestPolicy(ctx context.Context,
+	req *coctovigilv1.TestPolicyRequest, opts ...grpc.CallOption) (*coctovigilv1.TestPolicyResponse, error) {
+	return (&internalService{}).TestPolicy(ctx, req)
+}
+
+// RunPolicyTest reads a corev1.TestPolicyRequest in JSON from r, evaluates it
+// locally with the same validation and decision logic that the Cluster uses
+// and writes the corev1.TestPolicyResponse in JSON to w.
+func RunPolicyTest(ctx context.Context, r io.Reader, w io.Writer) error {
+	reqBytes, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	req := &corev1.TestPolicyRequest{}
+	if err := pbutils.UnmarshalJSON(reqBytes, req); err != nil {
+		return errors.Errorf("Could not parse the request: %+v", err)
+	}
+
+	adminSrv := admin.NewServer(&admin.Opts{
+		OctovigilC: &localPolicyTestC{
+			InternalServiceClient: coctovigilv1.NewInternalServiceClient(policyTestConn{}),
+		},
+	})
+
+	resp, err := adminSrv.TestPolicy(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	respBytes, err := pbutils.MarshalJSON(resp, false)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(respBytes)
+	return err
+}
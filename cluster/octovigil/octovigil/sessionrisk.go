@@ -0,0 +1,238 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package octovigil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rcachev1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/apis/rsc/rratelimitv1"
+	"github.com/octelium/octelium/cluster/common/sessionrisk"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"go.uber.org/zap"
+)
+
+// maxSessionDeniedWorkers is the maximum number of Sessions whose denied
+// requests are counted concurrently
+const maxSessionDeniedWorkers = 32
+
+// sessionDeniedCtl bounds the RPCs made to count the denied requests of the
+// Sessions. Every Session has at most one worker that counts its pending
+// denied requests one by one, and a Session whose DENIED_BURST signal has
+// already been raised is muted until the end of the window so that a Session
+// flooding denied requests cannot turn into an RPC flood.
+type sessionDeniedCtl struct {
+	mu       sync.Mutex
+	workers  int
+	sessions map[string]*sessionDeniedState
+}
+
+type sessionDeniedState struct {
+	pending    int
+	isRunning  bool
+	mutedUntil time.Time
+}
+
+// add records a denied request of the Session and returns whether a new
+// worker must be started to count it
+func (c *sessionDeniedCtl) add(sessUID string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.sessions == nil {
+		c.sessions = make(map[string]*sessionDeniedState)
+	}
+
+	st, ok := c.sessions[sessUID]
+	if !ok {
+		for uid, st := range c.sessions {
+			if !st.isRunning && !now.Before(st.mutedUntil) {
+				delete(c.sessions, uid)
+			}
+		}
+
+		st = &sessionDeniedState{}
+		c.sessions[sessUID] = st
+	}
+
+	if now.Before(st.mutedUntil) {
+		return false
+	}
+
+	if st.isRunning {
+		st.pending++
+		return false
+	}
+
+	if c.workers >= maxSessionDeniedWorkers {
+		delete(c.sessions, sessUID)
+		return false
+	}
+
+	st.pending++
+	st.isRunning = true
+	c.workers++
+	return true
+}
+
+// next returns whether the Session's worker has a pending denied request to
+// count. The worker must exit once it returns false.
+func (c *sessionDeniedCtl) next(sessUID string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	st := c.sessions[sessUID]
+	if st.pending == 0 {
+		st.isRunning = false
+		c.workers--
+		if !now.Before(st.mutedUntil) {
+			delete(c.sessions, sessUID)
+		}
+		return false
+	}
+
+	st.pending--
+	return true
+}
+
+// mute drops the pending denied requests of the Session and ignores its
+// denied requests until the given time
+func (c *sessionDeniedCtl) mute(sessUID string, until time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	st := c.sessions[sessUID]
+	st.pending = 0
+	st.mutedUntil = until
+}
+
+// onSessionDenied counts the denied requests of the Session and raises a
+// DENIED_BURST risk signal once they exceed the ClusterConfig threshold.
+// Requests that are only denied because they require a step-up
+// authentication are not counted.
+func (s *Server) onSessionDenied(req *corev1.RequestContext, reason *corev1.AccessLog_Entry_Common_Reason) {
+	if req.GetSession().GetMetadata() == nil {
+		return
+	}
+
+	if reason.GetType() == corev1.AccessLog_Entry_Common_Reason_STEP_UP_REQUIRED {
+		return
+	}
+
+	cc := s.ccCtl.Get()
+	if !sessionrisk.IsEnabled(cc) {
+		return
+	}
+
+	limit, window := sessionrisk.GetDeniedBurst(cc)
+	if limit == 0 {
+		return
+	}
+
+	sessUID := req.Session.Metadata.Uid
+
+	if !s.sessDeniedCtl.add(sessUID, time.Now()) {
+		return
+	}
+
+	go func() {
+		for s.sessDeniedCtl.next(sessUID, time.Now()) {
+			ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+			isRaised, err := s.doOnSessionDenied(ctx, cc, sessUID, limit, window)
+			cancelFn()
+			if err != nil {
+				zap.L().Warn("Could not evaluate Session denied burst",
+					zap.String("sessUID", sessUID), zap.Error(err))
+			}
+			if isRaised {
+				s.sessDeniedCtl.mute(sessUID, time.Now().Add(window))
+			}
+		}
+	}()
+}
+
+// doOnSessionDenied counts a denied request of the Session and returns whether
+// the Session's DENIED_BURST signal has been raised within the current window
+func (s *Server) doOnSessionDenied(ctx context.Context,
+	cc *corev1.ClusterConfig, sessUID string, limit uint32, window time.Duration) (bool, error) {
+	windowDuration := &metav1.Duration{
+		Type: &metav1.Duration_Seconds{
+			Seconds: uint32(window.Seconds()),
+		},
+	}
+
+	res, err := s.octeliumC.RateLimitC().CheckSlidingWindow(ctx,
+		&rratelimitv1.CheckSlidingWindowRequest{
+			Key:    []byte(fmt.Sprintf("octelium:sessrisk:denied:%s", sessUID)),
+			Window: windowDuration,
+			Limit:  int64(limit),
+		})
+	if err != nil {
+		return false, err
+	}
+
+	if res.IsAllowed {
+		return false, nil
+	}
+
+	// The signal is only raised once per window rather than upon every
+	// denied request beyond the limit
+	raisedKey := []byte(fmt.Sprintf("octelium:sessrisk:burst:%s", sessUID))
+	if _, err := s.octeliumC.CacheC().GetCache(ctx, &rcachev1.GetCacheRequest{
+		Key: raisedKey,
+	}); err == nil {
+		return true, nil
+	} else if !grpcerr.IsNotFound(err) {
+		return false, err
+	}
+
+	if _, err := s.octeliumC.CacheC().SetCache(ctx, &rcachev1.SetCacheRequest{
+		Key:      raisedKey,
+		Data:     []byte("1"),
+		Duration: windowDuration,
+	}); err != nil {
+		return false, err
+	}
+
+	sess, err := s.octeliumC.CoreC().GetSession(ctx, &rmetav1.GetOptions{
+		Uid: sessUID,
+	})
+	if err != nil {
+		return true, err
+	}
+
+	sessionrisk.AddSignal(cc, sess, corev1.Session_Status_Risk_Signal_DENIED_BURST,
+		fmt.Sprintf("More than %d denied requests within %s", limit, window))
+
+	action, err := sessionrisk.Enforce(ctx, s.octeliumC, cc, sess)
+	if err != nil {
+		return true, err
+	}
+
+	if action == sessionrisk.ActionTerminate {
+		return true, nil
+	}
+
+	_, err = s.octeliumC.CoreC().UpdateSession(ctx, sess)
+	return true, err
+}
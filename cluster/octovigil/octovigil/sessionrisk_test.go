@@ -0,0 +1,90 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package octovigil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionDeniedCtl(t *testing.T) {
+	now := time.Now()
+	c := &sessionDeniedCtl{}
+
+	assert.True(t, c.add("sess1", now))
+	assert.False(t, c.add("sess1", now))
+	assert.False(t, c.add("sess1", now))
+	assert.Equal(t, 1, c.workers)
+	assert.Equal(t, 3, c.sessions["sess1"].pending)
+
+	assert.True(t, c.next("sess1", now))
+	assert.True(t, c.next("sess1", now))
+	c.mute("sess1", now.Add(time.Minute))
+	assert.False(t, c.next("sess1", now))
+	assert.Equal(t, 0, c.workers)
+
+	// A muted Session is ignored until the end of the window
+	assert.False(t, c.add("sess1", now))
+	assert.Equal(t, 0, c.sessions["sess1"].pending)
+
+	assert.True(t, c.add("sess1", now.Add(2*time.Minute)))
+	assert.True(t, c.next("sess1", now.Add(2*time.Minute)))
+	assert.False(t, c.next("sess1", now.Add(2*time.Minute)))
+	assert.Equal(t, 0, len(c.sessions))
+}
+
+func TestSessionDeniedCtlMaxWorkers(t *testing.T) {
+	now := time.Now()
+	c := &sessionDeniedCtl{}
+
+	for i := range maxSessionDeniedWorkers {
+		assert.True(t, c.add(string(rune('a'+i)), now))
+	}
+
+	assert.False(t, c.add("extra", now))
+	assert.Equal(t, maxSessionDeniedWorkers, c.workers)
+	_, ok := c.sessions["extra"]
+	assert.False(t, ok)
+
+	assert.True(t, c.next("a", now))
+	assert.False(t, c.next("a", now))
+	assert.Equal(t, maxSessionDeniedWorkers-1, c.workers)
+	_, ok = c.sessions["a"]
+	assert.False(t, ok)
+
+	assert.True(t, c.add("extra", now))
+}
+
+func TestOnSessionDeniedStepUp(t *testing.T) {
+	s := &Server{}
+
+	s.onSessionDenied(&corev1.RequestContext{
+		Session: &corev1.Session{
+			Metadata: &metav1.Metadata{
+				Uid: "sess1",
+			},
+		},
+	}, &corev1.AccessLog_Entry_Common_Reason{
+		Type: corev1.AccessLog_Entry_Common_Reason_STEP_UP_REQUIRED,
+	})
+
+	assert.Equal(t, 0, len(s.sessDeniedCtl.sessions))
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package octovigil
+
+import (
+	"context"
+	"slices"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+)
+
+// policyTrace records the enforcement rules evaluated while collecting the
+// Policy rules of a request. It is only set in the context of simulations
+type policyTrace struct {
+	enforcementRules []*corev1.SimulatePolicyResponse_EnforcementRule
+}
+
+type ctxKeyPolicyTrace struct{}
+
+func withPolicyTrace(ctx context.Context, t *policyTrace) context.Context {
+	return context.WithValue(ctx, ctxKeyPolicyTrace{}, t)
+}
+
+func getPolicyTrace(ctx context.Context) *policyTrace {
+	ret, _ := ctx.Value(ctxKeyPolicyTrace{}).(*policyTrace)
+	return ret
+}
+
+func (t *policyTrace) addEnforcementRule(req *shouldEnforcePolicyReq,
+	rule *corev1.Policy_Spec_EnforcementRule, res *getDecisionEnforcementRuleRes, err error) {
+	if t == nil {
+		return
+	}
+
+	item := &corev1.SimulatePolicyResponse_EnforcementRule{
+		Source: getSimulateSource(req.policyRef, req.inline),
+		Index:  int32(slices.Index(req.spec.EnforcementRules, rule)),
+		Effect: rule.Effect,
+	}
+
+	switch {
+	case err != nil:
+		item.Result = corev1.SimulatePolicyResponse_ERROR
+		item.Error = err.Error()
+	case res.decision == matchDecisionMATCH_YES:
+		item.Result = corev1.SimulatePolicyResponse_MATCHED
+	default:
+		item.Result = corev1.SimulatePolicyResponse_NOT_MATCHED
+	}
+
+	t.enforcementRules = append(t.enforcementRules, item)
+}
+
+func getSimulateSource(policyRef *metav1.ObjectReference,
+	inline *policyRuleInline) *corev1.SimulatePolicyResponse_Source {
+	switch {
+	case policyRef != nil:
+		return &corev1.SimulatePolicyResponse_Source{
+			Type: &corev1.SimulatePolicyResponse_Source_PolicyRef{
+				PolicyRef: policyRef,
+			},
+		}
+	case inline != nil:
+		return &corev1.SimulatePolicyResponse_Source{
+			Type: &corev1.SimulatePolicyResponse_Source_InlinePolicy_{
+				InlinePolicy: &corev1.SimulatePolicyResponse_Source_InlinePolicy{
+					Name:        inline.name,
+					ResourceRef: inline.resourceRef,
+				},
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+// simulatePolicy computes the decision of the request exactly as isAuthorized
+// does and additionally evaluates every collected Policy rule, instead of
+// stopping at the first match, so that the whole picture can be inspected
+func (s *Server) simulatePolicy(ctx context.Context, reqCtx *corev1.RequestContext) (*corev1.SimulatePolicyResponse, error) {
+	ret := &corev1.SimulatePolicyResponse{}
+
+	if reqCtx.Namespace == nil {
+		reqCtx.Namespace, _ = s.getNamespaceFromName(ctx, reqCtx.Service.Status.NamespaceRef.Name)
+	}
+
+	isAuthorized, reason, err := s.isAuthorized(ctx, reqCtx, nil)
+	if err != nil {
+		ret.Error = err.Error()
+	} else {
+		ret.IsAuthorized = isAuthorized
+		ret.Reason = reason
+	}
+
+	isDecisionPending := err == nil &&
+		(reason.GetType() == corev1.AccessLog_Entry_Common_Reason_POLICY_MATCH ||
+			reason.GetType() == corev1.AccessLog_Entry_Common_Reason_STEP_UP_REQUIRED)
+
+	reqCtxMap, err := pbutils.ConvertToMap(reqCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := &policyTrace{}
+	allRules, err := s.getAllRules(withPolicyTrace(ctx, trace), &getDecisionReq{
+		i: reqCtx,
+	}, reqCtxMap)
+	if err != nil {
+		return nil, err
+	}
+
+	sortPolicyRules(allRules)
+
+	for _, rule := range allRules {
+		item := &corev1.SimulatePolicyResponse_Rule{
+			Name:     rule.rule.Name,
+			Index:    int32(rule.index),
+			Priority: rule.rule.Priority,
+			Effect:   rule.rule.Effect,
+			Source:   getSimulateSource(rule.policyRef, rule.inline),
+		}
+
+		res, err := s.getDecisionRule(ctx, &getDecisionRuleReq{
+			rule:      rule,
+			reqCtxMap: reqCtxMap,
+			session:   reqCtx.Session,
+		})
+		switch {
+		case err != nil:
+			item.Result = corev1.SimulatePolicyResponse_ERROR
+			item.Error = err.Error()
+		case res.decision == matchDecisionMATCH_YES:
+			item.Result = corev1.SimulatePolicyResponse_MATCHED
+			item.IsStepUpRequired = res.reason.GetType() == corev1.AccessLog_Entry_Common_Reason_STEP_UP_REQUIRED
+			if isDecisionPending {
+				item.IsDecisive = true
+				isDecisionPending = false
+			}
+		default:
+			item.Result = corev1.SimulatePolicyResponse_NOT_MATCHED
+		}
+
+		ret.Rules = append(ret.Rules, item)
+	}
+
+	ret.EnforcementRules = trace.enforcementRules
+
+	return ret, nil
+}
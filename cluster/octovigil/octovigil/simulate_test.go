@@ -0,0 +1,216 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package octovigil
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/admin"
+	"github.com/octelium/octelium/cluster/apiserver/apiserver/user"
+	"github.com/octelium/octelium/cluster/common/tests"
+	"github.com/octelium/octelium/cluster/common/tests/tstuser"
+	"github.com/octelium/octelium/pkg/utils/utilrand"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatePolicy(t *testing.T) {
+
+	ctx := context.Background()
+
+	tst, err := tests.Initialize(nil)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		tst.Destroy()
+	})
+	fakeC := tst.C
+
+	adminSrv := admin.NewServer(&admin.Opts{
+		OcteliumC:  fakeC.OcteliumC,
+		IsEmbedded: true,
+	})
+	usrSrv := user.NewServer(tst.C.OcteliumC)
+
+	srv, err := New(ctx, tst.C.OcteliumC)
+	assert.Nil(t, err)
+
+	usr, err := tstuser.NewUserWithType(tst.C.OcteliumC, adminSrv, usrSrv, nil,
+		corev1.User_Spec_HUMAN, corev1.Session_Status_CLIENT)
+	assert.Nil(t, err)
+
+	ignored, err := adminSrv.CreatePolicy(ctx, &corev1.Policy{
+		Metadata: &metav1.Metadata{
+			Name: utilrand.GetRandomStringCanonical(8),
+		},
+		Spec: &corev1.Policy_Spec{
+			Rules: []*corev1.Policy_Spec_Rule{
+				{
+					Effect: corev1.Policy_Spec_Rule_DENY,
+					Condition: &corev1.Condition{
+						Type: &corev1.Condition_MatchAny{
+							MatchAny: true,
+						},
+					},
+				},
+			},
+			EnforcementRules: []*corev1.Policy_Spec_EnforcementRule{
+				{
+					Effect: corev1.Policy_Spec_EnforcementRule_IGNORE,
+					Condition: &corev1.Condition{
+						Type: &corev1.Condition_MatchAny{
+							MatchAny: true,
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	svc, err := adminSrv.CreateService(ctx, &corev1.Service{
+		Metadata: &metav1.Metadata{
+			Name: utilrand.GetRandomStringCanonical(8),
+		},
+		Spec: &corev1.Service_Spec{
+			Mode: corev1.Service_Spec_HTTP,
+			Config: &corev1.Service_Spec_Config{
+				Upstream: &corev1.Service_Spec_Config_Upstream{
+					Type: &corev1.Service_Spec_Config_Upstream_Url{
+						Url: "https://example.com",
+					},
+				},
+			},
+			Authorization: &corev1.Service_Spec_Authorization{
+				Policies: []string{ignored.Metadata.Name},
+				InlinePolicies: []*corev1.InlinePolicy{
+					{
+						Name: "inline-1",
+						Spec: &corev1.Policy_Spec{
+							Rules: []*corev1.Policy_Spec_Rule{
+								{
+									Name:     "deny-others",
+									Effect:   corev1.Policy_Spec_Rule_DENY,
+									Priority: 1,
+									Condition: &corev1.Condition{
+										Type: &corev1.Condition_Match{
+											Match: fmt.Sprintf(`ctx.user.metadata.name != "%s"`, usr.Usr.Metadata.Name),
+										},
+									},
+								},
+								{
+									Name:   "allow-user",
+									Effect: corev1.Policy_Spec_Rule_ALLOW,
+									Condition: &corev1.Condition{
+										Type: &corev1.Condition_Match{
+											Match: fmt.Sprintf(`ctx.user.metadata.name == "%s"`, usr.Usr.Metadata.Name),
+										},
+									},
+								},
+								{
+									Name:   "allow-all",
+									Effect: corev1.Policy_Spec_Rule_ALLOW,
+									Condition: &corev1.Condition{
+										Type: &corev1.Condition_MatchAny{
+											MatchAny: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+	svc, err = fakeC.OcteliumC.CoreC().GetService(ctx, &rmetav1.GetOptions{Uid: svc.Metadata.Uid})
+	assert.Nil(t, err)
+
+	resp, err := srv.simulatePolicy(ctx, &corev1.RequestContext{
+		Service: svc,
+		Session: usr.Session,
+		User:    usr.Usr,
+		Device:  usr.Device,
+		Groups:  usr.MustGetGroups(ctx),
+	})
+	assert.Nil(t, err)
+
+	assert.True(t, resp.IsAuthorized)
+	assert.Equal(t, "", resp.Error)
+	assert.Equal(t, corev1.AccessLog_Entry_Common_Reason_POLICY_MATCH, resp.Reason.Type)
+	assert.Equal(t, "allow-user", resp.Reason.Details.GetPolicyMatch().RuleName)
+
+	assert.Equal(t, 3, len(resp.Rules))
+
+	assert.Equal(t, "allow-user", resp.Rules[0].Name)
+	assert.Equal(t, corev1.SimulatePolicyResponse_MATCHED, resp.Rules[0].Result)
+	assert.True(t, resp.Rules[0].IsDecisive)
+	assert.Equal(t, "inline-1", resp.Rules[0].Source.GetInlinePolicy().Name)
+	assert.Equal(t, svc.Metadata.Uid, resp.Rules[0].Source.GetInlinePolicy().ResourceRef.Uid)
+
+	assert.Equal(t, "allow-all", resp.Rules[1].Name)
+	assert.Equal(t, corev1.SimulatePolicyResponse_MATCHED, resp.Rules[1].Result)
+	assert.False(t, resp.Rules[1].IsDecisive)
+
+	assert.Equal(t, "deny-others", resp.Rules[2].Name)
+	assert.Equal(t, corev1.SimulatePolicyResponse_NOT_MATCHED, resp.Rules[2].Result)
+	assert.False(t, resp.Rules[2].IsDecisive)
+
+	assert.Equal(t, 1, len(resp.EnforcementRules))
+	assert.Equal(t, ignored.Metadata.Uid, resp.EnforcementRules[0].Source.GetPolicyRef().Uid)
+	assert.Equal(t, int32(0), resp.EnforcementRules[0].Index)
+	assert.Equal(t, corev1.Policy_Spec_EnforcementRule_IGNORE, resp.EnforcementRules[0].Effect)
+	assert.Equal(t, corev1.SimulatePolicyResponse_MATCHED, resp.EnforcementRules[0].Result)
+}
+
+func TestPolicyTrace(t *testing.T) {
+	var nilTrace *policyTrace
+	nilTrace.addEnforcementRule(&shouldEnforcePolicyReq{}, &corev1.Policy_Spec_EnforcementRule{},
+		&getDecisionEnforcementRuleRes{}, nil)
+
+	assert.Nil(t, getPolicyTrace(context.Background()))
+
+	rule := &corev1.Policy_Spec_EnforcementRule{
+		Effect: corev1.Policy_Spec_EnforcementRule_ENFORCE,
+	}
+	trace := &policyTrace{}
+	ctx := withPolicyTrace(context.Background(), trace)
+
+	getPolicyTrace(ctx).addEnforcementRule(&shouldEnforcePolicyReq{
+		spec: &corev1.Policy_Spec{
+			EnforcementRules: []*corev1.Policy_Spec_EnforcementRule{
+				{}, rule,
+			},
+		},
+		inline: &policyRuleInline{
+			name: "inline-1",
+		},
+	}, rule, &getDecisionEnforcementRuleRes{
+		decision: matchDecisionMATCH_YES,
+	}, nil)
+
+	assert.Equal(t, 1, len(trace.enforcementRules))
+	assert.Equal(t, int32(1), trace.enforcementRules[0].Index)
+	assert.Equal(t, corev1.Policy_Spec_EnforcementRule_ENFORCE, trace.enforcementRules[0].Effect)
+	assert.Equal(t, corev1.SimulatePolicyResponse_MATCHED, trace.enforcementRules[0].Result)
+	assert.Equal(t, "inline-1", trace.enforcementRules[0].Source.GetInlinePolicy().Name)
+	assert.Nil(t, getSimulateSource(nil, nil))
+}
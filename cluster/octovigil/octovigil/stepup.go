@@ -0,0 +1,74 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package octovigil
+
+import (
+	"slices"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+)
+
+const defaultStepUpMaxAge = 5 * time.Minute
+
+func isStepUpSatisfied(sess *corev1.Session, req *corev1.Policy_Spec_Rule_StepUp) bool {
+	stepUp := sess.GetStatus().GetStepUp()
+	if stepUp == nil || !stepUp.SetAt.IsValid() || !stepUp.ExpiresAt.IsValid() {
+		return false
+	}
+
+	now := time.Now()
+	if !now.Before(stepUp.ExpiresAt.AsTime()) {
+		return false
+	}
+
+	maxAge := umetav1.ToDuration(req.MaxAge).ToGo()
+	if maxAge <= 0 {
+		maxAge = defaultStepUpMaxAge
+	}
+
+	if now.Sub(stepUp.SetAt.AsTime()) > maxAge {
+		return false
+	}
+
+	if len(req.AuthenticatorTypes) > 0 &&
+		!slices.Contains(req.AuthenticatorTypes, stepUp.AuthenticatorType) {
+		return false
+	}
+
+	if stepUp.Aal < req.MinAAL {
+		return false
+	}
+
+	return true
+}
+
+func getSessionFromCtxMap(reqCtxMap map[string]any) *corev1.Session {
+	sessMap, ok := reqCtxMap["session"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	ret := &corev1.Session{}
+	if err := pbutils.UnmarshalFromMap(sessMap, ret); err != nil {
+		return nil
+	}
+
+	return ret
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package octovigil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsStepUpSatisfied(t *testing.T) {
+	getSess := func(setAt time.Time, typ corev1.Authenticator_Status_Type,
+		aal corev1.Session_Status_Authentication_Info_AAL) *corev1.Session {
+		return &corev1.Session{
+			Status: &corev1.Session_Status{
+				StepUp: &corev1.Session_Status_StepUp{
+					SetAt:             pbutils.Timestamp(setAt),
+					ExpiresAt:         pbutils.Timestamp(setAt.Add(15 * time.Minute)),
+					AuthenticatorType: typ,
+					Aal:               aal,
+				},
+			},
+		}
+	}
+
+	now := time.Now()
+
+	assert.False(t, isStepUpSatisfied(nil, &corev1.Policy_Spec_Rule_StepUp{}))
+	assert.False(t, isStepUpSatisfied(&corev1.Session{
+		Status: &corev1.Session_Status{},
+	}, &corev1.Policy_Spec_Rule_StepUp{}))
+
+	assert.True(t, isStepUpSatisfied(
+		getSess(now.Add(-time.Minute), corev1.Authenticator_Status_TOTP, corev1.Session_Status_Authentication_Info_AAL2),
+		&corev1.Policy_Spec_Rule_StepUp{}))
+
+	assert.False(t, isStepUpSatisfied(
+		getSess(now.Add(-6*time.Minute), corev1.Authenticator_Status_TOTP, corev1.Session_Status_Authentication_Info_AAL2),
+		&corev1.Policy_Spec_Rule_StepUp{}))
+
+	assert.True(t, isStepUpSatisfied(
+		getSess(now.Add(-6*time.Minute), corev1.Authenticator_Status_TOTP, corev1.Session_Status_Authentication_Info_AAL2),
+		&corev1.Policy_Spec_Rule_StepUp{
+			MaxAge: &metav1.Duration{
+				Type: &metav1.Duration_Minutes{Minutes: 10},
+			},
+		}))
+
+	assert.False(t, isStepUpSatisfied(
+		getSess(now.Add(-20*time.Minute), corev1.Authenticator_Status_TOTP, corev1.Session_Status_Authentication_Info_AAL2),
+		&corev1.Policy_Spec_Rule_StepUp{
+			MaxAge: &metav1.Duration{
+				Type: &metav1.Duration_Hours{Hours: 1},
+			},
+		}))
+
+	assert.False(t, isStepUpSatisfied(
+		getSess(now.Add(-time.Minute), corev1.Authenticator_Status_TOTP, corev1.Session_Status_Authentication_Info_AAL2),
+		&corev1.Policy_Spec_Rule_StepUp{
+			AuthenticatorTypes: []corev1.Authenticator_Status_Type{corev1.Authenticator_Status_FIDO},
+		}))
+
+	assert.True(t, isStepUpSatisfied(
+		getSess(now.Add(-time.Minute), corev1.Authenticator_Status_FIDO, corev1.Session_Status_Authentication_Info_AAL3),
+		&corev1.Policy_Spec_Rule_StepUp{
+			AuthenticatorTypes: []corev1.Authenticator_Status_Type{corev1.Authenticator_Status_FIDO},
+			MinAAL:             corev1.Session_Status_Authentication_Info_AAL3,
+		}))
+
+	assert.False(t, isStepUpSatisfied(
+		getSess(now.Add(-time.Minute), corev1.Authenticator_Status_FIDO, corev1.Session_Status_Authentication_Info_AAL2),
+		&corev1.Policy_Spec_Rule_StepUp{
+			MinAAL: corev1.Session_Status_Authentication_Info_AAL3,
+		}))
+}
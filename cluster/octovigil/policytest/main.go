@@ -0,0 +1,41 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+
+// Command octelium-policytest evaluates policy tests locally, without a
+// Cluster. It reads a TestPolicyRequest in JSON from stdin and writes the
+// TestPolicyResponse in JSON to stdout. It is run by `octeliumctl policy test --local`.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/octelium/octelium/cluster/octovigil/octovigil"
+	"google.golang.org/grpc/status"
+)
+
+func main() {
+	ctx, cancelFn := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancelFn()
+
+	if err := octovigil.RunPolicyTest(ctx, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, status.Convert(err).Message())
+		os.Exit(1)
+	}
+}
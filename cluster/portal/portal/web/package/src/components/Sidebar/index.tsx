@@ -1,5 +1,5 @@
 import { Button } from "@mantine/core";
-import { Boxes, LockKeyhole, LogOut, PanelTop } from "lucide-react";
+import { Boxes, KeyRound, LockKeyhole, LogOut, PanelTop } from "lucide-react";
 
 import Links from "@/pages/Links";
 import { getDomain } from "@/utils";
@@ -8,7 +8,7 @@ import { Modal } from "@mantine/core";
 import { useDisclosure } from "@mantine/hooks";
 import { LogoutRequest } from "@octelium/apis/main/userv1";
 import { useMutation } from "@tanstack/react-query";
-import { useLocation } from "react-router-dom";
+import { Link, useLocation } from "react-router-dom";
 import ReleaseBox from "../ReleaseBox";
 
 const items = [
@@ -47,6 +47,19 @@ export default function () {
         </div>
         <div className="flex-1"></div>
         <div className="flex flex-col">
+          <Button
+            className="mb-3 transition-all duration-500"
+            fullWidth
+            variant={
+              loc.pathname.startsWith("/accessrequests") ? "filled" : "outline"
+            }
+            component={Link}
+            to="/accessrequests"
+          >
+            <KeyRound className="mr-1" />
+            <span>Access Requests</span>
+          </Button>
+
           <Button
             className="mb-3 transition-all duration-500"
             fullWidth
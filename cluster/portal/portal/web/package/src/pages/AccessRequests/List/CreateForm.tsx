@@ -0,0 +1,88 @@
+import { getClientUser } from "@/utils/client";
+import { Button, Select, TextInput, Textarea } from "@mantine/core";
+import { AccessRequest } from "@octelium/apis/main/userv1";
+import { useMutation, useQueryClient } from "@tanstack/react-query";
+import * as React from "react";
+
+const durations = [
+  { value: "15", label: "15 minutes" },
+  { value: "60", label: "1 hour" },
+  { value: "240", label: "4 hours" },
+  { value: "480", label: "8 hours" },
+  { value: "1440", label: "1 day" },
+];
+
+export default () => {
+  const queryClient = useQueryClient();
+  const [service, setService] = React.useState("");
+  const [duration, setDuration] = React.useState<string | null>("60");
+  const [justification, setJustification] = React.useState("");
+
+  const mutation = useMutation({
+    mutationFn: async () => {
+      return await getClientUser().createAccessRequest(
+        AccessRequest.create({
+          spec: {
+            service,
+            justification,
+            duration: {
+              type: {
+                oneofKind: "minutes",
+                minutes: Number(duration ?? "60"),
+              },
+            },
+          },
+        }),
+      );
+    },
+    onSuccess: () => {
+      setService("");
+      setJustification("");
+      queryClient.invalidateQueries({
+        queryKey: ["user/main.listAccessRequests"],
+      });
+    },
+  });
+
+  return (
+    <div className="flex flex-col gap-3 mb-8">
+      <TextInput
+        label="Service"
+        placeholder="e.g. db.production"
+        value={service}
+        onChange={(e) => setService(e.currentTarget.value)}
+      />
+      <Select
+        label="Duration"
+        data={durations}
+        value={duration}
+        onChange={setDuration}
+        allowDeselect={false}
+      />
+      <Textarea
+        label="Justification"
+        placeholder="Why do you need access?"
+        value={justification}
+        onChange={(e) => setJustification(e.currentTarget.value)}
+        autosize
+        minRows={2}
+      />
+      {mutation.isError && (
+        <div className="text-red-600 text-sm font-semibold">
+          {mutation.error.message}
+        </div>
+      )}
+      <div className="flex justify-end">
+        <Button
+          loading={mutation.isPending}
+          disabled={service.length === 0 || justification.length === 0}
+          onClick={() => {
+            mutation.mutate();
+          }}
+        >
+          Request Access
+        </Button>
+      </div>
+    </div>
+  );
+};
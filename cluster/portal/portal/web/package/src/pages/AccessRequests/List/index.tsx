@@ -0,0 +1,181 @@
+import { getClientUser } from "@/utils/client";
+import { useAppSelector } from "@/utils/hooks";
+
+import {
+  AccessRequest,
+  AccessRequest_Status_State,
+  ApproveAccessRequestRequest,
+  DenyAccessRequestRequest,
+  ListAccessRequestOptions,
+} from "@octelium/apis/main/userv1";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+
+import EmptyList from "@/components/EmptyList";
+import {
+  ResourceListItem,
+  ResourceListLabel,
+  ResourceListWrapper,
+} from "@/components/ResourceList";
+import TimeAgo from "@/components/TimeAgo";
+import { Button, SegmentedControl, Text } from "@mantine/core";
+import * as React from "react";
+import { twMerge } from "tailwind-merge";
+import { match } from "ts-pattern";
+import CreateForm from "./CreateForm";
+
+const getState = (item: AccessRequest): string => {
+  return match(item.status?.state)
+    .with(AccessRequest_Status_State.PENDING, () => "Pending")
+    .with(AccessRequest_Status_State.APPROVED, () => "Approved")
+    .with(AccessRequest_Status_State.DENIED, () => "Denied")
+    .with(AccessRequest_Status_State.EXPIRED, () => "Expired")
+    .otherwise(() => "");
+};
+
+const Item = (props: { item: AccessRequest; toDecide: boolean }) => {
+  const { item } = props;
+  const queryClient = useQueryClient();
+
+  const onSuccess = () => {
+    queryClient.invalidateQueries({
+      queryKey: ["user/main.listAccessRequests"],
+    });
+  };
+
+  const mutationApprove = useMutation({
+    mutationFn: async () => {
+      await getClientUser().approveAccessRequest(
+        ApproveAccessRequestRequest.create({ name: item.metadata!.name }),
+      );
+    },
+    onSuccess,
+  });
+
+  const mutationDeny = useMutation({
+    mutationFn: async () => {
+      await getClientUser().denyAccessRequest(
+        DenyAccessRequestRequest.create({ name: item.metadata!.name }),
+      );
+    },
+    onSuccess,
+  });
+
+  return (
+    <div className="font-semibold w-full">
+      <div className="flex items-start">
+        <div className="flex flex-col flex-1">
+          <Text className="flex flex-row" size="sm" fw={"bold"}>
+            {item.spec!.service.length > 0
+              ? item.spec!.service
+              : `Namespace ${item.spec!.namespace}`}
+            <Text className="ml-3" c="gray.7" inherit>
+              {item.status!.user}
+            </Text>
+          </Text>
+          <Text size="sm" c="gray.7">
+            {item.spec!.justification}
+          </Text>
+          <div className="w-full mt-1 flex flex-row">
+            <ResourceListLabel>{getState(item)}</ResourceListLabel>
+            {item.status!.expiresAt && (
+              <ResourceListLabel>
+                <span className="mr-1">Expires</span>
+                <TimeAgo
+                  rfc3339={new Date(
+                    Number(item.status!.expiresAt.seconds) * 1000,
+                  ).toISOString()}
+                />
+              </ResourceListLabel>
+            )}
+          </div>
+        </div>
+        {props.toDecide &&
+          item.status!.state === AccessRequest_Status_State.PENDING && (
+            <div className="flex flex-row">
+              <Button
+                variant="outline"
+                loading={mutationDeny.isPending}
+                onClick={() => mutationDeny.mutate()}
+              >
+                Deny
+              </Button>
+              <Button
+                className="ml-2"
+                loading={mutationApprove.isPending}
+                onClick={() => mutationApprove.mutate()}
+              >
+                Approve
+              </Button>
+            </div>
+          )}
+      </div>
+    </div>
+  );
+};
+
+const Page = () => {
+  const settings = useAppSelector((state) => state.settings);
+  const [view, setView] = React.useState("mine");
+  const toDecide = view === "toDecide";
+
+  const o = ListAccessRequestOptions.create({
+    toDecide,
+    common: {
+      itemsPerPage: settings.itemsPerPage,
+    },
+  });
+
+  const qry = useQuery({
+    queryKey: [
+      "user/main.listAccessRequests",
+      ListAccessRequestOptions.toJsonString(o),
+    ],
+    queryFn: async () => {
+      return await getClientUser().listAccessRequest(o);
+    },
+  });
+
+  return (
+    <>
+      <title>Access Requests - Octelium Portal</title>
+      <div className="mt-4 mb-6 flex items-center">
+        <div
+          className={twMerge(
+            "font-bold text-3xl text-gray-800 text-shadow-2xs flex-1",
+          )}
+        >
+          Access Requests
+        </div>
+        <SegmentedControl
+          value={view}
+          onChange={setView}
+          data={[
+            { label: "My Requests", value: "mine" },
+            { label: "To Decide", value: "toDecide" },
+          ]}
+        />
+      </div>
+
+      {!toDecide && <CreateForm />}
+
+      {qry.isError && toDecide && (
+        <EmptyList title="You are not an approver"></EmptyList>
+      )}
+
+      {qry.isSuccess && qry.data && (
+        <ResourceListWrapper>
+          {qry.data.response.items.length === 0 && (
+            <EmptyList title="No Access Requests Found"></EmptyList>
+          )}
+          {qry.data.response.items.map((item) => (
+            <ResourceListItem key={item.metadata!.uid}>
+              <Item item={item} toDecide={toDecide} />
+            </ResourceListItem>
+          ))}
+        </ResourceListWrapper>
+      )}
+    </>
+  );
+};
+
+export default Page;
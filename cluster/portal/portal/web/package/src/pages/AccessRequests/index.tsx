@@ -0,0 +1,11 @@
+import { Outlet } from "react-router-dom";
+
+const Page = () => {
+  return (
+    <>
+      <Outlet />
+    </>
+  );
+};
+
+export default Page;
@@ -0,0 +1,16 @@
+import { RouteObject } from "react-router-dom";
+import Root from "./index";
+import List from "./List";
+
+export default (): RouteObject => {
+  return {
+    path: "accessrequests",
+    element: <Root />,
+    children: [
+      {
+        path: "",
+        element: <List />,
+      },
+    ],
+  };
+};
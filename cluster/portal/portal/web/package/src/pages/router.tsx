@@ -1,4 +1,5 @@
 import { RouteObject } from "react-router-dom";
+import routerAccessRequests from "./AccessRequests/router";
 import Home from "./Home";
 import Root from "./index";
 import routerNamespaces from "./Namespaces/router";
@@ -15,6 +16,7 @@ export default (): RouteObject => {
       },
       routerServices(),
       routerNamespaces(),
+      routerAccessRequests(),
     ],
   };
 };
@@ -0,0 +1,281 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	domainListRefreshPeriod  = 1 * time.Minute
+	domainListNotFoundPeriod = 30 * time.Second
+	domainListFetchTimeout   = 5 * time.Second
+)
+
+// dnsFilter applies the ClusterConfig's DNS filter rules to the queries
+// served by the Cluster's managed DNS server
+type dnsFilter struct {
+	octeliumC octeliumc.ClientInterface
+	celEngine *celengine.CELEngine
+
+	mu    sync.RWMutex
+	rules []*corev1.ClusterConfig_Spec_DNS_Filter_Rule
+
+	lists struct {
+		sync.Mutex
+		m  map[string]*domainList
+		sf singleflight.Group
+	}
+}
+
+// domainList is a cached domain list Config. A list whose Config is not found
+// is cached as an empty list so that it is not fetched on every query
+type domainList struct {
+	domains   map[string]struct{}
+	expiresAt time.Time
+}
+
+func newDNSFilter(octeliumC octeliumc.ClientInterface, celEngine *celengine.CELEngine) *dnsFilter {
+	ret := &dnsFilter{
+		octeliumC: octeliumC,
+		celEngine: celEngine,
+	}
+	ret.lists.m = make(map[string]*domainList)
+	return ret
+}
+
+func (f *dnsFilter) setClusterConfig(cc *corev1.ClusterConfig) {
+	var rules []*corev1.ClusterConfig_Spec_DNS_Filter_Rule
+	if cc != nil && cc.Spec != nil {
+		rules = cc.Spec.GetDns().GetFilter().GetRules()
+	}
+
+	f.mu.Lock()
+	f.rules = rules
+	f.mu.Unlock()
+
+	f.lists.Lock()
+	f.lists.m = make(map[string]*domainList)
+	f.lists.Unlock()
+}
+
+// match returns the first rule that matches the queried name and the request,
+// if any
+func (f *dnsFilter) match(ctx context.Context,
+	name string, reqCtx *corev1.RequestContext) *corev1.ClusterConfig_Spec_DNS_Filter_Rule {
+	f.mu.RLock()
+	rules := f.rules
+	f.mu.RUnlock()
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+
+	var inputMap map[string]any
+
+	for _, rule := range rules {
+		if !f.isDomainMatched(ctx, rule, name) {
+			continue
+		}
+
+		if rule.Condition != nil {
+			if inputMap == nil {
+				inputMap = map[string]any{
+					"ctx": pbutils.MustConvertToMap(reqCtx),
+				}
+			}
+
+			isMatched, err := f.celEngine.EvalCondition(ctx, rule.Condition, inputMap)
+			if err != nil {
+				zap.L().Warn("Could not eval DNS filter rule condition",
+					zap.String("rule", rule.Name), zap.Error(err))
+				// A broken BLOCK rule must not let the queries through
+				if rule.Effect != corev1.ClusterConfig_Spec_DNS_Filter_Rule_BLOCK {
+					continue
+				}
+			} else if !isMatched {
+				continue
+			}
+		}
+
+		return rule
+	}
+
+	return nil
+}
+
+func (f *dnsFilter) isDomainMatched(ctx context.Context,
+	rule *corev1.ClusterConfig_Spec_DNS_Filter_Rule, name string) bool {
+	for _, domain := range rule.Domains {
+		domain = normalizeFilterDomain(domain)
+		if domain != "" && (name == domain || strings.HasSuffix(name, "."+domain)) {
+			return true
+		}
+	}
+
+	for _, listName := range rule.Lists {
+		list := f.getList(ctx, listName)
+		if list == nil {
+			continue
+		}
+
+		if isInDomainList(list.domains, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isInDomainList(domains map[string]struct{}, name string) bool {
+	for {
+		if _, ok := domains[name]; ok {
+			return true
+		}
+
+		idx := strings.IndexByte(name, '.')
+		if idx < 0 {
+			return false
+		}
+		name = name[idx+1:]
+	}
+}
+
+func (f *dnsFilter) getList(ctx context.Context, name string) *domainList {
+	f.lists.Lock()
+	cached := f.lists.m[name]
+	f.lists.Unlock()
+
+	if cached != nil && time.Now().Before(cached.expiresAt) {
+		return cached
+	}
+
+	res, _, _ := f.lists.sf.Do(name, func() (any, error) {
+		return f.fetchList(ctx, name, cached), nil
+	})
+
+	ret, _ := res.(*domainList)
+	return ret
+}
+
+// fetchList fetches the domain list Config outside of the lists lock so that
+// a slow fetch does not block the queries matched against the other lists
+func (f *dnsFilter) fetchList(ctx context.Context, name string, cached *domainList) *domainList {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), domainListFetchTimeout)
+	defer cancel()
+
+	cfg, err := f.octeliumC.CoreC().GetConfig(ctx, &rmetav1.GetOptions{Name: name})
+	if err != nil {
+		if grpcerr.IsNotFound(err) {
+			zap.L().Warn("DNS filter domain list Config is not found", zap.String("config", name))
+			return f.setList(name, &domainList{
+				expiresAt: time.Now().Add(domainListNotFoundPeriod),
+			})
+		}
+
+		zap.L().Warn("Could not get DNS filter domain list Config",
+			zap.String("config", name), zap.Error(err))
+		if cached == nil {
+			return nil
+		}
+
+		return f.setList(name, &domainList{
+			domains:   cached.domains,
+			expiresAt: time.Now().Add(domainListRefreshPeriod),
+		})
+	}
+
+	var data string
+	if cfg.Data != nil {
+		switch {
+		case cfg.Data.GetValue() != "":
+			data = cfg.Data.GetValue()
+		case len(cfg.Data.GetValueBytes()) > 0:
+			data = string(cfg.Data.GetValueBytes())
+		}
+	}
+
+	return f.setList(name, &domainList{
+		domains:   parseDomainList(data),
+		expiresAt: time.Now().Add(domainListRefreshPeriod),
+	})
+}
+
+func (f *dnsFilter) setList(name string, list *domainList) *domainList {
+	f.lists.Lock()
+	f.lists.m[name] = list
+	f.lists.Unlock()
+	return list
+}
+
+// parseDomainList parses a list of domains, one domain per line, that can also
+// be in the hosts file format as commonly used by public block lists
+func parseDomainList(data string) map[string]struct{} {
+	ret := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			line = line[:idx]
+		}
+
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 0:
+			continue
+		case 1:
+			line = fields[0]
+		default:
+			line = fields[1]
+		}
+
+		if domain := normalizeFilterDomain(line); domain != "" {
+			ret[domain] = struct{}{}
+		}
+	}
+
+	return ret
+}
+
+func normalizeFilterDomain(arg string) string {
+	ret := strings.ToLower(strings.TrimSpace(arg))
+	ret = strings.TrimPrefix(ret, "*.")
+	ret = strings.TrimSuffix(ret, ".")
+
+	switch ret {
+	case "localhost", "localhost.localdomain", "local", "broadcasthost", "0.0.0.0":
+		return ""
+	}
+
+	return ret
+}
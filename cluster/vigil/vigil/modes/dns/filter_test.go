@@ -0,0 +1,254 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/rsc/rcorev1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
+	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/cluster/common/octeliumc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseDomainList(t *testing.T) {
+	res := parseDomainList(`
+# comment
+example.com
+*.example.org
+0.0.0.0 ads.example.net # inline comment
+127.0.0.1 localhost
+Tracker.Example.io.
+
+`)
+
+	assert.Equal(t, map[string]struct{}{
+		"example.com":        {},
+		"example.org":        {},
+		"ads.example.net":    {},
+		"tracker.example.io": {},
+	}, res)
+
+	assert.True(t, isInDomainList(res, "example.com"))
+	assert.True(t, isInDomainList(res, "a.b.example.com"))
+	assert.True(t, isInDomainList(res, "x.ads.example.net"))
+	assert.False(t, isInDomainList(res, "example.net"))
+	assert.False(t, isInDomainList(res, "notexample.com"))
+}
+
+func TestDNSFilter(t *testing.T) {
+	ctx := context.Background()
+
+	celEngine, err := celengine.New(ctx, &celengine.Opts{})
+	assert.Nil(t, err)
+
+	f := newDNSFilter(nil, celEngine)
+
+	assert.Nil(t, f.match(ctx, "example.com.", &corev1.RequestContext{}))
+
+	f.setClusterConfig(&corev1.ClusterConfig{
+		Spec: &corev1.ClusterConfig_Spec{
+			Dns: &corev1.ClusterConfig_Spec_DNS{
+				Filter: &corev1.ClusterConfig_Spec_DNS_Filter{
+					Rules: []*corev1.ClusterConfig_Spec_DNS_Filter_Rule{
+						{
+							Name: "allow-ops",
+							Condition: &corev1.Condition{
+								Type: &corev1.Condition_Match{
+									Match: `"ops" in ctx.user.spec.groups`,
+								},
+							},
+							Effect:  corev1.ClusterConfig_Spec_DNS_Filter_Rule_ALLOW,
+							Domains: []string{"ads.example.net"},
+						},
+						{
+							Name:   "block-list",
+							Effect: corev1.ClusterConfig_Spec_DNS_Filter_Rule_BLOCK,
+							Lists:  []string{"blocklist"},
+						},
+						{
+							Name:    "block-inline",
+							Effect:  corev1.ClusterConfig_Spec_DNS_Filter_Rule_BLOCK,
+							Domains: []string{"*.example.org"},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	f.lists.m["blocklist"] = &domainList{
+		domains:   parseDomainList("0.0.0.0 ads.example.net\ntracker.example.io"),
+		expiresAt: time.Now().Add(domainListRefreshPeriod),
+	}
+
+	getReqCtx := func(groups ...string) *corev1.RequestContext {
+		return &corev1.RequestContext{
+			User: &corev1.User{
+				Metadata: &metav1.Metadata{Name: "usr"},
+				Spec: &corev1.User_Spec{
+					Groups: groups,
+				},
+			},
+		}
+	}
+
+	assert.Nil(t, f.match(ctx, "example.com.", getReqCtx()))
+
+	assert.Equal(t, "block-list", f.match(ctx, "ads.example.net.", getReqCtx()).Name)
+	assert.Equal(t, "block-list", f.match(ctx, "x.ads.example.net.", getReqCtx("dev")).Name)
+	assert.Equal(t, "allow-ops", f.match(ctx, "ads.example.net.", getReqCtx("ops")).Name)
+	assert.Equal(t, "block-list", f.match(ctx, "TRACKER.example.io.", getReqCtx("ops")).Name)
+	assert.Equal(t, "block-inline", f.match(ctx, "www.example.org.", getReqCtx()).Name)
+	assert.Equal(t, "block-inline", f.match(ctx, "example.org.", getReqCtx()).Name)
+}
+
+func TestDNSFilterEvalError(t *testing.T) {
+	ctx := context.Background()
+
+	celEngine, err := celengine.New(ctx, &celengine.Opts{})
+	assert.Nil(t, err)
+
+	f := newDNSFilter(nil, celEngine)
+
+	condition := &corev1.Condition{
+		Type: &corev1.Condition_Match{
+			Match: `1 / (ctx.user.spec.groups.size() - 1) == 1`,
+		},
+	}
+
+	f.setClusterConfig(&corev1.ClusterConfig{
+		Spec: &corev1.ClusterConfig_Spec{
+			Dns: &corev1.ClusterConfig_Spec_DNS{
+				Filter: &corev1.ClusterConfig_Spec_DNS_Filter{
+					Rules: []*corev1.ClusterConfig_Spec_DNS_Filter_Rule{
+						{
+							Name:      "allow-broken",
+							Condition: condition,
+							Effect:    corev1.ClusterConfig_Spec_DNS_Filter_Rule_ALLOW,
+							Domains:   []string{"example.com"},
+						},
+						{
+							Name:      "block-broken",
+							Condition: condition,
+							Effect:    corev1.ClusterConfig_Spec_DNS_Filter_Rule_BLOCK,
+							Domains:   []string{"example.com"},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	reqCtx := &corev1.RequestContext{
+		User: &corev1.User{
+			Metadata: &metav1.Metadata{Name: "usr"},
+			Spec:     &corev1.User_Spec{Groups: []string{"dev"}},
+		},
+	}
+
+	assert.Equal(t, "block-broken", f.match(ctx, "www.example.com.", reqCtx).Name)
+	assert.Nil(t, f.match(ctx, "example.org.", reqCtx))
+}
+
+type fakeCoreC struct {
+	rcorev1.ResourceServiceClient
+	calls   atomic.Int32
+	delay   time.Duration
+	configs map[string]*corev1.Config
+}
+
+func (c *fakeCoreC) GetConfig(ctx context.Context,
+	in *rmetav1.GetOptions, opts ...grpc.CallOption) (*corev1.Config, error) {
+	c.calls.Add(1)
+	time.Sleep(c.delay)
+	cfg, ok := c.configs[in.Name]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return cfg, nil
+}
+
+type fakeOcteliumC struct {
+	octeliumc.ClientInterface
+	coreC *fakeCoreC
+}
+
+func (c *fakeOcteliumC) CoreC() rcorev1.ResourceServiceClient {
+	return c.coreC
+}
+
+func TestDNSFilterGetList(t *testing.T) {
+	ctx := context.Background()
+
+	coreC := &fakeCoreC{
+		delay: 50 * time.Millisecond,
+		configs: map[string]*corev1.Config{
+			"blocklist": {
+				Data: &corev1.Config_Data{
+					Type: &corev1.Config_Data_Value{
+						Value: "ads.example.net",
+					},
+				},
+			},
+		},
+	}
+
+	f := newDNSFilter(&fakeOcteliumC{coreC: coreC}, nil)
+
+	{
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				list := f.getList(ctx, "blocklist")
+				assert.NotNil(t, list)
+				assert.True(t, isInDomainList(list.domains, "x.ads.example.net"))
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), coreC.calls.Load())
+	}
+
+	{
+		coreC.calls.Store(0)
+
+		list := f.getList(ctx, "missing")
+		assert.NotNil(t, list)
+		assert.Empty(t, list.domains)
+
+		list = f.getList(ctx, "missing")
+		assert.NotNil(t, list)
+		assert.Equal(t, int32(1), coreC.calls.Load())
+
+		f.lists.m["missing"].expiresAt = time.Now().Add(-1 * time.Second)
+		f.getList(ctx, "missing")
+		assert.Equal(t, int32(2), coreC.calls.Load())
+	}
+}
@@ -19,6 +19,7 @@ package dns
 import (
 	"context"
 	"net"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -27,8 +28,11 @@ import (
 	"github.com/octelium/octelium/apis/cluster/coctovigilv1"
 	"github.com/octelium/octelium/apis/main/corev1"
 	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/cluster/common/ccctl"
+	"github.com/octelium/octelium/cluster/common/celengine"
 	"github.com/octelium/octelium/cluster/common/octeliumc"
 	"github.com/octelium/octelium/cluster/common/otelutils"
+	"github.com/octelium/octelium/cluster/common/vutils"
 	"github.com/octelium/octelium/cluster/vigil/vigil/controllers"
 	"github.com/octelium/octelium/cluster/vigil/vigil/loadbalancer"
 	"github.com/octelium/octelium/cluster/vigil/vigil/logentry"
@@ -39,6 +43,7 @@ import (
 	"github.com/octelium/octelium/cluster/vigil/vigil/vcache"
 	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
 	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -139,6 +144,8 @@ type Server struct {
 	svcRef *metav1.ObjectReference
 
 	metricsStore *metricsStore
+
+	filter *dnsFilter
 }
 
 type metricsStore struct {
@@ -185,6 +192,12 @@ func (s *Server) Run(ctx context.Context) error {
 	}
 	s.svcRef = umetav1.GetObjectReference(svc)
 
+	if isClusterDNSServer(svc) {
+		if err := s.runFilter(ctx); err != nil {
+			return err
+		}
+	}
+
 	addr := &net.UDPAddr{
 		IP:   net.ParseIP("::"),
 		Port: ucorev1.ToService(svc).RealPort(),
@@ -212,6 +225,42 @@ func (s *Server) Run(ctx context.Context) error {
 	return nil
 }
 
+func isClusterDNSServer(svc *corev1.Service) bool {
+	return svc.Status.ManagedService != nil && svc.Status.ManagedService.Type == "dnsserver"
+}
+
+func (s *Server) runFilter(ctx context.Context) error {
+	celEngine, err := celengine.New(ctx, &celengine.Opts{})
+	if err != nil {
+		return err
+	}
+
+	filter := newDNSFilter(s.octeliumC, celEngine)
+
+	ccCtl, err := ccctl.New(ctx, s.octeliumC, &ccctl.Opts{
+		OnUpdate: func(ctx context.Context, new, old *corev1.ClusterConfig) error {
+			if !pbutils.IsEqual(new.Spec.GetDns().GetFilter(), old.Spec.GetDns().GetFilter()) {
+				zap.L().Debug("Updating DNS filter rules")
+				filter.setClusterConfig(new)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	filter.setClusterConfig(ccCtl.Get())
+
+	if err := ccCtl.Run(ctx); err != nil {
+		return err
+	}
+
+	s.filter = filter
+
+	return nil
+}
+
 func (s *Server) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -395,6 +444,25 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 
 	state = "ALLOWED"
 
+	if s.filter != nil {
+		if rule := s.filter.match(ctx, canonicalName, authResp.RequestContext); rule != nil {
+			logE.Entry.Info.GetDns().FilterRuleName = rule.Name
+			if rule.Effect == corev1.ClusterConfig_Spec_DNS_Filter_Rule_BLOCK {
+				state = "DENIED"
+				reason = "DNS_FILTER"
+				rcodeClass = rcodeClassOf(dns.RcodeNameError)
+				logE.Entry.Info.GetDns().IsBlocked = true
+				logE.Entry.Info.GetDns().Rcode = int64(dns.RcodeNameError)
+				otelutils.EmitAccessLog(logE)
+
+				msg := new(dns.Msg)
+				msg.SetRcode(r, dns.RcodeNameError)
+				_ = w.WriteMsg(msg)
+				return
+			}
+		}
+	}
+
 	upstream, err := s.lbManager.GetUpstream(ctx, authResp)
 	if err != nil {
 		zap.L().Warn("Could not get lb upstream", zap.Error(err))
@@ -409,6 +477,9 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	}
 
 	clientReq := r.Copy()
+	hasOPT := clientReq.IsEdns0() != nil
+	setManagedDNSGroupsOption(clientReq,
+		ucorev1.ToService(svc).IsManagedService(), authResp.RequestContext.GetGroups())
 
 	client := dns.Client{
 		Net: func() string {
@@ -448,6 +519,11 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	resp.Answer = proxiedMsg.Answer
 	resp.Ns = proxiedMsg.Ns
 	resp.Extra = proxiedMsg.Extra
+	if !hasOPT {
+		resp.Extra = slices.DeleteFunc(resp.Extra, func(rr dns.RR) bool {
+			return rr.Header().Rrtype == dns.TypeOPT
+		})
+	}
 
 	_ = w.WriteMsg(resp)
 
@@ -459,3 +535,35 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	}
 	otelutils.EmitAccessLog(logE)
 }
+
+// setManagedDNSGroupsOption passes the Group names of the requesting User to
+// the Cluster's managed DNS server in order to resolve its per-Group zones.
+// Any such option set by the client is always removed since the managed DNS
+// server trusts it as it is.
+func setManagedDNSGroupsOption(msg *dns.Msg, isManaged bool, groups []*corev1.Group) {
+	if opt := msg.IsEdns0(); opt != nil {
+		opt.Option = slices.DeleteFunc(opt.Option, func(o dns.EDNS0) bool {
+			return o.Option() == vutils.ManagedDNSGroupsOptionCode
+		})
+	}
+
+	if !isManaged || len(groups) == 0 {
+		return
+	}
+
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.Metadata.Name)
+	}
+
+	opt := msg.IsEdns0()
+	if opt == nil {
+		msg.SetEdns0(dns.DefaultMsgSize, false)
+		opt = msg.IsEdns0()
+	}
+
+	opt.Option = append(opt.Option, &dns.EDNS0_LOCAL{
+		Code: vutils.ManagedDNSGroupsOptionCode,
+		Data: []byte(strings.Join(names, ",")),
+	})
+}
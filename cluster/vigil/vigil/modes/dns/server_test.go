@@ -33,6 +33,7 @@ import (
 	"github.com/octelium/octelium/cluster/apiserver/apiserver/user"
 	"github.com/octelium/octelium/cluster/common/tests"
 	"github.com/octelium/octelium/cluster/common/tests/tstuser"
+	"github.com/octelium/octelium/cluster/common/vutils"
 	"github.com/octelium/octelium/cluster/vigil/vigil/loadbalancer"
 	"github.com/octelium/octelium/cluster/vigil/vigil/modes"
 	"github.com/octelium/octelium/cluster/vigil/vigil/octovigilc"
@@ -557,3 +558,58 @@ func TestServerTLS(t *testing.T) {
 	assert.Nil(t, err)
 
 }
+
+func TestSetManagedDNSGroupsOption(t *testing.T) {
+	groups := []*corev1.Group{
+		{Metadata: &metav1.Metadata{Name: "dev"}},
+		{Metadata: &metav1.Metadata{Name: "ops"}},
+	}
+
+	getOption := func(m *dns.Msg) *dns.EDNS0_LOCAL {
+		opt := m.IsEdns0()
+		if opt == nil {
+			return nil
+		}
+		for _, o := range opt.Option {
+			if local, ok := o.(*dns.EDNS0_LOCAL); ok && local.Code == vutils.ManagedDNSGroupsOptionCode {
+				return local
+			}
+		}
+		return nil
+	}
+
+	{
+		m := &dns.Msg{}
+		m.SetQuestion("example.com.", dns.TypeA)
+		setManagedDNSGroupsOption(m, false, groups)
+		assert.Nil(t, m.IsEdns0())
+	}
+
+	{
+		m := &dns.Msg{}
+		m.SetQuestion("example.com.", dns.TypeA)
+		setManagedDNSGroupsOption(m, true, groups)
+		assert.Equal(t, []byte("dev,ops"), getOption(m).Data)
+	}
+
+	{
+		m := &dns.Msg{}
+		m.SetQuestion("example.com.", dns.TypeA)
+		m.SetEdns0(dns.DefaultMsgSize, false)
+		m.IsEdns0().Option = append(m.IsEdns0().Option, &dns.EDNS0_LOCAL{
+			Code: vutils.ManagedDNSGroupsOptionCode,
+			Data: []byte("admins"),
+		})
+
+		setManagedDNSGroupsOption(m, false, groups)
+		assert.Nil(t, getOption(m))
+
+		m.IsEdns0().Option = append(m.IsEdns0().Option, &dns.EDNS0_LOCAL{
+			Code: vutils.ManagedDNSGroupsOptionCode,
+			Data: []byte("admins"),
+		})
+		setManagedDNSGroupsOption(m, true, groups)
+		assert.Equal(t, []byte("dev,ops"), getOption(m).Data)
+		assert.Len(t, m.IsEdns0().Option, 1)
+	}
+}
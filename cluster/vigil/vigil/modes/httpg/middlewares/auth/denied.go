@@ -40,6 +40,12 @@ func (m *middleware) handleUnauthorized(w http.ResponseWriter, req *http.Request
 	w.Header().Set("X-Octelium-Unauthorized", "true")
 	svc := reqCtx.Service
 
+	isStepUpRequired := reqCtx.IsAuthenticated &&
+		reqCtx.DecisionReason.GetType() == corev1.AccessLog_Entry_Common_Reason_STEP_UP_REQUIRED
+	if isStepUpRequired {
+		w.Header().Set("X-Octelium-Step-Up-Required", "true")
+	}
+
 	isAnonymousAuthorizationMode := svc.Spec.IsAnonymous &&
 		svc.Spec.Authorization != nil &&
 		svc.Spec.Authorization.EnableAnonymous
@@ -146,6 +152,24 @@ func (m *middleware) handleUnauthorized(w http.ResponseWriter, req *http.Request
 			reqCtx.DownstreamInfo.User != nil &&
 			reqCtx.DownstreamInfo.User.Spec.Type == corev1.User_Spec_HUMAN {
 
+			if isStepUpRequired {
+				stepUpURL, err := url.Parse(fmt.Sprintf("https://%s/authenticators/authenticate", m.domain))
+				if err != nil {
+					w.WriteHeader(httpStatusCode)
+					return
+				}
+
+				q := stepUpURL.Query()
+				q.Set("stepUp", "true")
+				if svc.Spec.IsPublic {
+					q.Set("redirect", m.getRedirectURL(req, svc))
+				}
+				stepUpURL.RawQuery = q.Encode()
+
+				http.Redirect(w, req, stepUpURL.String(), http.StatusSeeOther)
+				return
+			}
+
 			http.Redirect(w, req, fmt.Sprintf("https://%s/denied", m.domain), http.StatusSeeOther)
 			return
 		}
@@ -161,26 +185,10 @@ func (m *middleware) handleUnauthorized(w http.ResponseWriter, req *http.Request
 
 				if svc.Spec.IsPublic {
 					q := loginURL.Query()
-
-					forwardedHost := req.Header.Get("X-Forwarded-Host")
-					if ucorev1.ToService(svc).IsManagedService() &&
-						svc.Status.ManagedService != nil &&
-						svc.Status.ManagedService.ForwardHost &&
-						(forwardedHost == m.domain || strings.HasSuffix(forwardedHost, "."+m.domain)) {
-
-						u := fmt.Sprintf("https://%s%s", forwardedHost, getDecodedPathWithQuery(req.URL))
-						zap.L().Debug("Adding redirect URL to login", zap.String("redirectURL", u))
-						q.Set("redirect", u)
-						loginURL.RawQuery = q.Encode()
-					} else {
-						u := fmt.Sprintf("https://%s%s",
-							vutils.GetServicePublicFQDN(svc, m.domain),
-							getDecodedPathWithQuery(req.URL))
-						zap.L().Debug("Adding redirect URL to login", zap.String("redirectURL", u))
-						q.Set("redirect", u)
-						loginURL.RawQuery = q.Encode()
-					}
-
+					u := m.getRedirectURL(req, svc)
+					zap.L().Debug("Adding redirect URL to login", zap.String("redirectURL", u))
+					q.Set("redirect", u)
+					loginURL.RawQuery = q.Encode()
 				}
 
 				lgnURL := loginURL.String()
@@ -195,6 +203,20 @@ func (m *middleware) handleUnauthorized(w http.ResponseWriter, req *http.Request
 
 }
 
+func (m *middleware) getRedirectURL(req *http.Request, svc *corev1.Service) string {
+	forwardedHost := req.Header.Get("X-Forwarded-Host")
+	if ucorev1.ToService(svc).IsManagedService() &&
+		svc.Status.ManagedService != nil &&
+		svc.Status.ManagedService.ForwardHost &&
+		(forwardedHost == m.domain || strings.HasSuffix(forwardedHost, "."+m.domain)) {
+		return fmt.Sprintf("https://%s%s", forwardedHost, getDecodedPathWithQuery(req.URL))
+	}
+
+	return fmt.Sprintf("https://%s%s",
+		vutils.GetServicePublicFQDN(svc, m.domain),
+		getDecodedPathWithQuery(req.URL))
+}
+
 func getDecodedPathWithQuery(u *url.URL) string {
 	if u.RawQuery == "" {
 		return u.Path
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/octelium/octelium/apis/main/corev1"
@@ -248,4 +249,65 @@ func TestHandleUnauthorized(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, rw.Code)
 		assert.Equal(t, "true", rw.Header().Get("X-Octelium-Unauthorized"))
 	}
+
+	{
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		rw := httptest.NewRecorder()
+		m.handleUnauthorized(rw, req, &middlewares.RequestContext{
+			IsAuthenticated: true,
+			DecisionReason: &corev1.AccessLog_Entry_Common_Reason{
+				Type: corev1.AccessLog_Entry_Common_Reason_STEP_UP_REQUIRED,
+			},
+			Service: &corev1.Service{
+				Spec: &corev1.Service_Spec{
+					IsPublic: true,
+					Mode:     corev1.Service_Spec_HTTP,
+				},
+			},
+		})
+
+		assert.Equal(t, http.StatusForbidden, rw.Code)
+		assert.Equal(t, "true", rw.Header().Get("X-Octelium-Step-Up-Required"))
+	}
+
+	{
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", "text/html")
+		req.Header.Set("user-agent",
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 12_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/12.0 Mobile/15E148 Safari/604.1")
+		rw := httptest.NewRecorder()
+		m.handleUnauthorized(rw, req, &middlewares.RequestContext{
+			IsAuthenticated: true,
+			DecisionReason: &corev1.AccessLog_Entry_Common_Reason{
+				Type: corev1.AccessLog_Entry_Common_Reason_STEP_UP_REQUIRED,
+			},
+			DownstreamInfo: &corev1.RequestContext{
+				User: &corev1.User{
+					Spec: &corev1.User_Spec{
+						Type: corev1.User_Spec_HUMAN,
+					},
+				},
+			},
+			Service: &corev1.Service{
+				Metadata: &metav1.Metadata{
+					Name: fmt.Sprintf("%s.default", utilrand.GetRandomStringCanonical(8)),
+				},
+				Spec: &corev1.Service_Spec{
+					IsPublic: true,
+					Mode:     corev1.Service_Spec_HTTP,
+				},
+				Status: &corev1.Service_Status{
+					NamespaceRef: &metav1.ObjectReference{
+						Name: "default",
+					},
+				},
+			},
+		})
+
+		assert.Equal(t, http.StatusSeeOther, rw.Code)
+		assert.Equal(t, "true", rw.Header().Get("X-Octelium-Step-Up-Required"))
+		assert.True(t, strings.HasPrefix(rw.Header().Get("Location"),
+			fmt.Sprintf("https://%s/authenticators/authenticate?", m.domain)))
+		assert.Contains(t, rw.Header().Get("Location"), "stepUp=true")
+	}
 }
@@ -29,6 +29,7 @@ import (
 
 	"github.com/octelium/octelium/apis/main/corev1"
 	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/cluster/common/jwkctl"
 	"github.com/octelium/octelium/cluster/common/k8sutils"
 	"github.com/octelium/octelium/cluster/common/vutils"
 	"github.com/octelium/octelium/cluster/vigil/vigil/modes/httpg/middlewares"
@@ -44,15 +45,26 @@ type middleware struct {
 	next      http.Handler
 	secretMan *secretman.SecretManager
 	celEngine *celengine.CELEngine
+
+	identityAssertionSigner identityAssertionSigner
+	domain                  string
 }
 
 func New(ctx context.Context,
-	next http.Handler, celEngine *celengine.CELEngine, secretMan *secretman.SecretManager) (http.Handler, error) {
-	return &middleware{
+	next http.Handler, celEngine *celengine.CELEngine, secretMan *secretman.SecretManager,
+	jwkCtl *jwkctl.Controller, domain string) (http.Handler, error) {
+	ret := &middleware{
 		next:      next,
 		secretMan: secretMan,
 		celEngine: celEngine,
-	}, nil
+		domain:    domain,
+	}
+
+	if jwkCtl != nil {
+		ret.identityAssertionSigner = jwkCtl
+	}
+
+	return ret, nil
 }
 
 func (m *middleware) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
@@ -289,6 +301,18 @@ func (m *middleware) setRequestHeaders(req *http.Request, reqCtx *middlewares.Re
 		}
 	}
 
+	if err := m.setIdentityAssertion(req, reqCtx); err != nil {
+		zap.L().Warn("Could not set identity assertion", zap.Error(err))
+	}
+
+	if isManagedSvc && svc.Spec.Mode == corev1.Service_Spec_RDP_WEB {
+		if val, err := getRDPConfigHeaderValue(svcCfg); err == nil {
+			req.Header.Set(rdpConfigHeader, val)
+		} else {
+			zap.L().Warn("Could not get RDP config header value", zap.Error(err))
+		}
+	}
+
 	if ucorev1.ToService(svc).IsKubernetes() && svcCfg != nil && svcCfg.GetKubernetes() != nil {
 		kCfg := svcCfg.GetKubernetes()
 		switch {
@@ -365,6 +389,26 @@ func (m *middleware) modifyResponseHeaders(rwHdr http.Header, req *http.Request,
 
 const octeliumHeaderPrefix = "x-octelium-"
 
+const rdpConfigHeader = "X-Octelium-Rdp-Config"
+
+// getRDPConfigHeaderValue returns the RDP controls of the Service config
+// selected for the request. The managed RDP gateway is only aware of the
+// Service's default config and relies on this header to enforce the controls
+// of the dynamic configs selected by Policies.
+func getRDPConfigHeaderValue(cfg *corev1.Service_Spec_Config) (string, error) {
+	rdp := cfg.GetRdp()
+	ret, err := pbutils.MarshalJSON(&corev1.Service_Spec_Config_RDP{
+		DisableClipboard:        rdp.GetDisableClipboard(),
+		DisableDriveRedirection: rdp.GetDisableDriveRedirection(),
+		Visibility:              rdp.GetVisibility(),
+	}, false)
+	if err != nil {
+		return "", err
+	}
+
+	return string(ret), nil
+}
+
 var managedServiceAllowedHeaders = map[string]struct{}{
 	"X-Octelium-Auth":          {},
 	"X-Octelium-Refresh-Token": {},
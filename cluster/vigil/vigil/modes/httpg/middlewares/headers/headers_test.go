@@ -63,7 +63,7 @@ func TestMiddleware(t *testing.T) {
 
 	celEngine, err := celengine.New(ctx, nil)
 	assert.Nil(t, err)
-	mdlwr, err := New(ctx, next, celEngine, secretMan)
+	mdlwr, err := New(ctx, next, celEngine, secretMan, nil, "")
 	assert.Nil(t, err)
 
 	{
@@ -831,6 +831,57 @@ func TestSessionHeadersAreSetByVigil(t *testing.T) {
 	assert.Equal(t, "/v1", req.Header.Get("X-Octelium-Req-Path"))
 }
 
+func TestRDPConfigHeaderIsSetByVigil(t *testing.T) {
+	{
+		reqCtx := newScrubReqCtx(true, false)
+		reqCtx.Service.Spec.Mode = corev1.Service_Spec_RDP_WEB
+		reqCtx.ServiceConfig = &corev1.Service_Spec_Config{
+			Type: &corev1.Service_Spec_Config_Rdp{
+				Rdp: &corev1.Service_Spec_Config_RDP{
+					DisableClipboard: true,
+					Auth: &corev1.Service_Spec_Config_RDP_Auth{
+						User: "admin",
+					},
+				},
+			},
+		}
+
+		req := newScrubRequest(t, reqCtx)
+		req.Header.Set(rdpConfigHeader, `{}`)
+
+		(&middleware{}).setRequestHeaders(req, reqCtx)
+
+		cfg := &corev1.Service_Spec_Config_RDP{}
+		assert.Nil(t, pbutils.UnmarshalJSON([]byte(req.Header.Get(rdpConfigHeader)), cfg))
+		assert.True(t, cfg.DisableClipboard)
+		assert.False(t, cfg.DisableDriveRedirection)
+		assert.Nil(t, cfg.Auth)
+	}
+
+	{
+		reqCtx := newScrubReqCtx(true, false)
+		reqCtx.Service.Spec.Mode = corev1.Service_Spec_RDP_WEB
+
+		req := newScrubRequest(t, reqCtx)
+		req.Header.Set(rdpConfigHeader, `{"disableClipboard":false}`)
+
+		(&middleware{}).setRequestHeaders(req, reqCtx)
+
+		assert.Equal(t, "{}", req.Header.Get(rdpConfigHeader))
+	}
+
+	{
+		reqCtx := newScrubReqCtx(true, false)
+
+		req := newScrubRequest(t, reqCtx)
+		req.Header.Set(rdpConfigHeader, `{}`)
+
+		(&middleware{}).setRequestHeaders(req, reqCtx)
+
+		assert.Equal(t, "", req.Header.Get(rdpConfigHeader))
+	}
+}
+
 func TestScrubIsCaseInsensitive(t *testing.T) {
 	reqCtx := newScrubReqCtx(false, false)
 	req := newScrubRequest(t, reqCtx)
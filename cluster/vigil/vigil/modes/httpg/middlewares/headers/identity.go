@@ -0,0 +1,167 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package headers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/vigil/vigil/modes/httpg/middlewares"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/pkg/errors"
+)
+
+const defaultIdentityAssertionHeader = "X-Octelium-Assertion"
+const defaultIdentityAssertionTTL = time.Minute
+
+type identityAssertionSigner interface {
+	SignJWT(claims any) (string, error)
+}
+
+type identityAssertionClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	Audience  string `json:"aud"`
+	IssuedAt  int64  `json:"iat"`
+	NotBefore int64  `json:"nbf"`
+	ExpiresAt int64  `json:"exp"`
+	ID        string `json:"jti"`
+
+	User    *identityAssertionUser    `json:"user"`
+	Groups  []string                  `json:"groups,omitempty"`
+	Session *identityAssertionObject  `json:"session"`
+	Device  *identityAssertionObject  `json:"device,omitempty"`
+	Service *identityAssertionService `json:"service"`
+}
+
+type identityAssertionUser struct {
+	UID   string `json:"uid"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	Type  string `json:"type"`
+}
+
+type identityAssertionObject struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+}
+
+type identityAssertionService struct {
+	UID       string `json:"uid"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+func getIdentityAssertionHeader(cfg *corev1.Service_Spec_Config_HTTP_IdentityAssertion) string {
+	if cfg.Header != "" {
+		return cfg.Header
+	}
+
+	return defaultIdentityAssertionHeader
+}
+
+// setIdentityAssertion attaches a signed JWT carrying the identity of the
+// requesting User so that the upstream does not have to trust the plain
+// X-Octelium-* headers. Any value sent by the downstream is always removed.
+func (m *middleware) setIdentityAssertion(req *http.Request, reqCtx *middlewares.RequestContext) error {
+	cfg := reqCtx.ServiceConfig.GetHttp().GetIdentityAssertion()
+	if cfg == nil {
+		return nil
+	}
+
+	hdr := getIdentityAssertionHeader(cfg)
+	req.Header.Del(hdr)
+
+	info := reqCtx.DownstreamInfo
+	if info == nil || info.User == nil || info.Session == nil {
+		return nil
+	}
+
+	if m.identityAssertionSigner == nil {
+		return errors.Errorf("No identity assertion signer")
+	}
+
+	claims := getIdentityAssertionClaims(reqCtx, cfg, m.domain, time.Now())
+
+	tkn, err := m.identityAssertionSigner.SignJWT(claims)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(hdr, tkn)
+
+	return nil
+}
+
+func getIdentityAssertionClaims(reqCtx *middlewares.RequestContext,
+	cfg *corev1.Service_Spec_Config_HTTP_IdentityAssertion, domain string, now time.Time) *identityAssertionClaims {
+	info := reqCtx.DownstreamInfo
+	svc := reqCtx.Service
+
+	ttl := defaultIdentityAssertionTTL
+	if cfg.Ttl != nil {
+		if d := umetav1.ToDuration(cfg.Ttl).ToGo(); d > 0 {
+			ttl = d
+		}
+	}
+
+	audience := cfg.Audience
+	if audience == "" {
+		audience = svc.Metadata.Name
+	}
+
+	ret := &identityAssertionClaims{
+		Issuer:    fmt.Sprintf("https://%s", domain),
+		Subject:   info.User.Metadata.Uid,
+		Audience:  audience,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+		ID:        uuid.New().String(),
+		User: &identityAssertionUser{
+			UID:   info.User.Metadata.Uid,
+			Name:  info.User.Metadata.Name,
+			Email: info.User.Spec.GetEmail(),
+			Type:  info.User.Spec.GetType().String(),
+		},
+		Session: &identityAssertionObject{
+			UID:  info.Session.Metadata.Uid,
+			Name: info.Session.Metadata.Name,
+		},
+		Service: &identityAssertionService{
+			UID:       svc.Metadata.Uid,
+			Name:      svc.Metadata.Name,
+			Namespace: svc.Status.GetNamespaceRef().GetName(),
+		},
+	}
+
+	for _, grp := range info.Groups {
+		ret.Groups = append(ret.Groups, grp.Metadata.Name)
+	}
+
+	if info.Device != nil {
+		ret.Device = &identityAssertionObject{
+			UID:  info.Device.Metadata.Uid,
+			Name: info.Device.Metadata.Name,
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package headers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAssertionSigner struct {
+	claims any
+	err    error
+}
+
+func (s *fakeAssertionSigner) SignJWT(claims any) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	s.claims = claims
+	return "signed", nil
+}
+
+func TestIdentityAssertion(t *testing.T) {
+	newReqCtx := func() *corev1.RequestContext {
+		return &corev1.RequestContext{
+			User: &corev1.User{
+				Metadata: &metav1.Metadata{Uid: "usr-uid", Name: "john"},
+				Spec:     &corev1.User_Spec{Email: "john@example.com", Type: corev1.User_Spec_HUMAN},
+			},
+			Groups: []*corev1.Group{
+				{Metadata: &metav1.Metadata{Name: "dev"}},
+				{Metadata: &metav1.Metadata{Name: "ops"}},
+			},
+			Session: &corev1.Session{
+				Metadata: &metav1.Metadata{Uid: "sess-uid", Name: "john-abcd"},
+			},
+		}
+	}
+
+	{
+		reqCtx := newScrubReqCtx(false, false)
+		req := newScrubRequest(t, reqCtx)
+		req.Header.Set(defaultIdentityAssertionHeader, "forged")
+
+		signer := &fakeAssertionSigner{}
+		(&middleware{identityAssertionSigner: signer}).setRequestHeaders(req, reqCtx)
+
+		assert.Nil(t, signer.claims)
+		assert.Equal(t, "", req.Header.Get(defaultIdentityAssertionHeader))
+	}
+
+	{
+		cfg := &corev1.Service_Spec_Config_HTTP_IdentityAssertion{}
+		reqCtx := newScrubReqCtx(false, false)
+		reqCtx.Service.Metadata.Uid = "svc-uid"
+		reqCtx.Service.Status.NamespaceRef = &metav1.ObjectReference{Name: "default"}
+		reqCtx.ServiceConfig = &corev1.Service_Spec_Config{
+			Type: &corev1.Service_Spec_Config_Http{
+				Http: &corev1.Service_Spec_Config_HTTP{IdentityAssertion: cfg},
+			},
+		}
+		reqCtx.DownstreamInfo = newReqCtx()
+
+		req := newScrubRequest(t, reqCtx)
+		req.Header.Set(defaultIdentityAssertionHeader, "forged")
+
+		signer := &fakeAssertionSigner{}
+		(&middleware{identityAssertionSigner: signer, domain: "example.com"}).setRequestHeaders(req, reqCtx)
+
+		assert.Equal(t, "signed", req.Header.Get(defaultIdentityAssertionHeader))
+
+		claims, ok := signer.claims.(*identityAssertionClaims)
+		assert.True(t, ok)
+		assert.Equal(t, "https://example.com", claims.Issuer)
+		assert.Equal(t, "usr-uid", claims.Subject)
+		assert.Equal(t, "tst.default", claims.Audience)
+		assert.Equal(t, int64(defaultIdentityAssertionTTL.Seconds()), claims.ExpiresAt-claims.IssuedAt)
+		assert.Equal(t, []string{"dev", "ops"}, claims.Groups)
+		assert.Equal(t, "HUMAN", claims.User.Type)
+		assert.Equal(t, "john@example.com", claims.User.Email)
+		assert.Equal(t, "sess-uid", claims.Session.UID)
+		assert.Nil(t, claims.Device)
+		assert.Equal(t, "default", claims.Service.Namespace)
+
+		b, err := json.Marshal(claims)
+		assert.Nil(t, err)
+		out := map[string]any{}
+		assert.Nil(t, json.Unmarshal(b, &out))
+		_, hasDevice := out["device"]
+		assert.False(t, hasDevice)
+	}
+
+	{
+		cfg := &corev1.Service_Spec_Config_HTTP_IdentityAssertion{
+			Header:   "X-Identity",
+			Audience: "my-app",
+			Ttl: &metav1.Duration{
+				Type: &metav1.Duration_Minutes{Minutes: 5},
+			},
+		}
+		reqCtx := newScrubReqCtx(false, false)
+		reqCtx.ServiceConfig = &corev1.Service_Spec_Config{
+			Type: &corev1.Service_Spec_Config_Http{
+				Http: &corev1.Service_Spec_Config_HTTP{IdentityAssertion: cfg},
+			},
+		}
+		reqCtx.DownstreamInfo = newReqCtx()
+		reqCtx.DownstreamInfo.Device = &corev1.Device{
+			Metadata: &metav1.Metadata{Uid: "dev-uid", Name: "laptop"},
+		}
+
+		claims := getIdentityAssertionClaims(reqCtx, cfg, "example.com", time.Unix(1000, 0))
+		assert.Equal(t, "my-app", claims.Audience)
+		assert.Equal(t, int64(1300), claims.ExpiresAt)
+		assert.Equal(t, "dev-uid", claims.Device.UID)
+
+		req := newScrubRequest(t, reqCtx)
+		req.Header.Set("X-Identity", "forged")
+		(&middleware{identityAssertionSigner: &fakeAssertionSigner{
+			err: errors.New("no key"),
+		}}).setRequestHeaders(req, reqCtx)
+		assert.Equal(t, "", req.Header.Get("X-Identity"))
+	}
+
+	{
+		cfg := &corev1.Service_Spec_Config_HTTP_IdentityAssertion{}
+		reqCtx := newScrubReqCtx(false, true)
+		reqCtx.ServiceConfig = &corev1.Service_Spec_Config{
+			Type: &corev1.Service_Spec_Config_Http{
+				Http: &corev1.Service_Spec_Config_HTTP{IdentityAssertion: cfg},
+			},
+		}
+
+		req := newScrubRequest(t, reqCtx)
+		signer := &fakeAssertionSigner{}
+		(&middleware{identityAssertionSigner: signer}).setRequestHeaders(req, reqCtx)
+		assert.Nil(t, signer.claims)
+		assert.Equal(t, "", req.Header.Get(defaultIdentityAssertionHeader))
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/octelium/octelium/apis/main/corev1"
 	"github.com/octelium/octelium/apis/rsc/rmetav1"
 	"github.com/octelium/octelium/cluster/common/celengine"
+	"github.com/octelium/octelium/cluster/common/jwkctl"
 	"github.com/octelium/octelium/cluster/common/ocrypto"
 	"github.com/octelium/octelium/cluster/common/octeliumc"
 	"github.com/octelium/octelium/cluster/common/vutils"
@@ -103,6 +104,8 @@ type Server struct {
 	svcUID string
 
 	transports *transportCache
+
+	identityAssertionJWKCtl *jwkctl.Controller
 }
 
 type metricsStore struct {
@@ -162,6 +165,13 @@ func New(ctx context.Context, opts *modes.Opts) (*Server, error) {
 
 	server.domain = cc.Status.Domain
 
+	server.identityAssertionJWKCtl, err = jwkctl.NewJWKController(ctx, server.octeliumC, &jwkctl.Opts{
+		IsIdentityAssertion: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return server, nil
 }
 
@@ -264,6 +274,10 @@ func (s *Server) Run(ctx context.Context) error {
 
 	s.transports.startSweepLoop(ctx)
 
+	if err := s.identityAssertionJWKCtl.Run(ctx); err != nil {
+		return err
+	}
+
 	if err := s.serve(ctx); err != nil {
 		return err
 	}
@@ -379,7 +393,7 @@ func (s *Server) getHTTPHandler(ctx context.Context, svc *corev1.Service) (http.
 	})
 
 	chain = chain.Append(func(next http.Handler) (http.Handler, error) {
-		return headers.New(ctx, next, s.celEngine, s.secretMan)
+		return headers.New(ctx, next, s.celEngine, s.secretMan, s.identityAssertionJWKCtl, s.domain)
 	})
 
 	chain = chain.Append(func(next http.Handler) (http.Handler, error) {
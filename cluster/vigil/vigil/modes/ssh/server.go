@@ -198,6 +198,22 @@ func (s *Server) Close() error {
 	return nil
 }
 
+// rejectStepUpRequired rejects the first channel opened by the client with a
+// message telling the User to authenticate with an Authenticator so that the
+// client shows the reason of the denial before the connection is closed
+func rejectStepUpRequired(reqs <-chan *ssh.Request, chans <-chan ssh.NewChannel) {
+	go ssh.DiscardRequests(reqs)
+
+	select {
+	case nch, ok := <-chans:
+		if ok && nch != nil {
+			nch.Reject(ssh.Prohibited,
+				"Octelium: step-up authentication required. Run `octelium auth authenticator` and retry")
+		}
+	case <-time.After(5 * time.Second):
+	}
+}
+
 func (s *Server) handleConn(ctx context.Context, c net.Conn) {
 	startTime := time.Now()
 
@@ -267,6 +283,10 @@ func (s *Server) handleConn(ctx context.Context, c net.Conn) {
 			attribute.String("state", "DENIED"),
 			attribute.String("reason", authResp.AuthorizationDecisionReason.GetType().String()),
 		)))
+		if authResp.AuthorizationDecisionReason.GetType() ==
+			corev1.AccessLog_Entry_Common_Reason_STEP_UP_REQUIRED {
+			rejectStepUpRequired(reqs, chans)
+		}
 		sshConn.Close()
 		c.Close()
 		return
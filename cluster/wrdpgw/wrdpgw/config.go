@@ -19,9 +19,13 @@ package wrdpgw
 import (
 	"context"
 	"encoding/hex"
+	"net/http"
 	"strings"
 
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/rsc/rmetav1"
 	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -130,3 +134,80 @@ func parseSHA256Pin(raw string) ([32]byte, error) {
 	copy(pin[:], decoded)
 	return pin, nil
 }
+
+const rdpConfigHeader = "X-Octelium-Rdp-Config"
+
+// getRDPControls returns the clipboard, drive redirection and visibility
+// controls of the request. Vigil sets them in the rdpConfigHeader from the
+// Service config selected by the Policies of the request and they fall back
+// to the Service's default config otherwise.
+func (s *server) getRDPControls(r *http.Request) (*corev1.Service_Spec_Config_RDP, error) {
+	if val := r.Header.Get(rdpConfigHeader); val != "" {
+		ret := &corev1.Service_Spec_Config_RDP{}
+		if err := pbutils.UnmarshalJSON([]byte(val), ret); err != nil {
+			return nil, errors.Errorf("invalid RDP config header: %+v", err)
+		}
+		return ret, nil
+	}
+
+	svc := s.vCache.GetService()
+	if svc == nil {
+		return nil, errors.Errorf("could not get Service from vcache")
+	}
+
+	if rdp := svc.Spec.GetConfig().GetRdp(); rdp != nil {
+		return rdp, nil
+	}
+
+	return &corev1.Service_Spec_Config_RDP{}, nil
+}
+
+func getBlockedChannels(rdp *corev1.Service_Spec_Config_RDP) []string {
+	var ret []string
+	if rdp.GetDisableClipboard() {
+		ret = append(ret, channelClipboard)
+	}
+	if rdp.GetDisableDriveRedirection() {
+		ret = append(ret, channelDeviceRedirection)
+	}
+	return ret
+}
+
+// getRequestContext builds the RequestContext used for the RDP AccessLogs
+// from the Session forwarded by Vigil
+func (s *server) getRequestContext(ctx context.Context, r *http.Request) (*corev1.RequestContext, error) {
+	svc := s.vCache.GetService()
+	if svc == nil {
+		return nil, errors.Errorf("could not get Service from vcache")
+	}
+
+	sessUID := r.Header.Get("X-Octelium-Session-Uid")
+	if sessUID == "" {
+		return nil, errors.Errorf("no Session UID header")
+	}
+
+	sess, err := s.octeliumC.CoreC().GetSession(ctx, &rmetav1.GetOptions{Uid: sessUID})
+	if err != nil {
+		return nil, err
+	}
+
+	usr, err := s.octeliumC.CoreC().GetUser(ctx, &rmetav1.GetOptions{Uid: sess.Status.UserRef.Uid})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &corev1.RequestContext{
+		Session: sess,
+		User:    usr,
+		Service: svc,
+	}
+
+	if sess.Status.DeviceRef != nil {
+		ret.Device, err = s.octeliumC.CoreC().GetDevice(ctx, &rmetav1.GetOptions{Uid: sess.Status.DeviceRef.Uid})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,287 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package wrdpgw
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	channelClipboard         = "cliprdr"
+	channelDeviceRedirection = "rdpdr"
+
+	gccBlockTypeCSNet   = 0xc003
+	channelDefLen       = 12
+	channelNameLen      = 8
+	maxPreambleLen      = 1024 * 1024
+	maxConnectInitalLen = 64 * 1024
+)
+
+var gccH221ClientKey = []byte("Duca")
+
+// tpktFrameLen returns the length of the TPKT PDU at the start of b
+func tpktFrameLen(b []byte) (int, bool) {
+	if len(b) < 4 {
+		return 0, false
+	}
+
+	return int(binary.BigEndian.Uint16(b[2:4])), true
+}
+
+// derFrameLen returns the length of the DER-encoded message (i.e. a CredSSP
+// TSRequest) at the start of b
+func derFrameLen(b []byte) (int, bool) {
+	if len(b) < 2 {
+		return 0, false
+	}
+
+	if b[1] < 0x80 {
+		return 2 + int(b[1]), true
+	}
+
+	numBytes := int(b[1] & 0x7f)
+	if numBytes == 0 || numBytes > 3 || len(b) < 2+numBytes {
+		return 0, false
+	}
+
+	l := 0
+	for _, c := range b[2 : 2+numBytes] {
+		l = l<<8 | int(c)
+	}
+
+	return 2 + numBytes + l, true
+}
+
+// filterMCSChannels renames the static virtual channels of the client network
+// data (i.e. CS_NET) of the MCS Connect Initial PDU whose names are in
+// blocked so that the RDP server does not bind them to their handlers. The
+// renamed channels are kept in place since removing them would shift the
+// channel IDs and require re-encoding the whole PDU. It returns the names of
+// the channels that were actually blocked.
+func filterMCSChannels(pdu []byte, blocked []string) ([]string, error) {
+	if len(pdu) < 7 || pdu[0] != tpktVersion || pdu[4] != 0x02 || pdu[5] != 0xf0 {
+		return nil, errors.Errorf("not an X.224 data PDU")
+	}
+
+	idx := bytes.Index(pdu[7:], gccH221ClientKey)
+	if idx < 0 {
+		return nil, errors.Errorf("could not find the GCC conference create request")
+	}
+
+	off := 7 + idx + len(gccH221ClientKey)
+	if off >= len(pdu) {
+		return nil, errors.Errorf("truncated GCC conference create request")
+	}
+
+	dataLen := int(pdu[off])
+	off++
+	if dataLen&0x80 != 0 {
+		if off >= len(pdu) {
+			return nil, errors.Errorf("truncated GCC user data length")
+		}
+		dataLen = (dataLen&0x7f)<<8 | int(pdu[off])
+		off++
+	}
+
+	if off+dataLen > len(pdu) {
+		return nil, errors.Errorf("invalid GCC user data length")
+	}
+
+	data := pdu[off : off+dataLen]
+
+	var ret []string
+	for i := 0; i < len(data); {
+		if i+4 > len(data) {
+			return nil, errors.Errorf("truncated client data block header")
+		}
+
+		blockType := binary.LittleEndian.Uint16(data[i : i+2])
+		blockLen := int(binary.LittleEndian.Uint16(data[i+2 : i+4]))
+		if blockLen < 4 || i+blockLen > len(data) {
+			return nil, errors.Errorf("invalid client data block length")
+		}
+
+		if blockType == gccBlockTypeCSNet {
+			block := data[i : i+blockLen]
+			if len(block) < 8 {
+				return nil, errors.Errorf("truncated client network data")
+			}
+
+			count := int(binary.LittleEndian.Uint32(block[4:8]))
+			if 8+count*channelDefLen > len(block) {
+				return nil, errors.Errorf("invalid client network data channel count")
+			}
+
+			for j := 0; j < count; j++ {
+				nameField := block[8+j*channelDefLen : 8+j*channelDefLen+channelNameLen]
+				name := string(bytes.TrimRight(nameField, "\x00"))
+				if !isBlockedChannel(name, blocked) {
+					continue
+				}
+
+				replacement := make([]byte, channelNameLen)
+				copy(replacement, fmt.Sprintf("octblk%d", len(ret)))
+				copy(nameField, replacement)
+				ret = append(ret, strings.ToLower(name))
+			}
+		}
+
+		i += blockLen
+	}
+
+	return ret, nil
+}
+
+func isBlockedChannel(name string, blocked []string) bool {
+	for _, itm := range blocked {
+		if strings.EqualFold(name, itm) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientStreamFilter processes the browser's stream up to and including the
+// MCS Connect Initial PDU, which is the first TPKT PDU after the optional
+// CredSSP exchange, and then passes the rest of the stream through as is
+type clientStreamFilter struct {
+	src io.Reader
+
+	rewriteSelectedProtocol uint32
+	blockedChannels         []string
+	onConnectInitial        func(blocked []string)
+
+	buf     []byte
+	pending []byte
+	done    bool
+}
+
+func (f *clientStreamFilter) Read(p []byte) (int, error) {
+	for !f.done && len(f.pending) == 0 {
+		if err := f.fill(); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(f.pending) > 0 {
+		n := copy(p, f.pending)
+		f.pending = f.pending[n:]
+		return n, nil
+	}
+
+	return f.src.Read(p)
+}
+
+func (f *clientStreamFilter) fill() error {
+	chunk := make([]byte, 32*1024)
+	n, err := f.src.Read(chunk)
+	f.buf = append(f.buf, chunk[:n]...)
+
+	if procErr := f.process(); procErr != nil {
+		return procErr
+	}
+
+	if err != nil && !f.done && len(f.pending) == 0 {
+		if len(f.buf) > 0 && len(f.blockedChannels) == 0 {
+			f.pending = f.buf
+			f.buf = nil
+			f.done = true
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (f *clientStreamFilter) process() error {
+	for len(f.buf) > 0 {
+		switch f.buf[0] {
+		case 0x30:
+			frameLen, ok := derFrameLen(f.buf)
+			if !ok || frameLen > len(f.buf) {
+				if len(f.buf) > maxPreambleLen {
+					return errors.Errorf("CredSSP message is too large")
+				}
+				return nil
+			}
+			f.pending = append(f.pending, f.buf[:frameLen]...)
+			f.buf = f.buf[frameLen:]
+		case tpktVersion:
+			frameLen, ok := tpktFrameLen(f.buf)
+			if !ok || frameLen > len(f.buf) {
+				if len(f.buf) > maxConnectInitalLen {
+					return errors.Errorf("MCS Connect Initial PDU is too large")
+				}
+				return nil
+			}
+
+			if err := f.processConnectInitial(f.buf[:frameLen]); err != nil {
+				return err
+			}
+
+			f.pending = append(f.pending, f.buf...)
+			f.buf = nil
+			f.done = true
+			return nil
+		default:
+			if len(f.blockedChannels) > 0 {
+				return errors.Errorf("unexpected PDU before the MCS Connect Initial: 0x%02x", f.buf[0])
+			}
+
+			f.pending = append(f.pending, f.buf...)
+			f.buf = nil
+			f.done = true
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (f *clientStreamFilter) processConnectInitial(pdu []byte) error {
+	if f.rewriteSelectedProtocol != 0 {
+		rewriteMCSSelectedProtocol(pdu, f.rewriteSelectedProtocol)
+	}
+
+	if len(f.blockedChannels) == 0 {
+		if f.onConnectInitial != nil {
+			f.onConnectInitial(nil)
+		}
+		return nil
+	}
+
+	blocked, err := filterMCSChannels(pdu, f.blockedChannels)
+	if err != nil {
+		return errors.Wrap(err, "could not filter MCS channels")
+	}
+
+	zap.L().Debug("wrdpgw filtered MCS channels", zap.Strings("blocked", blocked))
+
+	if f.onConnectInitial != nil {
+		f.onConnectInitial(blocked)
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package wrdpgw
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMCSChannels(t *testing.T) {
+	{
+		pdu, err := hex.DecodeString(mcsConnectInitialHex)
+		assert.Nil(t, err)
+		original := append([]byte(nil), pdu...)
+
+		blocked, err := filterMCSChannels(pdu, nil)
+		assert.Nil(t, err)
+		assert.Empty(t, blocked)
+		assert.Equal(t, original, pdu)
+	}
+
+	{
+		pdu, err := hex.DecodeString(mcsConnectInitialHex)
+		assert.Nil(t, err)
+
+		blocked, err := filterMCSChannels(pdu, []string{channelClipboard, channelDeviceRedirection})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{channelClipboard}, blocked)
+		assert.False(t, bytes.Contains(pdu, []byte("cliprdr")))
+		assert.True(t, bytes.Contains(pdu, []byte("octblk0\x00")))
+		assert.True(t, bytes.Contains(pdu, []byte("drdynvc")))
+	}
+
+	{
+		_, err := filterMCSChannels([]byte("invalid"), []string{channelClipboard})
+		assert.NotNil(t, err)
+	}
+
+	{
+		pdu, err := hex.DecodeString(mcsConnectInitialHex)
+		assert.Nil(t, err)
+		_, err = filterMCSChannels(pdu[:len(pdu)-20], []string{channelClipboard})
+		assert.NotNil(t, err)
+	}
+}
+
+func TestClientStreamFilter(t *testing.T) {
+	pdu, err := hex.DecodeString(mcsConnectInitialHex)
+	assert.Nil(t, err)
+
+	credSSP := []byte{0x30, 0x03, 0x01, 0x02, 0x03}
+	rest := []byte{0x03, 0x00, 0x00, 0x08, 0x02, 0xf0, 0x80, 0x04}
+
+	{
+		var in []byte
+		in = append(in, credSSP...)
+		in = append(in, pdu...)
+		in = append(in, rest...)
+
+		var blocked []string
+		f := &clientStreamFilter{
+			src:             io.MultiReader(bytes.NewReader(in[:3]), bytes.NewReader(in[3:100]), bytes.NewReader(in[100:])),
+			blockedChannels: []string{channelClipboard},
+			onConnectInitial: func(b []string) {
+				blocked = b
+			},
+		}
+
+		out, err := io.ReadAll(f)
+		assert.Nil(t, err)
+		assert.Equal(t, len(in), len(out))
+		assert.Equal(t, credSSP, out[:len(credSSP)])
+		assert.Equal(t, rest, out[len(out)-len(rest):])
+		assert.False(t, bytes.Contains(out, []byte("cliprdr")))
+		assert.Equal(t, []string{channelClipboard}, blocked)
+	}
+
+	{
+		f := &clientStreamFilter{
+			src:             bytes.NewReader([]byte("not an RDP stream")),
+			blockedChannels: []string{channelClipboard},
+		}
+
+		_, err := io.ReadAll(f)
+		assert.NotNil(t, err)
+	}
+
+	{
+		in := []byte("not an RDP stream")
+		f := &clientStreamFilter{
+			src: bytes.NewReader(in),
+		}
+
+		out, err := io.ReadAll(f)
+		assert.Nil(t, err)
+		assert.Equal(t, in, out)
+	}
+}
+
+func TestRecordingReader(t *testing.T) {
+	rec := newRecorder(&corev1.RequestContext{}, "id", false)
+
+	credSSP := []byte{0x30, 0x03, 0x01, 0x02, 0x03}
+	earlyUserAuthResult := []byte{0x00, 0x00, 0x00, 0x00}
+	output := []byte{0x03, 0x00, 0x00, 0x08, 0x02, 0xf0, 0x80, 0x04, 0x84, 0x05}
+
+	var in []byte
+	in = append(in, credSSP...)
+	in = append(in, earlyUserAuthResult...)
+	in = append(in, output...)
+
+	r := &recordingReader{
+		src:      io.MultiReader(bytes.NewReader(in[:2]), bytes.NewReader(in[2:7]), bytes.NewReader(in[7:])),
+		recorder: rec,
+	}
+
+	out, err := io.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, in, out)
+
+	close(rec.chunkCh)
+
+	var recorded []byte
+	for chunk := range rec.chunkCh {
+		recorded = append(recorded, chunk.data...)
+	}
+
+	assert.Equal(t, output, recorded)
+}
+
+func TestRecordingReaderFailure(t *testing.T) {
+	{
+		rec := newRecorder(&corev1.RequestContext{}, "id", false)
+		r := &recordingReader{
+			src:      bytes.NewReader([]byte{0x10, 0x20, 0x30, 0x40, 0x50}),
+			recorder: rec,
+		}
+
+		_, err := io.ReadAll(r)
+		assert.Equal(t, errRecordingFailed, err)
+
+		_, err = r.Read(make([]byte, 10))
+		assert.Equal(t, errRecordingFailed, err)
+	}
+
+	{
+		rec := newRecorder(&corev1.RequestContext{}, "id", true)
+		in := []byte{0x10, 0x20, 0x30, 0x40, 0x50}
+		r := &recordingReader{
+			src:      bytes.NewReader(in),
+			recorder: rec,
+		}
+
+		out, err := io.ReadAll(r)
+		assert.Nil(t, err)
+		assert.Equal(t, in, out)
+	}
+
+	{
+		rec := newRecorder(&corev1.RequestContext{}, "id", false)
+		rec.chunkCh = make(chan *recordingChunk, 1)
+
+		r := &recordingReader{
+			src: io.MultiReader(
+				bytes.NewReader([]byte{0x03, 0x00, 0x00, 0x04}),
+				bytes.NewReader([]byte{0x03, 0x00, 0x00, 0x05, 0x01}),
+				bytes.NewReader([]byte{0x03, 0x00, 0x00, 0x06, 0x01, 0x02})),
+			recorder: rec,
+		}
+
+		out, err := io.ReadAll(r)
+		assert.Equal(t, errRecordingFailed, err)
+		assert.Equal(t, []byte{0x03, 0x00, 0x00, 0x04}, out)
+		assert.True(t, rec.isFailed)
+		assert.Equal(t, uint64(5), rec.droppedBytes)
+
+		assert.Equal(t, errRecordingFailed, rec.write([]byte{0x01, 0x02}))
+		assert.Equal(t, uint64(7), rec.droppedBytes)
+	}
+}
+
+func TestGetBlockedChannels(t *testing.T) {
+	assert.Empty(t, getBlockedChannels(nil))
+	assert.Equal(t, []string{channelClipboard, channelDeviceRedirection},
+		getBlockedChannels(&corev1.Service_Spec_Config_RDP{
+			DisableClipboard:        true,
+			DisableDriveRedirection: true,
+		}))
+	assert.Equal(t, []string{channelDeviceRedirection},
+		getBlockedChannels(&corev1.Service_Spec_Config_RDP{
+			DisableDriveRedirection: true,
+		}))
+}
+
+func TestGetRDPControlsFromHeader(t *testing.T) {
+	s := &server{}
+
+	{
+		req := httptest.NewRequest("GET", "/ws", nil)
+		req.Header.Set(rdpConfigHeader, `{"disableClipboard":true,"visibility":{"enableSessionRecording":true}}`)
+
+		res, err := s.getRDPControls(req)
+		assert.Nil(t, err)
+		assert.True(t, res.DisableClipboard)
+		assert.False(t, res.DisableDriveRedirection)
+		assert.True(t, res.Visibility.EnableSessionRecording)
+	}
+
+	{
+		req := httptest.NewRequest("GET", "/ws", nil)
+		req.Header.Set(rdpConfigHeader, `not-json`)
+
+		_, err := s.getRDPControls(req)
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,408 @@
+/*
+ * Copyright Octelium Labs, LLC. All rights reserved.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License version 3,
+ * as published by the Free Software Foundation of the License.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package wrdpgw
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/cluster/common/otelutils"
+	"github.com/octelium/octelium/cluster/vigil/vigil/logentry"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	maxRDPRecordingChunk    = 64 * 1024
+	rdpRecordingFlushPeriod = 1 * time.Second
+	rdpRecordingWaitTimeout = 3 * time.Second
+)
+
+// errRecordingFailed is returned by the recordingReader once the session can
+// no longer be recorded so that the relay closes the session instead of
+// letting it continue unrecorded
+var errRecordingFailed = errors.New("RDP session recording failed")
+
+type recordingChunk struct {
+	createdAt time.Time
+	data      []byte
+}
+
+// recorder emits the RDP server's output stream (i.e. the graphics and the
+// rest of the server to client PDUs after the CredSSP exchange) as
+// AccessLog SESSION_RECORDING entries that can be ordered by their sequence
+// and replayed by an RDP client-side parser
+type recorder struct {
+	chunkCh   chan *recordingChunk
+	doneCh    chan struct{}
+	createdAt time.Time
+
+	reqCtx    *corev1.RequestContext
+	id        string
+	sessionID string
+
+	skipRecording bool
+
+	mu           sync.Mutex
+	isClosed     bool
+	isFailed     bool
+	droppedBytes uint64
+
+	sequence struct {
+		sync.Mutex
+		val int64
+	}
+}
+
+func newRecorder(reqCtx *corev1.RequestContext, id string, skipRecording bool) *recorder {
+	return &recorder{
+		createdAt:     time.Now(),
+		chunkCh:       make(chan *recordingChunk, 10000),
+		doneCh:        make(chan struct{}),
+		reqCtx:        reqCtx,
+		id:            id,
+		sessionID:     reqCtx.GetSession().GetMetadata().GetUid(),
+		skipRecording: skipRecording,
+	}
+}
+
+func (t *recorder) run(ctx context.Context) {
+	if t.skipRecording {
+		zap.L().Debug("RDP recording is disabled", zap.String("id", t.id))
+		close(t.doneCh)
+		return
+	}
+	go t.doRun(ctx)
+}
+
+func (t *recorder) doRun(ctx context.Context) {
+	defer close(t.doneCh)
+
+	defer func() {
+		zap.L().Debug("Exiting RDP recorder loop", zap.String("id", t.id))
+	}()
+
+	ticker := time.NewTicker(rdpRecordingFlushPeriod)
+	defer ticker.Stop()
+
+	var pending *recordingChunk
+
+	flush := func() {
+		if pending == nil {
+			return
+		}
+		t.setRecordLog(pending)
+		pending = nil
+	}
+
+	finish := func() {
+		flush()
+		t.setGapLog()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			finish()
+			return
+		case <-ticker.C:
+			flush()
+		case record, ok := <-t.chunkCh:
+			if !ok {
+				finish()
+				return
+			}
+
+			if pending == nil {
+				pending = &recordingChunk{
+					createdAt: record.createdAt,
+					data:      make([]byte, 0, maxRDPRecordingChunk),
+				}
+			}
+
+			pending.data = append(pending.data, record.data...)
+			if len(pending.data) >= maxRDPRecordingChunk {
+				flush()
+			}
+		}
+	}
+}
+
+// close stops accepting new chunks and waits for the pending ones to be emitted
+func (t *recorder) close() {
+	if t.skipRecording {
+		return
+	}
+
+	t.mu.Lock()
+	t.isClosed = true
+	close(t.chunkCh)
+	t.mu.Unlock()
+
+	select {
+	case <-t.doneCh:
+	case <-time.After(5 * time.Second):
+		zap.L().Debug("Timed out waiting for the RDP recorder to finish", zap.String("id", t.id))
+	}
+}
+
+func (t *recorder) getSequence() int64 {
+	t.sequence.Lock()
+	defer t.sequence.Unlock()
+	ret := t.sequence.val
+	t.sequence.val++
+	return ret
+}
+
+func (t *recorder) initLogEntry() *corev1.AccessLog {
+	return logentry.InitializeLogEntry(&logentry.InitializeLogEntryOpts{
+		StartTime:       t.createdAt,
+		IsAuthenticated: true,
+		IsAuthorized:    true,
+		ReqCtx:          t.reqCtx,
+		ConnectionID:    t.id,
+		SessionID:       t.sessionID,
+		Sequence:        t.getSequence(),
+	})
+}
+
+func (t *recorder) setRecordLog(record *recordingChunk) {
+	logE := t.initLogEntry()
+
+	offset := record.createdAt.Sub(t.createdAt).Milliseconds()
+	if offset < 0 {
+		offset = 0
+	}
+
+	logE.Entry.Info.Type = &corev1.AccessLog_Entry_Info_Rdp{
+		Rdp: &corev1.AccessLog_Entry_Info_RDP{
+			Type: corev1.AccessLog_Entry_Info_RDP_SESSION_RECORDING,
+			Details: &corev1.AccessLog_Entry_Info_RDP_SessionRecording_{
+				SessionRecording: &corev1.AccessLog_Entry_Info_RDP_SessionRecording{
+					Data:               record.data,
+					OffsetMilliseconds: uint64(offset),
+				},
+			},
+		},
+	}
+
+	otelutils.EmitAccessLog(logE)
+}
+
+// setGapLog emits a recording chunk without data that marks the bytes that
+// could not be recorded, if any, after the previously emitted chunks
+func (t *recorder) setGapLog() {
+	t.mu.Lock()
+	droppedBytes := t.droppedBytes
+	t.mu.Unlock()
+
+	if droppedBytes == 0 {
+		return
+	}
+
+	logE := t.initLogEntry()
+
+	offset := time.Since(t.createdAt).Milliseconds()
+
+	logE.Entry.Info.Type = &corev1.AccessLog_Entry_Info_Rdp{
+		Rdp: &corev1.AccessLog_Entry_Info_RDP{
+			Type: corev1.AccessLog_Entry_Info_RDP_SESSION_RECORDING,
+			Details: &corev1.AccessLog_Entry_Info_RDP_SessionRecording_{
+				SessionRecording: &corev1.AccessLog_Entry_Info_RDP_SessionRecording{
+					OffsetMilliseconds: uint64(offset),
+					DroppedBytes:       droppedBytes,
+				},
+			},
+		},
+	}
+
+	otelutils.EmitAccessLog(logE)
+}
+
+func (t *recorder) setSessionStartLog(blockedChannels []string) {
+	logE := t.initLogEntry()
+
+	logE.Entry.Info.Type = &corev1.AccessLog_Entry_Info_Rdp{
+		Rdp: &corev1.AccessLog_Entry_Info_RDP{
+			Type: corev1.AccessLog_Entry_Info_RDP_SESSION_START,
+			Details: &corev1.AccessLog_Entry_Info_RDP_SessionStart_{
+				SessionStart: &corev1.AccessLog_Entry_Info_RDP_SessionStart{
+					BlockedChannels: blockedChannels,
+					IsRecorded:      !t.skipRecording,
+				},
+			},
+		},
+	}
+
+	otelutils.EmitAccessLog(logE)
+}
+
+func (t *recorder) setSessionEndLog(receivedBytes, sentBytes uint64) {
+	logE := t.initLogEntry()
+
+	logE.Entry.Info.Type = &corev1.AccessLog_Entry_Info_Rdp{
+		Rdp: &corev1.AccessLog_Entry_Info_RDP{
+			Type: corev1.AccessLog_Entry_Info_RDP_SESSION_END,
+			Details: &corev1.AccessLog_Entry_Info_RDP_SessionEnd_{
+				SessionEnd: &corev1.AccessLog_Entry_Info_RDP_SessionEnd{
+					ReceivedBytes: receivedBytes,
+					SentBytes:     sentBytes,
+				},
+			},
+		},
+	}
+
+	otelutils.EmitAccessLog(logE)
+}
+
+// write queues a chunk of the stream to be recorded. Since the session must
+// not continue unrecorded, it returns errRecordingFailed if the recorder cannot
+// keep up with the stream, in which case the chunk as well as the rest of the
+// stream are counted as dropped and marked as a gap in the recording
+func (t *recorder) write(b []byte) error {
+	if t.skipRecording || len(b) == 0 {
+		return nil
+	}
+
+	cp := make([]byte, len(b))
+	copy(cp, b)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.isClosed {
+		return nil
+	}
+
+	if t.isFailed {
+		t.droppedBytes += uint64(len(cp))
+		return errRecordingFailed
+	}
+
+	chunk := &recordingChunk{
+		createdAt: time.Now(),
+		data:      cp,
+	}
+
+	select {
+	case t.chunkCh <- chunk:
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(rdpRecordingWaitTimeout)
+	defer timer.Stop()
+
+	select {
+	case t.chunkCh <- chunk:
+		return nil
+	case <-timer.C:
+		zap.L().Warn("RDP recording channel full, closing the session",
+			zap.String("id", t.id), zap.Int("droppedBytes", len(cp)))
+		t.isFailed = true
+		t.droppedBytes += uint64(len(cp))
+		return errRecordingFailed
+	}
+}
+
+// recordingReader tees the server's output stream to the recorder skipping
+// the preamble that precedes the MCS Connect Response PDU, namely the
+// CredSSP messages and the optional Early User Authorization Result PDU,
+// since they are not needed to replay the session and the former might
+// carry credential material
+type recordingReader struct {
+	src      io.Reader
+	recorder *recorder
+
+	buf       []byte
+	recording bool
+	isFailed  bool
+}
+
+func (r *recordingReader) Read(p []byte) (int, error) {
+	if r.isFailed {
+		return 0, errRecordingFailed
+	}
+
+	n, err := r.src.Read(p)
+	if n > 0 {
+		if pErr := r.process(p[:n]); pErr != nil {
+			r.isFailed = true
+			return 0, pErr
+		}
+	}
+	return n, err
+}
+
+func (r *recordingReader) process(b []byte) error {
+	if r.recorder.skipRecording {
+		return nil
+	}
+
+	if r.isFailed {
+		return errRecordingFailed
+	}
+
+	if r.recording {
+		return r.recorder.write(b)
+	}
+
+	r.buf = append(r.buf, b...)
+
+	for len(r.buf) > 0 {
+		switch {
+		case r.buf[0] == tpktVersion:
+			r.recording = true
+			buf := r.buf
+			r.buf = nil
+			return r.recorder.write(buf)
+		case r.buf[0] == 0x30:
+			frameLen, ok := derFrameLen(r.buf)
+			if !ok || frameLen > len(r.buf) {
+				if len(r.buf) > maxPreambleLen {
+					return r.stopRecording()
+				}
+				return nil
+			}
+			r.buf = r.buf[frameLen:]
+		case len(r.buf) < 4:
+			return nil
+		case isEarlyUserAuthResult(r.buf[:4]):
+			r.buf = r.buf[4:]
+		default:
+			return r.stopRecording()
+		}
+	}
+
+	return nil
+}
+
+// stopRecording closes the session since the stream cannot be recorded
+// without finding its start
+func (r *recordingReader) stopRecording() error {
+	zap.L().Warn("Could not find the start of the RDP server output stream, closing the session",
+		zap.String("id", r.recorder.id))
+	r.buf = nil
+	r.isFailed = true
+	return errRecordingFailed
+}
+
+func isEarlyUserAuthResult(b []byte) bool {
+	return len(b) == 4 && (b[0] == 0x00 || b[0] == 0x05) && b[1] == 0x00 && b[2] == 0x00 && b[3] == 0x00
+}
@@ -389,6 +389,27 @@ func (s *server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		zap.Int("clientX224Length", len(rdcpReq.X224ConnectionPDU)),
 		zap.String("clientX224Hex", fmt.Sprintf("%x", rdcpReq.X224ConnectionPDU)))
 
+	rdpControls, err := s.getRDPControls(r)
+	if err != nil {
+		zap.L().Debug("Could not resolve wrdpgw RDP controls", zap.Error(err))
+		writeRDCleanPathError(ctx, ws, encodeRDCleanPathGeneralError())
+		ws.Close(websocket.StatusInternalError, "could not resolve RDP controls")
+		return
+	}
+
+	var rec *recorder
+	if reqCtx, err := s.getRequestContext(ctx, r); err == nil {
+		rec = newRecorder(reqCtx, vutils.GenerateLogID(),
+			!rdpControls.GetVisibility().GetEnableSessionRecording())
+	} else if rdpControls.GetVisibility().GetEnableSessionRecording() {
+		zap.L().Warn("Could not get RequestContext for RDP session recording", zap.Error(err))
+		writeRDCleanPathError(ctx, ws, encodeRDCleanPathHTTPError(http.StatusForbidden))
+		ws.Close(websocket.StatusPolicyViolation, "could not start RDP session recording")
+		return
+	} else {
+		zap.L().Debug("Could not get RequestContext for RDP AccessLogs", zap.Error(err))
+	}
+
 	cred, err := s.getInjectedCredential(ctx)
 	if err != nil {
 		zap.L().Debug("Could not resolve wrdpgw injected credential", zap.Error(err))
@@ -492,12 +513,25 @@ func (s *server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		zap.String("upstream", upstream.HostPort),
 		zap.Bool("secretless", cred != nil))
 
-	var rewriteSelectedProtocol uint32
+	opts := &relayOpts{
+		blockedChannels: getBlockedChannels(rdpControls),
+		recorder:        rec,
+	}
 	if cred != nil {
-		rewriteSelectedProtocol = protocolHybrid
+		opts.rewriteSelectedProtocol = protocolHybrid
+	}
+
+	if rec != nil {
+		rec.run(ctx)
+		opts.onConnectInitial = rec.setSessionStartLog
 	}
 
-	recvBytes, sentBytes := relay(ctx, wsConn, handshake.TLSConn, rewriteSelectedProtocol)
+	recvBytes, sentBytes := relay(ctx, wsConn, handshake.TLSConn, opts)
+
+	if rec != nil {
+		rec.close()
+		rec.setSessionEndLog(recvBytes, sentBytes)
+	}
 
 	zap.L().Debug("wrdpgw session ended",
 		zap.String("remoteAddr", r.RemoteAddr),
@@ -562,21 +596,6 @@ func (r *firstChunkLogger) Read(p []byte) (int, error) {
 	return n, err
 }
 
-type mcsSelectedProtocolRewriter struct {
-	src       io.Reader
-	target    uint32
-	rewritten bool
-}
-
-func (r *mcsSelectedProtocolRewriter) Read(p []byte) (int, error) {
-	n, err := r.src.Read(p)
-	if n > 0 && !r.rewritten {
-		r.rewritten = true
-		rewriteMCSSelectedProtocol(p[:n], r.target)
-	}
-	return n, err
-}
-
 func rewriteMCSSelectedProtocol(buf []byte, target uint32) {
 	const (
 		coreHeaderLen       = 4
@@ -623,20 +642,33 @@ func rewriteMCSSelectedProtocol(buf []byte, target uint32) {
 	zap.L().Debug("wrdpgw did not find MCS CS_CORE to rewrite serverSelectedProtocol")
 }
 
-func relay(ctx context.Context, downstream net.Conn, upstream net.Conn, rewriteSelectedProtocol uint32) (uint64, uint64) {
+type relayOpts struct {
+	rewriteSelectedProtocol uint32
+	blockedChannels         []string
+	onConnectInitial        func(blocked []string)
+	recorder                *recorder
+}
+
+func relay(ctx context.Context, downstream net.Conn, upstream net.Conn, opts *relayOpts) (uint64, uint64) {
 	resCh := make(chan copyResult, 2)
 
-	var downstreamSrc io.Reader = &firstChunkLogger{src: downstream, direction: "downstream_to_upstream"}
-	if rewriteSelectedProtocol != 0 {
-		downstreamSrc = &mcsSelectedProtocolRewriter{
-			src:    downstreamSrc,
-			target: rewriteSelectedProtocol,
+	downstreamSrc := &clientStreamFilter{
+		src:                     &firstChunkLogger{src: downstream, direction: "downstream_to_upstream"},
+		rewriteSelectedProtocol: opts.rewriteSelectedProtocol,
+		blockedChannels:         opts.blockedChannels,
+		onConnectInitial:        opts.onConnectInitial,
+	}
+
+	var upstreamSrc io.Reader = &firstChunkLogger{src: upstream, direction: "upstream_to_downstream"}
+	if opts.recorder != nil {
+		upstreamSrc = &recordingReader{
+			src:      upstreamSrc,
+			recorder: opts.recorder,
 		}
 	}
 
 	go copyConn(resCh, "downstream_to_upstream", upstream, downstreamSrc)
-	go copyConn(resCh, "upstream_to_downstream", downstream,
-		&firstChunkLogger{src: upstream, direction: "upstream_to_downstream"})
+	go copyConn(resCh, "upstream_to_downstream", downstream, upstreamSrc)
 
 	first := <-resCh
 
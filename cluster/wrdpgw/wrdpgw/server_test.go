@@ -182,7 +182,7 @@ func TestRelay(t *testing.T) {
 
 	resCh := make(chan [2]uint64, 1)
 	go func() {
-		recv, sent := relay(ctx, downstream, upstreamConn, 0)
+		recv, sent := relay(ctx, downstream, upstreamConn, &relayOpts{})
 		resCh <- [2]uint64{recv, sent}
 	}()
 
@@ -274,9 +274,10 @@ func TestWebSocketRejectsInvalidRDCleanPath(t *testing.T) {
 	assert.Equal(t, websocket.StatusUnsupportedData, websocket.CloseStatus(closeErr))
 }
 
-func TestRewriteMCSSelectedProtocol(t *testing.T) {
-	const mcsConnectInitialHex = "0300019f02f0807f658201930401010401010101ff301a020122020102020100020101020100020101020300ffff0201023019020101020101020101020101020100020101020204200201023020020300ffff020300fc17020300ffff020101020100020101020300ffff0201020482012d000500147c00018122000800100001c00044756361811601c0ea000400080040061a0301ca03aa0000000000000000690072006f006e007200640070002d007700650062000000000000000000000004000000000000000c0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001ca01000000000010000f0029080000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000006000100000000000000000000000000000000000000000002c00c00000000000000000003c0200002000000636c69707264720000008000647264796e76630000008000"
+// mcsConnectInitialHex is a Connect Initial PDU sent by the IronRDP web client
+const mcsConnectInitialHex = "0300019f02f0807f658201930401010401010101ff301a020122020102020100020101020100020101020300ffff0201023019020101020101020101020101020100020101020204200201023020020300ffff020300fc17020300ffff020101020100020101020300ffff0201020482012d000500147c00018122000800100001c00044756361811601c0ea000400080040061a0301ca03aa0000000000000000690072006f006e007200640070002d007700650062000000000000000000000004000000000000000c0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001ca01000000000010000f0029080000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000006000100000000000000000000000000000000000000000002c00c00000000000000000003c0200002000000636c69707264720000008000647264796e76630000008000"
 
+func TestRewriteMCSSelectedProtocol(t *testing.T) {
 	const fieldOffset = 349
 
 	buf, err := hex.DecodeString(mcsConnectInitialHex)
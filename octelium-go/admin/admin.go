@@ -0,0 +1,152 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin provides a typed client for the Cluster management API.
+//
+// It wraps corev1.MainServiceClient with per-kind CRUD helpers that page
+// through List calls, retry updates that lose an optimistic concurrency race,
+// wait for Resources to reach a given state and emit change events by
+// polling. Apply reconciles a set of desired Resources with the Cluster the
+// same way octeliumctl apply does.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	octelium "github.com/octelium/octelium/octelium-go"
+	"google.golang.org/grpc"
+)
+
+var (
+	ErrUnsupportedOperation = errors.New("octelium: operation is not supported by the Resource kind")
+	ErrUnknownKind          = errors.New("octelium: unknown Resource kind")
+	ErrTooManyConflicts     = errors.New("octelium: Resource kept changing during the update")
+)
+
+const (
+	defaultItemsPerPage = 100
+	defaultMaxRetries   = 8
+	defaultPollInterval = 5 * time.Second
+	minPollInterval     = 500 * time.Millisecond
+)
+
+type config struct {
+	maxRetries   int
+	pollInterval time.Duration
+	logger       *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config) error
+
+// WithMaxRetries sets how many times Mutate retries an update that failed
+// because the Resource changed concurrently. The default is 8.
+func WithMaxRetries(n int) Option {
+	return func(c *config) error {
+		if n < 1 {
+			return fmt.Errorf("octelium: max retries must be positive")
+		}
+		c.maxRetries = n
+		return nil
+	}
+}
+
+// WithPollInterval sets the default interval used by Watch and WaitFor. The
+// default is 5 seconds.
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *config) error {
+		if interval < minPollInterval {
+			return fmt.Errorf("octelium: poll interval must be at least %s", minPollInterval)
+		}
+		c.pollInterval = interval
+		return nil
+	}
+}
+
+// WithLogger sets the logger used for retries and polling failures.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) error {
+		if logger == nil {
+			return fmt.Errorf("octelium: nil logger")
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// Client is a typed management client. It is safe for concurrent use.
+type Client struct {
+	cfg  *config
+	core corev1.MainServiceClient
+}
+
+// New creates a management Client that uses the authenticated gRPC connection
+// of c. The Session of c must belong to a User allowed to use the Cluster
+// management API.
+func New(ctx context.Context, c *octelium.Client, opts ...Option) (*Client, error) {
+	if c == nil {
+		return nil, fmt.Errorf("octelium: nil client")
+	}
+
+	conn, err := c.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFromConn(conn, opts...)
+}
+
+// NewFromConn creates a management Client over an existing connection to the
+// Cluster API.
+func NewFromConn(conn grpc.ClientConnInterface, opts ...Option) (*Client, error) {
+	if conn == nil {
+		return nil, fmt.Errorf("octelium: nil connection")
+	}
+
+	return newClient(corev1.NewMainServiceClient(conn), opts...)
+}
+
+func newClient(core corev1.MainServiceClient, opts ...Option) (*Client, error) {
+	cfg := &config{
+		maxRetries:   defaultMaxRetries,
+		pollInterval: defaultPollInterval,
+		logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Client{
+		cfg:  cfg,
+		core: core,
+	}, nil
+}
+
+// Core returns the underlying generated client for calls that are not
+// covered by the typed helpers, such as kind-specific List filters.
+func (c *Client) Core() corev1.MainServiceClient {
+	return c.core
+}
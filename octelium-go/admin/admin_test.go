@@ -0,0 +1,376 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeCore stores Users in memory. Calls to other methods panic through the
+// nil embedded interface.
+type fakeCore struct {
+	corev1.MainServiceClient
+
+	mu        sync.Mutex
+	users     map[string]*corev1.User
+	version   int
+	conflicts int
+	creates   int
+	updates   int
+	deletes   int
+}
+
+func newFakeCore(names ...string) *fakeCore {
+	ret := &fakeCore{users: make(map[string]*corev1.User)}
+	for _, name := range names {
+		ret.put(newUser(name, corev1.User_Spec_HUMAN))
+	}
+	return ret
+}
+
+func newUser(name string, typ corev1.User_Spec_Type) *corev1.User {
+	return &corev1.User{
+		Kind:     ucorev1.KindUser,
+		Metadata: &metav1.Metadata{Name: name},
+		Spec:     &corev1.User_Spec{Type: typ},
+	}
+}
+
+func (f *fakeCore) put(usr *corev1.User) *corev1.User {
+	f.version++
+	usr = proto.Clone(usr).(*corev1.User)
+	if usr.Metadata.Uid == "" {
+		usr.Metadata.Uid = "uid-" + usr.Metadata.Name
+	}
+	usr.Metadata.ResourceVersion = fmt.Sprintf("v%d", f.version)
+	f.users[usr.Metadata.Name] = usr
+	return proto.Clone(usr).(*corev1.User)
+}
+
+func (f *fakeCore) GetUser(_ context.Context, in *metav1.GetOptions, _ ...grpc.CallOption) (*corev1.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, usr := range f.users {
+		if usr.Metadata.Name == in.Name || usr.Metadata.Uid == in.Uid {
+			return proto.Clone(usr).(*corev1.User), nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
+func (f *fakeCore) ListUser(_ context.Context, in *corev1.ListUserOptions, _ ...grpc.CallOption) (*corev1.UserList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var names []string
+	for name := range f.users {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Use a page size of 2 regardless of the request to exercise paging.
+	start := int(in.Common.Page) * 2
+	end := min(start+2, len(names))
+	ret := &corev1.UserList{
+		ListResponseMeta: &metav1.ListResponseMeta{
+			Page:    in.Common.Page,
+			HasMore: end < len(names),
+		},
+	}
+	for _, name := range names[min(start, end):end] {
+		ret.Items = append(ret.Items, proto.Clone(f.users[name]).(*corev1.User))
+	}
+	return ret, nil
+}
+
+func (f *fakeCore) CreateUser(_ context.Context, in *corev1.User, _ ...grpc.CallOption) (*corev1.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if in.Spec == nil {
+		return nil, status.Error(codes.InvalidArgument, "nil spec")
+	}
+	if _, ok := f.users[in.Metadata.Name]; ok {
+		return nil, status.Error(codes.AlreadyExists, "exists")
+	}
+	f.creates++
+	return f.put(in), nil
+}
+
+func (f *fakeCore) UpdateUser(_ context.Context, in *corev1.User, _ ...grpc.CallOption) (*corev1.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cur, ok := f.users[in.Metadata.Name]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	if f.conflicts > 0 {
+		f.conflicts--
+		f.put(cur)
+		return nil, status.Error(codes.OutOfRange, "changed")
+	}
+	if in.Metadata.ResourceVersion != "" && in.Metadata.ResourceVersion != cur.Metadata.ResourceVersion {
+		return nil, status.Error(codes.OutOfRange, "changed")
+	}
+	f.updates++
+	upd := proto.Clone(cur).(*corev1.User)
+	upd.Spec = in.Spec
+	upd.Metadata.Description = in.Metadata.Description
+	return f.put(upd), nil
+}
+
+func (f *fakeCore) DeleteUser(_ context.Context, in *metav1.DeleteOptions, _ ...grpc.CallOption) (*metav1.OperationResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for name, usr := range f.users {
+		if usr.Metadata.Name == in.Name || usr.Metadata.Uid == in.Uid {
+			delete(f.users, name)
+			f.deletes++
+			return &metav1.OperationResult{}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
+func newTestClient(t *testing.T, core *fakeCore) *Client {
+	t.Helper()
+	ret, err := newClient(core, WithPollInterval(minPollInterval))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func TestList(t *testing.T) {
+	c := newTestClient(t, newFakeCore("usr1", "usr2", "usr3", "usr4", "usr5"))
+
+	items, err := c.Users().List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 5 {
+		t.Fatalf("expected 5 Users, got %d", len(items))
+	}
+
+	if _, err := c.Regions().Create(context.Background(), &corev1.Region{}); !errors.Is(err, ErrUnsupportedOperation) {
+		t.Fatalf("expected ErrUnsupportedOperation, got %v", err)
+	}
+}
+
+func TestGetOrCreate(t *testing.T) {
+	ctx := context.Background()
+	core := newFakeCore("usr1")
+	c := newTestClient(t, core)
+
+	usr, created, err := c.Users().GetOrCreate(ctx, newUser("usr1", corev1.User_Spec_WORKLOAD))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created || usr.Spec.Type != corev1.User_Spec_HUMAN {
+		t.Fatalf("expected the existing User")
+	}
+
+	_, created, err = c.Users().GetOrCreate(ctx, newUser("usr2", corev1.User_Spec_WORKLOAD))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created || core.creates != 1 {
+		t.Fatalf("expected the User to be created")
+	}
+}
+
+func TestMutate(t *testing.T) {
+	ctx := context.Background()
+	core := newFakeCore("usr1")
+	core.conflicts = 2
+	c := newTestClient(t, core)
+
+	calls := 0
+	usr, err := c.Users().Mutate(ctx, "usr1", func(usr *corev1.User) error {
+		calls++
+		usr.Metadata.Description = "updated"
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 || usr.Metadata.Description != "updated" {
+		t.Fatalf("unexpected result after %d calls: %v", calls, usr)
+	}
+
+	updates := core.updates
+	if _, err := c.Users().Mutate(ctx, "usr1", func(usr *corev1.User) error {
+		usr.Metadata.Description = "updated"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if core.updates != updates {
+		t.Fatalf("unexpected update of an unchanged User")
+	}
+
+	core.conflicts = 100
+	c.cfg.maxRetries = 2
+	if _, err := c.Users().Mutate(ctx, "usr1", func(usr *corev1.User) error {
+		usr.Metadata.Description = "conflict"
+		return nil
+	}); !errors.Is(err, ErrTooManyConflicts) {
+		t.Fatalf("expected ErrTooManyConflicts, got %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	core := newFakeCore("usr1", "usr2", "usr3")
+	c := newTestClient(t, core)
+
+	changed := newUser("usr2", corev1.User_Spec_WORKLOAD)
+	desired := []umetav1.ResourceObjectI{
+		newUser("usr1", corev1.User_Spec_HUMAN),
+		changed,
+		newUser("usr4", corev1.User_Spec_HUMAN),
+		&corev1.User{Kind: ucorev1.KindUser, Metadata: &metav1.Metadata{Name: "invalid"}},
+	}
+
+	res, err := c.Apply(ctx, desired, WithPrune(), WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Created) != 2 || len(res.Updated) != 1 || len(res.Deleted) != 1 {
+		t.Fatalf("unexpected dry-run result: %+v", res)
+	}
+	if core.creates+core.updates+core.deletes != 0 {
+		t.Fatalf("dry-run changed the Cluster")
+	}
+
+	res, err = c.Apply(ctx, desired, WithPrune())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Created) != 1 || len(res.Updated) != 1 || len(res.Deleted) != 1 || len(res.Failed) != 1 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if res.Updated[0].Current.GetMetadata().GetName() != "usr2" {
+		t.Fatalf("unexpected updated Resource")
+	}
+
+	res, err = c.Apply(ctx, desired[:3], WithPrune())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.HasChanges() {
+		t.Fatalf("unexpected changes on a second apply: %+v", res)
+	}
+
+	if _, err := c.Apply(ctx, []umetav1.ResourceObjectI{&corev1.Session{Kind: ucorev1.KindSession}}); !errors.Is(err, ErrUnknownKind) {
+		t.Fatalf("expected ErrUnknownKind, got %v", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	core := newFakeCore("usr1", "usr2")
+	c := newTestClient(t, core)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	events := make(chan Event[*corev1.User], 16)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.Users().Watch(ctx, func(ev Event[*corev1.User]) error {
+			events <- ev
+			return nil
+		}, WithSkipExisting())
+	}()
+
+	// Let the initial poll happen before changing anything.
+	time.Sleep(100 * time.Millisecond)
+
+	core.mu.Lock()
+	core.put(newUser("usr3", corev1.User_Spec_HUMAN))
+	core.put(newUser("usr1", corev1.User_Spec_WORKLOAD))
+	delete(core.users, "usr2")
+	core.mu.Unlock()
+
+	got := make(map[string]EventType)
+	for len(got) < 3 {
+		select {
+		case ev := <-events:
+			got[ev.Object.Metadata.Name] = ev.Type
+		case <-ctx.Done():
+			t.Fatalf("timed out with events: %v", got)
+		}
+	}
+
+	if got["usr3"] != EventAdded || got["usr1"] != EventModified || got["usr2"] != EventDeleted {
+		t.Fatalf("unexpected events: %v", got)
+	}
+
+	cancel()
+	if err := <-errCh; !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	core := newFakeCore()
+	c := newTestClient(t, core)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		core.mu.Lock()
+		core.put(newUser("usr1", corev1.User_Spec_HUMAN))
+		core.mu.Unlock()
+
+		time.Sleep(600 * time.Millisecond)
+		core.mu.Lock()
+		core.put(newUser("usr1", corev1.User_Spec_WORKLOAD))
+		core.mu.Unlock()
+	}()
+
+	usr, err := c.Users().WaitFor(ctx, "usr1", func(usr *corev1.User) (bool, error) {
+		return usr.Spec.Type == corev1.User_Spec_WORKLOAD, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usr.Spec.Type != corev1.User_Spec_WORKLOAD {
+		t.Fatalf("unexpected User: %v", usr)
+	}
+
+	core.mu.Lock()
+	delete(core.users, "usr1")
+	core.mu.Unlock()
+	if err := c.Users().WaitForDeletion(ctx, "usr1"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/objdiff"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// applyKinds is the order in which Apply reconciles Resource kinds so that
+// referenced Resources exist before the ones referring to them.
+var applyKinds = []string{
+	ucorev1.KindSecret,
+	ucorev1.KindConfig,
+	ucorev1.KindPolicy,
+	ucorev1.KindIdentityProvider,
+	ucorev1.KindNamespace,
+	ucorev1.KindGroup,
+	ucorev1.KindUser,
+	ucorev1.KindService,
+	ucorev1.KindCredential,
+}
+
+type applyConfig struct {
+	prune  bool
+	dryRun bool
+	kinds  []string
+}
+
+// ApplyOption configures Apply.
+type ApplyOption func(*applyConfig)
+
+// WithPrune makes Apply delete the Resources of the applied kinds that exist
+// in the Cluster but not in the desired Resources. System Resources are never
+// deleted.
+func WithPrune() ApplyOption {
+	return func(c *applyConfig) {
+		c.prune = true
+	}
+}
+
+// WithDryRun makes Apply compute and return the changes without performing
+// them.
+func WithDryRun() ApplyOption {
+	return func(c *applyConfig) {
+		c.dryRun = true
+	}
+}
+
+// WithKinds restricts Apply to the given Resource kinds. By default, Apply
+// reconciles the kinds present in the desired Resources. Listing a kind that
+// has no desired Resources together with WithPrune deletes all its non-System
+// Resources.
+func WithKinds(kinds ...string) ApplyOption {
+	return func(c *applyConfig) {
+		c.kinds = kinds
+	}
+}
+
+// ApplyError reports a Resource that the Cluster refused to create, update
+// or delete, for example because it is invalid.
+type ApplyError struct {
+	Op       string
+	Resource umetav1.ResourceObjectI
+	Err      error
+}
+
+func (e *ApplyError) Error() string {
+	return fmt.Sprintf("octelium: could not %s %s %s: %v",
+		e.Op, e.Resource.GetKind(), e.Resource.GetMetadata().GetName(), e.Err)
+}
+
+func (e *ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyResult lists the changes performed by Apply, or the ones it would
+// perform in dry-run mode.
+type ApplyResult struct {
+	Created []umetav1.ResourceObjectI
+	Updated []*objdiff.UpdateItem
+	Deleted []umetav1.ResourceObjectI
+
+	// ClusterConfigUpdated reports whether the ClusterConfig spec was changed.
+	ClusterConfigUpdated bool
+
+	// Failed lists the Resources rejected by the Cluster. Apply goes on with
+	// the remaining Resources after such failures.
+	Failed []*ApplyError
+}
+
+// HasChanges reports whether anything was, or would be, changed.
+func (r *ApplyResult) HasChanges() bool {
+	return len(r.Created) > 0 || len(r.Updated) > 0 || len(r.Deleted) > 0 || r.ClusterConfigUpdated
+}
+
+// Apply reconciles the Cluster with the desired Resources. Resources are
+// matched by name: missing ones are created and the ones whose metadata or
+// spec differ are updated. A ClusterConfig among the desired Resources
+// replaces the current ClusterConfig spec when it differs.
+//
+// Apply returns an error and stops at the first failure that is not caused by
+// a specific Resource, such as a network or permission error.
+func (c *Client) Apply(ctx context.Context, desired []umetav1.ResourceObjectI, opts ...ApplyOption) (*ApplyResult, error) {
+	cfg := &applyConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+
+	kinds, err := getApplyKinds(desired, cfg.kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &ApplyResult{}
+
+	for _, kind := range kinds {
+		if err := c.applyKind(ctx, kind, desired, cfg, ret); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, itm := range desired {
+		cc, ok := itm.(*corev1.ClusterConfig)
+		if !ok {
+			continue
+		}
+
+		if err := c.applyClusterConfig(ctx, cc, cfg, ret); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+func getApplyKinds(desired []umetav1.ResourceObjectI, requested []string) ([]string, error) {
+	wanted := make(map[string]bool)
+	if len(requested) > 0 {
+		for _, kind := range requested {
+			wanted[kind] = true
+		}
+	} else {
+		for _, itm := range desired {
+			if itm.GetKind() != ucorev1.KindClusterConfig {
+				wanted[itm.GetKind()] = true
+			}
+		}
+	}
+
+	var ret []string
+	for _, kind := range applyKinds {
+		if wanted[kind] {
+			ret = append(ret, kind)
+			delete(wanted, kind)
+		}
+	}
+
+	for kind := range wanted {
+		return nil, fmt.Errorf("%w: %s cannot be applied", ErrUnknownKind, kind)
+	}
+
+	return ret, nil
+}
+
+func (c *Client) applyKind(ctx context.Context, kind string,
+	desired []umetav1.ResourceObjectI, cfg *applyConfig, ret *ApplyResult) error {
+	ops, err := c.kindOps(kind)
+	if err != nil {
+		return err
+	}
+
+	var desiredItems []umetav1.ResourceObjectI
+	for _, itm := range desired {
+		if itm.GetKind() == kind {
+			desiredItems = append(desiredItems, itm)
+		}
+	}
+
+	var currentItems []umetav1.ResourceObjectI
+	if err := ops.eachAny(ctx, func(itm umetav1.ResourceObjectI) error {
+		if !itm.GetMetadata().GetIsSystem() {
+			currentItems = append(currentItems, itm)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	diff := objdiff.Compute(ucorev1.API, desiredItems, currentItems)
+
+	handle := func(op string, itm umetav1.ResourceObjectI, err error) (bool, error) {
+		switch {
+		case err == nil:
+			return true, nil
+		case isResourceError(err):
+			ret.Failed = append(ret.Failed, &ApplyError{Op: op, Resource: itm, Err: err})
+			return false, nil
+		default:
+			return false, &ApplyError{Op: op, Resource: itm, Err: err}
+		}
+	}
+
+	for _, itm := range diff.Create {
+		if !cfg.dryRun {
+			if ok, err := handle("create", itm, ops.createAny(ctx, itm)); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+		}
+		ret.Created = append(ret.Created, itm)
+	}
+
+	for _, itm := range diff.Update {
+		if !cfg.dryRun {
+			if ok, err := handle("update", itm.Desired, ops.updateAny(ctx, itm.Desired)); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+		}
+		ret.Updated = append(ret.Updated, itm)
+	}
+
+	if !cfg.prune {
+		return nil
+	}
+
+	for _, itm := range diff.Delete {
+		if !cfg.dryRun {
+			err := ops.deleteAny(ctx, itm)
+			if grpcerr.IsNotFound(err) {
+				continue
+			}
+			if ok, err := handle("delete", itm, err); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+		}
+		ret.Deleted = append(ret.Deleted, itm)
+	}
+
+	return nil
+}
+
+func (c *Client) applyClusterConfig(ctx context.Context,
+	desired *corev1.ClusterConfig, cfg *applyConfig, ret *ApplyResult) error {
+	cur, err := c.GetClusterConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if proto.Equal(cur.Spec, desired.Spec) {
+		return nil
+	}
+
+	if !cfg.dryRun {
+		if _, err := c.core.UpdateClusterConfig(ctx, desired); err != nil {
+			if isResourceError(err) {
+				ret.Failed = append(ret.Failed, &ApplyError{Op: "update", Resource: desired, Err: err})
+				return nil
+			}
+			return err
+		}
+	}
+
+	ret.ClusterConfigUpdated = true
+	return nil
+}
+
+func isResourceError(err error) bool {
+	return grpcerr.IsInvalidArg(err) || grpcerr.IsNotFound(err) ||
+		grpcerr.AlreadyExists(err) || grpcerr.IsResourceChanged(err)
+}
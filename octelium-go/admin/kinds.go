@@ -0,0 +1,320 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"google.golang.org/grpc"
+)
+
+// Users returns the typed operations for Users.
+func (c *Client) Users() *Resources[*corev1.User] {
+	return &Resources[*corev1.User]{
+		c:    c,
+		kind: ucorev1.KindUser,
+		get:  c.core.GetUser,
+		list: toListFunc[*corev1.User](c.core.ListUser,
+			func(common *metav1.CommonListOptions) *corev1.ListUserOptions {
+				return &corev1.ListUserOptions{Common: common}
+			}),
+		create: c.core.CreateUser,
+		update: c.core.UpdateUser,
+		delete: c.core.DeleteUser,
+	}
+}
+
+// Groups returns the typed operations for Groups.
+func (c *Client) Groups() *Resources[*corev1.Group] {
+	return &Resources[*corev1.Group]{
+		c:    c,
+		kind: ucorev1.KindGroup,
+		get:  c.core.GetGroup,
+		list: toListFunc[*corev1.Group](c.core.ListGroup,
+			func(common *metav1.CommonListOptions) *corev1.ListGroupOptions {
+				return &corev1.ListGroupOptions{Common: common}
+			}),
+		create: c.core.CreateGroup,
+		update: c.core.UpdateGroup,
+		delete: c.core.DeleteGroup,
+	}
+}
+
+// Namespaces returns the typed operations for Namespaces.
+func (c *Client) Namespaces() *Resources[*corev1.Namespace] {
+	return &Resources[*corev1.Namespace]{
+		c:    c,
+		kind: ucorev1.KindNamespace,
+		get:  c.core.GetNamespace,
+		list: toListFunc[*corev1.Namespace](c.core.ListNamespace,
+			func(common *metav1.CommonListOptions) *corev1.ListNamespaceOptions {
+				return &corev1.ListNamespaceOptions{Common: common}
+			}),
+		create: c.core.CreateNamespace,
+		update: c.core.UpdateNamespace,
+		delete: c.core.DeleteNamespace,
+	}
+}
+
+// Services returns the typed operations for Services.
+// Service names without a Namespace refer to the default Namespace.
+func (c *Client) Services() *Resources[*corev1.Service] {
+	return &Resources[*corev1.Service]{
+		c:    c,
+		kind: ucorev1.KindService,
+		get:  c.core.GetService,
+		list: toListFunc[*corev1.Service](c.core.ListService,
+			func(common *metav1.CommonListOptions) *corev1.ListServiceOptions {
+				return &corev1.ListServiceOptions{Common: common}
+			}),
+		create: c.core.CreateService,
+		update: c.core.UpdateService,
+		delete: c.core.DeleteService,
+	}
+}
+
+// Policies returns the typed operations for Policies.
+func (c *Client) Policies() *Resources[*corev1.Policy] {
+	return &Resources[*corev1.Policy]{
+		c:    c,
+		kind: ucorev1.KindPolicy,
+		get:  c.core.GetPolicy,
+		list: toListFunc[*corev1.Policy](c.core.ListPolicy,
+			func(common *metav1.CommonListOptions) *corev1.ListPolicyOptions {
+				return &corev1.ListPolicyOptions{Common: common}
+			}),
+		create: c.core.CreatePolicy,
+		update: c.core.UpdatePolicy,
+		delete: c.core.DeletePolicy,
+	}
+}
+
+// Secrets returns the typed operations for Secrets.
+// The Cluster never returns the data of a Secret. Updates must always set it.
+func (c *Client) Secrets() *Resources[*corev1.Secret] {
+	return &Resources[*corev1.Secret]{
+		c:    c,
+		kind: ucorev1.KindSecret,
+		get:  c.core.GetSecret,
+		list: toListFunc[*corev1.Secret](c.core.ListSecret,
+			func(common *metav1.CommonListOptions) *corev1.ListSecretOptions {
+				return &corev1.ListSecretOptions{Common: common}
+			}),
+		create: c.core.CreateSecret,
+		update: c.core.UpdateSecret,
+		delete: c.core.DeleteSecret,
+	}
+}
+
+// Configs returns the typed operations for Configs.
+// The Cluster never returns the data of a Config. Updates must always set it.
+func (c *Client) Configs() *Resources[*corev1.Config] {
+	return &Resources[*corev1.Config]{
+		c:    c,
+		kind: ucorev1.KindConfig,
+		get:  c.core.GetConfig,
+		list: toListFunc[*corev1.Config](c.core.ListConfig,
+			func(common *metav1.CommonListOptions) *corev1.ListConfigOptions {
+				return &corev1.ListConfigOptions{Common: common}
+			}),
+		create: c.core.CreateConfig,
+		update: c.core.UpdateConfig,
+		delete: c.core.DeleteConfig,
+	}
+}
+
+// Credentials returns the typed operations for Credentials.
+func (c *Client) Credentials() *Resources[*corev1.Credential] {
+	return &Resources[*corev1.Credential]{
+		c:    c,
+		kind: ucorev1.KindCredential,
+		get:  c.core.GetCredential,
+		list: toListFunc[*corev1.Credential](c.core.ListCredential,
+			func(common *metav1.CommonListOptions) *corev1.ListCredentialOptions {
+				return &corev1.ListCredentialOptions{Common: common}
+			}),
+		create: c.core.CreateCredential,
+		update: c.core.UpdateCredential,
+		delete: c.core.DeleteCredential,
+	}
+}
+
+// IdentityProviders returns the typed operations for IdentityProviders.
+func (c *Client) IdentityProviders() *Resources[*corev1.IdentityProvider] {
+	return &Resources[*corev1.IdentityProvider]{
+		c:    c,
+		kind: ucorev1.KindIdentityProvider,
+		get:  c.core.GetIdentityProvider,
+		list: toListFunc[*corev1.IdentityProvider](c.core.ListIdentityProvider,
+			func(common *metav1.CommonListOptions) *corev1.ListIdentityProviderOptions {
+				return &corev1.ListIdentityProviderOptions{Common: common}
+			}),
+		create: c.core.CreateIdentityProvider,
+		update: c.core.UpdateIdentityProvider,
+		delete: c.core.DeleteIdentityProvider,
+	}
+}
+
+// Sessions returns the typed operations for Sessions.
+// Sessions are created by authentication and cannot be created here.
+func (c *Client) Sessions() *Resources[*corev1.Session] {
+	return &Resources[*corev1.Session]{
+		c:    c,
+		kind: ucorev1.KindSession,
+		get:  c.core.GetSession,
+		list: toListFunc[*corev1.Session](c.core.ListSession,
+			func(common *metav1.CommonListOptions) *corev1.ListSessionOptions {
+				return &corev1.ListSessionOptions{Common: common}
+			}),
+		update: c.core.UpdateSession,
+		delete: c.core.DeleteSession,
+	}
+}
+
+// Devices returns the typed operations for Devices.
+// Devices are registered by their clients and cannot be created here.
+func (c *Client) Devices() *Resources[*corev1.Device] {
+	return &Resources[*corev1.Device]{
+		c:    c,
+		kind: ucorev1.KindDevice,
+		get:  c.core.GetDevice,
+		list: toListFunc[*corev1.Device](c.core.ListDevice,
+			func(common *metav1.CommonListOptions) *corev1.ListDeviceOptions {
+				return &corev1.ListDeviceOptions{Common: common}
+			}),
+		update: c.core.UpdateDevice,
+		delete: c.core.DeleteDevice,
+	}
+}
+
+// Authenticators returns the typed operations for Authenticators.
+// Authenticators are registered by their Users and cannot be created here.
+func (c *Client) Authenticators() *Resources[*corev1.Authenticator] {
+	return &Resources[*corev1.Authenticator]{
+		c:    c,
+		kind: ucorev1.KindAuthenticator,
+		get:  c.core.GetAuthenticator,
+		list: toListFunc[*corev1.Authenticator](c.core.ListAuthenticator,
+			func(common *metav1.CommonListOptions) *corev1.ListAuthenticatorOptions {
+				return &corev1.ListAuthenticatorOptions{Common: common}
+			}),
+		update: c.core.UpdateAuthenticator,
+		delete: c.core.DeleteAuthenticator,
+	}
+}
+
+// AccessRequests returns the typed operations for AccessRequests.
+// Use Core to approve or deny AccessRequests.
+func (c *Client) AccessRequests() *Resources[*corev1.AccessRequest] {
+	return &Resources[*corev1.AccessRequest]{
+		c:    c,
+		kind: ucorev1.KindAccessRequest,
+		get:  c.core.GetAccessRequest,
+		list: toListFunc[*corev1.AccessRequest](c.core.ListAccessRequest,
+			func(common *metav1.CommonListOptions) *corev1.ListAccessRequestOptions {
+				return &corev1.ListAccessRequestOptions{Common: common}
+			}),
+		delete: c.core.DeleteAccessRequest,
+	}
+}
+
+// Regions returns the typed operations for Regions.
+// Regions are read-only.
+func (c *Client) Regions() *Resources[*corev1.Region] {
+	return &Resources[*corev1.Region]{
+		c:    c,
+		kind: ucorev1.KindRegion,
+		get:  c.core.GetRegion,
+		list: toListFunc[*corev1.Region](c.core.ListRegion,
+			func(common *metav1.CommonListOptions) *corev1.ListRegionOptions {
+				return &corev1.ListRegionOptions{Common: common}
+			}),
+	}
+}
+
+// Gateways returns the typed operations for Gateways.
+// Gateways are read-only.
+func (c *Client) Gateways() *Resources[*corev1.Gateway] {
+	return &Resources[*corev1.Gateway]{
+		c:    c,
+		kind: ucorev1.KindGateway,
+		get:  c.core.GetGateway,
+		list: toListFunc[*corev1.Gateway](c.core.ListGateway,
+			func(common *metav1.CommonListOptions) *corev1.ListGatewayOptions {
+				return &corev1.ListGatewayOptions{Common: common}
+			}),
+	}
+}
+
+func (c *Client) kindOps(kind string) (anyResources, error) {
+	switch kind {
+	case ucorev1.KindUser:
+		return c.Users(), nil
+	case ucorev1.KindGroup:
+		return c.Groups(), nil
+	case ucorev1.KindNamespace:
+		return c.Namespaces(), nil
+	case ucorev1.KindService:
+		return c.Services(), nil
+	case ucorev1.KindPolicy:
+		return c.Policies(), nil
+	case ucorev1.KindSecret:
+		return c.Secrets(), nil
+	case ucorev1.KindConfig:
+		return c.Configs(), nil
+	case ucorev1.KindCredential:
+		return c.Credentials(), nil
+	case ucorev1.KindIdentityProvider:
+		return c.IdentityProviders(), nil
+	case ucorev1.KindSession:
+		return c.Sessions(), nil
+	case ucorev1.KindDevice:
+		return c.Devices(), nil
+	case ucorev1.KindAuthenticator:
+		return c.Authenticators(), nil
+	case ucorev1.KindAccessRequest:
+		return c.AccessRequests(), nil
+	case ucorev1.KindRegion:
+		return c.Regions(), nil
+	case ucorev1.KindGateway:
+		return c.Gateways(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+}
+
+// GetClusterConfig returns the ClusterConfig.
+func (c *Client) GetClusterConfig(ctx context.Context) (*corev1.ClusterConfig, error) {
+	return c.core.GetClusterConfig(ctx, &corev1.GetClusterConfigRequest{})
+}
+
+// MutateClusterConfig applies fn to the ClusterConfig and updates it, retrying
+// the same way as Resources.Mutate when the ClusterConfig changed concurrently.
+func (c *Client) MutateClusterConfig(ctx context.Context, fn func(*corev1.ClusterConfig) error) (*corev1.ClusterConfig, error) {
+	r := &Resources[*corev1.ClusterConfig]{
+		c:    c,
+		kind: ucorev1.KindClusterConfig,
+		get: func(ctx context.Context, _ *metav1.GetOptions, opts ...grpc.CallOption) (*corev1.ClusterConfig, error) {
+			return c.core.GetClusterConfig(ctx, &corev1.GetClusterConfigRequest{}, opts...)
+		},
+		update: c.core.UpdateClusterConfig,
+	}
+
+	return r.Mutate(ctx, "default", fn)
+}
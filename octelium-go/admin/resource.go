@@ -0,0 +1,314 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/grpcerr"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+type getFunc[T any] func(context.Context, *metav1.GetOptions, ...grpc.CallOption) (T, error)
+type writeFunc[T any] func(context.Context, T, ...grpc.CallOption) (T, error)
+type deleteFunc func(context.Context, *metav1.DeleteOptions, ...grpc.CallOption) (*metav1.OperationResult, error)
+type listFunc[T any] func(context.Context, *metav1.CommonListOptions) ([]T, *metav1.ListResponseMeta, error)
+
+type listResponse[T any] interface {
+	GetItems() []T
+	GetListResponseMeta() *metav1.ListResponseMeta
+}
+
+// toListFunc adapts a generated List method to a listFunc.
+func toListFunc[T any, O any, L listResponse[T]](
+	fn func(context.Context, O, ...grpc.CallOption) (L, error),
+	newOpts func(*metav1.CommonListOptions) O) listFunc[T] {
+	return func(ctx context.Context, common *metav1.CommonListOptions) ([]T, *metav1.ListResponseMeta, error) {
+		res, err := fn(ctx, newOpts(common))
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.GetItems(), res.GetListResponseMeta(), nil
+	}
+}
+
+// Resources provides typed operations for a single Resource kind. Operations
+// that the Cluster does not support for the kind, for example creating a
+// Session, return ErrUnsupportedOperation.
+type Resources[T umetav1.ResourceObjectI] struct {
+	c    *Client
+	kind string
+
+	get    getFunc[T]
+	list   listFunc[T]
+	create writeFunc[T]
+	update writeFunc[T]
+	delete deleteFunc
+}
+
+func (r *Resources[T]) unsupported(op string) error {
+	return fmt.Errorf("%w: %s %s", ErrUnsupportedOperation, op, r.kind)
+}
+
+// Kind returns the Resource kind, for example "User".
+func (r *Resources[T]) Kind() string {
+	return r.kind
+}
+
+// Get returns the Resource with the given name.
+func (r *Resources[T]) Get(ctx context.Context, name string) (T, error) {
+	return r.doGet(ctx, &metav1.GetOptions{Name: name})
+}
+
+// GetByUID returns the Resource with the given UID.
+func (r *Resources[T]) GetByUID(ctx context.Context, uid string) (T, error) {
+	return r.doGet(ctx, &metav1.GetOptions{Uid: uid})
+}
+
+func (r *Resources[T]) doGet(ctx context.Context, req *metav1.GetOptions) (T, error) {
+	var zero T
+	if r.get == nil {
+		return zero, r.unsupported("get")
+	}
+	return r.get(ctx, req)
+}
+
+// ListPage returns a single page of Resources along with the page metadata.
+func (r *Resources[T]) ListPage(ctx context.Context, opts *metav1.CommonListOptions) ([]T, *metav1.ListResponseMeta, error) {
+	if r.list == nil {
+		return nil, nil, r.unsupported("list")
+	}
+	return r.list(ctx, opts)
+}
+
+// List returns all the Resources of the kind, following every page.
+func (r *Resources[T]) List(ctx context.Context) ([]T, error) {
+	var ret []T
+	err := r.Each(ctx, func(itm T) error {
+		ret = append(ret, itm)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Each calls fn for every Resource of the kind, page by page. It stops at the
+// first error returned by fn.
+func (r *Resources[T]) Each(ctx context.Context, fn func(T) error) error {
+	for page := uint32(0); ; page++ {
+		items, meta, err := r.ListPage(ctx, &metav1.CommonListOptions{
+			Page:         page,
+			ItemsPerPage: defaultItemsPerPage,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, itm := range items {
+			if err := fn(itm); err != nil {
+				return err
+			}
+		}
+
+		if !meta.GetHasMore() || len(items) == 0 {
+			return nil
+		}
+	}
+}
+
+// Create creates the Resource and returns it as stored by the Cluster.
+func (r *Resources[T]) Create(ctx context.Context, itm T) (T, error) {
+	var zero T
+	if r.create == nil {
+		return zero, r.unsupported("create")
+	}
+	return r.create(ctx, itm)
+}
+
+// Update replaces the spec and the user-settable metadata of the Resource. If
+// the metadata of itm carries a resourceVersion, the update fails with a
+// ResourceChanged error when the Resource has changed since it was read.
+func (r *Resources[T]) Update(ctx context.Context, itm T) (T, error) {
+	var zero T
+	if r.update == nil {
+		return zero, r.unsupported("update")
+	}
+	return r.update(ctx, itm)
+}
+
+// Delete deletes the Resource with the given name.
+func (r *Resources[T]) Delete(ctx context.Context, name string) error {
+	return r.doDelete(ctx, &metav1.DeleteOptions{Name: name})
+}
+
+// DeleteByUID deletes the Resource with the given UID.
+func (r *Resources[T]) DeleteByUID(ctx context.Context, uid string) error {
+	return r.doDelete(ctx, &metav1.DeleteOptions{Uid: uid})
+}
+
+func (r *Resources[T]) doDelete(ctx context.Context, req *metav1.DeleteOptions) error {
+	if r.delete == nil {
+		return r.unsupported("delete")
+	}
+	_, err := r.delete(ctx, req)
+	return err
+}
+
+// GetOrCreate returns the existing Resource with the name of itm, or creates
+// itm if there is none. The returned bool reports whether itm was created.
+func (r *Resources[T]) GetOrCreate(ctx context.Context, itm T) (T, bool, error) {
+	var zero T
+	name := itm.GetMetadata().GetName()
+
+	for {
+		cur, err := r.Get(ctx, name)
+		switch {
+		case err == nil:
+			return cur, false, nil
+		case !grpcerr.IsNotFound(err):
+			return zero, false, err
+		}
+
+		ret, err := r.Create(ctx, itm)
+		switch {
+		case err == nil:
+			return ret, true, nil
+		case grpcerr.AlreadyExists(err):
+			// Created concurrently. Get it again.
+			if err := ctx.Err(); err != nil {
+				return zero, false, err
+			}
+			continue
+		default:
+			return zero, false, err
+		}
+	}
+}
+
+// Mutate reads the Resource, applies fn to it and updates it. The update is
+// conditional on the resourceVersion that was read, and the whole cycle is
+// retried with a backoff whenever the Resource changed in between. fn may
+// therefore be called more than once and must only modify its argument.
+func (r *Resources[T]) Mutate(ctx context.Context, name string, fn func(T) error) (T, error) {
+	var zero T
+
+	for attempt := 0; ; attempt++ {
+		cur, err := r.Get(ctx, name)
+		if err != nil {
+			return zero, err
+		}
+
+		itm := proto.Clone(cur).(T)
+		if err := fn(itm); err != nil {
+			return zero, err
+		}
+
+		if proto.Equal(itm, cur) {
+			return cur, nil
+		}
+
+		itm.GetMetadata().ResourceVersion = cur.GetMetadata().GetResourceVersion()
+
+		ret, err := r.Update(ctx, itm)
+		switch {
+		case err == nil:
+			return ret, nil
+		case !grpcerr.IsResourceChanged(err):
+			return zero, err
+		case attempt+1 >= r.c.cfg.maxRetries:
+			return zero, fmt.Errorf("%w: %s %s: %w", ErrTooManyConflicts, r.kind, name, err)
+		}
+
+		r.c.cfg.logger.Debug("Resource changed during the update. Retrying",
+			"kind", r.kind, "name", name, "attempt", attempt+1)
+
+		if err := sleepCtx(ctx, conflictBackoff(attempt)); err != nil {
+			return zero, err
+		}
+	}
+}
+
+func conflictBackoff(attempt int) time.Duration {
+	base := 50 * time.Millisecond << min(attempt, 5)
+	return base/2 + rand.N(base/2+1)
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// anyResources is a Resources with the type parameter erased, used to
+// operate on Resources of a kind only known at runtime.
+type anyResources interface {
+	eachAny(ctx context.Context, fn func(umetav1.ResourceObjectI) error) error
+	createAny(ctx context.Context, itm umetav1.ResourceObjectI) error
+	updateAny(ctx context.Context, itm umetav1.ResourceObjectI) error
+	deleteAny(ctx context.Context, itm umetav1.ResourceObjectI) error
+}
+
+func (r *Resources[T]) eachAny(ctx context.Context, fn func(umetav1.ResourceObjectI) error) error {
+	return r.Each(ctx, func(itm T) error {
+		return fn(itm)
+	})
+}
+
+func (r *Resources[T]) toTyped(itm umetav1.ResourceObjectI) (T, error) {
+	ret, ok := itm.(T)
+	if !ok {
+		return ret, fmt.Errorf("octelium: unexpected %T for the %s kind", itm, r.kind)
+	}
+	return ret, nil
+}
+
+func (r *Resources[T]) createAny(ctx context.Context, itm umetav1.ResourceObjectI) error {
+	typed, err := r.toTyped(itm)
+	if err != nil {
+		return err
+	}
+	_, err = r.Create(ctx, typed)
+	return err
+}
+
+func (r *Resources[T]) updateAny(ctx context.Context, itm umetav1.ResourceObjectI) error {
+	typed, err := r.toTyped(itm)
+	if err != nil {
+		return err
+	}
+	_, err = r.Update(ctx, typed)
+	return err
+}
+
+func (r *Resources[T]) deleteAny(ctx context.Context, itm umetav1.ResourceObjectI) error {
+	if uid := itm.GetMetadata().GetUid(); uid != "" {
+		return r.DeleteByUID(ctx, uid)
+	}
+	return r.Delete(ctx, itm.GetMetadata().GetName())
+}
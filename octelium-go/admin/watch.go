@@ -0,0 +1,211 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/grpcerr"
+)
+
+// EventType is the type of a change observed by Watch.
+type EventType int
+
+const (
+	EventAdded EventType = iota + 1
+	EventModified
+	EventDeleted
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventAdded:
+		return "ADDED"
+	case EventModified:
+		return "MODIFIED"
+	case EventDeleted:
+		return "DELETED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Event is a change of a Resource. For EventDeleted, Object is the last
+// observed state of the Resource.
+type Event[T any] struct {
+	Type   EventType
+	Object T
+}
+
+type watchConfig struct {
+	interval     time.Duration
+	skipExisting bool
+}
+
+// WatchOption configures Watch and WaitFor.
+type WatchOption func(*watchConfig)
+
+// WithInterval overrides the polling interval set for the Client.
+func WithInterval(interval time.Duration) WatchOption {
+	return func(c *watchConfig) {
+		c.interval = max(interval, minPollInterval)
+	}
+}
+
+// WithSkipExisting makes Watch not emit EventAdded for the Resources that
+// exist when it starts.
+func WithSkipExisting() WatchOption {
+	return func(c *watchConfig) {
+		c.skipExisting = true
+	}
+}
+
+func (r *Resources[T]) getWatchConfig(opts []WatchOption) *watchConfig {
+	ret := &watchConfig{
+		interval: r.c.cfg.pollInterval,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(ret)
+		}
+	}
+	return ret
+}
+
+// Watch polls the Resources of the kind and calls fn for every observed
+// change until ctx is done or fn returns an error. Resources are matched by
+// UID and reported as modified whenever their resourceVersion changes. Since
+// changes are detected by polling, several changes to the same Resource
+// between two polls are reported as one. Failed polls are logged and retried
+// at the next interval.
+func (r *Resources[T]) Watch(ctx context.Context, fn func(Event[T]) error, opts ...WatchOption) error {
+	cfg := r.getWatchConfig(opts)
+
+	var known map[string]T
+	for {
+		items, err := r.List(ctx)
+		switch {
+		case err == nil:
+			if known == nil && cfg.skipExisting {
+				known = toUIDMap(items)
+			} else if known, err = emitChanges(known, items, fn); err != nil {
+				return err
+			}
+		case ctx.Err() != nil:
+			return ctx.Err()
+		default:
+			r.c.cfg.logger.Warn("Could not list Resources. Retrying",
+				"kind", r.kind, "error", err)
+		}
+
+		if err := sleepCtx(ctx, cfg.interval); err != nil {
+			return err
+		}
+	}
+}
+
+func toUIDMap[T umetav1.ResourceObjectI](items []T) map[string]T {
+	ret := make(map[string]T, len(items))
+	for _, itm := range items {
+		ret[itm.GetMetadata().GetUid()] = itm
+	}
+	return ret
+}
+
+func emitChanges[T umetav1.ResourceObjectI](known map[string]T, items []T, fn func(Event[T]) error) (map[string]T, error) {
+	cur := toUIDMap(items)
+
+	for _, itm := range items {
+		old, ok := known[itm.GetMetadata().GetUid()]
+		switch {
+		case !ok:
+			if err := fn(Event[T]{Type: EventAdded, Object: itm}); err != nil {
+				return nil, err
+			}
+		case old.GetMetadata().GetResourceVersion() != itm.GetMetadata().GetResourceVersion():
+			if err := fn(Event[T]{Type: EventModified, Object: itm}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for uid, itm := range known {
+		if _, ok := cur[uid]; !ok {
+			if err := fn(Event[T]{Type: EventDeleted, Object: itm}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return cur, nil
+}
+
+// WaitFor polls the Resource with the given name until cond returns true and
+// then returns the Resource. cond is not called while the Resource does not
+// exist. WaitFor returns early if cond returns an error or ctx is done.
+func (r *Resources[T]) WaitFor(ctx context.Context, name string, cond func(T) (bool, error), opts ...WatchOption) (T, error) {
+	var zero T
+	cfg := r.getWatchConfig(opts)
+
+	for {
+		itm, err := r.Get(ctx, name)
+		switch {
+		case err == nil:
+			ok, err := cond(itm)
+			if err != nil {
+				return zero, err
+			}
+			if ok {
+				return itm, nil
+			}
+		case grpcerr.IsNotFound(err):
+		case ctx.Err() != nil:
+			return zero, ctx.Err()
+		default:
+			r.c.cfg.logger.Warn("Could not get the Resource. Retrying",
+				"kind", r.kind, "name", name, "error", err)
+		}
+
+		if err := sleepCtx(ctx, cfg.interval); err != nil {
+			return zero, err
+		}
+	}
+}
+
+// WaitForDeletion polls until the Resource with the given name no longer
+// exists.
+func (r *Resources[T]) WaitForDeletion(ctx context.Context, name string, opts ...WatchOption) error {
+	cfg := r.getWatchConfig(opts)
+
+	for {
+		_, err := r.Get(ctx, name)
+		switch {
+		case grpcerr.IsNotFound(err):
+			return nil
+		case err == nil:
+		case ctx.Err() != nil:
+			return ctx.Err()
+		default:
+			r.c.cfg.logger.Warn("Could not get the Resource. Retrying",
+				"kind", r.kind, "name", name, "error", err)
+		}
+
+		if err := sleepCtx(ctx, cfg.interval); err != nil {
+			return err
+		}
+	}
+}
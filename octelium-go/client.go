@@ -466,6 +466,12 @@ func (c *Client) authenticationContext(ctx context.Context) (context.Context, co
 	return context.WithTimeout(ctx, c.cfg.authenticationTimeout)
 }
 
+// TLSConfig returns a TLS configuration for connecting to the Cluster's
+// servers (e.g. the Gateways) that verifies them the same way as the Client.
+func (c *Client) TLSConfig(serverName string) *tls.Config {
+	return c.tlsConfig(serverName)
+}
+
 func (c *Client) tlsConfig(serverName string) *tls.Config {
 	return &tls.Config{
 		MinVersion:         tls.VersionTLS12,
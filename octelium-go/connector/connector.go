@@ -0,0 +1,593 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connector runs an Octelium Connection inside the process. It
+// establishes the Connection with the Cluster, runs a userspace WireGuard or
+// QUIC tunnel over a gVisor network stack and exposes dialing and private DNS
+// resolution of the Cluster's Services without requiring root privileges or
+// a TUN device.
+//
+// Connections opened through a Connector only exist inside the process. They
+// are not visible to the host networking stack and other processes.
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/userv1"
+	octelium "github.com/octelium/octelium/octelium-go"
+	"golang.zx2c4.com/wireguard/conn"
+	"golang.zx2c4.com/wireguard/device"
+)
+
+var (
+	ErrClosed       = errors.New("octelium: connector is closed")
+	ErrDisconnected = errors.New("octelium: disconnected by the Cluster")
+	ErrNoDNSServers = errors.New("octelium: the Cluster did not provide any private DNS servers")
+)
+
+type wgDevice interface {
+	IpcSet(string) error
+	Close()
+}
+
+// Connector is an active Connection to a Cluster. It is safe for concurrent
+// use. A Connector does not reconnect once the Connection is lost; callers
+// watch Done and create a new Connector when needed.
+type Connector struct {
+	cfg    *config
+	stream userv1.MainService_ConnectClient
+	cancel context.CancelFunc
+
+	tun  *netTun
+	dev  wgDevice
+	quic *quicTunnel
+
+	mu         sync.Mutex
+	state      *userv1.ConnectionState
+	hasV4      bool
+	hasV6      bool
+	dnsServers []netip.Addr
+	dnsIdx     atomic.Uint32
+
+	resolver *net.Resolver
+
+	done      chan struct{}
+	doneOnce  sync.Once
+	err       error
+	closeOnce sync.Once
+}
+
+// Connect establishes a Connection to the Cluster of the given Client and
+// starts the userspace tunnel. The Client's Session must be a client Session,
+// for example one created from an authentication token or an assertion. The
+// Client must outlive the Connector. ctx only bounds the startup.
+func Connect(ctx context.Context, c *octelium.Client, opts ...Option) (*Connector, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("octelium: nil context")
+	}
+	if c == nil {
+		return nil, fmt.Errorf("octelium: nil client")
+	}
+
+	cfg := &config{
+		keepAlive:    defaultKeepAlive,
+		startTimeout: defaultStartTimeout,
+		logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	grpcConn, err := c.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stream outlives ctx and is only cancelled by Close.
+	streamCtx, cancel := context.WithCancel(context.Background())
+	ret := &Connector{
+		cfg:    cfg,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	ret.resolver = &net.Resolver{
+		PreferGo: true,
+		Dial:     ret.dialDNS,
+	}
+
+	isStarted := false
+	defer func() {
+		if !isStarted {
+			ret.Close()
+		}
+	}()
+
+	ret.stream, err = userv1.NewMainServiceClient(grpcConn).Connect(streamCtx)
+	if err != nil {
+		return nil, fmt.Errorf("octelium: could not connect: %w", err)
+	}
+
+	if err := ret.stream.Send(&userv1.ConnectRequest{
+		Type: &userv1.ConnectRequest_Initialize_{
+			Initialize: &userv1.ConnectRequest_Initialize{
+				L3Mode:         cfg.l3Mode.toInitialize(),
+				ConnectionType: cfg.tunnelMode.toInitialize(),
+			},
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("octelium: could not initialize the Connection: %w", err)
+	}
+
+	state, err := ret.recvState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ret.start(state, c); err != nil {
+		return nil, err
+	}
+
+	isStarted = true
+
+	go ret.runRecvLoop()
+	go ret.runKeepAliveLoop(streamCtx)
+
+	return ret, nil
+}
+
+func (m L3Mode) toInitialize() userv1.ConnectRequest_Initialize_L3Mode {
+	switch m {
+	case L3ModeV4:
+		return userv1.ConnectRequest_Initialize_V4
+	case L3ModeV6:
+		return userv1.ConnectRequest_Initialize_V6
+	default:
+		return userv1.ConnectRequest_Initialize_BOTH
+	}
+}
+
+func (m TunnelMode) toInitialize() userv1.ConnectRequest_Initialize_ConnectionType {
+	switch m {
+	case TunnelModeQUIC:
+		return userv1.ConnectRequest_Initialize_QUICV0
+	default:
+		return userv1.ConnectRequest_Initialize_UNSET
+	}
+}
+
+func (c *Connector) recvState(ctx context.Context) (*userv1.ConnectionState, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.startTimeout)
+	defer cancel()
+
+	type result struct {
+		state *userv1.ConnectionState
+		err   error
+	}
+
+	resCh := make(chan result, 1)
+	go func() {
+		for {
+			msg, err := c.stream.Recv()
+			if err != nil {
+				resCh <- result{err: fmt.Errorf("octelium: could not get the Connection state: %w", err)}
+				return
+			}
+			if state := msg.GetState(); state != nil {
+				resCh <- result{state: state}
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		// Cancelling the stream unblocks the receiving goroutine.
+		c.cancel()
+		return nil, fmt.Errorf("octelium: could not get the Connection state: %w", ctx.Err())
+	case res := <-resCh:
+		return res.state, res.err
+	}
+}
+
+func (c *Connector) start(state *userv1.ConnectionState, client *octelium.Client) error {
+	if c.cfg.tunnelMode == TunnelModeWireGuard && len(state.X25519Key) != 32 {
+		return fmt.Errorf("octelium: invalid Connection private key")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setL3Mode(state)
+
+	mtu := c.cfg.mtu
+	if mtu == 0 {
+		mtu = int(state.Mtu)
+	}
+	if mtu == 0 {
+		mtu = defaultMTU
+	}
+
+	var err error
+	c.tun, err = newNetTun(mtu, c.hasV4, c.hasV6)
+	if err != nil {
+		return err
+	}
+
+	if c.cfg.tunnelMode == TunnelModeQUIC {
+		c.quic = newQUICTunnel(c.tun, c.cfg.logger, c.cfg.keepAlive,
+			client.TLSConfig, client.AccessToken)
+		return c.setState(state)
+	}
+
+	dev := device.NewDevice(c.tun, conn.NewDefaultBind(),
+		device.NewLogger(device.LogLevelSilent, ""))
+	c.dev = dev
+
+	if err := c.setState(state); err != nil {
+		return err
+	}
+
+	if err := dev.Up(); err != nil {
+		return fmt.Errorf("octelium: could not bring the WireGuard device up: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Connector) setL3Mode(state *userv1.ConnectionState) {
+	c.hasV4 = state.L3Mode == userv1.ConnectionState_V4 || state.L3Mode == userv1.ConnectionState_BOTH
+	c.hasV6 = state.L3Mode == userv1.ConnectionState_V6 || state.L3Mode == userv1.ConnectionState_BOTH
+}
+
+// setState applies a full Connection state. c.mu must be held.
+func (c *Connector) setState(state *userv1.ConnectionState) error {
+	addrs, err := getStateAddrs(state, c.hasV4, c.hasV6)
+	if err != nil {
+		return err
+	}
+
+	if err := c.tun.setAddrs(addrs); err != nil {
+		return err
+	}
+
+	old := c.state
+	c.state = state
+	if err := c.reconfigure(); err != nil {
+		c.state = old
+		return err
+	}
+
+	c.setDNSServers(state.Dns)
+
+	return nil
+}
+
+// reconfigure pushes the current Gateways to the WireGuard device or the QUIC
+// tunnel. c.mu must be held.
+func (c *Connector) reconfigure() error {
+	if c.quic != nil {
+		return c.quic.setGateways(c.state.Gateways, c.hasV4, c.hasV6)
+	}
+
+	uapi, err := toUAPI(c.state, c.hasV4, c.hasV6, c.cfg.keepAlive)
+	if err != nil {
+		return err
+	}
+
+	return c.dev.IpcSet(uapi)
+}
+
+func (c *Connector) setDNSServers(dns *userv1.DNS) {
+	var servers []netip.Addr
+	for _, s := range dns.GetServers() {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			c.cfg.logger.Warn("Skipping an invalid DNS server", "server", s)
+			continue
+		}
+		addr = addr.Unmap()
+		if (addr.Is4() && c.hasV4) || (addr.Is6() && c.hasV6) {
+			servers = append(servers, addr)
+		}
+	}
+
+	c.dnsServers = servers
+}
+
+func (c *Connector) handleResponse(resp *userv1.ConnectResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch ev := resp.Event.(type) {
+	case *userv1.ConnectResponse_State:
+		return c.setState(ev.State)
+
+	case *userv1.ConnectResponse_AddGateway_:
+		gw := ev.AddGateway.GetGateway()
+		if gw == nil {
+			return nil
+		}
+		c.state.Gateways = append(c.state.Gateways, gw)
+		return c.reconfigure()
+
+	case *userv1.ConnectResponse_UpdateGateway_:
+		gw := ev.UpdateGateway.GetGateway()
+		if gw == nil {
+			return nil
+		}
+		for i, cur := range c.state.Gateways {
+			if cur.GetId() == gw.Id {
+				c.state.Gateways[i] = gw
+				return c.reconfigure()
+			}
+		}
+		return fmt.Errorf("octelium: could not find Gateway %s to update", gw.Id)
+
+	case *userv1.ConnectResponse_DeleteGateway_:
+		for i, cur := range c.state.Gateways {
+			if cur.GetId() == ev.DeleteGateway.GetId() {
+				c.state.Gateways = append(c.state.Gateways[:i], c.state.Gateways[i+1:]...)
+				return c.reconfigure()
+			}
+		}
+		return nil
+
+	case *userv1.ConnectResponse_UpdateDNS_:
+		c.state.Dns = ev.UpdateDNS.GetDns()
+		c.setDNSServers(c.state.Dns)
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+func (c *Connector) runRecvLoop() {
+	for {
+		resp, err := c.stream.Recv()
+		if err != nil {
+			c.finish(fmt.Errorf("octelium: Connection lost: %w", err))
+			return
+		}
+
+		if _, ok := resp.GetEvent().(*userv1.ConnectResponse_Disconnect_); ok {
+			c.finish(ErrDisconnected)
+			return
+		}
+
+		if err := c.handleResponse(resp); err != nil {
+			c.cfg.logger.Warn("Could not handle a Connection event", "error", err)
+		}
+	}
+}
+
+func (c *Connector) runKeepAliveLoop(ctx context.Context) {
+	ticker := time.NewTicker(streamKeepAlivePeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if err := c.stream.Send(&userv1.ConnectRequest{
+				Type: &userv1.ConnectRequest_KeepAlive_{
+					KeepAlive: &userv1.ConnectRequest_KeepAlive{},
+				},
+			}); err != nil {
+				c.cfg.logger.Debug("Could not send a keepalive", "error", err)
+			}
+		}
+	}
+}
+
+// finish records the first terminal error and tears the tunnel down.
+func (c *Connector) finish(err error) {
+	c.doneOnce.Do(func() {
+		c.err = err
+		close(c.done)
+	})
+	c.Close()
+}
+
+// Done is closed when the Connection ends, either by Close or by the Cluster.
+func (c *Connector) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the Connection ended, or nil while it is still active.
+func (c *Connector) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+// Close ends the Connection. Connections dialed through the Connector are
+// closed as well.
+func (c *Connector) Close() error {
+	c.closeOnce.Do(func() {
+		c.doneOnce.Do(func() {
+			c.err = ErrClosed
+			close(c.done)
+		})
+
+		c.cancel()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.dev != nil {
+			c.dev.Close()
+		}
+		if c.quic != nil {
+			c.quic.Close()
+		}
+		if c.tun != nil {
+			c.tun.Close()
+		}
+	})
+
+	return nil
+}
+
+// Resolver returns a resolver that queries the Cluster's private DNS servers
+// through the tunnel.
+func (c *Connector) Resolver() *net.Resolver {
+	return c.resolver
+}
+
+// LookupHost resolves host using the Cluster's private DNS servers.
+func (c *Connector) LookupHost(ctx context.Context, host string) ([]string, error) {
+	return c.resolver.LookupHost(ctx, host)
+}
+
+func (c *Connector) getDNSServers() []netip.Addr {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.dnsServers
+}
+
+func (c *Connector) dialDNS(ctx context.Context, network, _ string) (net.Conn, error) {
+	servers := c.getDNSServers()
+	if len(servers) == 0 {
+		return nil, ErrNoDNSServers
+	}
+
+	server := netip.AddrPortFrom(servers[int(c.dnsIdx.Add(1))%len(servers)], 53)
+
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+		return c.tun.dialTCP(ctx, server)
+	default:
+		return c.tun.dialUDP(server)
+	}
+}
+
+// DialContext connects to the address on the named network through the
+// tunnel. The supported networks are "tcp", "tcp4", "tcp6", "udp", "udp4"
+// and "udp6". Host names are resolved using the Cluster's private DNS
+// servers.
+func (c *Connector) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	opErr := func(err error) error {
+		return &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+
+	select {
+	case <-c.done:
+		return nil, opErr(ErrClosed)
+	default:
+	}
+
+	var isUDP, acceptV4, acceptV6 bool
+	switch network {
+	case "tcp", "udp":
+		acceptV4, acceptV6 = true, true
+	case "tcp4", "udp4":
+		acceptV4 = true
+	case "tcp6", "udp6":
+		acceptV6 = true
+	default:
+		return nil, opErr(net.UnknownNetworkError(network))
+	}
+	isUDP = network[:3] == "udp"
+
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, opErr(err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, opErr(fmt.Errorf("invalid port %q", portStr))
+	}
+
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr.Unmap()}
+	} else {
+		addrs, err = c.resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return nil, opErr(err)
+		}
+	}
+
+	var candidates []netip.Addr
+	for _, addr := range addrs {
+		addr = addr.Unmap()
+		if (addr.Is4() && acceptV4 && c.hasV4) || (addr.Is6() && acceptV6 && c.hasV6) {
+			candidates = append(candidates, addr)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, opErr(fmt.Errorf("no suitable address found for %s", host))
+	}
+
+	var firstErr error
+	for _, addr := range candidates {
+		if err := ctx.Err(); err != nil {
+			return nil, opErr(err)
+		}
+
+		var conn net.Conn
+		if isUDP {
+			conn, err = c.tun.dialUDP(netip.AddrPortFrom(addr, uint16(port)))
+		} else {
+			conn, err = c.tun.dialTCP(ctx, netip.AddrPortFrom(addr, uint16(port)))
+		}
+		if err == nil {
+			return conn, nil
+		}
+		if firstErr == nil {
+			firstErr = opErr(err)
+		}
+	}
+
+	return nil, firstErr
+}
+
+// Dial is like DialContext without a context.
+func (c *Connector) Dial(network, address string) (net.Conn, error) {
+	return c.DialContext(context.Background(), network, address)
+}
+
+// HTTPTransport returns an HTTP transport that dials through the tunnel. It
+// ignores proxy environment variables since proxies are not reachable from
+// inside the tunnel.
+func (c *Connector) HTTPTransport() *http.Transport {
+	ret := http.DefaultTransport.(*http.Transport).Clone()
+	ret.Proxy = nil
+	ret.DialContext = c.DialContext
+	return ret
+}
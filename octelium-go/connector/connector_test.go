@@ -0,0 +1,280 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connector
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/main/userv1"
+)
+
+type fakeDevice struct {
+	uapi   []string
+	closed bool
+}
+
+func (d *fakeDevice) IpcSet(arg string) error {
+	d.uapi = append(d.uapi, arg)
+	return nil
+}
+
+func (d *fakeDevice) Close() {
+	d.closed = true
+}
+
+func newTestKey(t *testing.T) []byte {
+	t.Helper()
+	ret := make([]byte, 32)
+	if _, err := rand.Read(ret); err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func newTestGateway(t *testing.T, id string, cidrs ...string) *userv1.Gateway {
+	t.Helper()
+	return &userv1.Gateway{
+		Id:        id,
+		Addresses: []string{"203.0.113.10"},
+		CIDRs:     cidrs,
+		Wireguard: &userv1.Gateway_WireGuard{
+			PublicKey: base64.StdEncoding.EncodeToString(newTestKey(t)),
+			Port:      53820,
+		},
+	}
+}
+
+func TestToUAPI(t *testing.T) {
+	privateKey := newTestKey(t)
+	gw := newTestGateway(t, "gw-1", "10.10.0.0/16", "fd00:10::/64")
+
+	state := &userv1.ConnectionState{
+		X25519Key: privateKey,
+		Gateways:  []*userv1.Gateway{gw},
+	}
+
+	uapi, err := toUAPI(state, true, false, 25*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubKey, _ := base64.StdEncoding.DecodeString(gw.Wireguard.PublicKey)
+	for _, line := range []string{
+		"private_key=" + hex.EncodeToString(privateKey),
+		"replace_peers=true",
+		"public_key=" + hex.EncodeToString(pubKey),
+		"endpoint=203.0.113.10:53820",
+		"persistent_keepalive_interval=25",
+		"allowed_ip=10.10.0.0/16",
+	} {
+		if !strings.Contains(uapi, line+"\n") {
+			t.Errorf("missing %q in:\n%s", line, uapi)
+		}
+	}
+	if strings.Contains(uapi, "fd00:10::/64") {
+		t.Errorf("unexpected IPv6 allowed IP in an IPv4-only Connection:\n%s", uapi)
+	}
+
+	gw.Wireguard.PublicKey = "invalid"
+	if _, err := toUAPI(state, true, false, 0); err == nil {
+		t.Fatal("expected an error for an invalid Gateway public key")
+	}
+}
+
+func TestGetStateAddrs(t *testing.T) {
+	state := &userv1.ConnectionState{
+		Addresses: []*metav1.DualStackNetwork{
+			{V4: "10.10.1.2/32", V6: "fd00:10::1:2/128"},
+		},
+	}
+
+	addrs, err := getStateAddrs(state, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 2 || addrs[0] != netip.MustParseAddr("10.10.1.2") ||
+		addrs[1] != netip.MustParseAddr("fd00:10::1:2") {
+		t.Fatalf("unexpected addresses: %v", addrs)
+	}
+
+	addrs, err = getStateAddrs(state, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 1 || !addrs[0].Is6() {
+		t.Fatalf("unexpected addresses: %v", addrs)
+	}
+
+	if _, err := getStateAddrs(&userv1.ConnectionState{}, true, true); err == nil {
+		t.Fatal("expected an error for a state without addresses")
+	}
+}
+
+func TestHandleResponse(t *testing.T) {
+	dev := &fakeDevice{}
+	c := &Connector{
+		cfg: &config{
+			logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+		dev:   dev,
+		hasV4: true,
+		state: &userv1.ConnectionState{
+			X25519Key: newTestKey(t),
+		},
+		done: make(chan struct{}),
+	}
+
+	gw := newTestGateway(t, "gw-1", "10.10.0.0/16")
+	if err := c.handleResponse(&userv1.ConnectResponse{
+		Event: &userv1.ConnectResponse_AddGateway_{
+			AddGateway: &userv1.ConnectResponse_AddGateway{Gateway: gw},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.state.Gateways) != 1 || len(dev.uapi) != 1 {
+		t.Fatalf("unexpected state after adding a Gateway")
+	}
+
+	updated := newTestGateway(t, "gw-1", "10.20.0.0/16")
+	if err := c.handleResponse(&userv1.ConnectResponse{
+		Event: &userv1.ConnectResponse_UpdateGateway_{
+			UpdateGateway: &userv1.ConnectResponse_UpdateGateway{Gateway: updated},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dev.uapi[len(dev.uapi)-1], "allowed_ip=10.20.0.0/16\n") {
+		t.Fatalf("Gateway update was not applied:\n%s", dev.uapi[len(dev.uapi)-1])
+	}
+
+	if err := c.handleResponse(&userv1.ConnectResponse{
+		Event: &userv1.ConnectResponse_UpdateDNS_{
+			UpdateDNS: &userv1.ConnectResponse_UpdateDNS{
+				Dns: &userv1.DNS{Servers: []string{"10.10.0.53", "fd00::53", "invalid"}},
+			},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if servers := c.getDNSServers(); len(servers) != 1 || servers[0] != netip.MustParseAddr("10.10.0.53") {
+		t.Fatalf("unexpected DNS servers: %v", servers)
+	}
+
+	if err := c.handleResponse(&userv1.ConnectResponse{
+		Event: &userv1.ConnectResponse_DeleteGateway_{
+			DeleteGateway: &userv1.ConnectResponse_DeleteGateway{Id: "gw-1"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.state.Gateways) != 0 || strings.Contains(dev.uapi[len(dev.uapi)-1], "public_key=") {
+		t.Fatalf("Gateway was not deleted")
+	}
+}
+
+func TestDialContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tun, err := newNetTun(defaultMTU, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := netip.MustParseAddr("10.10.1.2")
+	if err := tun.setAddrs([]netip.Addr{addr}); err != nil {
+		t.Fatal(err)
+	}
+
+	dev := &fakeDevice{}
+	c := &Connector{
+		cfg: &config{
+			logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+		cancel: func() {},
+		tun:    tun,
+		dev:    dev,
+		hasV4:  true,
+		done:   make(chan struct{}),
+	}
+	c.resolver = &net.Resolver{PreferGo: true, Dial: c.dialDNS}
+
+	lis, err := tun.listenTCP(netip.AddrPortFrom(addr, 8080))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}()
+
+	conn, err := c.DialContext(ctx, "tcp", "10.10.1.2:8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := conn.Write([]byte("octelium")); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len("octelium"))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "octelium" {
+		t.Fatalf("unexpected echo: %q", buf)
+	}
+	conn.Close()
+
+	if _, err := c.DialContext(ctx, "tcp6", "10.10.1.2:8080"); err == nil {
+		t.Fatal("expected an error for an IPv4 address on tcp6")
+	}
+	if _, err := c.DialContext(ctx, "unix", "/tmp/sock"); err == nil {
+		t.Fatal("expected an error for an unsupported network")
+	}
+	if _, err := c.LookupHost(ctx, "svc.example.com"); err == nil ||
+		!strings.Contains(err.Error(), ErrNoDNSServers.Error()) {
+		t.Fatalf("expected ErrNoDNSServers, got %v", err)
+	}
+
+	c.Close()
+	if !dev.closed {
+		t.Fatal("device was not closed")
+	}
+	if !errors.Is(c.Err(), ErrClosed) {
+		t.Fatalf("unexpected error: %v", c.Err())
+	}
+	if _, err := c.DialContext(ctx, "tcp", "10.10.1.2:8080"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"sync"
+
+	"golang.zx2c4.com/wireguard/tun"
+	"gvisor.dev/gvisor/pkg/buffer"
+	"gvisor.dev/gvisor/pkg/tcpip"
+	"gvisor.dev/gvisor/pkg/tcpip/adapters/gonet"
+	"gvisor.dev/gvisor/pkg/tcpip/header"
+	"gvisor.dev/gvisor/pkg/tcpip/network/ipv4"
+	"gvisor.dev/gvisor/pkg/tcpip/network/ipv6"
+	"gvisor.dev/gvisor/pkg/tcpip/stack"
+	"gvisor.dev/gvisor/pkg/tcpip/transport/tcp"
+	"gvisor.dev/gvisor/pkg/tcpip/transport/udp"
+)
+
+const (
+	netstackNICID               = 1
+	netstackIncomingPacketQueue = 1024
+)
+
+// netTun is a userspace TUN device backed by a gVisor network stack. The
+// WireGuard device or the QUIC tunnel reads the packets sent by the stack and
+// writes the packets received from the Gateways back into it.
+type netTun struct {
+	stack          *stack.Stack
+	dispatcher     stack.NetworkDispatcher
+	events         chan tun.Event
+	incomingPacket chan *buffer.View
+	mtu            int
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+type endpoint netTun
+
+func (e *endpoint) Attach(dispatcher stack.NetworkDispatcher) {
+	e.dispatcher = dispatcher
+}
+
+func (e *endpoint) IsAttached() bool {
+	return e.dispatcher != nil
+}
+
+func (e *endpoint) MTU() uint32 {
+	return uint32(e.mtu)
+}
+
+func (*endpoint) Capabilities() stack.LinkEndpointCapabilities {
+	return stack.CapabilityNone
+}
+
+func (*endpoint) MaxHeaderLength() uint16 {
+	return 0
+}
+
+func (*endpoint) LinkAddress() tcpip.LinkAddress {
+	return ""
+}
+
+func (*endpoint) Wait() {}
+
+func (e *endpoint) WritePacket(pkt *stack.PacketBuffer) tcpip.Error {
+	select {
+	case <-e.closed:
+		return &tcpip.ErrClosedForSend{}
+	default:
+	}
+
+	view := pkt.ToView()
+
+	select {
+	case e.incomingPacket <- view:
+		return nil
+	default:
+	}
+
+	view.Release()
+	return &tcpip.ErrNoBufferSpace{}
+}
+
+func (e *endpoint) WritePackets(pbs stack.PacketBufferList) (int, tcpip.Error) {
+	var written int
+	for _, pkt := range pbs.AsSlice() {
+		if err := e.WritePacket(pkt); err != nil {
+			return written, err
+		}
+		written++
+	}
+
+	return written, nil
+}
+
+func (*endpoint) WriteRawPacket(*stack.PacketBuffer) tcpip.Error {
+	return &tcpip.ErrNotSupported{}
+}
+
+func (*endpoint) ParseHeader(*stack.PacketBuffer) bool { return true }
+
+func (*endpoint) ARPHardwareType() header.ARPHardwareType {
+	return header.ARPHardwareNone
+}
+
+func (*endpoint) AddHeader(*stack.PacketBuffer) {}
+
+func (*endpoint) Close() {}
+
+func (*endpoint) SetLinkAddress(tcpip.LinkAddress) {}
+
+func (*endpoint) SetMTU(uint32) {}
+
+func (*endpoint) SetOnCloseAction(func()) {}
+
+func newNetTun(mtu int, hasV4, hasV6 bool) (*netTun, error) {
+	ret := &netTun{
+		stack: stack.New(stack.Options{
+			NetworkProtocols:   []stack.NetworkProtocolFactory{ipv4.NewProtocol, ipv6.NewProtocol},
+			TransportProtocols: []stack.TransportProtocolFactory{tcp.NewProtocol, udp.NewProtocol},
+			HandleLocal:        true,
+		}),
+		events:         make(chan tun.Event, 10),
+		incomingPacket: make(chan *buffer.View, netstackIncomingPacketQueue),
+		mtu:            mtu,
+		closed:         make(chan struct{}),
+	}
+
+	if err := ret.stack.CreateNIC(netstackNICID, (*endpoint)(ret)); err != nil {
+		ret.Close()
+		return nil, fmt.Errorf("octelium: could not create the netstack NIC: %v", err)
+	}
+
+	if hasV4 {
+		ret.stack.AddRoute(tcpip.Route{Destination: header.IPv4EmptySubnet, NIC: netstackNICID})
+	}
+	if hasV6 {
+		ret.stack.AddRoute(tcpip.Route{Destination: header.IPv6EmptySubnet, NIC: netstackNICID})
+	}
+
+	ret.events <- tun.EventUp
+
+	return ret, nil
+}
+
+// setAddrs makes the stack's addresses exactly match addrs.
+func (t *netTun) setAddrs(addrs []netip.Addr) error {
+	want := make(map[tcpip.Address]tcpip.ProtocolAddress)
+	for _, addr := range addrs {
+		a := tcpip.AddrFromSlice(addr.AsSlice())
+		protocol := header.IPv4ProtocolNumber
+		if addr.Is6() {
+			protocol = header.IPv6ProtocolNumber
+		}
+		want[a] = tcpip.ProtocolAddress{
+			Protocol:          protocol,
+			AddressWithPrefix: a.WithPrefix(),
+		}
+	}
+
+	for _, cur := range t.stack.AllAddresses()[netstackNICID] {
+		if _, ok := want[cur.AddressWithPrefix.Address]; ok {
+			delete(want, cur.AddressWithPrefix.Address)
+			continue
+		}
+		t.stack.RemoveAddress(netstackNICID, cur.AddressWithPrefix.Address)
+	}
+
+	for _, protoAddr := range want {
+		if err := t.stack.AddProtocolAddress(netstackNICID, protoAddr, stack.AddressProperties{}); err != nil {
+			return fmt.Errorf("octelium: could not add the netstack address %s: %v",
+				protoAddr.AddressWithPrefix.Address, err)
+		}
+	}
+
+	return nil
+}
+
+func (t *netTun) Name() (string, error) {
+	return "octelium", nil
+}
+
+func (t *netTun) File() *os.File {
+	return nil
+}
+
+func (t *netTun) Events() <-chan tun.Event {
+	return t.events
+}
+
+func (t *netTun) Read(buf [][]byte, sizes []int, offset int) (int, error) {
+	var view *buffer.View
+
+	select {
+	case view = <-t.incomingPacket:
+	case <-t.closed:
+		return 0, os.ErrClosed
+	}
+
+	if view == nil {
+		return 0, os.ErrClosed
+	}
+	defer view.Release()
+
+	n, err := view.Read(buf[0][offset:])
+	if err != nil {
+		return 0, err
+	}
+	sizes[0] = n
+	return 1, nil
+}
+
+func (t *netTun) Write(bufs [][]byte, offset int) (int, error) {
+	for _, buf := range bufs {
+		packet := buf[offset:]
+		if len(packet) == 0 {
+			continue
+		}
+
+		pkb := stack.NewPacketBuffer(stack.PacketBufferOptions{
+			Payload: buffer.MakeWithData(packet),
+		})
+
+		switch packet[0] >> 4 {
+		case 4:
+			t.dispatcher.DeliverNetworkPacket(ipv4.ProtocolNumber, pkb)
+		case 6:
+			t.dispatcher.DeliverNetworkPacket(ipv6.ProtocolNumber, pkb)
+		}
+
+		pkb.DecRef()
+	}
+
+	return len(bufs), nil
+}
+
+func (t *netTun) MTU() (int, error) {
+	return t.mtu, nil
+}
+
+func (t *netTun) BatchSize() int {
+	return 1
+}
+
+func (t *netTun) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.closed)
+
+		t.stack.RemoveNIC(netstackNICID)
+		t.stack.Close()
+
+		for {
+			select {
+			case view := <-t.incomingPacket:
+				if view != nil {
+					view.Release()
+				}
+				continue
+			default:
+			}
+			break
+		}
+
+		close(t.events)
+	})
+
+	return nil
+}
+
+func toFullAddr(addr netip.AddrPort) (tcpip.FullAddress, tcpip.NetworkProtocolNumber) {
+	ip := addr.Addr().Unmap()
+	protocol := ipv4.ProtocolNumber
+	if ip.Is6() {
+		protocol = ipv6.ProtocolNumber
+	}
+
+	return tcpip.FullAddress{
+		NIC:  netstackNICID,
+		Addr: tcpip.AddrFromSlice(ip.AsSlice()),
+		Port: addr.Port(),
+	}, protocol
+}
+
+func (t *netTun) dialTCP(ctx context.Context, addr netip.AddrPort) (net.Conn, error) {
+	fa, pn := toFullAddr(addr)
+	return gonet.DialContextTCP(ctx, t.stack, fa, pn)
+}
+
+func (t *netTun) dialUDP(addr netip.AddrPort) (net.Conn, error) {
+	fa, pn := toFullAddr(addr)
+	return gonet.DialUDP(t.stack, nil, &fa, pn)
+}
+
+func (t *netTun) listenTCP(addr netip.AddrPort) (net.Listener, error) {
+	fa, pn := toFullAddr(addr)
+	return gonet.ListenTCP(t.stack, fa, pn)
+}
@@ -0,0 +1,134 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connector
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	defaultKeepAlive      = 30 * time.Second
+	defaultMTU            = 1280
+	defaultStartTimeout   = 20 * time.Second
+	streamKeepAlivePeriod = 5 * time.Minute
+)
+
+// L3Mode selects the IP families used inside the tunnel.
+type L3Mode int
+
+const (
+	// L3ModeBoth uses both IPv4 and IPv6 when the Cluster supports them.
+	L3ModeBoth L3Mode = iota
+	L3ModeV4
+	L3ModeV6
+)
+
+// TunnelMode selects the protocol of the tunnel to the Cluster's Gateways.
+type TunnelMode int
+
+const (
+	// TunnelModeWireGuard tunnels the traffic over WireGuard.
+	TunnelModeWireGuard TunnelMode = iota
+	// TunnelModeQUIC tunnels the traffic over QUIC datagrams. It is useful
+	// when WireGuard's UDP traffic is blocked or throttled.
+	TunnelModeQUIC
+)
+
+type config struct {
+	l3Mode       L3Mode
+	tunnelMode   TunnelMode
+	keepAlive    time.Duration
+	mtu          int
+	startTimeout time.Duration
+	logger       *slog.Logger
+}
+
+// Option configures a Connector.
+type Option func(*config) error
+
+// WithL3Mode restricts the tunnel to IPv4 or IPv6.
+func WithL3Mode(mode L3Mode) Option {
+	return func(c *config) error {
+		switch mode {
+		case L3ModeBoth, L3ModeV4, L3ModeV6:
+		default:
+			return fmt.Errorf("octelium: invalid L3 mode %d", mode)
+		}
+		c.l3Mode = mode
+		return nil
+	}
+}
+
+// WithTunnelMode sets the protocol of the tunnel. The default is WireGuard.
+func WithTunnelMode(mode TunnelMode) Option {
+	return func(c *config) error {
+		switch mode {
+		case TunnelModeWireGuard, TunnelModeQUIC:
+		default:
+			return fmt.Errorf("octelium: invalid tunnel mode %d", mode)
+		}
+		c.tunnelMode = mode
+		return nil
+	}
+}
+
+// WithKeepAlive sets the WireGuard persistent keepalive interval. A zero
+// value disables it. In QUIC mode, it sets the QUIC keepalive period unless
+// the Gateway sets its own, and a zero value uses the default period.
+func WithKeepAlive(interval time.Duration) Option {
+	return func(c *config) error {
+		if interval < 0 || interval > time.Hour {
+			return fmt.Errorf("octelium: invalid keepalive interval %s", interval)
+		}
+		c.keepAlive = interval
+		return nil
+	}
+}
+
+// WithMTU overrides the tunnel MTU announced by the Cluster.
+func WithMTU(mtu int) Option {
+	return func(c *config) error {
+		if mtu < 1280 || mtu > 1500 {
+			return fmt.Errorf("octelium: invalid MTU %d", mtu)
+		}
+		c.mtu = mtu
+		return nil
+	}
+}
+
+// WithStartTimeout bounds how long Connect waits for the initial Connection
+// state from the Cluster.
+func WithStartTimeout(timeout time.Duration) Option {
+	return func(c *config) error {
+		if timeout <= 0 {
+			return fmt.Errorf("octelium: start timeout must be positive")
+		}
+		c.startTimeout = timeout
+		return nil
+	}
+}
+
+// WithLogger sets the logger used for connection diagnostics.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) error {
+		if logger == nil {
+			return fmt.Errorf("octelium: nil logger")
+		}
+		c.logger = logger
+		return nil
+	}
+}
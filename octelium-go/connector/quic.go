@@ -0,0 +1,427 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connector
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/netip"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/quicv0"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/quic-go/quic-go"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	quicMaxPacketSize    = 65535
+	quicMsgHeaderSize    = 8
+	quicMsgTypeInit      = 1
+	quicMaxInitRespSize  = 4096
+	quicReconnectPeriod  = 1500 * time.Millisecond
+	quicDefaultKeepAlive = 30 * time.Second
+	quicConnectTimeout   = 10 * time.Second
+	quicIPv4HeaderLen    = 20
+	quicIPv4OffsetDst    = 16
+	quicIPv6HeaderLen    = 40
+	quicIPv6OffsetDst    = 24
+)
+
+// quicTunnel carries the packets of the netstack to the Gateways over QUIC
+// datagrams, one QUIC connection per Gateway, as an alternative to WireGuard
+// for networks where WireGuard's UDP traffic is blocked.
+type quicTunnel struct {
+	tun       *netTun
+	logger    *slog.Logger
+	keepAlive time.Duration
+	tlsConfig func(serverName string) *tls.Config
+	getToken  func(ctx context.Context) (string, error)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	gateways map[string]*quicGateway
+	isClosed bool
+
+	routes atomic.Pointer[[]quicRoute]
+}
+
+type quicRoute struct {
+	prefix netip.Prefix
+	gw     *quicGateway
+}
+
+type quicGateway struct {
+	tunnel *quicTunnel
+	gw     *userv1.Gateway
+	cancel context.CancelFunc
+	conn   atomic.Pointer[quic.Conn]
+}
+
+func newQUICTunnel(tun *netTun, logger *slog.Logger, keepAlive time.Duration,
+	tlsConfig func(serverName string) *tls.Config,
+	getToken func(ctx context.Context) (string, error)) *quicTunnel {
+	ctx, cancel := context.WithCancel(context.Background())
+	ret := &quicTunnel{
+		tun:       tun,
+		logger:    logger,
+		keepAlive: keepAlive,
+		tlsConfig: tlsConfig,
+		getToken:  getToken,
+		ctx:       ctx,
+		cancel:    cancel,
+		gateways:  make(map[string]*quicGateway),
+	}
+
+	go ret.runReadLoop()
+
+	return ret
+}
+
+// setGateways connects to the new and updated Gateways and disconnects from
+// the removed ones.
+func (t *quicTunnel) setGateways(gateways []*userv1.Gateway, hasV4, hasV6 bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isClosed {
+		return ErrClosed
+	}
+
+	cur := make(map[string]*quicGateway)
+	var routes []quicRoute
+
+	for _, gw := range gateways {
+		if gw == nil || gw.Quicv0 == nil || gw.Hostname == "" {
+			continue
+		}
+
+		var prefixes []netip.Prefix
+		for _, cidr := range gw.CIDRs {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return fmt.Errorf("octelium: invalid CIDR %q of Gateway %s", cidr, gw.Id)
+			}
+			if (prefix.Addr().Is4() && hasV4) || (prefix.Addr().Is6() && hasV6) {
+				prefixes = append(prefixes, prefix.Masked())
+			}
+		}
+
+		qgw, ok := t.gateways[gw.Id]
+		if !ok || !proto.Equal(qgw.gw, gw) {
+			if ok {
+				qgw.close()
+			}
+			qgw = t.newGateway(gw)
+		}
+
+		cur[gw.Id] = qgw
+		for _, prefix := range prefixes {
+			routes = append(routes, quicRoute{prefix: prefix, gw: qgw})
+		}
+	}
+
+	for id, qgw := range t.gateways {
+		if cur[id] != qgw {
+			qgw.close()
+		}
+	}
+
+	t.gateways = cur
+	t.routes.Store(&routes)
+
+	return nil
+}
+
+func (t *quicTunnel) newGateway(gw *userv1.Gateway) *quicGateway {
+	ctx, cancel := context.WithCancel(t.ctx)
+	ret := &quicGateway{
+		tunnel: t,
+		gw:     proto.Clone(gw).(*userv1.Gateway),
+		cancel: cancel,
+	}
+
+	go ret.run(ctx)
+
+	return ret
+}
+
+// Close disconnects from all the Gateways.
+func (t *quicTunnel) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isClosed {
+		return
+	}
+	t.isClosed = true
+
+	t.cancel()
+	for _, qgw := range t.gateways {
+		qgw.close()
+	}
+	t.gateways = nil
+	t.routes.Store(nil)
+}
+
+func (t *quicTunnel) getGateway(pkt []byte) *quicGateway {
+	dst, ok := getPacketDst(pkt)
+	if !ok {
+		return nil
+	}
+
+	routes := t.routes.Load()
+	if routes == nil {
+		return nil
+	}
+
+	for _, route := range *routes {
+		if route.prefix.Contains(dst) {
+			return route.gw
+		}
+	}
+
+	return nil
+}
+
+// runReadLoop sends the packets of the netstack to the Gateway whose CIDRs
+// contain their destination.
+func (t *quicTunnel) runReadLoop() {
+	bufs := [][]byte{make([]byte, quicMaxPacketSize)}
+	sizes := make([]int, 1)
+
+	for {
+		n, err := t.tun.Read(bufs, sizes, 0)
+		if err != nil {
+			return
+		}
+		if n == 0 || sizes[0] == 0 {
+			continue
+		}
+
+		pkt := bufs[0][:sizes[0]]
+
+		qgw := t.getGateway(pkt)
+		if qgw == nil {
+			continue
+		}
+
+		conn := qgw.conn.Load()
+		if conn == nil {
+			continue
+		}
+
+		if err := conn.SendDatagram(append([]byte(nil), pkt...)); err != nil {
+			t.logger.Debug("Could not send a packet to the Gateway", "gateway", qgw.gw.Id, "error", err)
+		}
+	}
+}
+
+func getPacketDst(pkt []byte) (netip.Addr, bool) {
+	if len(pkt) == 0 {
+		return netip.Addr{}, false
+	}
+
+	switch pkt[0] >> 4 {
+	case 4:
+		if len(pkt) < quicIPv4HeaderLen {
+			return netip.Addr{}, false
+		}
+		return netip.AddrFrom4([4]byte(pkt[quicIPv4OffsetDst : quicIPv4OffsetDst+4])), true
+	case 6:
+		if len(pkt) < quicIPv6HeaderLen {
+			return netip.Addr{}, false
+		}
+		return netip.AddrFrom16([16]byte(pkt[quicIPv6OffsetDst : quicIPv6OffsetDst+16])), true
+	default:
+		return netip.Addr{}, false
+	}
+}
+
+func (g *quicGateway) close() {
+	g.cancel()
+	if conn := g.conn.Swap(nil); conn != nil {
+		conn.CloseWithError(0, "")
+	}
+}
+
+// run keeps the connection to the Gateway up, reconnecting whenever it is
+// lost, until the Gateway is removed or the tunnel is closed.
+func (g *quicGateway) run(ctx context.Context) {
+	logger := g.tunnel.logger.With("gateway", g.gw.Id)
+
+	for {
+		conn, err := g.connect(ctx)
+		if err == nil {
+			g.conn.Store(conn)
+			g.runReceiveLoop(ctx, conn)
+			g.conn.CompareAndSwap(conn, nil)
+			conn.CloseWithError(0, "")
+			logger.Debug("Disconnected from the Gateway")
+		} else if ctx.Err() == nil {
+			logger.Warn("Could not connect to the Gateway", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(quicReconnectPeriod):
+		}
+	}
+}
+
+func (g *quicGateway) runReceiveLoop(ctx context.Context, conn *quic.Conn) {
+	for {
+		msg, err := conn.ReceiveDatagram(ctx)
+		if err != nil {
+			return
+		}
+
+		if len(msg) < quicIPv4HeaderLen {
+			continue
+		}
+
+		if _, err := g.tunnel.tun.Write([][]byte{msg}, 0); err != nil {
+			return
+		}
+	}
+}
+
+// connect dials the Gateway and authenticates the QUIC connection with the
+// Session's access token.
+func (g *quicGateway) connect(ctx context.Context) (*quic.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, quicConnectTimeout)
+	defer cancel()
+
+	tlsConfig := g.tunnel.tlsConfig(g.gw.Hostname)
+	tlsConfig.MinVersion = tls.VersionTLS13
+	tlsConfig.MaxVersion = tls.VersionTLS13
+	tlsConfig.NextProtos = []string{"h3"}
+
+	keepAlive := g.tunnel.keepAlive
+	if g.gw.Quicv0.KeepAliveSeconds > 0 {
+		keepAlive = time.Duration(g.gw.Quicv0.KeepAliveSeconds) * time.Second
+	}
+	if keepAlive == 0 {
+		keepAlive = quicDefaultKeepAlive
+	}
+
+	addr := net.JoinHostPort(g.gw.Hostname, strconv.Itoa(int(g.gw.Quicv0.Port)))
+
+	conn, err := quic.DialAddr(ctx, addr, tlsConfig, &quic.Config{
+		EnableDatagrams: true,
+		Versions:        []quic.Version{quic.Version1, quic.Version2},
+		KeepAlivePeriod: keepAlive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("octelium: could not dial the Gateway: %w", err)
+	}
+
+	if err := g.initialize(ctx, conn); err != nil {
+		conn.CloseWithError(0, "")
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (g *quicGateway) initialize(ctx context.Context, conn *quic.Conn) error {
+	accessToken, err := g.tunnel.getToken(ctx)
+	if err != nil {
+		return fmt.Errorf("octelium: could not get an access token: %w", err)
+	}
+
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		return fmt.Errorf("octelium: could not open the init stream: %w", err)
+	}
+	defer stream.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetDeadline(deadline)
+	}
+
+	req, err := encodeQUICMsg(&quicv0.InitRequest{AccessToken: accessToken}, quicMsgTypeInit)
+	if err != nil {
+		return err
+	}
+
+	if _, err := stream.Write(req); err != nil {
+		return fmt.Errorf("octelium: could not send the init request: %w", err)
+	}
+
+	resp := &quicv0.InitResponse{}
+	if err := decodeQUICMsg(stream, quicMsgTypeInit, resp); err != nil {
+		return err
+	}
+
+	if resp.Type != quicv0.InitResponse_OK {
+		return fmt.Errorf("octelium: the Gateway rejected the connection: %s", resp.Type)
+	}
+
+	return nil
+}
+
+// encodeQUICMsg frames msg as the QUICv0 protocol expects, namely a 4-byte
+// payload length and a 4-byte message type followed by the payload.
+func encodeQUICMsg(msg proto.Message, typ uint32) ([]byte, error) {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("octelium: could not encode the message: %w", err)
+	}
+
+	ret := make([]byte, quicMsgHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(ret[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(ret[4:quicMsgHeaderSize], typ)
+	copy(ret[quicMsgHeaderSize:], payload)
+
+	return ret, nil
+}
+
+func decodeQUICMsg(r io.Reader, typ uint32, msg proto.Message) error {
+	var hdr [quicMsgHeaderSize]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return fmt.Errorf("octelium: could not read the message header: %w", err)
+	}
+
+	size := binary.BigEndian.Uint32(hdr[0:4])
+	if binary.BigEndian.Uint32(hdr[4:quicMsgHeaderSize]) != typ {
+		return fmt.Errorf("octelium: unexpected message type")
+	}
+	if size == 0 || size > quicMaxInitRespSize {
+		return fmt.Errorf("octelium: invalid message size %d", size)
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return fmt.Errorf("octelium: could not read the message: %w", err)
+	}
+
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return fmt.Errorf("octelium: could not decode the message: %w", err)
+	}
+
+	return nil
+}
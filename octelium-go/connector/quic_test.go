@@ -0,0 +1,291 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connector
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/apis/main/quicv0"
+	"github.com/octelium/octelium/apis/main/userv1"
+	"github.com/quic-go/quic-go"
+)
+
+func TestQUICMsg(t *testing.T) {
+	req := &quicv0.InitRequest{AccessToken: "token"}
+
+	buf, err := encodeQUICMsg(req, quicMsgTypeInit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := &quicv0.InitRequest{}
+	if err := decodeQUICMsg(bytes.NewReader(buf), quicMsgTypeInit, res); err != nil {
+		t.Fatal(err)
+	}
+	if res.AccessToken != "token" {
+		t.Fatalf("unexpected access token: %q", res.AccessToken)
+	}
+
+	if err := decodeQUICMsg(bytes.NewReader(buf), 2, res); err == nil {
+		t.Fatal("expected an error for an unexpected message type")
+	}
+	if err := decodeQUICMsg(bytes.NewReader(buf[:4]), quicMsgTypeInit, res); err == nil {
+		t.Fatal("expected an error for a truncated message")
+	}
+}
+
+func TestGetPacketDst(t *testing.T) {
+	v4 := make([]byte, quicIPv4HeaderLen)
+	v4[0] = 0x45
+	copy(v4[quicIPv4OffsetDst:], []byte{10, 10, 1, 2})
+	if dst, ok := getPacketDst(v4); !ok || dst != netip.MustParseAddr("10.10.1.2") {
+		t.Fatalf("unexpected IPv4 destination: %v", dst)
+	}
+
+	v6 := make([]byte, quicIPv6HeaderLen)
+	v6[0] = 0x60
+	addr := netip.MustParseAddr("fd00::10").As16()
+	copy(v6[quicIPv6OffsetDst:], addr[:])
+	if dst, ok := getPacketDst(v6); !ok || dst != netip.MustParseAddr("fd00::10") {
+		t.Fatalf("unexpected IPv6 destination: %v", dst)
+	}
+
+	if _, ok := getPacketDst(v4[:10]); ok {
+		t.Fatal("expected no destination for a truncated packet")
+	}
+	if _, ok := getPacketDst([]byte{0x10, 0x00}); ok {
+		t.Fatal("expected no destination for an invalid packet")
+	}
+}
+
+func newTestTLSConfig(t *testing.T) *tls.Config {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		NextProtos:   []string{"h3"},
+	}
+}
+
+// runTestQUICGateway accepts QUICv0 connections and forwards their datagrams
+// to and from the given netstack as a Gateway would do.
+func runTestQUICGateway(t *testing.T, ctx context.Context, lis *quic.Listener, gwTun *netTun) {
+	t.Helper()
+
+	conn, err := lis.Accept(ctx)
+	if err != nil {
+		return
+	}
+
+	stream, err := conn.AcceptStream(ctx)
+	if err != nil {
+		return
+	}
+
+	req := &quicv0.InitRequest{}
+	if err := decodeQUICMsg(stream, quicMsgTypeInit, req); err != nil {
+		return
+	}
+
+	respType := quicv0.InitResponse_OK
+	if req.AccessToken != "access-token" {
+		respType = quicv0.InitResponse_UNKNOWN
+	}
+
+	resp, err := encodeQUICMsg(&quicv0.InitResponse{Type: respType}, quicMsgTypeInit)
+	if err != nil {
+		return
+	}
+	stream.Write(resp)
+	stream.Close()
+
+	go func() {
+		bufs := [][]byte{make([]byte, quicMaxPacketSize)}
+		sizes := make([]int, 1)
+		for {
+			n, err := gwTun.Read(bufs, sizes, 0)
+			if err != nil {
+				return
+			}
+			if n == 0 {
+				continue
+			}
+			if err := conn.SendDatagram(append([]byte(nil), bufs[0][:sizes[0]]...)); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		msg, err := conn.ReceiveDatagram(ctx)
+		if err != nil {
+			return
+		}
+		gwTun.Write([][]byte{msg}, 0)
+	}
+}
+
+func TestQUICTunnel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	lis, err := quic.ListenAddr("127.0.0.1:0", newTestTLSConfig(t), &quic.Config{
+		EnableDatagrams: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	gwTun, err := newNetTun(defaultMTU, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gwTun.Close()
+
+	svcAddr := netip.MustParseAddr("10.10.1.2")
+	if err := gwTun.setAddrs([]netip.Addr{svcAddr}); err != nil {
+		t.Fatal(err)
+	}
+
+	svcLis, err := gwTun.listenTCP(netip.AddrPortFrom(svcAddr, 8080))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svcLis.Close()
+
+	go func() {
+		conn, err := svcLis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}()
+
+	go runTestQUICGateway(t, ctx, lis, gwTun)
+
+	tun, err := newNetTun(defaultMTU, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Connector{
+		cfg: &config{
+			tunnelMode: TunnelModeQUIC,
+			logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+		cancel: func() {},
+		tun:    tun,
+		hasV4:  true,
+		done:   make(chan struct{}),
+	}
+	c.resolver = &net.Resolver{PreferGo: true, Dial: c.dialDNS}
+	c.quic = newQUICTunnel(tun, c.cfg.logger, 0,
+		func(serverName string) *tls.Config {
+			return &tls.Config{ServerName: serverName, InsecureSkipVerify: true}
+		},
+		func(context.Context) (string, error) {
+			return "access-token", nil
+		})
+
+	port := lis.Addr().(*net.UDPAddr).Port
+
+	c.mu.Lock()
+	err = c.setState(&userv1.ConnectionState{
+		Addresses: []*metav1.DualStackNetwork{{V4: "10.10.0.2/32"}},
+		Gateways: []*userv1.Gateway{
+			{
+				Id:       "gw-1",
+				Hostname: "127.0.0.1",
+				CIDRs:    []string{"10.10.1.0/24"},
+				Quicv0:   &userv1.Gateway_QUICV0{Port: int32(port)},
+			},
+		},
+	})
+	c.mu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var conn net.Conn
+	for conn == nil {
+		if ctx.Err() != nil {
+			t.Fatal("could not dial through the QUIC tunnel")
+		}
+
+		dialCtx, dialCancel := context.WithTimeout(ctx, 2*time.Second)
+		conn, _ = c.DialContext(dialCtx, "tcp", "10.10.1.2:8080")
+		dialCancel()
+	}
+
+	if _, err := conn.Write([]byte("octelium")); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len("octelium"))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "octelium" {
+		t.Fatalf("unexpected echo: %q", buf)
+	}
+	conn.Close()
+
+	if err := c.handleResponse(&userv1.ConnectResponse{
+		Event: &userv1.ConnectResponse_DeleteGateway_{
+			DeleteGateway: &userv1.ConnectResponse_DeleteGateway{Id: "gw-1"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.quic.gateways) != 0 || c.quic.getGateway(make([]byte, quicIPv4HeaderLen)) != nil {
+		t.Fatal("Gateway was not deleted")
+	}
+
+	c.Close()
+	if !c.quic.isClosed {
+		t.Fatal("QUIC tunnel was not closed")
+	}
+}
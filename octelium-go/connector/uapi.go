@@ -0,0 +1,101 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connector
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/octelium/octelium/apis/main/userv1"
+)
+
+// toUAPI renders the WireGuard configuration of the Connection state in the
+// wireguard-go UAPI format.
+func toUAPI(state *userv1.ConnectionState, hasV4, hasV6 bool, keepAlive time.Duration) (string, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "private_key=%s\n", hex.EncodeToString(state.X25519Key))
+	b.WriteString("replace_peers=true\n")
+
+	for _, gw := range state.Gateways {
+		if gw == nil || gw.Wireguard == nil || len(gw.Addresses) == 0 {
+			continue
+		}
+
+		pubKey, err := base64.StdEncoding.DecodeString(gw.Wireguard.PublicKey)
+		if err != nil || len(pubKey) != 32 {
+			return "", fmt.Errorf("octelium: invalid public key of Gateway %s", gw.Id)
+		}
+
+		fmt.Fprintf(&b, "public_key=%s\n", hex.EncodeToString(pubKey))
+		fmt.Fprintf(&b, "endpoint=%s\n",
+			net.JoinHostPort(gw.Addresses[0], strconv.Itoa(int(gw.Wireguard.Port))))
+		fmt.Fprintf(&b, "persistent_keepalive_interval=%d\n", int(keepAlive.Seconds()))
+		b.WriteString("replace_allowed_ips=true\n")
+
+		for _, cidr := range gw.CIDRs {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return "", fmt.Errorf("octelium: invalid CIDR %q of Gateway %s", cidr, gw.Id)
+			}
+			if (prefix.Addr().Is4() && hasV4) || (prefix.Addr().Is6() && hasV6) {
+				fmt.Fprintf(&b, "allowed_ip=%s\n", prefix.Masked())
+			}
+		}
+	}
+
+	return b.String(), nil
+}
+
+// getStateAddrs returns the tunnel addresses of the Connection state.
+func getStateAddrs(state *userv1.ConnectionState, hasV4, hasV6 bool) ([]netip.Addr, error) {
+	var ret []netip.Addr
+
+	parse := func(arg string) (netip.Addr, error) {
+		if prefix, err := netip.ParsePrefix(arg); err == nil {
+			return prefix.Addr(), nil
+		}
+		return netip.ParseAddr(arg)
+	}
+
+	for _, addr := range state.Addresses {
+		if hasV4 && addr.GetV4() != "" {
+			ip, err := parse(addr.V4)
+			if err != nil || !ip.Is4() {
+				return nil, fmt.Errorf("octelium: invalid Connection address %q", addr.V4)
+			}
+			ret = append(ret, ip)
+		}
+		if hasV6 && addr.GetV6() != "" {
+			ip, err := parse(addr.V6)
+			if err != nil || !ip.Is6() {
+				return nil, fmt.Errorf("octelium: invalid Connection address %q", addr.V6)
+			}
+			ret = append(ret, ip)
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("octelium: the Connection state has no addresses")
+	}
+
+	return ret, nil
+}
@@ -17,4 +17,11 @@
 //
 // A Client is safe for concurrent use. Applications should normally create one
 // Client per Cluster and share it for the lifetime of the process.
+//
+// HTTP upstreams behind a Service with an identityAssertion config can use an
+// IdentityVerifier to authenticate the requests forwarded by the Cluster.
+//
+// The connector subpackage runs a Connection inside the process so that
+// applications can dial the Cluster's Services without the octelium CLI. The
+// admin subpackage provides a typed client for the Cluster management API.
 package octelium
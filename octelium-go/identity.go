@@ -0,0 +1,477 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package octelium
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultIdentityAssertionHeader is the request header in which the
+	// Cluster sends the identity assertion to HTTP upstreams by default.
+	DefaultIdentityAssertionHeader = "X-Octelium-Assertion"
+
+	defaultIdentityLeeway      = 30 * time.Second
+	defaultJWKSCacheDuration   = 5 * time.Minute
+	defaultJWKSRefreshInterval = 30 * time.Second
+	maxJWKSResponseSize        = 1 << 20
+	maxIdentityAssertionSize   = 16 << 10
+)
+
+var (
+	ErrNoIdentityAssertion      = errors.New("octelium: no identity assertion was provided")
+	ErrInvalidIdentityAssertion = errors.New("octelium: invalid identity assertion")
+)
+
+// Identity is the verified identity of the User behind a request forwarded by
+// the Cluster to an HTTP upstream.
+type Identity struct {
+	Subject   string
+	Audience  string
+	ID        string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+
+	User    IdentityUser
+	Groups  []string
+	Session IdentityObject
+	// Device is nil for clientless Sessions.
+	Device  *IdentityObject
+	Service IdentityService
+}
+
+// IdentityUser is the User of an Identity.
+type IdentityUser struct {
+	UID   string `json:"uid"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	// Type is either HUMAN or WORKLOAD.
+	Type string `json:"type"`
+}
+
+// IdentityObject references a Session or a Device of an Identity.
+type IdentityObject struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+}
+
+// IdentityService is the Service that forwarded the request.
+type IdentityService struct {
+	UID       string `json:"uid"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type identityClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	Audience  string `json:"aud"`
+	IssuedAt  int64  `json:"iat"`
+	NotBefore int64  `json:"nbf"`
+	ExpiresAt int64  `json:"exp"`
+	ID        string `json:"jti"`
+
+	User    *IdentityUser    `json:"user"`
+	Groups  []string         `json:"groups"`
+	Session *IdentityObject  `json:"session"`
+	Device  *IdentityObject  `json:"device"`
+	Service *IdentityService `json:"service"`
+}
+
+type identityVerifierConfig struct {
+	header     string
+	jwksURL    string
+	httpClient *http.Client
+	leeway     time.Duration
+	now        func() time.Time
+}
+
+// IdentityVerifierOption configures an IdentityVerifier.
+type IdentityVerifierOption func(*identityVerifierConfig) error
+
+// WithIdentityHeader sets the request header that carries the assertion. It
+// must match the Service's identityAssertion header.
+func WithIdentityHeader(header string) IdentityVerifierOption {
+	return func(c *identityVerifierConfig) error {
+		if header == "" {
+			return fmt.Errorf("octelium: empty identity assertion header")
+		}
+		c.header = header
+		return nil
+	}
+}
+
+// WithJWKSURL overrides the URL of the Cluster's JWKS, which defaults to
+// https://<domain>/.well-known/jwks.json.
+func WithJWKSURL(u string) IdentityVerifierOption {
+	return func(c *identityVerifierConfig) error {
+		if u == "" {
+			return fmt.Errorf("octelium: empty JWKS URL")
+		}
+		c.jwksURL = u
+		return nil
+	}
+}
+
+// WithJWKSHTTPClient sets the HTTP client used to fetch the JWKS.
+func WithJWKSHTTPClient(client *http.Client) IdentityVerifierOption {
+	return func(c *identityVerifierConfig) error {
+		if client == nil {
+			return fmt.Errorf("octelium: nil HTTP client")
+		}
+		c.httpClient = client
+		return nil
+	}
+}
+
+// WithClockSkew sets the tolerated clock skew when validating the assertion's
+// validity period. It defaults to 30 seconds.
+func WithClockSkew(leeway time.Duration) IdentityVerifierOption {
+	return func(c *identityVerifierConfig) error {
+		if leeway < 0 {
+			return fmt.Errorf("octelium: negative clock skew")
+		}
+		c.leeway = leeway
+		return nil
+	}
+}
+
+// IdentityVerifier verifies the identity assertions that the Cluster attaches
+// to requests forwarded to HTTP upstreams.
+type IdentityVerifier struct {
+	cfg      *identityVerifierConfig
+	issuer   string
+	audience string
+
+	mu          sync.Mutex
+	keys        map[string]any
+	fetchedAt   time.Time
+	lastFetchAt time.Time
+}
+
+// NewIdentityVerifier creates a verifier for the assertions issued by the
+// Cluster of the given domain for the given audience. The audience is the
+// Service's identityAssertion audience, which defaults to the Service name
+// (e.g. svc1.default).
+func NewIdentityVerifier(domain, audience string, opts ...IdentityVerifierOption) (*IdentityVerifier, error) {
+	domain, err := normalizeDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	if audience == "" {
+		return nil, fmt.Errorf("octelium: no identity assertion audience was provided")
+	}
+
+	cfg := &identityVerifierConfig{
+		header:     DefaultIdentityAssertionHeader,
+		jwksURL:    fmt.Sprintf("https://%s/.well-known/jwks.json", domain),
+		httpClient: &http.Client{Timeout: defaultAuthenticationTimeout},
+		leeway:     defaultIdentityLeeway,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return &IdentityVerifier{
+		cfg:      cfg,
+		issuer:   fmt.Sprintf("https://%s", domain),
+		audience: audience,
+		keys:     make(map[string]any),
+	}, nil
+}
+
+// VerifyRequest verifies the identity assertion of an HTTP request.
+func (v *IdentityVerifier) VerifyRequest(r *http.Request) (*Identity, error) {
+	tkn := r.Header.Get(v.cfg.header)
+	if tkn == "" {
+		return nil, ErrNoIdentityAssertion
+	}
+	return v.Verify(r.Context(), tkn)
+}
+
+// Verify verifies an identity assertion and returns its Identity.
+func (v *IdentityVerifier) Verify(ctx context.Context, assertion string) (*Identity, error) {
+	if assertion == "" {
+		return nil, ErrNoIdentityAssertion
+	}
+	if len(assertion) > maxIdentityAssertionSize {
+		return nil, invalidIdentity("assertion is too large")
+	}
+
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		return nil, invalidIdentity("malformed assertion")
+	}
+
+	var hdr struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &hdr); err != nil {
+		return nil, invalidIdentity("malformed header")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalidIdentity("malformed signature")
+	}
+
+	key, err := v.getKey(ctx, hdr.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if !verifyJWS(key, hdr.Alg, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, invalidIdentity("invalid signature")
+	}
+
+	claims := &identityClaims{}
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, invalidIdentity("malformed claims")
+	}
+
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+
+	ret := &Identity{
+		Subject:   claims.Subject,
+		Audience:  claims.Audience,
+		ID:        claims.ID,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+		User:      *claims.User,
+		Groups:    claims.Groups,
+		Session:   *claims.Session,
+		Device:    claims.Device,
+		Service:   *claims.Service,
+	}
+
+	return ret, nil
+}
+
+func (v *IdentityVerifier) validateClaims(claims *identityClaims) error {
+	now := v.cfg.now()
+
+	switch {
+	case claims.Issuer != v.issuer:
+		return invalidIdentity("unexpected issuer")
+	case claims.Audience != v.audience:
+		return invalidIdentity("unexpected audience")
+	case claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(v.cfg.leeway)):
+		return invalidIdentity("assertion is expired")
+	case claims.NotBefore != 0 && now.Add(v.cfg.leeway).Before(time.Unix(claims.NotBefore, 0)):
+		return invalidIdentity("assertion is not valid yet")
+	case claims.User == nil || claims.Session == nil || claims.Service == nil:
+		return invalidIdentity("missing identity claims")
+	case claims.Subject == "" || claims.Subject != claims.User.UID:
+		return invalidIdentity("invalid subject")
+	}
+
+	return nil
+}
+
+func invalidIdentity(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidIdentityAssertion, reason)
+}
+
+func decodeSegment(seg string, out any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+func verifyJWS(key any, alg string, content, sig []byte) bool {
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return alg == "EdDSA" && ed25519.Verify(k, content, sig)
+	case *ecdsa.PublicKey:
+		if alg != "ES256" || len(sig) != 64 {
+			return false
+		}
+		digest := sha256.Sum256(content)
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		return ecdsa.Verify(k, digest[:], r, s)
+	default:
+		return false
+	}
+}
+
+// getKey returns the public key of the given kid. The JWKS is refetched when
+// the cache is stale or when the kid is unknown, e.g. after a key rotation,
+// but no more often than every defaultJWKSRefreshInterval.
+func (v *IdentityVerifier) getKey(ctx context.Context, kid string) (any, error) {
+	if kid == "" {
+		return nil, invalidIdentity("missing kid")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.cfg.now()
+	key, ok := v.keys[kid]
+	if ok && now.Sub(v.fetchedAt) < defaultJWKSCacheDuration {
+		return key, nil
+	}
+
+	if now.Sub(v.lastFetchAt) >= defaultJWKSRefreshInterval {
+		v.lastFetchAt = now
+		keys, err := v.fetchJWKS(ctx)
+		if err != nil {
+			if ok {
+				return key, nil
+			}
+			return nil, err
+		}
+		v.keys = keys
+		v.fetchedAt = now
+		key, ok = v.keys[kid]
+	}
+
+	if !ok {
+		return nil, invalidIdentity("unknown kid")
+	}
+
+	return key, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	Kid string `json:"kid"`
+}
+
+func (v *IdentityVerifier) fetchJWKS(ctx context.Context) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.jwksURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	resp, err := v.cfg.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("octelium: could not fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("octelium: could not fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var jwks struct {
+		Keys []*jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSResponseSize)).Decode(&jwks); err != nil {
+		return nil, fmt.Errorf("octelium: could not parse JWKS: %w", err)
+	}
+
+	ret := make(map[string]any)
+	for _, k := range jwks.Keys {
+		if k == nil || k.Kid == "" {
+			continue
+		}
+		if key, err := parseJWK(k); err == nil {
+			ret[k.Kid] = key
+		}
+	}
+
+	return ret, nil
+}
+
+func parseJWK(k *jwk) (any, error) {
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case k.Kty == "OKP" && k.Crv == "Ed25519":
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("octelium: invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	case k.Kty == "EC" && k.Crv == "P-256":
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("octelium: invalid P-256 key")
+		}
+		// Round-trip through the uncompressed point encoding so that points
+		// that are not on the curve are rejected.
+		point := append(append([]byte{0x04}, x...), y...)
+		pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+		if err != nil {
+			return nil, err
+		}
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("octelium: unsupported JWK type %s/%s", k.Kty, k.Crv)
+	}
+}
+
+type identityCtxKey struct{}
+
+// ContextWithIdentity returns a copy of ctx carrying the Identity.
+func ContextWithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityCtxKey{}, identity)
+}
+
+// IdentityFromContext returns the Identity set by the IdentityVerifier
+// middleware.
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	ret, ok := ctx.Value(identityCtxKey{}).(*Identity)
+	return ret, ok && ret != nil
+}
+
+// Middleware rejects requests without a valid identity assertion with 401 and
+// passes the verified Identity to next in the request context.
+func (v *IdentityVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := v.VerifyRequest(r)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ContextWithIdentity(r.Context(), identity)))
+	})
+}
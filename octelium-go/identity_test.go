@@ -0,0 +1,271 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package octelium
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testAssertionIssuer struct {
+	kid  string
+	priv any
+}
+
+func (i *testAssertionIssuer) jwk() map[string]string {
+	switch k := i.priv.(type) {
+	case ed25519.PrivateKey:
+		return map[string]string{
+			"kty": "OKP", "crv": "Ed25519", "kid": i.kid,
+			"x": base64.RawURLEncoding.EncodeToString(k.Public().(ed25519.PublicKey)),
+		}
+	case *ecdsa.PrivateKey:
+		x, y := make([]byte, 32), make([]byte, 32)
+		k.X.FillBytes(x)
+		k.Y.FillBytes(y)
+		return map[string]string{
+			"kty": "EC", "crv": "P-256", "kid": i.kid,
+			"x": base64.RawURLEncoding.EncodeToString(x),
+			"y": base64.RawURLEncoding.EncodeToString(y),
+		}
+	}
+	return nil
+}
+
+func (i *testAssertionIssuer) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+
+	alg := "EdDSA"
+	if _, ok := i.priv.(*ecdsa.PrivateKey); ok {
+		alg = "ES256"
+	}
+
+	hdr, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT", "kid": i.kid})
+	body, _ := json.Marshal(claims)
+	input := base64.RawURLEncoding.EncodeToString(hdr) + "." + base64.RawURLEncoding.EncodeToString(body)
+
+	var sig []byte
+	switch k := i.priv.(type) {
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, []byte(input))
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256([]byte(input))
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func testIdentityClaims(now time.Time) map[string]any {
+	return map[string]any{
+		"iss": "https://example.com",
+		"sub": "usr-uid",
+		"aud": "app.default",
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": now.Add(time.Minute).Unix(),
+		"jti": "id",
+		"user": map[string]any{
+			"uid": "usr-uid", "name": "john", "type": "HUMAN",
+		},
+		"groups":  []string{"dev"},
+		"session": map[string]any{"uid": "sess-uid", "name": "john-abcd"},
+		"service": map[string]any{"uid": "svc-uid", "name": "app.default", "namespace": "default"},
+	}
+}
+
+func newTestJWKSServer(t *testing.T, issuers ...*testAssertionIssuer) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		var keys []map[string]string
+		for _, i := range issuers {
+			keys = append(keys, i.jwk())
+		}
+		json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &hits
+}
+
+func TestIdentityVerifier(t *testing.T) {
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edIssuer := &testAssertionIssuer{kid: "k1", priv: edPriv}
+	ecIssuer := &testAssertionIssuer{kid: "k2", priv: ecPriv}
+
+	srv, hits := newTestJWKSServer(t, edIssuer, ecIssuer)
+
+	v, err := NewIdentityVerifier("example.com", "app.default", WithJWKSURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, issuer := range []*testAssertionIssuer{edIssuer, ecIssuer} {
+		identity, err := v.Verify(ctx, issuer.sign(t, testIdentityClaims(now)))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", issuer.kid, err)
+		}
+		if identity.User.Name != "john" || identity.Session.UID != "sess-uid" ||
+			identity.Service.Namespace != "default" || len(identity.Groups) != 1 || identity.Device != nil {
+			t.Fatalf("unexpected identity: %+v", identity)
+		}
+	}
+
+	if hits.Load() != 1 {
+		t.Fatalf("expected the JWKS to be fetched once, got %d", hits.Load())
+	}
+
+	invalid := map[string]func(map[string]any){
+		"issuer":   func(c map[string]any) { c["iss"] = "https://other.com" },
+		"audience": func(c map[string]any) { c["aud"] = "other.default" },
+		"expired":  func(c map[string]any) { c["exp"] = now.Add(-time.Hour).Unix() },
+		"nbf":      func(c map[string]any) { c["nbf"] = now.Add(time.Hour).Unix() },
+		"subject":  func(c map[string]any) { c["sub"] = "other" },
+		"session":  func(c map[string]any) { delete(c, "session") },
+	}
+	for name, fn := range invalid {
+		claims := testIdentityClaims(now)
+		fn(claims)
+		if _, err := v.Verify(ctx, edIssuer.sign(t, claims)); !errors.Is(err, ErrInvalidIdentityAssertion) {
+			t.Fatalf("%s: expected ErrInvalidIdentityAssertion, got %v", name, err)
+		}
+	}
+
+	{
+		_, otherPriv, _ := ed25519.GenerateKey(rand.Reader)
+		forged := (&testAssertionIssuer{kid: "k1", priv: otherPriv}).sign(t, testIdentityClaims(now))
+		if _, err := v.Verify(ctx, forged); !errors.Is(err, ErrInvalidIdentityAssertion) {
+			t.Fatalf("expected forged assertion to be rejected, got %v", err)
+		}
+	}
+
+	{
+		// An ES256 key must not verify an EdDSA-labelled assertion.
+		tkn := (&testAssertionIssuer{kid: "k2", priv: edPriv}).sign(t, testIdentityClaims(now))
+		if _, err := v.Verify(ctx, tkn); !errors.Is(err, ErrInvalidIdentityAssertion) {
+			t.Fatalf("expected alg mismatch to be rejected, got %v", err)
+		}
+	}
+
+	{
+		before := hits.Load()
+		unknown := (&testAssertionIssuer{kid: "k3", priv: edPriv}).sign(t, testIdentityClaims(now))
+		for range 3 {
+			if _, err := v.Verify(ctx, unknown); !errors.Is(err, ErrInvalidIdentityAssertion) {
+				t.Fatalf("expected unknown kid to be rejected, got %v", err)
+			}
+		}
+		if hits.Load()-before > 1 {
+			t.Fatalf("expected unknown kids to be rate limited, got %d fetches", hits.Load()-before)
+		}
+	}
+
+	for _, tkn := range []string{"a", "a.b", "a.b.c"} {
+		if _, err := v.Verify(ctx, tkn); !errors.Is(err, ErrInvalidIdentityAssertion) {
+			t.Fatalf("%q: expected ErrInvalidIdentityAssertion, got %v", tkn, err)
+		}
+	}
+
+	if _, err := v.Verify(ctx, ""); !errors.Is(err, ErrNoIdentityAssertion) {
+		t.Fatalf("expected ErrNoIdentityAssertion, got %v", err)
+	}
+}
+
+func TestIdentityVerifierMiddleware(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := &testAssertionIssuer{kid: "k1", priv: priv}
+	srv, _ := newTestJWKSServer(t, issuer)
+
+	v, err := NewIdentityVerifier("example.com", "app.default",
+		WithJWKSURL(srv.URL), WithIdentityHeader("X-Identity"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := IdentityFromContext(r.Context())
+		if !ok {
+			t.Fatal("expected an Identity in the request context")
+		}
+		w.Write([]byte(identity.User.Name))
+	}))
+
+	{
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Identity", issuer.sign(t, testIdentityClaims(time.Now())))
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		if rw.Code != http.StatusOK || rw.Body.String() != "john" {
+			t.Fatalf("unexpected response: %d %s", rw.Code, rw.Body.String())
+		}
+	}
+
+	{
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(DefaultIdentityAssertionHeader, issuer.sign(t, testIdentityClaims(time.Now())))
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		if rw.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", rw.Code)
+		}
+	}
+}
+
+func TestNewIdentityVerifierValidation(t *testing.T) {
+	if _, err := NewIdentityVerifier("example.com", ""); err == nil {
+		t.Fatal("expected an error for an empty audience")
+	}
+	if _, err := NewIdentityVerifier("https://example.com", "app.default"); err == nil {
+		t.Fatal("expected an error for an invalid domain")
+	}
+	if _, err := NewIdentityVerifier("example.com", "app.default", WithClockSkew(-time.Second)); err == nil {
+		t.Fatal("expected an error for a negative clock skew")
+	}
+}
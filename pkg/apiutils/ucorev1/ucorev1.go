@@ -49,6 +49,7 @@ const (
 	KindAccessLog        = "AccessLog"
 	KindComponentLog     = "ComponentLog"
 	KindPolicyTrigger    = "PolicyTrigger"
+	KindAccessRequest    = "AccessRequest"
 )
 
 func NewObject(kind string) (umetav1.ResourceObjectI, error) {
@@ -87,6 +88,8 @@ func NewObject(kind string) (umetav1.ResourceObjectI, error) {
 		return &corev1.PolicyTrigger{}, nil
 	case KindAuthenticator:
 		return &corev1.Authenticator{}, nil
+	case KindAccessRequest:
+		return &corev1.AccessRequest{}, nil
 	default:
 		return nil, errors.Errorf("Invalid kind: %s", kind)
 	}
@@ -122,6 +125,8 @@ func NewObjectListOptions(kind string) (proto.Message, error) {
 		return &corev1.ListGatewayOptions{}, nil
 	case KindAuthenticator:
 		return &corev1.ListAuthenticatorOptions{}, nil
+	case KindAccessRequest:
+		return &corev1.ListAccessRequestOptions{}, nil
 	default:
 		return nil, errors.Errorf("Invalid kind: %s", kind)
 	}
@@ -162,6 +167,8 @@ func NewObjectList(kind string) (umetav1.ObjectI, error) {
 
 	case KindAuthenticator:
 		return &corev1.AuthenticatorList{}, nil
+	case KindAccessRequest:
+		return &corev1.AccessRequestList{}, nil
 	default:
 		return nil, errors.Errorf("Invalid kind: %s", kind)
 	}
@@ -172,7 +179,8 @@ type ResourceObjectRefG interface {
 		*corev1.User | *corev1.Group | *corev1.Namespace | *corev1.Config | *corev1.Policy |
 		*corev1.IdentityProvider |
 		*corev1.Region | *corev1.Gateway |
-		*corev1.ClusterConfig | *corev1.PolicyTrigger | *corev1.Credential | *corev1.Authenticator
+		*corev1.ClusterConfig | *corev1.PolicyTrigger | *corev1.Credential | *corev1.Authenticator |
+		*corev1.AccessRequest
 }
 
 type Session struct {
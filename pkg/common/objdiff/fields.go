@@ -0,0 +1,169 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objdiff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/octelium/octelium/pkg/common/pbutils"
+	"google.golang.org/protobuf/proto"
+)
+
+// SensitiveValue replaces the values of data fields in FieldChanges.
+const SensitiveValue = "(sensitive)"
+
+// FieldChange is a change of a single field. Paths are dot-separated JSON
+// field names, with list indexes in brackets, e.g. "spec.config.upstream.url"
+// or "spec.rules[0].effect". Old is nil for added fields and New is nil for
+// removed ones.
+type FieldChange struct {
+	Path string `json:"path"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// GetFieldChanges returns the field-level changes of the metadata and the spec
+// compared by IsEqual between current and desired. current may be nil for a
+// Resource that is to be created. The values of data fields are never
+// included since the Cluster does not return them.
+func GetFieldChanges(api string, current, desired umetav1.ResourceObjectI) ([]*FieldChange, error) {
+	toMap := func(itm umetav1.ResourceObjectI) (map[string]any, error) {
+		ret := make(map[string]any)
+		if itm == nil {
+			return ret, nil
+		}
+
+		md, err := toMapOrNil(getCmpMetadata(api, itm))
+		if err != nil {
+			return nil, err
+		}
+		if md != nil {
+			ret["metadata"] = md
+		}
+
+		spec, err := toMapOrNil(GetFieldSpec(itm))
+		if err != nil {
+			return nil, err
+		}
+		if spec != nil {
+			ret["spec"] = spec
+		}
+
+		return ret, nil
+	}
+
+	from, err := toMap(current)
+	if err != nil {
+		return nil, err
+	}
+	to, err := toMap(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*FieldChange
+	diffValues("", from, to, &ret)
+
+	if HasFieldData(desired) {
+		ret = append(ret, &FieldChange{
+			Path: "data",
+			New:  SensitiveValue,
+		})
+	}
+
+	return ret, nil
+}
+
+func toMapOrNil(msg proto.Message) (map[string]any, error) {
+	if msg == nil || reflect.ValueOf(msg).IsNil() {
+		return nil, nil
+	}
+
+	ret, err := pbutils.ConvertToMap(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret, nil
+}
+
+func diffValues(path string, from, to any, ret *[]*FieldChange) {
+	fromMap, fromIsMap := from.(map[string]any)
+	toMap, toIsMap := to.(map[string]any)
+	// Expand added and removed objects into their leaf fields.
+	fromIsMap = fromIsMap || (from == nil && toIsMap)
+	toIsMap = toIsMap || (to == nil && fromIsMap)
+	if fromIsMap && toIsMap {
+		keys := make(map[string]struct{})
+		for k := range fromMap {
+			keys[k] = struct{}{}
+		}
+		for k := range toMap {
+			keys[k] = struct{}{}
+		}
+
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			diffValues(joinPath(path, k), fromMap[k], toMap[k], ret)
+		}
+		return
+	}
+
+	fromList, fromIsList := from.([]any)
+	toList, toIsList := to.([]any)
+	fromIsList = fromIsList || (from == nil && toIsList)
+	toIsList = toIsList || (to == nil && fromIsList)
+	if fromIsList && toIsList {
+		for i := 0; i < max(len(fromList), len(toList)); i++ {
+			var f, t any
+			if i < len(fromList) {
+				f = fromList[i]
+			}
+			if i < len(toList) {
+				t = toList[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), f, t, ret)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+
+	*ret = append(*ret, &FieldChange{
+		Path: path,
+		Old:  from,
+		New:  to,
+	})
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
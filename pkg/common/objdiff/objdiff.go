@@ -0,0 +1,139 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package objdiff computes the changes required to move a set of current
+// resources to a set of desired resources. It is shared by octeliumctl apply
+// and the octelium-go management client.
+package objdiff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type UpdateItem struct {
+	Current umetav1.ResourceObjectI
+	Desired umetav1.ResourceObjectI
+}
+
+type Diff struct {
+	Create []umetav1.ResourceObjectI
+	Update []*UpdateItem
+	Delete []umetav1.ResourceObjectI
+}
+
+func (d *Diff) IsEmpty() bool {
+	return len(d.Create) == 0 && len(d.Update) == 0 && len(d.Delete) == 0
+}
+
+// Compute returns the resources of desired that do not exist in current, the
+// ones that exist but differ and the ones of current that do not exist in
+// desired. Resources are matched by their full name. Resources that carry a
+// data field, such as Secrets, are always considered changed since their
+// data is never returned by the Cluster.
+func Compute(api string, desired, current []umetav1.ResourceObjectI) *Diff {
+	ret := &Diff{}
+
+	for _, itm := range desired {
+		cur := getInList(api, current, itm)
+		switch {
+		case cur == nil:
+			ret.Create = append(ret.Create, itm)
+		case HasFieldData(itm) || !IsEqual(api, itm, cur):
+			ret.Update = append(ret.Update, &UpdateItem{
+				Current: cur,
+				Desired: itm,
+			})
+		}
+	}
+
+	for _, itm := range current {
+		if getInList(api, desired, itm) == nil {
+			ret.Delete = append(ret.Delete, itm)
+		}
+	}
+
+	return ret
+}
+
+// IsEqual compares the user-settable metadata and the spec of two resources.
+func IsEqual(api string, a, b umetav1.ResourceObjectI) bool {
+	return proto.Equal(getCmpMetadata(api, a), getCmpMetadata(api, b)) &&
+		proto.Equal(GetFieldSpec(a), GetFieldSpec(b))
+}
+
+func getCmpMetadata(api string, itm umetav1.ResourceObjectI) *metav1.Metadata {
+	md := itm.GetMetadata()
+	return &metav1.Metadata{
+		Name:        GetFullName(api, itm),
+		DisplayName: md.GetDisplayName(),
+		Labels:      md.GetLabels(),
+		Description: md.GetDescription(),
+		Annotations: md.GetAnnotations(),
+		PicURL:      md.GetPicURL(),
+	}
+}
+
+// GetFullName returns the name of the resource as stored by the Cluster. For
+// example, a Service name without a Namespace belongs to the default
+// Namespace.
+func GetFullName(api string, itm umetav1.ResourceObjectI) string {
+	name := itm.GetMetadata().GetName()
+	switch api {
+	case ucorev1.API:
+		switch itm.GetKind() {
+		case ucorev1.KindService:
+			if len(strings.Split(name, ".")) == 1 {
+				return fmt.Sprintf("%s.default", name)
+			}
+		}
+	}
+	return name
+}
+
+// GetFieldSpec returns the spec field of the resource or nil if it has none.
+func GetFieldSpec(item umetav1.ResourceObjectI) proto.Message {
+	fd := item.ProtoReflect().Descriptor().Fields().ByName("spec")
+	if fd == nil || fd.Kind() != protoreflect.MessageKind {
+		return nil
+	}
+
+	if !item.ProtoReflect().Has(fd) {
+		return nil
+	}
+
+	return item.ProtoReflect().Get(fd).Message().Interface()
+}
+
+// HasFieldData reports whether the resource has a set data field.
+func HasFieldData(item umetav1.ResourceObjectI) bool {
+	fd := item.ProtoReflect().Descriptor().Fields().ByName("data")
+	return fd != nil && item.ProtoReflect().Has(fd)
+}
+
+func getInList(api string, lst []umetav1.ResourceObjectI, cur umetav1.ResourceObjectI) umetav1.ResourceObjectI {
+	name := GetFullName(api, cur)
+	for _, itm := range lst {
+		if GetFullName(api, itm) == name {
+			return itm
+		}
+	}
+	return nil
+}
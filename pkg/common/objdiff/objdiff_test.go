@@ -0,0 +1,163 @@
+// Copyright Octelium Labs, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objdiff
+
+import (
+	"testing"
+
+	"github.com/octelium/octelium/apis/main/corev1"
+	"github.com/octelium/octelium/apis/main/metav1"
+	"github.com/octelium/octelium/pkg/apiutils/ucorev1"
+	"github.com/octelium/octelium/pkg/apiutils/umetav1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompute(t *testing.T) {
+	current := []umetav1.ResourceObjectI{
+		&corev1.User{
+			Metadata: &metav1.Metadata{Name: "usr1", Uid: "uid-1", ResourceVersion: "v1"},
+			Spec:     &corev1.User_Spec{Type: corev1.User_Spec_HUMAN},
+		},
+		&corev1.Service{
+			Kind:     ucorev1.KindService,
+			Metadata: &metav1.Metadata{Name: "svc1.default"},
+			Spec:     &corev1.Service_Spec{Port: 8080},
+		},
+		&corev1.Service{
+			Kind:     ucorev1.KindService,
+			Metadata: &metav1.Metadata{Name: "svc2.default"},
+			Spec:     &corev1.Service_Spec{Port: 8080},
+		},
+		&corev1.Secret{
+			Metadata: &metav1.Metadata{Name: "sec1"},
+			Spec:     &corev1.Secret_Spec{},
+		},
+		&corev1.Namespace{
+			Metadata: &metav1.Metadata{Name: "ns1"},
+		},
+	}
+
+	desired := []umetav1.ResourceObjectI{
+		&corev1.User{
+			Metadata: &metav1.Metadata{Name: "usr1"},
+			Spec:     &corev1.User_Spec{Type: corev1.User_Spec_HUMAN},
+		},
+		&corev1.User{
+			Metadata: &metav1.Metadata{Name: "usr2"},
+			Spec:     &corev1.User_Spec{Type: corev1.User_Spec_HUMAN},
+		},
+		&corev1.Service{
+			Kind:     ucorev1.KindService,
+			Metadata: &metav1.Metadata{Name: "svc1"},
+			Spec:     &corev1.Service_Spec{Port: 8080},
+		},
+		&corev1.Service{
+			Kind:     ucorev1.KindService,
+			Metadata: &metav1.Metadata{Name: "svc2"},
+			Spec:     &corev1.Service_Spec{Port: 8081},
+		},
+		&corev1.Secret{
+			Metadata: &metav1.Metadata{Name: "sec1"},
+			Spec:     &corev1.Secret_Spec{},
+			Data: &corev1.Secret_Data{
+				Type: &corev1.Secret_Data_Value{Value: "value"},
+			},
+		},
+	}
+
+	diff := Compute(ucorev1.API, desired, current)
+	assert.False(t, diff.IsEmpty())
+
+	assert.Len(t, diff.Create, 1)
+	assert.Equal(t, "usr2", diff.Create[0].GetMetadata().Name)
+
+	assert.Len(t, diff.Update, 2)
+	assert.Equal(t, "svc2", diff.Update[0].Desired.GetMetadata().Name)
+	assert.Equal(t, "svc2.default", diff.Update[0].Current.GetMetadata().Name)
+	assert.Equal(t, "sec1", diff.Update[1].Desired.GetMetadata().Name)
+
+	assert.Len(t, diff.Delete, 1)
+	assert.Equal(t, "ns1", diff.Delete[0].GetMetadata().Name)
+
+	assert.True(t, Compute(ucorev1.API, current, current).IsEmpty())
+}
+
+func TestGetFieldChanges(t *testing.T) {
+	current := &corev1.Service{
+		Kind: ucorev1.KindService,
+		Metadata: &metav1.Metadata{
+			Name:            "svc1.default",
+			Uid:             "uid-1",
+			ResourceVersion: "v1",
+			Labels:          map[string]string{"env": "prod"},
+		},
+		Spec: &corev1.Service_Spec{
+			Port: 8080,
+			Config: &corev1.Service_Spec_Config{
+				Upstream: &corev1.Service_Spec_Config_Upstream{
+					Type: &corev1.Service_Spec_Config_Upstream_Url{Url: "https://a.example.com"},
+				},
+			},
+		},
+	}
+
+	desired := &corev1.Service{
+		Kind: ucorev1.KindService,
+		Metadata: &metav1.Metadata{
+			Name:        "svc1",
+			Description: "My Service",
+			Labels:      map[string]string{"env": "prod"},
+		},
+		Spec: &corev1.Service_Spec{
+			Port:     8081,
+			IsPublic: true,
+			Config: &corev1.Service_Spec_Config{
+				Upstream: &corev1.Service_Spec_Config_Upstream{
+					Type: &corev1.Service_Spec_Config_Upstream_Url{Url: "https://a.example.com"},
+				},
+			},
+		},
+	}
+
+	changes, err := GetFieldChanges(ucorev1.API, current, desired)
+	assert.Nil(t, err)
+
+	paths := map[string]*FieldChange{}
+	for _, change := range changes {
+		paths[change.Path] = change
+	}
+
+	assert.Len(t, changes, 3)
+	assert.Nil(t, paths["metadata.description"].Old)
+	assert.Equal(t, "My Service", paths["metadata.description"].New)
+	assert.EqualValues(t, 8080, paths["spec.port"].Old)
+	assert.EqualValues(t, 8081, paths["spec.port"].New)
+	assert.Equal(t, true, paths["spec.isPublic"].New)
+
+	changes, err = GetFieldChanges(ucorev1.API, nil, &corev1.Secret{
+		Metadata: &metav1.Metadata{Name: "sec1"},
+		Spec:     &corev1.Secret_Spec{},
+		Data: &corev1.Secret_Data{
+			Type: &corev1.Secret_Data_Value{Value: "secret-value"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "metadata.name", changes[0].Path)
+	assert.Equal(t, "data", changes[len(changes)-1].Path)
+	assert.Equal(t, SensitiveValue, changes[len(changes)-1].New)
+	for _, change := range changes {
+		assert.NotEqual(t, "secret-value", change.New)
+	}
+}